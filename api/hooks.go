@@ -87,12 +87,37 @@ func (h *ApplyHook) PostStateUpdate(newState *terraform.State) (terraform.HookAc
 	return terraform.HookActionContinue, nil
 }
 
-// PlanHook is used during a plan request
+// PlanHook is used during a plan request. If a stream is set, the progress
+// of each resource is tracked in the PlanResponse and sent to the calling
+// gRPC client
 type PlanHook struct {
 	terraform.NilHook
 	sync.Mutex
 
-	resp *tfpb.PlanResponse
+	stream tfpb.Terraform_PlanDestroyServer
+	resp   *tfpb.PlanResponse
+}
+
+// PreDiff is called before a single resource is diffed, it adds the new
+// state to the PlanResponse and sends it to the calling gRPC client
+func (h *PlanHook) PreDiff(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.stream == nil {
+		return terraform.HookActionContinue, nil
+	}
+
+	h.resp.States[n.HumanId()] = tfpb.ResourceState_StateRunning
+
+	// Write the new state over the connected gRPC stream
+	if err := h.stream.Send(h.resp); err != nil {
+		return terraform.HookActionHalt, err
+	}
+
+	return terraform.HookActionContinue, nil
 }
 
 // PostDiff is triggered after each individual resource is diffed, and adds
@@ -116,5 +141,61 @@ func (h *PlanHook) PostDiff(
 		h.resp.Actions[n.HumanId()] = tfpb.ResourceAction_ActionNone
 	}
 
+	if h.stream == nil {
+		return terraform.HookActionContinue, nil
+	}
+
+	h.resp.States[n.HumanId()] = tfpb.ResourceState_StateSuccess
+
+	// Write the new state over the connected gRPC stream
+	if err := h.stream.Send(h.resp); err != nil {
+		return terraform.HookActionHalt, err
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+// RefreshHook is used during a refresh only request
+type RefreshHook struct {
+	terraform.NilHook
+	sync.Mutex
+
+	stream tfpb.Terraform_RefreshOnlyServer
+	resp   *tfpb.RefreshResponse
+}
+
+// PreRefresh is called before a single resource is refreshed, it adds the
+// new state to the RefreshResponse and sends it to the calling gRPC client
+func (h *RefreshHook) PreRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.resp.States[n.HumanId()] = tfpb.ResourceState_StateRunning
+
+	// Write the new state over the connected gRPC stream
+	if err := h.stream.Send(h.resp); err != nil {
+		return terraform.HookActionHalt, err
+	}
+
+	return terraform.HookActionContinue, nil
+}
+
+// PostRefresh is called after a single resource is refreshed, it adds the
+// new state to the RefreshResponse and sends it to the calling gRPC client
+func (h *RefreshHook) PostRefresh(
+	n *terraform.InstanceInfo,
+	s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.resp.States[n.HumanId()] = tfpb.ResourceState_StateSuccess
+
+	// Write the new state over the connected gRPC stream
+	if err := h.stream.Send(h.resp); err != nil {
+		return terraform.HookActionHalt, err
+	}
+
 	return terraform.HookActionContinue, nil
 }
@@ -53,7 +53,7 @@ func (s *Server) Plan(c context.Context, req *tfpb.PlanRequest) (*tfpb.PlanRespo
 	}
 	resp.Plan = b.Bytes()
 
-	resp.Diff, err = json.Marshal(plan.Diff)
+	resp.Diff, err = json.Marshal(redactDiff(plan.Diff))
 	if err != nil {
 		return nil, fmt.Errorf("Error marshalling diff: %v", err)
 	}
@@ -69,3 +69,107 @@ func (s *Server) Plan(c context.Context, req *tfpb.PlanRequest) (*tfpb.PlanRespo
 
 	return resp, nil
 }
+
+// PlanDestroy implements the TerraformServer interface
+func (s *Server) PlanDestroy(req *tfpb.PlanDestroyRequest, stream tfpb.Terraform_PlanDestroyServer) error {
+	resp := &tfpb.PlanResponse{
+		Actions: make(map[string]tfpb.ResourceAction),
+		States:  make(map[string]tfpb.ResourceState),
+	}
+
+	oldState, err := terraform.ReadState(bytes.NewReader(req.State))
+	if err != nil {
+		return fmt.Errorf("Error reading state: %v", err)
+	}
+
+	hooks := []terraform.Hook{&PlanHook{
+		stream: stream,
+		resp:   resp,
+	}}
+
+	ctx, err := s.newContext(req.Config, true, nil, req.State, req.Parallelism, hooks)
+	if err != nil {
+		return err
+	}
+
+	if err := validateContext(ctx); err != nil {
+		return fmt.Errorf("Error validating context: %v", err)
+	}
+
+	if req.Refresh {
+		_, err := ctx.Refresh()
+		if err != nil {
+			return fmt.Errorf("Error refreshing state: %v", err)
+		}
+	}
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		return fmt.Errorf("Error running plan: %v", err)
+	}
+
+	var b bytes.Buffer
+	err = terraform.WritePlan(plan, &b)
+	if err != nil {
+		return fmt.Errorf("Error writing plan: %v", err)
+	}
+	resp.Plan = b.Bytes()
+
+	resp.Diff, err = json.Marshal(redactDiff(plan.Diff))
+	if err != nil {
+		return fmt.Errorf("Error marshalling diff: %v", err)
+	}
+
+	// Check if we need to update the state serial
+	plan.State.IncrementSerialMaybe(oldState)
+	resp.Serial = plan.State.Serial
+
+	resp.State, err = json.Marshal(plan.State)
+	if err != nil {
+		return fmt.Errorf("Error marshalling refreshed state: %v", err)
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return fmt.Errorf("Error sending final plan: %v", err)
+	}
+
+	return nil
+}
+
+// redactDiff returns a copy of the given diff in which the values of all
+// sensitive attributes are redacted, so they are not sent to the client.
+func redactDiff(d *terraform.Diff) *terraform.Diff {
+	if d == nil {
+		return nil
+	}
+
+	result := &terraform.Diff{
+		Modules: make([]*terraform.ModuleDiff, len(d.Modules)),
+	}
+	for i, md := range d.Modules {
+		rmd := &terraform.ModuleDiff{
+			Path:      md.Path,
+			Resources: make(map[string]*terraform.InstanceDiff, len(md.Resources)),
+			Destroy:   md.Destroy,
+		}
+		for name, id := range md.Resources {
+			if id == nil {
+				rmd.Resources[name] = nil
+				continue
+			}
+
+			rid := &terraform.InstanceDiff{
+				Attributes:     make(map[string]*terraform.ResourceAttrDiff, len(id.Attributes)),
+				Destroy:        id.Destroy,
+				DestroyTainted: id.DestroyTainted,
+			}
+			for k, ad := range id.Attributes {
+				rid.Attributes[k] = ad.Redacted()
+			}
+			rmd.Resources[name] = rid
+		}
+		result.Modules[i] = rmd
+	}
+
+	return result
+}
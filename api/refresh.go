@@ -44,3 +44,50 @@ func (s *Server) Refresh(c context.Context, req *tfpb.RefreshRequest) (*tfpb.Ref
 
 	return resp, nil
 }
+
+// RefreshOnly implements the TerraformServer interface
+func (s *Server) RefreshOnly(req *tfpb.RefreshRequest, stream tfpb.Terraform_RefreshOnlyServer) error {
+	resp := &tfpb.RefreshResponse{
+		States: make(map[string]tfpb.ResourceState),
+	}
+
+	oldState, err := terraform.ReadState(bytes.NewReader(req.State))
+	if err != nil {
+		return fmt.Errorf("Error reading state: %v", err)
+	}
+
+	hooks := []terraform.Hook{&RefreshHook{
+		stream: stream,
+		resp:   resp,
+	}}
+
+	ctx, err := s.newContext(req.Config, false, nil, req.State, req.Parallelism, hooks)
+	if err != nil {
+		return err
+	}
+
+	if err := validateContext(ctx); err != nil {
+		return fmt.Errorf("Error validating context: %v", err)
+	}
+
+	newState, err := ctx.Refresh()
+	parseErrors(&resp.Errors, err)
+
+	// Make sure we send the refreshed state, or the errors, back to the client
+	if newState != nil {
+		// Check if we need to update the state serial
+		newState.IncrementSerialMaybe(oldState)
+		resp.Serial = newState.Serial
+
+		resp.State, err = json.Marshal(newState)
+		if err != nil {
+			return fmt.Errorf("Error marshalling refreshed state: %v", err)
+		}
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return fmt.Errorf("Error sending final state: %v", err)
+	}
+
+	return nil
+}
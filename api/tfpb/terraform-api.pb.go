@@ -13,6 +13,7 @@ It has these top-level messages:
 	ApplyResponse
 	PlanRequest
 	PlanResponse
+	PlanDestroyRequest
 	RefreshRequest
 	RefreshResponse
 	StateRequest
@@ -165,6 +166,7 @@ type PlanResponse struct {
 	Serial   int64                     `protobuf:"varint,4,opt,name=serial" json:"serial,omitempty"`
 	State    []byte                    `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
 	Warnings []string                  `protobuf:"bytes,6,rep,name=warnings" json:"warnings,omitempty"`
+	States   map[string]ResourceState  `protobuf:"bytes,7,rep,name=states" json:"states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=tfpb.ResourceState"`
 }
 
 func (m *PlanResponse) Reset()                    { *m = PlanResponse{} }
@@ -179,6 +181,26 @@ func (m *PlanResponse) GetActions() map[string]ResourceAction {
 	return nil
 }
 
+func (m *PlanResponse) GetStates() map[string]ResourceState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+// PlanDestroyRequest represents a destroy plan request
+type PlanDestroyRequest struct {
+	Config      []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Refresh     bool   `protobuf:"varint,2,opt,name=refresh" json:"refresh,omitempty"`
+	State       []byte `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Parallelism int32  `protobuf:"varint,4,opt,name=parallelism" json:"parallelism,omitempty"`
+}
+
+func (m *PlanDestroyRequest) Reset()                    { *m = PlanDestroyRequest{} }
+func (m *PlanDestroyRequest) String() string            { return proto.CompactTextString(m) }
+func (*PlanDestroyRequest) ProtoMessage()               {}
+func (*PlanDestroyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
 // RefreshRequest represents a refresh request
 type RefreshRequest struct {
 	Config      []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
@@ -189,18 +211,27 @@ type RefreshRequest struct {
 func (m *RefreshRequest) Reset()                    { *m = RefreshRequest{} }
 func (m *RefreshRequest) String() string            { return proto.CompactTextString(m) }
 func (*RefreshRequest) ProtoMessage()               {}
-func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 // RefreshResponse represents a refresh response
 type RefreshResponse struct {
-	Serial int64  `protobuf:"varint,1,opt,name=serial" json:"serial,omitempty"`
-	State  []byte `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Serial int64                    `protobuf:"varint,1,opt,name=serial" json:"serial,omitempty"`
+	State  []byte                   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	States map[string]ResourceState `protobuf:"bytes,3,rep,name=states" json:"states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=tfpb.ResourceState"`
+	Errors []string                 `protobuf:"bytes,4,rep,name=errors" json:"errors,omitempty"`
 }
 
 func (m *RefreshResponse) Reset()                    { *m = RefreshResponse{} }
 func (m *RefreshResponse) String() string            { return proto.CompactTextString(m) }
 func (*RefreshResponse) ProtoMessage()               {}
-func (*RefreshResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*RefreshResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *RefreshResponse) GetStates() map[string]ResourceState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
 
 // StateRequest represents a new state request
 type StateRequest struct {
@@ -209,7 +240,7 @@ type StateRequest struct {
 func (m *StateRequest) Reset()                    { *m = StateRequest{} }
 func (m *StateRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateRequest) ProtoMessage()               {}
-func (*StateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*StateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 // StateResponse represents a new state response
 type StateResponse struct {
@@ -220,7 +251,7 @@ type StateResponse struct {
 func (m *StateResponse) Reset()                    { *m = StateResponse{} }
 func (m *StateResponse) String() string            { return proto.CompactTextString(m) }
 func (*StateResponse) ProtoMessage()               {}
-func (*StateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*StateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 // TaintRequest represents a taint request
 type TaintRequest struct {
@@ -232,7 +263,7 @@ type TaintRequest struct {
 func (m *TaintRequest) Reset()                    { *m = TaintRequest{} }
 func (m *TaintRequest) String() string            { return proto.CompactTextString(m) }
 func (*TaintRequest) ProtoMessage()               {}
-func (*TaintRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*TaintRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 // TaintResponse represents a taint response
 type TaintResponse struct {
@@ -243,7 +274,7 @@ type TaintResponse struct {
 func (m *TaintResponse) Reset()                    { *m = TaintResponse{} }
 func (m *TaintResponse) String() string            { return proto.CompactTextString(m) }
 func (*TaintResponse) ProtoMessage()               {}
-func (*TaintResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*TaintResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 // ValidateRequest represents a validation request
 type ValidateRequest struct {
@@ -253,7 +284,7 @@ type ValidateRequest struct {
 func (m *ValidateRequest) Reset()                    { *m = ValidateRequest{} }
 func (m *ValidateRequest) String() string            { return proto.CompactTextString(m) }
 func (*ValidateRequest) ProtoMessage()               {}
-func (*ValidateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*ValidateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 // ValidateResponse represents a validation response
 type ValidateResponse struct {
@@ -265,13 +296,14 @@ type ValidateResponse struct {
 func (m *ValidateResponse) Reset()                    { *m = ValidateResponse{} }
 func (m *ValidateResponse) String() string            { return proto.CompactTextString(m) }
 func (*ValidateResponse) ProtoMessage()               {}
-func (*ValidateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ValidateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func init() {
 	proto.RegisterType((*ApplyRequest)(nil), "tfpb.ApplyRequest")
 	proto.RegisterType((*ApplyResponse)(nil), "tfpb.ApplyResponse")
 	proto.RegisterType((*PlanRequest)(nil), "tfpb.PlanRequest")
 	proto.RegisterType((*PlanResponse)(nil), "tfpb.PlanResponse")
+	proto.RegisterType((*PlanDestroyRequest)(nil), "tfpb.PlanDestroyRequest")
 	proto.RegisterType((*RefreshRequest)(nil), "tfpb.RefreshRequest")
 	proto.RegisterType((*RefreshResponse)(nil), "tfpb.RefreshResponse")
 	proto.RegisterType((*StateRequest)(nil), "tfpb.StateRequest")
@@ -295,8 +327,12 @@ type TerraformClient interface {
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (Terraform_ApplyClient, error)
 	// Generate a diff and execution plan
 	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanResponse, error)
+	// Generate a diff and execution plan to destroy the infrastructure
+	PlanDestroy(ctx context.Context, in *PlanDestroyRequest, opts ...grpc.CallOption) (Terraform_PlanDestroyClient, error)
 	// Update the state against real resources
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
+	// Update the state against real resources, reporting the progress
+	RefreshOnly(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (Terraform_RefreshOnlyClient, error)
 	// Get a new empty state
 	State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error)
 	// Mark a resource for recreation
@@ -354,6 +390,38 @@ func (c *terraformClient) Plan(ctx context.Context, in *PlanRequest, opts ...grp
 	return out, nil
 }
 
+func (c *terraformClient) PlanDestroy(ctx context.Context, in *PlanDestroyRequest, opts ...grpc.CallOption) (Terraform_PlanDestroyClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Terraform_serviceDesc.Streams[1], c.cc, "/tfpb.Terraform/PlanDestroy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terraformPlanDestroyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Terraform_PlanDestroyClient interface {
+	Recv() (*PlanResponse, error)
+	grpc.ClientStream
+}
+
+type terraformPlanDestroyClient struct {
+	grpc.ClientStream
+}
+
+func (x *terraformPlanDestroyClient) Recv() (*PlanResponse, error) {
+	m := new(PlanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *terraformClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error) {
 	out := new(RefreshResponse)
 	err := grpc.Invoke(ctx, "/tfpb.Terraform/Refresh", in, out, c.cc, opts...)
@@ -363,6 +431,38 @@ func (c *terraformClient) Refresh(ctx context.Context, in *RefreshRequest, opts
 	return out, nil
 }
 
+func (c *terraformClient) RefreshOnly(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (Terraform_RefreshOnlyClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Terraform_serviceDesc.Streams[2], c.cc, "/tfpb.Terraform/RefreshOnly", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &terraformRefreshOnlyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Terraform_RefreshOnlyClient interface {
+	Recv() (*RefreshResponse, error)
+	grpc.ClientStream
+}
+
+type terraformRefreshOnlyClient struct {
+	grpc.ClientStream
+}
+
+func (x *terraformRefreshOnlyClient) Recv() (*RefreshResponse, error) {
+	m := new(RefreshResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *terraformClient) State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error) {
 	out := new(StateResponse)
 	err := grpc.Invoke(ctx, "/tfpb.Terraform/State", in, out, c.cc, opts...)
@@ -397,8 +497,12 @@ type TerraformServer interface {
 	Apply(*ApplyRequest, Terraform_ApplyServer) error
 	// Generate a diff and execution plan
 	Plan(context.Context, *PlanRequest) (*PlanResponse, error)
+	// Generate a diff and execution plan to destroy the infrastructure
+	PlanDestroy(*PlanDestroyRequest, Terraform_PlanDestroyServer) error
 	// Update the state against real resources
 	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
+	// Update the state against real resources, reporting the progress
+	RefreshOnly(*RefreshRequest, Terraform_RefreshOnlyServer) error
 	// Get a new empty state
 	State(context.Context, *StateRequest) (*StateResponse, error)
 	// Mark a resource for recreation
@@ -444,6 +548,27 @@ func _Terraform_Plan_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return out, nil
 }
 
+func _Terraform_PlanDestroy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PlanDestroyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraformServer).PlanDestroy(m, &terraformPlanDestroyServer{stream})
+}
+
+type Terraform_PlanDestroyServer interface {
+	Send(*PlanResponse) error
+	grpc.ServerStream
+}
+
+type terraformPlanDestroyServer struct {
+	grpc.ServerStream
+}
+
+func (x *terraformPlanDestroyServer) Send(m *PlanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Terraform_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(RefreshRequest)
 	if err := dec(in); err != nil {
@@ -456,6 +581,27 @@ func _Terraform_Refresh_Handler(srv interface{}, ctx context.Context, dec func(i
 	return out, nil
 }
 
+func _Terraform_RefreshOnly_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RefreshRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraformServer).RefreshOnly(m, &terraformRefreshOnlyServer{stream})
+}
+
+type Terraform_RefreshOnlyServer interface {
+	Send(*RefreshResponse) error
+	grpc.ServerStream
+}
+
+type terraformRefreshOnlyServer struct {
+	grpc.ServerStream
+}
+
+func (x *terraformRefreshOnlyServer) Send(m *RefreshResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Terraform_State_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(StateRequest)
 	if err := dec(in); err != nil {
@@ -523,52 +669,68 @@ var _Terraform_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Terraform_Apply_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PlanDestroy",
+			Handler:       _Terraform_PlanDestroy_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RefreshOnly",
+			Handler:       _Terraform_RefreshOnly_Handler,
+			ServerStreams: true,
+		},
 	},
 }
 
 var fileDescriptor0 = []byte{
-	// 686 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xad, 0x63, 0x3b, 0x4d, 0x6e, 0x5e, 0xee, 0x84, 0xa2, 0x28, 0x1b, 0xc0, 0x6c, 0xaa, 0x4a,
-	0x84, 0x2a, 0xb0, 0xa8, 0x60, 0x81, 0x4a, 0xdb, 0x2d, 0xaa, 0xd2, 0xc2, 0xde, 0x75, 0x26, 0xc5,
-	0xaa, 0x63, 0x9b, 0x19, 0xa7, 0x55, 0xbe, 0x83, 0x4f, 0xe1, 0x4b, 0x10, 0x3f, 0xc4, 0x9d, 0x87,
-	0x9d, 0x99, 0x50, 0xa4, 0xec, 0xe2, 0x3b, 0x73, 0xce, 0x99, 0x39, 0xf7, 0xdc, 0x09, 0x0c, 0x4b,
-	0xca, 0x58, 0xb4, 0xc8, 0xd9, 0xf2, 0x4d, 0x54, 0x24, 0x93, 0x82, 0xe5, 0x65, 0x4e, 0xbc, 0x72,
-	0x51, 0xdc, 0x86, 0x0c, 0xba, 0x67, 0x45, 0x91, 0xae, 0x67, 0xf4, 0xc7, 0x8a, 0xf2, 0x92, 0xf4,
-	0xa1, 0x19, 0xe7, 0xd9, 0x22, 0xb9, 0x1b, 0x39, 0x2f, 0x9d, 0xa3, 0x2e, 0x19, 0xc0, 0xfe, 0x1c,
-	0xeb, 0x2c, 0x5f, 0x8f, 0x1a, 0x58, 0x68, 0x91, 0x2e, 0x78, 0x45, 0x1a, 0x65, 0x23, 0xb7, 0x5a,
-	0x66, 0x74, 0xc1, 0x28, 0xff, 0x3e, 0xf2, 0xe4, 0x72, 0x0f, 0x7c, 0x5e, 0x46, 0x25, 0x1d, 0xf9,
-	0x72, 0x7d, 0x08, 0x9d, 0x22, 0x62, 0x51, 0x9a, 0xd2, 0x34, 0xe1, 0xcb, 0x51, 0x13, 0x8b, 0x7e,
-	0xf8, 0xcb, 0x81, 0x9e, 0x16, 0xe5, 0x45, 0x9e, 0x71, 0x2a, 0x54, 0x39, 0x65, 0x49, 0x94, 0x4a,
-	0x55, 0x77, 0xc3, 0xd2, 0x90, 0x2c, 0x6f, 0x71, 0x59, 0x7c, 0x72, 0x54, 0x75, 0x8f, 0x3a, 0xd3,
-	0x17, 0x13, 0x71, 0xf6, 0x89, 0xc5, 0x31, 0xb9, 0x96, 0x3b, 0x2e, 0xb3, 0x92, 0xad, 0x05, 0x1f,
-	0xde, 0x38, 0x67, 0x1c, 0x4f, 0xe5, 0x1e, 0xb5, 0xc7, 0x9f, 0xa1, 0x63, 0x2e, 0x77, 0xc0, 0xbd,
-	0xa7, 0x6b, 0xa9, 0xd5, 0x26, 0x21, 0xf8, 0x0f, 0x51, 0xba, 0x52, 0x5a, 0xfd, 0xe9, 0x50, 0x71,
-	0x23, 0x6d, 0xbe, 0x62, 0x31, 0x95, 0xb0, 0x0f, 0x8d, 0x53, 0x27, 0x8c, 0xa1, 0x73, 0x85, 0x17,
-	0xdf, 0xd9, 0x28, 0xc3, 0x1a, 0xd7, 0xb6, 0xc6, 0x7b, 0xca, 0x1a, 0x5f, 0x5a, 0xf3, 0xc7, 0x81,
-	0xae, 0x52, 0xd1, 0xce, 0xa0, 0xdd, 0xf3, 0x64, 0xb1, 0xd0, 0x22, 0x27, 0xb0, 0x1f, 0xc5, 0x65,
-	0x82, 0x2b, 0x28, 0x62, 0x38, 0x61, 0x42, 0x26, 0x67, 0x6a, 0x87, 0xba, 0xaa, 0xdd, 0xae, 0x8d,
-	0xcf, 0x9e, 0xed, 0xb3, 0xea, 0x56, 0x00, 0xad, 0xc7, 0x88, 0x65, 0x49, 0x76, 0xc7, 0xb1, 0x55,
-	0xc2, 0xb8, 0x0b, 0x8c, 0x87, 0x49, 0x67, 0x39, 0xf7, 0xda, 0x76, 0xee, 0x99, 0xed, 0x9c, 0xc2,
-	0x49, 0xeb, 0x2e, 0xa0, 0x3f, 0x53, 0x56, 0xfc, 0xcf, 0xbd, 0xad, 0x86, 0x6f, 0x79, 0xe3, 0x4a,
-	0x6f, 0x4e, 0x60, 0x50, 0xb3, 0xec, 0x94, 0x9b, 0xb0, 0x0f, 0x5d, 0xd9, 0x3f, 0xad, 0x1a, 0x4e,
-	0xa0, 0xa7, 0xbf, 0x77, 0xc3, 0x7f, 0x82, 0xee, 0x4d, 0x94, 0x64, 0xa5, 0x71, 0xea, 0x65, 0x3e,
-	0x5f, 0xa5, 0x54, 0x1b, 0x80, 0x7e, 0x31, 0x7d, 0x5b, 0x89, 0x68, 0x6f, 0x08, 0xa4, 0xdf, 0x42,
-	0x50, 0x13, 0xec, 0x26, 0xf8, 0x0a, 0x06, 0xdf, 0xa2, 0x34, 0x99, 0x6f, 0xce, 0xbc, 0xed, 0x54,
-	0x78, 0x0e, 0xc1, 0x66, 0x8b, 0x66, 0xed, 0xc9, 0x46, 0x24, 0x73, 0xb9, 0xa5, 0x65, 0xb5, 0x51,
-	0xc4, 0xa4, 0x6d, 0xcc, 0x83, 0x18, 0xa0, 0xf6, 0x71, 0x0a, 0x3d, 0x2b, 0xe0, 0x08, 0x51, 0x4e,
-	0x7d, 0xcd, 0xee, 0xb3, 0xfc, 0x31, 0x0b, 0xf6, 0xea, 0xca, 0x6c, 0x95, 0x09, 0xa6, 0xc0, 0x21,
-	0x87, 0x70, 0x20, 0x2b, 0x57, 0x2c, 0x7f, 0x48, 0x38, 0xf6, 0x56, 0x94, 0x1b, 0xf5, 0xc6, 0xeb,
-	0x55, 0x1c, 0x53, 0xce, 0x03, 0x17, 0xd5, 0x40, 0x56, 0x2e, 0x85, 0x64, 0xe0, 0x1d, 0xff, 0x74,
-	0x44, 0xff, 0xcd, 0x54, 0x90, 0x03, 0x7c, 0x01, 0xe4, 0xaf, 0x8d, 0x20, 0xa2, 0x54, 0xe9, 0x4b,
-	0x9e, 0x51, 0x94, 0x0b, 0xaa, 0xe8, 0x9d, 0x33, 0x8a, 0x64, 0x4a, 0x49, 0x83, 0x0a, 0x71, 0x7d,
-	0x54, 0xaa, 0x69, 0x2e, 0xd4, 0xe8, 0x05, 0x1e, 0x21, 0xd0, 0x57, 0xa5, 0x19, 0x8d, 0x15, 0xd0,
-	0xc7, 0x38, 0x0d, 0x54, 0xad, 0x3e, 0x7a, 0xd0, 0x9c, 0xfe, 0x6e, 0x40, 0xfb, 0xa6, 0x7a, 0x17,
-	0xc9, 0x7b, 0xf0, 0xe5, 0x73, 0x42, 0x88, 0xf5, 0xb6, 0xc8, 0x1e, 0x8c, 0x87, 0x4f, 0xbc, 0x37,
-	0xe1, 0xde, 0x89, 0x83, 0x0f, 0x93, 0x27, 0x46, 0x8f, 0x1c, 0x98, 0x63, 0xa8, 0x30, 0xe4, 0xdf,
-	0xc9, 0x0c, 0xf7, 0xc8, 0x29, 0xec, 0xeb, 0x0c, 0x93, 0x7a, 0x5c, 0xcc, 0xc1, 0x18, 0x1f, 0x6e,
-	0x55, 0x6b, 0xe4, 0x14, 0x7c, 0xd5, 0x2a, 0x4d, 0x6c, 0x06, 0xbb, 0x3a, 0xa0, 0x15, 0x6e, 0x85,
-	0x91, 0xf1, 0xab, 0x30, 0x66, 0x98, 0x2b, 0x8c, 0x95, 0x4f, 0xc4, 0x7c, 0x84, 0x56, 0x95, 0x2f,
-	0xa2, 0x0f, 0xb3, 0x15, 0xc9, 0xf1, 0xf3, 0xed, 0x72, 0x05, 0xbe, 0x6d, 0xca, 0xbf, 0x96, 0x77,
-	0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xa0, 0xfb, 0xe0, 0x71, 0x06, 0x00, 0x00,
+	// 770 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x18, 0x8d, 0xff, 0xf2, 0xf3, 0xc5, 0x71, 0xdc, 0x49, 0x7b, 0x65, 0x65, 0x71, 0x6f, 0xea, 0xbb,
+	0x89, 0x2a, 0x91, 0x86, 0xc0, 0xa2, 0x02, 0x89, 0xaa, 0xb4, 0xdd, 0x42, 0x95, 0xb6, 0x48, 0x2c,
+	0xdd, 0x64, 0x52, 0xac, 0xba, 0x63, 0x33, 0xe3, 0xb4, 0xca, 0x73, 0xf0, 0x28, 0x2c, 0x78, 0x04,
+	0xf6, 0x3c, 0x11, 0xf2, 0xcc, 0x38, 0xf6, 0x98, 0x80, 0x82, 0x04, 0xbb, 0xfa, 0x9b, 0xf9, 0xce,
+	0xf9, 0xe6, 0x9c, 0x39, 0xd3, 0x40, 0x2f, 0xc5, 0x94, 0x06, 0x8b, 0x98, 0xde, 0x3f, 0x09, 0x92,
+	0x70, 0x94, 0xd0, 0x38, 0x8d, 0x91, 0x99, 0x2e, 0x92, 0x1b, 0x9f, 0x82, 0x7d, 0x92, 0x24, 0xd1,
+	0x6a, 0x8a, 0x3f, 0x2e, 0x31, 0x4b, 0x91, 0x03, 0xf5, 0x59, 0x4c, 0x16, 0xe1, 0xad, 0xa7, 0x0d,
+	0xb4, 0xa1, 0x8d, 0xba, 0xd0, 0x98, 0x63, 0x96, 0xd2, 0x78, 0xe5, 0xe9, 0x03, 0x6d, 0xd8, 0x44,
+	0x36, 0x98, 0x49, 0x14, 0x10, 0xcf, 0xc8, 0x97, 0x29, 0x5e, 0x50, 0xcc, 0x3e, 0x78, 0x26, 0x5f,
+	0xee, 0x80, 0xc5, 0xd2, 0x20, 0xc5, 0x9e, 0xc5, 0xd7, 0x7b, 0xd0, 0x4e, 0x02, 0x1a, 0x44, 0x11,
+	0x8e, 0x42, 0x76, 0xef, 0xd5, 0x07, 0xda, 0xd0, 0xf2, 0x3f, 0x6b, 0xd0, 0x91, 0xa4, 0x2c, 0x89,
+	0x09, 0xc3, 0x19, 0x2b, 0xc3, 0x34, 0x0c, 0x22, 0xce, 0x6a, 0x14, 0x28, 0x3a, 0x47, 0x39, 0x84,
+	0x3a, 0xff, 0x64, 0x9e, 0x31, 0x30, 0x86, 0xed, 0xc9, 0x7f, 0xa3, 0x6c, 0xf6, 0x91, 0x82, 0x31,
+	0xba, 0xe4, 0x3b, 0xce, 0x49, 0x4a, 0x57, 0x19, 0x1e, 0xa6, 0x34, 0xa6, 0xcc, 0x33, 0x07, 0xc6,
+	0xb0, 0xd5, 0x7f, 0x0d, 0xed, 0xf2, 0x72, 0x1b, 0x8c, 0x3b, 0xbc, 0xe2, 0x5c, 0x2d, 0xe4, 0x83,
+	0xf5, 0x10, 0x44, 0x4b, 0xc1, 0xe5, 0x4c, 0x7a, 0x02, 0x7b, 0x8a, 0x59, 0xbc, 0xa4, 0x33, 0xcc,
+	0xdb, 0x5e, 0xe8, 0x47, 0x9a, 0x3f, 0x83, 0xf6, 0x45, 0x14, 0x90, 0xad, 0x85, 0x2a, 0x49, 0x63,
+	0xa8, 0xd2, 0x98, 0x9b, 0xa4, 0xb1, 0xb8, 0x34, 0x5f, 0x75, 0xb0, 0x05, 0x8b, 0x54, 0xc6, 0x06,
+	0x73, 0x1e, 0x2e, 0x16, 0x92, 0x64, 0x0c, 0x8d, 0x60, 0x96, 0x86, 0x31, 0x61, 0x9e, 0x5e, 0x56,
+	0xa2, 0xdc, 0x32, 0x3a, 0x11, 0x3b, 0xc4, 0x51, 0x55, 0xbb, 0x0a, 0x9d, 0x4d, 0x55, 0x67, 0xe1,
+	0x96, 0x0b, 0xcd, 0xc7, 0x80, 0x92, 0x90, 0xdc, 0x32, 0xaf, 0x9e, 0x09, 0x87, 0x46, 0x6b, 0xe5,
+	0x1b, 0x9c, 0xef, 0xdf, 0x0d, 0x7c, 0x25, 0x65, 0xfb, 0x67, 0x60, 0x2b, 0xf4, 0x8a, 0xd2, 0xff,
+	0xab, 0x4a, 0xef, 0xaa, 0x4a, 0x8b, 0xbe, 0x4c, 0xea, 0x3f, 0x62, 0xd7, 0x7b, 0x40, 0xd9, 0x94,
+	0x67, 0xc2, 0x93, 0x5f, 0xb8, 0x96, 0x9b, 0xa4, 0xab, 0x26, 0x19, 0x9b, 0x4c, 0x32, 0xb9, 0x49,
+	0x67, 0xe0, 0x4c, 0x45, 0xd3, 0xcf, 0x60, 0x2b, 0xf7, 0xb7, 0x82, 0x62, 0x70, 0x94, 0x2f, 0x1a,
+	0x74, 0xd7, 0x30, 0xdb, 0xe5, 0xe0, 0x69, 0x25, 0x07, 0xfb, 0xf9, 0xe1, 0x15, 0x94, 0xbf, 0x9e,
+	0x04, 0x07, 0x6c, 0xfe, 0x21, 0x4f, 0xef, 0x8f, 0xa0, 0x23, 0xbf, 0xb7, 0x3a, 0x86, 0x7f, 0x0c,
+	0xf6, 0x55, 0x10, 0x92, 0xb4, 0xa4, 0xde, 0x7d, 0x3c, 0x5f, 0x46, 0x58, 0xce, 0xe1, 0x42, 0x93,
+	0x4a, 0x52, 0xde, 0xd1, 0xaa, 0xb8, 0x92, 0x11, 0x4a, 0x80, 0xed, 0x08, 0xf7, 0xa1, 0xfb, 0x2e,
+	0x88, 0xc2, 0x79, 0x31, 0x73, 0xd5, 0x31, 0xff, 0x14, 0xdc, 0x62, 0x8b, 0x44, 0xed, 0x70, 0x3d,
+	0xc2, 0x39, 0xdf, 0xd2, 0x54, 0xd2, 0xa1, 0xf3, 0x74, 0x14, 0xe2, 0x66, 0x7e, 0xb4, 0x0e, 0x22,
+	0xe8, 0x28, 0x6a, 0x21, 0x57, 0x2a, 0x75, 0x4d, 0xee, 0x48, 0xfc, 0x48, 0xdc, 0xda, 0xba, 0x32,
+	0x5d, 0x92, 0x0c, 0xc9, 0xd5, 0xd0, 0x1e, 0xec, 0xf0, 0xca, 0x05, 0x8d, 0x1f, 0x42, 0x16, 0xc6,
+	0xbc, 0xac, 0xaf, 0x37, 0x5e, 0x2e, 0x67, 0x33, 0xcc, 0x98, 0x6b, 0x20, 0x07, 0x80, 0x57, 0xce,
+	0x33, 0x4a, 0xd7, 0x3c, 0xf8, 0xa4, 0x81, 0x93, 0xd3, 0x89, 0xf0, 0xa0, 0x1d, 0xe8, 0x88, 0xbf,
+	0x0a, 0x42, 0x07, 0x40, 0x94, 0xde, 0xc4, 0x04, 0xbb, 0x5a, 0x86, 0x2b, 0xbe, 0x4f, 0x29, 0x0e,
+	0x52, 0xec, 0xea, 0x45, 0xe5, 0x3a, 0xc9, 0x8e, 0xef, 0x1a, 0x05, 0x8c, 0x4c, 0x8f, 0x6b, 0x22,
+	0x04, 0x8e, 0x28, 0x4d, 0xf1, 0x4c, 0x34, 0x5a, 0xa8, 0x07, 0x5d, 0x51, 0x5b, 0x8f, 0xee, 0xd6,
+	0x27, 0xdf, 0x0c, 0x68, 0x5d, 0xe5, 0xff, 0x6e, 0xd0, 0x73, 0xb0, 0xf8, 0x2b, 0x8d, 0x90, 0xf2,
+	0x64, 0x73, 0x0f, 0xfa, 0xbd, 0x0d, 0xcf, 0xb8, 0x5f, 0x1b, 0x6b, 0xe8, 0x10, 0xcc, 0x2c, 0xbb,
+	0x68, 0xa7, 0xfc, 0xda, 0x88, 0x1e, 0xf4, 0xe3, 0x03, 0xe4, 0xd7, 0xd0, 0xb1, 0x78, 0x9b, 0xe5,
+	0xb8, 0xc8, 0x2b, 0x36, 0xa9, 0xf9, 0xdf, 0xdc, 0x3e, 0xd6, 0xd0, 0x11, 0x34, 0x64, 0x8a, 0xd0,
+	0x6e, 0x25, 0x54, 0xa2, 0x71, 0x6f, 0x63, 0xd4, 0xfc, 0x1a, 0x7a, 0x05, 0x6d, 0x59, 0x7c, 0x4b,
+	0xa2, 0xd5, 0x6f, 0x76, 0x8f, 0x35, 0x34, 0x01, 0x4b, 0xdc, 0x15, 0x39, 0x5a, 0x39, 0x59, 0xfd,
+	0x9e, 0x52, 0x5b, 0x73, 0x4e, 0xc0, 0xe2, 0xf7, 0x3f, 0xef, 0x29, 0xa7, 0xa9, 0xdf, 0x53, 0x6a,
+	0xeb, 0x9e, 0x97, 0xd0, 0xcc, 0x2f, 0x38, 0x92, 0xe3, 0x54, 0x32, 0xd1, 0xff, 0xa7, 0x5a, 0xce,
+	0x9b, 0x6f, 0xea, 0xfc, 0x27, 0xc3, 0xb3, 0xef, 0x03, 0x00, 0x2e, 0x28, 0x90, 0xe9, 0x49, 0x08,
+	0x00, 0x00,
 }
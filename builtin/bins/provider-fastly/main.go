@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/xanzy/terraform-api/builtin/providers/fastly"
+	"github.com/xanzy/terraform-api/plugin"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: fastly.Provider,
+	})
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/xanzy/terraform-api/builtin/providers/random"
+	"github.com/xanzy/terraform-api/plugin"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: random.Provider,
+	})
+}
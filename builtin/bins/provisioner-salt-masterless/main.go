@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/xanzy/terraform-api/builtin/provisioners/salt-masterless"
+	"github.com/xanzy/terraform-api/plugin"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		ProvisionerFunc: func() terraform.ResourceProvisioner {
+			return new(saltmasterless.ResourceProvisioner)
+		},
+	})
+}
@@ -0,0 +1,597 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/xanzy/terraform-api/helper/hashcode"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+// cloudFrontRoute53ZoneID is the hosted zone of all CloudFront
+// distributions, used to create Route 53 alias records.
+const cloudFrontRoute53ZoneID = "Z2FDTNDATAQYW2"
+
+// expandDistributionConfig builds the configuration of a distribution from
+// the resource data. The caller reference has to be set by the caller.
+func expandDistributionConfig(d *schema.ResourceData) *cloudfront.DistributionConfig {
+	dc := &cloudfront.DistributionConfig{
+		CacheBehaviors:       expandCacheBehaviors(d.Get("cache_behavior").([]interface{})),
+		CustomErrorResponses: expandCustomErrorResponses(d.Get("custom_error_response").(*schema.Set)),
+		DefaultCacheBehavior: expandDefaultCacheBehavior(d.Get("default_cache_behavior").([]interface{})[0].(map[string]interface{})),
+		Enabled:              aws.Bool(d.Get("enabled").(bool)),
+		HttpVersion:          aws.String(d.Get("http_version").(string)),
+		Origins:              expandOrigins(d.Get("origin").(*schema.Set)),
+		PriceClass:           aws.String(d.Get("price_class").(string)),
+		Restrictions:         expandRestrictions(d.Get("restrictions").([]interface{})[0].(map[string]interface{})),
+		ViewerCertificate:    expandViewerCertificate(d.Get("viewer_certificate").([]interface{})[0].(map[string]interface{})),
+
+		// These have to be sent even when they are empty
+		Aliases:           expandAliases(d.Get("aliases").(*schema.Set)),
+		Comment:           aws.String(d.Get("comment").(string)),
+		DefaultRootObject: aws.String(d.Get("default_root_object").(string)),
+		Logging:           expandLoggingConfig(d.Get("logging_config").([]interface{})),
+		WebACLId:          aws.String(d.Get("web_acl_id").(string)),
+	}
+
+	return dc
+}
+
+// flattenDistributionConfig sets the arguments of the resource data from
+// the given configuration of a distribution.
+func flattenDistributionConfig(d *schema.ResourceData, dc *cloudfront.DistributionConfig) error {
+	d.Set("caller_reference", aws.StringValue(dc.CallerReference))
+	d.Set("comment", aws.StringValue(dc.Comment))
+	d.Set("default_root_object", aws.StringValue(dc.DefaultRootObject))
+	d.Set("enabled", aws.BoolValue(dc.Enabled))
+	d.Set("http_version", aws.StringValue(dc.HttpVersion))
+	d.Set("price_class", aws.StringValue(dc.PriceClass))
+	d.Set("web_acl_id", aws.StringValue(dc.WebACLId))
+
+	if err := d.Set("aliases", flattenAliases(dc.Aliases)); err != nil {
+		return err
+	}
+	if err := d.Set("default_cache_behavior", []interface{}{flattenDefaultCacheBehavior(dc.DefaultCacheBehavior)}); err != nil {
+		return err
+	}
+	if err := d.Set("cache_behavior", flattenCacheBehaviors(dc.CacheBehaviors)); err != nil {
+		return err
+	}
+	if err := d.Set("custom_error_response", flattenCustomErrorResponses(dc.CustomErrorResponses)); err != nil {
+		return err
+	}
+	if err := d.Set("logging_config", flattenLoggingConfig(dc.Logging)); err != nil {
+		return err
+	}
+	if err := d.Set("origin", flattenOrigins(dc.Origins)); err != nil {
+		return err
+	}
+	if err := d.Set("restrictions", []interface{}{flattenRestrictions(dc.Restrictions)}); err != nil {
+		return err
+	}
+	if err := d.Set("viewer_certificate", []interface{}{flattenViewerCertificate(dc.ViewerCertificate)}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// expandCloudFrontStringSet returns the items and quantity of a set of
+// strings, the way most lists are modelled by the CloudFront API.
+func expandCloudFrontStringSet(s *schema.Set) ([]*string, *int64) {
+	items := expandStringList(s.List())
+	return items, aws.Int64(int64(len(items)))
+}
+
+func flattenCloudFrontStringSet(items []*string) *schema.Set {
+	return schema.NewSet(schema.HashString, flattenStringList(items))
+}
+
+func expandAliases(s *schema.Set) *cloudfront.Aliases {
+	items, quantity := expandCloudFrontStringSet(s)
+	return &cloudfront.Aliases{
+		Items:    items,
+		Quantity: quantity,
+	}
+}
+
+func flattenAliases(aliases *cloudfront.Aliases) *schema.Set {
+	if aliases == nil {
+		return flattenCloudFrontStringSet(nil)
+	}
+	return flattenCloudFrontStringSet(aliases.Items)
+}
+
+func expandCacheBehaviors(l []interface{}) *cloudfront.CacheBehaviors {
+	behaviors := make([]*cloudfront.CacheBehavior, 0, len(l))
+	for _, raw := range l {
+		behaviors = append(behaviors, expandCacheBehavior(raw.(map[string]interface{})))
+	}
+
+	return &cloudfront.CacheBehaviors{
+		Items:    behaviors,
+		Quantity: aws.Int64(int64(len(behaviors))),
+	}
+}
+
+func flattenCacheBehaviors(behaviors *cloudfront.CacheBehaviors) []interface{} {
+	if behaviors == nil {
+		return nil
+	}
+
+	l := make([]interface{}, 0, len(behaviors.Items))
+	for _, cb := range behaviors.Items {
+		l = append(l, flattenCacheBehavior(cb))
+	}
+	return l
+}
+
+func expandCacheBehavior(m map[string]interface{}) *cloudfront.CacheBehavior {
+	allowedMethods, allowedQuantity := expandCloudFrontStringSet(m["allowed_methods"].(*schema.Set))
+	cachedMethods, cachedQuantity := expandCloudFrontStringSet(m["cached_methods"].(*schema.Set))
+	trustedSigners, signersQuantity := expandCloudFrontStringSet(m["trusted_signers"].(*schema.Set))
+
+	cb := &cloudfront.CacheBehavior{
+		AllowedMethods: &cloudfront.AllowedMethods{
+			Items:    allowedMethods,
+			Quantity: allowedQuantity,
+			CachedMethods: &cloudfront.CachedMethods{
+				Items:    cachedMethods,
+				Quantity: cachedQuantity,
+			},
+		},
+		Compress:        aws.Bool(m["compress"].(bool)),
+		DefaultTTL:      aws.Int64(int64(m["default_ttl"].(int))),
+		ForwardedValues: expandForwardedValues(m["forwarded_values"].([]interface{})[0].(map[string]interface{})),
+		MaxTTL:          aws.Int64(int64(m["max_ttl"].(int))),
+		MinTTL:          aws.Int64(int64(m["min_ttl"].(int))),
+		SmoothStreaming: aws.Bool(m["smooth_streaming"].(bool)),
+		TargetOriginId:  aws.String(m["target_origin_id"].(string)),
+		TrustedSigners: &cloudfront.TrustedSigners{
+			Enabled:  aws.Bool(len(trustedSigners) > 0),
+			Items:    trustedSigners,
+			Quantity: signersQuantity,
+		},
+		ViewerProtocolPolicy: aws.String(m["viewer_protocol_policy"].(string)),
+	}
+
+	if v, ok := m["path_pattern"]; ok {
+		cb.PathPattern = aws.String(v.(string))
+	}
+
+	return cb
+}
+
+func flattenCacheBehavior(cb *cloudfront.CacheBehavior) map[string]interface{} {
+	m := map[string]interface{}{
+		"compress":               aws.BoolValue(cb.Compress),
+		"default_ttl":            int(aws.Int64Value(cb.DefaultTTL)),
+		"max_ttl":                int(aws.Int64Value(cb.MaxTTL)),
+		"min_ttl":                int(aws.Int64Value(cb.MinTTL)),
+		"smooth_streaming":       aws.BoolValue(cb.SmoothStreaming),
+		"target_origin_id":       aws.StringValue(cb.TargetOriginId),
+		"viewer_protocol_policy": aws.StringValue(cb.ViewerProtocolPolicy),
+		"allowed_methods":        flattenCloudFrontStringSet(nil),
+		"cached_methods":         flattenCloudFrontStringSet(nil),
+		"trusted_signers":        flattenCloudFrontStringSet(nil),
+	}
+
+	if cb.PathPattern != nil {
+		m["path_pattern"] = *cb.PathPattern
+	}
+	if cb.AllowedMethods != nil {
+		m["allowed_methods"] = flattenCloudFrontStringSet(cb.AllowedMethods.Items)
+		if cb.AllowedMethods.CachedMethods != nil {
+			m["cached_methods"] = flattenCloudFrontStringSet(cb.AllowedMethods.CachedMethods.Items)
+		}
+	}
+	if cb.TrustedSigners != nil {
+		m["trusted_signers"] = flattenCloudFrontStringSet(cb.TrustedSigners.Items)
+	}
+	if cb.ForwardedValues != nil {
+		m["forwarded_values"] = []interface{}{flattenForwardedValues(cb.ForwardedValues)}
+	}
+
+	return m
+}
+
+// expandDefaultCacheBehavior builds the default cache behavior, which has
+// the same fields as any other cache behavior apart from the path pattern.
+func expandDefaultCacheBehavior(m map[string]interface{}) *cloudfront.DefaultCacheBehavior {
+	cb := expandCacheBehavior(m)
+	return &cloudfront.DefaultCacheBehavior{
+		AllowedMethods:       cb.AllowedMethods,
+		Compress:             cb.Compress,
+		DefaultTTL:           cb.DefaultTTL,
+		ForwardedValues:      cb.ForwardedValues,
+		MaxTTL:               cb.MaxTTL,
+		MinTTL:               cb.MinTTL,
+		SmoothStreaming:      cb.SmoothStreaming,
+		TargetOriginId:       cb.TargetOriginId,
+		TrustedSigners:       cb.TrustedSigners,
+		ViewerProtocolPolicy: cb.ViewerProtocolPolicy,
+	}
+}
+
+func flattenDefaultCacheBehavior(dcb *cloudfront.DefaultCacheBehavior) map[string]interface{} {
+	return flattenCacheBehavior(&cloudfront.CacheBehavior{
+		AllowedMethods:       dcb.AllowedMethods,
+		Compress:             dcb.Compress,
+		DefaultTTL:           dcb.DefaultTTL,
+		ForwardedValues:      dcb.ForwardedValues,
+		MaxTTL:               dcb.MaxTTL,
+		MinTTL:               dcb.MinTTL,
+		SmoothStreaming:      dcb.SmoothStreaming,
+		TargetOriginId:       dcb.TargetOriginId,
+		TrustedSigners:       dcb.TrustedSigners,
+		ViewerProtocolPolicy: dcb.ViewerProtocolPolicy,
+	})
+}
+
+func expandForwardedValues(m map[string]interface{}) *cloudfront.ForwardedValues {
+	headers, headersQuantity := expandCloudFrontStringSet(m["headers"].(*schema.Set))
+
+	fv := &cloudfront.ForwardedValues{
+		Headers: &cloudfront.Headers{
+			Items:    headers,
+			Quantity: headersQuantity,
+		},
+		QueryString: aws.Bool(m["query_string"].(bool)),
+	}
+
+	cookies := m["cookies"].([]interface{})[0].(map[string]interface{})
+	fv.Cookies = &cloudfront.CookiePreference{
+		Forward: aws.String(cookies["forward"].(string)),
+	}
+	if names := cookies["whitelisted_names"].(*schema.Set); names.Len() > 0 {
+		items, quantity := expandCloudFrontStringSet(names)
+		fv.Cookies.WhitelistedNames = &cloudfront.CookieNames{
+			Items:    items,
+			Quantity: quantity,
+		}
+	}
+
+	return fv
+}
+
+func flattenForwardedValues(fv *cloudfront.ForwardedValues) map[string]interface{} {
+	m := map[string]interface{}{
+		"query_string": aws.BoolValue(fv.QueryString),
+		"headers":      flattenCloudFrontStringSet(nil),
+	}
+
+	if fv.Headers != nil {
+		m["headers"] = flattenCloudFrontStringSet(fv.Headers.Items)
+	}
+
+	if fv.Cookies != nil {
+		cookies := map[string]interface{}{
+			"forward":           aws.StringValue(fv.Cookies.Forward),
+			"whitelisted_names": flattenCloudFrontStringSet(nil),
+		}
+		if fv.Cookies.WhitelistedNames != nil {
+			cookies["whitelisted_names"] = flattenCloudFrontStringSet(fv.Cookies.WhitelistedNames.Items)
+		}
+		m["cookies"] = []interface{}{cookies}
+	}
+
+	return m
+}
+
+func expandOrigins(s *schema.Set) *cloudfront.Origins {
+	origins := make([]*cloudfront.Origin, 0, s.Len())
+	for _, raw := range s.List() {
+		origins = append(origins, expandOrigin(raw.(map[string]interface{})))
+	}
+
+	return &cloudfront.Origins{
+		Items:    origins,
+		Quantity: aws.Int64(int64(len(origins))),
+	}
+}
+
+func flattenOrigins(origins *cloudfront.Origins) *schema.Set {
+	s := schema.NewSet(originHash, nil)
+	if origins == nil {
+		return s
+	}
+
+	for _, o := range origins.Items {
+		s.Add(flattenOrigin(o))
+	}
+	return s
+}
+
+func expandOrigin(m map[string]interface{}) *cloudfront.Origin {
+	o := &cloudfront.Origin{
+		DomainName: aws.String(m["domain_name"].(string)),
+		Id:         aws.String(m["origin_id"].(string)),
+		OriginPath: aws.String(m["origin_path"].(string)),
+	}
+
+	headers := m["custom_header"].(*schema.Set).List()
+	o.CustomHeaders = &cloudfront.CustomHeaders{
+		Quantity: aws.Int64(int64(len(headers))),
+	}
+	for _, raw := range headers {
+		h := raw.(map[string]interface{})
+		o.CustomHeaders.Items = append(o.CustomHeaders.Items, &cloudfront.OriginCustomHeader{
+			HeaderName:  aws.String(h["name"].(string)),
+			HeaderValue: aws.String(h["value"].(string)),
+		})
+	}
+
+	if l := m["custom_origin_config"].([]interface{}); len(l) > 0 {
+		c := l[0].(map[string]interface{})
+		protocols, quantity := expandCloudFrontStringSet(c["origin_ssl_protocols"].(*schema.Set))
+		o.CustomOriginConfig = &cloudfront.CustomOriginConfig{
+			HTTPPort:             aws.Int64(int64(c["http_port"].(int))),
+			HTTPSPort:            aws.Int64(int64(c["https_port"].(int))),
+			OriginProtocolPolicy: aws.String(c["origin_protocol_policy"].(string)),
+			OriginSslProtocols: &cloudfront.OriginSslProtocols{
+				Items:    protocols,
+				Quantity: quantity,
+			},
+		}
+	}
+
+	if l := m["s3_origin_config"].([]interface{}); len(l) > 0 {
+		c := l[0].(map[string]interface{})
+		o.S3OriginConfig = &cloudfront.S3OriginConfig{
+			OriginAccessIdentity: aws.String(c["origin_access_identity"].(string)),
+		}
+	}
+
+	return o
+}
+
+func flattenOrigin(o *cloudfront.Origin) map[string]interface{} {
+	m := map[string]interface{}{
+		"domain_name":   aws.StringValue(o.DomainName),
+		"origin_id":     aws.StringValue(o.Id),
+		"origin_path":   aws.StringValue(o.OriginPath),
+		"custom_header": schema.NewSet(customHeaderHash, nil),
+	}
+
+	if o.CustomHeaders != nil {
+		for _, h := range o.CustomHeaders.Items {
+			m["custom_header"].(*schema.Set).Add(map[string]interface{}{
+				"name":  aws.StringValue(h.HeaderName),
+				"value": aws.StringValue(h.HeaderValue),
+			})
+		}
+	}
+
+	if c := o.CustomOriginConfig; c != nil {
+		config := map[string]interface{}{
+			"http_port":              int(aws.Int64Value(c.HTTPPort)),
+			"https_port":             int(aws.Int64Value(c.HTTPSPort)),
+			"origin_protocol_policy": aws.StringValue(c.OriginProtocolPolicy),
+			"origin_ssl_protocols":   flattenCloudFrontStringSet(nil),
+		}
+		if c.OriginSslProtocols != nil {
+			config["origin_ssl_protocols"] = flattenCloudFrontStringSet(c.OriginSslProtocols.Items)
+		}
+		m["custom_origin_config"] = []interface{}{config}
+	}
+
+	if c := o.S3OriginConfig; c != nil {
+		m["s3_origin_config"] = []interface{}{
+			map[string]interface{}{
+				"origin_access_identity": aws.StringValue(c.OriginAccessIdentity),
+			},
+		}
+	}
+
+	return m
+}
+
+func expandCustomErrorResponses(s *schema.Set) *cloudfront.CustomErrorResponses {
+	responses := make([]*cloudfront.CustomErrorResponse, 0, s.Len())
+	for _, raw := range s.List() {
+		m := raw.(map[string]interface{})
+		r := &cloudfront.CustomErrorResponse{
+			ErrorCode: aws.Int64(int64(m["error_code"].(int))),
+		}
+		if v, ok := m["error_caching_min_ttl"]; ok {
+			r.ErrorCachingMinTTL = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := m["response_code"]; ok && v.(int) != 0 {
+			r.ResponseCode = aws.String(fmt.Sprintf("%d", v.(int)))
+		} else {
+			r.ResponseCode = aws.String("")
+		}
+		if v, ok := m["response_page_path"]; ok {
+			r.ResponsePagePath = aws.String(v.(string))
+		}
+		responses = append(responses, r)
+	}
+
+	return &cloudfront.CustomErrorResponses{
+		Items:    responses,
+		Quantity: aws.Int64(int64(len(responses))),
+	}
+}
+
+func flattenCustomErrorResponses(responses *cloudfront.CustomErrorResponses) *schema.Set {
+	s := schema.NewSet(customErrorResponseHash, nil)
+	if responses == nil {
+		return s
+	}
+
+	for _, r := range responses.Items {
+		m := map[string]interface{}{
+			"error_caching_min_ttl": int(aws.Int64Value(r.ErrorCachingMinTTL)),
+			"error_code":            int(aws.Int64Value(r.ErrorCode)),
+			"response_page_path":    aws.StringValue(r.ResponsePagePath),
+		}
+		var code int
+		fmt.Sscanf(aws.StringValue(r.ResponseCode), "%d", &code)
+		m["response_code"] = code
+		s.Add(m)
+	}
+	return s
+}
+
+// expandLoggingConfig builds the logging configuration, which has to be
+// sent disabled when no logging is configured.
+func expandLoggingConfig(l []interface{}) *cloudfront.LoggingConfig {
+	if len(l) == 0 {
+		return &cloudfront.LoggingConfig{
+			Enabled:        aws.Bool(false),
+			Bucket:         aws.String(""),
+			IncludeCookies: aws.Bool(false),
+			Prefix:         aws.String(""),
+		}
+	}
+
+	m := l[0].(map[string]interface{})
+	return &cloudfront.LoggingConfig{
+		Enabled:        aws.Bool(true),
+		Bucket:         aws.String(m["bucket"].(string)),
+		IncludeCookies: aws.Bool(m["include_cookies"].(bool)),
+		Prefix:         aws.String(m["prefix"].(string)),
+	}
+}
+
+func flattenLoggingConfig(lc *cloudfront.LoggingConfig) []interface{} {
+	if lc == nil || !aws.BoolValue(lc.Enabled) {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"bucket":          aws.StringValue(lc.Bucket),
+			"include_cookies": aws.BoolValue(lc.IncludeCookies),
+			"prefix":          aws.StringValue(lc.Prefix),
+		},
+	}
+}
+
+func expandRestrictions(m map[string]interface{}) *cloudfront.Restrictions {
+	gr := m["geo_restriction"].([]interface{})[0].(map[string]interface{})
+	locations, quantity := expandCloudFrontStringSet(gr["locations"].(*schema.Set))
+
+	return &cloudfront.Restrictions{
+		GeoRestriction: &cloudfront.GeoRestriction{
+			Items:           locations,
+			Quantity:        quantity,
+			RestrictionType: aws.String(gr["restriction_type"].(string)),
+		},
+	}
+}
+
+func flattenRestrictions(r *cloudfront.Restrictions) map[string]interface{} {
+	gr := map[string]interface{}{
+		"locations": flattenCloudFrontStringSet(nil),
+	}
+	if r != nil && r.GeoRestriction != nil {
+		gr["locations"] = flattenCloudFrontStringSet(r.GeoRestriction.Items)
+		gr["restriction_type"] = aws.StringValue(r.GeoRestriction.RestrictionType)
+	}
+
+	return map[string]interface{}{
+		"geo_restriction": []interface{}{gr},
+	}
+}
+
+func expandViewerCertificate(m map[string]interface{}) *cloudfront.ViewerCertificate {
+	vc := &cloudfront.ViewerCertificate{}
+
+	if v := m["acm_certificate_arn"].(string); v != "" {
+		vc.ACMCertificateArn = aws.String(v)
+		vc.SSLSupportMethod = aws.String(m["ssl_support_method"].(string))
+	} else if v := m["iam_certificate_id"].(string); v != "" {
+		vc.IAMCertificateId = aws.String(v)
+		vc.SSLSupportMethod = aws.String(m["ssl_support_method"].(string))
+	} else {
+		vc.CloudFrontDefaultCertificate = aws.Bool(m["cloudfront_default_certificate"].(bool))
+	}
+
+	if v := m["minimum_protocol_version"].(string); v != "" {
+		vc.MinimumProtocolVersion = aws.String(v)
+	}
+
+	return vc
+}
+
+func flattenViewerCertificate(vc *cloudfront.ViewerCertificate) map[string]interface{} {
+	m := make(map[string]interface{})
+	if vc == nil {
+		return m
+	}
+
+	if vc.ACMCertificateArn != nil {
+		m["acm_certificate_arn"] = *vc.ACMCertificateArn
+		m["ssl_support_method"] = aws.StringValue(vc.SSLSupportMethod)
+	}
+	if vc.IAMCertificateId != nil {
+		m["iam_certificate_id"] = *vc.IAMCertificateId
+		m["ssl_support_method"] = aws.StringValue(vc.SSLSupportMethod)
+	}
+	if vc.CloudFrontDefaultCertificate != nil {
+		m["cloudfront_default_certificate"] = *vc.CloudFrontDefaultCertificate
+	}
+	if vc.MinimumProtocolVersion != nil {
+		m["minimum_protocol_version"] = *vc.MinimumProtocolVersion
+	}
+
+	return m
+}
+
+func originHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["origin_id"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["domain_name"].(string)))
+	if v, ok := m["origin_path"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["custom_header"].(*schema.Set); ok {
+		for _, h := range v.List() {
+			buf.WriteString(fmt.Sprintf("%d-", customHeaderHash(h)))
+		}
+	}
+	if v, ok := m["custom_origin_config"].([]interface{}); ok && len(v) > 0 {
+		c := v[0].(map[string]interface{})
+		buf.WriteString(fmt.Sprintf("%d-", c["http_port"].(int)))
+		buf.WriteString(fmt.Sprintf("%d-", c["https_port"].(int)))
+		buf.WriteString(fmt.Sprintf("%s-", c["origin_protocol_policy"].(string)))
+		if protocols, ok := c["origin_ssl_protocols"].(*schema.Set); ok {
+			for _, p := range protocols.List() {
+				buf.WriteString(fmt.Sprintf("%s-", p.(string)))
+			}
+		}
+	}
+	if v, ok := m["s3_origin_config"].([]interface{}); ok && len(v) > 0 {
+		c := v[0].(map[string]interface{})
+		buf.WriteString(fmt.Sprintf("%s-", c["origin_access_identity"].(string)))
+	}
+	return hashcode.String(buf.String())
+}
+
+func customHeaderHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["value"].(string)))
+	return hashcode.String(buf.String())
+}
+
+func customErrorResponseHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%d-", m["error_code"].(int)))
+	if v, ok := m["error_caching_min_ttl"]; ok {
+		buf.WriteString(fmt.Sprintf("%d-", v.(int)))
+	}
+	if v, ok := m["response_code"]; ok {
+		buf.WriteString(fmt.Sprintf("%d-", v.(int)))
+	}
+	if v, ok := m["response_page_path"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	return hashcode.String(buf.String())
+}
@@ -0,0 +1,217 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func testCloudFrontStringSet(items ...string) *schema.Set {
+	l := make([]interface{}, 0, len(items))
+	for _, v := range items {
+		l = append(l, v)
+	}
+	return schema.NewSet(schema.HashString, l)
+}
+
+func testCloudFrontCacheBehavior() map[string]interface{} {
+	return map[string]interface{}{
+		"allowed_methods":        testCloudFrontStringSet("GET", "HEAD"),
+		"cached_methods":         testCloudFrontStringSet("GET", "HEAD"),
+		"compress":               true,
+		"default_ttl":            86400,
+		"max_ttl":                31536000,
+		"min_ttl":                0,
+		"path_pattern":           "images/*",
+		"smooth_streaming":       false,
+		"target_origin_id":       "myS3Origin",
+		"trusted_signers":        testCloudFrontStringSet(),
+		"viewer_protocol_policy": "allow-all",
+		"forwarded_values": []interface{}{
+			map[string]interface{}{
+				"query_string": true,
+				"headers":      testCloudFrontStringSet("Host", "Origin"),
+				"cookies": []interface{}{
+					map[string]interface{}{
+						"forward":           "whitelist",
+						"whitelisted_names": testCloudFrontStringSet("session"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCloudFrontStructure_cacheBehavior(t *testing.T) {
+	m := testCloudFrontCacheBehavior()
+
+	cb := expandCacheBehavior(m)
+	if *cb.PathPattern != "images/*" {
+		t.Fatalf("bad path pattern: %s", *cb.PathPattern)
+	}
+	if *cb.AllowedMethods.Quantity != 2 || *cb.AllowedMethods.CachedMethods.Quantity != 2 {
+		t.Fatalf("bad methods: %#v", cb.AllowedMethods)
+	}
+	if *cb.TrustedSigners.Enabled || *cb.TrustedSigners.Quantity != 0 {
+		t.Fatalf("bad trusted signers: %#v", cb.TrustedSigners)
+	}
+	if *cb.ForwardedValues.Cookies.WhitelistedNames.Quantity != 1 {
+		t.Fatalf("bad cookies: %#v", cb.ForwardedValues.Cookies)
+	}
+
+	out := flattenCacheBehavior(cb)
+	for _, k := range []string{"allowed_methods", "cached_methods", "trusted_signers"} {
+		if !out[k].(*schema.Set).Equal(m[k]) {
+			t.Fatalf("bad %s: %#v", k, out[k])
+		}
+	}
+	fv := out["forwarded_values"].([]interface{})[0].(map[string]interface{})
+	if !fv["headers"].(*schema.Set).Equal(testCloudFrontStringSet("Host", "Origin")) {
+		t.Fatalf("bad headers: %#v", fv["headers"])
+	}
+	delete(out, "allowed_methods")
+	delete(out, "cached_methods")
+	delete(out, "trusted_signers")
+	delete(out, "forwarded_values")
+	for k, v := range out {
+		if !reflect.DeepEqual(m[k], v) {
+			t.Fatalf("bad %s: expected %#v, got %#v", k, m[k], v)
+		}
+	}
+}
+
+func TestCloudFrontStructure_defaultCacheBehavior(t *testing.T) {
+	m := testCloudFrontCacheBehavior()
+	delete(m, "path_pattern")
+
+	dcb := expandDefaultCacheBehavior(m)
+	if *dcb.TargetOriginId != "myS3Origin" || !*dcb.Compress {
+		t.Fatalf("bad default cache behavior: %#v", dcb)
+	}
+
+	out := flattenDefaultCacheBehavior(dcb)
+	if _, ok := out["path_pattern"]; ok {
+		t.Fatalf("default cache behavior has a path pattern: %#v", out)
+	}
+}
+
+func TestCloudFrontStructure_origin(t *testing.T) {
+	m := map[string]interface{}{
+		"domain_name": "www.example.com",
+		"origin_id":   "myCustomOrigin",
+		"origin_path": "/static",
+		"custom_header": schema.NewSet(customHeaderHash, []interface{}{
+			map[string]interface{}{"name": "X-Origin", "value": "terraform"},
+		}),
+		"custom_origin_config": []interface{}{
+			map[string]interface{}{
+				"http_port":              80,
+				"https_port":             443,
+				"origin_protocol_policy": "http-only",
+				"origin_ssl_protocols":   testCloudFrontStringSet("SSLv3", "TLSv1"),
+			},
+		},
+		"s3_origin_config": []interface{}{},
+	}
+
+	o := expandOrigin(m)
+	if *o.CustomHeaders.Quantity != 1 || *o.CustomHeaders.Items[0].HeaderName != "X-Origin" {
+		t.Fatalf("bad custom headers: %#v", o.CustomHeaders)
+	}
+	if o.S3OriginConfig != nil {
+		t.Fatalf("unexpected S3 origin config: %#v", o.S3OriginConfig)
+	}
+	if *o.CustomOriginConfig.OriginSslProtocols.Quantity != 2 {
+		t.Fatalf("bad custom origin config: %#v", o.CustomOriginConfig)
+	}
+
+	if originHash(flattenOrigin(o)) != originHash(m) {
+		t.Fatalf("flattened origin doesn't match: %#v", flattenOrigin(o))
+	}
+}
+
+func TestCloudFrontStructure_customErrorResponses(t *testing.T) {
+	s := schema.NewSet(customErrorResponseHash, []interface{}{
+		map[string]interface{}{
+			"error_caching_min_ttl": 300,
+			"error_code":            404,
+			"response_code":         200,
+			"response_page_path":    "/index.html",
+		},
+		map[string]interface{}{
+			"error_caching_min_ttl": 0,
+			"error_code":            503,
+			"response_code":         0,
+			"response_page_path":    "",
+		},
+	})
+
+	responses := expandCustomErrorResponses(s)
+	if *responses.Quantity != 2 {
+		t.Fatalf("bad quantity: %d", *responses.Quantity)
+	}
+
+	if out := flattenCustomErrorResponses(responses); !out.Equal(s) {
+		t.Fatalf("bad custom error responses: %#v", out.List())
+	}
+}
+
+func TestCloudFrontStructure_loggingConfig(t *testing.T) {
+	lc := expandLoggingConfig(nil)
+	if *lc.Enabled {
+		t.Fatalf("logging is enabled without configuration: %#v", lc)
+	}
+	if out := flattenLoggingConfig(lc); out != nil {
+		t.Fatalf("bad flattened logging config: %#v", out)
+	}
+
+	l := []interface{}{
+		map[string]interface{}{
+			"bucket":          "mylogs.s3.amazonaws.com",
+			"include_cookies": false,
+			"prefix":          "myprefix",
+		},
+	}
+	lc = expandLoggingConfig(l)
+	if !*lc.Enabled || *lc.Bucket != "mylogs.s3.amazonaws.com" {
+		t.Fatalf("bad logging config: %#v", lc)
+	}
+	if out := flattenLoggingConfig(lc); !reflect.DeepEqual(out, l) {
+		t.Fatalf("bad flattened logging config: %#v", out)
+	}
+}
+
+func TestCloudFrontStructure_viewerCertificate(t *testing.T) {
+	vc := expandViewerCertificate(map[string]interface{}{
+		"acm_certificate_arn":            "arn:aws:acm:us-east-1:123456789012:certificate/12345678-1234-1234-1234-123456789012",
+		"cloudfront_default_certificate": false,
+		"iam_certificate_id":             "",
+		"minimum_protocol_version":       "TLSv1",
+		"ssl_support_method":             "sni-only",
+	})
+	expected := &cloudfront.ViewerCertificate{
+		ACMCertificateArn:      aws.String("arn:aws:acm:us-east-1:123456789012:certificate/12345678-1234-1234-1234-123456789012"),
+		MinimumProtocolVersion: aws.String("TLSv1"),
+		SSLSupportMethod:       aws.String("sni-only"),
+	}
+	if !reflect.DeepEqual(vc, expected) {
+		t.Fatalf("bad viewer certificate: %#v", vc)
+	}
+
+	vc = expandViewerCertificate(map[string]interface{}{
+		"acm_certificate_arn":            "",
+		"cloudfront_default_certificate": true,
+		"iam_certificate_id":             "",
+		"minimum_protocol_version":       "",
+		"ssl_support_method":             "",
+	})
+	expected = &cloudfront.ViewerCertificate{
+		CloudFrontDefaultCertificate: aws.Bool(true),
+	}
+	if !reflect.DeepEqual(vc, expected) {
+		t.Fatalf("bad viewer certificate: %#v", vc)
+	}
+}
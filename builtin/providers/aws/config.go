@@ -19,11 +19,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/codecommit"
 	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/directoryservice"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -45,6 +48,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/waf"
 )
 
 type Config struct {
@@ -56,18 +61,35 @@ type Config struct {
 	Region        string
 	MaxRetries    int
 
+	// SkipMetadataApiCheck disables looking up credentials of the
+	// instance role through the EC2 metadata API.
+	SkipMetadataApiCheck bool
+
 	AllowedAccountIds   []interface{}
 	ForbiddenAccountIds []interface{}
 
 	DynamoDBEndpoint string
 	KinesisEndpoint  string
+
+	// Endpoints maps service names, as listed in endpointServices, to
+	// custom endpoint URLs to use instead of the ones constructed from
+	// the region.
+	Endpoints        map[string]string
+	S3ForcePathStyle bool
+
+	// DefaultTags are merged into the tags of every resource that
+	// supports tags.
+	DefaultTags map[string]string
 }
 
 type AWSClient struct {
 	cfconn             *cloudformation.CloudFormation
+	cloudfrontconn     *cloudfront.CloudFront
 	cloudtrailconn     *cloudtrail.CloudTrail
 	cloudwatchconn     *cloudwatch.CloudWatch
 	cloudwatchlogsconn *cloudwatchlogs.CloudWatchLogs
+	cweconn            *cloudwatchevents.CloudWatchEvents
+	configconn         *configservice.ConfigService
 	dsconn             *directoryservice.DirectoryService
 	dynamodbconn       *dynamodb.DynamoDB
 	ec2conn            *ec2.EC2
@@ -79,6 +101,7 @@ type AWSClient struct {
 	autoscalingconn    *autoscaling.AutoScaling
 	s3conn             *s3.S3
 	sqsconn            *sqs.SQS
+	ssmconn            *ssm.SSM
 	snsconn            *sns.SNS
 	redshiftconn       *redshift.Redshift
 	r53conn            *route53.Route53
@@ -93,6 +116,8 @@ type AWSClient struct {
 	glacierconn        *glacier.Glacier
 	codedeployconn     *codedeploy.CodeDeploy
 	codecommitconn     *codecommit.CodeCommit
+	wafconn            *waf.WAF
+	defaultTags        map[string]string
 }
 
 // Client configures and returns a fully initialized AWSClient
@@ -113,9 +138,11 @@ func (c *Config) Client() (interface{}, error) {
 		// store AWS region in client struct, for region specific operations such as
 		// bucket storage in S3
 		client.region = c.Region
+		client.defaultTags = c.DefaultTags
 
 		log.Println("[INFO] Building AWS auth structure")
-		creds := getCreds(c.AccessKey, c.SecretKey, c.Token, c.Profile, c.CredsFilename)
+		creds := getCreds(c.AccessKey, c.SecretKey, c.Token, c.Profile, c.CredsFilename,
+			c.SkipMetadataApiCheck)
 		// Call Get to check for credential provider. If nothing found, we'll get an
 		// error, and we can present it nicely to the user
 		_, err = creds.Get()
@@ -123,16 +150,20 @@ func (c *Config) Client() (interface{}, error) {
 			errs = append(errs, fmt.Errorf("Error loading credentials for AWS Provider: %s", err))
 			return nil, &multierror.Error{Errors: errs}
 		}
+		// All clients share the same retryer, which retries throttled
+		// requests and server errors with a jittered backoff
+		retryer := newAWSRetryer(c.MaxRetries)
+
 		awsConfig := &aws.Config{
 			Credentials: creds,
 			Region:      aws.String(c.Region),
 			MaxRetries:  aws.Int(c.MaxRetries),
+			Retryer:     retryer,
 			HTTPClient:  cleanhttp.DefaultClient(),
 		}
 
 		log.Println("[INFO] Initializing IAM Connection")
-		sess := session.New(awsConfig)
-		client.iamconn = iam.New(sess)
+		client.iamconn = iam.New(c.session("iam", awsConfig))
 
 		err = c.ValidateCredentials(client.iamconn)
 		if err != nil {
@@ -148,38 +179,35 @@ func (c *Config) Client() (interface{}, error) {
 			Credentials: creds,
 			Region:      aws.String("us-east-1"),
 			MaxRetries:  aws.Int(c.MaxRetries),
+			Retryer:     retryer,
 			HTTPClient:  cleanhttp.DefaultClient(),
 		}
-		usEast1Sess := session.New(usEast1AwsConfig)
-
-		awsDynamoDBConfig := *awsConfig
-		awsDynamoDBConfig.Endpoint = aws.String(c.DynamoDBEndpoint)
 
 		log.Println("[INFO] Initializing DynamoDB connection")
-		dynamoSess := session.New(&awsDynamoDBConfig)
-		client.dynamodbconn = dynamodb.New(dynamoSess)
+		client.dynamodbconn = dynamodb.New(c.session("dynamodb", awsConfig))
 
 		log.Println("[INFO] Initializing ELB connection")
-		client.elbconn = elb.New(sess)
+		client.elbconn = elb.New(c.session("elb", awsConfig))
 
 		log.Println("[INFO] Initializing S3 connection")
-		client.s3conn = s3.New(sess)
+		awsS3Config := *awsConfig
+		awsS3Config.S3ForcePathStyle = aws.Bool(c.S3ForcePathStyle)
+		client.s3conn = s3.New(c.session("s3", &awsS3Config))
 
 		log.Println("[INFO] Initializing SQS connection")
-		client.sqsconn = sqs.New(sess)
+		client.sqsconn = sqs.New(c.session("sqs", awsConfig))
+
+		log.Println("[INFO] Initializing SSM connection")
+		client.ssmconn = ssm.New(c.session("ssm", awsConfig))
 
 		log.Println("[INFO] Initializing SNS connection")
-		client.snsconn = sns.New(sess)
+		client.snsconn = sns.New(c.session("sns", awsConfig))
 
 		log.Println("[INFO] Initializing RDS Connection")
-		client.rdsconn = rds.New(sess)
-
-		awsKinesisConfig := *awsConfig
-		awsKinesisConfig.Endpoint = aws.String(c.KinesisEndpoint)
+		client.rdsconn = rds.New(c.session("rds", awsConfig))
 
 		log.Println("[INFO] Initializing Kinesis Connection")
-		kinesisSess := session.New(&awsKinesisConfig)
-		client.kinesisconn = kinesis.New(kinesisSess)
+		client.kinesisconn = kinesis.New(c.session("kinesis", awsConfig))
 
 		authErr := c.ValidateAccountId(client.iamconn)
 		if authErr != nil {
@@ -187,64 +215,76 @@ func (c *Config) Client() (interface{}, error) {
 		}
 
 		log.Println("[INFO] Initializing Kinesis Firehose Connection")
-		client.firehoseconn = firehose.New(sess)
+		client.firehoseconn = firehose.New(c.session("firehose", awsConfig))
 
 		log.Println("[INFO] Initializing AutoScaling connection")
-		client.autoscalingconn = autoscaling.New(sess)
+		client.autoscalingconn = autoscaling.New(c.session("autoscaling", awsConfig))
 
 		log.Println("[INFO] Initializing EC2 Connection")
-		client.ec2conn = ec2.New(sess)
+		client.ec2conn = ec2.New(c.session("ec2", awsConfig))
 
 		log.Println("[INFO] Initializing ECR Connection")
-		client.ecrconn = ecr.New(sess)
+		client.ecrconn = ecr.New(c.session("ecr", awsConfig))
 
 		log.Println("[INFO] Initializing ECS Connection")
-		client.ecsconn = ecs.New(sess)
+		client.ecsconn = ecs.New(c.session("ecs", awsConfig))
 
 		log.Println("[INFO] Initializing EFS Connection")
-		client.efsconn = efs.New(sess)
+		client.efsconn = efs.New(c.session("efs", awsConfig))
 
 		log.Println("[INFO] Initializing ElasticSearch Connection")
-		client.esconn = elasticsearch.New(sess)
+		client.esconn = elasticsearch.New(c.session("elasticsearch", awsConfig))
 
 		log.Println("[INFO] Initializing Route 53 connection")
-		client.r53conn = route53.New(usEast1Sess)
+		client.r53conn = route53.New(c.session("route53", usEast1AwsConfig))
 
 		log.Println("[INFO] Initializing Elasticache Connection")
-		client.elasticacheconn = elasticache.New(sess)
+		client.elasticacheconn = elasticache.New(c.session("elasticache", awsConfig))
 
 		log.Println("[INFO] Initializing Lambda Connection")
-		client.lambdaconn = lambda.New(sess)
+		client.lambdaconn = lambda.New(c.session("lambda", awsConfig))
 
 		log.Println("[INFO] Initializing Cloudformation Connection")
-		client.cfconn = cloudformation.New(sess)
+		client.cfconn = cloudformation.New(c.session("cloudformation", awsConfig))
+
+		log.Println("[INFO] Initializing CloudFront connection")
+		client.cloudfrontconn = cloudfront.New(c.session("cloudfront", usEast1AwsConfig))
 
 		log.Println("[INFO] Initializing CloudWatch SDK connection")
-		client.cloudwatchconn = cloudwatch.New(sess)
+		client.cloudwatchconn = cloudwatch.New(c.session("cloudwatch", awsConfig))
 
 		log.Println("[INFO] Initializing CloudTrail connection")
-		client.cloudtrailconn = cloudtrail.New(sess)
+		client.cloudtrailconn = cloudtrail.New(c.session("cloudtrail", awsConfig))
+
+		log.Println("[INFO] Initializing CloudWatch Events connection")
+		client.cweconn = cloudwatchevents.New(c.session("cloudwatchevents", awsConfig))
 
 		log.Println("[INFO] Initializing CloudWatch Logs connection")
-		client.cloudwatchlogsconn = cloudwatchlogs.New(sess)
+		client.cloudwatchlogsconn = cloudwatchlogs.New(c.session("cloudwatchlogs", awsConfig))
 
 		log.Println("[INFO] Initializing OpsWorks Connection")
-		client.opsworksconn = opsworks.New(usEast1Sess)
+		client.opsworksconn = opsworks.New(c.session("opsworks", usEast1AwsConfig))
 
 		log.Println("[INFO] Initializing Directory Service connection")
-		client.dsconn = directoryservice.New(sess)
+		client.dsconn = directoryservice.New(c.session("directoryservice", awsConfig))
 
 		log.Println("[INFO] Initializing Glacier connection")
-		client.glacierconn = glacier.New(sess)
+		client.glacierconn = glacier.New(c.session("glacier", awsConfig))
 
 		log.Println("[INFO] Initializing CodeDeploy Connection")
-		client.codedeployconn = codedeploy.New(sess)
+		client.codedeployconn = codedeploy.New(c.session("codedeploy", awsConfig))
+
+		log.Println("[INFO] Initializing Config connection")
+		client.configconn = configservice.New(c.session("configservice", awsConfig))
 
 		log.Println("[INFO] Initializing CodeCommit SDK connection")
-		client.codecommitconn = codecommit.New(usEast1Sess)
+		client.codecommitconn = codecommit.New(c.session("codecommit", usEast1AwsConfig))
 
 		log.Println("[INFO] Initializing Redshift SDK connection")
-		client.redshiftconn = redshift.New(sess)
+		client.redshiftconn = redshift.New(c.session("redshift", awsConfig))
+
+		log.Println("[INFO] Initializing WAF connection")
+		client.wafconn = waf.New(c.session("waf", usEast1AwsConfig))
 
 	}
 
@@ -255,6 +295,19 @@ func (c *Config) Client() (interface{}, error) {
 	return &client, nil
 }
 
+// session returns a new session for the given service, using the custom
+// endpoint configured for it, if any.
+func (c *Config) session(service string, config *aws.Config) *session.Session {
+	if endpoint := c.Endpoints[service]; endpoint != "" {
+		log.Printf("[INFO] Using custom endpoint for %s: %s", service, endpoint)
+		copied := *config
+		copied.Endpoint = aws.String(endpoint)
+		config = &copied
+	}
+
+	return session.New(config)
+}
+
 // ValidateRegion returns an error if the configured region is not a
 // valid aws region and nil otherwise.
 func (c *Config) ValidateRegion() error {
@@ -343,7 +396,12 @@ func (c *Config) ValidateAccountId(iamconn *iam.IAM) error {
 // This function is responsible for reading credentials from the
 // environment in the case that they're not explicitly specified
 // in the Terraform configuration.
-func getCreds(key, secret, token, profile, credsfile string) *awsCredentials.Credentials {
+//
+// Credentials are looked up in order from the static keys, the environment,
+// the shared credentials file and finally the EC2 instance role, unless
+// skipMetadataApiCheck is set.
+func getCreds(key, secret, token, profile, credsfile string,
+	skipMetadataApiCheck bool) *awsCredentials.Credentials {
 	// build a chain provider, lazy-evaulated by aws-sdk
 	providers := []awsCredentials.Provider{
 		&awsCredentials.StaticProvider{Value: awsCredentials.Value{
@@ -358,6 +416,11 @@ func getCreds(key, secret, token, profile, credsfile string) *awsCredentials.Cre
 		},
 	}
 
+	if skipMetadataApiCheck {
+		log.Printf("[DEBUG] Skipping EC2 Metadata API check, not adding EC2 Role Credential Provider")
+		return awsCredentials.NewChainCredentials(providers)
+	}
+
 	// We only look in the EC2 metadata API if we can connect
 	// to the metadata service within a reasonable amount of time
 	metadataURL := os.Getenv("AWS_METADATA_URL")
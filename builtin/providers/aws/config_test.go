@@ -9,6 +9,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 )
 
@@ -17,7 +18,7 @@ func TestAWSConfig_shouldError(t *testing.T) {
 	defer resetEnv()
 	cfg := Config{}
 
-	c := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename)
+	c := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename, false)
 	_, err := c.Get()
 	if awsErr, ok := err.(awserr.Error); ok {
 		if awsErr.Code() != "NoCredentialProviders" {
@@ -50,7 +51,7 @@ func TestAWSConfig_shouldBeStatic(t *testing.T) {
 			Token:     c.Token,
 		}
 
-		creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename)
+		creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename, false)
 		if creds == nil {
 			t.Fatalf("Expected a static creds provider to be returned")
 		}
@@ -85,7 +86,7 @@ func TestAWSConfig_shouldIAM(t *testing.T) {
 	// An empty config, no key supplied
 	cfg := Config{}
 
-	creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename)
+	creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename, false)
 	if creds == nil {
 		t.Fatalf("Expected a static creds provider to be returned")
 	}
@@ -105,6 +106,32 @@ func TestAWSConfig_shouldIAM(t *testing.T) {
 	}
 }
 
+// TestAWSConfig_shouldSkipMetadata verifies the EC2 instance role isn't used
+// when the metadata API check is skipped, even when running on EC2.
+func TestAWSConfig_shouldSkipMetadata(t *testing.T) {
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+	// capture the test server's close method, to call after the test returns
+	ts := awsEnv(t)
+	defer ts()
+
+	// An empty config, no key supplied
+	cfg := Config{
+		SkipMetadataApiCheck: true,
+	}
+
+	creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename,
+		cfg.SkipMetadataApiCheck)
+	if creds == nil {
+		t.Fatalf("Expected a static creds provider to be returned")
+	}
+
+	v, err := creds.Get()
+	if err == nil {
+		t.Fatalf("Expected an error getting creds, got: %#v", v)
+	}
+}
+
 // TestAWSConfig_shouldIAM is designed to test the scenario of running Terraform
 // from an EC2 instance, without environment variables or manually supplied
 // credentials.
@@ -134,7 +161,7 @@ func TestAWSConfig_shouldIgnoreIAM(t *testing.T) {
 			Token:     c.Token,
 		}
 
-		creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename)
+		creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename, false)
 		if creds == nil {
 			t.Fatalf("Expected a static creds provider to be returned")
 		}
@@ -185,7 +212,7 @@ func TestAWSConfig_shouldBeShared(t *testing.T) {
 		t.Fatalf("Error resetting env var AWS_SHARED_CREDENTIALS_FILE: %s", err)
 	}
 
-	creds := getCreds("", "", "", "myprofile", file.Name())
+	creds := getCreds("", "", "", "myprofile", file.Name(), false)
 	if creds == nil {
 		t.Fatalf("Expected a provider chain to be returned")
 	}
@@ -212,7 +239,7 @@ func TestAWSConfig_shouldBeENV(t *testing.T) {
 	defer resetEnv()
 
 	cfg := Config{}
-	creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename)
+	creds := getCreds(cfg.AccessKey, cfg.SecretKey, cfg.Token, cfg.Profile, cfg.CredsFilename, false)
 	if creds == nil {
 		t.Fatalf("Expected a static creds provider to be returned")
 	}
@@ -374,3 +401,29 @@ const aws_routes = `
   ]
 }
 `
+
+func TestAWSConfig_session(t *testing.T) {
+	c := &Config{
+		Endpoints: map[string]string{
+			"dynamodb": "http://localhost:8000",
+		},
+	}
+
+	base := &aws.Config{
+		Region: aws.String("us-west-2"),
+	}
+
+	sess := c.session("dynamodb", base)
+	if v := aws.StringValue(sess.Config.Endpoint); v != "http://localhost:8000" {
+		t.Fatalf("bad endpoint: %s", v)
+	}
+
+	sess = c.session("ec2", base)
+	if v := aws.StringValue(sess.Config.Endpoint); v != "" {
+		t.Fatalf("bad endpoint: %s", v)
+	}
+
+	if base.Endpoint != nil {
+		t.Fatalf("base config should not be modified: %s", *base.Endpoint)
+	}
+}
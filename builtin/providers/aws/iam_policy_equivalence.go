@@ -0,0 +1,206 @@
+package aws
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+// awsAccountIDRegexp matches a bare AWS account ID, which AWS expands to
+// the ARN of the root user of the account when used as a principal.
+var awsAccountIDRegexp = regexp.MustCompile(`^\d{12}$`)
+
+// suppressEquivalentAwsPolicyDiffs is a DiffSuppressFunc for attributes
+// that hold an IAM policy document. AWS returns policies in a normalized
+// form, so a diff is only shown if the policies aren't equivalent.
+func suppressEquivalentAwsPolicyDiffs(k, old, new string, d *schema.ResourceData) bool {
+	equivalent, err := iamPolicyEquivalent(old, new)
+	if err != nil {
+		return false
+	}
+
+	return equivalent
+}
+
+// iamPolicyEquivalent returns true if the two given IAM policy documents
+// are equivalent. Besides formatting and the order of keys, this ignores
+// differences AWS doesn't care about, such as a single value versus an
+// array with only that value, the order of actions and resources, and an
+// account ID versus the ARN of the root user of that account.
+func iamPolicyEquivalent(policy1, policy2 string) (bool, error) {
+	if policy1 == policy2 {
+		return true, nil
+	}
+
+	var raw1, raw2 interface{}
+	if err := json.Unmarshal([]byte(policy1), &raw1); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(policy2), &raw2); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(normalizeIamPolicy(raw1), normalizeIamPolicy(raw2)), nil
+}
+
+// normalizeIamPolicy returns the normalized form of a decoded policy
+// document, where every value that can be either a string or an array is
+// a sorted array.
+func normalizeIamPolicy(raw interface{}) interface{} {
+	policy, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+
+	result := make(map[string]interface{}, len(policy))
+	for k, v := range policy {
+		result[k] = v
+	}
+
+	// A single statement doesn't have to be wrapped in an array
+	var statements []interface{}
+	switch s := policy["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	}
+
+	if statements != nil {
+		normalized := make([]interface{}, len(statements))
+		for i, s := range statements {
+			normalized[i] = normalizeIamPolicyStatement(s)
+		}
+		result["Statement"] = normalized
+	}
+
+	return result
+}
+
+func normalizeIamPolicyStatement(raw interface{}) interface{} {
+	statement, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+
+	result := make(map[string]interface{}, len(statement))
+	for k, v := range statement {
+		switch k {
+		case "Sid":
+			// An empty statement ID is the same as none at all
+			if v == "" {
+				continue
+			}
+			result[k] = v
+		case "Action", "NotAction", "Resource", "NotResource":
+			result[k] = normalizeIamPolicyValues(v)
+		case "Principal", "NotPrincipal":
+			result[k] = normalizeIamPolicyPrincipal(v)
+		case "Condition":
+			result[k] = normalizeIamPolicyCondition(v)
+		default:
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// normalizeIamPolicyPrincipal normalizes a principal, where "*" is the
+// same as {"AWS": "*"}.
+func normalizeIamPolicyPrincipal(raw interface{}) interface{} {
+	if raw == "*" {
+		raw = map[string]interface{}{"AWS": "*"}
+	}
+
+	principals, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+
+	result := make(map[string]interface{}, len(principals))
+	for k, v := range principals {
+		values := normalizeIamPolicyValues(v)
+		if k == "AWS" {
+			if l, ok := values.([]interface{}); ok {
+				for i, p := range l {
+					if s, ok := p.(string); ok && awsAccountIDRegexp.MatchString(s) {
+						l[i] = "arn:aws:iam::" + s + ":root"
+					}
+				}
+				values = sortIamPolicyValues(l)
+			}
+		}
+
+		result[k] = values
+	}
+
+	return result
+}
+
+func normalizeIamPolicyCondition(raw interface{}) interface{} {
+	conditions, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+
+	result := make(map[string]interface{}, len(conditions))
+	for op, c := range conditions {
+		keys, ok := c.(map[string]interface{})
+		if !ok {
+			result[op] = c
+			continue
+		}
+
+		normalized := make(map[string]interface{}, len(keys))
+		for k, v := range keys {
+			normalized[k] = normalizeIamPolicyValues(v)
+		}
+		result[op] = normalized
+	}
+
+	return result
+}
+
+// normalizeIamPolicyValues turns a single value into an array, and sorts
+// and deduplicates an array of strings.
+func normalizeIamPolicyValues(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		copy(l, v)
+		return sortIamPolicyValues(l)
+	case map[string]interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+// sortIamPolicyValues sorts and deduplicates the given values if they are
+// all strings, and returns them unchanged otherwise.
+func sortIamPolicyValues(values []interface{}) []interface{} {
+	strs := make([]string, 0, len(values))
+	seen := make(map[string]bool)
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return values
+		}
+		if !seen[s] {
+			seen[s] = true
+			strs = append(strs, s)
+		}
+	}
+	sort.Strings(strs)
+
+	result := make([]interface{}, len(strs))
+	for i, s := range strs {
+		result[i] = s
+	}
+
+	return result
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestIamPolicyEquivalent(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Policy1    string
+		Policy2    string
+		Equivalent bool
+		Err        bool
+	}{
+		{
+			Name:       "identical",
+			Policy1:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,
+			Policy2:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name: "whitespace and key order",
+			Policy1: `{
+  "Statement": [{"Resource": "*", "Action": "s3:*", "Effect": "Allow"}],
+  "Version": "2012-10-17"
+}`,
+			Policy2:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "single statement object",
+			Policy1:    `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:*","Resource":"*"}}`,
+			Policy2:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "string versus array",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["*"]}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "action order",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Action":["s3:PutObject","s3:GetObject"],"Resource":"*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":"*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "wildcard principal",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"es:*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"es:*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "account ID principal",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"123456789012"},"Action":"es:*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root"]},"Action":"es:*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "empty sid",
+			Policy1:    `{"Statement":[{"Sid":"","Effect":"Allow","Action":"es:*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Action":"es:*"}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "condition values",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Action":"es:*","Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Action":"es:*","Condition":{"IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}}]}`,
+			Equivalent: true,
+		},
+		{
+			Name:       "different action",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Action":"s3:PutObject","Resource":"*"}]}`,
+			Equivalent: false,
+		},
+		{
+			Name:       "different principal",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"123456789012"},"Action":"es:*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"210987654321"},"Action":"es:*"}]}`,
+			Equivalent: false,
+		},
+		{
+			Name:       "statement order",
+			Policy1:    `{"Statement":[{"Effect":"Allow","Action":"a:*"},{"Effect":"Deny","Action":"b:*"}]}`,
+			Policy2:    `{"Statement":[{"Effect":"Deny","Action":"b:*"},{"Effect":"Allow","Action":"a:*"}]}`,
+			Equivalent: false,
+		},
+		{
+			Name:    "invalid JSON",
+			Policy1: `{"Statement":`,
+			Policy2: `{}`,
+			Err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		equivalent, err := iamPolicyEquivalent(tc.Policy1, tc.Policy2)
+		if err != nil != tc.Err {
+			t.Fatalf("%s: err: %s", tc.Name, err)
+		}
+		if equivalent != tc.Equivalent {
+			t.Fatalf("%s: expected equivalent to be %t", tc.Name, tc.Equivalent)
+		}
+	}
+}
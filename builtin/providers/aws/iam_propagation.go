@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+// iamPropagationTimeout is how long requests failing because of IAM
+// changes that haven't propagated yet are retried. Propagation usually
+// takes about 10 seconds, but can take much longer, see
+// http://docs.aws.amazon.com/IAM/latest/UserGuide/troubleshoot_general.html#troubleshoot_general_eventual-consistency
+var iamPropagationTimeout = 2 * time.Minute
+
+// iamPropagationErrors are the errors returned by services that are
+// handed an IAM role, instance profile or principal that was created so
+// recently they can't see it yet. The error is matched by its code and a
+// part of its message, since most of the codes are also used for genuine
+// mistakes in the configuration.
+var iamPropagationErrors = []struct {
+	Code    string
+	Message string
+}{
+	// Lambda: The role defined for the function cannot be assumed by Lambda.
+	{"InvalidParameterValueException", "cannot be assumed"},
+
+	// Lambda: The provided execution role does not have permissions to
+	// call CreateNetworkInterface on EC2
+	{"InvalidParameterValueException", "does not have permissions to call CreateNetworkInterface"},
+
+	// EC2: Value (...) for parameter iamInstanceProfile.name is invalid.
+	// Invalid IAM Instance Profile name
+	{"InvalidParameterValue", "Invalid IAM Instance Profile"},
+
+	// Auto Scaling: Invalid IamInstanceProfile
+	{"ValidationError", "Invalid IamInstanceProfile"},
+
+	// IAM, SNS, SQS: Invalid principal in policy
+	{"MalformedPolicyDocument", "Invalid principal"},
+
+	// Elasticsearch: Error setting policy
+	{"InvalidTypeException", "Error setting policy"},
+}
+
+// isIAMPropagationError returns true if the error is caused by an IAM
+// change that hasn't propagated to the service yet.
+func isIAMPropagationError(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	for _, e := range iamPropagationErrors {
+		if awsErr.Code() == e.Code && strings.Contains(awsErr.Message(), e.Message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryOnIAMPropagation calls f until it no longer fails with an error
+// caused by an IAM change that hasn't propagated yet. Any other error is
+// returned right away. Retrying stops when the cancel channel is closed.
+//
+// If the changes still haven't propagated when the time is up, the last
+// error is returned, since it tells more than the timeout itself.
+func retryOnIAMPropagation(cancel <-chan struct{}, f func() error) error {
+	var lastErr error
+	var lastErrLock sync.Mutex
+
+	err := resource.RetryWithCancel(iamPropagationTimeout, cancel, func() error {
+		err := f()
+
+		lastErrLock.Lock()
+		defer lastErrLock.Unlock()
+		lastErr = nil
+
+		if err == nil {
+			return nil
+		}
+
+		if isIAMPropagationError(err) {
+			log.Printf("[DEBUG] Waiting for IAM changes to propagate: %s", err)
+			lastErr = err
+			return err
+		}
+
+		return resource.RetryError{Err: err}
+	})
+
+	lastErrLock.Lock()
+	defer lastErrLock.Unlock()
+	if err != nil && err != resource.ErrCancelled && lastErr != nil {
+		return lastErr
+	}
+
+	return err
+}
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+func TestIsIAMPropagationError(t *testing.T) {
+	cases := []struct {
+		Err      error
+		Expected bool
+	}{
+		{
+			awserr.New("InvalidParameterValueException",
+				"The role defined for the function cannot be assumed by Lambda.", nil),
+			true,
+		},
+		{
+			awserr.New("InvalidParameterValue",
+				"Value (foo) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", nil),
+			true,
+		},
+		{
+			awserr.New("InvalidParameterValueException",
+				"The provided execution role does not have permissions to call CreateNetworkInterface on EC2", nil),
+			true,
+		},
+		{awserr.New("ValidationError", "Invalid IamInstanceProfile: foo", nil), true},
+		{awserr.New("MalformedPolicyDocument", "Invalid principal in policy", nil), true},
+		{awserr.New("InvalidTypeException", "Error setting policy: [...]", nil), true},
+		{awserr.New("InvalidParameterValueException", "Unsupported runtime", nil), false},
+		{awserr.New("MalformedPolicyDocument", "Syntax errors in policy", nil), false},
+		{fmt.Errorf("Invalid IAM Instance Profile"), false},
+	}
+
+	for i, tc := range cases {
+		if actual := isIAMPropagationError(tc.Err); actual != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestRetryOnIAMPropagation(t *testing.T) {
+	calls := 0
+	err := retryOnIAMPropagation(nil, func() error {
+		calls++
+		if calls < 2 {
+			return awserr.New("MalformedPolicyDocument", "Invalid principal in policy", nil)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+
+	expected := awserr.New("AccessDenied", "", nil)
+	calls = 0
+	err = retryOnIAMPropagation(nil, func() error {
+		calls++
+		return expected
+	})
+	if err != expected {
+		t.Fatalf("bad: %#v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryOnIAMPropagation_timeout(t *testing.T) {
+	defer func(d time.Duration) {
+		iamPropagationTimeout = d
+	}(iamPropagationTimeout)
+	iamPropagationTimeout = 1 * time.Second
+
+	expected := awserr.New("MalformedPolicyDocument", "Invalid principal in policy", nil)
+	err := retryOnIAMPropagation(nil, func() error {
+		return expected
+	})
+	if err != expected {
+		t.Fatalf("bad: %#v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/xanzy/terraform-api/helper/hashcode"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -97,6 +98,13 @@ func (lt *opsworksLayerType) SchemaResource() *schema.Resource {
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 
+		"custom_json": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			StateFunc:    normalizeJson,
+			ValidateFunc: validation.JSONString,
+		},
+
 		"custom_security_group_ids": &schema.Schema{
 			Type:     schema.TypeSet,
 			Optional: true,
@@ -214,14 +222,7 @@ func (lt *opsworksLayerType) SchemaResource() *schema.Resource {
 		}
 	}
 
-	for key, def := range lt.Attributes {
-		resourceSchema[key] = &schema.Schema{
-			Type:     def.Type,
-			Default:  def.Default,
-			Required: def.Required,
-			Optional: !def.Required,
-		}
-	}
+	opsworksAttributesSchema(resourceSchema, lt.Attributes)
 
 	return &schema.Resource{
 		Read: func(d *schema.ResourceData, meta interface{}) error {
@@ -271,6 +272,11 @@ func (lt *opsworksLayerType) Read(d *schema.ResourceData, client *opsworks.OpsWo
 	d.Set("auto_assign_elastic_ips", layer.AutoAssignElasticIps)
 	d.Set("auto_assign_public_ips", layer.AutoAssignPublicIps)
 	d.Set("custom_instance_profile_arn", layer.CustomInstanceProfileArn)
+	if layer.CustomJson != nil && *layer.CustomJson != "" {
+		d.Set("custom_json", normalizeJson(*layer.CustomJson))
+	} else {
+		d.Set("custom_json", "")
+	}
 	d.Set("custom_security_group_ids", unwrapAwsStringList(layer.CustomSecurityGroupIds))
 	d.Set("auto_healing", layer.EnableAutoHealing)
 	d.Set("install_updates_on_boot", layer.InstallUpdatesOnBoot)
@@ -317,6 +323,10 @@ func (lt *opsworksLayerType) Create(d *schema.ResourceData, client *opsworks.Ops
 		req.Shortname = aws.String(lt.TypeName)
 	}
 
+	if v, ok := d.GetOk("custom_json"); ok {
+		req.CustomJson = aws.String(normalizeJson(v.(string)))
+	}
+
 	log.Printf("[DEBUG] Creating OpsWorks layer: %s", d.Id())
 
 	resp, err := client.CreateLayer(req)
@@ -356,6 +366,10 @@ func (lt *opsworksLayerType) Update(d *schema.ResourceData, client *opsworks.Ops
 		req.Shortname = aws.String(lt.TypeName)
 	}
 
+	if d.HasChange("custom_json") {
+		req.CustomJson = aws.String(normalizeJson(d.Get("custom_json").(string)))
+	}
+
 	log.Printf("[DEBUG] Updating OpsWorks layer: %s", d.Id())
 
 	_, err := client.UpdateLayer(req)
@@ -378,9 +392,32 @@ func (lt *opsworksLayerType) Delete(d *schema.ResourceData, client *opsworks.Ops
 }
 
 func (lt *opsworksLayerType) AttributeMap(d *schema.ResourceData) map[string]*string {
+	return opsworksAttributeMap(d, lt.Attributes)
+}
+
+func (lt *opsworksLayerType) SetAttributeMap(d *schema.ResourceData, attrs map[string]*string) {
+	opsworksSetAttributeMap(d, lt.Attributes, attrs)
+}
+
+// The layers and the applications both pack some of their settings into an
+// "Attributes" map, which are exposed as first-class arguments. The following
+// functions are shared between them to convert those arguments.
+
+func opsworksAttributesSchema(resourceSchema map[string]*schema.Schema, attributes map[string]*opsworksLayerTypeAttribute) {
+	for key, def := range attributes {
+		resourceSchema[key] = &schema.Schema{
+			Type:     def.Type,
+			Default:  def.Default,
+			Required: def.Required,
+			Optional: !def.Required,
+		}
+	}
+}
+
+func opsworksAttributeMap(d *schema.ResourceData, attributes map[string]*opsworksLayerTypeAttribute) map[string]*string {
 	attrs := map[string]*string{}
 
-	for key, def := range lt.Attributes {
+	for key, def := range attributes {
 		value := d.Get(key)
 		switch def.Type {
 		case schema.TypeString:
@@ -399,49 +436,48 @@ func (lt *opsworksLayerType) AttributeMap(d *schema.ResourceData) map[string]*st
 			}
 		default:
 			// should never happen
-			panic(fmt.Errorf("Unsupported OpsWorks layer attribute type"))
+			panic(fmt.Errorf("Unsupported OpsWorks attribute type"))
 		}
 	}
 
 	return attrs
 }
 
-func (lt *opsworksLayerType) SetAttributeMap(d *schema.ResourceData, attrs map[string]*string) {
-	for key, def := range lt.Attributes {
+func opsworksSetAttributeMap(d *schema.ResourceData, attributes map[string]*opsworksLayerTypeAttribute, attrs map[string]*string) {
+	for key, def := range attributes {
 		// Ignore write-only attributes; we'll just keep what we already have stored.
 		// (The AWS API returns garbage placeholder values for these.)
 		if def.WriteOnly {
 			continue
 		}
 
-		if strPtr, ok := attrs[def.AttrName]; ok && strPtr != nil {
-			strValue := *strPtr
-
-			switch def.Type {
-			case schema.TypeString:
-				d.Set(key, strValue)
-			case schema.TypeInt:
-				intValue, err := strconv.Atoi(strValue)
-				if err == nil {
-					d.Set(key, intValue)
-				} else {
-					// Got garbage from the AWS API
-					d.Set(key, nil)
-				}
-			case schema.TypeBool:
-				boolValue := true
-				if strValue == opsworksFalseString {
-					boolValue = false
-				}
-				d.Set(key, boolValue)
-			default:
-				// should never happen
-				panic(fmt.Errorf("Unsupported OpsWorks layer attribute type"))
-			}
-			return
-
-		} else {
+		strPtr, ok := attrs[def.AttrName]
+		if !ok || strPtr == nil {
 			d.Set(key, nil)
+			continue
+		}
+
+		strValue := *strPtr
+		switch def.Type {
+		case schema.TypeString:
+			d.Set(key, strValue)
+		case schema.TypeInt:
+			intValue, err := strconv.Atoi(strValue)
+			if err == nil {
+				d.Set(key, intValue)
+			} else {
+				// Got garbage from the AWS API
+				d.Set(key, nil)
+			}
+		case schema.TypeBool:
+			boolValue := true
+			if strValue == opsworksFalseString {
+				boolValue = false
+			}
+			d.Set(key, boolValue)
+		default:
+			// should never happen
+			panic(fmt.Errorf("Unsupported OpsWorks attribute type"))
 		}
 	}
 }
@@ -495,7 +531,14 @@ func (lt *opsworksLayerType) SetCustomRecipes(d *schema.ResourceData, v *opswork
 }
 
 func (lt *opsworksLayerType) VolumeConfigurations(d *schema.ResourceData) []*opsworks.VolumeConfiguration {
-	configuredVolumes := d.Get("ebs_volume").(*schema.Set).List()
+	return expandOpsworksVolumeConfigurations(d.Get("ebs_volume").(*schema.Set).List())
+}
+
+func (lt *opsworksLayerType) SetVolumeConfigurations(d *schema.ResourceData, v []*opsworks.VolumeConfiguration) {
+	d.Set("ebs_volume", flattenOpsworksVolumeConfigurations(v))
+}
+
+func expandOpsworksVolumeConfigurations(configuredVolumes []interface{}) []*opsworks.VolumeConfiguration {
 	result := make([]*opsworks.VolumeConfiguration, len(configuredVolumes))
 
 	for i := 0; i < len(configuredVolumes); i++ {
@@ -524,13 +567,13 @@ func (lt *opsworksLayerType) VolumeConfigurations(d *schema.ResourceData) []*ops
 	return result
 }
 
-func (lt *opsworksLayerType) SetVolumeConfigurations(d *schema.ResourceData, v []*opsworks.VolumeConfiguration) {
-	newValue := make([]*map[string]interface{}, len(v))
+func flattenOpsworksVolumeConfigurations(v []*opsworks.VolumeConfiguration) []map[string]interface{} {
+	newValue := make([]map[string]interface{}, len(v))
 
 	for i := 0; i < len(v); i++ {
 		config := v[i]
 		data := make(map[string]interface{})
-		newValue[i] = &data
+		newValue[i] = data
 
 		if config.Iops != nil {
 			data["iops"] = int(*config.Iops)
@@ -554,5 +597,5 @@ func (lt *opsworksLayerType) SetVolumeConfigurations(d *schema.ResourceData, v [
 		}
 	}
 
-	d.Set("ebs_volume", newValue)
+	return newValue
 }
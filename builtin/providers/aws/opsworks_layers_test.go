@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/opsworks"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func TestOpsworksLayerSchemas(t *testing.T) {
+	shared := []string{
+		"custom_json",
+		"auto_healing",
+		"ebs_volume",
+		"instance_shutdown_timeout",
+		"drain_elb_on_shutdown",
+	}
+
+	for name, r := range Provider().(*schema.Provider).ResourcesMap {
+		if !strings.HasPrefix(name, "aws_opsworks_") || !strings.HasSuffix(name, "_layer") {
+			continue
+		}
+
+		for _, k := range shared {
+			if _, ok := r.Schema[k]; !ok {
+				t.Errorf("%s: missing argument %s", name, k)
+			}
+		}
+	}
+}
+
+func TestExpandOpsworksVolumeConfigurations(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"mount_point":     "/home",
+			"number_of_disks": 2,
+			"size":            100,
+			"type":            "gp2",
+			"iops":            0,
+			"raid_level":      "",
+		},
+		map[string]interface{}{
+			"mount_point":     "/var",
+			"number_of_disks": 4,
+			"size":            100,
+			"type":            "io1",
+			"iops":            3000,
+			"raid_level":      "1",
+		},
+	}
+
+	expected := []*opsworks.VolumeConfiguration{
+		&opsworks.VolumeConfiguration{
+			MountPoint:    aws.String("/home"),
+			NumberOfDisks: aws.Int64(2),
+			Size:          aws.Int64(100),
+			VolumeType:    aws.String("gp2"),
+		},
+		&opsworks.VolumeConfiguration{
+			MountPoint:    aws.String("/var"),
+			NumberOfDisks: aws.Int64(4),
+			Size:          aws.Int64(100),
+			VolumeType:    aws.String("io1"),
+			Iops:          aws.Int64(3000),
+			RaidLevel:     aws.Int64(1),
+		},
+	}
+
+	result := expandOpsworksVolumeConfigurations(configured)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("bad:\n\n%#v\n\nexpected:\n\n%#v", result, expected)
+	}
+}
+
+func TestFlattenOpsworksVolumeConfigurations(t *testing.T) {
+	volumes := []*opsworks.VolumeConfiguration{
+		&opsworks.VolumeConfiguration{
+			MountPoint:    aws.String("/var"),
+			NumberOfDisks: aws.Int64(4),
+			Size:          aws.Int64(100),
+			VolumeType:    aws.String("io1"),
+			Iops:          aws.Int64(3000),
+			RaidLevel:     aws.Int64(1),
+		},
+	}
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{
+			"mount_point":     "/var",
+			"number_of_disks": 4,
+			"size":            100,
+			"type":            "io1",
+			"iops":            3000,
+			"raid_level":      "1",
+		},
+	}
+
+	result := flattenOpsworksVolumeConfigurations(volumes)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("bad:\n\n%#v\n\nexpected:\n\n%#v", result, expected)
+	}
+}
@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/xanzy/terraform-api/helper/hashcode"
 	"github.com/xanzy/terraform-api/helper/mutexkv"
 	"github.com/xanzy/terraform-api/helper/schema"
@@ -13,26 +16,35 @@ func Provider() terraform.ResourceProvider {
 	// TODO: Move the configuration to this, requires validation
 
 	// The actual provider
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"AWS_ACCESS_KEY_ID",
+					"AWS_ACCESS_KEY",
+				}, ""),
 				Description: descriptions["access_key"],
 			},
 
 			"secret_key": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"AWS_SECRET_ACCESS_KEY",
+					"AWS_SECRET_KEY",
+				}, ""),
 				Description: descriptions["secret_key"],
 			},
 
 			"profile": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"AWS_PROFILE",
+					"AWS_DEFAULT_PROFILE",
+				}, ""),
 				Description: descriptions["profile"],
 			},
 
@@ -44,12 +56,22 @@ func Provider() terraform.ResourceProvider {
 			},
 
 			"token": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"AWS_SESSION_TOKEN",
+					"AWS_SECURITY_TOKEN",
+				}, ""),
 				Description: descriptions["token"],
 			},
 
+			"skip_metadata_api_check": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_metadata_api_check"],
+			},
+
 			"region": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -93,6 +115,7 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				Default:     "",
 				Description: descriptions["dynamodb_endpoint"],
+				Deprecated:  "Use the dynamodb argument of the endpoints block instead",
 			},
 
 			"kinesis_endpoint": &schema.Schema{
@@ -100,127 +123,169 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				Default:     "",
 				Description: descriptions["kinesis_endpoint"],
+				Deprecated:  "Use the kinesis argument of the endpoints block instead",
+			},
+
+			"endpoints": endpointsSchema(),
+
+			"s3_force_path_style": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["s3_force_path_style"],
+			},
+
+			"default_tags": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
 			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"aws_ami":                              resourceAwsAmi(),
-			"aws_ami_copy":                         resourceAwsAmiCopy(),
-			"aws_ami_from_instance":                resourceAwsAmiFromInstance(),
-			"aws_app_cookie_stickiness_policy":     resourceAwsAppCookieStickinessPolicy(),
-			"aws_autoscaling_group":                resourceAwsAutoscalingGroup(),
-			"aws_autoscaling_notification":         resourceAwsAutoscalingNotification(),
-			"aws_autoscaling_policy":               resourceAwsAutoscalingPolicy(),
-			"aws_autoscaling_schedule":             resourceAwsAutoscalingSchedule(),
-			"aws_cloudformation_stack":             resourceAwsCloudFormationStack(),
-			"aws_cloudtrail":                       resourceAwsCloudTrail(),
-			"aws_cloudwatch_log_group":             resourceAwsCloudWatchLogGroup(),
-			"aws_autoscaling_lifecycle_hook":       resourceAwsAutoscalingLifecycleHook(),
-			"aws_cloudwatch_metric_alarm":          resourceAwsCloudWatchMetricAlarm(),
-			"aws_codedeploy_app":                   resourceAwsCodeDeployApp(),
-			"aws_codedeploy_deployment_group":      resourceAwsCodeDeployDeploymentGroup(),
-			"aws_codecommit_repository":            resourceAwsCodeCommitRepository(),
-			"aws_customer_gateway":                 resourceAwsCustomerGateway(),
-			"aws_db_instance":                      resourceAwsDbInstance(),
-			"aws_db_parameter_group":               resourceAwsDbParameterGroup(),
-			"aws_db_security_group":                resourceAwsDbSecurityGroup(),
-			"aws_db_subnet_group":                  resourceAwsDbSubnetGroup(),
-			"aws_directory_service_directory":      resourceAwsDirectoryServiceDirectory(),
-			"aws_dynamodb_table":                   resourceAwsDynamoDbTable(),
-			"aws_ebs_volume":                       resourceAwsEbsVolume(),
-			"aws_ecr_repository":                   resourceAwsEcrRepository(),
-			"aws_ecr_repository_policy":            resourceAwsEcrRepositoryPolicy(),
-			"aws_ecs_cluster":                      resourceAwsEcsCluster(),
-			"aws_ecs_service":                      resourceAwsEcsService(),
-			"aws_ecs_task_definition":              resourceAwsEcsTaskDefinition(),
-			"aws_efs_file_system":                  resourceAwsEfsFileSystem(),
-			"aws_efs_mount_target":                 resourceAwsEfsMountTarget(),
-			"aws_eip":                              resourceAwsEip(),
-			"aws_elasticache_cluster":              resourceAwsElasticacheCluster(),
-			"aws_elasticache_parameter_group":      resourceAwsElasticacheParameterGroup(),
-			"aws_elasticache_security_group":       resourceAwsElasticacheSecurityGroup(),
-			"aws_elasticache_subnet_group":         resourceAwsElasticacheSubnetGroup(),
-			"aws_elasticsearch_domain":             resourceAwsElasticSearchDomain(),
-			"aws_elb":                              resourceAwsElb(),
-			"aws_flow_log":                         resourceAwsFlowLog(),
-			"aws_glacier_vault":                    resourceAwsGlacierVault(),
-			"aws_iam_access_key":                   resourceAwsIamAccessKey(),
-			"aws_iam_group_policy":                 resourceAwsIamGroupPolicy(),
-			"aws_iam_group":                        resourceAwsIamGroup(),
-			"aws_iam_group_membership":             resourceAwsIamGroupMembership(),
-			"aws_iam_instance_profile":             resourceAwsIamInstanceProfile(),
-			"aws_iam_policy":                       resourceAwsIamPolicy(),
-			"aws_iam_policy_attachment":            resourceAwsIamPolicyAttachment(),
-			"aws_iam_role_policy":                  resourceAwsIamRolePolicy(),
-			"aws_iam_role":                         resourceAwsIamRole(),
-			"aws_iam_saml_provider":                resourceAwsIamSamlProvider(),
-			"aws_iam_server_certificate":           resourceAwsIAMServerCertificate(),
-			"aws_iam_user_policy":                  resourceAwsIamUserPolicy(),
-			"aws_iam_user":                         resourceAwsIamUser(),
-			"aws_instance":                         resourceAwsInstance(),
-			"aws_internet_gateway":                 resourceAwsInternetGateway(),
-			"aws_key_pair":                         resourceAwsKeyPair(),
-			"aws_kinesis_firehose_delivery_stream": resourceAwsKinesisFirehoseDeliveryStream(),
-			"aws_kinesis_stream":                   resourceAwsKinesisStream(),
-			"aws_lambda_function":                  resourceAwsLambdaFunction(),
-			"aws_lambda_event_source_mapping":      resourceAwsLambdaEventSourceMapping(),
-			"aws_lambda_alias":                     resourceAwsLambdaAlias(),
-			"aws_launch_configuration":             resourceAwsLaunchConfiguration(),
-			"aws_lb_cookie_stickiness_policy":      resourceAwsLBCookieStickinessPolicy(),
-			"aws_main_route_table_association":     resourceAwsMainRouteTableAssociation(),
-			"aws_nat_gateway":                      resourceAwsNatGateway(),
-			"aws_network_acl":                      resourceAwsNetworkAcl(),
-			"aws_network_acl_rule":                 resourceAwsNetworkAclRule(),
-			"aws_network_interface":                resourceAwsNetworkInterface(),
-			"aws_opsworks_stack":                   resourceAwsOpsworksStack(),
-			"aws_opsworks_java_app_layer":          resourceAwsOpsworksJavaAppLayer(),
-			"aws_opsworks_haproxy_layer":           resourceAwsOpsworksHaproxyLayer(),
-			"aws_opsworks_static_web_layer":        resourceAwsOpsworksStaticWebLayer(),
-			"aws_opsworks_php_app_layer":           resourceAwsOpsworksPhpAppLayer(),
-			"aws_opsworks_rails_app_layer":         resourceAwsOpsworksRailsAppLayer(),
-			"aws_opsworks_nodejs_app_layer":        resourceAwsOpsworksNodejsAppLayer(),
-			"aws_opsworks_memcached_layer":         resourceAwsOpsworksMemcachedLayer(),
-			"aws_opsworks_mysql_layer":             resourceAwsOpsworksMysqlLayer(),
-			"aws_opsworks_ganglia_layer":           resourceAwsOpsworksGangliaLayer(),
-			"aws_opsworks_custom_layer":            resourceAwsOpsworksCustomLayer(),
-			"aws_placement_group":                  resourceAwsPlacementGroup(),
-			"aws_proxy_protocol_policy":            resourceAwsProxyProtocolPolicy(),
-			"aws_rds_cluster":                      resourceAwsRDSCluster(),
-			"aws_rds_cluster_instance":             resourceAwsRDSClusterInstance(),
-			"aws_redshift_cluster":                 resourceAwsRedshiftCluster(),
-			"aws_redshift_security_group":          resourceAwsRedshiftSecurityGroup(),
-			"aws_redshift_parameter_group":         resourceAwsRedshiftParameterGroup(),
-			"aws_redshift_subnet_group":            resourceAwsRedshiftSubnetGroup(),
-			"aws_route53_delegation_set":           resourceAwsRoute53DelegationSet(),
-			"aws_route53_record":                   resourceAwsRoute53Record(),
-			"aws_route53_zone_association":         resourceAwsRoute53ZoneAssociation(),
-			"aws_route53_zone":                     resourceAwsRoute53Zone(),
-			"aws_route53_health_check":             resourceAwsRoute53HealthCheck(),
-			"aws_route":                            resourceAwsRoute(),
-			"aws_route_table":                      resourceAwsRouteTable(),
-			"aws_route_table_association":          resourceAwsRouteTableAssociation(),
-			"aws_s3_bucket":                        resourceAwsS3Bucket(),
-			"aws_s3_bucket_object":                 resourceAwsS3BucketObject(),
-			"aws_security_group":                   resourceAwsSecurityGroup(),
-			"aws_security_group_rule":              resourceAwsSecurityGroupRule(),
-			"aws_spot_instance_request":            resourceAwsSpotInstanceRequest(),
-			"aws_sqs_queue":                        resourceAwsSqsQueue(),
-			"aws_sns_topic":                        resourceAwsSnsTopic(),
-			"aws_sns_topic_subscription":           resourceAwsSnsTopicSubscription(),
-			"aws_subnet":                           resourceAwsSubnet(),
-			"aws_volume_attachment":                resourceAwsVolumeAttachment(),
-			"aws_vpc_dhcp_options_association":     resourceAwsVpcDhcpOptionsAssociation(),
-			"aws_vpc_dhcp_options":                 resourceAwsVpcDhcpOptions(),
-			"aws_vpc_peering_connection":           resourceAwsVpcPeeringConnection(),
-			"aws_vpc":                              resourceAwsVpc(),
-			"aws_vpc_endpoint":                     resourceAwsVpcEndpoint(),
-			"aws_vpn_connection":                   resourceAwsVpnConnection(),
-			"aws_vpn_connection_route":             resourceAwsVpnConnectionRoute(),
-			"aws_vpn_gateway":                      resourceAwsVpnGateway(),
+			"aws_ami":                                  resourceAwsAmi(),
+			"aws_ami_copy":                             resourceAwsAmiCopy(),
+			"aws_ami_from_instance":                    resourceAwsAmiFromInstance(),
+			"aws_app_cookie_stickiness_policy":         resourceAwsAppCookieStickinessPolicy(),
+			"aws_autoscaling_group":                    resourceAwsAutoscalingGroup(),
+			"aws_autoscaling_notification":             resourceAwsAutoscalingNotification(),
+			"aws_autoscaling_policy":                   resourceAwsAutoscalingPolicy(),
+			"aws_autoscaling_schedule":                 resourceAwsAutoscalingSchedule(),
+			"aws_cloudformation_stack":                 resourceAwsCloudFormationStack(),
+			"aws_cloudfront_distribution":              resourceAwsCloudFrontDistribution(),
+			"aws_cloudtrail":                           resourceAwsCloudTrail(),
+			"aws_cloudwatch_event_rule":                resourceAwsCloudWatchEventRule(),
+			"aws_cloudwatch_event_target":              resourceAwsCloudWatchEventTarget(),
+			"aws_cloudwatch_log_group":                 resourceAwsCloudWatchLogGroup(),
+			"aws_autoscaling_lifecycle_hook":           resourceAwsAutoscalingLifecycleHook(),
+			"aws_cloudwatch_metric_alarm":              resourceAwsCloudWatchMetricAlarm(),
+			"aws_codedeploy_app":                       resourceAwsCodeDeployApp(),
+			"aws_codedeploy_deployment_group":          resourceAwsCodeDeployDeploymentGroup(),
+			"aws_codecommit_repository":                resourceAwsCodeCommitRepository(),
+			"aws_config_config_rule":                   resourceAwsConfigConfigRule(),
+			"aws_config_configuration_recorder":        resourceAwsConfigConfigurationRecorder(),
+			"aws_config_configuration_recorder_status": resourceAwsConfigConfigurationRecorderStatus(),
+			"aws_config_delivery_channel":              resourceAwsConfigDeliveryChannel(),
+			"aws_customer_gateway":                     resourceAwsCustomerGateway(),
+			"aws_db_event_subscription":                resourceAwsDbEventSubscription(),
+			"aws_db_instance":                          resourceAwsDbInstance(),
+			"aws_db_parameter_group":                   resourceAwsDbParameterGroup(),
+			"aws_db_security_group":                    resourceAwsDbSecurityGroup(),
+			"aws_db_subnet_group":                      resourceAwsDbSubnetGroup(),
+			"aws_directory_service_directory":          resourceAwsDirectoryServiceDirectory(),
+			"aws_dynamodb_table":                       resourceAwsDynamoDbTable(),
+			"aws_ebs_volume":                           resourceAwsEbsVolume(),
+			"aws_ecr_repository":                       resourceAwsEcrRepository(),
+			"aws_ecr_repository_policy":                resourceAwsEcrRepositoryPolicy(),
+			"aws_ecs_cluster":                          resourceAwsEcsCluster(),
+			"aws_ecs_service":                          resourceAwsEcsService(),
+			"aws_ecs_task_definition":                  resourceAwsEcsTaskDefinition(),
+			"aws_efs_file_system":                      resourceAwsEfsFileSystem(),
+			"aws_efs_mount_target":                     resourceAwsEfsMountTarget(),
+			"aws_eip":                                  resourceAwsEip(),
+			"aws_elasticache_cluster":                  resourceAwsElasticacheCluster(),
+			"aws_elasticache_parameter_group":          resourceAwsElasticacheParameterGroup(),
+			"aws_elasticache_replication_group":        resourceAwsElasticacheReplicationGroup(),
+			"aws_elasticache_security_group":           resourceAwsElasticacheSecurityGroup(),
+			"aws_elasticache_subnet_group":             resourceAwsElasticacheSubnetGroup(),
+			"aws_elasticsearch_domain":                 resourceAwsElasticSearchDomain(),
+			"aws_elb":                                  resourceAwsElb(),
+			"aws_elb_attachment":                       resourceAwsElbAttachment(),
+			"aws_elb_listener":                         resourceAwsElbListener(),
+			"aws_flow_log":                             resourceAwsFlowLog(),
+			"aws_glacier_vault":                        resourceAwsGlacierVault(),
+			"aws_iam_access_key":                       resourceAwsIamAccessKey(),
+			"aws_iam_group_policy":                     resourceAwsIamGroupPolicy(),
+			"aws_iam_group":                            resourceAwsIamGroup(),
+			"aws_iam_group_membership":                 resourceAwsIamGroupMembership(),
+			"aws_iam_instance_profile":                 resourceAwsIamInstanceProfile(),
+			"aws_iam_policy":                           resourceAwsIamPolicy(),
+			"aws_iam_policy_attachment":                resourceAwsIamPolicyAttachment(),
+			"aws_iam_role_policy":                      resourceAwsIamRolePolicy(),
+			"aws_iam_role":                             resourceAwsIamRole(),
+			"aws_iam_saml_provider":                    resourceAwsIamSamlProvider(),
+			"aws_iam_server_certificate":               resourceAwsIAMServerCertificate(),
+			"aws_iam_user_policy":                      resourceAwsIamUserPolicy(),
+			"aws_iam_user":                             resourceAwsIamUser(),
+			"aws_instance":                             resourceAwsInstance(),
+			"aws_internet_gateway":                     resourceAwsInternetGateway(),
+			"aws_key_pair":                             resourceAwsKeyPair(),
+			"aws_kinesis_firehose_delivery_stream":     resourceAwsKinesisFirehoseDeliveryStream(),
+			"aws_kinesis_stream":                       resourceAwsKinesisStream(),
+			"aws_lambda_function":                      resourceAwsLambdaFunction(),
+			"aws_lambda_event_source_mapping":          resourceAwsLambdaEventSourceMapping(),
+			"aws_lambda_alias":                         resourceAwsLambdaAlias(),
+			"aws_launch_configuration":                 resourceAwsLaunchConfiguration(),
+			"aws_lb_cookie_stickiness_policy":          resourceAwsLBCookieStickinessPolicy(),
+			"aws_load_balancer_backend_server_policy":  resourceAwsLoadBalancerBackendServerPolicy(),
+			"aws_load_balancer_listener_policy":        resourceAwsLoadBalancerListenerPolicy(),
+			"aws_load_balancer_policy":                 resourceAwsLoadBalancerPolicy(),
+			"aws_main_route_table_association":         resourceAwsMainRouteTableAssociation(),
+			"aws_nat_gateway":                          resourceAwsNatGateway(),
+			"aws_network_acl":                          resourceAwsNetworkAcl(),
+			"aws_network_acl_rule":                     resourceAwsNetworkAclRule(),
+			"aws_network_interface":                    resourceAwsNetworkInterface(),
+			"aws_opsworks_application":                 resourceAwsOpsworksApplication(),
+			"aws_opsworks_stack":                       resourceAwsOpsworksStack(),
+			"aws_opsworks_instance":                    resourceAwsOpsworksInstance(),
+			"aws_opsworks_java_app_layer":              resourceAwsOpsworksJavaAppLayer(),
+			"aws_opsworks_haproxy_layer":               resourceAwsOpsworksHaproxyLayer(),
+			"aws_opsworks_static_web_layer":            resourceAwsOpsworksStaticWebLayer(),
+			"aws_opsworks_php_app_layer":               resourceAwsOpsworksPhpAppLayer(),
+			"aws_opsworks_rails_app_layer":             resourceAwsOpsworksRailsAppLayer(),
+			"aws_opsworks_nodejs_app_layer":            resourceAwsOpsworksNodejsAppLayer(),
+			"aws_opsworks_memcached_layer":             resourceAwsOpsworksMemcachedLayer(),
+			"aws_opsworks_mysql_layer":                 resourceAwsOpsworksMysqlLayer(),
+			"aws_opsworks_ganglia_layer":               resourceAwsOpsworksGangliaLayer(),
+			"aws_opsworks_custom_layer":                resourceAwsOpsworksCustomLayer(),
+			"aws_placement_group":                      resourceAwsPlacementGroup(),
+			"aws_proxy_protocol_policy":                resourceAwsProxyProtocolPolicy(),
+			"aws_rds_cluster":                          resourceAwsRDSCluster(),
+			"aws_rds_cluster_instance":                 resourceAwsRDSClusterInstance(),
+			"aws_redshift_cluster":                     resourceAwsRedshiftCluster(),
+			"aws_redshift_security_group":              resourceAwsRedshiftSecurityGroup(),
+			"aws_redshift_parameter_group":             resourceAwsRedshiftParameterGroup(),
+			"aws_redshift_subnet_group":                resourceAwsRedshiftSubnetGroup(),
+			"aws_route53_delegation_set":               resourceAwsRoute53DelegationSet(),
+			"aws_route53_record":                       resourceAwsRoute53Record(),
+			"aws_route53_zone_association":             resourceAwsRoute53ZoneAssociation(),
+			"aws_route53_zone":                         resourceAwsRoute53Zone(),
+			"aws_route53_health_check":                 resourceAwsRoute53HealthCheck(),
+			"aws_route":                                resourceAwsRoute(),
+			"aws_route_table":                          resourceAwsRouteTable(),
+			"aws_route_table_association":              resourceAwsRouteTableAssociation(),
+			"aws_s3_bucket":                            resourceAwsS3Bucket(),
+			"aws_s3_bucket_object":                     resourceAwsS3BucketObject(),
+			"aws_security_group":                       resourceAwsSecurityGroup(),
+			"aws_security_group_rule":                  resourceAwsSecurityGroupRule(),
+			"aws_spot_instance_request":                resourceAwsSpotInstanceRequest(),
+			"aws_sqs_queue":                            resourceAwsSqsQueue(),
+			"aws_sns_topic":                            resourceAwsSnsTopic(),
+			"aws_sns_topic_subscription":               resourceAwsSnsTopicSubscription(),
+			"aws_ssm_association":                      resourceAwsSsmAssociation(),
+			"aws_ssm_document":                         resourceAwsSsmDocument(),
+			"aws_subnet":                               resourceAwsSubnet(),
+			"aws_volume_attachment":                    resourceAwsVolumeAttachment(),
+			"aws_vpc_dhcp_options_association":         resourceAwsVpcDhcpOptionsAssociation(),
+			"aws_vpc_dhcp_options":                     resourceAwsVpcDhcpOptions(),
+			"aws_vpc_peering_connection":               resourceAwsVpcPeeringConnection(),
+			"aws_vpc":                                  resourceAwsVpc(),
+			"aws_vpc_endpoint":                         resourceAwsVpcEndpoint(),
+			"aws_vpn_connection":                       resourceAwsVpnConnection(),
+			"aws_vpn_connection_route":                 resourceAwsVpnConnectionRoute(),
+			"aws_vpn_gateway":                          resourceAwsVpnGateway(),
+			"aws_waf_byte_match_set":                   resourceAwsWafByteMatchSet(),
+			"aws_waf_ipset":                            resourceAwsWafIPSet(),
+			"aws_waf_rule":                             resourceAwsWafRule(),
+			"aws_waf_web_acl":                          resourceAwsWafWebAcl(),
 		},
 
 		ConfigureFunc: providerConfigure,
 	}
+
+	addDefaultTags(provider.ResourcesMap)
+
+	return provider
 }
 
 var descriptions map[string]string
@@ -245,18 +310,103 @@ func init() {
 		"token": "session token. A session token is only required if you are\n" +
 			"using temporary security credentials.",
 
+		"skip_metadata_api_check": "Skip the EC2 metadata API check. When set, credentials\n" +
+			"of the EC2 instance role are never used.",
+
 		"max_retries": "The maximum number of times an AWS API request is\n" +
-			"being executed. If the API request still fails, an error is\n" +
-			"thrown.",
+			"retried when it is throttled or fails with a server error.\n" +
+			"If the API request still fails, an error is thrown.",
 
 		"dynamodb_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n" +
 			"It's typically used to connect to dynamodb-local.",
 
 		"kinesis_endpoint": "Use this to override the default endpoint URL constructed from the `region`.\n" +
 			"It's typically used to connect to kinesalite.",
+
+		"endpoint": "Use this to override the default endpoint URL constructed from the `region`\n" +
+			"for the %s service.",
+
+		"s3_force_path_style": "Use path-style addressing for S3 buckets instead of virtual\n" +
+			"hosted-style addressing. This is typically needed for custom S3 endpoints.",
+
+		"default_tags": "Tags to add to every resource that supports tags. Tags set\n" +
+			"on a resource take precedence over the default tags.",
+	}
+}
+
+// endpointServices are the services that can be given a custom endpoint
+// in the endpoints block, one for every connection in AWSClient.
+var endpointServices = []string{
+	"autoscaling",
+	"cloudformation",
+	"cloudfront",
+	"cloudtrail",
+	"cloudwatch",
+	"cloudwatchevents",
+	"cloudwatchlogs",
+	"codecommit",
+	"codedeploy",
+	"configservice",
+	"directoryservice",
+	"dynamodb",
+	"ec2",
+	"ecr",
+	"ecs",
+	"efs",
+	"elasticache",
+	"elasticsearch",
+	"elb",
+	"firehose",
+	"glacier",
+	"iam",
+	"kinesis",
+	"lambda",
+	"opsworks",
+	"rds",
+	"redshift",
+	"route53",
+	"s3",
+	"sns",
+	"sqs",
+	"ssm",
+	"waf",
+}
+
+func endpointsSchema() *schema.Schema {
+	endpoints := make(map[string]*schema.Schema)
+	for _, service := range endpointServices {
+		endpoints[service] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: fmt.Sprintf(descriptions["endpoint"], service),
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: endpoints,
+		},
+		Set: endpointsToHash,
 	}
 }
 
+func endpointsToHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	for _, service := range endpointServices {
+		if v, ok := m[service]; ok {
+			buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+		} else {
+			buf.WriteString("-")
+		}
+	}
+
+	return hashcode.String(buf.String())
+}
+
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
 		AccessKey:        d.Get("access_key").(string),
@@ -268,6 +418,26 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		MaxRetries:       d.Get("max_retries").(int),
 		DynamoDBEndpoint: d.Get("dynamodb_endpoint").(string),
 		KinesisEndpoint:  d.Get("kinesis_endpoint").(string),
+
+		SkipMetadataApiCheck: d.Get("skip_metadata_api_check").(bool),
+		S3ForcePathStyle:     d.Get("s3_force_path_style").(bool),
+	}
+
+	config.Endpoints = make(map[string]string)
+	if config.DynamoDBEndpoint != "" {
+		config.Endpoints["dynamodb"] = config.DynamoDBEndpoint
+	}
+	if config.KinesisEndpoint != "" {
+		config.Endpoints["kinesis"] = config.KinesisEndpoint
+	}
+
+	for _, v := range d.Get("endpoints").(*schema.Set).List() {
+		endpoints := v.(map[string]interface{})
+		for _, service := range endpointServices {
+			if e := endpoints[service].(string); e != "" {
+				config.Endpoints[service] = e
+			}
+		}
 	}
 
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
@@ -278,6 +448,11 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.ForbiddenAccountIds = v.(*schema.Set).List()
 	}
 
+	config.DefaultTags = make(map[string]string)
+	for k, v := range d.Get("default_tags").(map[string]interface{}) {
+		config.DefaultTags[k] = v.(string)
+	}
+
 	return config.Client()
 }
 
@@ -1,10 +1,12 @@
 package aws
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"testing"
 
+	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/terraform"
 )
@@ -19,6 +21,10 @@ func init() {
 	}
 }
 
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -41,3 +47,30 @@ func testAccPreCheck(t *testing.T) {
 		os.Setenv("AWS_DEFAULT_REGION", "us-west-2")
 	}
 }
+
+// sharedClientForRegion returns a client configured for the given region
+// using the same credentials as the acceptance tests. It is used by the
+// sweepers, which run outside of the provider.
+func sharedClientForRegion(region string) (*AWSClient, error) {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf(
+			"AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for sweepers")
+	}
+
+	conf := &Config{
+		AccessKey:  accessKey,
+		SecretKey:  secretKey,
+		Token:      os.Getenv("AWS_SESSION_TOKEN"),
+		Region:     region,
+		MaxRetries: 11,
+	}
+
+	client, err := conf.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting AWS client: %s", err)
+	}
+
+	return client.(*AWSClient), nil
+}
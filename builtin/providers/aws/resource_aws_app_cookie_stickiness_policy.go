@@ -2,7 +2,8 @@ package aws
 
 import (
 	"fmt"
-	"regexp"
+	"log"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -21,17 +22,10 @@ func resourceAwsAppCookieStickinessPolicy() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
-					value := v.(string)
-					if !regexp.MustCompile(`^[0-9A-Za-z-]+$`).MatchString(value) {
-						es = append(es, fmt.Errorf(
-							"only alphanumeric characters and hyphens allowed in %q", k))
-					}
-					return
-				},
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateElbPolicyName,
 			},
 
 			"load_balancer": &schema.Schema{
@@ -65,7 +59,21 @@ func resourceAwsAppCookieStickinessPolicyCreate(d *schema.ResourceData, meta int
 		PolicyName:       aws.String(d.Get("name").(string)),
 	}
 
-	if _, err := elbconn.CreateAppCookieStickinessPolicy(acspOpts); err != nil {
+	_, err := elbconn.CreateAppCookieStickinessPolicy(acspOpts)
+	if isElbDuplicatePolicyName(err) {
+		// A policy that was detached from its listener outside of
+		// Terraform is removed from the state, but it still exists.
+		// Replace it, so it is created with the configured settings.
+		log.Printf("[WARN] Replacing existing, unattached ELB policy %s", *acspOpts.PolicyName)
+		if _, err := elbconn.DeleteLoadBalancerPolicy(&elb.DeleteLoadBalancerPolicyInput{
+			LoadBalancerName: acspOpts.LoadBalancerName,
+			PolicyName:       acspOpts.PolicyName,
+		}); err != nil {
+			return fmt.Errorf("Error replacing AppCookieStickinessPolicy: %s", err)
+		}
+		_, err = elbconn.CreateAppCookieStickinessPolicy(acspOpts)
+	}
+	if err != nil {
 		return fmt.Errorf("Error creating AppCookieStickinessPolicy: %s", err)
 	}
 
@@ -98,7 +106,7 @@ func resourceAwsAppCookieStickinessPolicyRead(d *schema.ResourceData, meta inter
 
 	getResp, err := elbconn.DescribeLoadBalancerPolicies(request)
 	if err != nil {
-		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "PolicyNotFound" {
+		if ec2err, ok := err.(awserr.Error); ok && (ec2err.Code() == "PolicyNotFound" || ec2err.Code() == "LoadBalancerNotFound") {
 			// The policy is gone.
 			d.SetId("")
 			return nil
@@ -110,6 +118,18 @@ func resourceAwsAppCookieStickinessPolicyRead(d *schema.ResourceData, meta inter
 		return fmt.Errorf("Unable to find policy %#v", getResp.PolicyDescriptions)
 	}
 
+	// The policy only takes effect while it is attached to the listener,
+	// so a policy that was detached is treated as gone as well.
+	assigned, err := resourceAwsELBStickinessPolicyAssigned(elbconn, lbName, lbPort, policyName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving listener policies: %s", err)
+	}
+	if !assigned {
+		log.Printf("[WARN] ELB policy %s is no longer attached to listener %s of %s", policyName, lbPort, lbName)
+		d.SetId("")
+		return nil
+	}
+
 	// We can get away with this because there's only one attribute, the
 	// cookie expiration, in these descriptions.
 	policyDesc := getResp.PolicyDescriptions[0]
@@ -162,3 +182,44 @@ func resourceAwsAppCookieStickinessPolicyParseId(id string) (string, string, str
 	parts := strings.SplitN(id, ":", 3)
 	return parts[0], parts[1], parts[2]
 }
+
+// resourceAwsELBStickinessPolicyAssigned checks whether the given policy is
+// attached to the listener on the given port of the load balancer. It is
+// shared by the app and lb cookie stickiness policies.
+func resourceAwsELBStickinessPolicyAssigned(elbconn *elb.ELB, lbName, lbPort, policyName string) (bool, error) {
+	port, err := strconv.ParseInt(lbPort, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, lb := range describeResp.LoadBalancerDescriptions {
+		for _, l := range lb.ListenerDescriptions {
+			if l.Listener == nil || aws.Int64Value(l.Listener.LoadBalancerPort) != port {
+				continue
+			}
+
+			for _, name := range l.PolicyNames {
+				if aws.StringValue(name) == policyName {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func isElbDuplicatePolicyName(err error) bool {
+	elberr, ok := err.(awserr.Error)
+	return ok && elberr.Code() == "DuplicatePolicyName"
+}
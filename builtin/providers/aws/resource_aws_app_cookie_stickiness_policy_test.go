@@ -40,6 +40,34 @@ func TestAccAWSAppCookieStickinessPolicy_basic(t *testing.T) {
 	})
 }
 
+// Detaching the policy from its listener outside of Terraform makes it get
+// attached again on the next apply.
+func TestAccAWSAppCookieStickinessPolicy_drift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAppCookieStickinessPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAppCookieStickinessPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAppCookieStickinessPolicy(
+						"aws_elb.lb",
+						"aws_app_cookie_stickiness_policy.foo",
+					),
+				),
+			},
+			resource.TestStep{
+				PreConfig: testAccDetachELBListenerPolicies("test-lb", 80),
+				Config:    testAccAppCookieStickinessPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckELBStickinessPolicyAssigned("aws_app_cookie_stickiness_policy.foo"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAppCookieStickinessPolicyDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).elbconn
 
@@ -140,3 +168,40 @@ resource "aws_app_cookie_stickiness_policy" "foo" {
 	cookie_name = "MyOtherAppCookie"
 }
 `
+
+// testAccDetachELBListenerPolicies removes all the policies from the given
+// listener, like it would be done outside of Terraform.
+func testAccDetachELBListenerPolicies(lbName string, lbPort int64) func() {
+	return func() {
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		_, err := conn.SetLoadBalancerPoliciesOfListener(&elb.SetLoadBalancerPoliciesOfListenerInput{
+			LoadBalancerName: aws.String(lbName),
+			LoadBalancerPort: aws.Int64(lbPort),
+			PolicyNames:      []*string{},
+		})
+		if err != nil {
+			panic(fmt.Sprintf("Error detaching policies of ELB listener: %s", err))
+		}
+	}
+}
+
+func testAccCheckELBStickinessPolicyAssigned(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		lbName, lbPort, policyName := resourceAwsAppCookieStickinessPolicyParseId(rs.Primary.ID)
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		assigned, err := resourceAwsELBStickinessPolicyAssigned(conn, lbName, lbPort, policyName)
+		if err != nil {
+			return err
+		}
+		if !assigned {
+			return fmt.Errorf("Policy %s is not attached to listener %s of %s", policyName, lbPort, lbName)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,617 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsCloudFrontDistribution() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCloudFrontDistributionCreate,
+		Read:   resourceAwsCloudFrontDistributionRead,
+		Update: resourceAwsCloudFrontDistributionUpdate,
+		Delete: resourceAwsCloudFrontDistributionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"aliases": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"cache_behavior": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     cloudFrontCacheBehaviorResource(true),
+			},
+
+			"comment": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 128),
+			},
+
+			"custom_error_response": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      customErrorResponseHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"error_caching_min_ttl": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"error_code": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"response_code": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"response_page_path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"default_cache_behavior": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem:     cloudFrontCacheBehaviorResource(false),
+			},
+
+			"default_root_object": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			"http_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  cloudfront.HttpVersionHttp11,
+				ValidateFunc: validation.StringInSlice([]string{
+					cloudfront.HttpVersionHttp11,
+					cloudfront.HttpVersionHttp2,
+				}, false),
+			},
+
+			"logging_config": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"include_cookies": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"origin": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Set:      originHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_header": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Set:      customHeaderHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"value": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"custom_origin_config": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"http_port": &schema.Schema{
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+									"https_port": &schema.Schema{
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+									"origin_protocol_policy": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											cloudfront.OriginProtocolPolicyHttpOnly,
+											cloudfront.OriginProtocolPolicyHttpsOnly,
+											cloudfront.OriginProtocolPolicyMatchViewer,
+										}, false),
+									},
+									"origin_ssl_protocols": &schema.Schema{
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+						"domain_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"origin_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"origin_path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"s3_origin_config": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"origin_access_identity": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"price_class": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  cloudfront.PriceClassPriceClassAll,
+				ValidateFunc: validation.StringInSlice([]string{
+					cloudfront.PriceClassPriceClassAll,
+					cloudfront.PriceClassPriceClass200,
+					cloudfront.PriceClassPriceClass100,
+				}, false),
+			},
+
+			"restrictions": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"geo_restriction": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"locations": &schema.Schema{
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+									"restriction_type": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											cloudfront.GeoRestrictionTypeNone,
+											cloudfront.GeoRestrictionTypeWhitelist,
+											cloudfront.GeoRestrictionTypeBlacklist,
+										}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"viewer_certificate": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"acm_certificate_arn": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.ARN,
+						},
+						"cloudfront_default_certificate": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"iam_certificate_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"minimum_protocol_version": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"ssl_support_method": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								cloudfront.SSLSupportMethodSniOnly,
+								cloudfront.SSLSupportMethodVip,
+							}, false),
+						},
+					},
+				},
+			},
+
+			"web_acl_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"retain_on_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"caller_reference": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"in_progress_invalidation_batches": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hosted_zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// cloudFrontCacheBehaviorResource returns the schema of a cache behavior,
+// which only has a path pattern if it isn't the default cache behavior.
+func cloudFrontCacheBehaviorResource(withPathPattern bool) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"allowed_methods": &schema.Schema{
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"cached_methods": &schema.Schema{
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"compress": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"default_ttl": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  86400,
+		},
+		"forwarded_values": &schema.Schema{
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cookies": &schema.Schema{
+						Type:     schema.TypeList,
+						Required: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"forward": &schema.Schema{
+									Type:     schema.TypeString,
+									Required: true,
+									ValidateFunc: validation.StringInSlice([]string{
+										cloudfront.ItemSelectionAll,
+										cloudfront.ItemSelectionNone,
+										cloudfront.ItemSelectionWhitelist,
+									}, false),
+								},
+								"whitelisted_names": &schema.Schema{
+									Type:     schema.TypeSet,
+									Optional: true,
+									Elem:     &schema.Schema{Type: schema.TypeString},
+									Set:      schema.HashString,
+								},
+							},
+						},
+					},
+					"headers": &schema.Schema{
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Set:      schema.HashString,
+					},
+					"query_string": &schema.Schema{
+						Type:     schema.TypeBool,
+						Required: true,
+					},
+				},
+			},
+		},
+		"max_ttl": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  31536000,
+		},
+		"min_ttl": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		"smooth_streaming": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"target_origin_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"trusted_signers": &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"viewer_protocol_policy": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				cloudfront.ViewerProtocolPolicyAllowAll,
+				cloudfront.ViewerProtocolPolicyHttpsOnly,
+				cloudfront.ViewerProtocolPolicyRedirectToHttps,
+			}, false),
+		},
+	}
+
+	if withPathPattern {
+		s["path_pattern"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		}
+	}
+
+	return &schema.Resource{Schema: s}
+}
+
+func resourceAwsCloudFrontDistributionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cloudfrontconn
+
+	dc := expandDistributionConfig(d)
+	dc.CallerReference = aws.String(resource.UniqueId())
+
+	log.Printf("[DEBUG] Creating CloudFront Distribution: %s", dc)
+	resp, err := conn.CreateDistribution(&cloudfront.CreateDistributionInput{
+		DistributionConfig: dc,
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating CloudFront Distribution: %s", err)
+	}
+
+	d.SetId(*resp.Distribution.Id)
+
+	if err := resourceAwsCloudFrontDistributionWaitUntilDeployed(conn, d.Id()); err != nil {
+		return err
+	}
+
+	return resourceAwsCloudFrontDistributionRead(d, meta)
+}
+
+func resourceAwsCloudFrontDistributionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cloudfrontconn
+
+	resp, err := conn.GetDistribution(&cloudfront.GetDistributionInput{
+		Id: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isCloudFrontDistributionNotFound(err) {
+			log.Printf("[WARN] CloudFront Distribution (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading CloudFront Distribution %s: %s", d.Id(), err)
+	}
+
+	dist := resp.Distribution
+	if err := flattenDistributionConfig(d, dist.DistributionConfig); err != nil {
+		return fmt.Errorf("Error saving configuration of CloudFront Distribution %s: %s", d.Id(), err)
+	}
+
+	d.Set("etag", aws.StringValue(resp.ETag))
+	d.Set("status", aws.StringValue(dist.Status))
+	d.Set("domain_name", aws.StringValue(dist.DomainName))
+	d.Set("in_progress_invalidation_batches", int(aws.Int64Value(dist.InProgressInvalidationBatches)))
+	d.Set("hosted_zone_id", cloudFrontRoute53ZoneID)
+	if dist.LastModifiedTime != nil {
+		d.Set("last_modified_time", dist.LastModifiedTime.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func resourceAwsCloudFrontDistributionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cloudfrontconn
+
+	// UpdateDistribution replaces the whole configuration, which must be
+	// sent along with the caller reference it was created with.
+	dc := expandDistributionConfig(d)
+	dc.CallerReference = aws.String(d.Get("caller_reference").(string))
+
+	log.Printf("[DEBUG] Updating CloudFront Distribution %s: %s", d.Id(), dc)
+	_, err := conn.UpdateDistribution(&cloudfront.UpdateDistributionInput{
+		Id:                 aws.String(d.Id()),
+		IfMatch:            aws.String(d.Get("etag").(string)),
+		DistributionConfig: dc,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating CloudFront Distribution %s: %s", d.Id(), err)
+	}
+
+	if err := resourceAwsCloudFrontDistributionWaitUntilDeployed(conn, d.Id()); err != nil {
+		return err
+	}
+
+	return resourceAwsCloudFrontDistributionRead(d, meta)
+}
+
+func resourceAwsCloudFrontDistributionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cloudfrontconn
+
+	// A distribution has to be disabled and deployed before it can be
+	// deleted, which can take a long time.
+	resp, err := conn.GetDistributionConfig(&cloudfront.GetDistributionConfigInput{
+		Id: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isCloudFrontDistributionNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error reading CloudFront Distribution %s: %s", d.Id(), err)
+	}
+
+	etag := resp.ETag
+	if aws.BoolValue(resp.DistributionConfig.Enabled) {
+		log.Printf("[DEBUG] Disabling CloudFront Distribution %s", d.Id())
+		resp.DistributionConfig.Enabled = aws.Bool(false)
+		out, err := conn.UpdateDistribution(&cloudfront.UpdateDistributionInput{
+			Id:                 aws.String(d.Id()),
+			IfMatch:            etag,
+			DistributionConfig: resp.DistributionConfig,
+		})
+		if err != nil {
+			return fmt.Errorf("Error disabling CloudFront Distribution %s: %s", d.Id(), err)
+		}
+		etag = out.ETag
+	}
+
+	if d.Get("retain_on_delete").(bool) {
+		log.Printf("[WARN] Retaining disabled CloudFront Distribution %s", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := resourceAwsCloudFrontDistributionWaitUntilDeployed(conn, d.Id()); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting CloudFront Distribution %s", d.Id())
+	_, err = conn.DeleteDistribution(&cloudfront.DeleteDistributionInput{
+		Id:      aws.String(d.Id()),
+		IfMatch: etag,
+	})
+	if err != nil && !isCloudFrontDistributionNotFound(err) {
+		return fmt.Errorf("Error deleting CloudFront Distribution %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceAwsCloudFrontDistributionWaitUntilDeployed waits until the
+// distribution is deployed to all edge locations, which often takes more
+// than 15 minutes.
+func resourceAwsCloudFrontDistributionWaitUntilDeployed(conn *cloudfront.CloudFront, id string) error {
+	log.Printf("[INFO] Waiting for CloudFront Distribution %s to be deployed", id)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"InProgress"},
+		Target:     "Deployed",
+		Refresh:    resourceAwsCloudFrontDistributionStateRefreshFunc(conn, id),
+		Timeout:    70 * time.Minute,
+		MinTimeout: 15 * time.Second,
+		Delay:      1 * time.Minute,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for CloudFront Distribution %s to be deployed: %s", id, err)
+	}
+
+	return nil
+}
+
+func resourceAwsCloudFrontDistributionStateRefreshFunc(conn *cloudfront.CloudFront, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.GetDistribution(&cloudfront.GetDistributionInput{
+			Id: aws.String(id),
+		})
+		if err != nil {
+			log.Printf("[WARN] Error on retrieving CloudFront Distribution when waiting: %s", err)
+			return nil, "", err
+		}
+
+		return resp.Distribution, aws.StringValue(resp.Distribution.Status), nil
+	}
+}
+
+func isCloudFrontDistributionNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "NoSuchDistribution"
+}
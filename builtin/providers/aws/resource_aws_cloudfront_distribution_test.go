@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSCloudFrontDistribution_S3Origin(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudFrontDistributionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudFrontDistributionS3Config(rInt, "Some comment"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCloudFrontDistributionExists("aws_cloudfront_distribution.s3_distribution"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.s3_distribution", "status", "Deployed"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.s3_distribution", "hosted_zone_id", "Z2FDTNDATAQYW2"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.s3_distribution", "comment", "Some comment"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudFrontDistributionS3Config(rInt, "Updated comment"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCloudFrontDistributionExists("aws_cloudfront_distribution.s3_distribution"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.s3_distribution", "comment", "Updated comment"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCloudFrontDistribution_customOrigin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudFrontDistributionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudFrontDistributionCustomConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCloudFrontDistributionExists("aws_cloudfront_distribution.custom_distribution"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.custom_distribution", "cache_behavior.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.custom_distribution", "cache_behavior.0.path_pattern", "images/*"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.custom_distribution", "custom_error_response.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_cloudfront_distribution.custom_distribution", "price_class", "PriceClass_200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSCloudFrontDistributionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No CloudFront Distribution ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cloudfrontconn
+		_, err := conn.GetDistribution(&cloudfront.GetDistributionInput{
+			Id: aws.String(rs.Primary.ID),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckAWSCloudFrontDistributionDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cloudfrontconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cloudfront_distribution" {
+			continue
+		}
+
+		_, err := conn.GetDistribution(&cloudfront.GetDistributionInput{
+			Id: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("CloudFront Distribution %q still exists", rs.Primary.ID)
+		}
+		if !isCloudFrontDistributionNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSCloudFrontDistributionS3Config(rInt int, comment string) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "s3_bucket" {
+	bucket = "tf-test-cloudfront-%d"
+	acl = "public-read"
+}
+
+resource "aws_cloudfront_distribution" "s3_distribution" {
+	origin {
+		domain_name = "${aws_s3_bucket.s3_bucket.id}.s3.amazonaws.com"
+		origin_id = "myS3Origin"
+	}
+	enabled = true
+	comment = "%s"
+	default_root_object = "index.html"
+	default_cache_behavior {
+		allowed_methods = ["DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"]
+		cached_methods = ["GET", "HEAD"]
+		target_origin_id = "myS3Origin"
+		forwarded_values {
+			query_string = false
+			cookies {
+				forward = "none"
+			}
+		}
+		viewer_protocol_policy = "allow-all"
+		min_ttl = 0
+		default_ttl = 3600
+		max_ttl = 86400
+	}
+	price_class = "PriceClass_200"
+	restrictions {
+		geo_restriction {
+			restriction_type = "whitelist"
+			locations = ["US", "CA", "GB", "DE"]
+		}
+	}
+	viewer_certificate {
+		cloudfront_default_certificate = true
+	}
+}
+`, rInt, comment)
+}
+
+const testAccAWSCloudFrontDistributionCustomConfig = `
+resource "aws_cloudfront_distribution" "custom_distribution" {
+	origin {
+		domain_name = "www.example.com"
+		origin_id = "myCustomOrigin"
+		custom_origin_config {
+			http_port = 80
+			https_port = 443
+			origin_protocol_policy = "http-only"
+			origin_ssl_protocols = ["SSLv3", "TLSv1"]
+		}
+		custom_header {
+			name = "X-Origin"
+			value = "terraform"
+		}
+	}
+	enabled = true
+	comment = "Some comment"
+	default_cache_behavior {
+		allowed_methods = ["GET", "HEAD"]
+		cached_methods = ["GET", "HEAD"]
+		target_origin_id = "myCustomOrigin"
+		forwarded_values {
+			query_string = true
+			headers = ["Host"]
+			cookies {
+				forward = "all"
+			}
+		}
+		viewer_protocol_policy = "redirect-to-https"
+	}
+	cache_behavior {
+		path_pattern = "images/*"
+		allowed_methods = ["GET", "HEAD"]
+		cached_methods = ["GET", "HEAD"]
+		target_origin_id = "myCustomOrigin"
+		forwarded_values {
+			query_string = false
+			cookies {
+				forward = "whitelist"
+				whitelisted_names = ["session"]
+			}
+		}
+		viewer_protocol_policy = "allow-all"
+		compress = true
+	}
+	custom_error_response {
+		error_code = 404
+		response_code = 200
+		response_page_path = "/index.html"
+		error_caching_min_ttl = 300
+	}
+	price_class = "PriceClass_200"
+	restrictions {
+		geo_restriction {
+			restriction_type = "none"
+		}
+	}
+	viewer_certificate {
+		cloudfront_default_certificate = true
+	}
+	retain_on_delete = false
+}
+`
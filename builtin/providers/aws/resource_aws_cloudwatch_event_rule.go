@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	events "github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsCloudWatchEventRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCloudWatchEventRuleCreate,
+		Read:   resourceAwsCloudWatchEventRuleRead,
+		Update: resourceAwsCloudWatchEventRuleUpdate,
+		Delete: resourceAwsCloudWatchEventRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+			},
+
+			"schedule_expression": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+
+			"event_pattern": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    normalizeJson,
+				ValidateFunc: validation.JSONString,
+			},
+
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 512),
+			},
+
+			"role_arn": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"is_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsCloudWatchEventRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	input, err := buildPutRuleInputStruct(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating CloudWatch Event Rule: %s", input)
+	out, err := conn.PutRule(input)
+	if err != nil {
+		return fmt.Errorf("Creating CloudWatch Event Rule failed: %s", err)
+	}
+
+	d.Set("arn", *out.RuleArn)
+	d.SetId(d.Get("name").(string))
+
+	log.Printf("[INFO] CloudWatch Event Rule %q created", *out.RuleArn)
+
+	return resourceAwsCloudWatchEventRuleRead(d, meta)
+}
+
+func resourceAwsCloudWatchEventRuleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	log.Printf("[DEBUG] Reading CloudWatch Event Rule: %s", d.Id())
+	out, err := conn.DescribeRule(&events.DescribeRuleInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			log.Printf("[WARN] Removing CloudWatch Event Rule %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading CloudWatch Event Rule %s: %s", d.Id(), err)
+	}
+
+	log.Printf("[DEBUG] Found CloudWatch Event Rule: %s", out)
+
+	d.Set("arn", *out.Arn)
+	d.Set("name", *out.Name)
+	d.Set("description", aws.StringValue(out.Description))
+	d.Set("schedule_expression", aws.StringValue(out.ScheduleExpression))
+	d.Set("role_arn", aws.StringValue(out.RoleArn))
+
+	if out.EventPattern != nil {
+		d.Set("event_pattern", normalizeJson(*out.EventPattern))
+	} else {
+		d.Set("event_pattern", "")
+	}
+
+	switch *out.State {
+	case events.RuleStateEnabled:
+		d.Set("is_enabled", true)
+	case events.RuleStateDisabled:
+		d.Set("is_enabled", false)
+	default:
+		log.Printf("[WARN] Unknown state of CloudWatch Event Rule %s: %s", d.Id(), *out.State)
+	}
+
+	return nil
+}
+
+func resourceAwsCloudWatchEventRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	// PutRule replaces the whole rule, so we always send all arguments
+	input, err := buildPutRuleInputStruct(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating CloudWatch Event Rule: %s", input)
+	if _, err := conn.PutRule(input); err != nil {
+		return fmt.Errorf("Updating CloudWatch Event Rule failed: %s", err)
+	}
+
+	return resourceAwsCloudWatchEventRuleRead(d, meta)
+}
+
+func resourceAwsCloudWatchEventRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	log.Printf("[INFO] Deleting CloudWatch Event Rule: %s", d.Id())
+	_, err := conn.DeleteRule(&events.DeleteRuleInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting CloudWatch Event Rule: %s", err)
+	}
+	log.Println("[INFO] CloudWatch Event Rule deleted")
+
+	d.SetId("")
+
+	return nil
+}
+
+func buildPutRuleInputStruct(d *schema.ResourceData) (*events.PutRuleInput, error) {
+	input := events.PutRuleInput{
+		Name:  aws.String(d.Get("name").(string)),
+		State: aws.String(getStringStateFromBoolean(d.Get("is_enabled").(bool))),
+	}
+
+	scheduleExpression, hasSchedule := d.GetOk("schedule_expression")
+	eventPattern, hasPattern := d.GetOk("event_pattern")
+	if !hasSchedule && !hasPattern {
+		return nil, fmt.Errorf(
+			"One of schedule_expression or event_pattern must be set for CloudWatch Event Rule %s",
+			d.Get("name").(string))
+	}
+
+	if hasSchedule {
+		input.ScheduleExpression = aws.String(scheduleExpression.(string))
+	}
+	if hasPattern {
+		input.EventPattern = aws.String(normalizeJson(eventPattern.(string)))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("role_arn"); ok {
+		input.RoleArn = aws.String(v.(string))
+	}
+
+	return &input, nil
+}
+
+// getStringStateFromBoolean returns the state of a rule for the value of
+// its is_enabled argument.
+func getStringStateFromBoolean(isEnabled bool) string {
+	if isEnabled {
+		return events.RuleStateEnabled
+	}
+
+	return events.RuleStateDisabled
+}
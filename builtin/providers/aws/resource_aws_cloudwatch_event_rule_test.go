@@ -0,0 +1,133 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	events "github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSCloudWatchEventRule_basic(t *testing.T) {
+	var rule events.DescribeRuleOutput
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchEventRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventRuleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventRuleExists("aws_cloudwatch_event_rule.foo", &rule),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "name", "tf-acc-cw-event-rule"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "schedule_expression", "rate(1 hour)"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "is_enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventRuleConfigModified,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventRuleExists("aws_cloudwatch_event_rule.foo", &rule),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "schedule_expression", "rate(1 day)"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "is_enabled", "false"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.foo", "description", "modified"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCloudWatchEventRule_eventPattern(t *testing.T) {
+	var rule events.DescribeRuleOutput
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchEventRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventRuleConfig_eventPattern,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventRuleExists("aws_cloudwatch_event_rule.pattern", &rule),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_rule.pattern", "event_pattern",
+						`{"detail-type":["EC2 Instance State-change Notification"],"source":["aws.ec2"]}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudWatchEventRuleExists(n string, rule *events.DescribeRuleOutput) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cweconn
+		resp, err := conn.DescribeRule(&events.DescribeRuleInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		*rule = *resp
+
+		return nil
+	}
+}
+
+func testAccCheckAWSCloudWatchEventRuleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cweconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cloudwatch_event_rule" {
+			continue
+		}
+
+		_, err := conn.DescribeRule(&events.DescribeRuleInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("CloudWatch Event Rule %q still exists", rs.Primary.ID)
+		}
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ResourceNotFoundException" {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var testAccAWSCloudWatchEventRuleConfig = `
+resource "aws_cloudwatch_event_rule" "foo" {
+    name = "tf-acc-cw-event-rule"
+    schedule_expression = "rate(1 hour)"
+}
+`
+
+var testAccAWSCloudWatchEventRuleConfigModified = `
+resource "aws_cloudwatch_event_rule" "foo" {
+    name = "tf-acc-cw-event-rule"
+    schedule_expression = "rate(1 day)"
+    description = "modified"
+    is_enabled = false
+}
+`
+
+var testAccAWSCloudWatchEventRuleConfig_eventPattern = `
+resource "aws_cloudwatch_event_rule" "pattern" {
+    name = "tf-acc-cw-event-rule-pattern"
+    event_pattern = <<PATTERN
+{
+  "source": ["aws.ec2"],
+  "detail-type": ["EC2 Instance State-change Notification"]
+}
+PATTERN
+}
+`
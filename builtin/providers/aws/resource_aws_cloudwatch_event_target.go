@@ -0,0 +1,291 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	events "github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsCloudWatchEventTarget() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCloudWatchEventTargetCreate,
+		Read:   resourceAwsCloudWatchEventTargetRead,
+		Update: resourceAwsCloudWatchEventTargetUpdate,
+		Delete: resourceAwsCloudWatchEventTargetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"rule": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+			},
+
+			"target_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 64),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z_.-]+$`),
+						"can only contain alphanumeric characters, underscores, periods and hyphens"),
+				),
+			},
+
+			"arn": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"role_arn": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"input": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				StateFunc:     normalizeJson,
+				ValidateFunc:  validation.JSONString,
+				ConflictsWith: []string{"input_path", "input_transformer"},
+			},
+
+			"input_path": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"input", "input_transformer"},
+			},
+
+			"input_transformer": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"input", "input_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input_paths": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"input_template": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cweInputTransformer is the input_transformer block of an event target.
+type cweInputTransformer struct {
+	InputPaths    map[string]string
+	InputTemplate string
+}
+
+func resourceAwsCloudWatchEventTargetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	rule := d.Get("rule").(string)
+
+	var targetId string
+	if v, ok := d.GetOk("target_id"); ok {
+		targetId = v.(string)
+	} else {
+		targetId = resource.UniqueId()
+		d.Set("target_id", targetId)
+	}
+
+	input, err := buildPutTargetInputStruct(d, targetId)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating CloudWatch Event Target: %s", input)
+	if err := putCloudWatchEventTarget(conn, input); err != nil {
+		return fmt.Errorf("Creating CloudWatch Event Target failed: %s", err)
+	}
+
+	d.SetId(rule + "-" + targetId)
+
+	log.Printf("[INFO] CloudWatch Event Target %q created", d.Id())
+
+	return resourceAwsCloudWatchEventTargetRead(d, meta)
+}
+
+func resourceAwsCloudWatchEventTargetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	rule := d.Get("rule").(string)
+	targetId := d.Get("target_id").(string)
+
+	log.Printf("[DEBUG] Reading CloudWatch Event Target: %s", d.Id())
+	t, err := findCloudWatchEventTarget(conn, rule, targetId)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ResourceNotFoundException" {
+			log.Printf("[WARN] Removing CloudWatch Event Target %q because its rule is gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading CloudWatch Event Target %s: %s", d.Id(), err)
+	}
+	if t == nil {
+		log.Printf("[WARN] Removing CloudWatch Event Target %q because it's gone", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[DEBUG] Found CloudWatch Event Target: %s", t)
+
+	d.Set("arn", *t.Arn)
+	d.Set("target_id", *t.Id)
+	d.Set("role_arn", aws.StringValue(t.RoleArn))
+	d.Set("input_path", aws.StringValue(t.InputPath))
+
+	if t.Input != nil {
+		d.Set("input", normalizeJson(*t.Input))
+	} else {
+		d.Set("input", "")
+	}
+
+	var transformer *cweInputTransformer
+	if t.InputTransformer != nil {
+		transformer = &cweInputTransformer{
+			InputPaths:    aws.StringValueMap(t.InputTransformer.InputPathsMap),
+			InputTemplate: aws.StringValue(t.InputTransformer.InputTemplate),
+		}
+	}
+	if err := d.SetStruct("input_transformer", transformer); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsCloudWatchEventTargetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	// PutTargets replaces the whole target with the same ID
+	input, err := buildPutTargetInputStruct(d, d.Get("target_id").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating CloudWatch Event Target: %s", input)
+	if err := putCloudWatchEventTarget(conn, input); err != nil {
+		return fmt.Errorf("Updating CloudWatch Event Target failed: %s", err)
+	}
+
+	return resourceAwsCloudWatchEventTargetRead(d, meta)
+}
+
+func resourceAwsCloudWatchEventTargetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cweconn
+
+	log.Printf("[INFO] Deleting CloudWatch Event Target: %s", d.Id())
+	out, err := conn.RemoveTargets(&events.RemoveTargetsInput{
+		Ids:  []*string{aws.String(d.Get("target_id").(string))},
+		Rule: aws.String(d.Get("rule").(string)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting CloudWatch Event Target: %s", err)
+	}
+	if out.FailedEntryCount != nil && *out.FailedEntryCount > 0 {
+		entry := out.FailedEntries[0]
+		return fmt.Errorf("Error deleting CloudWatch Event Target: %s: %s",
+			aws.StringValue(entry.ErrorCode), aws.StringValue(entry.ErrorMessage))
+	}
+	log.Println("[INFO] CloudWatch Event Target deleted")
+
+	d.SetId("")
+
+	return nil
+}
+
+func buildPutTargetInputStruct(d *schema.ResourceData, targetId string) (*events.PutTargetsInput, error) {
+	target := &events.Target{
+		Arn: aws.String(d.Get("arn").(string)),
+		Id:  aws.String(targetId),
+	}
+
+	if v, ok := d.GetOk("role_arn"); ok {
+		target.RoleArn = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("input"); ok {
+		target.Input = aws.String(normalizeJson(v.(string)))
+	}
+	if v, ok := d.GetOk("input_path"); ok {
+		target.InputPath = aws.String(v.(string))
+	}
+
+	var transformer *cweInputTransformer
+	if err := d.GetStruct("input_transformer", &transformer); err != nil {
+		return nil, err
+	}
+	if transformer != nil {
+		target.InputTransformer = &events.InputTransformer{
+			InputPathsMap: aws.StringMap(transformer.InputPaths),
+			InputTemplate: aws.String(transformer.InputTemplate),
+		}
+	}
+
+	return &events.PutTargetsInput{
+		Rule:    aws.String(d.Get("rule").(string)),
+		Targets: []*events.Target{target},
+	}, nil
+}
+
+// putCloudWatchEventTarget puts the given target, returning an error if
+// the target failed, which isn't an error of the request itself.
+func putCloudWatchEventTarget(conn *events.CloudWatchEvents, input *events.PutTargetsInput) error {
+	out, err := conn.PutTargets(input)
+	if err != nil {
+		return err
+	}
+
+	if out.FailedEntryCount != nil && *out.FailedEntryCount > 0 {
+		entry := out.FailedEntries[0]
+		return fmt.Errorf("%s: %s",
+			aws.StringValue(entry.ErrorCode), aws.StringValue(entry.ErrorMessage))
+	}
+
+	return nil
+}
+
+// findCloudWatchEventTarget returns the target with the given ID of the
+// given rule, or nil if the rule has no such target.
+func findCloudWatchEventTarget(conn *events.CloudWatchEvents, rule, targetId string) (*events.Target, error) {
+	input := &events.ListTargetsByRuleInput{
+		Rule: aws.String(rule),
+	}
+
+	for {
+		out, err := conn.ListTargetsByRule(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range out.Targets {
+			if aws.StringValue(t.Id) == targetId {
+				return t, nil
+			}
+		}
+
+		if out.NextToken == nil || *out.NextToken == "" {
+			return nil, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
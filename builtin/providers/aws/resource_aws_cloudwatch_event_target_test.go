@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	events "github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSCloudWatchEventTarget_basic(t *testing.T) {
+	var target events.Target
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCloudWatchEventTargetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventTargetConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventTargetExists("aws_cloudwatch_event_target.foo", &target),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "rule", "tf-acc-cw-event-target-rule"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "target_id", "tf-acc-cw-target"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "input", `{"source":["aws.cloudtrail"]}`),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCloudWatchEventTargetConfig_inputTransformer,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudWatchEventTargetExists("aws_cloudwatch_event_target.foo", &target),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "input", ""),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "input_transformer.#", "1"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "input_transformer.0.input_paths.instance", "$.detail.instance"),
+					resource.TestCheckResourceAttr("aws_cloudwatch_event_target.foo", "input_transformer.0.input_template", "\"<instance> changed\""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudWatchEventTargetExists(n string, target *events.Target) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cweconn
+		t, err := findCloudWatchEventTarget(conn,
+			rs.Primary.Attributes["rule"], rs.Primary.Attributes["target_id"])
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("CloudWatch Event Target %q not found", rs.Primary.ID)
+		}
+
+		*target = *t
+
+		return nil
+	}
+}
+
+func testAccCheckAWSCloudWatchEventTargetDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cweconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cloudwatch_event_target" {
+			continue
+		}
+
+		t, err := findCloudWatchEventTarget(conn,
+			rs.Primary.Attributes["rule"], rs.Primary.Attributes["target_id"])
+		if err == nil && t != nil {
+			return fmt.Errorf("CloudWatch Event Target %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccAWSCloudWatchEventTargetConfig = `
+resource "aws_cloudwatch_event_rule" "foo" {
+    name = "tf-acc-cw-event-target-rule"
+    schedule_expression = "rate(1 hour)"
+}
+
+resource "aws_sns_topic" "foo" {
+    name = "tf-acc-cw-event-target"
+}
+
+resource "aws_cloudwatch_event_target" "foo" {
+    rule = "${aws_cloudwatch_event_rule.foo.name}"
+    target_id = "tf-acc-cw-target"
+    arn = "${aws_sns_topic.foo.arn}"
+    input = <<INPUT
+{ "source": ["aws.cloudtrail"] }
+INPUT
+}
+`
+
+var testAccAWSCloudWatchEventTargetConfig_inputTransformer = `
+resource "aws_cloudwatch_event_rule" "foo" {
+    name = "tf-acc-cw-event-target-rule"
+    schedule_expression = "rate(1 hour)"
+}
+
+resource "aws_sns_topic" "foo" {
+    name = "tf-acc-cw-event-target"
+}
+
+resource "aws_cloudwatch_event_target" "foo" {
+    rule = "${aws_cloudwatch_event_rule.foo.name}"
+    target_id = "tf-acc-cw-target"
+    arn = "${aws_sns_topic.foo.arn}"
+
+    input_transformer {
+        input_paths {
+            instance = "$.detail.instance"
+        }
+        input_template = "\"<instance> changed\""
+    }
+}
+`
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/xanzy/terraform-api/helper/hashcode"
@@ -125,6 +126,35 @@ func resourceAwsCodeDeployDeploymentGroup() *schema.Resource {
 				},
 				Set: resourceAwsCodeDeployTagFilterHash,
 			},
+
+			"trigger_configuration": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"trigger_events": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateTriggerEvent,
+							},
+						},
+
+						"trigger_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"trigger_target_arn": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Set: resourceAwsCodeDeployTriggerConfigHash,
+			},
 		},
 	}
 }
@@ -146,7 +176,7 @@ func resourceAwsCodeDeployDeploymentGroupCreate(d *schema.ResourceData, meta int
 	if attr, ok := d.GetOk("autoscaling_groups"); ok {
 		input.AutoScalingGroups = expandStringList(attr.(*schema.Set).List())
 	}
-	if attr, ok := d.GetOk("on_premises_instance_tag_filters"); ok {
+	if attr, ok := d.GetOk("on_premises_instance_tag_filter"); ok {
 		onPremFilters := buildOnPremTagFilters(attr.(*schema.Set).List())
 		input.OnPremisesInstanceTagFilters = onPremFilters
 	}
@@ -154,6 +184,9 @@ func resourceAwsCodeDeployDeploymentGroupCreate(d *schema.ResourceData, meta int
 		ec2TagFilters := buildEC2TagFilters(attr.(*schema.Set).List())
 		input.Ec2TagFilters = ec2TagFilters
 	}
+	if attr, ok := d.GetOk("trigger_configuration"); ok {
+		input.TriggerConfigurations = buildTriggerConfigs(attr.(*schema.Set).List())
+	}
 
 	// Retry to handle IAM role eventual consistency.
 	var resp *codedeploy.CreateDeploymentGroupOutput
@@ -193,6 +226,11 @@ func resourceAwsCodeDeployDeploymentGroupRead(d *schema.ResourceData, meta inter
 		DeploymentGroupName: aws.String(d.Get("deployment_group_name").(string)),
 	})
 	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "DeploymentGroupDoesNotExistException" || awsErr.Code() == "ApplicationDoesNotExistException") {
+			log.Printf("[WARN] CodeDeploy DeploymentGroup %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -207,6 +245,9 @@ func resourceAwsCodeDeployDeploymentGroupRead(d *schema.ResourceData, meta inter
 	if err := d.Set("on_premises_instance_tag_filter", onPremisesTagFiltersToMap(resp.DeploymentGroupInfo.OnPremisesInstanceTagFilters)); err != nil {
 		return err
 	}
+	if err := d.Set("trigger_configuration", triggerConfigsToMap(resp.DeploymentGroupInfo.TriggerConfigurations)); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -244,6 +285,13 @@ func resourceAwsCodeDeployDeploymentGroupUpdate(d *schema.ResourceData, meta int
 		input.Ec2TagFilters = ec2Filters
 	}
 
+	// Triggers are replaced as a whole as well, an empty list removes all of
+	// them.
+	if d.HasChange("trigger_configuration") {
+		_, n := d.GetChange("trigger_configuration")
+		input.TriggerConfigurations = buildTriggerConfigs(n.(*schema.Set).List())
+	}
+
 	log.Printf("[DEBUG] Updating CodeDeploy DeploymentGroup %s", d.Id())
 	_, err := conn.UpdateDeploymentGroup(&input)
 	if err != nil {
@@ -306,6 +354,24 @@ func buildEC2TagFilters(configured []interface{}) []*codedeploy.EC2TagFilter {
 	return filters
 }
 
+// buildTriggerConfigs converts a raw schema list into a list of
+// codedeploy.TriggerConfig.
+func buildTriggerConfigs(configured []interface{}) []*codedeploy.TriggerConfig {
+	configs := make([]*codedeploy.TriggerConfig, 0, len(configured))
+	for _, raw := range configured {
+		var config codedeploy.TriggerConfig
+		m := raw.(map[string]interface{})
+
+		config.TriggerEvents = expandStringList(m["trigger_events"].(*schema.Set).List())
+		config.TriggerName = aws.String(m["trigger_name"].(string))
+		config.TriggerTargetArn = aws.String(m["trigger_target_arn"].(string))
+
+		configs = append(configs, &config)
+	}
+
+	return configs
+}
+
 // ec2TagFiltersToMap converts lists of tag filters into a []map[string]string.
 func ec2TagFiltersToMap(list []*codedeploy.EC2TagFilter) []map[string]string {
 	result := make([]map[string]string, 0, len(list))
@@ -344,6 +410,19 @@ func onPremisesTagFiltersToMap(list []*codedeploy.TagFilter) []map[string]string
 	return result
 }
 
+// triggerConfigsToMap converts a list of []*codedeploy.TriggerConfig into a []map[string]interface{}
+func triggerConfigsToMap(list []*codedeploy.TriggerConfig) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, tc := range list {
+		item := make(map[string]interface{})
+		item["trigger_events"] = schema.NewSet(schema.HashString, flattenStringList(tc.TriggerEvents))
+		item["trigger_name"] = aws.StringValue(tc.TriggerName)
+		item["trigger_target_arn"] = aws.StringValue(tc.TriggerTargetArn)
+		result = append(result, item)
+	}
+	return result
+}
+
 func resourceAwsCodeDeployTagFilterHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
@@ -362,3 +441,24 @@ func resourceAwsCodeDeployTagFilterHash(v interface{}) int {
 
 	return hashcode.String(buf.String())
 }
+
+func resourceAwsCodeDeployTriggerConfigHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["trigger_name"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["trigger_target_arn"].(string)))
+
+	if triggerEvents, ok := m["trigger_events"]; ok {
+		raw := triggerEvents.(*schema.Set).List()
+		events := make([]string, len(raw))
+		for i, e := range raw {
+			events[i] = e.(string)
+		}
+		sort.Strings(events)
+
+		for _, e := range events {
+			buf.WriteString(fmt.Sprintf("%s-", e))
+		}
+	}
+	return hashcode.String(buf.String())
+}
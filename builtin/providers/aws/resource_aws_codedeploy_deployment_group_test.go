@@ -2,12 +2,14 @@ package aws
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/codedeploy"
 	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
@@ -33,6 +35,90 @@ func TestAccAWSCodeDeployDeploymentGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSCodeDeployDeploymentGroup_triggerConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCodeDeployDeploymentGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSCodeDeployDeploymentGroupTriggerConfig(`"DeploymentFailure"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeDeployDeploymentGroupExists("aws_codedeploy_deployment_group.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_codedeploy_deployment_group.foo", "trigger_configuration.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSCodeDeployDeploymentGroupTriggerConfig(`"DeploymentFailure", "DeploymentSuccess"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSCodeDeployDeploymentGroupExists("aws_codedeploy_deployment_group.foo"),
+					resource.TestCheckResourceAttr(
+						"aws_codedeploy_deployment_group.foo", "trigger_configuration.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestBuildTriggerConfigs(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"trigger_events": schema.NewSet(schema.HashString, []interface{}{
+				"DeploymentFailure",
+			}),
+			"trigger_name":       "foo-trigger",
+			"trigger_target_arn": "arn:aws:sns:us-west-2:123456789012:foo-topic",
+		},
+	}
+
+	expected := []*codedeploy.TriggerConfig{
+		&codedeploy.TriggerConfig{
+			TriggerEvents: []*string{
+				aws.String("DeploymentFailure"),
+			},
+			TriggerName:      aws.String("foo-trigger"),
+			TriggerTargetArn: aws.String("arn:aws:sns:us-west-2:123456789012:foo-topic"),
+		},
+	}
+
+	actual := buildTriggerConfigs(input)
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("buildTriggerConfigs output is not correct.\nGot:\n%#v\nExpected:\n%#v\n",
+			actual, expected)
+	}
+}
+
+func TestTriggerConfigsToMap(t *testing.T) {
+	input := []*codedeploy.TriggerConfig{
+		&codedeploy.TriggerConfig{
+			TriggerEvents: []*string{
+				aws.String("DeploymentFailure"),
+				aws.String("InstanceFailure"),
+			},
+			TriggerName:      aws.String("bar-trigger"),
+			TriggerTargetArn: aws.String("arn:aws:sns:us-west-2:123456789012:bar-topic"),
+		},
+	}
+
+	actual := triggerConfigsToMap(input)
+	if len(actual) != 1 {
+		t.Fatalf("expected 1 trigger configuration, got %d", len(actual))
+	}
+
+	events := actual[0]["trigger_events"].(*schema.Set)
+	if events.Len() != 2 || !events.Contains("DeploymentFailure") || !events.Contains("InstanceFailure") {
+		t.Fatalf("bad trigger_events: %#v", events.List())
+	}
+	if actual[0]["trigger_name"] != "bar-trigger" {
+		t.Fatalf("bad trigger_name: %#v", actual[0]["trigger_name"])
+	}
+	if actual[0]["trigger_target_arn"] != "arn:aws:sns:us-west-2:123456789012:bar-topic" {
+		t.Fatalf("bad trigger_target_arn: %#v", actual[0]["trigger_target_arn"])
+	}
+}
+
 func testAccCheckAWSCodeDeployDeploymentGroupDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).codedeployconn
 
@@ -202,3 +288,76 @@ resource "aws_codedeploy_deployment_group" "foo" {
 		value = "filtervalue"
 	}
 }`
+
+func testAccAWSCodeDeployDeploymentGroupTriggerConfig(events string) string {
+	return fmt.Sprintf(`
+resource "aws_codedeploy_app" "foo_app" {
+	name = "foo_app"
+}
+
+resource "aws_sns_topic" "foo_topic" {
+	name = "foo-topic"
+}
+
+resource "aws_iam_role_policy" "foo_policy" {
+	name = "foo_policy"
+	role = "${aws_iam_role.foo_role.id}"
+	policy = <<EOF
+{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": [
+                "autoscaling:CompleteLifecycleAction",
+                "autoscaling:DeleteLifecycleHook",
+                "autoscaling:DescribeAutoScalingGroups",
+                "autoscaling:DescribeLifecycleHooks",
+                "autoscaling:PutLifecycleHook",
+                "autoscaling:RecordLifecycleActionHeartbeat",
+                "ec2:DescribeInstances",
+                "ec2:DescribeInstanceStatus",
+                "sns:Publish",
+                "tag:GetTags",
+                "tag:GetResources"
+            ],
+            "Resource": "*"
+        }
+    ]
+}
+EOF
+}
+
+resource "aws_iam_role" "foo_role" {
+	name = "foo_role"
+	assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "",
+      "Effect": "Allow",
+      "Principal": {
+        "Service": [
+          "codedeploy.amazonaws.com"
+        ]
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_codedeploy_deployment_group" "foo" {
+	app_name = "${aws_codedeploy_app.foo_app.name}"
+	deployment_group_name = "foo"
+	service_role_arn = "${aws_iam_role.foo_role.arn}"
+
+	trigger_configuration {
+		trigger_events = [%s]
+		trigger_name = "foo-trigger"
+		trigger_target_arn = "${aws_sns_topic.foo_topic.arn}"
+	}
+}`, events)
+}
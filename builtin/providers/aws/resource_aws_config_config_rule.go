@@ -0,0 +1,318 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/hashcode"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsConfigConfigRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsConfigConfigRulePut,
+		Read:   resourceAwsConfigConfigRuleRead,
+		Update: resourceAwsConfigConfigRulePut,
+		Delete: resourceAwsConfigConfigRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+			},
+
+			"rule_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 256),
+			},
+
+			"input_parameters": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    normalizeJson,
+				ValidateFunc: validation.JSONString,
+			},
+
+			"maximum_execution_frequency": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateConfigExecutionFrequency,
+			},
+
+			"scope": &schema.Schema{
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"compliance_resource_id": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 256),
+						},
+
+						"compliance_resource_types": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							MaxItems: 100,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"tag_key": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(1, 128),
+						},
+
+						"tag_value": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 256),
+						},
+					},
+				},
+			},
+
+			"source": &schema.Schema{
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"owner": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"CUSTOM_LAMBDA", "AWS"}, false),
+						},
+
+						"source_detail": &schema.Schema{
+							Type:     schema.TypeSet,
+							Set:      configRuleSourceDetailsHash,
+							Optional: true,
+							MaxItems: 25,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"event_source": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"maximum_execution_frequency": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateConfigExecutionFrequency,
+									},
+
+									"message_type": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"source_identifier": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsConfigConfigRulePut(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Get("name").(string)
+	ruleInput := configservice.ConfigRule{
+		ConfigRuleName: aws.String(name),
+		Source:         expandConfigRuleSource(d.Get("source").([]interface{})),
+	}
+
+	scopes := d.Get("scope").([]interface{})
+	if len(scopes) > 0 {
+		ruleInput.Scope = expandConfigRuleScope(scopes[0].(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		ruleInput.Description = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("input_parameters"); ok {
+		ruleInput.InputParameters = aws.String(normalizeJson(v.(string)))
+	}
+	if v, ok := d.GetOk("maximum_execution_frequency"); ok {
+		ruleInput.MaximumExecutionFrequency = aws.String(v.(string))
+	}
+
+	input := configservice.PutConfigRuleInput{
+		ConfigRule: &ruleInput,
+	}
+
+	log.Printf("[DEBUG] Creating AWSConfig config rule: %s", input)
+	// Retry to handle the eventual consistency of the permission that allows
+	// AWS Config to invoke a custom rule's Lambda function.
+	err := resource.Retry(2*time.Minute, func() error {
+		_, err := conn.PutConfigRule(&input)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InsufficientPermissionsException" {
+				log.Printf("[DEBUG] Retrying to put AWSConfig config rule: %s", awsErr.Message())
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to create AWSConfig rule: %s", err)
+	}
+
+	d.SetId(name)
+
+	log.Printf("[DEBUG] AWSConfig config rule %q created", name)
+
+	return resourceAwsConfigConfigRuleRead(d, meta)
+}
+
+func resourceAwsConfigConfigRuleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	out, err := conn.DescribeConfigRules(&configservice.DescribeConfigRulesInput{
+		ConfigRuleNames: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigRuleException" {
+			log.Printf("[WARN] Config Rule %q is gone (NoSuchConfigRuleException)", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	numberOfRules := len(out.ConfigRules)
+	if numberOfRules < 1 {
+		log.Printf("[WARN] Config Rule %q is gone (no rules found)", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if numberOfRules > 1 {
+		return fmt.Errorf("Expected exactly 1 Config Rule, received %d: %#v",
+			numberOfRules, out.ConfigRules)
+	}
+
+	log.Printf("[DEBUG] AWS Config config rule received: %s", out)
+
+	rule := out.ConfigRules[0]
+	d.Set("arn", rule.ConfigRuleArn)
+	d.Set("rule_id", rule.ConfigRuleId)
+	d.Set("name", rule.ConfigRuleName)
+	d.Set("description", aws.StringValue(rule.Description))
+	d.Set("maximum_execution_frequency", aws.StringValue(rule.MaximumExecutionFrequency))
+
+	if rule.InputParameters != nil {
+		d.Set("input_parameters", normalizeJson(*rule.InputParameters))
+	} else {
+		d.Set("input_parameters", "")
+	}
+
+	if rule.Scope != nil {
+		d.Set("scope", flattenConfigRuleScope(rule.Scope))
+	}
+
+	d.Set("source", flattenConfigRuleSource(rule.Source))
+
+	return nil
+}
+
+func resourceAwsConfigConfigRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Deleting AWS Config config rule %q", name)
+	_, err := conn.DeleteConfigRule(&configservice.DeleteConfigRuleInput{
+		ConfigRuleName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigRuleException" {
+			return nil
+		}
+		return fmt.Errorf("Deleting Config Rule failed: %s", err)
+	}
+
+	// Deleting a rule is asynchronous, wait for it to be gone so a rule with
+	// the same name can be created right away.
+	conf := resource.StateChangeConf{
+		Pending: []string{
+			configservice.ConfigRuleStateActive,
+			configservice.ConfigRuleStateDeleting,
+			configservice.ConfigRuleStateDeletingResults,
+			configservice.ConfigRuleStateEvaluating,
+		},
+		Target:  "",
+		Timeout: 5 * time.Minute,
+		Refresh: func() (interface{}, string, error) {
+			out, err := conn.DescribeConfigRules(&configservice.DescribeConfigRulesInput{
+				ConfigRuleNames: []*string{aws.String(d.Id())},
+			})
+			if err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigRuleException" {
+					return 42, "", nil
+				}
+				return 42, "", fmt.Errorf("Failed to describe Config Rule %q: %s", d.Id(), err)
+			}
+			if len(out.ConfigRules) < 1 {
+				return 42, "", nil
+			}
+			rule := out.ConfigRules[0]
+			return out, *rule.ConfigRuleState, nil
+		},
+	}
+	if _, err := conf.WaitForState(); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] AWS Config config rule %q deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func configRuleSourceDetailsHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["message_type"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["event_source"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["maximum_execution_frequency"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	return hashcode.String(buf.String())
+}
@@ -0,0 +1,184 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSConfigConfigRule_basic(t *testing.T) {
+	var cr configservice.ConfigRule
+	rInt := acctest.RandInt()
+	expectedName := fmt.Sprintf("tf-acc-test-%d", rInt)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigConfigRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigConfigRuleConfig_basic(rInt, "Three_Hours"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigRuleExists("aws_config_config_rule.foo", &cr),
+					testAccCheckConfigConfigRuleName("aws_config_config_rule.foo", expectedName, &cr),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "name", expectedName),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "maximum_execution_frequency", "Three_Hours"),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "source.#", "1"),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "source.0.owner", "AWS"),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "source.0.source_identifier", "S3_BUCKET_VERSIONING_ENABLED"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccConfigConfigRuleConfig_basic(rInt, "Six_Hours"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigRuleExists("aws_config_config_rule.foo", &cr),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "maximum_execution_frequency", "Six_Hours"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSConfigConfigRule_scope(t *testing.T) {
+	var cr configservice.ConfigRule
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigConfigRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigConfigRuleConfig_scope(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigRuleExists("aws_config_config_rule.foo", &cr),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "scope.#", "1"),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "scope.0.compliance_resource_types.#", "1"),
+					resource.TestCheckResourceAttr("aws_config_config_rule.foo", "input_parameters", `{"desiredInstanceType":"t2.micro"}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConfigConfigRuleName(n, desired string, obj *configservice.ConfigRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.Attributes["name"] != *obj.ConfigRuleName {
+			return fmt.Errorf("Expected name: %q, given: %q", desired, *obj.ConfigRuleName)
+		}
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigRuleExists(n string, obj *configservice.ConfigRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No config rule ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).configconn
+		out, err := conn.DescribeConfigRules(&configservice.DescribeConfigRulesInput{
+			ConfigRuleNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to describe config rule: %s", err)
+		}
+		if len(out.ConfigRules) < 1 {
+			return fmt.Errorf("No config rule found when describing %q", rs.Primary.Attributes["name"])
+		}
+
+		*obj = *out.ConfigRules[0]
+
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigRuleDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).configconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_config_config_rule" {
+			continue
+		}
+
+		resp, err := conn.DescribeConfigRules(&configservice.DescribeConfigRulesInput{
+			ConfigRuleNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigRuleException" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.ConfigRules) != 0 {
+			return fmt.Errorf("Expected AWS Config Rule to be destroyed, %d found", len(resp.ConfigRules))
+		}
+	}
+
+	return nil
+}
+
+func testAccConfigConfigRuleConfig_basic(randInt int, frequency string) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+
+resource "aws_config_config_rule" "foo" {
+  name = "tf-acc-test-%d"
+  maximum_execution_frequency = "%s"
+
+  source {
+    owner = "AWS"
+    source_identifier = "S3_BUCKET_VERSIONING_ENABLED"
+  }
+
+  depends_on = ["aws_config_configuration_recorder.foo"]
+}
+`, randInt, randInt, frequency)
+}
+
+func testAccConfigConfigRuleConfig_scope(randInt int) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+
+resource "aws_config_config_rule" "foo" {
+  name = "tf-acc-test-%d"
+  description = "Checks the instance types of EC2 instances"
+  input_parameters = <<PARAMS
+{ "desiredInstanceType": "t2.micro" }
+PARAMS
+
+  scope {
+    compliance_resource_types = ["AWS::EC2::Instance"]
+  }
+
+  source {
+    owner = "AWS"
+    source_identifier = "DESIRED_INSTANCE_TYPE"
+  }
+
+  depends_on = ["aws_config_configuration_recorder.foo"]
+}
+`, randInt, randInt)
+}
@@ -0,0 +1,161 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsConfigConfigurationRecorder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsConfigConfigurationRecorderPut,
+		Read:   resourceAwsConfigConfigurationRecorderRead,
+		Update: resourceAwsConfigConfigurationRecorderPut,
+		Delete: resourceAwsConfigConfigurationRecorderDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+
+			"role_arn": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"recording_group": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"all_supported": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"include_global_resource_types": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"resource_types": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsConfigConfigurationRecorderPut(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Get("name").(string)
+	recorder := configservice.ConfigurationRecorder{
+		Name:    aws.String(name),
+		RoleARN: aws.String(d.Get("role_arn").(string)),
+	}
+
+	if g, ok := d.GetOk("recording_group"); ok {
+		recorder.RecordingGroup = expandConfigRecordingGroup(g.([]interface{}))
+	}
+
+	input := configservice.PutConfigurationRecorderInput{
+		ConfigurationRecorder: &recorder,
+	}
+
+	log.Printf("[DEBUG] Putting AWS Config Configuration Recorder: %s", input)
+	// Retry to handle IAM role eventual consistency.
+	err := resource.Retry(2*time.Minute, func() error {
+		_, err := conn.PutConfigurationRecorder(&input)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidRoleException" {
+				log.Printf("[DEBUG] Retrying to put AWS Config Configuration Recorder: %s", awsErr.Message())
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Creating Configuration Recorder failed: %s", err)
+	}
+
+	d.SetId(name)
+
+	return resourceAwsConfigConfigurationRecorderRead(d, meta)
+}
+
+func resourceAwsConfigConfigurationRecorderRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	input := configservice.DescribeConfigurationRecordersInput{
+		ConfigurationRecorderNames: []*string{aws.String(d.Id())},
+	}
+	out, err := conn.DescribeConfigurationRecorders(&input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+			log.Printf("[WARN] Configuration Recorder %q is gone (NoSuchConfigurationRecorderException)", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Getting Configuration Recorder failed: %s", err)
+	}
+
+	if len(out.ConfigurationRecorders) == 0 {
+		log.Printf("[WARN] Configuration Recorder %q is gone", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	recorder := out.ConfigurationRecorders[0]
+
+	d.Set("name", recorder.Name)
+	d.Set("role_arn", recorder.RoleARN)
+
+	if recorder.RecordingGroup != nil {
+		if err := d.Set("recording_group", flattenConfigRecordingGroup(recorder.RecordingGroup)); err != nil {
+			return fmt.Errorf("Failed to set recording_group: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsConfigConfigurationRecorderDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	input := configservice.DeleteConfigurationRecorderInput{
+		ConfigurationRecorderName: aws.String(d.Id()),
+	}
+
+	log.Printf("[DEBUG] Deleting AWS Config Configuration Recorder %q", d.Id())
+	if _, err := conn.DeleteConfigurationRecorder(&input); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+			return nil
+		}
+		return fmt.Errorf("Deleting Configuration Recorder failed: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
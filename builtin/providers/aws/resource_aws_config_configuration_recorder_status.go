@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsConfigConfigurationRecorderStatus() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsConfigConfigurationRecorderStatusPut,
+		Read:   resourceAwsConfigConfigurationRecorderStatusRead,
+		Update: resourceAwsConfigConfigurationRecorderStatusPut,
+		Delete: resourceAwsConfigConfigurationRecorderStatusDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"is_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAwsConfigConfigurationRecorderStatusPut(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Get("name").(string)
+	d.SetId(name)
+
+	if d.HasChange("is_enabled") {
+		if d.Get("is_enabled").(bool) {
+			log.Printf("[DEBUG] Starting AWS Config Configuration Recorder %q", name)
+			_, err := conn.StartConfigurationRecorder(&configservice.StartConfigurationRecorderInput{
+				ConfigurationRecorderName: aws.String(name),
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to start Configuration Recorder: %s", err)
+			}
+		} else {
+			log.Printf("[DEBUG] Stopping AWS Config Configuration Recorder %q", name)
+			_, err := conn.StopConfigurationRecorder(&configservice.StopConfigurationRecorderInput{
+				ConfigurationRecorderName: aws.String(name),
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to stop Configuration Recorder: %s", err)
+			}
+		}
+	}
+
+	return resourceAwsConfigConfigurationRecorderStatusRead(d, meta)
+}
+
+func resourceAwsConfigConfigurationRecorderStatusRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Id()
+	statusInput := configservice.DescribeConfigurationRecorderStatusInput{
+		ConfigurationRecorderNames: []*string{aws.String(name)},
+	}
+	statusOut, err := conn.DescribeConfigurationRecorderStatus(&statusInput)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+			log.Printf("[WARN] Configuration Recorder (status) %q is gone (NoSuchConfigurationRecorderException)", name)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Failed describing Configuration Recorder %q status: %s", name, err)
+	}
+
+	if len(statusOut.ConfigurationRecordersStatus) != 1 {
+		return fmt.Errorf("Expected exactly 1 Configuration Recorder (status), received %d: %#v",
+			len(statusOut.ConfigurationRecordersStatus), statusOut.ConfigurationRecordersStatus)
+	}
+
+	d.Set("name", name)
+	d.Set("is_enabled", statusOut.ConfigurationRecordersStatus[0].Recording)
+
+	return nil
+}
+
+func resourceAwsConfigConfigurationRecorderStatusDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	input := configservice.StopConfigurationRecorderInput{
+		ConfigurationRecorderName: aws.String(d.Get("name").(string)),
+	}
+
+	log.Printf("[DEBUG] Stopping AWS Config Configuration Recorder %q", d.Id())
+	if _, err := conn.StopConfigurationRecorder(&input); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+			return nil
+		}
+		return fmt.Errorf("Stopping Configuration Recorder failed: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSConfigConfigurationRecorderStatus_basic(t *testing.T) {
+	var cr configservice.ConfigurationRecorder
+	var crs configservice.ConfigurationRecorderStatus
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigConfigurationRecorderStatusDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigConfigurationRecorderStatusConfig(rInt, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigurationRecorderExists("aws_config_configuration_recorder.foo", &cr),
+					testAccCheckConfigConfigurationRecorderStatusExists("aws_config_configuration_recorder_status.foo", &crs),
+					testAccCheckConfigConfigurationRecorderStatus("aws_config_configuration_recorder_status.foo", true, &crs),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder_status.foo", "is_enabled", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccConfigConfigurationRecorderStatusConfig(rInt, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigurationRecorderStatusExists("aws_config_configuration_recorder_status.foo", &crs),
+					testAccCheckConfigConfigurationRecorderStatus("aws_config_configuration_recorder_status.foo", false, &crs),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder_status.foo", "is_enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConfigConfigurationRecorderStatusExists(n string, obj *configservice.ConfigurationRecorderStatus) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).configconn
+		out, err := conn.DescribeConfigurationRecorderStatus(&configservice.DescribeConfigurationRecorderStatusInput{
+			ConfigurationRecorderNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to describe status of configuration recorder: %s", err)
+		}
+		if len(out.ConfigurationRecordersStatus) < 1 {
+			return fmt.Errorf("Configuration Recorder %q not found", rs.Primary.Attributes["name"])
+		}
+
+		*obj = *out.ConfigurationRecordersStatus[0]
+
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigurationRecorderStatus(n string, desired bool, obj *configservice.ConfigurationRecorderStatus) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *obj.Recording != desired {
+			return fmt.Errorf("Expected configuration recorder %q recording to be %t, given: %t",
+				n, desired, *obj.Recording)
+		}
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigurationRecorderStatusDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).configconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_config_configuration_recorder_status" {
+			continue
+		}
+
+		resp, err := conn.DescribeConfigurationRecorderStatus(&configservice.DescribeConfigurationRecorderStatusInput{
+			ConfigurationRecorderNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+				continue
+			}
+			return err
+		}
+
+		for _, status := range resp.ConfigurationRecordersStatus {
+			if *status.Recording {
+				return fmt.Errorf("Configuration recorder %q is still recording", *status.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccConfigConfigurationRecorderStatusConfig(randInt int, enabled bool) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+
+resource "aws_config_delivery_channel" "foo" {
+  name = "tf-acc-test-%d"
+  s3_bucket_name = "${aws_s3_bucket.b.bucket}"
+  depends_on = ["aws_config_configuration_recorder.foo"]
+}
+
+resource "aws_config_configuration_recorder_status" "foo" {
+  name = "${aws_config_configuration_recorder.foo.name}"
+  is_enabled = %t
+  depends_on = ["aws_config_delivery_channel.foo"]
+}
+`, randInt, randInt, enabled)
+}
@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSConfigConfigurationRecorder_basic(t *testing.T) {
+	var cr configservice.ConfigurationRecorder
+	rInt := acctest.RandInt()
+	expectedName := fmt.Sprintf("tf-acc-test-%d", rInt)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigConfigurationRecorderDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigConfigurationRecorderConfig_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigurationRecorderExists("aws_config_configuration_recorder.foo", &cr),
+					testAccCheckConfigConfigurationRecorderName(&cr, expectedName),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder.foo", "name", expectedName),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder.foo", "recording_group.#", "1"),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder.foo", "recording_group.0.all_supported", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSConfigConfigurationRecorder_resourceTypes(t *testing.T) {
+	var cr configservice.ConfigurationRecorder
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigConfigurationRecorderDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigConfigurationRecorderConfig_resourceTypes(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigConfigurationRecorderExists("aws_config_configuration_recorder.foo", &cr),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder.foo", "recording_group.0.all_supported", "false"),
+					resource.TestCheckResourceAttr("aws_config_configuration_recorder.foo", "recording_group.0.resource_types.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConfigConfigurationRecorderName(cr *configservice.ConfigurationRecorder, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *cr.Name != expected {
+			return fmt.Errorf("Expected name %q, given %q", expected, *cr.Name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigurationRecorderExists(n string, obj *configservice.ConfigurationRecorder) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No configuration recorder ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).configconn
+		out, err := conn.DescribeConfigurationRecorders(&configservice.DescribeConfigurationRecordersInput{
+			ConfigurationRecorderNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to describe configuration recorder: %s", err)
+		}
+		if len(out.ConfigurationRecorders) < 1 {
+			return fmt.Errorf("No configuration recorder found when describing %q", rs.Primary.Attributes["name"])
+		}
+
+		*obj = *out.ConfigurationRecorders[0]
+
+		return nil
+	}
+}
+
+func testAccCheckConfigConfigurationRecorderDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).configconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_config_configuration_recorder" {
+			continue
+		}
+
+		resp, err := conn.DescribeConfigurationRecorders(&configservice.DescribeConfigurationRecordersInput{
+			ConfigurationRecorderNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchConfigurationRecorderException" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.ConfigurationRecorders) != 0 {
+			return fmt.Errorf("Configuration recorder %q still exists", rs.Primary.Attributes["name"])
+		}
+	}
+
+	return nil
+}
+
+// testAccConfigConfigurationRecorderRole is the IAM role AWS Config assumes
+// in all the acceptance tests of the Config resources.
+func testAccConfigConfigurationRecorderRole(randInt int) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "r" {
+  name = "tf-acc-test-awsconfig-%d"
+  assume_role_policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "config.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+POLICY
+}
+
+resource "aws_iam_role_policy" "p" {
+  name = "tf-acc-test-awsconfig-%d"
+  role = "${aws_iam_role.r.id}"
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "s3:*"
+      ],
+      "Effect": "Allow",
+      "Resource": [
+        "${aws_s3_bucket.b.arn}",
+        "${aws_s3_bucket.b.arn}/*"
+      ]
+    },
+    {
+      "Action": [
+        "config:Put*",
+        "ec2:Describe*",
+        "sns:Publish"
+      ],
+      "Effect": "Allow",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_s3_bucket" "b" {
+  bucket = "tf-acc-test-awsconfig-%d"
+  force_destroy = true
+}
+`, randInt, randInt, randInt)
+}
+
+func testAccConfigConfigurationRecorderConfig_basic(randInt int) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+`, randInt)
+}
+
+func testAccConfigConfigurationRecorderConfig_resourceTypes(randInt int) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+
+  recording_group {
+    all_supported = false
+    resource_types = ["AWS::EC2::Instance", "AWS::CloudTrail::Trail"]
+  }
+}
+`, randInt)
+}
@@ -0,0 +1,186 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsConfigDeliveryChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsConfigDeliveryChannelPut,
+		Read:   resourceAwsConfigDeliveryChannelRead,
+		Update: resourceAwsConfigDeliveryChannelPut,
+		Delete: resourceAwsConfigDeliveryChannelDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+
+			"s3_bucket_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"s3_key_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"sns_topic_arn": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"snapshot_delivery_properties": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"delivery_frequency": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateConfigExecutionFrequency,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsConfigDeliveryChannelPut(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	name := d.Get("name").(string)
+	channel := configservice.DeliveryChannel{
+		Name:         aws.String(name),
+		S3BucketName: aws.String(d.Get("s3_bucket_name").(string)),
+	}
+
+	if v, ok := d.GetOk("s3_key_prefix"); ok {
+		channel.S3KeyPrefix = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("sns_topic_arn"); ok {
+		channel.SnsTopicARN = aws.String(v.(string))
+	}
+
+	if p, ok := d.GetOk("snapshot_delivery_properties"); ok {
+		propertiesBlocks := p.([]interface{})
+		block := propertiesBlocks[0].(map[string]interface{})
+
+		if v, ok := block["delivery_frequency"]; ok && v.(string) != "" {
+			channel.ConfigSnapshotDeliveryProperties = &configservice.ConfigSnapshotDeliveryProperties{
+				DeliveryFrequency: aws.String(v.(string)),
+			}
+		}
+	}
+
+	input := configservice.PutDeliveryChannelInput{DeliveryChannel: &channel}
+
+	log.Printf("[DEBUG] Putting AWS Config Delivery Channel: %s", input)
+	// Retry to handle the eventual consistency of the IAM role and the S3
+	// bucket policy the channel delivers with.
+	err := resource.Retry(2*time.Minute, func() error {
+		_, err := conn.PutDeliveryChannel(&input)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InsufficientDeliveryPolicyException" {
+				log.Printf("[DEBUG] Retrying to put AWS Config Delivery Channel: %s", awsErr.Message())
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Creating Delivery Channel failed: %s", err)
+	}
+
+	d.SetId(name)
+
+	return resourceAwsConfigDeliveryChannelRead(d, meta)
+}
+
+func resourceAwsConfigDeliveryChannelRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	input := configservice.DescribeDeliveryChannelsInput{
+		DeliveryChannelNames: []*string{aws.String(d.Id())},
+	}
+	out, err := conn.DescribeDeliveryChannels(&input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchDeliveryChannelException" {
+			log.Printf("[WARN] Delivery Channel %q is gone (NoSuchDeliveryChannelException)", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Getting Delivery Channel failed: %s", err)
+	}
+
+	if len(out.DeliveryChannels) == 0 {
+		log.Printf("[WARN] Delivery Channel %q is gone", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	channel := out.DeliveryChannels[0]
+
+	d.Set("name", channel.Name)
+	d.Set("s3_bucket_name", channel.S3BucketName)
+	d.Set("s3_key_prefix", aws.StringValue(channel.S3KeyPrefix))
+	d.Set("sns_topic_arn", aws.StringValue(channel.SnsTopicARN))
+
+	if channel.ConfigSnapshotDeliveryProperties != nil {
+		d.Set("snapshot_delivery_properties", []map[string]interface{}{{
+			"delivery_frequency": aws.StringValue(channel.ConfigSnapshotDeliveryProperties.DeliveryFrequency),
+		}})
+	}
+
+	return nil
+}
+
+func resourceAwsConfigDeliveryChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).configconn
+
+	input := configservice.DeleteDeliveryChannelInput{
+		DeliveryChannelName: aws.String(d.Id()),
+	}
+
+	log.Printf("[DEBUG] Deleting AWS Config Delivery Channel %q", d.Id())
+	// The last delivery channel can't be deleted while the recorder is
+	// running, and stopping the recorder takes a moment to propagate.
+	err := resource.Retry(1*time.Minute, func() error {
+		_, err := conn.DeleteDeliveryChannel(&input)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "LastDeliveryChannelDeleteFailedException" {
+				log.Printf("[DEBUG] Retrying to delete AWS Config Delivery Channel: %s", awsErr.Message())
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchDeliveryChannelException" {
+			return nil
+		}
+		return fmt.Errorf("Unable to delete Delivery Channel: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
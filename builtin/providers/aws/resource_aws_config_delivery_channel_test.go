@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSConfigDeliveryChannel_basic(t *testing.T) {
+	var dc configservice.DeliveryChannel
+	rInt := acctest.RandInt()
+	expectedName := fmt.Sprintf("tf-acc-test-%d", rInt)
+	expectedBucketName := fmt.Sprintf("tf-acc-test-awsconfig-%d", rInt)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigDeliveryChannelDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigDeliveryChannelConfig_basic(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigDeliveryChannelExists("aws_config_delivery_channel.foo", &dc),
+					resource.TestCheckResourceAttr("aws_config_delivery_channel.foo", "name", expectedName),
+					resource.TestCheckResourceAttr("aws_config_delivery_channel.foo", "s3_bucket_name", expectedBucketName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSConfigDeliveryChannel_allParams(t *testing.T) {
+	var dc configservice.DeliveryChannel
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigDeliveryChannelDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConfigDeliveryChannelConfig_allParams(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConfigDeliveryChannelExists("aws_config_delivery_channel.foo", &dc),
+					resource.TestCheckResourceAttr("aws_config_delivery_channel.foo", "s3_key_prefix", "one/two/three"),
+					resource.TestCheckResourceAttr("aws_config_delivery_channel.foo", "snapshot_delivery_properties.#", "1"),
+					resource.TestCheckResourceAttr("aws_config_delivery_channel.foo", "snapshot_delivery_properties.0.delivery_frequency", "Six_Hours"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConfigDeliveryChannelExists(n string, obj *configservice.DeliveryChannel) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).configconn
+		out, err := conn.DescribeDeliveryChannels(&configservice.DescribeDeliveryChannelsInput{
+			DeliveryChannelNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to describe delivery channel: %s", err)
+		}
+		if len(out.DeliveryChannels) < 1 {
+			return fmt.Errorf("No delivery channel found when describing %q", rs.Primary.Attributes["name"])
+		}
+
+		*obj = *out.DeliveryChannels[0]
+
+		return nil
+	}
+}
+
+func testAccCheckConfigDeliveryChannelDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).configconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_config_delivery_channel" {
+			continue
+		}
+
+		resp, err := conn.DescribeDeliveryChannels(&configservice.DescribeDeliveryChannelsInput{
+			DeliveryChannelNames: []*string{aws.String(rs.Primary.Attributes["name"])},
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchDeliveryChannelException" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.DeliveryChannels) != 0 {
+			return fmt.Errorf("Expected AWS Config Delivery Channel to be destroyed, %d found",
+				len(resp.DeliveryChannels))
+		}
+	}
+
+	return nil
+}
+
+func testAccConfigDeliveryChannelConfig_basic(randInt int) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+
+resource "aws_config_delivery_channel" "foo" {
+  name = "tf-acc-test-%d"
+  s3_bucket_name = "${aws_s3_bucket.b.bucket}"
+  depends_on = ["aws_config_configuration_recorder.foo"]
+}
+`, randInt, randInt)
+}
+
+func testAccConfigDeliveryChannelConfig_allParams(randInt int) string {
+	return testAccConfigConfigurationRecorderRole(randInt) + fmt.Sprintf(`
+resource "aws_config_configuration_recorder" "foo" {
+  name = "tf-acc-test-%d"
+  role_arn = "${aws_iam_role.r.arn}"
+}
+
+resource "aws_sns_topic" "t" {
+  name = "tf-acc-test-%d"
+}
+
+resource "aws_config_delivery_channel" "foo" {
+  name = "tf-acc-test-%d"
+  s3_bucket_name = "${aws_s3_bucket.b.bucket}"
+  s3_key_prefix = "one/two/three"
+  sns_topic_arn = "${aws_sns_topic.t.arn}"
+
+  snapshot_delivery_properties {
+    delivery_frequency = "Six_Hours"
+  }
+
+  depends_on = ["aws_config_configuration_recorder.foo"]
+}
+`, randInt, randInt, randInt)
+}
@@ -0,0 +1,330 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsDbEventSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDbEventSubscriptionCreate,
+		Read:   resourceAwsDbEventSubscriptionRead,
+		Update: resourceAwsDbEventSubscriptionUpdate,
+		Delete: resourceAwsDbEventSubscriptionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 255),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z-]+$`),
+						"can only contain alphanumeric characters and hyphens"),
+				),
+			},
+
+			"sns_topic": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			"event_categories": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"source_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"source_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"customer_aws_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAwsDbEventSubscriptionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	name := d.Get("name").(string)
+	req := &rds.CreateEventSubscriptionInput{
+		SubscriptionName: aws.String(name),
+		SnsTopicArn:      aws.String(d.Get("sns_topic").(string)),
+		Enabled:          aws.Bool(d.Get("enabled").(bool)),
+		Tags:             tagsFromMapRDS(d.Get("tags").(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("source_type"); ok {
+		req.SourceType = aws.String(v.(string))
+	}
+
+	if attr := d.Get("event_categories").(*schema.Set); attr.Len() > 0 {
+		req.EventCategories = expandStringList(attr.List())
+	}
+
+	if attr := d.Get("source_ids").(*schema.Set); attr.Len() > 0 {
+		req.SourceIds = expandStringList(attr.List())
+	}
+
+	log.Printf("[DEBUG] Creating RDS Event Subscription: %s", req)
+	_, err := conn.CreateEventSubscription(req)
+	if err != nil {
+		return fmt.Errorf("Error creating RDS Event Subscription %s: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	log.Println("[INFO] Waiting for RDS Event Subscription to be ready")
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     "active",
+		Refresh:    resourceAwsDbEventSubscriptionRefreshFunc(conn, d.Id()),
+		Timeout:    40 * time.Minute,
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for RDS Event Subscription %s to become active: %s", d.Id(), err)
+	}
+
+	return resourceAwsDbEventSubscriptionRead(d, meta)
+}
+
+func resourceAwsDbEventSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	sub, err := resourceAwsDbEventSubscriptionRetrieve(conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error retrieving RDS Event Subscription %s: %s", d.Id(), err)
+	}
+	if sub == nil {
+		log.Printf("[WARN] RDS Event Subscription (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", sub.CustSubscriptionId)
+	d.Set("sns_topic", sub.SnsTopicArn)
+	d.Set("source_type", sub.SourceType)
+	d.Set("enabled", sub.Enabled)
+	d.Set("customer_aws_id", sub.CustomerAwsId)
+
+	if err := d.Set("source_ids", aws.StringValueSlice(sub.SourceIdsList)); err != nil {
+		return fmt.Errorf("Error saving source_ids for RDS Event Subscription %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("event_categories", aws.StringValueSlice(sub.EventCategoriesList)); err != nil {
+		return fmt.Errorf("Error saving event_categories for RDS Event Subscription %s: %s", d.Id(), err)
+	}
+
+	arn := buildRDSEventSubscriptionARN(meta.(*AWSClient).region, *sub.CustomerAwsId, d.Id())
+	if err := saveTagsRDS(conn, d, arn); err != nil {
+		log.Printf("[WARN] Failed to save tags for RDS Event Subscription (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsDbEventSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	d.Partial(true)
+
+	req := &rds.ModifyEventSubscriptionInput{
+		SubscriptionName: aws.String(d.Id()),
+	}
+
+	requestUpdate := false
+	if d.HasChange("sns_topic") {
+		req.SnsTopicArn = aws.String(d.Get("sns_topic").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("enabled") {
+		req.Enabled = aws.Bool(d.Get("enabled").(bool))
+		requestUpdate = true
+	}
+
+	// The source type and event categories are replaced together, leaving
+	// out the event categories subscribes to all of them.
+	if d.HasChange("source_type") || d.HasChange("event_categories") {
+		req.SourceType = aws.String(d.Get("source_type").(string))
+		req.EventCategories = expandStringList(d.Get("event_categories").(*schema.Set).List())
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Modifying RDS Event Subscription: %s", req)
+		if _, err := conn.ModifyEventSubscription(req); err != nil {
+			return fmt.Errorf("Error modifying RDS Event Subscription %s: %s", d.Id(), err)
+		}
+
+		log.Println("[INFO] Waiting for RDS Event Subscription modification to finish")
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"modifying"},
+			Target:     "active",
+			Refresh:    resourceAwsDbEventSubscriptionRefreshFunc(conn, d.Id()),
+			Timeout:    40 * time.Minute,
+			MinTimeout: 10 * time.Second,
+			Delay:      30 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for RDS Event Subscription %s to be modified: %s", d.Id(), err)
+		}
+
+		d.SetPartial("sns_topic")
+		d.SetPartial("enabled")
+		d.SetPartial("source_type")
+		d.SetPartial("event_categories")
+	}
+
+	if d.HasChange("source_ids") {
+		o, n := d.GetChange("source_ids")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		for _, id := range os.Difference(ns).List() {
+			log.Printf("[DEBUG] Removing source %s from RDS Event Subscription %s", id, d.Id())
+			_, err := conn.RemoveSourceIdentifierFromSubscription(&rds.RemoveSourceIdentifierFromSubscriptionInput{
+				SourceIdentifier: aws.String(id.(string)),
+				SubscriptionName: aws.String(d.Id()),
+			})
+			if err != nil {
+				return fmt.Errorf("Error removing source %s from RDS Event Subscription %s: %s", id, d.Id(), err)
+			}
+		}
+
+		for _, id := range ns.Difference(os).List() {
+			log.Printf("[DEBUG] Adding source %s to RDS Event Subscription %s", id, d.Id())
+			_, err := conn.AddSourceIdentifierToSubscription(&rds.AddSourceIdentifierToSubscriptionInput{
+				SourceIdentifier: aws.String(id.(string)),
+				SubscriptionName: aws.String(d.Id()),
+			})
+			if err != nil {
+				return fmt.Errorf("Error adding source %s to RDS Event Subscription %s: %s", id, d.Id(), err)
+			}
+		}
+
+		d.SetPartial("source_ids")
+	}
+
+	arn := buildRDSEventSubscriptionARN(meta.(*AWSClient).region, d.Get("customer_aws_id").(string), d.Id())
+	if err := setTagsRDS(conn, d, arn); err != nil {
+		return err
+	}
+	d.SetPartial("tags")
+
+	d.Partial(false)
+
+	return resourceAwsDbEventSubscriptionRead(d, meta)
+}
+
+func resourceAwsDbEventSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	log.Printf("[DEBUG] Deleting RDS Event Subscription: %s", d.Id())
+	_, err := conn.DeleteEventSubscription(&rds.DeleteEventSubscriptionInput{
+		SubscriptionName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "SubscriptionNotFound" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting RDS Event Subscription %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     "destroyed",
+		Refresh:    resourceAwsDbEventSubscriptionRefreshFunc(conn, d.Id()),
+		Timeout:    40 * time.Minute,
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for RDS Event Subscription %s to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsDbEventSubscriptionRetrieve returns the event subscription
+// with the given name, or nil if there is no such subscription.
+func resourceAwsDbEventSubscriptionRetrieve(conn *rds.RDS, name string) (*rds.EventSubscription, error) {
+	resp, err := conn.DescribeEventSubscriptions(&rds.DescribeEventSubscriptionsInput{
+		SubscriptionName: aws.String(name),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "SubscriptionNotFound" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(resp.EventSubscriptionsList) != 1 ||
+		*resp.EventSubscriptionsList[0].CustSubscriptionId != name {
+		return nil, nil
+	}
+
+	return resp.EventSubscriptionsList[0], nil
+}
+
+func resourceAwsDbEventSubscriptionRefreshFunc(conn *rds.RDS, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		sub, err := resourceAwsDbEventSubscriptionRetrieve(conn, name)
+		if err != nil {
+			log.Printf("[WARN] Error on retrieving RDS Event Subscription when waiting: %s", err)
+			return nil, "", err
+		}
+
+		if sub == nil {
+			return 42, "destroyed", nil
+		}
+
+		if sub.Status != nil {
+			log.Printf("[DEBUG] RDS Event Subscription status for %s: %s", name, *sub.Status)
+		}
+
+		return sub, aws.StringValue(sub.Status), nil
+	}
+}
+
+// buildRDSEventSubscriptionARN returns the ARN of an event subscription,
+// which is needed to manage its tags.
+func buildRDSEventSubscriptionARN(region, customerAwsId, name string) string {
+	return fmt.Sprintf("arn:aws:rds:%s:%s:es:%s", region, customerAwsId, name)
+}
@@ -0,0 +1,135 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSDBEventSubscription_basicUpdate(t *testing.T) {
+	var v rds.EventSubscription
+	rName := fmt.Sprintf("tf-acc-test-rds-event-subs-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBEventSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSDBEventSubscriptionConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBEventSubscriptionExists("aws_db_event_subscription.bar", &v),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "enabled", "true"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "source_type", "db-instance"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "event_categories.#", "5"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "tags.Name", "name"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSDBEventSubscriptionConfigUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBEventSubscriptionExists("aws_db_event_subscription.bar", &v),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "enabled", "false"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "source_type", "db-parameter-group"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "event_categories.#", "1"),
+					resource.TestCheckResourceAttr("aws_db_event_subscription.bar", "tags.Name", "new-name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSDBEventSubscriptionExists(n string, v *rds.EventSubscription) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No RDS Event Subscription is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).rdsconn
+		sub, err := resourceAwsDbEventSubscriptionRetrieve(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if sub == nil {
+			return fmt.Errorf("RDS Event Subscription %s not found", rs.Primary.ID)
+		}
+
+		*v = *sub
+
+		return nil
+	}
+}
+
+func testAccCheckAWSDBEventSubscriptionDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_db_event_subscription" {
+			continue
+		}
+
+		sub, err := resourceAwsDbEventSubscriptionRetrieve(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if sub != nil {
+			return fmt.Errorf("RDS Event Subscription %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSDBEventSubscriptionConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_sns_topic" "aws_sns_topic" {
+  name = "%[1]s"
+}
+
+resource "aws_db_event_subscription" "bar" {
+  name = "%[1]s"
+  sns_topic = "${aws_sns_topic.aws_sns_topic.arn}"
+  source_type = "db-instance"
+  event_categories = [
+    "availability",
+    "backup",
+    "creation",
+    "deletion",
+    "maintenance"
+  ]
+  tags {
+    Name = "name"
+  }
+}
+`, name)
+}
+
+func testAccAWSDBEventSubscriptionConfigUpdate(name string) string {
+	return fmt.Sprintf(`
+resource "aws_sns_topic" "aws_sns_topic" {
+  name = "%[1]s"
+}
+
+resource "aws_db_event_subscription" "bar" {
+  name = "%[1]s"
+  sns_topic = "${aws_sns_topic.aws_sns_topic.arn}"
+  enabled = false
+  source_type = "db-parameter-group"
+  event_categories = [
+    "configuration change"
+  ]
+  tags {
+    Name = "new-name"
+  }
+}
+`, name)
+}
@@ -33,9 +33,10 @@ func resourceAwsDirectoryServiceDirectory() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 			"size": &schema.Schema{
 				Type:     schema.TypeString,
@@ -61,6 +62,7 @@ func resourceAwsDirectoryServiceDirectory() *schema.Resource {
 			},
 			"vpc_settings": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
@@ -82,6 +84,7 @@ func resourceAwsDirectoryServiceDirectory() *schema.Resource {
 			},
 			"connect_settings": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				ForceNew: true,
 				Elem: &schema.Resource{
@@ -98,6 +101,12 @@ func resourceAwsDirectoryServiceDirectory() *schema.Resource {
 							Elem:     &schema.Schema{Type: schema.TypeString},
 							Set:      schema.HashString,
 						},
+						"connect_ips": &schema.Schema{
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
 						"subnet_ids": &schema.Schema{
 							Type:     schema.TypeSet,
 							Required: true,
@@ -134,7 +143,7 @@ func resourceAwsDirectoryServiceDirectory() *schema.Resource {
 				Default:  "SimpleAD",
 				ForceNew: true,
 				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
-					validTypes := []string{"SimpleAD", "MicrosoftAD"}
+					validTypes := []string{"SimpleAD", "MicrosoftAD", "ADConnector"}
 					value := v.(string)
 					for validType, _ := range directoryCreationFuncs {
 						if validType == value {
@@ -155,9 +164,7 @@ func buildVpcSettings(d *schema.ResourceData) (vpcSettings *directoryservice.Dir
 	} else {
 		settings := v.([]interface{})
 
-		if len(settings) > 1 {
-			return nil, fmt.Errorf("Only a single vpc_settings block is expected")
-		} else if len(settings) == 1 {
+		if len(settings) == 1 {
 			s := settings[0].(map[string]interface{})
 			var subnetIds []*string
 			for _, id := range s["subnet_ids"].(*schema.Set).List() {
@@ -180,9 +187,7 @@ func buildConnectSettings(d *schema.ResourceData) (connectSettings *directoryser
 	} else {
 		settings := v.([]interface{})
 
-		if len(settings) > 1 {
-			return nil, fmt.Errorf("Only a single connect_settings block is expected")
-		} else if len(settings) == 1 {
+		if len(settings) == 1 {
 			s := settings[0].(map[string]interface{})
 
 			var subnetIds []*string
@@ -230,7 +235,7 @@ func createDirectoryConnector(dsconn *directoryservice.DirectoryService, d *sche
 		return "", err
 	}
 
-	log.Printf("[DEBUG] Creating Directory Connector: %s", input)
+	log.Printf("[DEBUG] Creating Directory Connector: %q", *input.Name)
 	out, err := dsconn.ConnectDirectory(&input)
 	if err != nil {
 		return "", err
@@ -263,7 +268,7 @@ func createSimpleDirectoryService(dsconn *directoryservice.DirectoryService, d *
 		return "", err
 	}
 
-	log.Printf("[DEBUG] Creating Simple Directory Service: %s", input)
+	log.Printf("[DEBUG] Creating Simple Directory Service: %q", *input.Name)
 	out, err := dsconn.CreateDirectory(&input)
 	if err != nil {
 		return "", err
@@ -291,7 +296,7 @@ func createActiveDirectoryService(dsconn *directoryservice.DirectoryService, d *
 		return "", err
 	}
 
-	log.Printf("[DEBUG] Creating Microsoft AD Directory Service: %s", input)
+	log.Printf("[DEBUG] Creating Microsoft AD Directory Service: %q", *input.Name)
 	out, err := dsconn.CreateMicrosoftAD(&input)
 	if err != nil {
 		return "", err
@@ -331,12 +336,23 @@ func resourceAwsDirectoryServiceDirectoryCreate(d *schema.ResourceData, meta int
 				return nil, "", err
 			}
 
+			if len(resp.DirectoryDescriptions) == 0 {
+				// Not visible yet, keep waiting
+				return nil, "Requested", nil
+			}
+
 			ds := resp.DirectoryDescriptions[0]
 			log.Printf("[DEBUG] Creation of DS %q is in following stage: %q.",
 				d.Id(), *ds.Stage)
+			if *ds.Stage == "Failed" {
+				return ds, *ds.Stage, fmt.Errorf("Directory Service (%s) failed to create: %s",
+					d.Id(), aws.StringValue(ds.StageReason))
+			}
 			return ds, *ds.Stage, nil
 		},
-		Timeout: 30 * time.Minute,
+		Timeout:    60 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf(
@@ -400,14 +416,25 @@ func resourceAwsDirectoryServiceDirectoryRead(d *schema.ResourceData, meta inter
 	}
 	out, err := dsconn.DescribeDirectories(&input)
 	if err != nil {
+		if dserr, ok := err.(awserr.Error); ok && dserr.Code() == "EntityDoesNotExistException" {
+			log.Printf("[WARN] Directory Service Directory (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
+	if len(out.DirectoryDescriptions) == 0 {
+		log.Printf("[WARN] Directory Service Directory (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	dir := out.DirectoryDescriptions[0]
 	log.Printf("[DEBUG] Received DS directory: %s", *dir)
 
-	d.Set("access_url", *dir.AccessUrl)
-	d.Set("alias", *dir.Alias)
+	d.Set("access_url", aws.StringValue(dir.AccessUrl))
+	d.Set("alias", aws.StringValue(dir.Alias))
 	if dir.Description != nil {
 		d.Set("description", *dir.Description)
 	}
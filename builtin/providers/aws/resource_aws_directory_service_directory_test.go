@@ -54,6 +54,8 @@ func TestAccAWSDirectoryServiceDirectory_connector(t *testing.T) {
 				Config: testAccDirectoryServiceDirectoryConfig_connector,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckServiceDirectoryExists("aws_directory_service_directory.connector"),
+					resource.TestCheckResourceAttr(
+						"aws_directory_service_directory.connector", "connect_settings.0.connect_ips.#", "2"),
 				),
 			},
 		},
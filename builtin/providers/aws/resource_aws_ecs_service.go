@@ -14,6 +14,7 @@ import (
 	"github.com/xanzy/terraform-api/helper/hashcode"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 )
 
 var taskDefinitionRE = regexp.MustCompile("^([a-zA-Z0-9_-]+):([0-9]+)$")
@@ -55,6 +56,26 @@ func resourceAwsEcsService() *schema.Resource {
 				Optional: true,
 			},
 
+			"deployment_maximum_percent": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      200,
+				ValidateFunc: validation.IntBetween(100, 1000),
+			},
+
+			"deployment_minimum_healthy_percent": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+
+			"wait_for_steady_state": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"load_balancer": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -94,6 +115,10 @@ func resourceAwsEcsServiceCreate(d *schema.ResourceData, meta interface{}) error
 		TaskDefinition: aws.String(d.Get("task_definition").(string)),
 		DesiredCount:   aws.Int64(int64(d.Get("desired_count").(int))),
 		ClientToken:    aws.String(resource.UniqueId()),
+		DeploymentConfiguration: &ecs.DeploymentConfiguration{
+			MaximumPercent:        aws.Int64(int64(d.Get("deployment_maximum_percent").(int))),
+			MinimumHealthyPercent: aws.Int64(int64(d.Get("deployment_minimum_healthy_percent").(int))),
+		},
 	}
 
 	if v, ok := d.GetOk("cluster"); ok {
@@ -208,8 +233,13 @@ func resourceAwsEcsServiceRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if service.DeploymentConfiguration != nil {
+		d.Set("deployment_maximum_percent", *service.DeploymentConfiguration.MaximumPercent)
+		d.Set("deployment_minimum_healthy_percent", *service.DeploymentConfiguration.MinimumHealthyPercent)
+	}
+
 	if service.LoadBalancers != nil {
-		d.Set("load_balancers", flattenEcsLoadBalancers(service.LoadBalancers))
+		d.Set("load_balancer", flattenEcsLoadBalancers(service.LoadBalancers))
 	}
 
 	return nil
@@ -232,6 +262,12 @@ func resourceAwsEcsServiceUpdate(d *schema.ResourceData, meta interface{}) error
 		_, n := d.GetChange("task_definition")
 		input.TaskDefinition = aws.String(n.(string))
 	}
+	if d.HasChange("deployment_maximum_percent") || d.HasChange("deployment_minimum_healthy_percent") {
+		input.DeploymentConfiguration = &ecs.DeploymentConfiguration{
+			MaximumPercent:        aws.Int64(int64(d.Get("deployment_maximum_percent").(int))),
+			MinimumHealthyPercent: aws.Int64(int64(d.Get("deployment_minimum_healthy_percent").(int))),
+		}
+	}
 
 	out, err := conn.UpdateService(&input)
 	if err != nil {
@@ -240,9 +276,57 @@ func resourceAwsEcsServiceUpdate(d *schema.ResourceData, meta interface{}) error
 	service := out.Service
 	log.Printf("[DEBUG] Updated ECS service %s", service)
 
+	if d.Get("wait_for_steady_state").(bool) {
+		if err := waitForEcsServiceSteadyState(conn, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsEcsServiceRead(d, meta)
 }
 
+// waitForEcsServiceSteadyState waits until the service has a single
+// deployment and runs the desired number of tasks, which means a new task
+// definition has been rolled out completely.
+func waitForEcsServiceSteadyState(conn *ecs.ECS, d *schema.ResourceData) error {
+	log.Printf("[DEBUG] Waiting for ECS service %s to reach a steady state", d.Id())
+
+	wait := resource.StateChangeConf{
+		Pending:    []string{"PENDING"},
+		Target:     "STEADY",
+		Timeout:    10 * time.Minute,
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeServices(&ecs.DescribeServicesInput{
+				Services: []*string{aws.String(d.Id())},
+				Cluster:  aws.String(d.Get("cluster").(string)),
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(resp.Services) == 0 {
+				return nil, "", fmt.Errorf("ECS service %s not found", d.Id())
+			}
+
+			service := resp.Services[0]
+			log.Printf("[DEBUG] ECS service %s has %d deployment(s), running %d of %d task(s)",
+				d.Id(), len(service.Deployments), *service.RunningCount, *service.DesiredCount)
+
+			if len(service.Deployments) == 1 && *service.RunningCount == *service.DesiredCount {
+				return service, "STEADY", nil
+			}
+
+			return service, "PENDING", nil
+		},
+	}
+
+	if _, err := wait.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for ECS service %s to reach a steady state: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
 func resourceAwsEcsServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).ecsconn
 
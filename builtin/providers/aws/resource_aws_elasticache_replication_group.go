@@ -0,0 +1,613 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/xanzy/terraform-api/helper/hashcode"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsElasticacheReplicationGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElasticacheReplicationGroupCreate,
+		Read:   resourceAwsElasticacheReplicationGroupRead,
+		Update: resourceAwsElasticacheReplicationGroupUpdate,
+		Delete: resourceAwsElasticacheReplicationGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"replication_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 20),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z-]+$`),
+						"can only contain alphanumeric characters and hyphens"),
+				),
+				StateFunc: func(val interface{}) string {
+					// Elasticache normalizes replication group ids to
+					// lowercase, just like cluster ids.
+					return strings.ToLower(val.(string))
+				},
+			},
+			"replication_group_description": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"engine": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "redis",
+			},
+			"engine_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"node_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"number_cache_clusters": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cluster_mode"},
+			},
+			"cluster_mode": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"num_node_groups": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(1, 15),
+						},
+						"replicas_per_node_group": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.IntBetween(0, 5),
+						},
+					},
+				},
+			},
+			"automatic_failover_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"availability_zones": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  6379,
+			},
+			"parameter_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"subnet_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"security_group_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+			"security_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+			"maintenance_window": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				StateFunc: func(val interface{}) string {
+					// Elasticache always changes the maintenance
+					// to lowercase
+					return strings.ToLower(val.(string))
+				},
+			},
+			"notification_topic_arn": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"snapshot_window": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_retention_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 35),
+			},
+			"snapshot_arns": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+
+			// apply_immediately is used to determine when the update modifications
+			// take place.
+			// See http://docs.aws.amazon.com/AmazonElastiCache/latest/APIReference/API_ModifyReplicationGroup.html
+			"apply_immediately": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			// Exported Attributes
+			"primary_endpoint_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"configuration_endpoint_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"member_clusters": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+		},
+	}
+}
+
+// elasticacheClusterMode is the cluster_mode block of a replication group.
+type elasticacheClusterMode struct {
+	NumNodeGroups        int
+	ReplicasPerNodeGroup int
+}
+
+func resourceAwsElasticacheReplicationGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	req := &elasticache.CreateReplicationGroupInput{
+		ReplicationGroupId:          aws.String(d.Get("replication_group_id").(string)),
+		ReplicationGroupDescription: aws.String(d.Get("replication_group_description").(string)),
+		AutomaticFailoverEnabled:    aws.Bool(d.Get("automatic_failover_enabled").(bool)),
+		CacheNodeType:               aws.String(d.Get("node_type").(string)),
+		Engine:                      aws.String(d.Get("engine").(string)),
+		Port:                        aws.Int64(int64(d.Get("port").(int))),
+	}
+
+	var clusterMode *elasticacheClusterMode
+	if err := d.GetStruct("cluster_mode", &clusterMode); err != nil {
+		return err
+	}
+
+	if clusterMode != nil {
+		req.NumNodeGroups = aws.Int64(int64(clusterMode.NumNodeGroups))
+		req.ReplicasPerNodeGroup = aws.Int64(int64(clusterMode.ReplicasPerNodeGroup))
+	} else {
+		numberCacheClusters, ok := d.GetOk("number_cache_clusters")
+		if !ok {
+			return fmt.Errorf(
+				"One of number_cache_clusters or cluster_mode must be set for ElastiCache Replication Group %s",
+				d.Get("replication_group_id").(string))
+		}
+
+		// Without cluster mode, a failover needs a replica to promote
+		if d.Get("automatic_failover_enabled").(bool) && numberCacheClusters.(int) < 2 {
+			return fmt.Errorf(
+				"number_cache_clusters must be at least 2 when automatic_failover_enabled is set")
+		}
+		req.NumCacheClusters = aws.Int64(int64(numberCacheClusters.(int)))
+	}
+
+	if v, ok := d.GetOk("engine_version"); ok {
+		req.EngineVersion = aws.String(v.(string))
+	}
+
+	// parameter groups are optional and can be defaulted by AWS
+	if v, ok := d.GetOk("parameter_group_name"); ok {
+		req.CacheParameterGroupName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("subnet_group_name"); ok {
+		req.CacheSubnetGroupName = aws.String(v.(string))
+	}
+
+	if v := d.Get("security_group_names").(*schema.Set); v.Len() > 0 {
+		req.CacheSecurityGroupNames = expandStringList(v.List())
+	}
+
+	if v := d.Get("security_group_ids").(*schema.Set); v.Len() > 0 {
+		req.SecurityGroupIds = expandStringList(v.List())
+	}
+
+	if v := d.Get("availability_zones").(*schema.Set); v.Len() > 0 {
+		req.PreferredCacheClusterAZs = expandStringList(v.List())
+	}
+
+	if v, ok := d.GetOk("maintenance_window"); ok {
+		req.PreferredMaintenanceWindow = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("notification_topic_arn"); ok {
+		req.NotificationTopicArn = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("snapshot_window"); ok {
+		req.SnapshotWindow = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("snapshot_retention_limit"); ok {
+		req.SnapshotRetentionLimit = aws.Int64(int64(v.(int)))
+	}
+
+	if v := d.Get("snapshot_arns").(*schema.Set); v.Len() > 0 {
+		req.SnapshotArns = expandStringList(v.List())
+		log.Printf("[DEBUG] Restoring Redis replication group from S3 snapshot: %#v", req.SnapshotArns)
+	}
+
+	log.Printf("[DEBUG] Creating ElastiCache Replication Group: %s", req)
+	resp, err := conn.CreateReplicationGroup(req)
+	if err != nil {
+		return fmt.Errorf("Error creating ElastiCache Replication Group: %s", err)
+	}
+
+	// Elasticache always retains the id in lower case, see the
+	// elasticache cluster resource.
+	d.SetId(strings.ToLower(*resp.ReplicationGroup.ReplicationGroupId))
+
+	pending := []string{"creating", "modifying"}
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     "available",
+		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "available", pending),
+		Timeout:    40 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for state to become available: %v", d.Id())
+	_, sterr := stateConf.WaitForState()
+	if sterr != nil {
+		return fmt.Errorf("Error waiting for ElastiCache Replication Group (%s) to be created: %s", d.Id(), sterr)
+	}
+
+	return resourceAwsElasticacheReplicationGroupRead(d, meta)
+}
+
+func resourceAwsElasticacheReplicationGroupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	rg, err := describeReplicationGroup(conn, d.Id())
+	if err != nil {
+		if isReplicationGroupNotFound(err) {
+			log.Printf("[WARN] ElastiCache Replication Group (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if rg.Status != nil && *rg.Status == "deleting" {
+		log.Printf("[WARN] ElastiCache Replication Group (%s) is being deleted", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("replication_group_id", rg.ReplicationGroupId)
+	d.Set("replication_group_description", rg.Description)
+	d.Set("member_clusters", flattenStringList(rg.MemberClusters))
+
+	if rg.AutomaticFailover != nil {
+		switch *rg.AutomaticFailover {
+		case "enabled", "enabling":
+			d.Set("automatic_failover_enabled", true)
+		case "disabled", "disabling":
+			d.Set("automatic_failover_enabled", false)
+		}
+	}
+
+	if rg.ConfigurationEndpoint != nil {
+		// Cluster mode enabled, the nodes are spread over node groups
+		d.Set("configuration_endpoint_address", rg.ConfigurationEndpoint.Address)
+		d.Set("port", rg.ConfigurationEndpoint.Port)
+
+		clusterMode := &elasticacheClusterMode{
+			NumNodeGroups: len(rg.NodeGroups),
+		}
+		if len(rg.NodeGroups) > 0 {
+			clusterMode.ReplicasPerNodeGroup = len(rg.NodeGroups[0].NodeGroupMembers) - 1
+		}
+		if err := d.SetStruct("cluster_mode", clusterMode); err != nil {
+			return err
+		}
+	} else {
+		d.Set("number_cache_clusters", len(rg.MemberClusters))
+		if len(rg.NodeGroups) > 0 && rg.NodeGroups[0].PrimaryEndpoint != nil {
+			d.Set("primary_endpoint_address", rg.NodeGroups[0].PrimaryEndpoint.Address)
+			d.Set("port", rg.NodeGroups[0].PrimaryEndpoint.Port)
+		}
+	}
+
+	// The settings shared by all the clusters of the group are only
+	// returned for the clusters themselves.
+	if len(rg.MemberClusters) == 0 {
+		return nil
+	}
+
+	res, err := conn.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{
+		CacheClusterId: rg.MemberClusters[0],
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading member cluster of ElastiCache Replication Group %s: %s", d.Id(), err)
+	}
+
+	if len(res.CacheClusters) == 1 {
+		c := res.CacheClusters[0]
+		d.Set("node_type", c.CacheNodeType)
+		d.Set("engine", c.Engine)
+		d.Set("engine_version", c.EngineVersion)
+		d.Set("subnet_group_name", c.CacheSubnetGroupName)
+		d.Set("security_group_names", flattenElastiCacheSecurityGroupNames(c.CacheSecurityGroups))
+		d.Set("security_group_ids", flattenElastiCacheSecurityGroupIds(c.SecurityGroups))
+		if c.CacheParameterGroup != nil {
+			d.Set("parameter_group_name", c.CacheParameterGroup.CacheParameterGroupName)
+		}
+		d.Set("maintenance_window", c.PreferredMaintenanceWindow)
+		d.Set("snapshot_window", c.SnapshotWindow)
+		d.Set("snapshot_retention_limit", c.SnapshotRetentionLimit)
+		if c.NotificationConfiguration != nil {
+			if *c.NotificationConfiguration.TopicStatus == "active" {
+				d.Set("notification_topic_arn", c.NotificationConfiguration.TopicArn)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsElasticacheReplicationGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	req := &elasticache.ModifyReplicationGroupInput{
+		ReplicationGroupId: aws.String(d.Id()),
+		ApplyImmediately:   aws.Bool(d.Get("apply_immediately").(bool)),
+	}
+
+	requestUpdate := false
+	if d.HasChange("replication_group_description") {
+		req.ReplicationGroupDescription = aws.String(d.Get("replication_group_description").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("automatic_failover_enabled") {
+		req.AutomaticFailoverEnabled = aws.Bool(d.Get("automatic_failover_enabled").(bool))
+		requestUpdate = true
+	}
+
+	if d.HasChange("node_type") {
+		req.CacheNodeType = aws.String(d.Get("node_type").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("engine_version") {
+		req.EngineVersion = aws.String(d.Get("engine_version").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("parameter_group_name") {
+		req.CacheParameterGroupName = aws.String(d.Get("parameter_group_name").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("security_group_ids") {
+		if attr := d.Get("security_group_ids").(*schema.Set); attr.Len() > 0 {
+			req.SecurityGroupIds = expandStringList(attr.List())
+			requestUpdate = true
+		}
+	}
+
+	if d.HasChange("maintenance_window") {
+		req.PreferredMaintenanceWindow = aws.String(d.Get("maintenance_window").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("notification_topic_arn") {
+		v := d.Get("notification_topic_arn").(string)
+		req.NotificationTopicArn = aws.String(v)
+		if v == "" {
+			req.NotificationTopicStatus = aws.String("inactive")
+		}
+		requestUpdate = true
+	}
+
+	if d.HasChange("snapshot_window") {
+		req.SnapshotWindow = aws.String(d.Get("snapshot_window").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("snapshot_retention_limit") {
+		req.SnapshotRetentionLimit = aws.Int64(int64(d.Get("snapshot_retention_limit").(int)))
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		log.Printf("[DEBUG] Modifying ElastiCache Replication Group (%s), opts:\n%s", d.Id(), req)
+		_, err := conn.ModifyReplicationGroup(req)
+		if err != nil {
+			return fmt.Errorf("Error updating ElastiCache Replication Group (%s): %s", d.Id(), err)
+		}
+
+		log.Printf("[DEBUG] Waiting for update: %s", d.Id())
+		pending := []string{"modifying", "snapshotting"}
+		stateConf := &resource.StateChangeConf{
+			Pending:    pending,
+			Target:     "available",
+			Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "available", pending),
+			Timeout:    40 * time.Minute,
+			Delay:      30 * time.Second,
+			MinTimeout: 10 * time.Second,
+		}
+
+		_, sterr := stateConf.WaitForState()
+		if sterr != nil {
+			return fmt.Errorf("Error waiting for ElastiCache Replication Group (%s) to update: %s", d.Id(), sterr)
+		}
+	}
+
+	return resourceAwsElasticacheReplicationGroupRead(d, meta)
+}
+
+func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	log.Printf("[INFO] Deleting ElastiCache Replication Group: %s", d.Id())
+	_, err := conn.DeleteReplicationGroup(&elasticache.DeleteReplicationGroupInput{
+		ReplicationGroupId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isReplicationGroupNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting ElastiCache Replication Group: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for deletion: %v", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "available", "modifying", "deleting"},
+		Target:     "",
+		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "", []string{}),
+		Timeout:    40 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, sterr := stateConf.WaitForState()
+	if sterr != nil {
+		return fmt.Errorf("Error waiting for ElastiCache Replication Group (%s) to delete: %s", d.Id(), sterr)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// replicationGroupStateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch the status of a replication group. Like for cache
+// clusters, the given state is only returned once all member clusters are
+// available too, since the group is reported available before they are.
+func replicationGroupStateRefreshFunc(conn *elasticache.ElastiCache, replicationGroupId, givenState string, pending []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		rg, err := describeReplicationGroup(conn, replicationGroupId)
+		if err != nil {
+			if isReplicationGroupNotFound(err) {
+				log.Printf("[DEBUG] Detect deletion")
+				return nil, "", nil
+			}
+
+			log.Printf("[ERROR] replicationGroupStateRefreshFunc: %s", err)
+			return nil, "", err
+		}
+
+		status := aws.StringValue(rg.Status)
+		log.Printf("[DEBUG] ElastiCache Replication Group (%s) status: %v", replicationGroupId, status)
+
+		for _, p := range pending {
+			if p == status {
+				return rg, p, nil
+			}
+		}
+
+		if givenState == "" || status != givenState {
+			return rg, status, nil
+		}
+
+		for _, id := range rg.MemberClusters {
+			resp, err := conn.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{
+				CacheClusterId: id,
+			})
+			if err != nil {
+				return nil, "", err
+			}
+
+			for _, c := range resp.CacheClusters {
+				if aws.StringValue(c.CacheClusterStatus) != "available" {
+					log.Printf("[DEBUG] Member cluster (%s) is not yet available, status: %s",
+						*c.CacheClusterId, aws.StringValue(c.CacheClusterStatus))
+					return rg, "modifying", nil
+				}
+			}
+		}
+
+		return rg, givenState, nil
+	}
+}
+
+func describeReplicationGroup(conn *elasticache.ElastiCache, replicationGroupId string) (*elasticache.ReplicationGroup, error) {
+	resp, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+		ReplicationGroupId: aws.String(replicationGroupId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rg := range resp.ReplicationGroups {
+		if aws.StringValue(rg.ReplicationGroupId) == replicationGroupId {
+			return rg, nil
+		}
+	}
+
+	return nil, awserr.New("ReplicationGroupNotFoundFault",
+		fmt.Sprintf("ReplicationGroup %s not found", replicationGroupId), nil)
+}
+
+func isReplicationGroupNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "ReplicationGroupNotFoundFault"
+}
@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSElasticacheReplicationGroup_basic(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	name := fmt.Sprintf("tf-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSElasticacheReplicationGroupConfig(name, "cache.m1.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &rg),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "number_cache_clusters", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "automatic_failover_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "member_clusters.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSElasticacheReplicationGroupConfig(name, "cache.m3.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &rg),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "node_type", "cache.m3.medium"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_clusterMode(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	name := fmt.Sprintf("tf-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSElasticacheReplicationGroupClusterModeConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.bar", &rg),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "cluster_mode.0.num_node_groups", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "cluster_mode.0.replicas_per_node_group", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_elasticache_replication_group.bar", "member_clusters.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSElasticacheReplicationGroupExists(n string, v *elasticache.ReplicationGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No replication group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+		rg, err := describeReplicationGroup(conn, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Elasticache error: %v", err)
+		}
+
+		*v = *rg
+
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_elasticache_replication_group" {
+			continue
+		}
+
+		_, err := describeReplicationGroup(conn, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("ElastiCache Replication Group %q still exists", rs.Primary.ID)
+		}
+		if !isReplicationGroupNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSElasticacheReplicationGroupConfig(name, nodeType string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "foo" {
+    cidr_block = "192.168.0.0/16"
+    tags {
+        Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.0.0/20"
+    availability_zone = "us-west-2a"
+}
+
+resource "aws_subnet" "bar" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.16.0/20"
+    availability_zone = "us-west-2b"
+}
+
+resource "aws_elasticache_subnet_group" "bar" {
+    name = "%[1]s"
+    description = "tf-test-cache-subnet-group-descr"
+    subnet_ids = [
+        "${aws_subnet.foo.id}",
+        "${aws_subnet.bar.id}"
+    ]
+}
+
+resource "aws_elasticache_replication_group" "bar" {
+    replication_group_id = "%[1]s"
+    replication_group_description = "test description"
+    node_type = "%[2]s"
+    number_cache_clusters = 2
+    automatic_failover_enabled = true
+    subnet_group_name = "${aws_elasticache_subnet_group.bar.name}"
+    availability_zones = ["us-west-2a", "us-west-2b"]
+    apply_immediately = true
+}
+`, name, nodeType)
+}
+
+func testAccAWSElasticacheReplicationGroupClusterModeConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "foo" {
+    cidr_block = "192.168.0.0/16"
+    tags {
+        Name = "tf-test"
+    }
+}
+
+resource "aws_subnet" "foo" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.0.0/20"
+    availability_zone = "us-west-2a"
+}
+
+resource "aws_subnet" "bar" {
+    vpc_id = "${aws_vpc.foo.id}"
+    cidr_block = "192.168.16.0/20"
+    availability_zone = "us-west-2b"
+}
+
+resource "aws_elasticache_subnet_group" "bar" {
+    name = "%[1]s"
+    description = "tf-test-cache-subnet-group-descr"
+    subnet_ids = [
+        "${aws_subnet.foo.id}",
+        "${aws_subnet.bar.id}"
+    ]
+}
+
+resource "aws_elasticache_replication_group" "bar" {
+    replication_group_id = "%[1]s"
+    replication_group_description = "test description"
+    node_type = "cache.m3.medium"
+    parameter_group_name = "default.redis3.2.cluster.on"
+    automatic_failover_enabled = true
+    subnet_group_name = "${aws_elasticache_subnet_group.bar.name}"
+
+    cluster_mode {
+        num_node_groups = 2
+        replicas_per_node_group = 1
+    }
+}
+`, name)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,6 +12,7 @@ import (
 	elasticsearch "github.com/aws/aws-sdk-go/service/elasticsearchservice"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 )
 
 func resourceAwsElasticSearchDomain() *schema.Resource {
@@ -22,31 +24,27 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"access_policies": &schema.Schema{
-				Type:      schema.TypeString,
-				StateFunc: normalizeJson,
-				Optional:  true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.JSONString,
+				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
 			},
 			"advanced_options": &schema.Schema{
-				Type:     schema.TypeMap,
-				Optional: true,
-				Computed: true,
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressESAdvancedOptionDefaults,
 			},
 			"domain_name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					value := v.(string)
-					if !regexp.MustCompile(`^[0-9A-Za-z]+`).MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q must start with a letter or number", k))
-					}
-					if !regexp.MustCompile(`^[0-9A-Za-z][0-9a-z-]+$`).MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q can only contain lowercase characters, numbers and hyphens", k))
-					}
-					return
-				},
+				ValidateFunc: validation.All(
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z]+`),
+						"must start with a letter or number"),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z][0-9a-z-]+$`),
+						"can only contain lowercase characters, numbers and hyphens"),
+				),
 			},
 			"arn": &schema.Schema{
 				Type:     schema.TypeString,
@@ -62,6 +60,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 			},
 			"ebs_options": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				Computed: true,
 				Elem: &schema.Resource{
@@ -87,6 +86,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 			},
 			"cluster_config": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				Computed: true,
 				Elem: &schema.Resource{
@@ -123,6 +123,7 @@ func resourceAwsElasticSearchDomain() *schema.Resource {
 			},
 			"snapshot_options": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -152,57 +153,40 @@ func resourceAwsElasticSearchDomainCreate(d *schema.ResourceData, meta interface
 		input.AdvancedOptions = stringMapToPointers(v.(map[string]interface{}))
 	}
 
-	if v, ok := d.GetOk("ebs_options"); ok {
-		options := v.([]interface{})
-
-		if len(options) > 1 {
-			return fmt.Errorf("Only a single ebs_options block is expected")
-		} else if len(options) == 1 {
-			if options[0] == nil {
-				return fmt.Errorf("At least one field is expected inside ebs_options")
-			}
-
-			s := options[0].(map[string]interface{})
-			input.EBSOptions = expandESEBSOptions(s)
-		}
+	var ebsOptions *esEBSOptions
+	if err := d.GetStruct("ebs_options", &ebsOptions); err != nil {
+		return err
 	}
-
-	if v, ok := d.GetOk("cluster_config"); ok {
-		config := v.([]interface{})
-
-		if len(config) > 1 {
-			return fmt.Errorf("Only a single cluster_config block is expected")
-		} else if len(config) == 1 {
-			if config[0] == nil {
-				return fmt.Errorf("At least one field is expected inside cluster_config")
-			}
-			m := config[0].(map[string]interface{})
-			input.ElasticsearchClusterConfig = expandESClusterConfig(m)
-		}
+	if ebsOptions != nil {
+		input.EBSOptions = expandESEBSOptions(ebsOptions)
 	}
 
-	if v, ok := d.GetOk("snapshot_options"); ok {
-		options := v.([]interface{})
-
-		if len(options) > 1 {
-			return fmt.Errorf("Only a single snapshot_options block is expected")
-		} else if len(options) == 1 {
-			if options[0] == nil {
-				return fmt.Errorf("At least one field is expected inside snapshot_options")
-			}
-
-			o := options[0].(map[string]interface{})
-
-			snapshotOptions := elasticsearch.SnapshotOptions{
-				AutomatedSnapshotStartHour: aws.Int64(int64(o["automated_snapshot_start_hour"].(int))),
-			}
+	var clusterConfig *esClusterConfig
+	if err := d.GetStruct("cluster_config", &clusterConfig); err != nil {
+		return err
+	}
+	if clusterConfig != nil {
+		input.ElasticsearchClusterConfig = expandESClusterConfig(clusterConfig)
+	}
 
-			input.SnapshotOptions = &snapshotOptions
-		}
+	var snapshotOptions *esSnapshotOptions
+	if err := d.GetStruct("snapshot_options", &snapshotOptions); err != nil {
+		return err
+	}
+	if snapshotOptions != nil {
+		input.SnapshotOptions = expandESSnapshotOptions(snapshotOptions)
 	}
 
-	log.Printf("[DEBUG] Creating ElasticSearch domain: %s", input)
-	out, err := conn.CreateElasticsearchDomain(&input)
+	// The access policies may refer to principals that were created so
+	// recently that they can't be used in policies yet. The input isn't
+	// logged as a whole, as it contains the access policies.
+	log.Printf("[DEBUG] Creating ElasticSearch domain: %q", d.Get("domain_name").(string))
+	var out *elasticsearch.CreateElasticsearchDomainOutput
+	err := retryOnIAMPropagation(d.StopCh(), func() error {
+		var err error
+		out, err = conn.CreateElasticsearchDomain(&input)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -210,7 +194,7 @@ func resourceAwsElasticSearchDomainCreate(d *schema.ResourceData, meta interface
 	d.SetId(*out.DomainStatus.ARN)
 
 	log.Printf("[DEBUG] Waiting for ElasticSearch domain %q to be created", d.Id())
-	err = resource.Retry(15*time.Minute, func() error {
+	err = resource.RetryWithCancel(15*time.Minute, d.StopCh(), func() error {
 		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
 			DomainName: aws.String(d.Get("domain_name").(string)),
 		})
@@ -243,12 +227,12 @@ func resourceAwsElasticSearchDomainRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	log.Printf("[DEBUG] Received ElasticSearch domain: %s", out)
+	log.Printf("[DEBUG] Received ElasticSearch domain: %q", d.Get("domain_name").(string))
 
 	ds := out.DomainStatus
 
 	if ds.AccessPolicies != nil && *ds.AccessPolicies != "" {
-		d.Set("access_policies", normalizeJson(*ds.AccessPolicies))
+		d.Set("access_policies", *ds.AccessPolicies)
 	}
 	err = d.Set("advanced_options", pointersMapToStringList(ds.AdvancedOptions))
 	if err != nil {
@@ -295,49 +279,44 @@ func resourceAwsElasticSearchDomainUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("ebs_options") {
-		options := d.Get("ebs_options").([]interface{})
-
-		if len(options) > 1 {
-			return fmt.Errorf("Only a single ebs_options block is expected")
-		} else if len(options) == 1 {
-			s := options[0].(map[string]interface{})
-			input.EBSOptions = expandESEBSOptions(s)
+		var options *esEBSOptions
+		if err := d.GetStruct("ebs_options", &options); err != nil {
+			return err
+		}
+		if options != nil {
+			input.EBSOptions = expandESEBSOptions(options)
 		}
 	}
 
 	if d.HasChange("cluster_config") {
-		config := d.Get("cluster_config").([]interface{})
-
-		if len(config) > 1 {
-			return fmt.Errorf("Only a single cluster_config block is expected")
-		} else if len(config) == 1 {
-			m := config[0].(map[string]interface{})
-			input.ElasticsearchClusterConfig = expandESClusterConfig(m)
+		var config *esClusterConfig
+		if err := d.GetStruct("cluster_config", &config); err != nil {
+			return err
+		}
+		if config != nil {
+			input.ElasticsearchClusterConfig = expandESClusterConfig(config)
 		}
 	}
 
 	if d.HasChange("snapshot_options") {
-		options := d.Get("snapshot_options").([]interface{})
-
-		if len(options) > 1 {
-			return fmt.Errorf("Only a single snapshot_options block is expected")
-		} else if len(options) == 1 {
-			o := options[0].(map[string]interface{})
-
-			snapshotOptions := elasticsearch.SnapshotOptions{
-				AutomatedSnapshotStartHour: aws.Int64(int64(o["automated_snapshot_start_hour"].(int))),
-			}
-
-			input.SnapshotOptions = &snapshotOptions
+		var options *esSnapshotOptions
+		if err := d.GetStruct("snapshot_options", &options); err != nil {
+			return err
+		}
+		if options != nil {
+			input.SnapshotOptions = expandESSnapshotOptions(options)
 		}
 	}
 
-	_, err := conn.UpdateElasticsearchDomainConfig(&input)
+	err := retryOnIAMPropagation(d.StopCh(), func() error {
+		_, err := conn.UpdateElasticsearchDomainConfig(&input)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	err = resource.Retry(25*time.Minute, func() error {
+	err = resource.RetryWithCancel(25*time.Minute, d.StopCh(), func() error {
 		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
 			DomainName: aws.String(d.Get("domain_name").(string)),
 		})
@@ -370,7 +349,7 @@ func resourceAwsElasticSearchDomainDelete(d *schema.ResourceData, meta interface
 	}
 
 	log.Printf("[DEBUG] Waiting for ElasticSearch domain %q to be deleted", d.Get("domain_name").(string))
-	err = resource.Retry(15*time.Minute, func() error {
+	err = resource.RetryWithCancel(15*time.Minute, d.StopCh(), func() error {
 		out, err := conn.DescribeElasticsearchDomain(&elasticsearch.DescribeElasticsearchDomainInput{
 			DomainName: aws.String(d.Get("domain_name").(string)),
 		})
@@ -394,8 +373,52 @@ func resourceAwsElasticSearchDomainDelete(d *schema.ResourceData, meta interface
 
 		return fmt.Errorf("%q: Timeout while waiting for the domain to be deleted", d.Id())
 	})
+	if err != nil {
+		return err
+	}
 
 	d.SetId("")
 
-	return err
+	return nil
+}
+
+// esAdvancedOptionDefaults are the advanced options AWS adds to every
+// domain, with their default values.
+var esAdvancedOptionDefaults = map[string]string{
+	"rest.action.multi.allow_explicit_index": "true",
+}
+
+// suppressESAdvancedOptionDefaults suppresses the removal of advanced
+// options that aren't in the configuration but were added by AWS with
+// their default value, so they don't show up as a diff on every plan.
+// Options that are in the configuration, or that have another value,
+// are still diffed.
+func suppressESAdvancedOptionDefaults(k, old, new string, d *schema.ResourceData) bool {
+	o, n := d.GetChange("advanced_options")
+	oldOpts := o.(map[string]interface{})
+	newOpts := n.(map[string]interface{})
+
+	// The count only changed because of the added options
+	if k == "advanced_options.#" {
+		count := len(oldOpts)
+		for name, v := range oldOpts {
+			if _, ok := newOpts[name]; !ok && isESAdvancedOptionDefault(name, v) {
+				count--
+			}
+		}
+
+		return count == len(newOpts)
+	}
+
+	name := strings.TrimPrefix(k, "advanced_options.")
+	if _, ok := newOpts[name]; ok {
+		return false
+	}
+
+	return isESAdvancedOptionDefault(name, old)
+}
+
+func isESAdvancedOptionDefault(name string, v interface{}) bool {
+	def, ok := esAdvancedOptionDefaults[name]
+	return ok && fmt.Sprintf("%v", v) == def
 }
@@ -2,15 +2,145 @@ package aws
 
 import (
 	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elasticsearch "github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/xanzy/terraform-api/config"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("aws_elasticsearch_domain", &resource.Sweeper{
+		Name: "aws_elasticsearch_domain",
+		F:    testSweepElasticSearchDomains,
+	})
+}
+
+// testSweepElasticSearchDomains deletes the ES domains leaked by the
+// acceptance tests, which keep costing money until they're removed.
+func testSweepElasticSearchDomains(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+	conn := client.esconn
+
+	resp, err := conn.ListDomainNames(&elasticsearch.ListDomainNamesInput{})
+	if err != nil {
+		return fmt.Errorf("Error listing ElasticSearch domains: %s", err)
+	}
+
+	for _, d := range resp.DomainNames {
+		name := *d.DomainName
+		if !strings.HasPrefix(name, "tf-test-") {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting ElasticSearch domain: %s", name)
+		_, err := conn.DeleteElasticsearchDomain(&elasticsearch.DeleteElasticsearchDomainInput{
+			DomainName: aws.String(name),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting ElasticSearch domain %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+func TestResourceAwsElasticSearchDomain_advancedOptionsDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "arn:aws:es:us-east-1:123456789012:domain/tf-test",
+		Attributes: map[string]string{
+			"domain_name":        "tf-test",
+			"advanced_options.#": "2",
+			"advanced_options.indices.fielddata.cache.size":           "80",
+			"advanced_options.rest.action.multi.allow_explicit_index": "true",
+		},
+	}
+
+	cases := []struct {
+		Options  map[string]interface{}
+		Expected []string
+	}{
+		// The option added by AWS is ignored
+		{
+			map[string]interface{}{"indices.fielddata.cache.size": "80"},
+			nil,
+		},
+
+		// Setting it explicitly doesn't change anything either
+		{
+			map[string]interface{}{
+				"indices.fielddata.cache.size":           "80",
+				"rest.action.multi.allow_explicit_index": "true",
+			},
+			nil,
+		},
+
+		// Changes to the configured options are still detected
+		{
+			map[string]interface{}{"indices.fielddata.cache.size": "60"},
+			[]string{"advanced_options.indices.fielddata.cache.size"},
+		},
+
+		// And so are changes to the default ones
+		{
+			map[string]interface{}{
+				"indices.fielddata.cache.size":           "80",
+				"rest.action.multi.allow_explicit_index": "false",
+			},
+			[]string{"advanced_options.rest.action.multi.allow_explicit_index"},
+		},
+
+		// Removing a configured option is a change
+		{
+			map[string]interface{}{"indices.query.bool.max_clause_count": "1024"},
+			[]string{
+				"advanced_options.indices.fielddata.cache.size",
+				"advanced_options.indices.query.bool.max_clause_count",
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"domain_name":      "tf-test",
+			"advanced_options": tc.Options,
+		})
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		diff, err := resourceAwsElasticSearchDomain().Diff(
+			state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		var actual []string
+		if diff != nil {
+			for k := range diff.Attributes {
+				if strings.HasPrefix(k, "advanced_options.") {
+					actual = append(actual, k)
+				}
+			}
+		}
+		sort.Strings(actual)
+
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: expected diff on %v, got %v", i, tc.Expected, actual)
+		}
+	}
+}
+
 func TestAccAWSElasticSearchDomain_basic(t *testing.T) {
 	var domain elasticsearch.ElasticsearchDomainStatus
 
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsElbAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElbAttachmentCreate,
+		Read:   resourceAwsElbAttachmentRead,
+		Delete: resourceAwsElbAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"elb": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsElbAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+	elbName := d.Get("elb").(string)
+
+	registerInstancesOpts := elb.RegisterInstancesWithLoadBalancerInput{
+		LoadBalancerName: aws.String(elbName),
+		Instances:        expandInstanceString([]interface{}{d.Get("instance").(string)}),
+	}
+
+	log.Printf("[INFO] Registering instance %s with ELB %s", d.Get("instance").(string), elbName)
+	if _, err := elbconn.RegisterInstancesWithLoadBalancer(&registerInstancesOpts); err != nil {
+		return fmt.Errorf("Failure registering instance with ELB %s: %s", elbName, err)
+	}
+
+	d.SetId(resource.PrefixedUniqueId(fmt.Sprintf("%s-", elbName)))
+
+	return nil
+}
+
+func resourceAwsElbAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+	elbName := d.Get("elb").(string)
+
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			log.Printf("[WARN] ELB %s not found, removing attachment %s", elbName, d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+	if len(describeResp.LoadBalancerDescriptions) != 1 {
+		return fmt.Errorf("Unable to find ELB: %#v", describeResp.LoadBalancerDescriptions)
+	}
+
+	// The attachment only exists as long as the instance is registered
+	expected := d.Get("instance").(string)
+	for _, i := range describeResp.LoadBalancerDescriptions[0].Instances {
+		if aws.StringValue(i.InstanceId) == expected {
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] Instance %s is no longer registered with ELB %s", expected, elbName)
+	d.SetId("")
+
+	return nil
+}
+
+func resourceAwsElbAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+	elbName := d.Get("elb").(string)
+
+	deRegisterInstancesOpts := elb.DeregisterInstancesFromLoadBalancerInput{
+		LoadBalancerName: aws.String(elbName),
+		Instances:        expandInstanceString([]interface{}{d.Get("instance").(string)}),
+	}
+
+	log.Printf("[INFO] Deregistering instance %s from ELB %s", d.Get("instance").(string), elbName)
+	if _, err := elbconn.DeregisterInstancesFromLoadBalancer(&deRegisterInstancesOpts); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Failure deregistering instance from ELB %s: %s", elbName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSELBAttachment_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSELBDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSELBAttachmentConfig1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBAttachmentCount("aws_elb.bar", 1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSELBAttachmentConfig2,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBAttachmentCount("aws_elb.bar", 2),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSELBAttachmentConfig3,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBAttachmentCount("aws_elb.bar", 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSELBAttachmentCount(n string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ELB ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(rs.Primary.ID)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.LoadBalancerDescriptions) != 1 {
+			return fmt.Errorf("ELB not found")
+		}
+
+		if count := len(describe.LoadBalancerDescriptions[0].Instances); count != expected {
+			return fmt.Errorf("Wrong number of instances attached to ELB, expected %d, got %d", expected, count)
+		}
+
+		return nil
+	}
+}
+
+// one instance attached
+const testAccAWSELBAttachmentConfig1 = `
+resource "aws_elb" "bar" {
+	availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]
+
+	listener {
+		instance_port = 8000
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_instance" "foo1" {
+	# us-west-2
+	ami = "ami-043a5034"
+	instance_type = "t1.micro"
+}
+
+resource "aws_elb_attachment" "foo1" {
+	elb = "${aws_elb.bar.id}"
+	instance = "${aws_instance.foo1.id}"
+}
+`
+
+// two instances attached
+const testAccAWSELBAttachmentConfig2 = `
+resource "aws_elb" "bar" {
+	availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]
+
+	listener {
+		instance_port = 8000
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_instance" "foo1" {
+	# us-west-2
+	ami = "ami-043a5034"
+	instance_type = "t1.micro"
+}
+
+resource "aws_instance" "foo2" {
+	# us-west-2
+	ami = "ami-043a5034"
+	instance_type = "t1.micro"
+}
+
+resource "aws_elb_attachment" "foo1" {
+	elb = "${aws_elb.bar.id}"
+	instance = "${aws_instance.foo1.id}"
+}
+
+resource "aws_elb_attachment" "foo2" {
+	elb = "${aws_elb.bar.id}"
+	instance = "${aws_instance.foo2.id}"
+}
+`
+
+// one instance attached, the first attachment removed
+const testAccAWSELBAttachmentConfig3 = `
+resource "aws_elb" "bar" {
+	availability_zones = ["us-west-2a", "us-west-2b", "us-west-2c"]
+
+	listener {
+		instance_port = 8000
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_instance" "foo2" {
+	# us-west-2
+	ami = "ami-043a5034"
+	instance_type = "t1.micro"
+}
+
+resource "aws_elb_attachment" "foo2" {
+	elb = "${aws_elb.bar.id}"
+	instance = "${aws_instance.foo2.id}"
+}
+`
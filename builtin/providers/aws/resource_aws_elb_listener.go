@@ -0,0 +1,216 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsElbListener() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElbListenerCreate,
+		Read:   resourceAwsElbListenerRead,
+		Update: resourceAwsElbListenerUpdate,
+		Delete: resourceAwsElbListenerDelete,
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_protocol": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: resourceAwsElbListenerProtocolStateFunc,
+			},
+
+			"lb_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"lb_protocol": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: resourceAwsElbListenerProtocolStateFunc,
+			},
+
+			"ssl_certificate_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceAwsElbListenerCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+	elbName := d.Get("load_balancer").(string)
+
+	// Reuse the inline listener expansion, so the protocol and certificate
+	// validation is the same for both ways of defining a listener.
+	listeners, err := expandListeners([]interface{}{map[string]interface{}{
+		"instance_port":      d.Get("instance_port").(int),
+		"instance_protocol":  d.Get("instance_protocol").(string),
+		"lb_port":            d.Get("lb_port").(int),
+		"lb_protocol":        d.Get("lb_protocol").(string),
+		"ssl_certificate_id": d.Get("ssl_certificate_id").(string),
+	}})
+	if err != nil {
+		return err
+	}
+
+	createListenersOpts := &elb.CreateLoadBalancerListenersInput{
+		LoadBalancerName: aws.String(elbName),
+		Listeners:        listeners,
+	}
+
+	log.Printf("[DEBUG] ELB Listener create configuration: %#v", createListenersOpts)
+	err = resource.Retry(1*time.Minute, func() error {
+		_, err := elbconn.CreateLoadBalancerListeners(createListenersOpts)
+
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok {
+				// Check for IAM SSL Cert error, eventual consistancy issue
+				if awsErr.Code() == "CertificateNotFound" {
+					return fmt.Errorf("[WARN] Error creating ELB Listener with SSL Cert, retrying: %s", err)
+				}
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error creating ELB Listener: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", elbName, d.Get("lb_port").(int)))
+
+	return resourceAwsElbListenerRead(d, meta)
+}
+
+func resourceAwsElbListenerRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	elbName, lbPort, err := resourceAwsElbListenerParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(elbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			log.Printf("[WARN] ELB %s not found, removing listener %s", elbName, d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+	if len(describeResp.LoadBalancerDescriptions) != 1 {
+		return fmt.Errorf("Unable to find ELB: %#v", describeResp.LoadBalancerDescriptions)
+	}
+
+	for _, l := range flattenListeners(describeResp.LoadBalancerDescriptions[0].ListenerDescriptions) {
+		if l["lb_port"].(int64) != lbPort {
+			continue
+		}
+
+		d.Set("load_balancer", elbName)
+		d.Set("instance_port", l["instance_port"])
+		d.Set("instance_protocol", l["instance_protocol"])
+		d.Set("lb_port", l["lb_port"])
+		d.Set("lb_protocol", l["lb_protocol"])
+		d.Set("ssl_certificate_id", l["ssl_certificate_id"])
+
+		return nil
+	}
+
+	log.Printf("[WARN] ELB Listener %s not found", d.Id())
+	d.SetId("")
+
+	return nil
+}
+
+func resourceAwsElbListenerUpdate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	if d.HasChange("ssl_certificate_id") {
+		setCertificateOpts := &elb.SetLoadBalancerListenerSSLCertificateInput{
+			LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+			LoadBalancerPort: aws.Int64(int64(d.Get("lb_port").(int))),
+			SSLCertificateId: aws.String(d.Get("ssl_certificate_id").(string)),
+		}
+
+		log.Printf("[DEBUG] ELB Listener certificate update: %#v", setCertificateOpts)
+		if _, err := elbconn.SetLoadBalancerListenerSSLCertificate(setCertificateOpts); err != nil {
+			return fmt.Errorf("Error updating certificate of ELB Listener %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAwsElbListenerRead(d, meta)
+}
+
+func resourceAwsElbListenerDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	deleteListenersOpts := &elb.DeleteLoadBalancerListenersInput{
+		LoadBalancerName:  aws.String(d.Get("load_balancer").(string)),
+		LoadBalancerPorts: []*int64{aws.Int64(int64(d.Get("lb_port").(int)))},
+	}
+
+	log.Printf("[DEBUG] ELB Listener delete configuration: %#v", deleteListenersOpts)
+	if _, err := elbconn.DeleteLoadBalancerListeners(deleteListenersOpts); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting ELB Listener %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsElbListenerParseId takes an ID and parses it into the name of
+// the load balancer and the load balancer port, which together identify a
+// listener.
+func resourceAwsElbListenerParseId(id string) (string, int64, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("Unexpected format of ELB Listener ID (%s), expected ELB:PORT", id)
+	}
+
+	port, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid port in ELB Listener ID (%s): %s", id, err)
+	}
+
+	return parts[0], port, nil
+}
+
+// ELB reports the protocols in uppercase, while they are usually
+// configured in lowercase, so both are normalized to lowercase.
+func resourceAwsElbListenerProtocolStateFunc(v interface{}) string {
+	return strings.ToLower(v.(string))
+}
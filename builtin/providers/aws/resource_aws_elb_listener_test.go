@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSELBListener_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSELBListenerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSELBListenerConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSELBListenerExists("aws_elb_listener.tcp"),
+					resource.TestCheckResourceAttr(
+						"aws_elb_listener.tcp", "lb_protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"aws_elb_listener.tcp", "instance_port", "8022"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateElbListenerParseId(t *testing.T) {
+	name, port, err := resourceAwsElbListenerParseId("foo-elb:443")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if name != "foo-elb" || port != 443 {
+		t.Fatalf("bad: %s, %d", name, port)
+	}
+
+	for _, id := range []string{"foo-elb", "foo-elb:https"} {
+		if _, _, err := resourceAwsElbListenerParseId(id); err == nil {
+			t.Fatalf("expected an error parsing %q", id)
+		}
+	}
+}
+
+func testAccCheckAWSELBListenerDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_elb_listener" {
+			continue
+		}
+
+		elbName, lbPort, err := resourceAwsElbListenerParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(elbName)},
+		})
+		if err != nil {
+			if isLoadBalancerNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, lb := range describe.LoadBalancerDescriptions {
+			for _, l := range lb.ListenerDescriptions {
+				if *l.Listener.LoadBalancerPort == lbPort {
+					return fmt.Errorf("ELB Listener still exists")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSELBListenerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ELB Listener ID is set")
+		}
+
+		elbName, lbPort, err := resourceAwsElbListenerParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(elbName)},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, lb := range describe.LoadBalancerDescriptions {
+			for _, l := range lb.ListenerDescriptions {
+				if *l.Listener.LoadBalancerPort == lbPort {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("ELB Listener not found")
+	}
+}
+
+const testAccAWSELBListenerConfig = `
+resource "aws_elb" "bar" {
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 8000
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+
+	lifecycle {
+		ignore_changes = ["listener"]
+	}
+}
+
+resource "aws_elb_listener" "tcp" {
+	load_balancer = "${aws_elb.bar.id}"
+	instance_port = 8022
+	instance_protocol = "tcp"
+	lb_port = 22
+	lb_protocol = "TCP"
+}
+`
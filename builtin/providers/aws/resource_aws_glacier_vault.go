@@ -56,6 +56,7 @@ func resourceAwsGlacierVault() *schema.Resource {
 
 			"notification": &schema.Schema{
 				Type:     schema.TypeList,
+				MaxItems: 1,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -181,9 +182,7 @@ func resourceAwsGlacierVaultNotificationUpdate(glacierconn *glacier.Glacier, d *
 	if v, ok := d.GetOk("notification"); ok {
 		settings := v.([]interface{})
 
-		if len(settings) > 1 {
-			return fmt.Errorf("Only a single Notification Block is allowed for Glacier Vault")
-		} else if len(settings) == 1 {
+		if len(settings) == 1 {
 			s := settings[0].(map[string]interface{})
 			var events []*string
 			for _, id := range s["events"].(*schema.Set).List() {
@@ -359,26 +359,21 @@ func resourceAwsInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	// Create the instance
 	log.Printf("[DEBUG] Run configuration: %s", runOpts)
 
+	// The instance profile may have been created so recently that EC2
+	// can't see it yet
 	var runResp *ec2.Reservation
-	for i := 0; i < 5; i++ {
+	err = retryOnIAMPropagation(d.StopCh(), func() error {
+		var err error
 		runResp, err = conn.RunInstances(runOpts)
-		if awsErr, ok := err.(awserr.Error); ok {
-			// IAM profiles can take ~10 seconds to propagate in AWS:
-			//  http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#launch-instance-with-role-console
-			if awsErr.Code() == "InvalidParameterValue" && strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile") {
-				log.Printf("[DEBUG] Invalid IAM Instance Profile referenced, retrying...")
-				time.Sleep(2 * time.Second)
-				continue
-			}
-
-			// Warn if the AWS Error involves group ids, to help identify situation
-			// where a user uses group ids in security_groups for the Default VPC.
-			//   See https://github.com/hashicorp/terraform/issues/3798
-			if awsErr.Code() == "InvalidParameterValue" && strings.Contains(awsErr.Message(), "groupId is invalid") {
-				return fmt.Errorf("Error launching instance, possible mismatch of Security Group IDs and Names. See AWS Instance docs here: %s.\n\n\tAWS Error: %s", "https://terraform.io/docs/providers/aws/r/instance.html", awsErr.Message())
-			}
+		return err
+	})
+	if awsErr, ok := err.(awserr.Error); ok {
+		// Warn if the AWS Error involves group ids, to help identify situation
+		// where a user uses group ids in security_groups for the Default VPC.
+		//   See https://github.com/hashicorp/terraform/issues/3798
+		if awsErr.Code() == "InvalidParameterValue" && strings.Contains(awsErr.Message(), "groupId is invalid") {
+			return fmt.Errorf("Error launching instance, possible mismatch of Security Group IDs and Names. See AWS Instance docs here: %s.\n\n\tAWS Error: %s", "https://terraform.io/docs/providers/aws/r/instance.html", awsErr.Message())
 		}
-		break
 	}
 	if err != nil {
 		return fmt.Errorf("Error launching source instance: %s", err)
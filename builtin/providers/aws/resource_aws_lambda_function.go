@@ -6,14 +6,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/mitchellh/go-homedir"
 
-	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
 )
 
@@ -148,23 +145,11 @@ func resourceAwsLambdaFunctionCreate(d *schema.ResourceData, meta interface{}) e
 		Timeout:      aws.Int64(int64(d.Get("timeout").(int))),
 	}
 
-	// IAM profiles can take ~10 seconds to propagate in AWS:
-	//  http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#launch-instance-with-role-console
-	// Error creating Lambda function: InvalidParameterValueException: The role defined for the task cannot be assumed by Lambda.
-	err := resource.Retry(1*time.Minute, func() error {
+	// The role may have been created so recently that Lambda can't
+	// assume it yet
+	err := retryOnIAMPropagation(d.StopCh(), func() error {
 		_, err := conn.CreateFunction(params)
-		if err != nil {
-			if awserr, ok := err.(awserr.Error); ok {
-				if awserr.Code() == "InvalidParameterValueException" {
-					// Retryable
-					return awserr
-				}
-			}
-			// Not retryable
-			return resource.RetryError{Err: err}
-		}
-		// No error
-		return nil
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("Error creating Lambda function: %s", err)
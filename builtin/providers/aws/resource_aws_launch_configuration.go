@@ -426,21 +426,11 @@ func resourceAwsLaunchConfigurationCreate(d *schema.ResourceData, meta interface
 	log.Printf(
 		"[DEBUG] autoscaling create launch configuration: %s", createLaunchConfigurationOpts)
 
-	// IAM profiles can take ~10 seconds to propagate in AWS:
-	// http://docs.aws.amazon.com/AWSEC2/latest/UserGuide/iam-roles-for-amazon-ec2.html#launch-instance-with-role-console
-	err := resource.Retry(30*time.Second, func() error {
+	// The instance profile may have been created so recently that Auto
+	// Scaling can't see it yet
+	err := retryOnIAMPropagation(d.StopCh(), func() error {
 		_, err := autoscalingconn.CreateLaunchConfiguration(&createLaunchConfigurationOpts)
-		if err != nil {
-			if awsErr, ok := err.(awserr.Error); ok {
-				if awsErr.Message() == "Invalid IamInstanceProfile" {
-					return err
-				}
-			}
-			return &resource.RetryError{
-				Err: err,
-			}
-		}
-		return nil
+		return err
 	})
 
 	if err != nil {
@@ -21,9 +21,10 @@ func resourceAwsLBCookieStickinessPolicy() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateElbPolicyName,
 			},
 
 			"load_balancer": &schema.Schema{
@@ -69,7 +70,21 @@ func resourceAwsLBCookieStickinessPolicyCreate(d *schema.ResourceData, meta inte
 	}
 
 	log.Printf("[DEBUG] LB Cookie Stickiness Policy opts: %#v", lbspOpts)
-	if _, err := elbconn.CreateLBCookieStickinessPolicy(lbspOpts); err != nil {
+	_, err := elbconn.CreateLBCookieStickinessPolicy(lbspOpts)
+	if isElbDuplicatePolicyName(err) {
+		// A policy that was detached from its listener outside of
+		// Terraform is removed from the state, but it still exists.
+		// Replace it, so it is created with the configured settings.
+		log.Printf("[WARN] Replacing existing, unattached ELB policy %s", *lbspOpts.PolicyName)
+		if _, err := elbconn.DeleteLoadBalancerPolicy(&elb.DeleteLoadBalancerPolicyInput{
+			LoadBalancerName: lbspOpts.LoadBalancerName,
+			PolicyName:       lbspOpts.PolicyName,
+		}); err != nil {
+			return fmt.Errorf("Error replacing LBCookieStickinessPolicy: %s", err)
+		}
+		_, err = elbconn.CreateLBCookieStickinessPolicy(lbspOpts)
+	}
+	if err != nil {
 		return fmt.Errorf("Error creating LBCookieStickinessPolicy: %s", err)
 	}
 
@@ -103,7 +118,7 @@ func resourceAwsLBCookieStickinessPolicyRead(d *schema.ResourceData, meta interf
 
 	getResp, err := elbconn.DescribeLoadBalancerPolicies(request)
 	if err != nil {
-		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "PolicyNotFound" {
+		if ec2err, ok := err.(awserr.Error); ok && (ec2err.Code() == "PolicyNotFound" || ec2err.Code() == "LoadBalancerNotFound") {
 			// The policy is gone.
 			d.SetId("")
 			return nil
@@ -115,6 +130,18 @@ func resourceAwsLBCookieStickinessPolicyRead(d *schema.ResourceData, meta interf
 		return fmt.Errorf("Unable to find policy %#v", getResp.PolicyDescriptions)
 	}
 
+	// The policy only takes effect while it is attached to the listener,
+	// so a policy that was detached is treated as gone as well.
+	assigned, err := resourceAwsELBStickinessPolicyAssigned(elbconn, lbName, lbPort, policyName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving listener policies: %s", err)
+	}
+	if !assigned {
+		log.Printf("[WARN] ELB policy %s is no longer attached to listener %s of %s", policyName, lbPort, lbName)
+		d.SetId("")
+		return nil
+	}
+
 	// We can get away with this because there's only one attribute, the
 	// cookie expiration, in these descriptions.
 	policyDesc := getResp.PolicyDescriptions[0]
@@ -40,6 +40,34 @@ func TestAccAWSLBCookieStickinessPolicy_basic(t *testing.T) {
 	})
 }
 
+// Detaching the policy from its listener outside of Terraform makes it get
+// attached again on the next apply.
+func TestAccAWSLBCookieStickinessPolicy_drift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLBCookieStickinessPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccLBCookieStickinessPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBCookieStickinessPolicy(
+						"aws_elb.lb",
+						"aws_lb_cookie_stickiness_policy.foo",
+					),
+				),
+			},
+			resource.TestStep{
+				PreConfig: testAccDetachELBListenerPolicies("test-lb", 80),
+				Config:    testAccLBCookieStickinessPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckELBStickinessPolicyAssigned("aws_lb_cookie_stickiness_policy.foo"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckLBCookieStickinessPolicyDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).elbconn
 
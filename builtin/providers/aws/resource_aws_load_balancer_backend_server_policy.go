@@ -0,0 +1,112 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsLoadBalancerBackendServerPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsLoadBalancerBackendServerPolicySet,
+		Read:   resourceAwsLoadBalancerBackendServerPolicyRead,
+		Update: resourceAwsLoadBalancerBackendServerPolicySet,
+		Delete: resourceAwsLoadBalancerBackendServerPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAwsLoadBalancerBackendServerPolicySet(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	// The given policies replace all the policies of the backend server
+	input := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+		LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+		InstancePort:     aws.Int64(int64(d.Get("instance_port").(int))),
+		PolicyNames:      expandStringList(d.Get("policy_names").(*schema.Set).List()),
+	}
+
+	log.Printf("[DEBUG] ELB set backend server policies: %#v", input)
+	if _, err := elbconn.SetLoadBalancerPoliciesForBackendServer(input); err != nil {
+		return fmt.Errorf("Error setting policies of ELB backend server: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", *input.LoadBalancerName, *input.InstancePort))
+
+	return resourceAwsLoadBalancerBackendServerPolicyRead(d, meta)
+}
+
+func resourceAwsLoadBalancerBackendServerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, instancePort, err := resourceAwsLoadBalancerPortPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+	if len(describeResp.LoadBalancerDescriptions) != 1 {
+		return fmt.Errorf("Unable to find ELB: %#v", describeResp.LoadBalancerDescriptions)
+	}
+
+	// A backend server without any policies isn't described at all
+	policies := flattenBackendPolicies(describeResp.LoadBalancerDescriptions[0].BackendServerDescriptions)
+
+	d.Set("load_balancer", lbName)
+	d.Set("instance_port", instancePort)
+	d.Set("policy_names", policies[instancePort])
+
+	return nil
+}
+
+func resourceAwsLoadBalancerBackendServerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	input := &elb.SetLoadBalancerPoliciesForBackendServerInput{
+		LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+		InstancePort:     aws.Int64(int64(d.Get("instance_port").(int))),
+		PolicyNames:      []*string{},
+	}
+
+	log.Printf("[DEBUG] ELB remove backend server policies: %#v", input)
+	if _, err := elbconn.SetLoadBalancerPoliciesForBackendServer(input); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error removing policies of ELB backend server: %s", err)
+	}
+
+	return nil
+}
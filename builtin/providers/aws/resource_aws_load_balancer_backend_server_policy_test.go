@@ -0,0 +1,169 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSLoadBalancerBackendServerPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLoadBalancerBackendServerPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerBackendServerPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerBackendServerPolicyAssigned(
+						"aws_load_balancer_backend_server_policy.test-backend", "test-proxy-protocol", true),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerBackendServerPolicyConfigEmpty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerBackendServerPolicyAssigned(
+						"aws_load_balancer_backend_server_policy.test-backend", "test-proxy-protocol", false),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLoadBalancerBackendServerPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_load_balancer_backend_server_policy" {
+			continue
+		}
+
+		lbName, instancePort, err := resourceAwsLoadBalancerPortPolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(lbName)},
+		})
+		if err != nil {
+			if isLoadBalancerNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, lb := range describe.LoadBalancerDescriptions {
+			if len(flattenBackendPolicies(lb.BackendServerDescriptions)[instancePort]) > 0 {
+				return fmt.Errorf("ELB backend server still has policies assigned")
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLoadBalancerBackendServerPolicyAssigned(n, policyName string, assigned bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		lbName, instancePort, err := resourceAwsLoadBalancerPortPolicyParseId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+			LoadBalancerNames: []*string{aws.String(lbName)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(describe.LoadBalancerDescriptions) != 1 {
+			return fmt.Errorf("ELB not found")
+		}
+
+		found := false
+		policies := flattenBackendPolicies(describe.LoadBalancerDescriptions[0].BackendServerDescriptions)
+		for _, name := range policies[instancePort] {
+			if name == policyName {
+				found = true
+			}
+		}
+
+		if found != assigned {
+			return fmt.Errorf("Expected policy %s to be assigned: %t, got: %t", policyName, assigned, found)
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSLoadBalancerBackendServerPolicyConfig = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-backend-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 25
+		instance_protocol = "tcp"
+		lb_port = 25
+		lb_protocol = "tcp"
+	}
+}
+
+resource "aws_load_balancer_policy" "proxy-protocol" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-proxy-protocol"
+	policy_type_name = "ProxyProtocolPolicyType"
+
+	policy_attribute {
+		name = "ProxyProtocol"
+		value = "true"
+	}
+}
+
+resource "aws_load_balancer_backend_server_policy" "test-backend" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	instance_port = 25
+	policy_names = ["${aws_load_balancer_policy.proxy-protocol.policy_name}"]
+}
+`
+
+const testAccAWSLoadBalancerBackendServerPolicyConfigEmpty = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-backend-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 25
+		instance_protocol = "tcp"
+		lb_port = 25
+		lb_protocol = "tcp"
+	}
+}
+
+resource "aws_load_balancer_policy" "proxy-protocol" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-proxy-protocol"
+	policy_type_name = "ProxyProtocolPolicyType"
+
+	policy_attribute {
+		name = "ProxyProtocol"
+		value = "true"
+	}
+}
+
+resource "aws_load_balancer_backend_server_policy" "test-backend" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	instance_port = 25
+}
+`
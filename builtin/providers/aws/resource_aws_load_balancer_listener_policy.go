@@ -0,0 +1,140 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsLoadBalancerListenerPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsLoadBalancerListenerPolicySet,
+		Read:   resourceAwsLoadBalancerListenerPolicyRead,
+		Update: resourceAwsLoadBalancerListenerPolicySet,
+		Delete: resourceAwsLoadBalancerListenerPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"load_balancer_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_names": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceAwsLoadBalancerListenerPolicySet(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	// The given policies replace all the policies of the listener
+	input := &elb.SetLoadBalancerPoliciesOfListenerInput{
+		LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+		LoadBalancerPort: aws.Int64(int64(d.Get("load_balancer_port").(int))),
+		PolicyNames:      expandStringList(d.Get("policy_names").(*schema.Set).List()),
+	}
+
+	log.Printf("[DEBUG] ELB set listener policies: %#v", input)
+	if _, err := elbconn.SetLoadBalancerPoliciesOfListener(input); err != nil {
+		return fmt.Errorf("Error setting policies of ELB listener: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", *input.LoadBalancerName, *input.LoadBalancerPort))
+
+	return resourceAwsLoadBalancerListenerPolicyRead(d, meta)
+}
+
+func resourceAwsLoadBalancerListenerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, lbPort, err := resourceAwsLoadBalancerPortPolicyParseId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+	if len(describeResp.LoadBalancerDescriptions) != 1 {
+		return fmt.Errorf("Unable to find ELB: %#v", describeResp.LoadBalancerDescriptions)
+	}
+
+	for _, l := range describeResp.LoadBalancerDescriptions[0].ListenerDescriptions {
+		if aws.Int64Value(l.Listener.LoadBalancerPort) != lbPort {
+			continue
+		}
+
+		d.Set("load_balancer", lbName)
+		d.Set("load_balancer_port", lbPort)
+		d.Set("policy_names", flattenStringList(l.PolicyNames))
+
+		return nil
+	}
+
+	// The listener itself is gone
+	log.Printf("[WARN] ELB listener %s not found", d.Id())
+	d.SetId("")
+
+	return nil
+}
+
+func resourceAwsLoadBalancerListenerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	input := &elb.SetLoadBalancerPoliciesOfListenerInput{
+		LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+		LoadBalancerPort: aws.Int64(int64(d.Get("load_balancer_port").(int))),
+		PolicyNames:      []*string{},
+	}
+
+	log.Printf("[DEBUG] ELB remove listener policies: %#v", input)
+	if _, err := elbconn.SetLoadBalancerPoliciesOfListener(input); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error removing policies of ELB listener: %s", err)
+	}
+
+	return nil
+}
+
+// resourceAwsLoadBalancerPortPolicyParseId takes the ID of a listener or
+// backend server policy and parses it into the name of the load balancer and
+// the port the policies are assigned to.
+func resourceAwsLoadBalancerPortPolicyParseId(id string) (string, int64, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("Unexpected format of ID (%s), expected ELB:PORT", id)
+	}
+
+	port, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid port in ID (%s): %s", id, err)
+	}
+
+	return parts[0], port, nil
+}
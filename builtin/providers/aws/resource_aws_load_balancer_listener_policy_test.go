@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSLoadBalancerListenerPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLoadBalancerListenerPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerListenerPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerListenerPolicyCount("aws_load_balancer_listener_policy.test-lb-listener", 1),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerListenerPolicyConfigEmpty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerListenerPolicyCount("aws_load_balancer_listener_policy.test-lb-listener", 0),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLoadBalancerListenerPolicyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_load_balancer_listener_policy" {
+			continue
+		}
+
+		names, err := testAccAWSLoadBalancerListenerPolicyNames(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(names) > 0 {
+			return fmt.Errorf("ELB listener still has policies assigned")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLoadBalancerListenerPolicyCount(n string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ELB Listener Policy ID is set")
+		}
+
+		names, err := testAccAWSLoadBalancerListenerPolicyNames(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(names) != expected {
+			return fmt.Errorf("Wrong number of policies assigned to ELB listener, expected %d, got %d", expected, len(names))
+		}
+
+		return nil
+	}
+}
+
+// testAccAWSLoadBalancerListenerPolicyNames returns the names of the policies
+// assigned to the listener with the given ID.
+func testAccAWSLoadBalancerListenerPolicyNames(id string) ([]*string, error) {
+	lbName, lbPort, err := resourceAwsLoadBalancerPortPolicyParseId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := testAccProvider.Meta().(*AWSClient).elbconn
+	describe, err := conn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, lb := range describe.LoadBalancerDescriptions {
+		for _, l := range lb.ListenerDescriptions {
+			if *l.Listener.LoadBalancerPort == lbPort {
+				return l.PolicyNames, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+const testAccAWSLoadBalancerListenerPolicyConfig = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-listener-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 80
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_load_balancer_policy" "stickiness" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-listener-stickiness"
+	policy_type_name = "LBCookieStickinessPolicyType"
+
+	policy_attribute {
+		name = "CookieExpirationPeriod"
+		value = "86400"
+	}
+}
+
+resource "aws_load_balancer_listener_policy" "test-lb-listener" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	load_balancer_port = 80
+	policy_names = ["${aws_load_balancer_policy.stickiness.policy_name}"]
+}
+`
+
+const testAccAWSLoadBalancerListenerPolicyConfigEmpty = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-listener-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 80
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_load_balancer_policy" "stickiness" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-listener-stickiness"
+	policy_type_name = "LBCookieStickinessPolicyType"
+
+	policy_attribute {
+		name = "CookieExpirationPeriod"
+		value = "86400"
+	}
+}
+
+resource "aws_load_balancer_listener_policy" "test-lb-listener" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	load_balancer_port = 80
+}
+`
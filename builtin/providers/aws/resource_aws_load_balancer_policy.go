@@ -0,0 +1,260 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/hashcode"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsLoadBalancerPolicy() *schema.Resource {
+	return &schema.Resource{
+		// There is no concept of "updating" a policy in the AWS API, a
+		// changed policy is always a new policy.
+		Create: resourceAwsLoadBalancerPolicyCreate,
+		Read:   resourceAwsLoadBalancerPolicyRead,
+		Delete: resourceAwsLoadBalancerPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"load_balancer": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateElbPolicyName,
+			},
+
+			"policy_type_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_attribute": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Set: resourceAwsLoadBalancerPolicyAttributeHash,
+			},
+		},
+	}
+}
+
+func resourceAwsLoadBalancerPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	input := &elb.CreateLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(d.Get("load_balancer").(string)),
+		PolicyName:       aws.String(d.Get("policy_name").(string)),
+		PolicyTypeName:   aws.String(d.Get("policy_type_name").(string)),
+		PolicyAttributes: expandLoadBalancerPolicyAttributes(d.Get("policy_attribute").(*schema.Set).List()),
+	}
+
+	log.Printf("[DEBUG] ELB create a policy %s from policy type %s",
+		*input.PolicyName, *input.PolicyTypeName)
+	if _, err := elbconn.CreateLoadBalancerPolicy(input); err != nil {
+		return fmt.Errorf("Error creating a policy %s: %s", *input.PolicyName, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", *input.LoadBalancerName, *input.PolicyName))
+	log.Printf("[INFO] ELB Policy ID: %s", d.Id())
+
+	return resourceAwsLoadBalancerPolicyRead(d, meta)
+}
+
+func resourceAwsLoadBalancerPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, policyName := resourceAwsLoadBalancerPolicyParseId(d.Id())
+
+	getResp, err := elbconn.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{
+		LoadBalancerName: aws.String(lbName),
+		PolicyNames:      []*string{aws.String(policyName)},
+	})
+	if err != nil {
+		if elberr, ok := err.(awserr.Error); ok && (elberr.Code() == "PolicyNotFound" || elberr.Code() == "LoadBalancerNotFound") {
+			// The policy is gone.
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving policy: %s", err)
+	}
+
+	if len(getResp.PolicyDescriptions) != 1 {
+		return fmt.Errorf("Unable to find policy %#v", getResp.PolicyDescriptions)
+	}
+
+	policyDesc := getResp.PolicyDescriptions[0]
+
+	d.Set("load_balancer", lbName)
+	d.Set("policy_name", policyDesc.PolicyName)
+	d.Set("policy_type_name", policyDesc.PolicyTypeName)
+
+	// Only the attributes that were configured are read back, since AWS
+	// returns all the attributes of the policy type, most of them with a
+	// default value.
+	configured := make(map[string]bool)
+	for _, a := range d.Get("policy_attribute").(*schema.Set).List() {
+		configured[a.(map[string]interface{})["name"].(string)] = true
+	}
+
+	attributes := make([]map[string]interface{}, 0, len(configured))
+	for _, a := range policyDesc.PolicyAttributeDescriptions {
+		if !configured[aws.StringValue(a.AttributeName)] {
+			continue
+		}
+		attributes = append(attributes, map[string]interface{}{
+			"name":  aws.StringValue(a.AttributeName),
+			"value": aws.StringValue(a.AttributeValue),
+		})
+	}
+
+	if err := d.Set("policy_attribute", attributes); err != nil {
+		return fmt.Errorf("Error setting policy_attribute for ELB Policy %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsLoadBalancerPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbconn
+
+	lbName, policyName := resourceAwsLoadBalancerPolicyParseId(d.Id())
+
+	// A policy can't be deleted while it is in use, so it is unassigned
+	// from all the listeners and backend servers first.
+	if err := resourceAwsLoadBalancerPolicyUnassign(elbconn, lbName, policyName); err != nil {
+		return err
+	}
+
+	request := &elb.DeleteLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String(lbName),
+		PolicyName:       aws.String(policyName),
+	}
+
+	log.Printf("[DEBUG] ELB delete policy: %#v", request)
+	if _, err := elbconn.DeleteLoadBalancerPolicy(request); err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting ELB Policy %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsLoadBalancerPolicyUnassign removes the given policy from every
+// listener and backend server of the load balancer it is assigned to.
+func resourceAwsLoadBalancerPolicyUnassign(elbconn *elb.ELB, lbName, policyName string) error {
+	describeResp, err := elbconn.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: []*string{aws.String(lbName)},
+	})
+	if err != nil {
+		if isLoadBalancerNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+
+	for _, lb := range describeResp.LoadBalancerDescriptions {
+		for _, l := range lb.ListenerDescriptions {
+			remaining, found := removePolicyName(l.PolicyNames, policyName)
+			if !found {
+				continue
+			}
+
+			log.Printf("[DEBUG] Unassigning ELB Policy %s from listener %d", policyName, *l.Listener.LoadBalancerPort)
+			if _, err := elbconn.SetLoadBalancerPoliciesOfListener(&elb.SetLoadBalancerPoliciesOfListenerInput{
+				LoadBalancerName: aws.String(lbName),
+				LoadBalancerPort: l.Listener.LoadBalancerPort,
+				PolicyNames:      remaining,
+			}); err != nil {
+				return fmt.Errorf("Error unassigning ELB Policy %s from listener: %s", policyName, err)
+			}
+		}
+
+		for _, b := range lb.BackendServerDescriptions {
+			remaining, found := removePolicyName(b.PolicyNames, policyName)
+			if !found {
+				continue
+			}
+
+			log.Printf("[DEBUG] Unassigning ELB Policy %s from backend server %d", policyName, *b.InstancePort)
+			if _, err := elbconn.SetLoadBalancerPoliciesForBackendServer(&elb.SetLoadBalancerPoliciesForBackendServerInput{
+				LoadBalancerName: aws.String(lbName),
+				InstancePort:     b.InstancePort,
+				PolicyNames:      remaining,
+			}); err != nil {
+				return fmt.Errorf("Error unassigning ELB Policy %s from backend server: %s", policyName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// removePolicyName returns the policy names without the given name, and
+// whether the name was present at all.
+func removePolicyName(names []*string, name string) ([]*string, bool) {
+	remaining := make([]*string, 0, len(names))
+	found := false
+	for _, n := range names {
+		if aws.StringValue(n) == name {
+			found = true
+			continue
+		}
+		remaining = append(remaining, n)
+	}
+	return remaining, found
+}
+
+func expandLoadBalancerPolicyAttributes(configured []interface{}) []*elb.PolicyAttribute {
+	attributes := make([]*elb.PolicyAttribute, 0, len(configured))
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		attributes = append(attributes, &elb.PolicyAttribute{
+			AttributeName:  aws.String(data["name"].(string)),
+			AttributeValue: aws.String(data["value"].(string)),
+		})
+	}
+	return attributes
+}
+
+func resourceAwsLoadBalancerPolicyAttributeHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["value"].(string)))
+	return hashcode.String(buf.String())
+}
+
+// resourceAwsLoadBalancerPolicyParseId takes an ID and parses it into the
+// name of the load balancer and the name of the policy.
+func resourceAwsLoadBalancerPolicyParseId(id string) (string, string) {
+	parts := strings.SplitN(id, ":", 2)
+	return parts[0], parts[1]
+}
@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSLoadBalancerPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLoadBalancerPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerPolicyExists("aws_load_balancer_policy.test-policy"),
+					resource.TestCheckResourceAttr(
+						"aws_load_balancer_policy.test-policy", "policy_type_name", "LBCookieStickinessPolicyType"),
+					resource.TestCheckResourceAttr(
+						"aws_load_balancer_policy.test-policy", "policy_attribute.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSLoadBalancerPolicyConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLoadBalancerPolicyExists("aws_load_balancer_policy.test-policy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLoadBalancerPolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elbconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_load_balancer_policy" {
+			continue
+		}
+
+		lbName, policyName := resourceAwsLoadBalancerPolicyParseId(rs.Primary.ID)
+		out, err := conn.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{
+			LoadBalancerName: aws.String(lbName),
+			PolicyNames:      []*string{aws.String(policyName)},
+		})
+		if err != nil {
+			if elberr, ok := err.(awserr.Error); ok && (elberr.Code() == "PolicyNotFound" || elberr.Code() == "LoadBalancerNotFound") {
+				continue
+			}
+			return err
+		}
+
+		if len(out.PolicyDescriptions) > 0 {
+			return fmt.Errorf("Policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLoadBalancerPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ELB Policy ID is set")
+		}
+
+		lbName, policyName := resourceAwsLoadBalancerPolicyParseId(rs.Primary.ID)
+		conn := testAccProvider.Meta().(*AWSClient).elbconn
+		out, err := conn.DescribeLoadBalancerPolicies(&elb.DescribeLoadBalancerPoliciesInput{
+			LoadBalancerName: aws.String(lbName),
+			PolicyNames:      []*string{aws.String(policyName)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(out.PolicyDescriptions) != 1 {
+			return fmt.Errorf("ELB Policy not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccAWSLoadBalancerPolicyConfig = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 80
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_load_balancer_policy" "test-policy" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-policy-policy"
+	policy_type_name = "LBCookieStickinessPolicyType"
+
+	policy_attribute {
+		name = "CookieExpirationPeriod"
+		value = "86400"
+	}
+}
+`
+
+const testAccAWSLoadBalancerPolicyConfigUpdate = `
+resource "aws_elb" "test-lb" {
+	name = "test-lb-policy"
+	availability_zones = ["us-west-2a"]
+
+	listener {
+		instance_port = 80
+		instance_protocol = "http"
+		lb_port = 80
+		lb_protocol = "http"
+	}
+}
+
+resource "aws_load_balancer_policy" "test-policy" {
+	load_balancer = "${aws_elb.test-lb.name}"
+	policy_name = "test-policy-policy"
+	policy_type_name = "LBCookieStickinessPolicyType"
+
+	policy_attribute {
+		name = "CookieExpirationPeriod"
+		value = "3600"
+	}
+}
+`
@@ -0,0 +1,490 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/xanzy/terraform-api/helper/hashcode"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/opsworks"
+)
+
+// Like the layer settings, some of the application settings are packed into
+// an "Attributes" map by the OpsWorks API.
+var opsworksApplicationAttributes = map[string]*opsworksLayerTypeAttribute{
+	"document_root": &opsworksLayerTypeAttribute{
+		AttrName: "DocumentRoot",
+		Type:     schema.TypeString,
+	},
+	"rails_env": &opsworksLayerTypeAttribute{
+		AttrName: "RailsEnv",
+		Type:     schema.TypeString,
+	},
+	"auto_bundle_on_deploy": &opsworksLayerTypeAttribute{
+		AttrName: "AutoBundleOnDeploy",
+		Type:     schema.TypeString,
+	},
+	"aws_flow_ruby_settings": &opsworksLayerTypeAttribute{
+		AttrName: "AwsFlowRubySettings",
+		Type:     schema.TypeString,
+	},
+}
+
+func resourceAwsOpsworksApplication() *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		"short_name": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+
+		"stack_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"type": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				opsworks.AppTypeAwsFlowRuby,
+				opsworks.AppTypeJava,
+				opsworks.AppTypeRails,
+				opsworks.AppTypePhp,
+				opsworks.AppTypeNodejs,
+				opsworks.AppTypeStatic,
+				opsworks.AppTypeOther,
+			}, false),
+		},
+
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"domains": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		"environment": &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"value": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"secure": &schema.Schema{
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+			Set: resourceAwsOpsworksApplicationEnvironmentHash,
+		},
+
+		"app_source": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							opsworks.SourceTypeGit,
+							opsworks.SourceTypeSvn,
+							opsworks.SourceTypeArchive,
+							opsworks.SourceTypeS3,
+						}, false),
+					},
+
+					"url": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"username": &schema.Schema{
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+
+					"password": &schema.Schema{
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+
+					"revision": &schema.Schema{
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+
+					"ssh_key": &schema.Schema{
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+
+		"data_source_type": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"data_source_arn": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"data_source_database_name": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"enable_ssl": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"ssl_configuration": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"certificate": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"private_key": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"chain": &schema.Schema{
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	opsworksAttributesSchema(resourceSchema, opsworksApplicationAttributes)
+
+	return &schema.Resource{
+		Create: resourceAwsOpsworksApplicationCreate,
+		Read:   resourceAwsOpsworksApplicationRead,
+		Update: resourceAwsOpsworksApplicationUpdate,
+		Delete: resourceAwsOpsworksApplicationDelete,
+
+		Schema: resourceSchema,
+	}
+}
+
+func resourceAwsOpsworksApplicationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.DescribeAppsInput{
+		AppIds: []*string{
+			aws.String(d.Id()),
+		},
+	}
+
+	log.Printf("[DEBUG] Reading OpsWorks application: %s", d.Id())
+
+	resp, err := client.DescribeApps(req)
+	if err != nil {
+		if awserr, ok := err.(awserr.Error); ok {
+			if awserr.Code() == "ResourceNotFoundException" {
+				log.Printf("[DEBUG] OpsWorks application (%s) not found", d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	app := resp.Apps[0]
+	d.Set("name", app.Name)
+	d.Set("short_name", app.Shortname)
+	d.Set("stack_id", app.StackId)
+	d.Set("type", app.Type)
+	d.Set("description", app.Description)
+	d.Set("domains", unwrapAwsStringList(app.Domains))
+	d.Set("enable_ssl", app.EnableSsl)
+
+	resourceAwsOpsworksSetApplicationEnvironmentVariables(d, app.Environment)
+	resourceAwsOpsworksSetApplicationSource(d, app.AppSource)
+	resourceAwsOpsworksSetApplicationSsl(d, app.SslConfiguration)
+	resourceAwsOpsworksSetApplicationDataSources(d, app.DataSources)
+	opsworksSetAttributeMap(d, opsworksApplicationAttributes, app.Attributes)
+
+	return nil
+}
+
+func resourceAwsOpsworksApplicationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.CreateAppInput{
+		Name:             aws.String(d.Get("name").(string)),
+		StackId:          aws.String(d.Get("stack_id").(string)),
+		Type:             aws.String(d.Get("type").(string)),
+		Description:      aws.String(d.Get("description").(string)),
+		Domains:          makeAwsStringList(d.Get("domains").([]interface{})),
+		EnableSsl:        aws.Bool(d.Get("enable_ssl").(bool)),
+		SslConfiguration: resourceAwsOpsworksApplicationSsl(d),
+		AppSource:        resourceAwsOpsworksApplicationSource(d),
+		DataSources:      resourceAwsOpsworksApplicationDataSources(d),
+		Environment:      resourceAwsOpsworksApplicationEnvironmentVariables(d),
+		Attributes:       opsworksAttributeMap(d, opsworksApplicationAttributes),
+	}
+
+	if v, ok := d.GetOk("short_name"); ok {
+		req.Shortname = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating OpsWorks application: %s", req)
+
+	resp, err := client.CreateApp(req)
+	if err != nil {
+		return fmt.Errorf("Error creating OpsWorks application: %s", err)
+	}
+
+	appId := *resp.AppId
+	d.SetId(appId)
+	d.Set("id", appId)
+
+	return resourceAwsOpsworksApplicationRead(d, meta)
+}
+
+func resourceAwsOpsworksApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.UpdateAppInput{
+		AppId:            aws.String(d.Id()),
+		Name:             aws.String(d.Get("name").(string)),
+		Type:             aws.String(d.Get("type").(string)),
+		Description:      aws.String(d.Get("description").(string)),
+		Domains:          makeAwsStringList(d.Get("domains").([]interface{})),
+		EnableSsl:        aws.Bool(d.Get("enable_ssl").(bool)),
+		SslConfiguration: resourceAwsOpsworksApplicationSsl(d),
+		AppSource:        resourceAwsOpsworksApplicationSource(d),
+		DataSources:      resourceAwsOpsworksApplicationDataSources(d),
+		Environment:      resourceAwsOpsworksApplicationEnvironmentVariables(d),
+		Attributes:       opsworksAttributeMap(d, opsworksApplicationAttributes),
+	}
+
+	log.Printf("[DEBUG] Updating OpsWorks application: %s", req)
+
+	_, err := client.UpdateApp(req)
+	if err != nil {
+		return fmt.Errorf("Error updating OpsWorks application %s: %s", d.Id(), err)
+	}
+
+	return resourceAwsOpsworksApplicationRead(d, meta)
+}
+
+func resourceAwsOpsworksApplicationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.DeleteAppInput{
+		AppId: aws.String(d.Id()),
+	}
+
+	log.Printf("[DEBUG] Deleting OpsWorks application: %s", d.Id())
+
+	_, err := client.DeleteApp(req)
+	return err
+}
+
+func resourceAwsOpsworksApplicationSource(d *schema.ResourceData) *opsworks.Source {
+	count := d.Get("app_source.#").(int)
+	if count == 0 {
+		return nil
+	}
+
+	return &opsworks.Source{
+		Type:     aws.String(d.Get("app_source.0.type").(string)),
+		Url:      aws.String(d.Get("app_source.0.url").(string)),
+		Username: aws.String(d.Get("app_source.0.username").(string)),
+		Password: aws.String(d.Get("app_source.0.password").(string)),
+		Revision: aws.String(d.Get("app_source.0.revision").(string)),
+		SshKey:   aws.String(d.Get("app_source.0.ssh_key").(string)),
+	}
+}
+
+func resourceAwsOpsworksSetApplicationSource(d *schema.ResourceData, v *opsworks.Source) {
+	nv := make([]interface{}, 0, 1)
+	if v != nil {
+		m := make(map[string]interface{})
+		if v.Type != nil {
+			m["type"] = *v.Type
+		}
+		if v.Url != nil {
+			m["url"] = *v.Url
+		}
+		if v.Username != nil {
+			m["username"] = *v.Username
+		}
+		if v.Revision != nil {
+			m["revision"] = *v.Revision
+		}
+		// The password and the SSH key are returned as a placeholder, so
+		// we keep what we already have stored.
+		m["password"] = d.Get("app_source.0.password").(string)
+		m["ssh_key"] = d.Get("app_source.0.ssh_key").(string)
+		nv = append(nv, m)
+	}
+
+	err := d.Set("app_source", nv)
+	if err != nil {
+		// should never happen
+		panic(err)
+	}
+}
+
+func resourceAwsOpsworksApplicationDataSources(d *schema.ResourceData) []*opsworks.DataSource {
+	if v, ok := d.GetOk("data_source_type"); ok {
+		return []*opsworks.DataSource{
+			&opsworks.DataSource{
+				Type:         aws.String(v.(string)),
+				Arn:          aws.String(d.Get("data_source_arn").(string)),
+				DatabaseName: aws.String(d.Get("data_source_database_name").(string)),
+			},
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsOpsworksSetApplicationDataSources(d *schema.ResourceData, v []*opsworks.DataSource) {
+	d.Set("data_source_type", nil)
+	d.Set("data_source_arn", nil)
+	d.Set("data_source_database_name", nil)
+
+	// OpsWorks supports a single data source for each application
+	if len(v) == 0 {
+		return
+	}
+
+	d.Set("data_source_type", v[0].Type)
+	d.Set("data_source_arn", v[0].Arn)
+	d.Set("data_source_database_name", v[0].DatabaseName)
+}
+
+func resourceAwsOpsworksApplicationSsl(d *schema.ResourceData) *opsworks.SslConfiguration {
+	count := d.Get("ssl_configuration.#").(int)
+	if count == 0 {
+		return nil
+	}
+
+	return &opsworks.SslConfiguration{
+		Certificate: aws.String(d.Get("ssl_configuration.0.certificate").(string)),
+		PrivateKey:  aws.String(d.Get("ssl_configuration.0.private_key").(string)),
+		Chain:       aws.String(d.Get("ssl_configuration.0.chain").(string)),
+	}
+}
+
+func resourceAwsOpsworksSetApplicationSsl(d *schema.ResourceData, v *opsworks.SslConfiguration) {
+	nv := make([]interface{}, 0, 1)
+	if v != nil && aws.StringValue(v.Certificate) != "" {
+		nv = append(nv, map[string]interface{}{
+			"certificate": aws.StringValue(v.Certificate),
+			// The private key is never returned by the API
+			"private_key": d.Get("ssl_configuration.0.private_key").(string),
+			"chain":       aws.StringValue(v.Chain),
+		})
+	}
+
+	err := d.Set("ssl_configuration", nv)
+	if err != nil {
+		// should never happen
+		panic(err)
+	}
+}
+
+func resourceAwsOpsworksApplicationEnvironmentVariables(d *schema.ResourceData) []*opsworks.EnvironmentVariable {
+	configured := d.Get("environment").(*schema.Set).List()
+	result := make([]*opsworks.EnvironmentVariable, 0, len(configured))
+
+	for _, raw := range configured {
+		data := raw.(map[string]interface{})
+		result = append(result, &opsworks.EnvironmentVariable{
+			Key:    aws.String(data["key"].(string)),
+			Value:  aws.String(data["value"].(string)),
+			Secure: aws.Bool(data["secure"].(bool)),
+		})
+	}
+
+	return result
+}
+
+func resourceAwsOpsworksSetApplicationEnvironmentVariables(d *schema.ResourceData, v []*opsworks.EnvironmentVariable) {
+	// The values of secure variables aren't returned by the API, so they
+	// are taken from the configuration instead.
+	configured := make(map[string]string)
+	for _, raw := range d.Get("environment").(*schema.Set).List() {
+		data := raw.(map[string]interface{})
+		configured[data["key"].(string)] = data["value"].(string)
+	}
+
+	result := make([]map[string]interface{}, 0, len(v))
+	for _, variable := range v {
+		data := map[string]interface{}{
+			"key":    aws.StringValue(variable.Key),
+			"value":  aws.StringValue(variable.Value),
+			"secure": aws.BoolValue(variable.Secure),
+		}
+		if aws.BoolValue(variable.Secure) {
+			data["value"] = configured[aws.StringValue(variable.Key)]
+		}
+		result = append(result, data)
+	}
+
+	err := d.Set("environment", result)
+	if err != nil {
+		// should never happen
+		panic(err)
+	}
+}
+
+func resourceAwsOpsworksApplicationEnvironmentHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["key"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["value"].(string)))
+	buf.WriteString(fmt.Sprintf("%t-", m["secure"].(bool)))
+	return hashcode.String(buf.String())
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/opsworks"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// These tests assume the existence of predefined Opsworks IAM roles named `aws-opsworks-ec2-role`
+// and `aws-opsworks-service-role`.
+
+func TestAccAWSOpsworksApplication(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsOpsworksApplicationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsOpsworksApplicationConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "name", "tf-ops-acc-application",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "type", "other",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "enable_ssl", "false",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "environment.#", "1",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "document_root", "foo",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAwsOpsworksApplicationConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "name", "tf-ops-acc-application",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "type", "rails",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "app_source.#", "1",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "app_source.0.type", "git",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "app_source.0.url", "https://github.com/aws/example.git",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "environment.#", "2",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "rails_env", "staging",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_application.tf-acc-app", "auto_bundle_on_deploy", "true",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsOpsworksApplicationDestroy(s *terraform.State) error {
+	opsworksconn := testAccProvider.Meta().(*AWSClient).opsworksconn
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_opsworks_application" {
+			continue
+		}
+
+		resp, err := opsworksconn.DescribeApps(&opsworks.DescribeAppsInput{
+			AppIds: []*string{
+				aws.String(rs.Primary.ID),
+			},
+		})
+		if err != nil {
+			if awserr, ok := err.(awserr.Error); ok && awserr.Code() == "ResourceNotFoundException" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.Apps) > 0 {
+			return fmt.Errorf("OpsWorks application still exists")
+		}
+	}
+
+	return nil
+}
+
+var testAccAwsOpsworksApplicationConfigCreate = testAccAwsOpsworksStackConfigNoVpcCreate + `
+provider "aws" {
+	region = "us-east-1"
+}
+
+resource "aws_opsworks_application" "tf-acc-app" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  name = "tf-ops-acc-application"
+  type = "other"
+  enable_ssl = false
+  document_root = "foo"
+
+  environment {
+    key = "key1"
+    value = "value1"
+    secure = false
+  }
+}
+`
+
+var testAccAwsOpsworksApplicationConfigUpdate = testAccAwsOpsworksStackConfigNoVpcCreate + `
+provider "aws" {
+	region = "us-east-1"
+}
+
+resource "aws_opsworks_application" "tf-acc-app" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  name = "tf-ops-acc-application"
+  type = "rails"
+  domains = ["example.com", "sub.example.com"]
+  rails_env = "staging"
+  auto_bundle_on_deploy = "true"
+
+  app_source {
+    type = "git"
+    revision = "master"
+    url = "https://github.com/aws/example.git"
+  }
+
+  environment {
+    key = "key1"
+    value = "value1"
+    secure = false
+  }
+
+  environment {
+    key = "key2"
+    value = "value2"
+  }
+}
+`
@@ -38,6 +38,9 @@ func TestAccAWSOpsworksCustomLayer(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"aws_opsworks_custom_layer.tf-acc", "instance_shutdown_timeout", "300",
 					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_custom_layer.tf-acc", "custom_json", `{"layer_key":"layer_value1"}`,
+					),
 					resource.TestCheckResourceAttr(
 						"aws_opsworks_custom_layer.tf-acc", "custom_security_group_ids.#", "2",
 					),
@@ -79,6 +82,9 @@ func TestAccAWSOpsworksCustomLayer(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"aws_opsworks_custom_layer.tf-acc", "instance_shutdown_timeout", "120",
 					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_custom_layer.tf-acc", "custom_json", `{"layer_key":"layer_value2"}`,
+					),
 					resource.TestCheckResourceAttr(
 						"aws_opsworks_custom_layer.tf-acc", "custom_security_group_ids.#", "3",
 					),
@@ -197,6 +203,7 @@ resource "aws_opsworks_custom_layer" "tf-acc" {
   ]
   drain_elb_on_shutdown = true
   instance_shutdown_timeout = 300
+  custom_json = "{\"layer_key\": \"layer_value1\"}"
   system_packages = [
     "git",
     "golang",
@@ -233,6 +240,7 @@ resource "aws_opsworks_custom_layer" "tf-acc" {
   ]
   drain_elb_on_shutdown = false
   instance_shutdown_timeout = 120
+  custom_json = "{\"layer_key\": \"layer_value2\"}"
   system_packages = [
     "git",
     "golang",
@@ -0,0 +1,469 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/opsworks"
+)
+
+func resourceAwsOpsworksInstance() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsOpsworksInstanceCreate,
+		Read:   resourceAwsOpsworksInstanceRead,
+		Update: resourceAwsOpsworksInstanceUpdate,
+		Delete: resourceAwsOpsworksInstanceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stack_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"layer_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"instance_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "running",
+				ValidateFunc: validation.StringInSlice([]string{
+					"running",
+					"stopped",
+				}, false),
+			},
+
+			"auto_scaling_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					opsworks.AutoScalingTypeLoad,
+					opsworks.AutoScalingTypeTimer,
+				}, false),
+			},
+
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"os": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ami_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"architecture": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  opsworks.ArchitectureX8664,
+				ValidateFunc: validation.StringInSlice([]string{
+					opsworks.ArchitectureX8664,
+					opsworks.ArchitectureI386,
+				}, false),
+			},
+
+			"availability_zone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"subnet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"ssh_key_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"root_device_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					opsworks.RootDeviceTypeEbs,
+					opsworks.RootDeviceTypeInstanceStore,
+				}, false),
+			},
+
+			"virtualization_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"tenancy": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"install_updates_on_boot": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ebs_optimized": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"agent_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "INHERIT",
+			},
+
+			"ec2_instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"private_dns": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"private_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_dns": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsOpsworksInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.DescribeInstancesInput{
+		InstanceIds: []*string{
+			aws.String(d.Id()),
+		},
+	}
+
+	log.Printf("[DEBUG] Reading OpsWorks instance: %s", d.Id())
+
+	resp, err := client.DescribeInstances(req)
+	if err != nil {
+		if awserr, ok := err.(awserr.Error); ok {
+			if awserr.Code() == "ResourceNotFoundException" {
+				log.Printf("[DEBUG] OpsWorks instance (%s) not found", d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	instance := resp.Instances[0]
+	d.Set("stack_id", instance.StackId)
+	d.Set("layer_ids", unwrapAwsStringList(instance.LayerIds))
+	d.Set("instance_type", instance.InstanceType)
+	d.Set("auto_scaling_type", instance.AutoScalingType)
+	d.Set("hostname", instance.Hostname)
+	d.Set("os", instance.Os)
+	d.Set("ami_id", instance.AmiId)
+	d.Set("architecture", instance.Architecture)
+	d.Set("availability_zone", instance.AvailabilityZone)
+	d.Set("subnet_id", instance.SubnetId)
+	d.Set("ssh_key_name", instance.SshKeyName)
+	d.Set("root_device_type", instance.RootDeviceType)
+	d.Set("virtualization_type", instance.VirtualizationType)
+	d.Set("tenancy", instance.Tenancy)
+	d.Set("install_updates_on_boot", instance.InstallUpdatesOnBoot)
+	d.Set("ebs_optimized", instance.EbsOptimized)
+	d.Set("agent_version", instance.AgentVersion)
+	d.Set("ec2_instance_id", instance.Ec2InstanceId)
+	d.Set("status", instance.Status)
+	d.Set("private_dns", instance.PrivateDns)
+	d.Set("private_ip", instance.PrivateIp)
+	d.Set("public_dns", instance.PublicDns)
+	d.Set("public_ip", instance.PublicIp)
+
+	// Only the running and stopped states are managed, any other status
+	// is on its way to one of them. Instances with an auto scaling type are
+	// started and stopped by OpsWorks itself, so their state is left alone.
+	if aws.StringValue(instance.AutoScalingType) == "" {
+		switch aws.StringValue(instance.Status) {
+		case "stopped", "stopping", "shutting_down", "terminated", "terminating":
+			d.Set("state", "stopped")
+		default:
+			d.Set("state", "running")
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsOpsworksInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.CreateInstanceInput{
+		StackId:              aws.String(d.Get("stack_id").(string)),
+		LayerIds:             makeAwsStringList(d.Get("layer_ids").([]interface{})),
+		InstanceType:         aws.String(d.Get("instance_type").(string)),
+		Architecture:         aws.String(d.Get("architecture").(string)),
+		InstallUpdatesOnBoot: aws.Bool(d.Get("install_updates_on_boot").(bool)),
+		EbsOptimized:         aws.Bool(d.Get("ebs_optimized").(bool)),
+		AgentVersion:         aws.String(d.Get("agent_version").(string)),
+	}
+
+	if v, ok := d.GetOk("auto_scaling_type"); ok {
+		req.AutoScalingType = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("hostname"); ok {
+		req.Hostname = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("os"); ok {
+		req.Os = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("ami_id"); ok {
+		req.AmiId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("availability_zone"); ok {
+		req.AvailabilityZone = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("subnet_id"); ok {
+		req.SubnetId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("ssh_key_name"); ok {
+		req.SshKeyName = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("root_device_type"); ok {
+		req.RootDeviceType = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("virtualization_type"); ok {
+		req.VirtualizationType = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("tenancy"); ok {
+		req.Tenancy = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating OpsWorks instance: %s", req)
+
+	resp, err := client.CreateInstance(req)
+	if err != nil {
+		return fmt.Errorf("Error creating OpsWorks instance: %s", err)
+	}
+
+	instanceId := *resp.InstanceId
+	d.SetId(instanceId)
+	d.Set("id", instanceId)
+
+	// Instances with an auto scaling type are started and stopped by
+	// OpsWorks itself.
+	if _, ok := d.GetOk("auto_scaling_type"); !ok && d.Get("state").(string) == "running" {
+		if err := resourceAwsOpsworksInstanceStart(d, client); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsOpsworksInstanceRead(d, meta)
+}
+
+func resourceAwsOpsworksInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	req := &opsworks.UpdateInstanceInput{
+		InstanceId:           aws.String(d.Id()),
+		LayerIds:             makeAwsStringList(d.Get("layer_ids").([]interface{})),
+		InstanceType:         aws.String(d.Get("instance_type").(string)),
+		Architecture:         aws.String(d.Get("architecture").(string)),
+		InstallUpdatesOnBoot: aws.Bool(d.Get("install_updates_on_boot").(bool)),
+		EbsOptimized:         aws.Bool(d.Get("ebs_optimized").(bool)),
+		AgentVersion:         aws.String(d.Get("agent_version").(string)),
+	}
+
+	if v, ok := d.GetOk("hostname"); ok {
+		req.Hostname = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("os"); ok {
+		req.Os = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("ami_id"); ok {
+		req.AmiId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("ssh_key_name"); ok {
+		req.SshKeyName = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Updating OpsWorks instance: %s", req)
+
+	_, err := client.UpdateInstance(req)
+	if err != nil {
+		return fmt.Errorf("Error updating OpsWorks instance %s: %s", d.Id(), err)
+	}
+
+	if _, ok := d.GetOk("auto_scaling_type"); !ok && d.HasChange("state") {
+		if d.Get("state").(string) == "running" {
+			err = resourceAwsOpsworksInstanceStart(d, client)
+		} else {
+			err = resourceAwsOpsworksInstanceStop(d, client)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsOpsworksInstanceRead(d, meta)
+}
+
+func resourceAwsOpsworksInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AWSClient).opsworksconn
+
+	// An instance has to be stopped before it can be deleted
+	if d.Get("status").(string) != "stopped" {
+		if err := resourceAwsOpsworksInstanceStop(d, client); err != nil {
+			return err
+		}
+	}
+
+	req := &opsworks.DeleteInstanceInput{
+		InstanceId: aws.String(d.Id()),
+	}
+
+	log.Printf("[DEBUG] Deleting OpsWorks instance: %s", d.Id())
+
+	_, err := client.DeleteInstance(req)
+	if err != nil {
+		return fmt.Errorf("Error deleting OpsWorks instance %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsOpsworksInstanceStart(d *schema.ResourceData, client *opsworks.OpsWorks) error {
+	log.Printf("[DEBUG] Starting OpsWorks instance: %s", d.Id())
+
+	_, err := client.StartInstance(&opsworks.StartInstanceInput{
+		InstanceId: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error starting OpsWorks instance %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"requested", "pending", "booting", "running_setup"},
+		Target:     "online",
+		Refresh:    resourceAwsOpsworksInstanceStateRefreshFunc(client, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for OpsWorks instance (%s) to become online: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsOpsworksInstanceStop(d *schema.ResourceData, client *opsworks.OpsWorks) error {
+	log.Printf("[DEBUG] Stopping OpsWorks instance: %s", d.Id())
+
+	_, err := client.StopInstance(&opsworks.StopInstanceInput{
+		InstanceId: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error stopping OpsWorks instance %s: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"stopping", "terminating", "shutting_down", "terminated"},
+		Target:     "stopped",
+		Refresh:    resourceAwsOpsworksInstanceStateRefreshFunc(client, d.Id()),
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for OpsWorks instance (%s) to become stopped: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsOpsworksInstanceStateRefreshFunc returns a
+// resource.StateRefreshFunc that is used to watch the status of an OpsWorks
+// instance.
+func resourceAwsOpsworksInstanceStateRefreshFunc(client *opsworks.OpsWorks, instanceId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.DescribeInstances(&opsworks.DescribeInstancesInput{
+			InstanceIds: []*string{
+				aws.String(instanceId),
+			},
+		})
+		if err != nil {
+			if awserr, ok := err.(awserr.Error); ok && awserr.Code() == "ResourceNotFoundException" {
+				// Set this to nil as if we didn't find anything.
+				return nil, "", nil
+			}
+			log.Printf("[ERROR] Error on OpsworksInstanceStateRefresh: %s", err)
+			return nil, "", err
+		}
+
+		if resp == nil || len(resp.Instances) == 0 {
+			return nil, "", nil
+		}
+
+		i := resp.Instances[0]
+		return i, aws.StringValue(i.Status), nil
+	}
+}
@@ -0,0 +1,154 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/opsworks"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// These tests assume the existence of predefined Opsworks IAM roles named `aws-opsworks-ec2-role`
+// and `aws-opsworks-service-role`.
+
+func TestAccAWSOpsworksInstance(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsOpsworksInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsOpsworksInstanceConfigCreate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "hostname", "tf-acc1",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "instance_type", "t2.micro",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "state", "stopped",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "layer_ids.#", "1",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAwsOpsworksInstanceConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "hostname", "tf-acc1",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "instance_type", "t2.small",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "layer_ids.#", "2",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSOpsworksInstance_autoScaling(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsOpsworksInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAwsOpsworksInstanceConfigAutoScaling,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "auto_scaling_type", "timer",
+					),
+					resource.TestCheckResourceAttr(
+						"aws_opsworks_instance.tf-acc", "status", "stopped",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsOpsworksInstanceDestroy(s *terraform.State) error {
+	opsworksconn := testAccProvider.Meta().(*AWSClient).opsworksconn
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_opsworks_instance" {
+			continue
+		}
+
+		resp, err := opsworksconn.DescribeInstances(&opsworks.DescribeInstancesInput{
+			InstanceIds: []*string{
+				aws.String(rs.Primary.ID),
+			},
+		})
+		if err != nil {
+			if awserr, ok := err.(awserr.Error); ok && awserr.Code() == "ResourceNotFoundException" {
+				continue
+			}
+			return err
+		}
+
+		if len(resp.Instances) > 0 {
+			return fmt.Errorf("OpsWorks instance still exists")
+		}
+	}
+
+	return nil
+}
+
+var testAccAwsOpsworksInstanceLayers = `
+provider "aws" {
+	region = "us-east-1"
+}
+
+resource "aws_opsworks_custom_layer" "tf-acc" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  name = "tf-ops-acc-instance-layer"
+  short_name = "tf-ops-acc-instance-layer"
+}
+
+resource "aws_opsworks_custom_layer" "tf-acc-2" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  name = "tf-ops-acc-instance-layer-2"
+  short_name = "tf-ops-acc-instance-layer-2"
+}
+`
+
+var testAccAwsOpsworksInstanceConfigCreate = testAccAwsOpsworksStackConfigNoVpcCreate + testAccAwsOpsworksInstanceLayers + `
+resource "aws_opsworks_instance" "tf-acc" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  layer_ids = ["${aws_opsworks_custom_layer.tf-acc.id}"]
+  instance_type = "t2.micro"
+  hostname = "tf-acc1"
+  state = "stopped"
+}
+`
+
+var testAccAwsOpsworksInstanceConfigUpdate = testAccAwsOpsworksStackConfigNoVpcCreate + testAccAwsOpsworksInstanceLayers + `
+resource "aws_opsworks_instance" "tf-acc" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  layer_ids = [
+    "${aws_opsworks_custom_layer.tf-acc.id}",
+    "${aws_opsworks_custom_layer.tf-acc-2.id}",
+  ]
+  instance_type = "t2.small"
+  hostname = "tf-acc1"
+  state = "stopped"
+}
+`
+
+var testAccAwsOpsworksInstanceConfigAutoScaling = testAccAwsOpsworksStackConfigNoVpcCreate + testAccAwsOpsworksInstanceLayers + `
+resource "aws_opsworks_instance" "tf-acc" {
+  stack_id = "${aws_opsworks_stack.tf-acc.id}"
+  layer_ids = ["${aws_opsworks_custom_layer.tf-acc.id}"]
+  instance_type = "t2.micro"
+  auto_scaling_type = "timer"
+}
+`
@@ -66,6 +66,11 @@ func resourceAwsRDSCluster() *schema.Resource {
 				Computed: true,
 			},
 
+			"reader_endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"engine": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -90,6 +95,12 @@ func resourceAwsRDSCluster() *schema.Resource {
 				},
 			},
 
+			"skip_final_snapshot": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"master_username": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -97,8 +108,9 @@ func resourceAwsRDSCluster() *schema.Resource {
 			},
 
 			"master_password": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 
 			"port": &schema.Schema{
@@ -201,7 +213,7 @@ func resourceAwsRDSClusterCreate(d *schema.ResourceData, meta interface{}) error
 		createOpts.PreferredMaintenanceWindow = aws.String(v.(string))
 	}
 
-	log.Printf("[DEBUG] RDS Cluster create options: %s", createOpts)
+	log.Printf("[DEBUG] Creating RDS Cluster: %s", *createOpts.DBClusterIdentifier)
 	resp, err := conn.CreateDBCluster(createOpts)
 	if err != nil {
 		log.Printf("[ERROR] Error creating RDS Cluster: %s", err)
@@ -214,7 +226,7 @@ func resourceAwsRDSClusterCreate(d *schema.ResourceData, meta interface{}) error
 		Pending:    []string{"creating", "backing-up", "modifying"},
 		Target:     "available",
 		Refresh:    resourceAwsRDSClusterStateRefreshFunc(d, meta),
-		Timeout:    5 * time.Minute,
+		Timeout:    15 * time.Minute,
 		MinTimeout: 3 * time.Second,
 	}
 
@@ -265,6 +277,7 @@ func resourceAwsRDSClusterRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("database_name", dbc.DatabaseName)
 	d.Set("db_subnet_group_name", dbc.DBSubnetGroup)
 	d.Set("endpoint", dbc.Endpoint)
+	d.Set("reader_endpoint", dbc.ReaderEndpoint)
 	d.Set("engine", dbc.Engine)
 	d.Set("master_username", dbc.MasterUsername)
 	d.Set("port", dbc.Port)
@@ -328,6 +341,21 @@ func resourceAwsRDSClusterUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("[WARN] Error modifying RDS Cluster (%s): %s", d.Id(), err)
 	}
 
+	if d.Get("apply_immediately").(bool) {
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"backing-up", "modifying", "resetting-master-credentials"},
+			Target:     "available",
+			Refresh:    resourceAwsRDSClusterStateRefreshFunc(d, meta),
+			Timeout:    15 * time.Minute,
+			MinTimeout: 3 * time.Second,
+			Delay:      10 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("[WARN] Error waiting for RDS Cluster (%s) to be modified: %s", d.Id(), err)
+		}
+	}
+
 	return resourceAwsRDSClusterRead(d, meta)
 }
 
@@ -339,8 +367,9 @@ func resourceAwsRDSClusterDelete(d *schema.ResourceData, meta interface{}) error
 		DBClusterIdentifier: aws.String(d.Id()),
 	}
 
+	// A final snapshot is taken if it has a name, unless it's skipped
 	finalSnapshot := d.Get("final_snapshot_identifier").(string)
-	if finalSnapshot == "" {
+	if finalSnapshot == "" || d.Get("skip_final_snapshot").(bool) {
 		deleteOpts.SkipFinalSnapshot = aws.Bool(true)
 	} else {
 		deleteOpts.FinalDBSnapshotIdentifier = aws.String(finalSnapshot)
@@ -348,13 +377,33 @@ func resourceAwsRDSClusterDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[DEBUG] RDS Cluster delete options: %s", deleteOpts)
-	_, err := conn.DeleteDBCluster(&deleteOpts)
+
+	// The cluster can't be deleted while it has instances. The instances
+	// are destroyed first, but they may not be completely gone yet.
+	err := resource.Retry(5*time.Minute, func() error {
+		_, err := conn.DeleteDBCluster(&deleteOpts)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok {
+				switch awsErr.Code() {
+				case "DBClusterNotFoundFault":
+					return nil
+				case "InvalidDBClusterStateFault":
+					return err
+				}
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting RDS Cluster (%s): %s", d.Id(), err)
+	}
 
 	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"deleting", "backing-up", "modifying"},
+		Pending:    []string{"available", "deleting", "backing-up", "modifying"},
 		Target:     "destroyed",
 		Refresh:    resourceAwsRDSClusterStateRefreshFunc(d, meta),
-		Timeout:    5 * time.Minute,
+		Timeout:    15 * time.Minute,
 		MinTimeout: 3 * time.Second,
 	}
 
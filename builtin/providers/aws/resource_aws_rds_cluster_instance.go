@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
@@ -95,9 +96,23 @@ func resourceAwsRDSClusterInstanceCreate(d *schema.ResourceData, meta interface{
 	}
 
 	log.Printf("[DEBUG] Creating RDS DB Instance opts: %s", createOpts)
-	resp, err := conn.CreateDBInstance(createOpts)
+
+	// Instances can only be added to an available cluster, which may still
+	// be busy with e.g. its first backup right after it was created.
+	var resp *rds.CreateDBInstanceOutput
+	err := resource.Retry(10*time.Minute, func() error {
+		var err error
+		resp, err = conn.CreateDBInstance(createOpts)
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidDBClusterStateFault" {
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("Error creating RDS Cluster Instance: %s", err)
 	}
 
 	d.SetId(*resp.DBInstance.DBInstanceIdentifier)
@@ -128,12 +143,21 @@ func resourceAwsRDSClusterInstanceRead(d *schema.ResourceData, meta interface{})
 		d.SetId("")
 		return nil
 	}
+	if db == nil {
+		log.Printf("[WARN] RDS Cluster Instance (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	}
 
 	// Retreive DB Cluster information, to determine if this Instance is a writer
 	conn := meta.(*AWSClient).rdsconn
 	resp, err := conn.DescribeDBClusters(&rds.DescribeDBClustersInput{
 		DBClusterIdentifier: db.DBClusterIdentifier,
 	})
+	if err != nil {
+		return fmt.Errorf("Error reading RDS Cluster (%s) of Cluster Instance (%s): %s",
+			*db.DBClusterIdentifier, *db.DBInstanceIdentifier, err)
+	}
 
 	var dbc *rds.DBCluster
 	for _, c := range resp.DBClusters {
@@ -143,8 +167,8 @@ func resourceAwsRDSClusterInstanceRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if dbc == nil {
-		return fmt.Errorf("[WARN] Error finding RDS Cluster (%s) for Cluster Instance (%s): %s",
-			*db.DBClusterIdentifier, *db.DBInstanceIdentifier, err)
+		return fmt.Errorf("[WARN] Error finding RDS Cluster (%s) for Cluster Instance (%s)",
+			*db.DBClusterIdentifier, *db.DBInstanceIdentifier)
 	}
 
 	for _, m := range dbc.DBClusterMembers {
@@ -204,7 +228,7 @@ func resourceAwsRDSClusterInstanceDelete(d *schema.ResourceData, meta interface{
 	// re-uses db_instance refresh func
 	log.Println("[INFO] Waiting for RDS Cluster Instance to be destroyed")
 	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"modifying", "deleting"},
+		Pending:    []string{"available", "modifying", "deleting"},
 		Target:     "",
 		Refresh:    resourceAwsDbInstanceStateRefreshFunc(d, meta),
 		Timeout:    40 * time.Minute,
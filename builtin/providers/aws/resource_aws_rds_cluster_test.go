@@ -29,6 +29,7 @@ func TestAccAWSRDSCluster_basic(t *testing.T) {
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSClusterExists("aws_rds_cluster.default", &v),
+					testAccCheckAWSClusterReaderEndpoint("aws_rds_cluster.default", &v),
 				),
 			},
 		},
@@ -110,6 +111,25 @@ func testAccCheckAWSClusterDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testAccCheckAWSClusterReaderEndpoint(n string, v *rds.DBCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if v.ReaderEndpoint == nil || *v.ReaderEndpoint == "" {
+			return fmt.Errorf("DB Cluster %s has no reader endpoint", rs.Primary.ID)
+		}
+
+		if rs.Primary.Attributes["reader_endpoint"] != *v.ReaderEndpoint {
+			return fmt.Errorf("Bad reader_endpoint: %s", rs.Primary.Attributes["reader_endpoint"])
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSClusterExists(n string, v *rds.DBCluster) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
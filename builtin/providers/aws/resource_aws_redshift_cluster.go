@@ -52,8 +52,9 @@ func resourceAwsRedshiftCluster() *schema.Resource {
 			},
 
 			"master_password": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 
 			"cluster_security_groups": &schema.Schema{
@@ -249,7 +250,7 @@ func resourceAwsRedshiftClusterCreate(d *schema.ResourceData, meta interface{})
 		createOpts.ElasticIp = aws.String(v.(string))
 	}
 
-	log.Printf("[DEBUG] Redshift Cluster create options: %s", createOpts)
+	log.Printf("[DEBUG] Creating Redshift Cluster: %s", *createOpts.ClusterIdentifier)
 	resp, err := conn.CreateCluster(createOpts)
 	if err != nil {
 		log.Printf("[ERROR] Error creating Redshift Cluster: %s", err)
@@ -394,7 +395,6 @@ func resourceAwsRedshiftClusterUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	log.Printf("[INFO] Modifying Redshift Cluster: %s", d.Id())
-	log.Printf("[DEBUG] Redshift Cluster Modify options: %s", req)
 	_, err := conn.ModifyCluster(req)
 	if err != nil {
 		return fmt.Errorf("[WARN] Error modifying Redshift Cluster (%s): %s", d.Id(), err)
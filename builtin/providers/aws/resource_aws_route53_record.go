@@ -10,6 +10,7 @@ import (
 	"github.com/xanzy/terraform-api/helper/hashcode"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -68,9 +69,11 @@ func resourceAwsRoute53Record() *schema.Resource {
 			// Because 0 is a valid value for Weight, we default to -1 so that any
 			// inclusion of a weight (zero or not) will be a usable value
 			"weight": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  -1,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Default:       -1,
+				ConflictsWith: []string{"weighted_routing_policy"},
+				Deprecated:    "Use weighted_routing_policy instead",
 			},
 
 			"set_identifier": &schema.Schema{
@@ -105,8 +108,100 @@ func resourceAwsRoute53Record() *schema.Resource {
 			},
 
 			"failover": &schema.Schema{ // PRIMARY | SECONDARY
-				Type:     schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"failover_routing_policy"},
+				Deprecated:    "Use failover_routing_policy instead",
+			},
+
+			"failover_routing_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				ConflictsWith: []string{
+					"failover",
+					"geolocation_routing_policy",
+					"latency_routing_policy",
+					"weighted_routing_policy",
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"PRIMARY", "SECONDARY"}, false),
+						},
+					},
+				},
+			},
+
+			"geolocation_routing_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				ConflictsWith: []string{
+					"failover_routing_policy",
+					"latency_routing_policy",
+					"weighted_routing_policy",
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"continent": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"country": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"subdivision": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"latency_routing_policy": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				ConflictsWith: []string{
+					"failover_routing_policy",
+					"geolocation_routing_policy",
+					"weighted_routing_policy",
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"weighted_routing_policy": &schema.Schema{
+				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
+				ConflictsWith: []string{
+					"failover_routing_policy",
+					"geolocation_routing_policy",
+					"latency_routing_policy",
+					"weight",
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"weight": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 255),
+						},
+					},
+				},
 			},
 
 			"health_check_id": &schema.Schema{ // ID of health check
@@ -296,16 +391,37 @@ func resourceAwsRoute53RecordRead(d *schema.ResourceData, meta interface{}) erro
 		}
 
 		d.Set("ttl", record.TTL)
-		// Only set the weight if it's non-nil, otherwise we end up with a 0 weight
-		// which has actual contextual meaning with Route 53 records
-		//   See http://docs.aws.amazon.com/fr_fr/Route53/latest/APIReference/API_ChangeResourceRecordSets_Examples.html
-		if record.Weight != nil {
-			d.Set("weight", record.Weight)
-		}
 		d.Set("set_identifier", record.SetIdentifier)
-		d.Set("failover", record.Failover)
 		d.Set("health_check_id", record.HealthCheckId)
 
+		// The failover and weighted routing policies can be configured both
+		// with the deprecated top-level attributes and with a routing policy
+		// block, so they are read back into whichever one is in use.
+		if _, ok := d.GetOk("failover"); ok {
+			d.Set("failover", record.Failover)
+		} else if err := d.Set("failover_routing_policy", flattenRoute53FailoverRoutingPolicy(record.Failover)); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting failover routing policy for: %s, error: %#v", en, err)
+		}
+
+		if _, ok := d.GetOk("weighted_routing_policy"); ok {
+			if err := d.Set("weighted_routing_policy", flattenRoute53WeightedRoutingPolicy(record.Weight)); err != nil {
+				return fmt.Errorf("[DEBUG] Error setting weighted routing policy for: %s, error: %#v", en, err)
+			}
+		} else if record.Weight != nil {
+			// Only set the weight if it's non-nil, otherwise we end up with a 0 weight
+			// which has actual contextual meaning with Route 53 records
+			//   See http://docs.aws.amazon.com/fr_fr/Route53/latest/APIReference/API_ChangeResourceRecordSets_Examples.html
+			d.Set("weight", record.Weight)
+		}
+
+		if err := d.Set("geolocation_routing_policy", flattenRoute53GeoLocation(record.GeoLocation)); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting geolocation routing policy for: %s, error: %#v", en, err)
+		}
+
+		if err := d.Set("latency_routing_policy", flattenRoute53LatencyRoutingPolicy(record.Region)); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting latency routing policy for: %s, error: %#v", en, err)
+		}
+
 		break
 	}
 
@@ -440,6 +556,20 @@ func resourceAwsRoute53RecordBuildSet(d *schema.ResourceData, zoneName string) (
 		rec.Failover = aws.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("failover_routing_policy"); ok {
+		policy := v.([]interface{})[0].(map[string]interface{})
+		rec.Failover = aws.String(policy["type"].(string))
+	}
+
+	if v, ok := d.GetOk("geolocation_routing_policy"); ok {
+		rec.GeoLocation = expandRoute53GeoLocation(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("latency_routing_policy"); ok {
+		policy := v.([]interface{})[0].(map[string]interface{})
+		rec.Region = aws.String(policy["region"].(string))
+	}
+
 	if v, ok := d.GetOk("health_check_id"); ok {
 		rec.HealthCheckId = aws.String(v.(string))
 	}
@@ -453,9 +583,60 @@ func resourceAwsRoute53RecordBuildSet(d *schema.ResourceData, zoneName string) (
 		rec.Weight = aws.Int64(int64(w))
 	}
 
+	if v, ok := d.GetOk("weighted_routing_policy"); ok {
+		policy := v.([]interface{})[0].(map[string]interface{})
+		rec.Weight = aws.Int64(int64(policy["weight"].(int)))
+	}
+
 	return rec, nil
 }
 
+func expandRoute53GeoLocation(m map[string]interface{}) *route53.GeoLocation {
+	geo := &route53.GeoLocation{}
+	if v, ok := m["continent"].(string); ok && v != "" {
+		geo.ContinentCode = aws.String(v)
+	}
+	if v, ok := m["country"].(string); ok && v != "" {
+		geo.CountryCode = aws.String(v)
+	}
+	if v, ok := m["subdivision"].(string); ok && v != "" {
+		geo.SubdivisionCode = aws.String(v)
+	}
+	return geo
+}
+
+func flattenRoute53GeoLocation(geo *route53.GeoLocation) []map[string]interface{} {
+	if geo == nil {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"continent":   aws.StringValue(geo.ContinentCode),
+		"country":     aws.StringValue(geo.CountryCode),
+		"subdivision": aws.StringValue(geo.SubdivisionCode),
+	}}
+}
+
+func flattenRoute53FailoverRoutingPolicy(failover *string) []map[string]interface{} {
+	if failover == nil {
+		return nil
+	}
+	return []map[string]interface{}{{"type": *failover}}
+}
+
+func flattenRoute53LatencyRoutingPolicy(region *string) []map[string]interface{} {
+	if region == nil {
+		return nil
+	}
+	return []map[string]interface{}{{"region": *region}}
+}
+
+func flattenRoute53WeightedRoutingPolicy(weight *int64) []map[string]interface{} {
+	if weight == nil {
+		return nil
+	}
+	return []map[string]interface{}{{"weight": *weight}}
+}
+
 func FQDN(name string) string {
 	n := len(name)
 	if n == 0 || name[n-1] == '.' {
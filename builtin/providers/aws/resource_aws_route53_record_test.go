@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -51,6 +52,42 @@ func TestExpandRecordName(t *testing.T) {
 	}
 }
 
+func TestExpandRoute53GeoLocation(t *testing.T) {
+	geo := expandRoute53GeoLocation(map[string]interface{}{
+		"continent":   "",
+		"country":     "US",
+		"subdivision": "CA",
+	})
+
+	if geo.ContinentCode != nil {
+		t.Fatalf("expected no continent, got: %s", *geo.ContinentCode)
+	}
+	if aws.StringValue(geo.CountryCode) != "US" {
+		t.Fatalf("bad country: %#v", geo.CountryCode)
+	}
+	if aws.StringValue(geo.SubdivisionCode) != "CA" {
+		t.Fatalf("bad subdivision: %#v", geo.SubdivisionCode)
+	}
+}
+
+func TestFlattenRoute53GeoLocation(t *testing.T) {
+	if actual := flattenRoute53GeoLocation(nil); actual != nil {
+		t.Fatalf("expected nil, got: %#v", actual)
+	}
+
+	actual := flattenRoute53GeoLocation(&route53.GeoLocation{
+		ContinentCode: aws.String("EU"),
+	})
+	expected := []map[string]interface{}{{
+		"continent":   "EU",
+		"country":     "",
+		"subdivision": "",
+	}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v\nexpected: %#v", actual, expected)
+	}
+}
+
 func TestAccAWSRoute53Record_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -157,6 +194,91 @@ func TestAccAWSRoute53Record_failover(t *testing.T) {
 	})
 }
 
+func TestAccAWSRoute53Record_failover_routing_policy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53FailoverRoutingPolicyRecord,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53RecordExists("aws_route53_record.www-primary"),
+					testAccCheckRoute53RecordExists("aws_route53_record.www-secondary"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-primary", "failover_routing_policy.0.type", "PRIMARY"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-secondary", "failover_routing_policy.0.type", "SECONDARY"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53Record_weighted_routing_policy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53WeightedRoutingPolicyRecord,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53RecordExists("aws_route53_record.www-live"),
+					testAccCheckRoute53RecordExists("aws_route53_record.www-off"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-live", "weighted_routing_policy.0.weight", "90"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.www-off", "weighted_routing_policy.0.weight", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53Record_latency_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53LatencyCNAMERecord,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53RecordExists("aws_route53_record.us-east-1"),
+					testAccCheckRoute53RecordExists("aws_route53_record.eu-west-1"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.eu-west-1", "latency_routing_policy.0.region", "eu-west-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRoute53Record_geolocation_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRoute53RecordDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRoute53GeolocationCNAMERecord,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoute53RecordExists("aws_route53_record.default"),
+					testAccCheckRoute53RecordExists("aws_route53_record.california"),
+					testAccCheckRoute53RecordExists("aws_route53_record.europe"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.default", "geolocation_routing_policy.0.country", "*"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.california", "geolocation_routing_policy.0.subdivision", "CA"),
+					resource.TestCheckResourceAttr(
+						"aws_route53_record.europe", "geolocation_routing_policy.0.continent", "EU"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSRoute53Record_weighted_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -478,6 +600,149 @@ resource "aws_route53_record" "www-secondary" {
 }
 `
 
+const testAccRoute53FailoverRoutingPolicyRecord = `
+resource "aws_route53_zone" "main" {
+  name = "notexample.com"
+}
+
+resource "aws_route53_health_check" "foo" {
+  fqdn = "dev.notexample.com"
+  port = 80
+  type = "HTTP"
+  resource_path = "/"
+  failure_threshold = "2"
+  request_interval = "30"
+}
+
+resource "aws_route53_record" "www-primary" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  failover_routing_policy {
+    type = "PRIMARY"
+  }
+  health_check_id = "${aws_route53_health_check.foo.id}"
+  set_identifier = "www-primary"
+  records = ["primary.notexample.com"]
+}
+
+resource "aws_route53_record" "www-secondary" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  failover_routing_policy {
+    type = "SECONDARY"
+  }
+  set_identifier = "www-secondary"
+  records = ["secondary.notexample.com"]
+}
+`
+
+const testAccRoute53WeightedRoutingPolicyRecord = `
+resource "aws_route53_zone" "main" {
+  name = "notexample.com"
+}
+
+resource "aws_route53_record" "www-live" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  weighted_routing_policy {
+    weight = 90
+  }
+  set_identifier = "live"
+  records = ["live.notexample.com"]
+}
+
+resource "aws_route53_record" "www-off" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  weighted_routing_policy {
+    weight = 0
+  }
+  set_identifier = "off"
+  records = ["off.notexample.com"]
+}
+`
+
+const testAccRoute53LatencyCNAMERecord = `
+resource "aws_route53_zone" "main" {
+  name = "notexample.com"
+}
+
+resource "aws_route53_record" "us-east-1" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  latency_routing_policy {
+    region = "us-east-1"
+  }
+  set_identifier = "us-east-1"
+  records = ["us-east-1.notexample.com"]
+}
+
+resource "aws_route53_record" "eu-west-1" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  latency_routing_policy {
+    region = "eu-west-1"
+  }
+  set_identifier = "eu-west-1"
+  records = ["eu-west-1.notexample.com"]
+}
+`
+
+const testAccRoute53GeolocationCNAMERecord = `
+resource "aws_route53_zone" "main" {
+  name = "notexample.com"
+}
+
+resource "aws_route53_record" "default" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  geolocation_routing_policy {
+    country = "*"
+  }
+  set_identifier = "Default"
+  records = ["dev.notexample.com"]
+}
+
+resource "aws_route53_record" "california" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  geolocation_routing_policy {
+    country = "US"
+    subdivision = "CA"
+  }
+  set_identifier = "California"
+  records = ["dev.notexample.com"]
+}
+
+resource "aws_route53_record" "europe" {
+  zone_id = "${aws_route53_zone.main.zone_id}"
+  name = "www"
+  type = "CNAME"
+  ttl = "5"
+  geolocation_routing_policy {
+    continent = "EU"
+  }
+  set_identifier = "Europe"
+  records = ["dev.notexample.com"]
+}
+`
+
 const testAccRoute53WeightedCNAMERecord = `
 resource "aws_route53_zone" "main" {
 	name = "notexample.com"
@@ -13,6 +13,7 @@ import (
 	"github.com/xanzy/terraform-api/helper/hashcode"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 )
 
 func resourceAwsS3Bucket() *schema.Resource {
@@ -174,6 +175,177 @@ func resourceAwsS3Bucket() *schema.Resource {
 				},
 			},
 
+			"lifecycle_rule": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringLenBetween(0, 255),
+						},
+						"prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"abort_incomplete_multipart_upload_days": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"expiration": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							MaxItems: 1,
+							Set:      expirationHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"date": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateS3BucketLifecycleTimestamp,
+									},
+									"days": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"expired_object_delete_marker": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"noncurrent_version_expiration": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							MaxItems: 1,
+							Set:      expirationHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"transition": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Set:      transitionHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"date": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validateS3BucketLifecycleTimestamp,
+									},
+									"days": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"storage_class": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketLifecycleStorageClass,
+									},
+								},
+							},
+						},
+						"noncurrent_version_transition": &schema.Schema{
+							Type:     schema.TypeSet,
+							Optional: true,
+							Set:      transitionHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"storage_class": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateS3BucketLifecycleStorageClass,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"replication_configuration": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.ARN,
+						},
+						"rules": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							Set:      rulesHash,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringLenBetween(0, 255),
+									},
+									"prefix": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringLenBetween(0, 1024),
+									},
+									"status": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											s3.ReplicationRuleStatusEnabled,
+											s3.ReplicationRuleStatusDisabled,
+										}, false),
+									},
+									"destination": &schema.Schema{
+										Type:     schema.TypeSet,
+										Required: true,
+										MaxItems: 1,
+										Set:      destinationHash,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"bucket": &schema.Schema{
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.ARN,
+												},
+												"storage_class": &schema.Schema{
+													Type:     schema.TypeString,
+													Optional: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														s3.StorageClassStandard,
+														s3.StorageClassReducedRedundancy,
+														s3.StorageClassStandardIa,
+													}, false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"tags": tagsSchema(),
 
 			"force_destroy": &schema.Schema{
@@ -248,6 +420,19 @@ func resourceAwsS3BucketUpdate(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 	}
+
+	if d.HasChange("lifecycle_rule") {
+		if err := resourceAwsS3BucketLifecycleUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
+
+	// Replication needs versioning, so it's updated after it
+	if d.HasChange("replication_configuration") {
+		if err := resourceAwsS3BucketReplicationConfigurationUpdate(s3conn, d); err != nil {
+			return err
+		}
+	}
 	if d.HasChange("acl") {
 		if err := resourceAwsS3BucketAclUpdate(s3conn, d); err != nil {
 			return err
@@ -307,19 +492,25 @@ func resourceAwsS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 	})
 	log.Printf("[DEBUG] S3 bucket: %s, read CORS: %v", d.Id(), cors)
 	if err != nil {
-		rules := make([]map[string]interface{}, 0, len(cors.CORSRules))
-		for _, ruleObject := range cors.CORSRules {
-			rule := make(map[string]interface{})
-			rule["allowed_headers"] = ruleObject.AllowedHeaders
-			rule["allowed_methods"] = ruleObject.AllowedMethods
-			rule["allowed_origins"] = ruleObject.AllowedOrigins
-			rule["expose_headers"] = ruleObject.ExposeHeaders
-			rule["max_age_seconds"] = ruleObject.MaxAgeSeconds
-			rules = append(rules, rule)
-		}
-		if err := d.Set("cors_rule", rules); err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "NoSuchCORSConfiguration" {
 			return fmt.Errorf("error reading S3 bucket \"%s\" CORS rules: %s", d.Id(), err)
 		}
+		cors = &s3.GetBucketCorsOutput{}
+	}
+	rules := make([]map[string]interface{}, 0, len(cors.CORSRules))
+	for _, ruleObject := range cors.CORSRules {
+		rule := make(map[string]interface{})
+		rule["allowed_headers"] = aws.StringValueSlice(ruleObject.AllowedHeaders)
+		rule["allowed_methods"] = aws.StringValueSlice(ruleObject.AllowedMethods)
+		rule["allowed_origins"] = aws.StringValueSlice(ruleObject.AllowedOrigins)
+		rule["expose_headers"] = aws.StringValueSlice(ruleObject.ExposeHeaders)
+		if ruleObject.MaxAgeSeconds != nil {
+			rule["max_age_seconds"] = int(*ruleObject.MaxAgeSeconds)
+		}
+		rules = append(rules, rule)
+	}
+	if err := d.Set("cors_rule", rules); err != nil {
+		return fmt.Errorf("error reading S3 bucket \"%s\" CORS rules: %s", d.Id(), err)
 	}
 
 	// Read the website configuration
@@ -393,6 +584,36 @@ func resourceAwsS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// Read the lifecycle configuration
+	lifecycle, err := s3conn.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "NoSuchLifecycleConfiguration" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" lifecycle rules: %s", d.Id(), err)
+		}
+		lifecycle = &s3.GetBucketLifecycleConfigurationOutput{}
+	}
+	log.Printf("[DEBUG] S3 Bucket: %s, lifecycle: %v", d.Id(), lifecycle)
+	if err := d.Set("lifecycle_rule", flattenS3LifecycleRules(lifecycle.Rules)); err != nil {
+		return fmt.Errorf("error reading S3 bucket \"%s\" lifecycle rules: %s", d.Id(), err)
+	}
+
+	// Read the replication configuration
+	replication, err := s3conn.GetBucketReplication(&s3.GetBucketReplicationInput{
+		Bucket: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "ReplicationConfigurationNotFoundError" {
+			return fmt.Errorf("error reading S3 bucket \"%s\" replication configuration: %s", d.Id(), err)
+		}
+		replication = &s3.GetBucketReplicationOutput{}
+	}
+	log.Printf("[DEBUG] S3 Bucket: %s, replication: %v", d.Id(), replication)
+	if err := d.Set("replication_configuration", flattenS3ReplicationConfiguration(replication.ReplicationConfiguration)); err != nil {
+		return fmt.Errorf("error reading S3 bucket \"%s\" replication configuration: %s", d.Id(), err)
+	}
+
 	// Add the region as an attribute
 	location, err := s3conn.GetBucketLocation(
 		&s3.GetBucketLocationInput{
@@ -811,6 +1032,360 @@ func resourceAwsS3BucketLoggingUpdate(s3conn *s3.S3, d *schema.ResourceData) err
 	return nil
 }
 
+func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	lifecycleRules := d.Get("lifecycle_rule").([]interface{})
+
+	if len(lifecycleRules) == 0 {
+		log.Printf("[DEBUG] S3 bucket: %s, delete lifecycle", bucket)
+		_, err := s3conn.DeleteBucketLifecycle(&s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 lifecycle: %s", err)
+		}
+		return nil
+	}
+
+	rules := make([]*s3.LifecycleRule, 0, len(lifecycleRules))
+	for i, lifecycleRule := range lifecycleRules {
+		r := lifecycleRule.(map[string]interface{})
+
+		rule := &s3.LifecycleRule{
+			Prefix: aws.String(r["prefix"].(string)),
+		}
+
+		// Rule ID
+		if v := r["id"].(string); v != "" {
+			rule.ID = aws.String(v)
+		} else {
+			rule.ID = aws.String(resource.PrefixedUniqueId("tf-s3-lifecycle-"))
+		}
+
+		// Enabled
+		if r["enabled"].(bool) {
+			rule.Status = aws.String(s3.ExpirationStatusEnabled)
+		} else {
+			rule.Status = aws.String(s3.ExpirationStatusDisabled)
+		}
+
+		// AbortIncompleteMultipartUpload
+		if v := r["abort_incomplete_multipart_upload_days"].(int); v > 0 {
+			rule.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int64(int64(v)),
+			}
+		}
+
+		// Expiration
+		if l := r["expiration"].(*schema.Set).List(); len(l) > 0 {
+			e := l[0].(map[string]interface{})
+			expiration := &s3.LifecycleExpiration{}
+
+			if v := e["date"].(string); v != "" {
+				t, err := time.Parse(time.RFC3339, fmt.Sprintf("%sT00:00:00Z", v))
+				if err != nil {
+					return fmt.Errorf("Error parsing expiration date of S3 lifecycle rule %d: %s", i, err)
+				}
+				expiration.Date = aws.Time(t)
+			} else if v := e["days"].(int); v > 0 {
+				expiration.Days = aws.Int64(int64(v))
+			} else {
+				expiration.ExpiredObjectDeleteMarker = aws.Bool(e["expired_object_delete_marker"].(bool))
+			}
+			rule.Expiration = expiration
+		}
+
+		// NoncurrentVersionExpiration
+		if l := r["noncurrent_version_expiration"].(*schema.Set).List(); len(l) > 0 {
+			e := l[0].(map[string]interface{})
+			if v := e["days"].(int); v > 0 {
+				rule.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{
+					NoncurrentDays: aws.Int64(int64(v)),
+				}
+			}
+		}
+
+		// Transitions
+		for _, raw := range r["transition"].(*schema.Set).List() {
+			t := raw.(map[string]interface{})
+			transition := &s3.Transition{
+				StorageClass: aws.String(t["storage_class"].(string)),
+			}
+
+			if v := t["date"].(string); v != "" {
+				date, err := time.Parse(time.RFC3339, fmt.Sprintf("%sT00:00:00Z", v))
+				if err != nil {
+					return fmt.Errorf("Error parsing transition date of S3 lifecycle rule %d: %s", i, err)
+				}
+				transition.Date = aws.Time(date)
+			} else {
+				transition.Days = aws.Int64(int64(t["days"].(int)))
+			}
+
+			rule.Transitions = append(rule.Transitions, transition)
+		}
+
+		// NoncurrentVersionTransitions
+		for _, raw := range r["noncurrent_version_transition"].(*schema.Set).List() {
+			t := raw.(map[string]interface{})
+			rule.NoncurrentVersionTransitions = append(rule.NoncurrentVersionTransitions,
+				&s3.NoncurrentVersionTransition{
+					NoncurrentDays: aws.Int64(int64(t["days"].(int))),
+					StorageClass:   aws.String(t["storage_class"].(string)),
+				})
+		}
+
+		rules = append(rules, rule)
+	}
+
+	i := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: rules,
+		},
+	}
+	log.Printf("[DEBUG] S3 put bucket lifecycle: %#v", i)
+
+	_, err := s3conn.PutBucketLifecycleConfiguration(i)
+	if err != nil {
+		return fmt.Errorf("Error putting S3 lifecycle: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsS3BucketReplicationConfigurationUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	replicationConfiguration := d.Get("replication_configuration").([]interface{})
+
+	if len(replicationConfiguration) == 0 {
+		log.Printf("[DEBUG] S3 bucket: %s, delete replication configuration", bucket)
+		_, err := s3conn.DeleteBucketReplication(&s3.DeleteBucketReplicationInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 replication configuration: %s", err)
+		}
+		return nil
+	}
+
+	c := replicationConfiguration[0].(map[string]interface{})
+	rc := &s3.ReplicationConfiguration{
+		Role: aws.String(c["role"].(string)),
+	}
+
+	for _, raw := range c["rules"].(*schema.Set).List() {
+		rr := raw.(map[string]interface{})
+		rule := &s3.ReplicationRule{
+			Prefix: aws.String(rr["prefix"].(string)),
+			Status: aws.String(rr["status"].(string)),
+		}
+
+		if v := rr["id"].(string); v != "" {
+			rule.ID = aws.String(v)
+		}
+
+		for _, rawDest := range rr["destination"].(*schema.Set).List() {
+			dest := rawDest.(map[string]interface{})
+			rule.Destination = &s3.Destination{
+				Bucket: aws.String(dest["bucket"].(string)),
+			}
+			if v := dest["storage_class"].(string); v != "" {
+				rule.Destination.StorageClass = aws.String(v)
+			}
+		}
+
+		rc.Rules = append(rc.Rules, rule)
+	}
+
+	i := &s3.PutBucketReplicationInput{
+		Bucket:                   aws.String(bucket),
+		ReplicationConfiguration: rc,
+	}
+	log.Printf("[DEBUG] S3 put bucket replication configuration: %#v", i)
+
+	// The IAM role of the replication may not have propagated yet
+	err := resource.Retry(1*time.Minute, func() error {
+		if _, err := s3conn.PutBucketReplication(i); err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidRequest" {
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 replication configuration: %s", err)
+	}
+
+	return nil
+}
+
+func flattenS3LifecycleRules(lifecycleRules []*s3.LifecycleRule) []map[string]interface{} {
+	rules := make([]map[string]interface{}, 0, len(lifecycleRules))
+	for _, lifecycleRule := range lifecycleRules {
+		rule := make(map[string]interface{})
+
+		rule["id"] = aws.StringValue(lifecycleRule.ID)
+		rule["prefix"] = aws.StringValue(lifecycleRule.Prefix)
+		rule["enabled"] = aws.StringValue(lifecycleRule.Status) == s3.ExpirationStatusEnabled
+
+		if v := lifecycleRule.AbortIncompleteMultipartUpload; v != nil && v.DaysAfterInitiation != nil {
+			rule["abort_incomplete_multipart_upload_days"] = int(*v.DaysAfterInitiation)
+		}
+
+		if v := lifecycleRule.Expiration; v != nil {
+			e := make(map[string]interface{})
+			if v.Date != nil {
+				e["date"] = v.Date.Format("2006-01-02")
+			}
+			if v.Days != nil {
+				e["days"] = int(*v.Days)
+			}
+			if v.ExpiredObjectDeleteMarker != nil {
+				e["expired_object_delete_marker"] = *v.ExpiredObjectDeleteMarker
+			}
+			rule["expiration"] = schema.NewSet(expirationHash, []interface{}{e})
+		}
+
+		if v := lifecycleRule.NoncurrentVersionExpiration; v != nil && v.NoncurrentDays != nil {
+			e := map[string]interface{}{
+				"days": int(*v.NoncurrentDays),
+			}
+			rule["noncurrent_version_expiration"] = schema.NewSet(expirationHash, []interface{}{e})
+		}
+
+		if len(lifecycleRule.Transitions) > 0 {
+			transitions := make([]interface{}, 0, len(lifecycleRule.Transitions))
+			for _, v := range lifecycleRule.Transitions {
+				t := map[string]interface{}{
+					"storage_class": aws.StringValue(v.StorageClass),
+				}
+				if v.Date != nil {
+					t["date"] = v.Date.Format("2006-01-02")
+				}
+				if v.Days != nil {
+					t["days"] = int(*v.Days)
+				}
+				transitions = append(transitions, t)
+			}
+			rule["transition"] = schema.NewSet(transitionHash, transitions)
+		}
+
+		if len(lifecycleRule.NoncurrentVersionTransitions) > 0 {
+			transitions := make([]interface{}, 0, len(lifecycleRule.NoncurrentVersionTransitions))
+			for _, v := range lifecycleRule.NoncurrentVersionTransitions {
+				t := map[string]interface{}{
+					"storage_class": aws.StringValue(v.StorageClass),
+				}
+				if v.NoncurrentDays != nil {
+					t["days"] = int(*v.NoncurrentDays)
+				}
+				transitions = append(transitions, t)
+			}
+			rule["noncurrent_version_transition"] = schema.NewSet(transitionHash, transitions)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func flattenS3ReplicationConfiguration(r *s3.ReplicationConfiguration) []map[string]interface{} {
+	if r == nil {
+		return nil
+	}
+
+	rules := make([]interface{}, 0, len(r.Rules))
+	for _, v := range r.Rules {
+		rule := map[string]interface{}{
+			"id":     aws.StringValue(v.ID),
+			"prefix": aws.StringValue(v.Prefix),
+			"status": aws.StringValue(v.Status),
+		}
+
+		if v.Destination != nil {
+			dest := map[string]interface{}{
+				"bucket":        aws.StringValue(v.Destination.Bucket),
+				"storage_class": aws.StringValue(v.Destination.StorageClass),
+			}
+			rule["destination"] = schema.NewSet(destinationHash, []interface{}{dest})
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return []map[string]interface{}{
+		map[string]interface{}{
+			"role":  aws.StringValue(r.Role),
+			"rules": schema.NewSet(rulesHash, rules),
+		},
+	}
+}
+
+func expirationHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["date"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["days"]; ok {
+		buf.WriteString(fmt.Sprintf("%d-", v.(int)))
+	}
+	if v, ok := m["expired_object_delete_marker"]; ok {
+		buf.WriteString(fmt.Sprintf("%t-", v.(bool)))
+	}
+	return hashcode.String(buf.String())
+}
+
+func transitionHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["date"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["days"]; ok {
+		buf.WriteString(fmt.Sprintf("%d-", v.(int)))
+	}
+	if v, ok := m["storage_class"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	return hashcode.String(buf.String())
+}
+
+func rulesHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["id"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["prefix"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["status"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["destination"].(*schema.Set); ok {
+		for _, dest := range v.List() {
+			buf.WriteString(fmt.Sprintf("%d-", destinationHash(dest)))
+		}
+	}
+	return hashcode.String(buf.String())
+}
+
+func destinationHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	if v, ok := m["bucket"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	if v, ok := m["storage_class"]; ok {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+	return hashcode.String(buf.String())
+}
+
 func normalizeJson(jsonString interface{}) string {
 	if jsonString == nil {
 		return ""
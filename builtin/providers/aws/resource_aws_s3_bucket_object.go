@@ -2,13 +2,18 @@ package aws
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -19,8 +24,11 @@ func resourceAwsS3BucketObject() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsS3BucketObjectPut,
 		Read:   resourceAwsS3BucketObjectRead,
+		Update: resourceAwsS3BucketObjectPut,
 		Delete: resourceAwsS3BucketObjectDelete,
 
+		CustomizeDiff: resourceAwsS3BucketObjectCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"bucket": &schema.Schema{
 				Type:     schema.TypeString,
@@ -31,31 +39,26 @@ func resourceAwsS3BucketObject() *schema.Resource {
 			"cache_control": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"content_disposition": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"content_encoding": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"content_language": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"content_type": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 
@@ -68,18 +71,52 @@ func resourceAwsS3BucketObject() *schema.Resource {
 			"source": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"content"},
 			},
 
 			"content": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"source"},
 			},
 
+			"storage_class": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					s3.StorageClassStandard,
+					s3.StorageClassReducedRedundancy,
+					s3.StorageClassStandardIa,
+				}, false),
+			},
+
+			"server_side_encryption": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					s3.ServerSideEncryptionAes256,
+					s3.ServerSideEncryptionAwsKms,
+				}, false),
+			},
+
+			"kms_key_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ARN,
+			},
+
+			// The etag is the MD5 hash of the object, unless it's encrypted
+			// with KMS. It's computed from the source or content during
+			// plan, so changes to the source file are detected too.
 			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"version_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
@@ -92,31 +129,25 @@ func resourceAwsS3BucketObjectPut(d *schema.ResourceData, meta interface{}) erro
 
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
-	var body io.ReadSeeker
-
-	if v, ok := d.GetOk("source"); ok {
-		source := v.(string)
-		path, err := homedir.Expand(source)
-		if err != nil {
-			return fmt.Errorf("Error expanding homedir in source (%s): %s", source, err)
-		}
-		file, err := os.Open(path)
-		if err != nil {
-			return fmt.Errorf("Error opening S3 bucket object source (%s): %s", source, err)
-		}
 
-		body = file
-	} else if v, ok := d.GetOk("content"); ok {
-		content := v.(string)
-		body = bytes.NewReader([]byte(content))
-	} else {
+	body, err := s3BucketObjectBody(d)
+	if err != nil {
+		return err
+	}
+	if file, ok := body.(*os.File); ok {
+		defer file.Close()
+	}
 
-		return fmt.Errorf("Must specify \"source\" or \"content\" field")
+	sum, err := s3BucketObjectMD5(body)
+	if err != nil {
+		return fmt.Errorf("Error reading S3 bucket object body: %s", err)
 	}
+
 	putInput := &s3.PutObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-		Body:   body,
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		Body:       body,
+		ContentMD5: aws.String(base64.StdEncoding.EncodeToString(sum)),
 	}
 
 	if v, ok := d.GetOk("cache_control"); ok {
@@ -139,14 +170,30 @@ func resourceAwsS3BucketObjectPut(d *schema.ResourceData, meta interface{}) erro
 		putInput.ContentDisposition = aws.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("storage_class"); ok {
+		putInput.StorageClass = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("server_side_encryption"); ok {
+		putInput.ServerSideEncryption = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		putInput.SSEKMSKeyId = aws.String(v.(string))
+		putInput.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+	}
+
 	resp, err := s3conn.PutObject(putInput)
 	if err != nil {
 		return fmt.Errorf("Error putting object in S3 bucket (%s): %s", bucket, err)
 	}
 
-	d.Set("etag", resp.ETag)
+	// S3 returns the etag in quotes
+	d.Set("etag", strings.Trim(aws.StringValue(resp.ETag), `"`))
+	d.Set("version_id", resp.VersionId)
 	d.SetId(key)
-	return nil
+
+	return resourceAwsS3BucketObjectRead(d, meta)
 }
 
 func resourceAwsS3BucketObjectRead(d *schema.ResourceData, meta interface{}) error {
@@ -154,13 +201,11 @@ func resourceAwsS3BucketObjectRead(d *schema.ResourceData, meta interface{}) err
 
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
-	etag := d.Get("etag").(string)
 
 	resp, err := s3conn.HeadObject(
 		&s3.HeadObjectInput{
-			Bucket:  aws.String(bucket),
-			Key:     aws.String(key),
-			IfMatch: aws.String(etag),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
 		})
 
 	if err != nil {
@@ -178,6 +223,25 @@ func resourceAwsS3BucketObjectRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("content_encoding", resp.ContentEncoding)
 	d.Set("content_language", resp.ContentLanguage)
 	d.Set("content_type", resp.ContentType)
+	d.Set("version_id", resp.VersionId)
+	d.Set("server_side_encryption", resp.ServerSideEncryption)
+
+	// The etag is only the MD5 hash of the object if it isn't encrypted with
+	// KMS, otherwise the configured etag is kept.
+	if aws.StringValue(resp.ServerSideEncryption) != s3.ServerSideEncryptionAwsKms {
+		d.Set("etag", strings.Trim(aws.StringValue(resp.ETag), `"`))
+	}
+
+	if _, ok := d.GetOk("kms_key_id"); ok {
+		d.Set("kms_key_id", resp.SSEKMSKeyId)
+	}
+
+	// The storage class is left out for standard objects
+	if resp.StorageClass != nil {
+		d.Set("storage_class", resp.StorageClass)
+	} else {
+		d.Set("storage_class", s3.StorageClassStandard)
+	}
 
 	log.Printf("[DEBUG] Reading S3 Bucket Object meta: %s", resp)
 	return nil
@@ -199,3 +263,89 @@ func resourceAwsS3BucketObjectDelete(d *schema.ResourceData, meta interface{}) e
 	}
 	return nil
 }
+
+func resourceAwsS3BucketObjectCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// A configured etag is compared as is
+	if _, ok := d.GetConfig("etag"); ok {
+		return nil
+	}
+
+	// The etag of objects encrypted with KMS isn't their MD5 hash
+	if _, ok := d.GetOk("kms_key_id"); ok {
+		return nil
+	}
+	if v, ok := d.GetOk("server_side_encryption"); ok && v.(string) == s3.ServerSideEncryptionAwsKms {
+		return nil
+	}
+
+	if !d.NewValueKnown("source") || !d.NewValueKnown("content") {
+		return d.SetNewComputed("etag")
+	}
+
+	var body io.ReadSeeker
+	if v, ok := d.GetOk("source"); ok {
+		path, err := homedir.Expand(v.(string))
+		if err != nil {
+			return fmt.Errorf("Error expanding homedir in source (%s): %s", v, err)
+		}
+
+		// The source file may be created during apply, so it's fine if
+		// it doesn't exist yet.
+		file, err := os.Open(path)
+		if err != nil {
+			log.Printf("[DEBUG] Not computing etag of S3 bucket object source (%s): %s", v, err)
+			return d.SetNewComputed("etag")
+		}
+		defer file.Close()
+
+		body = file
+	} else {
+		body = bytes.NewReader([]byte(d.Get("content").(string)))
+	}
+
+	sum, err := s3BucketObjectMD5(body)
+	if err != nil {
+		return fmt.Errorf("Error reading S3 bucket object body: %s", err)
+	}
+
+	return d.SetNew("etag", hex.EncodeToString(sum))
+}
+
+// s3BucketObjectBody returns the body of the object, which is either the
+// source file or the content.
+func s3BucketObjectBody(d *schema.ResourceData) (io.ReadSeeker, error) {
+	if v, ok := d.GetOk("source"); ok {
+		source := v.(string)
+		path, err := homedir.Expand(source)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding homedir in source (%s): %s", source, err)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error opening S3 bucket object source (%s): %s", source, err)
+		}
+
+		return file, nil
+	}
+
+	if v, ok := d.GetOk("content"); ok {
+		return bytes.NewReader([]byte(v.(string))), nil
+	}
+
+	return nil, fmt.Errorf("Must specify \"source\" or \"content\" field")
+}
+
+// s3BucketObjectMD5 returns the MD5 hash of the given body, and seeks back
+// to its start so it can be uploaded afterwards.
+func s3BucketObjectMD5(body io.ReadSeeker) ([]byte, error) {
+	h := md5.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return nil, err
+	}
+
+	if _, err := body.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/xanzy/terraform-api/config"
+	"github.com/xanzy/terraform-api/helper/acctest"
 	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/terraform"
 
@@ -80,6 +82,154 @@ func TestAccAWSS3BucketObject_withContentCharacteristics(t *testing.T) {
 	})
 }
 
+func TestAccAWSS3BucketObject_updates(t *testing.T) {
+	file, err := ioutil.TempFile("", "tf-acc-s3-obj-updates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	rInt := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				PreConfig: func() {
+					ioutil.WriteFile(file.Name(), []byte("initial object state"), 0644)
+				},
+				Config: testAccAWSS3BucketObjectConfig_updates(rInt, file.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket_object.object", "etag", "647d1d58e1011c743ec67d5e8af87b53"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket_object.object", "storage_class", "STANDARD"),
+				),
+			},
+			resource.TestStep{
+				PreConfig: func() {
+					ioutil.WriteFile(file.Name(), []byte("modified object"), 0644)
+				},
+				Config: testAccAWSS3BucketObjectConfig_updates(rInt, file.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket_object.object", "etag", "1c7fd13df1515c2a13ad9eb068931f09"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSS3BucketObject_kms(t *testing.T) {
+	rInt := acctest.RandInt()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketObjectConfig_withKMS(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketObjectExists("aws_s3_bucket_object.object"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket_object.object", "server_side_encryption", "aws:kms"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAwsS3BucketObjectCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		Config   map[string]interface{}
+		State    map[string]string
+		Expected string
+	}{
+		// The etag is the MD5 hash of the content
+		{
+			Config: map[string]interface{}{
+				"content": "hello",
+			},
+			Expected: "5d41402abc4b2a76b9719d911017c592",
+		},
+
+		// No diff if the content didn't change
+		{
+			Config: map[string]interface{}{
+				"content": "hello",
+			},
+			State: map[string]string{
+				"content": "hello",
+				"etag":    "5d41402abc4b2a76b9719d911017c592",
+			},
+			Expected: "",
+		},
+
+		// The object was changed outside of Terraform
+		{
+			Config: map[string]interface{}{
+				"content": "hello",
+			},
+			State: map[string]string{
+				"content": "hello",
+				"etag":    "d41d8cd98f00b204e9800998ecf8427e",
+			},
+			Expected: "5d41402abc4b2a76b9719d911017c592",
+		},
+
+		// A configured etag is kept
+		{
+			Config: map[string]interface{}{
+				"content": "hello",
+				"etag":    "foo",
+			},
+			Expected: "foo",
+		},
+
+		// The etag isn't the MD5 hash of objects encrypted with KMS
+		{
+			Config: map[string]interface{}{
+				"content":                "hello",
+				"server_side_encryption": "aws:kms",
+			},
+			Expected: "<computed>",
+		},
+	}
+
+	for i, tc := range cases {
+		tc.Config["bucket"] = "foo"
+		tc.Config["key"] = "bar"
+		raw, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		var state *terraform.InstanceState
+		if tc.State != nil {
+			tc.State["bucket"] = "foo"
+			tc.State["key"] = "bar"
+			state = &terraform.InstanceState{ID: "bar", Attributes: tc.State}
+		}
+
+		diff, err := resourceAwsS3BucketObject().Diff(state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		var actual string
+		if diff != nil && diff.Attributes["etag"] != nil {
+			actual = diff.Attributes["etag"].New
+			if diff.Attributes["etag"].NewComputed {
+				actual = "<computed>"
+			}
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%d: expected etag %q, got %q", i, tc.Expected, actual)
+		}
+	}
+}
+
 func testAccCheckAWSS3BucketObjectDestroy(s *terraform.State) error {
 	s3conn := testAccProvider.Meta().(*AWSClient).s3conn
 
@@ -166,3 +316,32 @@ resource "aws_s3_bucket_object" "object" {
         content = "some_bucket_content"
 }
 `, randomBucket)
+
+func testAccAWSS3BucketObjectConfig_updates(randInt int, source string) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "object_bucket_3" {
+	bucket = "tf-object-test-bucket-%d"
+}
+
+resource "aws_s3_bucket_object" "object" {
+	bucket = "${aws_s3_bucket.object_bucket_3.bucket}"
+	key = "updateable-key"
+	source = "%s"
+}
+`, randInt, source)
+}
+
+func testAccAWSS3BucketObjectConfig_withKMS(randInt int) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "object_bucket_4" {
+	bucket = "tf-object-test-bucket-%d"
+}
+
+resource "aws_s3_bucket_object" "object" {
+	bucket = "${aws_s3_bucket.object_bucket_4.bucket}"
+	key = "test-key"
+	content = "stuff"
+	server_side_encryption = "aws:kms"
+}
+`, randInt)
+}
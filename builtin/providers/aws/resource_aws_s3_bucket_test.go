@@ -256,6 +256,95 @@ func TestAccAWSS3Bucket_Cors(t *testing.T) {
 	})
 }
 
+func TestAccAWSS3Bucket_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWithLifecycle,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketLifecycleRules("aws_s3_bucket.bucket", 2),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.id", "id1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.prefix", "path1/"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.abort_incomplete_multipart_upload_days", "7"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.transition.#", "2"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.expiration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.1.id", "id2"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.1.noncurrent_version_transition.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.1.noncurrent_version_expiration.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWithLifecycleUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketLifecycleRules("aws_s3_bucket.bucket", 1),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.id", "id1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.enabled", "false"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "lifecycle_rule.0.transition.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketLifecycleRules("aws_s3_bucket.bucket", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSS3Bucket_Replication(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSS3BucketDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWithReplication,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketReplicationRules("aws_s3_bucket.bucket", 1),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "replication_configuration.#", "1"),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "replication_configuration.0.rules.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSS3BucketConfigWithoutReplication,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSS3BucketExists("aws_s3_bucket.bucket"),
+					testAccCheckAWSS3BucketReplicationRules("aws_s3_bucket.bucket", 0),
+					resource.TestCheckResourceAttr(
+						"aws_s3_bucket.bucket", "replication_configuration.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSS3Bucket_Logging(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -480,6 +569,53 @@ func testAccCheckAWSS3BucketCors(n string, corsRules []*s3.CORSRule) resource.Te
 	}
 }
 
+func testAccCheckAWSS3BucketLifecycleRules(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, _ := s.RootModule().Resources[n]
+		conn := testAccProvider.Meta().(*AWSClient).s3conn
+
+		out, err := conn.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchLifecycleConfiguration" && count == 0 {
+				return nil
+			}
+			return fmt.Errorf("GetBucketLifecycleConfiguration error: %v", err)
+		}
+
+		if len(out.Rules) != count {
+			return fmt.Errorf("bad number of lifecycle rules, expected: %d, got %d", count, len(out.Rules))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSS3BucketReplicationRules(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, _ := s.RootModule().Resources[n]
+		conn := testAccProvider.Meta().(*AWSClient).s3conn
+
+		out, err := conn.GetBucketReplication(&s3.GetBucketReplicationInput{
+			Bucket: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ReplicationConfigurationNotFoundError" && count == 0 {
+				return nil
+			}
+			return fmt.Errorf("GetBucketReplication error: %v", err)
+		}
+
+		if len(out.ReplicationConfiguration.Rules) != count {
+			return fmt.Errorf("bad number of replication rules, expected: %d, got %d",
+				count, len(out.ReplicationConfiguration.Rules))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAWSS3BucketLogging(n, b, p string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, _ := s.RootModule().Resources[n]
@@ -643,3 +779,137 @@ resource "aws_s3_bucket" "bucket" {
 	}
 }
 `, randInt, randInt)
+
+var testAccAWSS3BucketConfigWithLifecycle = fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+		enabled = true
+	}
+	lifecycle_rule {
+		id = "id1"
+		prefix = "path1/"
+		enabled = true
+		abort_incomplete_multipart_upload_days = 7
+
+		expiration {
+			days = 365
+		}
+
+		transition {
+			days = 30
+			storage_class = "STANDARD_IA"
+		}
+		transition {
+			days = 60
+			storage_class = "GLACIER"
+		}
+	}
+	lifecycle_rule {
+		id = "id2"
+		prefix = "path2/"
+		enabled = true
+
+		noncurrent_version_expiration {
+			days = 90
+		}
+
+		noncurrent_version_transition {
+			days = 30
+			storage_class = "GLACIER"
+		}
+	}
+}
+`, randInt)
+
+var testAccAWSS3BucketConfigWithLifecycleUpdate = fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+		enabled = true
+	}
+	lifecycle_rule {
+		id = "id1"
+		prefix = "path1/"
+		enabled = false
+
+		expiration {
+			date = "2016-01-12"
+		}
+
+		transition {
+			date = "2016-01-12"
+			storage_class = "GLACIER"
+		}
+	}
+}
+`, randInt)
+
+var testAccAWSS3BucketConfigReplicationBasic = fmt.Sprintf(`
+provider "aws" {
+	alias = "euwest"
+	region = "eu-west-1"
+}
+
+resource "aws_iam_role" "role" {
+	name = "tf-iam-role-replication-%d"
+	assume_role_policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "s3.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+POLICY
+}
+
+resource "aws_s3_bucket" "destination" {
+	provider = "aws.euwest"
+	bucket = "tf-test-bucket-destination-%d"
+	versioning {
+		enabled = true
+	}
+}
+`, randInt, randInt)
+
+var testAccAWSS3BucketConfigWithReplication = testAccAWSS3BucketConfigReplicationBasic + fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+		enabled = true
+	}
+	replication_configuration {
+		role = "${aws_iam_role.role.arn}"
+		rules {
+			id = "foobar"
+			prefix = "foo"
+			status = "Enabled"
+
+			destination {
+				bucket = "${aws_s3_bucket.destination.arn}"
+				storage_class = "STANDARD"
+			}
+		}
+	}
+}
+`, randInt)
+
+var testAccAWSS3BucketConfigWithoutReplication = testAccAWSS3BucketConfigReplicationBasic + fmt.Sprintf(`
+resource "aws_s3_bucket" "bucket" {
+	bucket = "tf-test-bucket-%d"
+	acl = "private"
+	versioning {
+		enabled = true
+	}
+}
+`, randInt)
@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceAwsSsmAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSsmAssociationCreate,
+		Read:   resourceAwsSsmAssociationRead,
+		Delete: resourceAwsSsmAssociationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"parameters": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSsmAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	name := d.Get("name").(string)
+	instanceId := d.Get("instance_id").(string)
+
+	input := &ssm.CreateAssociationInput{
+		Name:       aws.String(name),
+		InstanceId: aws.String(instanceId),
+	}
+	if v, ok := d.GetOk("parameters"); ok {
+		input.Parameters = expandSsmParameters(v.(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Creating SSM Association: %s", input)
+	_, err := conn.CreateAssociation(input)
+	if err != nil {
+		return fmt.Errorf("Error creating SSM Association: %s", err)
+	}
+
+	// An association is identified by the document and the instance
+	d.SetId(name + "-" + instanceId)
+
+	log.Printf("[INFO] SSM Association %q created", d.Id())
+
+	return resourceAwsSsmAssociationRead(d, meta)
+}
+
+func resourceAwsSsmAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	log.Printf("[DEBUG] Reading SSM Association: %s", d.Id())
+	resp, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+		Name:       aws.String(d.Get("name").(string)),
+		InstanceId: aws.String(d.Get("instance_id").(string)),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "AssociationDoesNotExist" {
+			log.Printf("[WARN] Removing SSM Association %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading SSM Association %s: %s", d.Id(), err)
+	}
+
+	association := resp.AssociationDescription
+	d.Set("name", *association.Name)
+	d.Set("instance_id", *association.InstanceId)
+	d.Set("parameters", flattenSsmParameters(association.Parameters))
+
+	return nil
+}
+
+func resourceAwsSsmAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	log.Printf("[INFO] Deleting SSM Association: %s", d.Id())
+	_, err := conn.DeleteAssociation(&ssm.DeleteAssociationInput{
+		Name:       aws.String(d.Get("name").(string)),
+		InstanceId: aws.String(d.Get("instance_id").(string)),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "AssociationDoesNotExist" {
+			return nil
+		}
+		return fmt.Errorf("Error deleting SSM Association: %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// expandSsmParameters turns the parameters map of an association into the
+// parameters of the API, where a comma separated value is a list of values.
+func expandSsmParameters(m map[string]interface{}) map[string][]*string {
+	params := make(map[string][]*string, len(m))
+	for k, v := range m {
+		var values []*string
+		for _, value := range strings.Split(v.(string), ",") {
+			values = append(values, aws.String(strings.TrimSpace(value)))
+		}
+		params[k] = values
+	}
+
+	return params
+}
+
+func flattenSsmParameters(params map[string][]*string) map[string]interface{} {
+	m := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		m[k] = strings.Join(aws.StringValueSlice(v), ",")
+	}
+
+	return m
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSSSMAssociation_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-ssm-association-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSSMAssociationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSSMAssociationConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSSMAssociationExists("aws_ssm_association.foo"),
+					resource.TestCheckResourceAttr("aws_ssm_association.foo", "name", name),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandSsmParameters(t *testing.T) {
+	expanded := expandSsmParameters(map[string]interface{}{
+		"commands": "ifconfig, uptime",
+		"timeout":  "60",
+	})
+
+	expected := map[string][]*string{
+		"commands": []*string{aws.String("ifconfig"), aws.String("uptime")},
+		"timeout":  []*string{aws.String("60")},
+	}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Fatalf("Got:\n\n%#v\n\nExpected:\n\n%#v\n", expanded, expected)
+	}
+
+	flattened := flattenSsmParameters(expanded)
+	if flattened["commands"] != "ifconfig,uptime" || flattened["timeout"] != "60" {
+		t.Fatalf("bad: %#v", flattened)
+	}
+}
+
+func testAccCheckAWSSSMAssociationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSM Association ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ssmconn
+		_, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+			Name:       aws.String(rs.Primary.Attributes["name"]),
+			InstanceId: aws.String(rs.Primary.Attributes["instance_id"]),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckAWSSSMAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ssmconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_ssm_association" {
+			continue
+		}
+
+		_, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+			Name:       aws.String(rs.Primary.Attributes["name"]),
+			InstanceId: aws.String(rs.Primary.Attributes["instance_id"]),
+		})
+		if err == nil {
+			return fmt.Errorf("SSM Association %q still exists", rs.Primary.ID)
+		}
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "AssociationDoesNotExist" {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSSMAssociationConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_instance" "foo" {
+  ami = "ami-4fccb37f"
+  instance_type = "m1.small"
+}
+
+resource "aws_ssm_document" "foo" {
+  name = "%s"
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": ["ifconfig"]
+        }
+      ]
+    }
+  }
+}
+DOC
+}
+
+resource "aws_ssm_association" "foo" {
+  name = "${aws_ssm_document.foo.name}"
+  instance_id = "${aws_instance.foo.id}"
+}
+`, name)
+}
@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsSsmDocument() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsSsmDocumentCreate,
+		Read:   resourceAwsSsmDocumentRead,
+		Delete: resourceAwsSsmDocumentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(3, 128),
+					validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z_.-]+$`),
+						"can only contain alphanumeric characters, underscores, periods and hyphens"),
+				),
+			},
+
+			"content": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.JSONString,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"platform_types": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsSsmDocumentCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	input := &ssm.CreateDocumentInput{
+		Name:    aws.String(d.Get("name").(string)),
+		Content: aws.String(d.Get("content").(string)),
+	}
+
+	log.Printf("[DEBUG] Creating SSM Document: %s", input)
+	_, err := conn.CreateDocument(input)
+	if err != nil {
+		return fmt.Errorf("Error creating SSM Document: %s", err)
+	}
+
+	d.SetId(d.Get("name").(string))
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ssm.DocumentStatusCreating},
+		Target:  ssm.DocumentStatusActive,
+		Refresh: ssmDocumentStateRefreshFunc(conn, d.Id()),
+		Timeout: 5 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for SSM Document %q to become active: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] SSM Document %q created", d.Id())
+
+	return resourceAwsSsmDocumentRead(d, meta)
+}
+
+func resourceAwsSsmDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	log.Printf("[DEBUG] Reading SSM Document: %s", d.Id())
+	resp, err := conn.DescribeDocument(&ssm.DescribeDocumentInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isSsmDocumentNotFound(err) {
+			log.Printf("[WARN] Removing SSM Document %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading SSM Document %s: %s", d.Id(), err)
+	}
+
+	doc := resp.Document
+	d.Set("name", *doc.Name)
+	d.Set("description", aws.StringValue(doc.Description))
+	d.Set("status", aws.StringValue(doc.Status))
+	d.Set("platform_types", flattenStringList(doc.PlatformTypes))
+
+	content, err := conn.GetDocument(&ssm.GetDocumentInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error reading content of SSM Document %s: %s", d.Id(), err)
+	}
+	d.Set("content", aws.StringValue(content.Content))
+
+	return nil
+}
+
+func resourceAwsSsmDocumentDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ssmconn
+
+	log.Printf("[INFO] Deleting SSM Document: %s", d.Id())
+	_, err := conn.DeleteDocument(&ssm.DeleteDocumentInput{
+		Name: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isSsmDocumentNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting SSM Document: %s", err)
+	}
+
+	// Deleting is asynchronous, so wait until the document is gone to make
+	// sure a document with the same name can be created again right away.
+	return resource.Retry(2*time.Minute, func() error {
+		_, err := conn.DescribeDocument(&ssm.DescribeDocumentInput{
+			Name: aws.String(d.Id()),
+		})
+		if err != nil {
+			if isSsmDocumentNotFound(err) {
+				return nil
+			}
+			return resource.RetryError{Err: err}
+		}
+
+		return fmt.Errorf("SSM Document %q is still being deleted", d.Id())
+	})
+}
+
+// ssmDocumentStateRefreshFunc returns a resource.StateRefreshFunc that is
+// used to watch the status of an SSM Document.
+func ssmDocumentStateRefreshFunc(conn *ssm.SSM, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeDocument(&ssm.DescribeDocumentInput{
+			Name: aws.String(name),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		return resp, aws.StringValue(resp.Document.Status), nil
+	}
+}
+
+func isSsmDocumentNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "InvalidDocument"
+}
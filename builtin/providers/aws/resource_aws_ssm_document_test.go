@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSSSMDocument_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-ssm-document-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSSMDocumentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSSSMDocumentConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSSMDocumentExists("aws_ssm_document.foo"),
+					resource.TestCheckResourceAttr("aws_ssm_document.foo", "name", name),
+					resource.TestCheckResourceAttr("aws_ssm_document.foo", "status", "Active"),
+					resource.TestCheckResourceAttr("aws_ssm_document.foo", "description", "Check ip configuration of a Linux instance."),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSSMDocumentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSM Document ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ssmconn
+		_, err := conn.DescribeDocument(&ssm.DescribeDocumentInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckAWSSSMDocumentDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ssmconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_ssm_document" {
+			continue
+		}
+
+		_, err := conn.DescribeDocument(&ssm.DescribeDocumentInput{
+			Name: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("SSM Document %q still exists", rs.Primary.ID)
+		}
+		if !isSsmDocumentNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSSSMDocumentConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "foo" {
+  name = "%s"
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": ["ifconfig"]
+        }
+      ]
+    }
+  }
+}
+DOC
+}
+`, name)
+}
@@ -0,0 +1,250 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsWafByteMatchSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsWafByteMatchSetCreate,
+		Read:   resourceAwsWafByteMatchSetRead,
+		Update: resourceAwsWafByteMatchSetUpdate,
+		Delete: resourceAwsWafByteMatchSetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"byte_match_tuples": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_to_match": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"data": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"type": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											waf.MatchFieldTypeUri,
+											waf.MatchFieldTypeQueryString,
+											waf.MatchFieldTypeHeader,
+											waf.MatchFieldTypeMethod,
+											waf.MatchFieldTypeBody,
+										}, false),
+									},
+								},
+							},
+						},
+						"positional_constraint": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								waf.PositionalConstraintExactly,
+								waf.PositionalConstraintStartsWith,
+								waf.PositionalConstraintEndsWith,
+								waf.PositionalConstraintContains,
+								waf.PositionalConstraintContainsWord,
+							}, false),
+						},
+						"target_string": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 50),
+						},
+						"text_transformation": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								waf.TextTransformationNone,
+								waf.TextTransformationCompressWhiteSpace,
+								waf.TextTransformationHtmlEntityDecode,
+								waf.TextTransformationLowercase,
+								waf.TextTransformationCmdLine,
+								waf.TextTransformationUrlDecode,
+							}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsWafByteMatchSetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	out, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.CreateByteMatchSetInput{
+			ChangeToken: token,
+			Name:        aws.String(d.Get("name").(string)),
+		}
+
+		log.Printf("[DEBUG] Creating WAF ByteMatchSet: %s", req)
+		return conn.CreateByteMatchSet(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating WAF ByteMatchSet: %s", err)
+	}
+
+	d.SetId(*out.(*waf.CreateByteMatchSetOutput).ByteMatchSet.ByteMatchSetId)
+
+	return resourceAwsWafByteMatchSetUpdate(d, meta)
+}
+
+func resourceAwsWafByteMatchSetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	resp, err := conn.GetByteMatchSet(&waf.GetByteMatchSetInput{
+		ByteMatchSetId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isWafNotFound(err) {
+			log.Printf("[WARN] WAF ByteMatchSet (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading WAF ByteMatchSet %s: %s", d.Id(), err)
+	}
+
+	tuples := make([]interface{}, 0, len(resp.ByteMatchSet.ByteMatchTuples))
+	for _, t := range resp.ByteMatchSet.ByteMatchTuples {
+		tuple := map[string]interface{}{
+			"positional_constraint": aws.StringValue(t.PositionalConstraint),
+			"target_string":         string(t.TargetString),
+			"text_transformation":   aws.StringValue(t.TextTransformation),
+		}
+		if t.FieldToMatch != nil {
+			tuple["field_to_match"] = []interface{}{
+				map[string]interface{}{
+					"data": aws.StringValue(t.FieldToMatch.Data),
+					"type": aws.StringValue(t.FieldToMatch.Type),
+				},
+			}
+		}
+		tuples = append(tuples, tuple)
+	}
+
+	d.Set("name", aws.StringValue(resp.ByteMatchSet.Name))
+	if err := d.Set("byte_match_tuples", tuples); err != nil {
+		return fmt.Errorf("Error saving byte_match_tuples for WAF ByteMatchSet %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsWafByteMatchSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	if d.HasChange("byte_match_tuples") {
+		o, n := d.GetChange("byte_match_tuples")
+		if err := updateWafByteMatchSetTuples(conn, d.Id(), o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsWafByteMatchSetRead(d, meta)
+}
+
+func resourceAwsWafByteMatchSetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	// A ByteMatchSet can only be deleted when it's empty
+	tuples := d.Get("byte_match_tuples").(*schema.Set)
+	if tuples.Len() > 0 {
+		empty := schema.NewSet(tuples.F, nil)
+		if err := updateWafByteMatchSetTuples(conn, d.Id(), tuples, empty); err != nil {
+			return err
+		}
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.DeleteByteMatchSetInput{
+			ChangeToken:    token,
+			ByteMatchSetId: aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Deleting WAF ByteMatchSet: %s", req)
+		return conn.DeleteByteMatchSet(req)
+	})
+	if err != nil && !isWafNotFound(err) {
+		return fmt.Errorf("Error deleting WAF ByteMatchSet %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// updateWafByteMatchSetTuples deletes the tuples of the old set which
+// aren't in the new set and inserts the ones which are new.
+func updateWafByteMatchSetTuples(conn *waf.WAF, id string, o, n *schema.Set) error {
+	var updates []*waf.ByteMatchSetUpdate
+	for _, raw := range o.Difference(n).List() {
+		updates = append(updates, &waf.ByteMatchSetUpdate{
+			Action:         aws.String(waf.ChangeActionDelete),
+			ByteMatchTuple: expandWafByteMatchTuple(raw.(map[string]interface{})),
+		})
+	}
+	for _, raw := range n.Difference(o).List() {
+		updates = append(updates, &waf.ByteMatchSetUpdate{
+			Action:         aws.String(waf.ChangeActionInsert),
+			ByteMatchTuple: expandWafByteMatchTuple(raw.(map[string]interface{})),
+		})
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.UpdateByteMatchSetInput{
+			ChangeToken:    token,
+			ByteMatchSetId: aws.String(id),
+			Updates:        updates,
+		}
+
+		log.Printf("[DEBUG] Updating WAF ByteMatchSet: %s", req)
+		return conn.UpdateByteMatchSet(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating WAF ByteMatchSet %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func expandWafByteMatchTuple(m map[string]interface{}) *waf.ByteMatchTuple {
+	t := &waf.ByteMatchTuple{
+		PositionalConstraint: aws.String(m["positional_constraint"].(string)),
+		TargetString:         []byte(m["target_string"].(string)),
+		TextTransformation:   aws.String(m["text_transformation"].(string)),
+	}
+
+	if l := m["field_to_match"].([]interface{}); len(l) > 0 {
+		f := l[0].(map[string]interface{})
+		t.FieldToMatch = &waf.FieldToMatch{
+			Type: aws.String(f["type"].(string)),
+		}
+		if v := f["data"].(string); v != "" {
+			t.FieldToMatch.Data = aws.String(v)
+		}
+	}
+
+	return t
+}
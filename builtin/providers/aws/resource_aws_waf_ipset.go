@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsWafIPSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsWafIPSetCreate,
+		Read:   resourceAwsWafIPSetRead,
+		Update: resourceAwsWafIPSetUpdate,
+		Delete: resourceAwsWafIPSetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"ip_set_descriptors": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								waf.IPSetDescriptorTypeIpv4,
+								waf.IPSetDescriptorTypeIpv6,
+							}, false),
+						},
+						"value": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.CIDRNetwork(0, 128),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsWafIPSetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	out, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.CreateIPSetInput{
+			ChangeToken: token,
+			Name:        aws.String(d.Get("name").(string)),
+		}
+
+		log.Printf("[DEBUG] Creating WAF IPSet: %s", req)
+		return conn.CreateIPSet(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating WAF IPSet: %s", err)
+	}
+
+	d.SetId(*out.(*waf.CreateIPSetOutput).IPSet.IPSetId)
+
+	return resourceAwsWafIPSetUpdate(d, meta)
+}
+
+func resourceAwsWafIPSetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	resp, err := conn.GetIPSet(&waf.GetIPSetInput{
+		IPSetId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isWafNotFound(err) {
+			log.Printf("[WARN] WAF IPSet (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading WAF IPSet %s: %s", d.Id(), err)
+	}
+
+	descriptors := make([]interface{}, 0, len(resp.IPSet.IPSetDescriptors))
+	for _, descriptor := range resp.IPSet.IPSetDescriptors {
+		descriptors = append(descriptors, map[string]interface{}{
+			"type":  aws.StringValue(descriptor.Type),
+			"value": aws.StringValue(descriptor.Value),
+		})
+	}
+
+	d.Set("name", aws.StringValue(resp.IPSet.Name))
+	if err := d.Set("ip_set_descriptors", descriptors); err != nil {
+		return fmt.Errorf("Error saving ip_set_descriptors for WAF IPSet %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsWafIPSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	if d.HasChange("ip_set_descriptors") {
+		o, n := d.GetChange("ip_set_descriptors")
+		if err := updateWafIPSetDescriptors(conn, d.Id(), o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsWafIPSetRead(d, meta)
+}
+
+func resourceAwsWafIPSetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	// An IPSet can only be deleted when it's empty
+	descriptors := d.Get("ip_set_descriptors").(*schema.Set)
+	if descriptors.Len() > 0 {
+		empty := schema.NewSet(descriptors.F, nil)
+		if err := updateWafIPSetDescriptors(conn, d.Id(), descriptors, empty); err != nil {
+			return err
+		}
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.DeleteIPSetInput{
+			ChangeToken: token,
+			IPSetId:     aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Deleting WAF IPSet: %s", req)
+		return conn.DeleteIPSet(req)
+	})
+	if err != nil && !isWafNotFound(err) {
+		return fmt.Errorf("Error deleting WAF IPSet %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// updateWafIPSetDescriptors deletes the descriptors of the old set which
+// aren't in the new set and inserts the ones which are new.
+func updateWafIPSetDescriptors(conn *waf.WAF, id string, o, n *schema.Set) error {
+	var updates []*waf.IPSetUpdate
+	for _, raw := range o.Difference(n).List() {
+		updates = append(updates, &waf.IPSetUpdate{
+			Action:          aws.String(waf.ChangeActionDelete),
+			IPSetDescriptor: expandWafIPSetDescriptor(raw.(map[string]interface{})),
+		})
+	}
+	for _, raw := range n.Difference(o).List() {
+		updates = append(updates, &waf.IPSetUpdate{
+			Action:          aws.String(waf.ChangeActionInsert),
+			IPSetDescriptor: expandWafIPSetDescriptor(raw.(map[string]interface{})),
+		})
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.UpdateIPSetInput{
+			ChangeToken: token,
+			IPSetId:     aws.String(id),
+			Updates:     updates,
+		}
+
+		log.Printf("[DEBUG] Updating WAF IPSet: %s", req)
+		return conn.UpdateIPSet(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating WAF IPSet %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func expandWafIPSetDescriptor(m map[string]interface{}) *waf.IPSetDescriptor {
+	return &waf.IPSetDescriptor{
+		Type:  aws.String(m["type"].(string)),
+		Value: aws.String(m["value"].(string)),
+	}
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSWafIPSet_basic(t *testing.T) {
+	name := fmt.Sprintf("ip-set-%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafIPSetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSWafIPSetConfig(name, "192.0.7.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafIPSetExists("aws_waf_ipset.ipset"),
+					resource.TestCheckResourceAttr("aws_waf_ipset.ipset", "name", name),
+					resource.TestCheckResourceAttr("aws_waf_ipset.ipset", "ip_set_descriptors.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSWafIPSetConfig(name, "192.0.8.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafIPSetExists("aws_waf_ipset.ipset"),
+					resource.TestCheckResourceAttr("aws_waf_ipset.ipset", "ip_set_descriptors.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSWafIPSetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No WAF IPSet ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).wafconn
+		_, err := conn.GetIPSet(&waf.GetIPSetInput{
+			IPSetId: aws.String(rs.Primary.ID),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckAWSWafIPSetDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).wafconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_waf_ipset" {
+			continue
+		}
+
+		_, err := conn.GetIPSet(&waf.GetIPSetInput{
+			IPSetId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("WAF IPSet %q still exists", rs.Primary.ID)
+		}
+		if !isWafNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSWafIPSetConfig(name, cidr string) string {
+	return fmt.Sprintf(`
+resource "aws_waf_ipset" "ipset" {
+  name = "%s"
+  ip_set_descriptors {
+    type = "IPV4"
+    value = "%s"
+  }
+}
+`, name, cidr)
+}
@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsWafRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsWafRuleCreate,
+		Read:   resourceAwsWafRuleRead,
+		Update: resourceAwsWafRuleUpdate,
+		Delete: resourceAwsWafRuleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"metric_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateWafMetricName,
+			},
+
+			"predicates": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"negated": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"data_id": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 128),
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								waf.PredicateTypeIpmatch,
+								waf.PredicateTypeByteMatch,
+								waf.PredicateTypeSqlInjectionMatch,
+								waf.PredicateTypeSizeConstraint,
+								waf.PredicateTypeXssMatch,
+							}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateWafMetricName validates the name of the CloudWatch metrics of a
+// rule or web ACL.
+var validateWafMetricName = validation.All(
+	validation.StringLenBetween(1, 128),
+	validation.StringMatch(regexp.MustCompile(`^[0-9A-Za-z]+$`),
+		"can only contain alphanumeric characters"),
+)
+
+func resourceAwsWafRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	out, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.CreateRuleInput{
+			ChangeToken: token,
+			MetricName:  aws.String(d.Get("metric_name").(string)),
+			Name:        aws.String(d.Get("name").(string)),
+		}
+
+		log.Printf("[DEBUG] Creating WAF Rule: %s", req)
+		return conn.CreateRule(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating WAF Rule: %s", err)
+	}
+
+	d.SetId(*out.(*waf.CreateRuleOutput).Rule.RuleId)
+
+	return resourceAwsWafRuleUpdate(d, meta)
+}
+
+func resourceAwsWafRuleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	resp, err := conn.GetRule(&waf.GetRuleInput{
+		RuleId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isWafNotFound(err) {
+			log.Printf("[WARN] WAF Rule (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading WAF Rule %s: %s", d.Id(), err)
+	}
+
+	predicates := make([]interface{}, 0, len(resp.Rule.Predicates))
+	for _, p := range resp.Rule.Predicates {
+		predicates = append(predicates, map[string]interface{}{
+			"negated": aws.BoolValue(p.Negated),
+			"data_id": aws.StringValue(p.DataId),
+			"type":    aws.StringValue(p.Type),
+		})
+	}
+
+	d.Set("name", aws.StringValue(resp.Rule.Name))
+	d.Set("metric_name", aws.StringValue(resp.Rule.MetricName))
+	if err := d.Set("predicates", predicates); err != nil {
+		return fmt.Errorf("Error saving predicates for WAF Rule %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsWafRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	if d.HasChange("predicates") {
+		o, n := d.GetChange("predicates")
+		if err := updateWafRulePredicates(conn, d.Id(), o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsWafRuleRead(d, meta)
+}
+
+func resourceAwsWafRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	// A Rule can only be deleted when it has no predicates
+	predicates := d.Get("predicates").(*schema.Set)
+	if predicates.Len() > 0 {
+		empty := schema.NewSet(predicates.F, nil)
+		if err := updateWafRulePredicates(conn, d.Id(), predicates, empty); err != nil {
+			return err
+		}
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.DeleteRuleInput{
+			ChangeToken: token,
+			RuleId:      aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Deleting WAF Rule: %s", req)
+		return conn.DeleteRule(req)
+	})
+	if err != nil && !isWafNotFound(err) {
+		return fmt.Errorf("Error deleting WAF Rule %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// updateWafRulePredicates deletes the predicates of the old set which
+// aren't in the new set and inserts the ones which are new.
+func updateWafRulePredicates(conn *waf.WAF, id string, o, n *schema.Set) error {
+	var updates []*waf.RuleUpdate
+	for _, raw := range o.Difference(n).List() {
+		updates = append(updates, &waf.RuleUpdate{
+			Action:    aws.String(waf.ChangeActionDelete),
+			Predicate: expandWafPredicate(raw.(map[string]interface{})),
+		})
+	}
+	for _, raw := range n.Difference(o).List() {
+		updates = append(updates, &waf.RuleUpdate{
+			Action:    aws.String(waf.ChangeActionInsert),
+			Predicate: expandWafPredicate(raw.(map[string]interface{})),
+		})
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.UpdateRuleInput{
+			ChangeToken: token,
+			RuleId:      aws.String(id),
+			Updates:     updates,
+		}
+
+		log.Printf("[DEBUG] Updating WAF Rule: %s", req)
+		return conn.UpdateRule(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating WAF Rule %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func expandWafPredicate(m map[string]interface{}) *waf.Predicate {
+	return &waf.Predicate{
+		Negated: aws.Bool(m["negated"].(bool)),
+		DataId:  aws.String(m["data_id"].(string)),
+		Type:    aws.String(m["type"].(string)),
+	}
+}
@@ -0,0 +1,274 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
+)
+
+func resourceAwsWafWebAcl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsWafWebAclCreate,
+		Read:   resourceAwsWafWebAclRead,
+		Update: resourceAwsWafWebAclUpdate,
+		Delete: resourceAwsWafWebAclDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"metric_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateWafMetricName,
+			},
+
+			"default_action": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateWafActionType,
+						},
+					},
+				},
+			},
+
+			"rules": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateWafActionType,
+									},
+								},
+							},
+						},
+						"priority": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"rule_id": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 128),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateWafActionType validates the action taken for requests matching
+// a rule or no rule at all.
+var validateWafActionType = validation.StringInSlice([]string{
+	waf.WafActionTypeAllow,
+	waf.WafActionTypeBlock,
+	waf.WafActionTypeCount,
+}, false)
+
+func resourceAwsWafWebAclCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	out, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.CreateWebACLInput{
+			ChangeToken:   token,
+			DefaultAction: expandWafAction(d.Get("default_action").([]interface{})),
+			MetricName:    aws.String(d.Get("metric_name").(string)),
+			Name:          aws.String(d.Get("name").(string)),
+		}
+
+		log.Printf("[DEBUG] Creating WAF WebACL: %s", req)
+		return conn.CreateWebACL(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating WAF WebACL: %s", err)
+	}
+
+	d.SetId(*out.(*waf.CreateWebACLOutput).WebACL.WebACLId)
+
+	// The default action was set on creation already
+	if rules := d.Get("rules").(*schema.Set); rules.Len() > 0 {
+		err := updateWafWebAclRules(conn, d.Id(), nil, schema.NewSet(rules.F, nil), rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsWafWebAclRead(d, meta)
+}
+
+func resourceAwsWafWebAclRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	resp, err := conn.GetWebACL(&waf.GetWebACLInput{
+		WebACLId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isWafNotFound(err) {
+			log.Printf("[WARN] WAF WebACL (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading WAF WebACL %s: %s", d.Id(), err)
+	}
+
+	rules := make([]interface{}, 0, len(resp.WebACL.Rules))
+	for _, r := range resp.WebACL.Rules {
+		rules = append(rules, map[string]interface{}{
+			"action":   flattenWafAction(r.Action),
+			"priority": int(aws.Int64Value(r.Priority)),
+			"rule_id":  aws.StringValue(r.RuleId),
+		})
+	}
+
+	d.Set("name", aws.StringValue(resp.WebACL.Name))
+	d.Set("metric_name", aws.StringValue(resp.WebACL.MetricName))
+	if err := d.Set("default_action", flattenWafAction(resp.WebACL.DefaultAction)); err != nil {
+		return fmt.Errorf("Error saving default_action for WAF WebACL %s: %s", d.Id(), err)
+	}
+	if err := d.Set("rules", rules); err != nil {
+		return fmt.Errorf("Error saving rules for WAF WebACL %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceAwsWafWebAclUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	if d.HasChange("default_action") || d.HasChange("rules") {
+		var defaultAction *waf.WafAction
+		if d.HasChange("default_action") {
+			defaultAction = expandWafAction(d.Get("default_action").([]interface{}))
+		}
+
+		o, n := d.GetChange("rules")
+		if err := updateWafWebAclRules(conn, d.Id(), defaultAction, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsWafWebAclRead(d, meta)
+}
+
+func resourceAwsWafWebAclDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafconn
+
+	// A WebACL can only be deleted when it has no rules
+	rules := d.Get("rules").(*schema.Set)
+	if rules.Len() > 0 {
+		empty := schema.NewSet(rules.F, nil)
+		if err := updateWafWebAclRules(conn, d.Id(), nil, rules, empty); err != nil {
+			return err
+		}
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.DeleteWebACLInput{
+			ChangeToken: token,
+			WebACLId:    aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Deleting WAF WebACL: %s", req)
+		return conn.DeleteWebACL(req)
+	})
+	if err != nil && !isWafNotFound(err) {
+		return fmt.Errorf("Error deleting WAF WebACL %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// updateWafWebAclRules deletes the rules of the old set which aren't in
+// the new set and inserts the ones which are new, changing the default
+// action as well if it's given.
+func updateWafWebAclRules(conn *waf.WAF, id string, defaultAction *waf.WafAction, o, n *schema.Set) error {
+	var updates []*waf.WebACLUpdate
+	for _, raw := range o.Difference(n).List() {
+		updates = append(updates, &waf.WebACLUpdate{
+			Action:        aws.String(waf.ChangeActionDelete),
+			ActivatedRule: expandWafActivatedRule(raw.(map[string]interface{})),
+		})
+	}
+	for _, raw := range n.Difference(o).List() {
+		updates = append(updates, &waf.WebACLUpdate{
+			Action:        aws.String(waf.ChangeActionInsert),
+			ActivatedRule: expandWafActivatedRule(raw.(map[string]interface{})),
+		})
+	}
+	if len(updates) == 0 && defaultAction == nil {
+		return nil
+	}
+
+	_, err := withWafChangeToken(conn, func(token *string) (interface{}, error) {
+		req := &waf.UpdateWebACLInput{
+			ChangeToken:   token,
+			DefaultAction: defaultAction,
+			WebACLId:      aws.String(id),
+			Updates:       updates,
+		}
+
+		log.Printf("[DEBUG] Updating WAF WebACL: %s", req)
+		return conn.UpdateWebACL(req)
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating WAF WebACL %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func expandWafActivatedRule(m map[string]interface{}) *waf.ActivatedRule {
+	return &waf.ActivatedRule{
+		Action:   expandWafAction(m["action"].([]interface{})),
+		Priority: aws.Int64(int64(m["priority"].(int))),
+		RuleId:   aws.String(m["rule_id"].(string)),
+	}
+}
+
+func expandWafAction(l []interface{}) *waf.WafAction {
+	if len(l) == 0 {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+	return &waf.WafAction{
+		Type: aws.String(m["type"].(string)),
+	}
+}
+
+func flattenWafAction(a *waf.WafAction) []interface{} {
+	if a == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type": aws.StringValue(a.Type),
+		},
+	}
+}
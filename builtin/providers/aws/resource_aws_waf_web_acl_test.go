@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccAWSWafWebAcl_basic(t *testing.T) {
+	name := fmt.Sprintf("wafacl%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafWebAclDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAWSWafWebAclConfig(name, "ALLOW"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafWebAclExists("aws_waf_web_acl.waf_acl"),
+					resource.TestCheckResourceAttr("aws_waf_web_acl.waf_acl", "name", name),
+					resource.TestCheckResourceAttr("aws_waf_web_acl.waf_acl", "default_action.0.type", "ALLOW"),
+					resource.TestCheckResourceAttr("aws_waf_web_acl.waf_acl", "rules.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAWSWafWebAclConfig(name, "BLOCK"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafWebAclExists("aws_waf_web_acl.waf_acl"),
+					resource.TestCheckResourceAttr("aws_waf_web_acl.waf_acl", "default_action.0.type", "BLOCK"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSWafWebAclExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No WAF WebACL ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).wafconn
+		_, err := conn.GetWebACL(&waf.GetWebACLInput{
+			WebACLId: aws.String(rs.Primary.ID),
+		})
+
+		return err
+	}
+}
+
+func testAccCheckAWSWafWebAclDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).wafconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_waf_web_acl" {
+			continue
+		}
+
+		_, err := conn.GetWebACL(&waf.GetWebACLInput{
+			WebACLId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("WAF WebACL %q still exists", rs.Primary.ID)
+		}
+		if !isWafNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSWafWebAclConfig(name, defaultAction string) string {
+	return fmt.Sprintf(`
+resource "aws_waf_ipset" "ipset" {
+  name = "%s"
+  ip_set_descriptors {
+    type = "IPV4"
+    value = "192.0.7.0/24"
+  }
+}
+
+resource "aws_waf_rule" "wafrule" {
+  name = "%s"
+  metric_name = "%s"
+  predicates {
+    data_id = "${aws_waf_ipset.ipset.id}"
+    negated = false
+    type = "IPMatch"
+  }
+}
+
+resource "aws_waf_web_acl" "waf_acl" {
+  name = "%s"
+  metric_name = "%s"
+  default_action {
+    type = "%s"
+  }
+  rules {
+    action {
+      type = "BLOCK"
+    }
+    priority = 1
+    rule_id = "${aws_waf_rule.wafrule.id}"
+  }
+}
+`, name, name, name, name, name, defaultAction)
+}
@@ -0,0 +1,112 @@
+package aws
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// awsRetryMinDelay is the delay before the first retry of a request
+	// that failed with a server error. It doubles with every retry.
+	awsRetryMinDelay = 100 * time.Millisecond
+
+	// awsRetryMinThrottleDelay is the delay before the first retry of a
+	// throttled request, which is higher since retrying right away is
+	// likely to be throttled again.
+	awsRetryMinThrottleDelay = 500 * time.Millisecond
+
+	// awsRetryMaxDelay caps the delay between two retries.
+	awsRetryMaxDelay = 30 * time.Second
+)
+
+// awsThrottleCodes are the error codes returned by AWS APIs when the
+// request rate limit is exceeded.
+var awsThrottleCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestLimitExceeded":                   true,
+	"RequestThrottledException":              true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+}
+
+// awsRetryer is the retryer shared by all AWS API clients. On top of the
+// retries of the SDK, it retries requests that were throttled or failed
+// with a server error, using an exponential backoff with jitter so
+// concurrent requests don't retry in lockstep.
+type awsRetryer struct {
+	client.DefaultRetryer
+}
+
+func newAWSRetryer(maxRetries int) *awsRetryer {
+	return &awsRetryer{
+		DefaultRetryer: client.DefaultRetryer{NumMaxRetries: maxRetries},
+	}
+}
+
+// ShouldRetry returns true if the request should be retried.
+func (r *awsRetryer) ShouldRetry(req *request.Request) bool {
+	if isAWSThrottleError(req) || isAWSServerError(req) {
+		return true
+	}
+
+	return r.DefaultRetryer.ShouldRetry(req)
+}
+
+// RetryRules returns the delay before the request is retried.
+func (r *awsRetryer) RetryRules(req *request.Request) time.Duration {
+	minDelay := awsRetryMinDelay
+	if isAWSThrottleError(req) {
+		minDelay = awsRetryMinThrottleDelay
+	}
+
+	delay := awsRetryDelay(req.RetryCount, minDelay)
+	log.Printf("[DEBUG] Retrying AWS request %s in %s (%d/%d): %s",
+		awsRequestName(req), delay, req.RetryCount+1, r.MaxRetries(), req.Error)
+
+	return delay
+}
+
+// awsRetryDelay returns the delay before the given retry, which doubles
+// with every retry up to awsRetryMaxDelay. The delay is randomized
+// between half and the full value.
+func awsRetryDelay(retryCount int, minDelay time.Duration) time.Duration {
+	delay := awsRetryMaxDelay
+	if retryCount < 30 {
+		if d := minDelay << uint(retryCount); d > 0 && d < delay {
+			delay = d
+		}
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isAWSThrottleError(req *request.Request) bool {
+	if err, ok := req.Error.(awserr.Error); ok {
+		return awsThrottleCodes[err.Code()]
+	}
+
+	return false
+}
+
+func isAWSServerError(req *request.Request) bool {
+	return req.Error != nil && req.HTTPResponse != nil &&
+		req.HTTPResponse.StatusCode >= 500
+}
+
+func awsRequestName(req *request.Request) string {
+	name := req.ClientInfo.ServiceName
+	if req.Operation != nil {
+		name += "." + req.Operation.Name
+	}
+
+	return name
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestAWSRetryer_ShouldRetry(t *testing.T) {
+	cases := []struct {
+		Err        error
+		StatusCode int
+		Expected   bool
+	}{
+		{awserr.New("Throttling", "Rate exceeded", nil), 400, true},
+		{awserr.New("RequestLimitExceeded", "Request limit exceeded", nil), 503, true},
+		{awserr.New("InternalError", "", nil), 500, true},
+		{fmt.Errorf("connection reset"), 502, true},
+		{awserr.New("InvalidParameterValue", "", nil), 400, false},
+		{awserr.New("AccessDenied", "", nil), 403, false},
+	}
+
+	r := newAWSRetryer(11)
+	for i, tc := range cases {
+		req := &request.Request{
+			Error:        tc.Err,
+			HTTPResponse: &http.Response{StatusCode: tc.StatusCode},
+		}
+
+		if actual := r.ShouldRetry(req); actual != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestAWSRetryDelay(t *testing.T) {
+	cases := []struct {
+		RetryCount int
+		MinDelay   time.Duration
+		Max        time.Duration
+	}{
+		{0, awsRetryMinDelay, awsRetryMinDelay},
+		{3, awsRetryMinDelay, 8 * awsRetryMinDelay},
+		{0, awsRetryMinThrottleDelay, awsRetryMinThrottleDelay},
+		{20, awsRetryMinThrottleDelay, awsRetryMaxDelay},
+		{100, awsRetryMinDelay, awsRetryMaxDelay},
+	}
+
+	for i, tc := range cases {
+		for n := 0; n < 10; n++ {
+			actual := awsRetryDelay(tc.RetryCount, tc.MinDelay)
+			if actual < tc.Max/2 || actual > tc.Max {
+				t.Fatalf("%d: expected delay between %s and %s, got %s",
+					i, tc.Max/2, tc.Max, actual)
+			}
+		}
+	}
+}
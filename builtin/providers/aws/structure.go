@@ -9,6 +9,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/directoryservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecs"
@@ -460,6 +461,32 @@ func flattenElastiCacheParameters(list []*elasticache.Parameter) []map[string]in
 	return result
 }
 
+// Flattens the cache security group memberships of a cache cluster into
+// the names of the groups
+func flattenElastiCacheSecurityGroupNames(groups []*elasticache.CacheSecurityGroupMembership) []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.CacheSecurityGroupName != nil {
+			names = append(names, *g.CacheSecurityGroupName)
+		}
+	}
+
+	return names
+}
+
+// Flattens the VPC security group memberships of a cache cluster into the
+// IDs of the groups
+func flattenElastiCacheSecurityGroupIds(groups []*elasticache.SecurityGroupMembership) []string {
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.SecurityGroupId != nil {
+			ids = append(ids, *g.SecurityGroupId)
+		}
+	}
+
+	return ids
+}
+
 // Takes the result of flatmap.Expand for an array of strings
 // and returns a []*string
 func expandStringList(configured []interface{}) []*string {
@@ -551,32 +578,31 @@ func expandResourceRecords(recs []interface{}, typeStr string) []*route53.Resour
 	return records
 }
 
-func expandESClusterConfig(m map[string]interface{}) *elasticsearch.ElasticsearchClusterConfig {
-	config := elasticsearch.ElasticsearchClusterConfig{}
-
-	if v, ok := m["dedicated_master_enabled"]; ok {
-		isEnabled := v.(bool)
-		config.DedicatedMasterEnabled = aws.Bool(isEnabled)
+// esClusterConfig is the cluster_config block of an Elasticsearch domain.
+type esClusterConfig struct {
+	DedicatedMasterCount   int64
+	DedicatedMasterEnabled bool
+	DedicatedMasterType    string
+	InstanceCount          int64
+	InstanceType           string
+	ZoneAwarenessEnabled   bool
+}
 
-		if isEnabled {
-			if v, ok := m["dedicated_master_count"]; ok && v.(int) > 0 {
-				config.DedicatedMasterCount = aws.Int64(int64(v.(int)))
-			}
-			if v, ok := m["dedicated_master_type"]; ok && v.(string) != "" {
-				config.DedicatedMasterType = aws.String(v.(string))
-			}
-		}
+func expandESClusterConfig(c *esClusterConfig) *elasticsearch.ElasticsearchClusterConfig {
+	config := elasticsearch.ElasticsearchClusterConfig{
+		DedicatedMasterEnabled: aws.Bool(c.DedicatedMasterEnabled),
+		InstanceCount:          aws.Int64(c.InstanceCount),
+		InstanceType:           aws.String(c.InstanceType),
+		ZoneAwarenessEnabled:   aws.Bool(c.ZoneAwarenessEnabled),
 	}
 
-	if v, ok := m["instance_count"]; ok {
-		config.InstanceCount = aws.Int64(int64(v.(int)))
-	}
-	if v, ok := m["instance_type"]; ok {
-		config.InstanceType = aws.String(v.(string))
-	}
-
-	if v, ok := m["zone_awareness_enabled"]; ok {
-		config.ZoneAwarenessEnabled = aws.Bool(v.(bool))
+	if c.DedicatedMasterEnabled {
+		if c.DedicatedMasterCount > 0 {
+			config.DedicatedMasterCount = aws.Int64(c.DedicatedMasterCount)
+		}
+		if c.DedicatedMasterType != "" {
+			config.DedicatedMasterType = aws.String(c.DedicatedMasterType)
+		}
 	}
 
 	return &config
@@ -626,25 +652,44 @@ func flattenESEBSOptions(o *elasticsearch.EBSOptions) []map[string]interface{} {
 	return []map[string]interface{}{m}
 }
 
-func expandESEBSOptions(m map[string]interface{}) *elasticsearch.EBSOptions {
-	options := elasticsearch.EBSOptions{}
+// esEBSOptions is the ebs_options block of an Elasticsearch domain.
+type esEBSOptions struct {
+	EBSEnabled bool `tf:"ebs_enabled"`
+	Iops       int64
+	VolumeSize int64
+	VolumeType string
+}
 
-	if v, ok := m["ebs_enabled"]; ok {
-		options.EBSEnabled = aws.Bool(v.(bool))
+func expandESEBSOptions(o *esEBSOptions) *elasticsearch.EBSOptions {
+	options := elasticsearch.EBSOptions{
+		EBSEnabled: aws.Bool(o.EBSEnabled),
 	}
-	if v, ok := m["iops"]; ok && v.(int) > 0 {
-		options.Iops = aws.Int64(int64(v.(int)))
+
+	if o.Iops > 0 {
+		options.Iops = aws.Int64(o.Iops)
 	}
-	if v, ok := m["volume_size"]; ok && v.(int) > 0 {
-		options.VolumeSize = aws.Int64(int64(v.(int)))
+	if o.VolumeSize > 0 {
+		options.VolumeSize = aws.Int64(o.VolumeSize)
 	}
-	if v, ok := m["volume_type"]; ok && v.(string) != "" {
-		options.VolumeType = aws.String(v.(string))
+	if o.VolumeType != "" {
+		options.VolumeType = aws.String(o.VolumeType)
 	}
 
 	return &options
 }
 
+// esSnapshotOptions is the snapshot_options block of an Elasticsearch
+// domain.
+type esSnapshotOptions struct {
+	AutomatedSnapshotStartHour int64
+}
+
+func expandESSnapshotOptions(o *esSnapshotOptions) *elasticsearch.SnapshotOptions {
+	return &elasticsearch.SnapshotOptions{
+		AutomatedSnapshotStartHour: aws.Int64(o.AutomatedSnapshotStartHour),
+	}
+}
+
 func pointersMapToStringList(pointers map[string]*string) map[string]interface{} {
 	list := make(map[string]interface{}, len(pointers))
 	for i, v := range pointers {
@@ -746,3 +791,146 @@ func flattenCloudFormationOutputs(cfOutputs []*cloudformation.Output) map[string
 	}
 	return outputs
 }
+
+func expandConfigRecordingGroup(configured []interface{}) *configservice.RecordingGroup {
+	recordingGroup := configservice.RecordingGroup{}
+	group := configured[0].(map[string]interface{})
+
+	if v, ok := group["all_supported"]; ok {
+		recordingGroup.AllSupported = aws.Bool(v.(bool))
+	}
+
+	if v, ok := group["include_global_resource_types"]; ok {
+		recordingGroup.IncludeGlobalResourceTypes = aws.Bool(v.(bool))
+	}
+
+	if v, ok := group["resource_types"]; ok {
+		recordingGroup.ResourceTypes = expandStringList(v.(*schema.Set).List())
+	}
+	return &recordingGroup
+}
+
+func flattenConfigRecordingGroup(g *configservice.RecordingGroup) []map[string]interface{} {
+	m := make(map[string]interface{}, 1)
+
+	if g.AllSupported != nil {
+		m["all_supported"] = *g.AllSupported
+	}
+
+	if g.IncludeGlobalResourceTypes != nil {
+		m["include_global_resource_types"] = *g.IncludeGlobalResourceTypes
+	}
+
+	if g.ResourceTypes != nil && len(g.ResourceTypes) > 0 {
+		m["resource_types"] = schema.NewSet(schema.HashString, flattenStringList(g.ResourceTypes))
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandConfigRuleScope(configured map[string]interface{}) *configservice.Scope {
+	scope := &configservice.Scope{}
+
+	if v, ok := configured["compliance_resource_id"]; ok && v.(string) != "" {
+		scope.ComplianceResourceId = aws.String(v.(string))
+	}
+	if v, ok := configured["compliance_resource_types"]; ok {
+		l := v.(*schema.Set)
+		if l.Len() > 0 {
+			scope.ComplianceResourceTypes = expandStringList(l.List())
+		}
+	}
+	if v, ok := configured["tag_key"]; ok && v.(string) != "" {
+		scope.TagKey = aws.String(v.(string))
+	}
+	if v, ok := configured["tag_value"]; ok && v.(string) != "" {
+		scope.TagValue = aws.String(v.(string))
+	}
+
+	return scope
+}
+
+func flattenConfigRuleScope(scope *configservice.Scope) []map[string]interface{} {
+	m := make(map[string]interface{})
+	if scope.ComplianceResourceId != nil {
+		m["compliance_resource_id"] = *scope.ComplianceResourceId
+	}
+	if scope.ComplianceResourceTypes != nil {
+		m["compliance_resource_types"] = schema.NewSet(schema.HashString, flattenStringList(scope.ComplianceResourceTypes))
+	}
+	if scope.TagKey != nil {
+		m["tag_key"] = *scope.TagKey
+	}
+	if scope.TagValue != nil {
+		m["tag_value"] = *scope.TagValue
+	}
+
+	return []map[string]interface{}{m}
+}
+
+func expandConfigRuleSource(configured []interface{}) *configservice.Source {
+	cfg := configured[0].(map[string]interface{})
+	source := configservice.Source{
+		Owner:            aws.String(cfg["owner"].(string)),
+		SourceIdentifier: aws.String(cfg["source_identifier"].(string)),
+	}
+	if details, ok := cfg["source_detail"]; ok {
+		source.SourceDetails = expandConfigRuleSourceDetails(details.(*schema.Set))
+	}
+	return &source
+}
+
+func expandConfigRuleSourceDetails(configured *schema.Set) []*configservice.SourceDetail {
+	var results []*configservice.SourceDetail
+
+	for _, item := range configured.List() {
+		detail := item.(map[string]interface{})
+		src := configservice.SourceDetail{}
+
+		if msgType, ok := detail["message_type"].(string); ok && msgType != "" {
+			src.MessageType = aws.String(msgType)
+		}
+		if eventSource, ok := detail["event_source"].(string); ok && eventSource != "" {
+			src.EventSource = aws.String(eventSource)
+		}
+		if maxExecFreq, ok := detail["maximum_execution_frequency"].(string); ok && maxExecFreq != "" {
+			src.MaximumExecutionFrequency = aws.String(maxExecFreq)
+		}
+
+		results = append(results, &src)
+	}
+
+	return results
+}
+
+func flattenConfigRuleSource(source *configservice.Source) []interface{} {
+	var result []interface{}
+	m := make(map[string]interface{})
+	m["owner"] = *source.Owner
+	m["source_identifier"] = *source.SourceIdentifier
+	if len(source.SourceDetails) > 0 {
+		m["source_detail"] = schema.NewSet(configRuleSourceDetailsHash, flattenConfigRuleSourceDetails(source.SourceDetails))
+	}
+	result = append(result, m)
+	return result
+}
+
+func flattenConfigRuleSourceDetails(details []*configservice.SourceDetail) []interface{} {
+	var items []interface{}
+	for _, d := range details {
+		m := make(map[string]interface{})
+		if d.MessageType != nil {
+			m["message_type"] = *d.MessageType
+		}
+		if d.EventSource != nil {
+			m["event_source"] = *d.EventSource
+		}
+		if d.MaximumExecutionFrequency != nil {
+			m["maximum_execution_frequency"] = *d.MaximumExecutionFrequency
+		}
+
+		items = append(items, m)
+	}
+
+	return items
+}
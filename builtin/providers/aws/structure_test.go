@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -700,3 +701,82 @@ func TestFlattenResourceRecords(t *testing.T) {
 		t.Fatal("expected result to have value, but got nil")
 	}
 }
+
+func TestExpandConfigRuleScope(t *testing.T) {
+	scope := expandConfigRuleScope(map[string]interface{}{
+		"compliance_resource_id": "",
+		"compliance_resource_types": schema.NewSet(schema.HashString, []interface{}{
+			"AWS::EC2::Instance",
+		}),
+		"tag_key":   "Environment",
+		"tag_value": "",
+	})
+
+	expected := &configservice.Scope{
+		ComplianceResourceTypes: []*string{aws.String("AWS::EC2::Instance")},
+		TagKey:                  aws.String("Environment"),
+	}
+
+	if !reflect.DeepEqual(scope, expected) {
+		t.Fatalf("Got:\n\n%#v\n\nExpected:\n\n%#v\n", scope, expected)
+	}
+}
+
+func TestFlattenConfigRuleSource(t *testing.T) {
+	source := &configservice.Source{
+		Owner:            aws.String("CUSTOM_LAMBDA"),
+		SourceIdentifier: aws.String("arn:aws:lambda:us-west-2:123456789012:function:foo"),
+		SourceDetails: []*configservice.SourceDetail{
+			&configservice.SourceDetail{
+				EventSource: aws.String("aws.config"),
+				MessageType: aws.String("ConfigurationItemChangeNotification"),
+			},
+		},
+	}
+
+	result := flattenConfigRuleSource(source)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(result))
+	}
+
+	m := result[0].(map[string]interface{})
+	if m["owner"] != "CUSTOM_LAMBDA" {
+		t.Fatalf("bad owner: %#v", m["owner"])
+	}
+
+	details := m["source_detail"].(*schema.Set)
+	if details.Len() != 1 {
+		t.Fatalf("expected 1 source detail, got %d", details.Len())
+	}
+
+	// The flattened source must expand back to the same source
+	expanded := expandConfigRuleSource(result)
+	if !reflect.DeepEqual(expanded, source) {
+		t.Fatalf("Got:\n\n%#v\n\nExpected:\n\n%#v\n", expanded, source)
+	}
+}
+
+func TestFlattenConfigRecordingGroup(t *testing.T) {
+	group := &configservice.RecordingGroup{
+		AllSupported: aws.Bool(false),
+		ResourceTypes: []*string{
+			aws.String("AWS::EC2::Instance"),
+			aws.String("AWS::CloudTrail::Trail"),
+		},
+	}
+
+	result := flattenConfigRecordingGroup(group)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 recording group, got %d", len(result))
+	}
+
+	if result[0]["all_supported"] != false {
+		t.Fatalf("bad all_supported: %#v", result[0]["all_supported"])
+	}
+	if _, ok := result[0]["include_global_resource_types"]; ok {
+		t.Fatalf("expected include_global_resource_types to be unset")
+	}
+	if result[0]["resource_types"].(*schema.Set).Len() != 2 {
+		t.Fatalf("bad resource_types: %#v", result[0]["resource_types"])
+	}
+}
@@ -17,6 +17,86 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// addDefaultTags makes every resource with tags merge the default tags of
+// the provider into them while planning. The tags become computed, since
+// their new value depends on the provider configuration.
+func addDefaultTags(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		s, ok := r.Schema["tags"]
+		if !ok || s.Type != schema.TypeMap || !s.Optional {
+			continue
+		}
+
+		s.Computed = true
+		r.CustomizeDiff = defaultTagsCustomizeDiff(s, r.CustomizeDiff)
+	}
+}
+
+// defaultTagsCustomizeDiff returns a CustomizeDiffFunc that sets the new
+// value of the tags to the configured tags merged with the default tags,
+// and then calls the given CustomizeDiffFunc, if any.
+func defaultTagsCustomizeDiff(
+	s *schema.Schema, next schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if err := setDefaultTags(d, s, meta); err != nil {
+			return err
+		}
+
+		if next != nil {
+			return next(d, meta)
+		}
+
+		return nil
+	}
+}
+
+func setDefaultTags(d *schema.ResourceDiff, s *schema.Schema, meta interface{}) error {
+	// We can't merge tags that aren't known yet, so leave them alone
+	if !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	var defaults map[string]string
+	if client, ok := meta.(*AWSClient); ok {
+		defaults = client.defaultTags
+	}
+
+	raw, _ := d.GetConfig("tags")
+	tags, _ := raw.(map[string]interface{})
+	merged := mergeDefaultTags(defaults, tags)
+
+	// Don't add an empty map to resources that never had tags
+	old, _ := d.GetChange("tags")
+	if len(merged) == 0 && len(old.(map[string]interface{})) == 0 {
+		return d.Clear("tags")
+	}
+
+	if err := d.SetNew("tags", merged); err != nil {
+		return err
+	}
+
+	// Setting the new value drops the RequiresNew of the original diff
+	if s.ForceNew && d.HasChange("tags") {
+		return d.ForceNew("tags")
+	}
+
+	return nil
+}
+
+// mergeDefaultTags returns the given tags merged with the default tags,
+// where the tags take precedence.
+func mergeDefaultTags(defaults map[string]string, tags map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(defaults)+len(tags))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range tags {
+		result[k] = v
+	}
+
+	return result
+}
+
 // setTags is a helper to set the tags for a resource. It expects the
 // tags field to be named "tags"
 func setTags(conn *ec2.EC2, d *schema.ResourceData) error {
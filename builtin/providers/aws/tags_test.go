@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/xanzy/terraform-api/config"
 	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
@@ -62,6 +64,110 @@ func TestDiffTags(t *testing.T) {
 }
 
 // testAccCheckTags can be used to check the tags on a resource.
+func TestDefaultTags(t *testing.T) {
+	cases := []struct {
+		Defaults map[string]string
+		Config   map[string]interface{}
+		State    map[string]string
+		Expected map[string]string
+	}{
+		// Defaults are added to new resources
+		{
+			Defaults: map[string]string{"Env": "prod"},
+			Config:   map[string]interface{}{"Name": "foo"},
+			Expected: map[string]string{
+				"tags.#":    "2",
+				"tags.Env":  "prod",
+				"tags.Name": "foo",
+			},
+		},
+
+		// Tags of the resource take precedence
+		{
+			Defaults: map[string]string{"Env": "prod"},
+			Config:   map[string]interface{}{"Env": "dev"},
+			State: map[string]string{
+				"tags.#":   "1",
+				"tags.Env": "prod",
+			},
+			Expected: map[string]string{
+				"tags.Env": "dev",
+			},
+		},
+
+		// No diff if the defaults are already set
+		{
+			Defaults: map[string]string{"Env": "prod"},
+			Config:   map[string]interface{}{"Name": "foo"},
+			State: map[string]string{
+				"tags.#":    "2",
+				"tags.Env":  "prod",
+				"tags.Name": "foo",
+			},
+			Expected: map[string]string{},
+		},
+
+		// Removed defaults are removed from the resource
+		{
+			Config: map[string]interface{}{"Name": "foo"},
+			State: map[string]string{
+				"tags.#":    "2",
+				"tags.Env":  "prod",
+				"tags.Name": "foo",
+			},
+			Expected: map[string]string{
+				"tags.#":   "1",
+				"tags.Env": "",
+			},
+		},
+
+		// No tags at all
+		{
+			Expected: map[string]string{},
+		},
+	}
+
+	for i, tc := range cases {
+		resources := map[string]*schema.Resource{
+			"test": &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"tags": tagsSchema(),
+				},
+			},
+		}
+		addDefaultTags(resources)
+
+		raw, err := config.NewRawConfig(map[string]interface{}{})
+		if tc.Config != nil {
+			raw, err = config.NewRawConfig(map[string]interface{}{"tags": tc.Config})
+		}
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		var state *terraform.InstanceState
+		if tc.State != nil {
+			state = &terraform.InstanceState{ID: "foo", Attributes: tc.State}
+		}
+
+		meta := &AWSClient{defaultTags: tc.Defaults}
+		diff, err := resources["test"].Diff(state, terraform.NewResourceConfig(raw), meta)
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+
+		actual := make(map[string]string)
+		if diff != nil {
+			for k, attr := range diff.Attributes {
+				actual[k] = attr.New
+			}
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
 func testAccCheckTags(
 	ts *[]*ec2.Tag, key string, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
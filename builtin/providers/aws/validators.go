@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/xanzy/terraform-api/helper/validation"
 )
 
 func validateRdsId(v interface{}, k string) (ws []string, errors []error) {
@@ -49,6 +51,48 @@ func validateTagFilters(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// validateConfigExecutionFrequency confirms the frequency is one of the
+// intervals AWS Config can deliver snapshots and evaluate rules at.
+func validateConfigExecutionFrequency(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	allowedFrequencies := []string{
+		"One_Hour",
+		"Three_Hours",
+		"Six_Hours",
+		"Twelve_Hours",
+		"TwentyFour_Hours",
+	}
+	for _, f := range allowedFrequencies {
+		if value == f {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf(
+		"%q contains an invalid frequency %q. Valid frequencies are %q.",
+		k, value, allowedFrequencies))
+	return
+}
+
+// validateTriggerEvent confirms a CodeDeploy trigger event is one of the
+// event types AWS can notify about.
+func validateTriggerEvent(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	triggerEvents := map[string]bool{
+		"DeploymentStart":   true,
+		"DeploymentStop":    true,
+		"DeploymentSuccess": true,
+		"DeploymentFailure": true,
+		"InstanceStart":     true,
+		"InstanceSuccess":   true,
+		"InstanceFailure":   true,
+	}
+
+	if !triggerEvents[value] {
+		errors = append(errors, fmt.Errorf("%q must be a valid event type value: %q", k, value))
+	}
+	return
+}
+
 func validateDbParamGroupName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !regexp.MustCompile(`^[0-9a-z-]+$`).MatchString(value) {
@@ -113,6 +157,11 @@ func validateElbName(v interface{}, k string) (ws []string, errors []error) {
 
 }
 
+// validateElbPolicyName validates the name of a load balancer policy.
+var validateElbPolicyName = validation.StringMatch(
+	regexp.MustCompile(`^[0-9A-Za-z-]+$`),
+	"can only contain alphanumeric characters and hyphens")
+
 func validateEcrRepositoryName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if len(value) < 2 {
@@ -134,3 +183,23 @@ func validateEcrRepositoryName(v interface{}, k string) (ws []string, errors []e
 
 	return
 }
+
+// validateS3BucketLifecycleTimestamp validates a date of a lifecycle rule,
+// which S3 only accepts at midnight UTC.
+func validateS3BucketLifecycleTimestamp(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	_, err := time.Parse(time.RFC3339, fmt.Sprintf("%sT00:00:00Z", value))
+	if err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be parsed as a date in the format YYYY-MM-DD: %q", k, value))
+	}
+
+	return
+}
+
+// validateS3BucketLifecycleStorageClass validates the storage class of a
+// lifecycle transition.
+var validateS3BucketLifecycleStorageClass = validation.StringInSlice([]string{
+	"STANDARD_IA",
+	"GLACIER",
+}, false)
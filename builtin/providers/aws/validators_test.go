@@ -43,3 +43,105 @@ func TestValidateEcrRepositoryName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateElbPolicyName(t *testing.T) {
+	validNames := []string{
+		"foo-policy",
+		"Policy1",
+	}
+	for _, v := range validNames {
+		_, errors := validateElbPolicyName(v, "name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid policy name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"foo_policy",
+		"foo policy",
+	}
+	for _, v := range invalidNames {
+		_, errors := validateElbPolicyName(v, "name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid policy name", v)
+		}
+	}
+}
+
+func TestValidateConfigExecutionFrequency(t *testing.T) {
+	validFrequencies := []string{
+		"One_Hour",
+		"Three_Hours",
+		"TwentyFour_Hours",
+	}
+	for _, v := range validFrequencies {
+		_, errors := validateConfigExecutionFrequency(v, "delivery_frequency")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid frequency: %q", v, errors)
+		}
+	}
+
+	invalidFrequencies := []string{
+		"",
+		"one_hour",
+		"Two_Hours",
+	}
+	for _, v := range invalidFrequencies {
+		_, errors := validateConfigExecutionFrequency(v, "delivery_frequency")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid frequency", v)
+		}
+	}
+}
+
+func TestValidateTriggerEvent(t *testing.T) {
+	validEvents := []string{
+		"DeploymentStart",
+		"DeploymentFailure",
+		"InstanceSuccess",
+	}
+	for _, v := range validEvents {
+		_, errors := validateTriggerEvent(v, "trigger_events")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid trigger event: %q", v, errors)
+		}
+	}
+
+	invalidEvents := []string{
+		"",
+		"deploymentstart",
+		"DeploymentRollback",
+	}
+	for _, v := range invalidEvents {
+		_, errors := validateTriggerEvent(v, "trigger_events")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid trigger event", v)
+		}
+	}
+}
+
+func TestValidateS3BucketLifecycleTimestamp(t *testing.T) {
+	validDates := []string{
+		"2016-01-01",
+		"2006-01-02",
+	}
+	for _, v := range validDates {
+		_, errors := validateS3BucketLifecycleTimestamp(v, "date")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid date: %q", v, errors)
+		}
+	}
+
+	invalidDates := []string{
+		"Jan 01 2016",
+		"2016-01-01T00:00:00Z",
+		"20160101",
+	}
+	for _, v := range invalidDates {
+		_, errors := validateS3BucketLifecycleTimestamp(v, "date")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid date", v)
+		}
+	}
+}
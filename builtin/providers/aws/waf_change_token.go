@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+// wafChangeTokenFunc is a mutation of the WAF API using the given change
+// token, returning the output of the request.
+type wafChangeTokenFunc func(token *string) (interface{}, error)
+
+// withWafChangeToken gets a new change token and calls fn with it. Every
+// mutation of the WAF API needs its own change token, which gets stale as
+// soon as another change token is requested, so fn is retried with a new
+// change token in that case.
+func withWafChangeToken(conn *waf.WAF, fn wafChangeTokenFunc) (interface{}, error) {
+	var out interface{}
+	err := resource.Retry(1*time.Minute, func() error {
+		resp, err := conn.GetChangeToken(&waf.GetChangeTokenInput{})
+		if err != nil {
+			return resource.RetryError{Err: err}
+		}
+
+		out, err = fn(resp.ChangeToken)
+		if err != nil {
+			if isWafStaleData(err) {
+				log.Printf("[DEBUG] WAF change token is stale, retrying: %s", err)
+				return err
+			}
+			return resource.RetryError{Err: err}
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+func isWafStaleData(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "WAFStaleDataException"
+}
+
+func isWafNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "WAFNonexistentItemException"
+}
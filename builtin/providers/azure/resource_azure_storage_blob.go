@@ -78,7 +78,7 @@ func resourceAzureStorageBlobCreate(d *schema.ResourceData, meta interface{}) er
 		err = blobClient.CreateBlockBlob(cont, name)
 	case "PageBlob":
 		size := int64(d.Get("size").(int))
-		err = blobClient.PutPageBlob(cont, name, size)
+		err = blobClient.PutPageBlob(cont, name, size, nil)
 	default:
 		err = fmt.Errorf("Invalid blob type specified; see parameter desciptions for more info.")
 	}
@@ -163,7 +163,7 @@ func resourceAzureStorageBlobDelete(d *schema.ResourceData, meta interface{}) er
 	log.Println("[INFO] Issuing storage blob delete command off Azure.")
 	name := d.Get("name").(string)
 	cont := d.Get("storage_container_name").(string)
-	if _, err = blobClient.DeleteBlobIfExists(cont, name); err != nil {
+	if _, err = blobClient.DeleteBlobIfExists(cont, name, nil); err != nil {
 		return fmt.Errorf("Error whilst deleting storage blob: %s", err)
 	}
 
@@ -0,0 +1,678 @@
+package azurerm
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceArmVirtualMachine() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualMachineCreate,
+		Read:   resourceArmVirtualMachineRead,
+		Update: resourceArmVirtualMachineCreate,
+		Delete: resourceArmVirtualMachineDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"location": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: azureRMNormalizeLocation,
+			},
+
+			"resource_group_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"availability_set_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				StateFunc: func(id interface{}) string {
+					return strings.ToLower(id.(string))
+				},
+			},
+
+			"vm_size": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"storage_image_reference": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"publisher": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"offer": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"sku": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"version": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"storage_os_disk": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"vhd_uri": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"create_option": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"caching": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"os_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"storage_data_disk": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"vhd_uri": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"create_option": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"disk_size_gb": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validateDiskSizeGB,
+						},
+
+						"lun": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"os_profile": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"computer_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"admin_username": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"admin_password": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+
+						"custom_data": &schema.Schema{
+							Type:      schema.TypeString,
+							Optional:  true,
+							StateFunc: userDataStateFunc,
+						},
+					},
+				},
+			},
+
+			"os_profile_windows_config": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"os_profile_linux_config"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provision_vm_agent": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+
+						"enable_automatic_upgrades": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"os_profile_linux_config": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"os_profile_windows_config"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"disable_password_authentication": &schema.Schema{
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"ssh_keys": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"path": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"key_data": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"network_interface_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func validateDiskSizeGB(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < 1 || value > 1023 {
+		errors = append(errors, fmt.Errorf(
+			"The `disk_size_gb` can only be between 1 and 1023"))
+	}
+	return
+}
+
+// userDataStateFunc stores a hash of the custom data in the state instead of
+// the data itself, since it can be large and may contain secrets.
+func userDataStateFunc(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		hash := sha1.Sum([]byte(s))
+		return hex.EncodeToString(hash[:])
+	default:
+		return ""
+	}
+}
+
+func resourceArmVirtualMachineCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+	vmClient := client.vmClient
+
+	log.Printf("[INFO] preparing arguments for Azure ARM Virtual Machine creation.")
+
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	tags := d.Get("tags").(map[string]interface{})
+	expandedTags := expandTags(tags)
+
+	osDisk, err := expandAzureRmVirtualMachineOsDisk(d)
+	if err != nil {
+		return err
+	}
+	storageProfile := compute.StorageProfile{
+		OsDisk: osDisk,
+	}
+
+	if _, ok := d.GetOk("storage_image_reference"); ok {
+		storageProfile.ImageReference = expandAzureRmVirtualMachineImageReference(d)
+	}
+
+	if _, ok := d.GetOk("storage_data_disk"); ok {
+		dataDisks := expandAzureRmVirtualMachineDataDisk(d)
+		storageProfile.DataDisks = &dataDisks
+	}
+
+	osProfile, err := expandAzureRmVirtualMachineOsProfile(d)
+	if err != nil {
+		return err
+	}
+
+	properties := compute.VirtualMachineProperties{
+		NetworkProfile: expandAzureRmVirtualMachineNetworkProfile(d),
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(d.Get("vm_size").(string)),
+		},
+		StorageProfile: &storageProfile,
+		OsProfile:      osProfile,
+	}
+
+	if v, ok := d.GetOk("availability_set_id"); ok {
+		availabilitySet := v.(string)
+		properties.AvailabilitySet = &compute.SubResource{
+			ID: &availabilitySet,
+		}
+	}
+
+	vm := compute.VirtualMachine{
+		Name:       &name,
+		Location:   &location,
+		Properties: &properties,
+		Tags:       expandedTags,
+	}
+
+	resp, err := vmClient.CreateOrUpdate(resGroup, name, vm)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*resp.ID)
+
+	log.Printf("[DEBUG] Waiting for Virtual Machine (%s) to become available", name)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Creating", "Updating"},
+		Target:  "Succeeded",
+		Refresh: virtualMachineStateRefreshFunc(client, resGroup, name),
+		Timeout: 20 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Virtual Machine (%s) to become available: %s", name, err)
+	}
+
+	return resourceArmVirtualMachineRead(d, meta)
+}
+
+func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) error {
+	vmClient := meta.(*ArmClient).vmClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	resp, err := vmClient.Get(resGroup, name, "")
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error making Read request on Azure Virtual Machine %s: %s", name, err)
+	}
+
+	vm := *resp.Properties
+
+	if vm.AvailabilitySet != nil {
+		d.Set("availability_set_id", strings.ToLower(*vm.AvailabilitySet.ID))
+	}
+
+	if vm.HardwareProfile != nil {
+		d.Set("vm_size", string(vm.HardwareProfile.VMSize))
+	}
+
+	if vm.StorageProfile != nil {
+		if vm.StorageProfile.ImageReference != nil {
+			if err := d.Set("storage_image_reference", flattenAzureRmVirtualMachineImageReference(vm.StorageProfile.ImageReference)); err != nil {
+				return fmt.Errorf("Error setting Virtual Machine Storage Image Reference: %s", err)
+			}
+		}
+
+		if vm.StorageProfile.OsDisk != nil {
+			osType := d.Get("storage_os_disk.0.os_type").(string)
+			if err := d.Set("storage_os_disk", flattenAzureRmVirtualMachineOsDisk(vm.StorageProfile.OsDisk, osType)); err != nil {
+				return fmt.Errorf("Error setting Virtual Machine OS Disk: %s", err)
+			}
+		}
+
+		if vm.StorageProfile.DataDisks != nil {
+			if err := d.Set("storage_data_disk", flattenAzureRmVirtualMachineDataDisk(vm.StorageProfile.DataDisks)); err != nil {
+				return fmt.Errorf("Error setting Virtual Machine Data Disks: %s", err)
+			}
+		}
+	}
+
+	if vm.NetworkProfile != nil && vm.NetworkProfile.NetworkInterfaces != nil {
+		ids := make([]string, 0, len(*vm.NetworkProfile.NetworkInterfaces))
+		for _, iface := range *vm.NetworkProfile.NetworkInterfaces {
+			ids = append(ids, *iface.ID)
+		}
+		d.Set("network_interface_ids", ids)
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) error {
+	vmClient := meta.(*ArmClient).vmClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachines"]
+
+	_, err = vmClient.Delete(resGroup, name)
+
+	return err
+}
+
+func virtualMachineStateRefreshFunc(client *ArmClient, resourceGroupName string, vmName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.vmClient.Get(resourceGroupName, vmName, "")
+		if err != nil {
+			return nil, "", fmt.Errorf("Error issuing read request in virtualMachineStateRefreshFunc to Azure ARM for Virtual Machine '%s' (RG: '%s'): %s", vmName, resourceGroupName, err)
+		}
+
+		return res, *res.Properties.ProvisioningState, nil
+	}
+}
+
+func flattenAzureRmVirtualMachineImageReference(image *compute.ImageReference) []interface{} {
+	result := make(map[string]interface{})
+	result["publisher"] = *image.Publisher
+	result["offer"] = *image.Offer
+	result["sku"] = *image.Sku
+	if image.Version != nil {
+		result["version"] = *image.Version
+	}
+
+	return []interface{}{result}
+}
+
+// flattenAzureRmVirtualMachineOsDisk flattens the OS disk. The os_type is
+// optional and case insensitive, so the configured value is kept as long as
+// it matches what Azure reports.
+func flattenAzureRmVirtualMachineOsDisk(disk *compute.OSDisk, osType string) []interface{} {
+	result := make(map[string]interface{})
+	result["name"] = *disk.Name
+	result["vhd_uri"] = *disk.Vhd.URI
+	result["create_option"] = string(disk.CreateOption)
+	result["caching"] = string(disk.Caching)
+	result["os_type"] = osType
+	if osType != "" && !strings.EqualFold(osType, string(disk.OsType)) {
+		result["os_type"] = string(disk.OsType)
+	}
+
+	return []interface{}{result}
+}
+
+func flattenAzureRmVirtualMachineDataDisk(disks *[]compute.DataDisk) []interface{} {
+	result := make([]interface{}, 0, len(*disks))
+	for _, disk := range *disks {
+		l := make(map[string]interface{})
+		l["name"] = *disk.Name
+		l["vhd_uri"] = *disk.Vhd.URI
+		l["create_option"] = string(disk.CreateOption)
+		if disk.DiskSizeGB != nil {
+			l["disk_size_gb"] = *disk.DiskSizeGB
+		}
+		l["lun"] = *disk.Lun
+
+		result = append(result, l)
+	}
+
+	return result
+}
+
+func expandAzureRmVirtualMachineOsProfile(d *schema.ResourceData) (*compute.OSProfile, error) {
+	osProfile := d.Get("os_profile").([]interface{})[0].(map[string]interface{})
+
+	adminUsername := osProfile["admin_username"].(string)
+	adminPassword := osProfile["admin_password"].(string)
+	computerName := osProfile["computer_name"].(string)
+
+	profile := &compute.OSProfile{
+		AdminUsername: &adminUsername,
+		AdminPassword: &adminPassword,
+		ComputerName:  &computerName,
+	}
+
+	// custom_data is only kept as a hash in the state, so it has to be read
+	// from the configuration and can't be changed without recreating the VM.
+	if v := osProfile["custom_data"].(string); v != "" {
+		customData := base64.StdEncoding.EncodeToString([]byte(v))
+		profile.CustomData = &customData
+	}
+
+	if _, ok := d.GetOk("os_profile_windows_config"); ok {
+		profile.WindowsConfiguration = expandAzureRmVirtualMachineOsProfileWindowsConfig(d)
+	}
+
+	if _, ok := d.GetOk("os_profile_linux_config"); ok {
+		profile.LinuxConfiguration = expandAzureRmVirtualMachineOsProfileLinuxConfig(d)
+	}
+
+	if profile.WindowsConfiguration == nil && profile.LinuxConfiguration == nil {
+		return nil, fmt.Errorf("Error: either a `os_profile_linux_config` or a `os_profile_windows_config` must be specified.")
+	}
+
+	return profile, nil
+}
+
+func expandAzureRmVirtualMachineOsProfileLinuxConfig(d *schema.ResourceData) *compute.LinuxConfiguration {
+	linuxConfig := d.Get("os_profile_linux_config").([]interface{})[0].(map[string]interface{})
+	disablePasswordAuth := linuxConfig["disable_password_authentication"].(bool)
+
+	config := &compute.LinuxConfiguration{
+		DisablePasswordAuthentication: &disablePasswordAuth,
+	}
+
+	linuxKeys := linuxConfig["ssh_keys"].([]interface{})
+	sshPublicKeys := make([]compute.SSHPublicKey, 0, len(linuxKeys))
+	for _, key := range linuxKeys {
+		sshKey := key.(map[string]interface{})
+		path := sshKey["path"].(string)
+		keyData := sshKey["key_data"].(string)
+
+		sshPublicKeys = append(sshPublicKeys, compute.SSHPublicKey{
+			Path:    &path,
+			KeyData: &keyData,
+		})
+	}
+
+	if len(sshPublicKeys) > 0 {
+		config.SSH = &compute.SSHConfiguration{
+			PublicKeys: &sshPublicKeys,
+		}
+	}
+
+	return config
+}
+
+func expandAzureRmVirtualMachineOsProfileWindowsConfig(d *schema.ResourceData) *compute.WindowsConfiguration {
+	config := &compute.WindowsConfiguration{}
+
+	raw := d.Get("os_profile_windows_config").([]interface{})
+	if raw[0] == nil {
+		return config
+	}
+	windowsConfig := raw[0].(map[string]interface{})
+
+	provision := windowsConfig["provision_vm_agent"].(bool)
+	config.ProvisionVMAgent = &provision
+
+	update := windowsConfig["enable_automatic_upgrades"].(bool)
+	config.EnableAutomaticUpdates = &update
+
+	return config
+}
+
+func expandAzureRmVirtualMachineDataDisk(d *schema.ResourceData) []compute.DataDisk {
+	disks := d.Get("storage_data_disk").([]interface{})
+	dataDisks := make([]compute.DataDisk, 0, len(disks))
+	for _, diskConfig := range disks {
+		config := diskConfig.(map[string]interface{})
+
+		name := config["name"].(string)
+		vhd := config["vhd_uri"].(string)
+		createOption := config["create_option"].(string)
+		lun := config["lun"].(int)
+		diskSize := config["disk_size_gb"].(int)
+
+		dataDisks = append(dataDisks, compute.DataDisk{
+			Name: &name,
+			Vhd: &compute.VirtualHardDisk{
+				URI: &vhd,
+			},
+			Lun:          &lun,
+			DiskSizeGB:   &diskSize,
+			CreateOption: compute.DiskCreateOptionTypes(createOption),
+		})
+	}
+
+	return dataDisks
+}
+
+func expandAzureRmVirtualMachineImageReference(d *schema.ResourceData) *compute.ImageReference {
+	storageImageRef := d.Get("storage_image_reference").([]interface{})[0].(map[string]interface{})
+
+	publisher := storageImageRef["publisher"].(string)
+	offer := storageImageRef["offer"].(string)
+	sku := storageImageRef["sku"].(string)
+	version := storageImageRef["version"].(string)
+	if version == "" {
+		version = "latest"
+	}
+
+	return &compute.ImageReference{
+		Publisher: &publisher,
+		Offer:     &offer,
+		Sku:       &sku,
+		Version:   &version,
+	}
+}
+
+func expandAzureRmVirtualMachineNetworkProfile(d *schema.ResourceData) *compute.NetworkProfile {
+	nicIds := d.Get("network_interface_ids").(*schema.Set).List()
+	networkInterfaces := make([]compute.NetworkInterfaceReference, 0, len(nicIds))
+
+	for _, nic := range nicIds {
+		id := nic.(string)
+		networkInterfaces = append(networkInterfaces, compute.NetworkInterfaceReference{
+			ID: &id,
+		})
+	}
+
+	// With more than one interface one of them has to be the primary one
+	if len(networkInterfaces) > 1 {
+		primary := true
+		networkInterfaces[0].Properties = &compute.NetworkInterfaceReferenceProperties{
+			Primary: &primary,
+		}
+	}
+
+	return &compute.NetworkProfile{
+		NetworkInterfaces: &networkInterfaces,
+	}
+}
+
+func expandAzureRmVirtualMachineOsDisk(d *schema.ResourceData) (*compute.OSDisk, error) {
+	disk := d.Get("storage_os_disk").([]interface{})[0].(map[string]interface{})
+
+	name := disk["name"].(string)
+	vhdURI := disk["vhd_uri"].(string)
+	createOption := disk["create_option"].(string)
+
+	osDisk := &compute.OSDisk{
+		Name: &name,
+		Vhd: &compute.VirtualHardDisk{
+			URI: &vhdURI,
+		},
+		CreateOption: compute.DiskCreateOptionTypes(createOption),
+	}
+
+	if v := disk["caching"].(string); v != "" {
+		osDisk.Caching = compute.CachingTypes(v)
+	}
+
+	if v := disk["os_type"].(string); v != "" {
+		switch strings.ToLower(v) {
+		case "linux":
+			osDisk.OsType = compute.Linux
+		case "windows":
+			osDisk.OsType = compute.Windows
+		default:
+			return nil, fmt.Errorf("[ERROR] os_type must be 'linux' or 'windows'")
+		}
+	}
+
+	return osDisk, nil
+}
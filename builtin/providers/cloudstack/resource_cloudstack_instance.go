@@ -56,6 +56,13 @@ func resourceCloudStackInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"affinity_group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"project": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -153,15 +160,14 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 		p.SetIpaddress(ipaddres.(string))
 	}
 
+	// If there are affinity group IDs supplied, add them to the parameter struct
+	if groups := d.Get("affinity_group_ids").(*schema.Set); groups.Len() > 0 {
+		p.SetAffinitygroupids(expandAffinityGroupIDs(groups))
+	}
+
 	// If there is a project supplied, we retrieve and set the project id
-	if project, ok := d.GetOk("project"); ok {
-		// Retrieve the project ID
-		projectid, e := retrieveID(cs, "project", project.(string))
-		if e != nil {
-			return e.Error()
-		}
-		// Set the default project ID
-		p.SetProjectid(projectid)
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
 	}
 
 	// If a keypair is supplied, add it to the parameter struct
@@ -229,6 +235,14 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("ipaddress", vm.Nic[0].Ipaddress)
 	//NB cloudstack sometimes sends back the wrong keypair name, so dont update it
 
+	groups := &schema.Set{F: schema.HashString}
+	for _, group := range vm.Affinitygroup {
+		groups.Add(group.Id)
+	}
+	if groups.Len() > 0 {
+		d.Set("affinity_group_ids", groups)
+	}
+
 	setValueOrID(d, "network", vm.Nic[0].Networkname, vm.Nic[0].Networkid)
 	setValueOrID(d, "service_offering", vm.Serviceofferingname, vm.Serviceofferingid)
 	setValueOrID(d, "template", vm.Templatename, vm.Templateid)
@@ -265,7 +279,8 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	// Attributes that require reboot to update
-	if d.HasChange("service_offering") || d.HasChange("keypair") {
+	if d.HasChange("service_offering") || d.HasChange("keypair") ||
+		d.HasChange("affinity_group_ids") {
 		// Before we can actually make these changes, the virtual machine must be stopped
 		_, err := cs.VirtualMachine.StopVirtualMachine(
 			cs.VirtualMachine.NewStopVirtualMachineParams(d.Id()))
@@ -310,6 +325,26 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 			d.SetPartial("keypair")
 		}
 
+		// Check if the affinity groups are changed and if so, update the groups
+		if d.HasChange("affinity_group_ids") {
+			log.Printf("[DEBUG] Affinity groups changed for %s, starting update", name)
+
+			// Create a new parameter struct
+			p := cs.AffinityGroup.NewUpdateVMAffinityGroupParams(d.Id())
+
+			// Set the new affinity groups
+			p.SetAffinitygroupids(
+				expandAffinityGroupIDs(d.Get("affinity_group_ids").(*schema.Set)))
+
+			// Update the affinity groups
+			_, err = cs.AffinityGroup.UpdateVMAffinityGroup(p)
+			if err != nil {
+				return fmt.Errorf(
+					"Error updating the affinity groups for instance %s: %s", name, err)
+			}
+			d.SetPartial("affinity_group_ids")
+		}
+
 		// Start the virtual machine again
 		_, err = cs.VirtualMachine.StartVirtualMachine(
 			cs.VirtualMachine.NewStartVirtualMachineParams(d.Id()))
@@ -347,3 +382,12 @@ func resourceCloudStackInstanceDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+func expandAffinityGroupIDs(groups *schema.Set) []string {
+	var ids []string
+	for _, group := range groups.List() {
+		ids = append(ids, group.(string))
+	}
+
+	return ids
+}
@@ -108,6 +108,27 @@ func TestAccCloudStackInstance_keyPair(t *testing.T) {
 	})
 }
 
+func TestAccCloudStackInstance_affinityGroup(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCloudStackInstance_affinityGroup,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "affinity_group_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCloudStackInstance_project(t *testing.T) {
 	var instance cloudstack.VirtualMachine
 
@@ -294,6 +315,27 @@ resource "cloudstack_instance" "foobar" {
 	CLOUDSTACK_TEMPLATE,
 	CLOUDSTACK_ZONE)
 
+var testAccCloudStackInstance_affinityGroup = fmt.Sprintf(`
+resource "cloudstack_affinity_group" "foo" {
+  name = "terraform-affinity-group"
+  type = "host anti-affinity"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform"
+  service_offering= "%s"
+  network = "%s"
+  template = "%s"
+  zone = "%s"
+  affinity_group_ids = ["${cloudstack_affinity_group.foo.id}"]
+  expunge = true
+}`,
+	CLOUDSTACK_SERVICE_OFFERING_1,
+	CLOUDSTACK_NETWORK_1,
+	CLOUDSTACK_TEMPLATE,
+	CLOUDSTACK_ZONE)
+
 var testAccCloudStackInstance_project = fmt.Sprintf(`
 resource "cloudstack_instance" "foobar" {
   name = "terraform-test"
@@ -30,6 +30,12 @@ func resourceCloudStackSSHKeyPair() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"private_key": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -62,6 +68,12 @@ func resourceCloudStackSSHKeyPairCreate(d *schema.ResourceData, meta interface{}
 		}
 
 		p := cs.SSH.NewRegisterSSHKeyPairParams(name, string(key))
+
+		// If there is a project supplied, we retrieve and set the project id
+		if err := setProjectid(p, cs, d); err != nil {
+			return err
+		}
+
 		_, err = cs.SSH.RegisterSSHKeyPair(p)
 		if err != nil {
 			return err
@@ -69,6 +81,12 @@ func resourceCloudStackSSHKeyPairCreate(d *schema.ResourceData, meta interface{}
 	} else {
 		// No key supplied, must create one and return the private key
 		p := cs.SSH.NewCreateSSHKeyPairParams(name)
+
+		// If there is a project supplied, we retrieve and set the project id
+		if err := setProjectid(p, cs, d); err != nil {
+			return err
+		}
+
 		r, err := cs.SSH.CreateSSHKeyPair(p)
 		if err != nil {
 			return err
@@ -90,6 +108,11 @@ func resourceCloudStackSSHKeyPairRead(d *schema.ResourceData, meta interface{})
 	p := cs.SSH.NewListSSHKeyPairsParams()
 	p.SetName(d.Id())
 
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
 	r, err := cs.SSH.ListSSHKeyPairs(p)
 	if err != nil {
 		return err
@@ -113,6 +136,11 @@ func resourceCloudStackSSHKeyPairDelete(d *schema.ResourceData, meta interface{}
 	// Create a new parameter struct
 	p := cs.SSH.NewDeleteSSHKeyPairParams(d.Id())
 
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
 	// Remove the SSH Keypair
 	_, err := cs.SSH.DeleteSSHKeyPair(p)
 	if err != nil {
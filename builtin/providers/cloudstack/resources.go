@@ -61,6 +61,8 @@ func retrieveID(cs *cloudstack.CloudStackClient, name, value string) (id string,
 		id, err = cs.NetworkOffering.GetNetworkOfferingID(value)
 	case "project":
 		id, err = cs.Project.GetProjectID(value)
+	case "affinity_group":
+		id, err = cs.AffinityGroup.GetAffinityGroupID(value)
 	case "vpc_offering":
 		id, err = cs.VPC.GetVPCOfferingID(value)
 	case "vpc":
@@ -123,6 +125,26 @@ func retrieveTemplateID(cs *cloudstack.CloudStackClient, zoneid, value string) (
 	return id, nil
 }
 
+// ProjectIDSetter is implemented by all parameter structs that can be
+// scoped to a project
+type ProjectIDSetter interface {
+	SetProjectid(string)
+}
+
+// setProjectid retrieves the ID of the configured project (if any) and sets
+// it on the given parameter struct
+func setProjectid(p ProjectIDSetter, cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	if project, ok := d.GetOk("project"); ok {
+		projectid, e := retrieveID(cs, "project", project.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetProjectid(projectid)
+	}
+
+	return nil
+}
+
 // ID can be either a UUID or a UnlimitedResourceID
 func isID(id string) bool {
 	re := regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|-1)$`)
@@ -0,0 +1,128 @@
+package consul
+
+import (
+	"fmt"
+	"log"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceConsulACLToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsulACLTokenCreate,
+		Update: resourceConsulACLTokenUpdate,
+		Read:   resourceConsulACLTokenRead,
+		Delete: resourceConsulACLTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  consulapi.ACLClientType,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					value := v.(string)
+					if value != consulapi.ACLClientType && value != consulapi.ACLManagementType {
+						es = append(es, fmt.Errorf(
+							"%q must be either %q or %q", k, consulapi.ACLClientType, consulapi.ACLManagementType))
+					}
+					return
+				},
+			},
+
+			"rules": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceConsulACLTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wOpts := &consulapi.WriteOptions{Datacenter: d.Get("datacenter").(string)}
+
+	acl := &consulapi.ACLEntry{
+		Name:  d.Get("name").(string),
+		Type:  d.Get("type").(string),
+		Rules: d.Get("rules").(string),
+	}
+
+	log.Printf("[DEBUG] Creating Consul ACL token %q", acl.Name)
+	id, _, err := client.ACL().Create(acl, wOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to create Consul ACL token %q: %v", acl.Name, err)
+	}
+
+	d.SetId(id)
+
+	return resourceConsulACLTokenRead(d, meta)
+}
+
+func resourceConsulACLTokenUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wOpts := &consulapi.WriteOptions{Datacenter: d.Get("datacenter").(string)}
+
+	acl := &consulapi.ACLEntry{
+		ID:    d.Id(),
+		Name:  d.Get("name").(string),
+		Type:  d.Get("type").(string),
+		Rules: d.Get("rules").(string),
+	}
+
+	log.Printf("[DEBUG] Updating Consul ACL token %q", acl.Name)
+	if _, err := client.ACL().Update(acl, wOpts); err != nil {
+		return fmt.Errorf("Failed to update Consul ACL token %q: %v", acl.Name, err)
+	}
+
+	return resourceConsulACLTokenRead(d, meta)
+}
+
+func resourceConsulACLTokenRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	qOpts := &consulapi.QueryOptions{Datacenter: d.Get("datacenter").(string)}
+
+	acl, _, err := client.ACL().Info(d.Id(), qOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to read Consul ACL token %q: %v", d.Get("name").(string), err)
+	}
+	if acl == nil {
+		log.Printf("[WARN] Consul ACL token %q not found, removing it from state", d.Get("name").(string))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", acl.Name)
+	d.Set("type", acl.Type)
+	d.Set("rules", acl.Rules)
+
+	return nil
+}
+
+func resourceConsulACLTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wOpts := &consulapi.WriteOptions{Datacenter: d.Get("datacenter").(string)}
+
+	log.Printf("[DEBUG] Deleting Consul ACL token %q", d.Get("name").(string))
+	if _, err := client.ACL().Destroy(d.Id(), wOpts); err != nil {
+		return fmt.Errorf("Failed to delete Consul ACL token %q: %v", d.Get("name").(string), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,76 @@
+package consul
+
+import (
+	"fmt"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccConsulACLToken_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() {},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConsulACLTokenDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConsulACLTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulACLTokenExists("consul_acl_token.foo"),
+					resource.TestCheckResourceAttr("consul_acl_token.foo", "name", "foo"),
+					resource.TestCheckResourceAttr("consul_acl_token.foo", "type", "client"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConsulACLTokenDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*consulapi.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "consul_acl_token" {
+			continue
+		}
+
+		acl, _, err := client.ACL().Info(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+		if acl != nil {
+			return fmt.Errorf("ACL token %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckConsulACLTokenExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*consulapi.Client)
+		acl, _, err := client.ACL().Info(rs.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+		if acl == nil {
+			return fmt.Errorf("ACL token %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccConsulACLTokenConfig = `
+resource "consul_acl_token" "foo" {
+	name = "foo"
+	type = "client"
+	rules = "key \"\" { policy = \"read\" }"
+}
+`
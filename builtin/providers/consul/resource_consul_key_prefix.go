@@ -0,0 +1,240 @@
+package consul
+
+import (
+	"fmt"
+	"log"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceConsulKeyPrefix() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsulKeyPrefixCreate,
+		Update: resourceConsulKeyPrefixUpdate,
+		Read:   resourceConsulKeyPrefixRead,
+		Delete: resourceConsulKeyPrefixDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"path_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subkeys": &schema.Schema{
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceConsulKeyPrefixCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	kv := client.KV()
+
+	// Resolve the datacenter first, all the other keys are dependent
+	// on this.
+	var dc string
+	if v, ok := d.GetOk("datacenter"); ok {
+		dc = v.(string)
+		log.Printf("[DEBUG] Consul datacenter: %s", dc)
+	} else {
+		log.Printf("[DEBUG] Resolving Consul datacenter...")
+		var err error
+		dc, err = getDC(client)
+		if err != nil {
+			return err
+		}
+	}
+	var token string
+	if v, ok := d.GetOk("token"); ok {
+		token = v.(string)
+	}
+
+	// Setup the operations using the datacenter
+	qOpts := consulapi.QueryOptions{Datacenter: dc, Token: token}
+	wOpts := consulapi.WriteOptions{Datacenter: dc, Token: token}
+
+	pathPrefix := d.Get("path_prefix").(string)
+
+	// This resource manages the whole prefix, so refuse to take over a
+	// prefix that already contains keys we'd otherwise silently delete.
+	pairs, _, err := kv.List(pathPrefix, &qOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to list Consul keys under prefix '%s': %v", pathPrefix, err)
+	}
+	if len(pairs) > 0 {
+		return fmt.Errorf(
+			"%d keys already exist under '%s'; delete them before managing "+
+				"this prefix with Terraform", len(pairs), pathPrefix)
+	}
+
+	// Set the ID before writing, so a partial failure still lets Terraform
+	// clean up the keys that were written.
+	d.SetId(pathPrefix)
+	d.Set("datacenter", dc)
+
+	subKeys := d.Get("subkeys").(map[string]interface{})
+	for k, v := range subKeys {
+		path := pathPrefix + k
+		log.Printf("[DEBUG] Setting key '%s' in %s", path, dc)
+		pair := consulapi.KVPair{Key: path, Value: []byte(v.(string))}
+		if _, err := kv.Put(&pair, &wOpts); err != nil {
+			return fmt.Errorf("Failed to set Consul key '%s': %v", path, err)
+		}
+	}
+
+	return resourceConsulKeyPrefixRead(d, meta)
+}
+
+func resourceConsulKeyPrefixUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	kv := client.KV()
+
+	// Get the DC, error if not available.
+	var dc string
+	if v, ok := d.GetOk("datacenter"); ok {
+		dc = v.(string)
+		log.Printf("[DEBUG] Consul datacenter: %s", dc)
+	} else {
+		return fmt.Errorf("Missing datacenter configuration")
+	}
+	var token string
+	if v, ok := d.GetOk("token"); ok {
+		token = v.(string)
+	}
+
+	// Setup the operations using the datacenter
+	wOpts := consulapi.WriteOptions{Datacenter: dc, Token: token}
+
+	pathPrefix := d.Id()
+
+	if d.HasChange("subkeys") {
+		o, n := d.GetChange("subkeys")
+		if o == nil {
+			o = map[string]interface{}{}
+		}
+		if n == nil {
+			n = map[string]interface{}{}
+		}
+		om := o.(map[string]interface{})
+		nm := n.(map[string]interface{})
+
+		// First delete any keys that are no longer configured
+		for k := range om {
+			if _, ok := nm[k]; ok {
+				continue
+			}
+
+			path := pathPrefix + k
+			log.Printf("[DEBUG] Deleting key '%s' in %s", path, dc)
+			if _, err := kv.Delete(path, &wOpts); err != nil {
+				return fmt.Errorf("Failed to delete Consul key '%s': %v", path, err)
+			}
+		}
+
+		// Then set all keys that are new or have changed
+		for k, v := range nm {
+			if ov, ok := om[k]; ok && ov.(string) == v.(string) {
+				continue
+			}
+
+			path := pathPrefix + k
+			log.Printf("[DEBUG] Setting key '%s' in %s", path, dc)
+			pair := consulapi.KVPair{Key: path, Value: []byte(v.(string))}
+			if _, err := kv.Put(&pair, &wOpts); err != nil {
+				return fmt.Errorf("Failed to set Consul key '%s': %v", path, err)
+			}
+		}
+	}
+
+	return resourceConsulKeyPrefixRead(d, meta)
+}
+
+func resourceConsulKeyPrefixRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	kv := client.KV()
+
+	// Get the DC, error if not available.
+	var dc string
+	if v, ok := d.GetOk("datacenter"); ok {
+		dc = v.(string)
+		log.Printf("[DEBUG] Consul datacenter: %s", dc)
+	} else {
+		return fmt.Errorf("Missing datacenter configuration")
+	}
+	var token string
+	if v, ok := d.GetOk("token"); ok {
+		token = v.(string)
+	}
+
+	// Setup the operations using the datacenter
+	qOpts := consulapi.QueryOptions{Datacenter: dc, Token: token}
+
+	pathPrefix := d.Id()
+
+	log.Printf("[DEBUG] Refreshing keys under prefix '%s' in %s", pathPrefix, dc)
+	pairs, _, err := kv.List(pathPrefix, &qOpts)
+	if err != nil {
+		return fmt.Errorf("Failed to list Consul keys under prefix '%s': %v", pathPrefix, err)
+	}
+
+	// Every key under the prefix is recorded, so keys created outside of
+	// Terraform show up in the diff and are removed on the next apply.
+	subKeys := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		subKeys[pair.Key[len(pathPrefix):]] = string(pair.Value)
+	}
+
+	d.Set("path_prefix", pathPrefix)
+	d.Set("subkeys", subKeys)
+
+	return nil
+}
+
+func resourceConsulKeyPrefixDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+	kv := client.KV()
+
+	// Get the DC, error if not available.
+	var dc string
+	if v, ok := d.GetOk("datacenter"); ok {
+		dc = v.(string)
+		log.Printf("[DEBUG] Consul datacenter: %s", dc)
+	} else {
+		return fmt.Errorf("Missing datacenter configuration")
+	}
+	var token string
+	if v, ok := d.GetOk("token"); ok {
+		token = v.(string)
+	}
+
+	// Setup the operations using the datacenter
+	wOpts := consulapi.WriteOptions{Datacenter: dc, Token: token}
+
+	pathPrefix := d.Id()
+
+	log.Printf("[DEBUG] Deleting all keys under prefix '%s' in %s", pathPrefix, dc)
+	if _, err := kv.DeleteTree(pathPrefix, &wOpts); err != nil {
+		return fmt.Errorf("Failed to delete Consul keys under prefix '%s': %v", pathPrefix, err)
+	}
+
+	// Clear the ID
+	d.SetId("")
+	return nil
+}
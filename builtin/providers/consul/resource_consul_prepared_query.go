@@ -0,0 +1,251 @@
+package consul
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceConsulPreparedQuery() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConsulPreparedQueryCreate,
+		Update: resourceConsulPreparedQueryUpdate,
+		Read:   resourceConsulPreparedQueryRead,
+		Delete: resourceConsulPreparedQueryDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"stored_token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"session": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"service": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"near": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"only_passing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"failover": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nearest_n": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"datacenters": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"dns": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ttl": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceConsulPreparedQueryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	pq := preparedQueryDefinitionFromResourceData(d)
+
+	log.Printf("[DEBUG] Creating Consul prepared query %q", pq.Name)
+	id, _, err := client.PreparedQuery().Create(pq, wo)
+	if err != nil {
+		return fmt.Errorf("Failed to create Consul prepared query %q: %v", pq.Name, err)
+	}
+
+	d.SetId(id)
+
+	return resourceConsulPreparedQueryRead(d, meta)
+}
+
+func resourceConsulPreparedQueryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	pq := preparedQueryDefinitionFromResourceData(d)
+
+	log.Printf("[DEBUG] Updating Consul prepared query %q", pq.Name)
+	if _, err := client.PreparedQuery().Update(pq, wo); err != nil {
+		return fmt.Errorf("Failed to update Consul prepared query %q: %v", pq.Name, err)
+	}
+
+	return resourceConsulPreparedQueryRead(d, meta)
+}
+
+func resourceConsulPreparedQueryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	qo := &consulapi.QueryOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	queries, _, err := client.PreparedQuery().Get(d.Id(), qo)
+	if err != nil {
+		// The API doesn't return a typed error for missing queries
+		if strings.Contains(err.Error(), "Query not found") {
+			log.Printf("[WARN] Consul prepared query %q not found, removing it from state", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Failed to read Consul prepared query %q: %v", d.Get("name").(string), err)
+	}
+
+	if len(queries) != 1 {
+		d.SetId("")
+		return nil
+	}
+	pq := queries[0]
+
+	d.Set("name", pq.Name)
+	d.Set("session", pq.Session)
+	d.Set("stored_token", pq.Token)
+	d.Set("service", pq.Service.Service)
+	d.Set("near", pq.Service.Near)
+	d.Set("only_passing", pq.Service.OnlyPassing)
+	d.Set("tags", pq.Service.Tags)
+
+	if pq.Service.Failover.NearestN > 0 || len(pq.Service.Failover.Datacenters) > 0 {
+		d.Set("failover", []map[string]interface{}{
+			map[string]interface{}{
+				"nearest_n":   pq.Service.Failover.NearestN,
+				"datacenters": pq.Service.Failover.Datacenters,
+			},
+		})
+	}
+
+	if pq.DNS.TTL != "" {
+		d.Set("dns", []map[string]interface{}{
+			map[string]interface{}{
+				"ttl": pq.DNS.TTL,
+			},
+		})
+	}
+
+	return nil
+}
+
+func resourceConsulPreparedQueryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*consulapi.Client)
+
+	wo := &consulapi.WriteOptions{
+		Datacenter: d.Get("datacenter").(string),
+		Token:      d.Get("token").(string),
+	}
+
+	log.Printf("[DEBUG] Deleting Consul prepared query %q", d.Get("name").(string))
+	if _, err := client.PreparedQuery().Delete(d.Id(), wo); err != nil {
+		return fmt.Errorf("Failed to delete Consul prepared query %q: %v", d.Get("name").(string), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func preparedQueryDefinitionFromResourceData(d *schema.ResourceData) *consulapi.PreparedQueryDefinition {
+	pq := &consulapi.PreparedQueryDefinition{
+		ID:      d.Id(),
+		Name:    d.Get("name").(string),
+		Session: d.Get("session").(string),
+		Token:   d.Get("stored_token").(string),
+		Service: consulapi.ServiceQuery{
+			Service:     d.Get("service").(string),
+			Near:        d.Get("near").(string),
+			OnlyPassing: d.Get("only_passing").(bool),
+		},
+	}
+
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		pq.Service.Tags = append(pq.Service.Tags, tag.(string))
+	}
+
+	if _, ok := d.GetOk("failover.0"); ok {
+		failover := consulapi.QueryDatacenterOptions{
+			NearestN: d.Get("failover.0.nearest_n").(int),
+		}
+
+		for _, dc := range d.Get("failover.0.datacenters").([]interface{}) {
+			failover.Datacenters = append(failover.Datacenters, dc.(string))
+		}
+
+		pq.Service.Failover = failover
+	}
+
+	if _, ok := d.GetOk("dns.0"); ok {
+		pq.DNS = consulapi.QueryDNSOptions{
+			TTL: d.Get("dns.0.ttl").(string),
+		}
+	}
+
+	return pq
+}
@@ -0,0 +1,120 @@
+package consul
+
+import (
+	"fmt"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccConsulPreparedQuery_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() {},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConsulPreparedQueryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccConsulPreparedQueryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulPreparedQueryExists(),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "name", "foo"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "service", "redis"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "only_passing", "true"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "tags.#", "1"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "failover.0.nearest_n", "3"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "failover.0.datacenters.#", "2"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "dns.0.ttl", "8m"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccConsulPreparedQueryConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckConsulPreparedQueryExists(),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "service", "sql"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "only_passing", "false"),
+					resource.TestCheckResourceAttr("consul_prepared_query.foo", "dns.0.ttl", "16m"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckConsulPreparedQueryDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*consulapi.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "consul_prepared_query" {
+			continue
+		}
+
+		qo := &consulapi.QueryOptions{Datacenter: "nyc3"}
+		if _, _, err := client.PreparedQuery().Get(rs.Primary.ID, qo); err == nil {
+			return fmt.Errorf("Prepared query %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckConsulPreparedQueryExists() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["consul_prepared_query.foo"]
+		if !ok {
+			return fmt.Errorf("Resource not found")
+		}
+
+		client := testAccProvider.Meta().(*consulapi.Client)
+		qo := &consulapi.QueryOptions{Datacenter: "nyc3"}
+		queries, _, err := client.PreparedQuery().Get(rs.Primary.ID, qo)
+		if err != nil {
+			return err
+		}
+		if len(queries) != 1 {
+			return fmt.Errorf("Prepared query %q not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+const testAccConsulPreparedQueryConfig = `
+resource "consul_prepared_query" "foo" {
+	datacenter = "nyc3"
+	name = "foo"
+	service = "redis"
+	tags = ["prod"]
+	near = "_agent"
+	only_passing = true
+
+	failover {
+		nearest_n = 3
+		datacenters = ["dc1", "dc2"]
+	}
+
+	dns {
+		ttl = "8m"
+	}
+}
+`
+
+const testAccConsulPreparedQueryConfigUpdate = `
+resource "consul_prepared_query" "foo" {
+	datacenter = "nyc3"
+	name = "foo"
+	service = "sql"
+	tags = ["prod"]
+	near = "_agent"
+	only_passing = false
+
+	failover {
+		nearest_n = 3
+		datacenters = ["dc1", "dc2"]
+	}
+
+	dns {
+		ttl = "16m"
+	}
+}
+`
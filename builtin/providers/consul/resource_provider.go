@@ -26,10 +26,18 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"consul_keys": resourceConsulKeys(),
+			"consul_acl_token":      resourceConsulACLToken(),
+			"consul_keys":           resourceConsulKeys(),
+			"consul_key_prefix":     resourceConsulKeyPrefix(),
+			"consul_prepared_query": resourceConsulPreparedQuery(),
 		},
 
 		ConfigureFunc: providerConfigure,
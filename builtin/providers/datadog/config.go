@@ -0,0 +1,30 @@
+package datadog
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zorkian/go-datadog-api"
+)
+
+type Config struct {
+	APIKey string
+	APPKey string
+}
+
+// Client() returns a new client for accessing Datadog.
+func (c *Config) Client() (*datadog.Client, error) {
+	client := datadog.NewClient(c.APIKey, c.APPKey)
+
+	ok, err := client.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("Error validating Datadog keys: %s", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("Invalid or missing Datadog credentials")
+	}
+
+	log.Printf("[INFO] Datadog Client configured")
+
+	return client, nil
+}
@@ -0,0 +1,44 @@
+package datadog
+
+import (
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DATADOG_API_KEY", nil),
+				Description: "The API key of the Datadog organization.",
+			},
+
+			"app_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DATADOG_APP_KEY", nil),
+				Description: "The application key used for API operations.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"datadog_downtime":  resourceDatadogDowntime(),
+			"datadog_monitor":   resourceDatadogMonitor(),
+			"datadog_timeboard": resourceDatadogTimeboard(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		APIKey: d.Get("api_key").(string),
+		APPKey: d.Get("app_key").(string),
+	}
+
+	return config.Client()
+}
@@ -0,0 +1,245 @@
+package datadog
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func resourceDatadogDowntime() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatadogDowntimeCreate,
+		Read:   resourceDatadogDowntimeRead,
+		Update: resourceDatadogDowntimeUpdate,
+		Delete: resourceDatadogDowntimeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"scope": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// POSIX timestamps, an unset start means now and an unset end
+			// means forever.
+			"start": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"end": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"message": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"monitor_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"recurrence": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDatadogDowntimeRecurrenceType,
+						},
+
+						"period": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"week_days": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"until_date": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"until_occurrences": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func buildDowntimeStruct(d *schema.ResourceData) (*datadog.Downtime, error) {
+	var scope []string
+	for _, s := range d.Get("scope").([]interface{}) {
+		scope = append(scope, s.(string))
+	}
+
+	dt := &datadog.Downtime{
+		Scope:     scope,
+		Start:     d.Get("start").(int),
+		End:       d.Get("end").(int),
+		Message:   d.Get("message").(string),
+		MonitorId: d.Get("monitor_id").(int),
+	}
+
+	recurrences := d.Get("recurrence").([]interface{})
+	if len(recurrences) > 1 {
+		return nil, fmt.Errorf("Only one recurrence block can be set")
+	}
+	if len(recurrences) == 1 {
+		r := recurrences[0].(map[string]interface{})
+
+		var weekDays []string
+		for _, wd := range r["week_days"].([]interface{}) {
+			weekDays = append(weekDays, wd.(string))
+		}
+
+		dt.Recurrence = &datadog.Recurrence{
+			Type:             r["type"].(string),
+			Period:           r["period"].(int),
+			WeekDays:         weekDays,
+			UntilDate:        r["until_date"].(int),
+			UntilOccurrences: r["until_occurrences"].(int),
+		}
+	}
+
+	return dt, nil
+}
+
+func resourceDatadogDowntimeCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	dt, err := buildDowntimeStruct(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating Datadog downtime for scope: %v", dt.Scope)
+	dt, err = client.CreateDowntime(dt)
+	if err != nil {
+		return fmt.Errorf("Error creating Datadog downtime: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(dt.Id))
+
+	return resourceDatadogDowntimeRead(d, meta)
+}
+
+func resourceDatadogDowntimeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dt, err := client.GetDowntime(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Datadog downtime %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Datadog downtime %s: %s", d.Id(), err)
+	}
+
+	// A canceled downtime is kept around by the API, but is gone as far
+	// as we are concerned.
+	if dt.Canceled != 0 {
+		log.Printf("[WARN] Datadog downtime %s was canceled, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("scope", dt.Scope)
+	d.Set("start", dt.Start)
+	d.Set("end", dt.End)
+	d.Set("message", dt.Message)
+	d.Set("monitor_id", dt.MonitorId)
+	d.Set("active", dt.Active)
+
+	if r := dt.Recurrence; r != nil {
+		d.Set("recurrence", []map[string]interface{}{
+			map[string]interface{}{
+				"type":              r.Type,
+				"period":            r.Period,
+				"week_days":         r.WeekDays,
+				"until_date":        r.UntilDate,
+				"until_occurrences": r.UntilOccurrences,
+			},
+		})
+	} else {
+		d.Set("recurrence", nil)
+	}
+
+	return nil
+}
+
+func resourceDatadogDowntimeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dt, err := buildDowntimeStruct(d)
+	if err != nil {
+		return err
+	}
+	dt.Id = id
+
+	log.Printf("[DEBUG] Updating Datadog downtime: %s", d.Id())
+	if err := client.UpdateDowntime(dt); err != nil {
+		return fmt.Errorf("Error updating Datadog downtime %s: %s", d.Id(), err)
+	}
+
+	return resourceDatadogDowntimeRead(d, meta)
+}
+
+func resourceDatadogDowntimeDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting Datadog downtime: %s", d.Id())
+	if err := client.DeleteDowntime(id); err != nil {
+		return fmt.Errorf("Error deleting Datadog downtime %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func validateDatadogDowntimeRecurrenceType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "days", "months", "weeks", "years":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of days, weeks, months or years, got: %s", k, v))
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package datadog
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func TestAccDatadogDowntime_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatadogDowntimeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckDatadogDowntimeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogDowntimeExists("datadog_downtime.foo"),
+					resource.TestCheckResourceAttr(
+						"datadog_downtime.foo", "scope.0", "host:tf-test"),
+					resource.TestCheckResourceAttr(
+						"datadog_downtime.foo", "recurrence.0.type", "days"),
+					resource.TestCheckResourceAttr(
+						"datadog_downtime.foo", "recurrence.0.period", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogDowntimeExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No downtime ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*datadog.Client)
+		if _, err := client.GetDowntime(id); err != nil {
+			return fmt.Errorf("Error retrieving downtime %s: %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDatadogDowntimeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*datadog.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "datadog_downtime" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		dt, err := client.GetDowntime(id)
+		if err == nil && dt.Canceled == 0 {
+			return fmt.Errorf("Downtime %s still active", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDatadogDowntimeConfig = `
+resource "datadog_downtime" "foo" {
+  scope = ["host:tf-test"]
+  start = 1735707600
+  end = 1735765200
+  message = "tf-test downtime"
+
+  recurrence {
+    type = "days"
+    period = 1
+  }
+}
+`
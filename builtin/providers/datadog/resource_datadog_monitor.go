@@ -0,0 +1,293 @@
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func resourceDatadogMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatadogMonitorCreate,
+		Read:   resourceDatadogMonitorRead,
+		Update: resourceDatadogMonitorUpdate,
+		Delete: resourceDatadogMonitorDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"message": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+			},
+
+			"escalation_message": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+			},
+
+			"query": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// Valid keys are "ok", "warning" and "critical"
+			"thresholds": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"notify_no_data": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"no_data_timeframe": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"renotify_interval": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"notify_audit": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"timeout_h": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"include_tags": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"require_full_window": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"locked": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// Maps a scope to the POSIX timestamp the silence ends at, 0
+			// silences the scope indefinitely.
+			"silenced": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func buildMonitorStruct(d *schema.ResourceData) (*datadog.Monitor, error) {
+	var thresholds datadog.ThresholdCount
+	for k, v := range d.Get("thresholds").(map[string]interface{}) {
+		value := json.Number(fmt.Sprintf("%v", v))
+		switch k {
+		case "ok":
+			thresholds.Ok = value
+		case "warning":
+			thresholds.Warning = value
+		case "critical":
+			thresholds.Critical = value
+		default:
+			return nil, fmt.Errorf("Invalid threshold %q, must be one of ok, warning or critical", k)
+		}
+	}
+
+	silenced := make(map[string]int)
+	for k, v := range d.Get("silenced").(map[string]interface{}) {
+		ts, err := strconv.Atoi(fmt.Sprintf("%v", v))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid timestamp for silenced scope %q: %s", k, err)
+		}
+		silenced[k] = ts
+	}
+
+	o := datadog.Options{
+		Thresholds:        thresholds,
+		NotifyNoData:      d.Get("notify_no_data").(bool),
+		NoDataTimeframe:   datadog.NoDataTimeframe(d.Get("no_data_timeframe").(int)),
+		RenotifyInterval:  d.Get("renotify_interval").(int),
+		NotifyAudit:       d.Get("notify_audit").(bool),
+		TimeoutH:          d.Get("timeout_h").(int),
+		EscalationMessage: d.Get("escalation_message").(string),
+		IncludeTags:       d.Get("include_tags").(bool),
+		RequireFullWindow: d.Get("require_full_window").(bool),
+		Locked:            d.Get("locked").(bool),
+		Silenced:          silenced,
+	}
+
+	var tags []string
+	for _, t := range d.Get("tags").([]interface{}) {
+		tags = append(tags, t.(string))
+	}
+
+	m := &datadog.Monitor{
+		Type:    d.Get("type").(string),
+		Query:   d.Get("query").(string),
+		Name:    d.Get("name").(string),
+		Message: d.Get("message").(string),
+		Tags:    tags,
+		Options: o,
+	}
+
+	return m, nil
+}
+
+func resourceDatadogMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	m, err := buildMonitorStruct(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Creating Datadog monitor: %s", m.Name)
+	m, err = client.CreateMonitor(m)
+	if err != nil {
+		return fmt.Errorf("Error creating Datadog monitor: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(m.Id))
+
+	return resourceDatadogMonitorRead(d, meta)
+}
+
+func resourceDatadogMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	m, err := client.GetMonitor(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Datadog monitor %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Datadog monitor %s: %s", d.Id(), err)
+	}
+
+	thresholds := make(map[string]string)
+	if v := m.Options.Thresholds.Ok.String(); v != "" {
+		thresholds["ok"] = v
+	}
+	if v := m.Options.Thresholds.Warning.String(); v != "" {
+		thresholds["warning"] = v
+	}
+	if v := m.Options.Thresholds.Critical.String(); v != "" {
+		thresholds["critical"] = v
+	}
+
+	silenced := make(map[string]string)
+	for k, v := range m.Options.Silenced {
+		silenced[k] = strconv.Itoa(v)
+	}
+
+	d.Set("name", m.Name)
+	d.Set("message", m.Message)
+	d.Set("query", m.Query)
+	d.Set("type", m.Type)
+	d.Set("tags", m.Tags)
+	d.Set("thresholds", thresholds)
+	d.Set("silenced", silenced)
+	d.Set("notify_no_data", m.Options.NotifyNoData)
+	d.Set("no_data_timeframe", int(m.Options.NoDataTimeframe))
+	d.Set("renotify_interval", m.Options.RenotifyInterval)
+	d.Set("notify_audit", m.Options.NotifyAudit)
+	d.Set("timeout_h", m.Options.TimeoutH)
+	d.Set("escalation_message", m.Options.EscalationMessage)
+	d.Set("include_tags", m.Options.IncludeTags)
+	d.Set("require_full_window", m.Options.RequireFullWindow)
+	d.Set("locked", m.Options.Locked)
+
+	return nil
+}
+
+func resourceDatadogMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	m, err := buildMonitorStruct(d)
+	if err != nil {
+		return err
+	}
+	m.Id = id
+
+	log.Printf("[DEBUG] Updating Datadog monitor: %s", d.Id())
+	if err := client.UpdateMonitor(m); err != nil {
+		return fmt.Errorf("Error updating Datadog monitor %s: %s", d.Id(), err)
+	}
+
+	return resourceDatadogMonitorRead(d, meta)
+}
+
+func resourceDatadogMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting Datadog monitor: %s", d.Id())
+	if err := client.DeleteMonitor(id); err != nil {
+		return fmt.Errorf("Error deleting Datadog monitor %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// isNotFound returns true if the error returned by the Datadog API is a 404.
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "404 Not Found")
+}
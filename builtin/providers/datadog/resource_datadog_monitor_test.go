@@ -0,0 +1,128 @@
+package datadog
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func TestAccDatadogMonitor_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatadogMonitorDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckDatadogMonitorConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogMonitorExists("datadog_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "name", "name for monitor foo"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "type", "metric alert"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "thresholds.warning", "1"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "thresholds.critical", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckDatadogMonitorConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogMonitorExists("datadog_monitor.foo"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "name", "name for monitor bar"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "thresholds.critical", "3"),
+					resource.TestCheckResourceAttr(
+						"datadog_monitor.foo", "notify_no_data", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogMonitorExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No monitor ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*datadog.Client)
+		if _, err := client.GetMonitor(id); err != nil {
+			return fmt.Errorf("Error retrieving monitor %s: %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDatadogMonitorDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*datadog.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "datadog_monitor" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.GetMonitor(id); err == nil {
+			return fmt.Errorf("Monitor %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDatadogMonitorConfig = `
+resource "datadog_monitor" "foo" {
+  name = "name for monitor foo"
+  type = "metric alert"
+  message = "some message Notify: @hipchat-channel"
+  query = "avg(last_1h):avg:aws.ec2.cpu{environment:foo,host:foo} by {host} > 2"
+
+  thresholds {
+    warning = "1"
+    critical = "2"
+  }
+
+  tags = ["foo:bar", "baz"]
+}
+`
+
+const testAccCheckDatadogMonitorConfigUpdated = `
+resource "datadog_monitor" "foo" {
+  name = "name for monitor bar"
+  type = "metric alert"
+  message = "a different message Notify: @hipchat-channel"
+  query = "avg(last_1h):avg:aws.ec2.cpu{environment:foo,host:foo} by {host} > 3"
+
+  thresholds {
+    warning = "2"
+    critical = "3"
+  }
+
+  notify_no_data = true
+  renotify_interval = 40
+
+  tags = ["foo:bar"]
+}
+`
@@ -0,0 +1,240 @@
+package datadog
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func resourceDatadogTimeboard() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDatadogTimeboardCreate,
+		Read:   resourceDatadogTimeboardRead,
+		Update: resourceDatadogTimeboardUpdate,
+		Delete: resourceDatadogTimeboardDelete,
+
+		Schema: map[string]*schema.Schema{
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"read_only": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"graph": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"viz": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"request": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"q": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"stacked": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"template_variable": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"prefix": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"default": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildTimeboardStruct(d *schema.ResourceData) *datadog.Dashboard {
+	var graphs []datadog.Graph
+	for _, g := range d.Get("graph").([]interface{}) {
+		m := g.(map[string]interface{})
+
+		graph := datadog.Graph{Title: m["title"].(string)}
+		graph.Definition.Viz = m["viz"].(string)
+		for _, r := range m["request"].([]interface{}) {
+			rm := r.(map[string]interface{})
+			graph.Definition.Requests = append(graph.Definition.Requests,
+				datadog.GraphDefinitionRequest{
+					Query:   rm["q"].(string),
+					Stacked: rm["stacked"].(bool),
+				})
+		}
+
+		graphs = append(graphs, graph)
+	}
+
+	var templateVariables []datadog.TemplateVariable
+	for _, t := range d.Get("template_variable").([]interface{}) {
+		m := t.(map[string]interface{})
+		templateVariables = append(templateVariables, datadog.TemplateVariable{
+			Name:    m["name"].(string),
+			Prefix:  m["prefix"].(string),
+			Default: m["default"].(string),
+		})
+	}
+
+	return &datadog.Dashboard{
+		Title:             d.Get("title").(string),
+		Description:       d.Get("description").(string),
+		ReadOnly:          d.Get("read_only").(bool),
+		Graphs:            graphs,
+		TemplateVariables: templateVariables,
+	}
+}
+
+func resourceDatadogTimeboardCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	board := buildTimeboardStruct(d)
+
+	log.Printf("[DEBUG] Creating Datadog timeboard: %s", board.Title)
+	board, err := client.CreateDashboard(board)
+	if err != nil {
+		return fmt.Errorf("Error creating Datadog timeboard: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(board.Id))
+
+	return resourceDatadogTimeboardRead(d, meta)
+}
+
+func resourceDatadogTimeboardRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	board, err := client.GetDashboard(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Datadog timeboard %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Datadog timeboard %s: %s", d.Id(), err)
+	}
+
+	graphs := make([]map[string]interface{}, 0, len(board.Graphs))
+	for _, g := range board.Graphs {
+		requests := make([]map[string]interface{}, 0, len(g.Definition.Requests))
+		for _, r := range g.Definition.Requests {
+			requests = append(requests, map[string]interface{}{
+				"q":       r.Query,
+				"stacked": r.Stacked,
+			})
+		}
+
+		graphs = append(graphs, map[string]interface{}{
+			"title":   g.Title,
+			"viz":     g.Definition.Viz,
+			"request": requests,
+		})
+	}
+
+	templateVariables := make([]map[string]interface{}, 0, len(board.TemplateVariables))
+	for _, t := range board.TemplateVariables {
+		templateVariables = append(templateVariables, map[string]interface{}{
+			"name":    t.Name,
+			"prefix":  t.Prefix,
+			"default": t.Default,
+		})
+	}
+
+	d.Set("title", board.Title)
+	d.Set("description", board.Description)
+	d.Set("read_only", board.ReadOnly)
+	d.Set("graph", graphs)
+	d.Set("template_variable", templateVariables)
+
+	return nil
+}
+
+func resourceDatadogTimeboardUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	board := buildTimeboardStruct(d)
+	board.Id = id
+
+	log.Printf("[DEBUG] Updating Datadog timeboard: %s", d.Id())
+	if err := client.UpdateDashboard(board); err != nil {
+		return fmt.Errorf("Error updating Datadog timeboard %s: %s", d.Id(), err)
+	}
+
+	return resourceDatadogTimeboardRead(d, meta)
+}
+
+func resourceDatadogTimeboardDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*datadog.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting Datadog timeboard: %s", d.Id())
+	if err := client.DeleteDashboard(id); err != nil {
+		return fmt.Errorf("Error deleting Datadog timeboard %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,114 @@
+package datadog
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+	"github.com/zorkian/go-datadog-api"
+)
+
+func TestAccDatadogTimeboard_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDatadogTimeboardDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckDatadogTimeboardConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatadogTimeboardExists("datadog_timeboard.acceptance_test"),
+					resource.TestCheckResourceAttr(
+						"datadog_timeboard.acceptance_test", "title", "Acceptance Test Timeboard"),
+					resource.TestCheckResourceAttr(
+						"datadog_timeboard.acceptance_test", "graph.#", "2"),
+					resource.TestCheckResourceAttr(
+						"datadog_timeboard.acceptance_test", "graph.1.request.#", "2"),
+					resource.TestCheckResourceAttr(
+						"datadog_timeboard.acceptance_test", "template_variable.0.name", "host"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatadogTimeboardExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No timeboard ID is set")
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*datadog.Client)
+		if _, err := client.GetDashboard(id); err != nil {
+			return fmt.Errorf("Error retrieving timeboard %s: %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDatadogTimeboardDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*datadog.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "datadog_timeboard" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.GetDashboard(id); err == nil {
+			return fmt.Errorf("Timeboard %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckDatadogTimeboardConfig = `
+resource "datadog_timeboard" "acceptance_test" {
+  title = "Acceptance Test Timeboard"
+  description = "Created using the Datadog provider in Terraform"
+  read_only = true
+
+  graph {
+    title = "Top System CPU by Docker container"
+    viz = "toplist"
+    request {
+      q = "top(avg:docker.cpu.system{*} by {container_name}, 10, 'mean', 'desc')"
+    }
+  }
+
+  graph {
+    title = "Redis latency (ms)"
+    viz = "timeseries"
+    request {
+      q = "avg:redis.info.latency_ms{$host}"
+    }
+    request {
+      q = "avg:redis.info.latency_ms{$host}"
+      stacked = true
+    }
+  }
+
+  template_variable {
+    name = "host"
+    prefix = "host"
+  }
+}
+`
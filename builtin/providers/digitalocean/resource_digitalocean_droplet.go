@@ -102,6 +102,12 @@ func resourceDigitalOceanDroplet() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -135,6 +141,13 @@ func resourceDigitalOceanDropletCreate(d *schema.ResourceData, meta interface{})
 		opts.UserData = attr.(string)
 	}
 
+	// Get configured tags
+	if v, ok := d.GetOk("tags"); ok {
+		for _, tag := range v.([]interface{}) {
+			opts.Tags = append(opts.Tags, tag.(string))
+		}
+	}
+
 	// Get configured ssh_keys
 	sshKeys := d.Get("ssh_keys.#").(int)
 	if sshKeys > 0 {
@@ -223,6 +236,8 @@ func resourceDigitalOceanDropletRead(d *schema.ResourceData, meta interface{}) e
 		d.Set("ipv4_address_private", privateIPv4)
 	}
 
+	d.Set("tags", droplet.Tags)
+
 	// Initialize the connection info
 	d.SetConnInfo(map[string]string{
 		"type": "ssh",
@@ -372,6 +387,13 @@ func resourceDigitalOceanDropletUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("tags") {
+		err = setTags(client, d)
+		if err != nil {
+			return fmt.Errorf("Error updating tags: %s", err)
+		}
+	}
+
 	return resourceDigitalOceanDropletRead(d, meta)
 }
 
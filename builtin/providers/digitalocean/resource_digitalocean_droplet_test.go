@@ -71,6 +71,36 @@ func TestAccDigitalOceanDroplet_Update(t *testing.T) {
 	})
 }
 
+func TestAccDigitalOceanDroplet_UpdateTags(t *testing.T) {
+	var droplet godo.Droplet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDigitalOceanDropletDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckDigitalOceanDropletConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDigitalOceanDropletExists("digitalocean_droplet.foobar", &droplet),
+					testAccCheckDigitalOceanDropletAttributes(&droplet),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckDigitalOceanDropletConfig_tag_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDigitalOceanDropletExists("digitalocean_droplet.foobar", &droplet),
+					resource.TestCheckResourceAttr(
+						"digitalocean_droplet.foobar", "tags.#", "1"),
+					resource.TestCheckResourceAttr(
+						"digitalocean_droplet.foobar", "tags.0", "barbaz"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDigitalOceanDroplet_UpdateUserData(t *testing.T) {
 	var afterCreate, afterUpdate godo.Droplet
 
@@ -309,6 +339,27 @@ resource "digitalocean_droplet" "foobar" {
 }
 `, testAccValidPublicKey)
 
+var testAccCheckDigitalOceanDropletConfig_tag_update = fmt.Sprintf(`
+resource "digitalocean_tag" "barbaz" {
+  name       = "barbaz"
+}
+
+resource "digitalocean_ssh_key" "foobar" {
+  name       = "foobar"
+  public_key = "%s"
+}
+
+resource "digitalocean_droplet" "foobar" {
+  name      = "foo"
+  size      = "512mb"
+  image     = "centos-5-8-x32"
+  region    = "nyc3"
+  user_data = "foobar"
+  ssh_keys  = ["${digitalocean_ssh_key.foobar.id}"]
+  tags      = ["${digitalocean_tag.barbaz.id}"]
+}
+`, testAccValidPublicKey)
+
 var testAccCheckDigitalOceanDropletConfig_userdata_update = fmt.Sprintf(`
 resource "digitalocean_ssh_key" "foobar" {
   name       = "foobar"
@@ -113,8 +113,15 @@ func resourceDigitalOceanFloatingIpRead(d *schema.ResourceData, meta interface{}
 	client := meta.(*godo.Client)
 
 	log.Printf("[INFO] Reading the details of the FloatingIP %s", d.Id())
-	floatingIp, _, err := client.FloatingIPs.Get(d.Id())
+	floatingIp, resp, err := client.FloatingIPs.Get(d.Id())
 	if err != nil {
+		// check if the floating IP no longer exists.
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] DigitalOcean FloatingIP (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+
 		return fmt.Errorf("Error retrieving FloatingIP: %s", err)
 	}
 
@@ -122,6 +129,7 @@ func resourceDigitalOceanFloatingIpRead(d *schema.ResourceData, meta interface{}
 		log.Printf("[INFO] A droplet was detected on the FloatingIP so setting the Region based on the Droplet")
 		log.Printf("[INFO] The region of the Droplet is %s", floatingIp.Droplet.Region.Slug)
 		d.Set("region", floatingIp.Droplet.Region.Slug)
+		d.Set("droplet_id", floatingIp.Droplet.ID)
 	} else {
 		d.Set("region", floatingIp.Region.Slug)
 	}
@@ -0,0 +1,85 @@
+package digitalocean
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccDigitalOceanTag_Basic(t *testing.T) {
+	var tag godo.Tag
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDigitalOceanTagDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckDigitalOceanTagConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDigitalOceanTagExists("digitalocean_tag.foobar", &tag),
+					resource.TestCheckResourceAttr(
+						"digitalocean_tag.foobar", "name", "foobar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDigitalOceanTagDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*godo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "digitalocean_tag" {
+			continue
+		}
+
+		// Try to find the tag
+		_, _, err := client.Tags.Get(rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("Tag still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDigitalOceanTagExists(n string, tag *godo.Tag) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		client := testAccProvider.Meta().(*godo.Client)
+
+		// Try to find the tag
+		foundTag, _, err := client.Tags.Get(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if foundTag.Name != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		*tag = *foundTag
+
+		return nil
+	}
+}
+
+var testAccCheckDigitalOceanTagConfig_basic = `
+resource "digitalocean_tag" "foobar" {
+  name = "foobar"
+}`
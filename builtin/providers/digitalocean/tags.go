@@ -0,0 +1,69 @@
+package digitalocean
+
+import (
+	"log"
+
+	"github.com/digitalocean/godo"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+// setTags is a helper to set the tags of a droplet. It expects the tags
+// field to be named "tags". The tags themselves need to exist already.
+func setTags(client *godo.Client, d *schema.ResourceData) error {
+	oraw, nraw := d.GetChange("tags")
+	remove, create := diffTags(tagsFromSchema(oraw), tagsFromSchema(nraw))
+
+	resources := []godo.Resource{
+		{
+			ID:   d.Id(),
+			Type: godo.DropletResourceType,
+		},
+	}
+
+	log.Printf("[DEBUG] Removing tags: %#v from %s", remove, d.Id())
+	for _, tag := range remove {
+		_, err := client.Tags.UntagResources(tag, &godo.UntagResourcesRequest{
+			Resources: resources,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] Creating tags: %#v for %s", create, d.Id())
+	for _, tag := range create {
+		_, err := client.Tags.TagResources(tag, &godo.TagResourcesRequest{
+			Resources: resources,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagsFromSchema takes the raw schema tags and returns them as a
+// properly asserted map[string]string
+func tagsFromSchema(raw interface{}) map[string]string {
+	result := make(map[string]string)
+	for _, t := range raw.([]interface{}) {
+		result[t.(string)] = t.(string)
+	}
+
+	return result
+}
+
+// diffTags takes the old and the new tag sets and returns the difference of
+// both. The remaining tags are those that need to be removed and created
+func diffTags(oldTags, newTags map[string]string) (map[string]string, map[string]string) {
+	for k := range oldTags {
+		_, ok := newTags[k]
+		if ok {
+			delete(newTags, k)
+			delete(oldTags, k)
+		}
+	}
+
+	return oldTags, newTags
+}
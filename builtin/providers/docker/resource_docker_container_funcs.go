@@ -163,7 +163,9 @@ func resourceDockerContainerCreate(d *schema.ResourceData, meta interface{}) err
 		connectionOpts := dc.NetworkConnectionOptions{Container: retContainer.ID}
 
 		for _, network := range v.(*schema.Set).List() {
-			client.ConnectNetwork(network.(string), connectionOpts)
+			if err := client.ConnectNetwork(network.(string), connectionOpts); err != nil {
+				return fmt.Errorf("Unable to connect container to network %s: %s", network.(string), err)
+			}
 		}
 	}
 
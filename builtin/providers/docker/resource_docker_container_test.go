@@ -67,6 +67,36 @@ func TestAccDockerContainer_volume(t *testing.T) {
 	})
 }
 
+func TestAccDockerContainer_network(t *testing.T) {
+	var c dc.Container
+
+	testCheck := func(*terraform.State) error {
+		if c.NetworkSettings == nil {
+			return fmt.Errorf("Container has no network settings")
+		}
+
+		if _, ok := c.NetworkSettings.Networks["testAccDockerContainerNetwork_network"]; !ok {
+			return fmt.Errorf("Container is not connected to testAccDockerContainerNetwork_network")
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDockerContainerNetworkConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccContainerRunning("docker_container.foo", &c),
+					testCheck,
+				),
+			},
+		},
+	})
+}
+
 func TestAccDockerContainer_customized(t *testing.T) {
 	var c dc.Container
 
@@ -244,3 +274,22 @@ resource "docker_container" "foo" {
 	}
 }
 `
+
+const testAccDockerContainerNetworkConfig = `
+resource "docker_image" "foo" {
+	name = "nginx:latest"
+}
+
+resource "docker_network" "foo" {
+	name = "testAccDockerContainerNetwork_network"
+	ipam_config {
+		subnet = "10.0.10.0/24"
+	}
+}
+
+resource "docker_container" "foo" {
+	name = "tf-test"
+	image = "${docker_image.foo.latest}"
+	networks = ["${docker_network.foo.name}"]
+}
+`
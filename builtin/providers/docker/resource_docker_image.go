@@ -22,6 +22,45 @@ func resourceDockerImage() *schema.Resource {
 				Optional: true,
 			},
 
+			"build": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"context": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							// Store a hash of the context contents next to the
+							// path, so any change to the files triggers a rebuild
+							StateFunc: func(v interface{}) string {
+								dir := v.(string)
+								hash, err := hashBuildContext(dir)
+								if err != nil {
+									return dir
+								}
+								return dir + ":" + hash
+							},
+						},
+
+						"dockerfile": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Default:  "Dockerfile",
+						},
+
+						"build_args": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
 			"latest": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -1,7 +1,14 @@
 package docker
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
 	"strings"
 
 	dc "github.com/fsouza/go-dockerclient"
@@ -10,6 +17,13 @@ import (
 
 func resourceDockerImageCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*dc.Client)
+
+	if _, ok := d.GetOk("build"); ok {
+		if err := buildImage(d, client); err != nil {
+			return err
+		}
+	}
+
 	apiImage, err := findImage(d, client)
 	if err != nil {
 		return fmt.Errorf("Unable to read Docker image into resource: %s", err)
@@ -23,6 +37,26 @@ func resourceDockerImageCreate(d *schema.ResourceData, meta interface{}) error {
 
 func resourceDockerImageRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*dc.Client)
+
+	// A locally built image can't be pulled, so if it is gone it needs to
+	// be built again
+	if _, ok := d.GetOk("build"); ok {
+		var data Data
+		if err := fetchLocalImages(&data, client); err != nil {
+			return err
+		}
+
+		apiImage := searchLocalImage(&data, d.Get("name").(string))
+		if apiImage == nil {
+			log.Printf("[WARN] Docker image %s not found, removing it from state", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		d.Set("latest", apiImage.ID)
+		return nil
+	}
+
 	apiImage, err := findImage(d, client)
 	if err != nil {
 		return fmt.Errorf("Unable to read Docker image into resource: %s", err)
@@ -159,7 +193,10 @@ func findImage(d *schema.ResourceData, client *dc.Client) (*dc.APIImages, error)
 
 	foundImage := searchLocal()
 
-	if d.Get("keep_updated").(bool) || foundImage == nil {
+	// Images built from a local context are never pulled
+	_, build := d.GetOk("build")
+
+	if !build && (d.Get("keep_updated").(bool) || foundImage == nil) {
 		if err := pullImage(&data, client, imageName); err != nil {
 			return nil, fmt.Errorf("Unable to pull image %s: %s", imageName, err)
 		}
@@ -172,3 +209,88 @@ func findImage(d *schema.ResourceData, client *dc.Client) (*dc.APIImages, error)
 
 	return nil, fmt.Errorf("Unable to find or pull image %s", imageName)
 }
+
+func searchLocalImage(data *Data, imageName string) *dc.APIImages {
+	if apiImage, ok := data.DockerImages[imageName]; ok {
+		return apiImage
+	}
+	if apiImage, ok := data.DockerImages[imageName+":latest"]; ok {
+		return apiImage
+	}
+	return nil
+}
+
+func buildImage(d *schema.ResourceData, client *dc.Client) error {
+	name := d.Get("name").(string)
+	build := d.Get("build").([]interface{})[0].(map[string]interface{})
+
+	// The context is stored together with its hash, so strip that off in
+	// case the value was read back from the state
+	context := build["context"].(string)
+	if i := strings.LastIndex(context, ":"); i > 0 && len(context)-i-1 == sha256.Size*2 {
+		context = context[:i]
+	}
+
+	var buildArgs []dc.BuildArg
+	for k, v := range build["build_args"].(map[string]interface{}) {
+		buildArgs = append(buildArgs, dc.BuildArg{
+			Name:  k,
+			Value: v.(string),
+		})
+	}
+
+	var output bytes.Buffer
+	buildOpts := dc.BuildImageOptions{
+		Name:           name,
+		ContextDir:     context,
+		Dockerfile:     build["dockerfile"].(string),
+		BuildArgs:      buildArgs,
+		OutputStream:   &output,
+		SuppressOutput: false,
+		RmTmpContainer: true,
+	}
+
+	log.Printf("[DEBUG] Building Docker image %s from %s", name, context)
+	if err := client.BuildImage(buildOpts); err != nil {
+		return fmt.Errorf("Error building image %s: %s\n%s", name, err, output.String())
+	}
+	log.Printf("[DEBUG] Docker build output for %s:\n%s", name, output.String())
+
+	return nil
+}
+
+// hashBuildContext returns a hash over the names and contents of all files
+// in the build context directory
+func hashBuildContext(dir string) (string, error) {
+	h := sha256.New()
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		io.WriteString(h, filepath.ToSlash(rel))
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,6 +1,9 @@
 package docker
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -37,6 +40,59 @@ func TestAccDockerImage_private(t *testing.T) {
 	})
 }
 
+func TestAccDockerImage_build(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDockerImageBuildConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("docker_image.foobuild", "latest", regexp.MustCompile(`\A[a-f0-9]{64}\z`)),
+				),
+			},
+		},
+	})
+}
+
+func TestHashBuildContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-docker-build")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	if err := ioutil.WriteFile(dockerfile, []byte("FROM alpine:3.1\n"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	first, err := hashBuildContext(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	second, err := hashBuildContext(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if first != second {
+		t.Fatalf("hash changed without changing the context: %s != %s", first, second)
+	}
+
+	if err := ioutil.WriteFile(dockerfile, []byte("FROM alpine:3.2\n"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	third, err := hashBuildContext(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if first == third {
+		t.Fatalf("hash didn't change after changing the context: %s", third)
+	}
+}
+
 const testAccDockerImageConfig = `
 resource "docker_image" "foo" {
 	name = "alpine:3.1"
@@ -50,3 +106,15 @@ resource "docker_image" "foobar" {
 	keep_updated = true
 }
 `
+
+const testAccDockerImageBuildConfig = `
+resource "docker_image" "foobuild" {
+	name = "tftest-dockerbuild:latest"
+	build {
+		context = "test-fixtures/build"
+		build_args {
+			test_arg = "terraform"
+		}
+	}
+}
+`
@@ -0,0 +1,36 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/sethvargo/go-fastly"
+)
+
+type Config struct {
+	ApiKey string
+}
+
+type FastlyClient struct {
+	conn *gofastly.Client
+}
+
+// Client() returns a new client for accessing Fastly.
+func (c *Config) Client() (interface{}, error) {
+	var client FastlyClient
+
+	if c.ApiKey == "" {
+		return nil, fmt.Errorf("[Err] No API key for Fastly")
+	}
+
+	fastlyClient, err := gofastly.NewClient(c.ApiKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up client: %s", err)
+	}
+
+	client.conn = fastlyClient
+
+	log.Printf("[INFO] Fastly Client configured")
+
+	return &client, nil
+}
@@ -0,0 +1,34 @@
+package fastly
+
+import (
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("FASTLY_API_KEY", nil),
+				Description: "Fastly API Key from https://app.fastly.com/#account",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"fastly_service_v1": resourceServiceV1(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		ApiKey: d.Get("api_key").(string),
+	}
+
+	return config.Client()
+}
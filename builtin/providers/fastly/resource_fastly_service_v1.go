@@ -0,0 +1,699 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/sethvargo/go-fastly"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceServiceV1() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServiceV1Create,
+		Read:   resourceServiceV1Read,
+		Update: resourceServiceV1Update,
+		Delete: resourceServiceV1Delete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique name for this Service",
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Managed by Terraform",
+			},
+
+			// Active Version represents the currently activated version in Fastly.
+			// All changes to domains, backends, headers and VCLs are made on a
+			// clone of this version, which is activated when all changes
+			// validate.
+			"active_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"force_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"domain": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The domain that this Service will respond to",
+						},
+
+						"comment": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"backend": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name for this Backend",
+						},
+
+						"address": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "An IPv4, hostname, or IPv6 address for the Backend",
+						},
+
+						"auto_loadbalance": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"between_bytes_timeout": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10000,
+							Description: "How long to wait between bytes in milliseconds",
+						},
+
+						"connect_timeout": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1000,
+							Description: "How long to wait for a timeout in milliseconds",
+						},
+
+						"first_byte_timeout": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     15000,
+							Description: "How long to wait for the first bytes in milliseconds",
+						},
+
+						"port": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     80,
+							Description: "The port number Backend responds on",
+						},
+
+						"ssl_check_cert": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Be strict on checking SSL certs",
+						},
+
+						"weight": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     100,
+							Description: "The portion of traffic to send to a specific origins",
+						},
+					},
+				},
+			},
+
+			"header": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name to refer to this Header object",
+						},
+
+						"action": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "One of set, append, delete, regex, or regex_repeat",
+							ValidateFunc: validateHeaderAction,
+						},
+
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Type to manipulate: request, fetch, cache, response",
+							ValidateFunc: validateHeaderType,
+						},
+
+						"destination": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Header this affects",
+						},
+
+						"ignore_if_set": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Don't add the header if it is already present",
+						},
+
+						"source": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Variable to be used as a source for the header content",
+						},
+
+						"regex": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Regular expression to use with the regex actions",
+						},
+
+						"substitution": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Value to substitute in place of regular expression",
+						},
+
+						"priority": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     100,
+							Description: "Lower priorities execute first",
+						},
+					},
+				},
+			},
+
+			"vcl": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name to refer to this VCL file",
+						},
+
+						"content": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The contents of this VCL configuration",
+						},
+
+						"main": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Should this VCL configuration be the main configuration",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceServiceV1Create(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	service, err := conn.CreateService(&gofastly.CreateServiceInput{
+		Name:    d.Get("name").(string),
+		Comment: d.Get("comment").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Fastly Service: %s", err)
+	}
+
+	d.SetId(service.ID)
+	log.Printf("[INFO] Created Fastly Service: %s", d.Id())
+
+	return resourceServiceV1Update(d, meta)
+}
+
+func resourceServiceV1Update(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	// Name and comment are not versioned
+	if d.HasChange("name") || d.HasChange("comment") {
+		_, err := conn.UpdateService(&gofastly.UpdateServiceInput{
+			ID:      d.Id(),
+			Name:    d.Get("name").(string),
+			Comment: d.Get("comment").(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating Fastly Service %s: %s", d.Id(), err)
+		}
+	}
+
+	needsChange := false
+	for _, k := range []string{"domain", "backend", "header", "vcl"} {
+		if d.HasChange(k) {
+			needsChange = true
+			break
+		}
+	}
+
+	if needsChange {
+		version := d.Get("active_version").(string)
+		if version == "" {
+			// A new service comes with an inactive version 1, which can
+			// be modified directly.
+			version = "1"
+		} else {
+			log.Printf("[DEBUG] Cloning version %s of Fastly Service %s", version, d.Id())
+			newVersion, err := conn.CloneVersion(&gofastly.CloneVersionInput{
+				Service: d.Id(),
+				Version: version,
+			})
+			if err != nil {
+				return fmt.Errorf("Error cloning version %s of Fastly Service %s: %s", version, d.Id(), err)
+			}
+			version = newVersion.Number
+		}
+
+		if err := updateServiceV1Domains(conn, d, version); err != nil {
+			return err
+		}
+		if err := updateServiceV1Backends(conn, d, version); err != nil {
+			return err
+		}
+		if err := updateServiceV1Headers(conn, d, version); err != nil {
+			return err
+		}
+		if err := updateServiceV1VCLs(conn, d, version); err != nil {
+			return err
+		}
+
+		valid, msg, err := conn.ValidateVersion(&gofastly.ValidateVersionInput{
+			Service: d.Id(),
+			Version: version,
+		})
+		if err != nil {
+			return fmt.Errorf("Error validating version %s of Fastly Service %s: %s", version, d.Id(), err)
+		}
+		if !valid {
+			return fmt.Errorf("Invalid configuration for version %s of Fastly Service %s: %s", version, d.Id(), msg)
+		}
+
+		log.Printf("[DEBUG] Activating version %s of Fastly Service %s", version, d.Id())
+		_, err = conn.ActivateVersion(&gofastly.ActivateVersionInput{
+			Service: d.Id(),
+			Version: version,
+		})
+		if err != nil {
+			return fmt.Errorf("Error activating version %s of Fastly Service %s: %s", version, d.Id(), err)
+		}
+
+		d.Set("active_version", version)
+	}
+
+	return resourceServiceV1Read(d, meta)
+}
+
+func resourceServiceV1Read(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+		ID: d.Id(),
+	})
+	if err != nil {
+		if e, ok := err.(*gofastly.HTTPError); ok && e.StatusCode == 404 {
+			log.Printf("[WARN] Fastly Service %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Fastly Service %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", s.Name)
+	d.Set("comment", s.Comment)
+	d.Set("active_version", s.ActiveVersion.Number)
+
+	// Without an active version there is nothing to read, which happens
+	// when the first version failed to activate.
+	version := s.ActiveVersion.Number
+	if version == "" {
+		return nil
+	}
+
+	domains, err := conn.ListDomains(&gofastly.ListDomainsInput{
+		Service: d.Id(),
+		Version: version,
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing domains of Fastly Service %s: %s", d.Id(), err)
+	}
+	if err := d.Set("domain", flattenDomains(domains)); err != nil {
+		log.Printf("[WARN] Error setting domains for Fastly Service %s: %s", d.Id(), err)
+	}
+
+	backends, err := conn.ListBackends(&gofastly.ListBackendsInput{
+		Service: d.Id(),
+		Version: version,
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing backends of Fastly Service %s: %s", d.Id(), err)
+	}
+	if err := d.Set("backend", flattenBackends(backends)); err != nil {
+		log.Printf("[WARN] Error setting backends for Fastly Service %s: %s", d.Id(), err)
+	}
+
+	headers, err := conn.ListHeaders(&gofastly.ListHeadersInput{
+		Service: d.Id(),
+		Version: version,
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing headers of Fastly Service %s: %s", d.Id(), err)
+	}
+	if err := d.Set("header", flattenHeaders(headers)); err != nil {
+		log.Printf("[WARN] Error setting headers for Fastly Service %s: %s", d.Id(), err)
+	}
+
+	vcls, err := conn.ListVCLs(&gofastly.ListVCLsInput{
+		Service: d.Id(),
+		Version: version,
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing VCLs of Fastly Service %s: %s", d.Id(), err)
+	}
+	if err := d.Set("vcl", flattenVCLs(vcls)); err != nil {
+		log.Printf("[WARN] Error setting VCLs for Fastly Service %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceServiceV1Delete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	// Fastly won't delete a service with an active version
+	if d.Get("force_destroy").(bool) {
+		s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+			ID: d.Id(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if s.ActiveVersion.Number != "" {
+			_, err := conn.DeactivateVersion(&gofastly.DeactivateVersionInput{
+				Service: d.Id(),
+				Version: s.ActiveVersion.Number,
+			})
+			if err != nil {
+				return fmt.Errorf("Error deactivating version %s of Fastly Service %s: %s",
+					s.ActiveVersion.Number, d.Id(), err)
+			}
+		}
+	}
+
+	err := conn.DeleteService(&gofastly.DeleteServiceInput{
+		ID: d.Id(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting Fastly Service %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// setChanges returns the elements of a set that were removed and added.
+func setChanges(d *schema.ResourceData, k string) (remove, add []interface{}) {
+	o, n := d.GetChange(k)
+	if o == nil {
+		o = new(schema.Set)
+	}
+	if n == nil {
+		n = new(schema.Set)
+	}
+
+	oldSet := o.(*schema.Set)
+	newSet := n.(*schema.Set)
+
+	return oldSet.Difference(newSet).List(), newSet.Difference(oldSet).List()
+}
+
+func updateServiceV1Domains(conn *gofastly.Client, d *schema.ResourceData, version string) error {
+	if !d.HasChange("domain") {
+		return nil
+	}
+
+	remove, add := setChanges(d, "domain")
+
+	for _, raw := range remove {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Domain removal: %s", m["name"])
+		err := conn.DeleteDomain(&gofastly.DeleteDomainInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing domain %s: %s", m["name"], err)
+		}
+	}
+
+	for _, raw := range add {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Domain addition: %s", m["name"])
+		_, err := conn.CreateDomain(&gofastly.CreateDomainInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+			Comment: m["comment"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error adding domain %s: %s", m["name"], err)
+		}
+	}
+
+	return nil
+}
+
+func updateServiceV1Backends(conn *gofastly.Client, d *schema.ResourceData, version string) error {
+	if !d.HasChange("backend") {
+		return nil
+	}
+
+	remove, add := setChanges(d, "backend")
+
+	for _, raw := range remove {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Backend removal: %s", m["name"])
+		err := conn.DeleteBackend(&gofastly.DeleteBackendInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing backend %s: %s", m["name"], err)
+		}
+	}
+
+	for _, raw := range add {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Backend addition: %s", m["name"])
+		_, err := conn.CreateBackend(&gofastly.CreateBackendInput{
+			Service:             d.Id(),
+			Version:             version,
+			Name:                m["name"].(string),
+			Address:             m["address"].(string),
+			AutoLoadbalance:     m["auto_loadbalance"].(bool),
+			SSLCheckCert:        m["ssl_check_cert"].(bool),
+			Port:                uint(m["port"].(int)),
+			BetweenBytesTimeout: uint(m["between_bytes_timeout"].(int)),
+			ConnectTimeout:      uint(m["connect_timeout"].(int)),
+			FirstByteTimeout:    uint(m["first_byte_timeout"].(int)),
+			Weight:              uint(m["weight"].(int)),
+		})
+		if err != nil {
+			return fmt.Errorf("Error adding backend %s: %s", m["name"], err)
+		}
+	}
+
+	return nil
+}
+
+func updateServiceV1Headers(conn *gofastly.Client, d *schema.ResourceData, version string) error {
+	if !d.HasChange("header") {
+		return nil
+	}
+
+	remove, add := setChanges(d, "header")
+
+	for _, raw := range remove {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Header removal: %s", m["name"])
+		err := conn.DeleteHeader(&gofastly.DeleteHeaderInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing header %s: %s", m["name"], err)
+		}
+	}
+
+	for _, raw := range add {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly Header addition: %s", m["name"])
+		_, err := conn.CreateHeader(&gofastly.CreateHeaderInput{
+			Service:      d.Id(),
+			Version:      version,
+			Name:         m["name"].(string),
+			Action:       gofastly.HeaderAction(m["action"].(string)),
+			Type:         gofastly.HeaderType(m["type"].(string)),
+			Destination:  m["destination"].(string),
+			IgnoreIfSet:  m["ignore_if_set"].(bool),
+			Source:       m["source"].(string),
+			Regex:        m["regex"].(string),
+			Substitution: m["substitution"].(string),
+			Priority:     uint(m["priority"].(int)),
+		})
+		if err != nil {
+			return fmt.Errorf("Error adding header %s: %s", m["name"], err)
+		}
+	}
+
+	return nil
+}
+
+func updateServiceV1VCLs(conn *gofastly.Client, d *schema.ResourceData, version string) error {
+	if !d.HasChange("vcl") {
+		return nil
+	}
+
+	remove, add := setChanges(d, "vcl")
+
+	for _, raw := range remove {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly VCL removal: %s", m["name"])
+		err := conn.DeleteVCL(&gofastly.DeleteVCLInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing VCL %s: %s", m["name"], err)
+		}
+	}
+
+	for _, raw := range add {
+		m := raw.(map[string]interface{})
+		log.Printf("[DEBUG] Fastly VCL addition: %s", m["name"])
+		_, err := conn.CreateVCL(&gofastly.CreateVCLInput{
+			Service: d.Id(),
+			Version: version,
+			Name:    m["name"].(string),
+			Content: m["content"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("Error adding VCL %s: %s", m["name"], err)
+		}
+
+		if m["main"].(bool) {
+			_, err := conn.ActivateVCL(&gofastly.ActivateVCLInput{
+				Service: d.Id(),
+				Version: version,
+				Name:    m["name"].(string),
+			})
+			if err != nil {
+				return fmt.Errorf("Error setting VCL %s as main: %s", m["name"], err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func flattenDomains(list []*gofastly.Domain) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, d := range list {
+		result = append(result, map[string]interface{}{
+			"name":    d.Name,
+			"comment": d.Comment,
+		})
+	}
+	return result
+}
+
+func flattenBackends(list []*gofastly.Backend) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, b := range list {
+		result = append(result, map[string]interface{}{
+			"name":                  b.Name,
+			"address":               b.Address,
+			"auto_loadbalance":      b.AutoLoadbalance,
+			"between_bytes_timeout": int(b.BetweenBytesTimeout),
+			"connect_timeout":       int(b.ConnectTimeout),
+			"first_byte_timeout":    int(b.FirstByteTimeout),
+			"port":                  int(b.Port),
+			"ssl_check_cert":        b.SSLCheckCert,
+			"weight":                int(b.Weight),
+		})
+	}
+	return result
+}
+
+func flattenHeaders(list []*gofastly.Header) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, h := range list {
+		result = append(result, map[string]interface{}{
+			"name":          h.Name,
+			"action":        string(h.Action),
+			"type":          string(h.Type),
+			"destination":   h.Destination,
+			"ignore_if_set": h.IgnoreIfSet,
+			"source":        h.Source,
+			"regex":         h.Regex,
+			"substitution":  h.Substitution,
+			"priority":      int(h.Priority),
+		})
+	}
+	return result
+}
+
+func flattenVCLs(list []*gofastly.VCL) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, v := range list {
+		result = append(result, map[string]interface{}{
+			"name":    v.Name,
+			"content": v.Content,
+			"main":    v.Main,
+		})
+	}
+	return result
+}
+
+func validateHeaderAction(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "set", "append", "delete", "regex", "regex_repeat":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of set, append, delete, regex or regex_repeat, got: %s", k, v))
+	}
+	return
+}
+
+func validateHeaderType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "request", "fetch", "cache", "response":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of request, fetch, cache or response, got: %s", k, v))
+	}
+	return
+}
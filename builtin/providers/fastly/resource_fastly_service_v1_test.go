@@ -0,0 +1,156 @@
+package fastly
+
+import (
+	"fmt"
+	"testing"
+
+	gofastly "github.com/sethvargo/go-fastly"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccFastlyServiceV1_basic(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domainName1 := fmt.Sprintf("%s.notadomain.com", acctest.RandString(10))
+	domainName2 := fmt.Sprintf("%s.notadomain.com", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckServiceV1Destroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccServiceV1Config(name, domainName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceV1Exists("fastly_service_v1.foo", &service),
+					testAccCheckFastlyServiceV1Attributes(&service, name, []string{domainName1}),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "name", name),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "domain.#", "1"),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "header.#", "1"),
+				),
+			},
+
+			// Changing the domain clones and activates a new version
+			resource.TestStep{
+				Config: testAccServiceV1Config(name, domainName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceV1Exists("fastly_service_v1.foo", &service),
+					testAccCheckFastlyServiceV1Attributes(&service, name, []string{domainName2}),
+					resource.TestCheckResourceAttr(
+						"fastly_service_v1.foo", "active_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceV1Exists(n string, service *gofastly.ServiceDetail) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Service ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*FastlyClient).conn
+		latest, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+			ID: rs.Primary.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		*service = *latest
+
+		return nil
+	}
+}
+
+func testAccCheckFastlyServiceV1Attributes(service *gofastly.ServiceDetail, name string, domains []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if service.Name != name {
+			return fmt.Errorf("Bad name, expected (%s), got (%s)", name, service.Name)
+		}
+
+		conn := testAccProvider.Meta().(*FastlyClient).conn
+		domainList, err := conn.ListDomains(&gofastly.ListDomainsInput{
+			Service: service.ID,
+			Version: service.ActiveVersion.Number,
+		})
+		if err != nil {
+			return fmt.Errorf("Error looking up Domains for (%s), version (%s): %s",
+				service.Name, service.ActiveVersion.Number, err)
+		}
+
+		if len(domainList) != len(domains) {
+			return fmt.Errorf("Domain count mismatch, expected: %#v, got: %#v", domains, domainList)
+		}
+
+		for i, d := range domainList {
+			if d.Name != domains[i] {
+				return fmt.Errorf("Domain mismatch, expected: %#v, got: %#v", domains, domainList)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckServiceV1Destroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*FastlyClient).conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "fastly_service_v1" {
+			continue
+		}
+
+		l, err := conn.ListServices(&gofastly.ListServicesInput{})
+		if err != nil {
+			return fmt.Errorf("Error listing services when deleting Fastly Service (%s): %s", rs.Primary.ID, err)
+		}
+
+		for _, s := range l {
+			if s.ID == rs.Primary.ID {
+				return fmt.Errorf("Fastly Service %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccServiceV1Config(name, domain string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_v1" "foo" {
+  name = "%s"
+
+  domain {
+    name = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name = "amazon docs"
+  }
+
+  header {
+    name = "remove x-amz-request-id"
+    action = "delete"
+    type = "cache"
+    destination = "http.x-amz-request-id"
+  }
+
+  force_destroy = true
+}`, name, domain)
+}
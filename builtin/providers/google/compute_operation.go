@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/xanzy/terraform-api/helper/resource"
@@ -82,7 +83,14 @@ func (e ComputeOperationError) Error() string {
 	return buf.String()
 }
 
-func computeOperationWaitGlobal(config *Config, op *compute.Operation, activity string) error {
+// defaultComputeOperationTimeout is the number of minutes to wait for an
+// operation to finish when the resource doesn't configure a timeout.
+const defaultComputeOperationTimeout = 4
+
+// computeOperationWait waits for the given operation to finish, for at most
+// the given number of minutes. Whether the operation is global, regional or
+// zonal is derived from the operation itself.
+func computeOperationWait(config *Config, op *compute.Operation, activity string, minutes int) error {
 	w := &ComputeOperationWaiter{
 		Service: config.clientCompute,
 		Op:      op,
@@ -90,16 +98,35 @@ func computeOperationWaitGlobal(config *Config, op *compute.Operation, activity
 		Type:    ComputeOperationWaitGlobal,
 	}
 
+	switch {
+	case op.Zone != "":
+		w.Type = ComputeOperationWaitZone
+		w.Zone = resourceNameFromLink(op.Zone)
+	case op.Region != "":
+		w.Type = ComputeOperationWaitRegion
+		w.Region = resourceNameFromLink(op.Region)
+	}
+
+	return w.Wait(activity, minutes)
+}
+
+// Wait waits for the operation to finish, for at most the given number of
+// minutes, and returns the error of the operation if it failed.
+func (w *ComputeOperationWaiter) Wait(activity string, minutes int) error {
+	if minutes <= 0 {
+		minutes = defaultComputeOperationTimeout
+	}
+
 	state := w.Conf()
 	state.Delay = 10 * time.Second
-	state.Timeout = 4 * time.Minute
+	state.Timeout = time.Duration(minutes) * time.Minute
 	state.MinTimeout = 2 * time.Second
 	opRaw, err := state.WaitForState()
 	if err != nil {
 		return fmt.Errorf("Error waiting for %s: %s", activity, err)
 	}
 
-	op = opRaw.(*compute.Operation)
+	op := opRaw.(*compute.Operation)
 	if op.Error != nil {
 		return ComputeOperationError(*op.Error)
 	}
@@ -107,34 +134,38 @@ func computeOperationWaitGlobal(config *Config, op *compute.Operation, activity
 	return nil
 }
 
-func computeOperationWaitRegion(config *Config, op *compute.Operation, region, activity string) error {
+// resourceNameFromLink returns the name of a resource from its self link,
+// or the value itself if it isn't a link.
+func resourceNameFromLink(link string) string {
+	parts := strings.Split(link, "/")
+	return parts[len(parts)-1]
+}
+
+func computeOperationWaitGlobal(config *Config, op *compute.Operation, activity string) error {
 	w := &ComputeOperationWaiter{
 		Service: config.clientCompute,
 		Op:      op,
 		Project: config.Project,
-		Type:    ComputeOperationWaitRegion,
-		Region:  region,
+		Type:    ComputeOperationWaitGlobal,
 	}
 
-	state := w.Conf()
-	state.Delay = 10 * time.Second
-	state.Timeout = 4 * time.Minute
-	state.MinTimeout = 2 * time.Second
-	opRaw, err := state.WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for %s: %s", activity, err)
-	}
+	return w.Wait(activity, defaultComputeOperationTimeout)
+}
 
-	op = opRaw.(*compute.Operation)
-	if op.Error != nil {
-		return ComputeOperationError(*op.Error)
+func computeOperationWaitRegion(config *Config, op *compute.Operation, region, activity string) error {
+	w := &ComputeOperationWaiter{
+		Service: config.clientCompute,
+		Op:      op,
+		Project: config.Project,
+		Type:    ComputeOperationWaitRegion,
+		Region:  region,
 	}
 
-	return nil
+	return w.Wait(activity, defaultComputeOperationTimeout)
 }
 
 func computeOperationWaitZone(config *Config, op *compute.Operation, zone, activity string) error {
-	return computeOperationWaitZoneTime(config, op, zone, 4, activity)
+	return computeOperationWaitZoneTime(config, op, zone, defaultComputeOperationTimeout, activity)
 }
 
 func computeOperationWaitZoneTime(config *Config, op *compute.Operation, zone string, minutes int, activity string) error {
@@ -145,18 +176,6 @@ func computeOperationWaitZoneTime(config *Config, op *compute.Operation, zone st
 		Zone:    zone,
 		Type:    ComputeOperationWaitZone,
 	}
-	state := w.Conf()
-	state.Delay = 10 * time.Second
-	state.Timeout = time.Duration(minutes) * time.Minute
-	state.MinTimeout = 2 * time.Second
-	opRaw, err := state.WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for %s: %s", activity, err)
-	}
-	op = opRaw.(*compute.Operation)
-	if op.Error != nil {
-		// Return the error
-		return ComputeOperationError(*op.Error)
-	}
-	return nil
+
+	return w.Wait(activity, minutes)
 }
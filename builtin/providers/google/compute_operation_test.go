@@ -0,0 +1,19 @@
+package google
+
+import (
+	"testing"
+)
+
+func TestResourceNameFromLink(t *testing.T) {
+	cases := map[string]string{
+		"https://www.googleapis.com/compute/v1/projects/foo/zones/us-central1-a": "us-central1-a",
+		"https://www.googleapis.com/compute/v1/projects/foo/regions/us-central1": "us-central1",
+		"us-central1-a": "us-central1-a",
+	}
+
+	for link, expected := range cases {
+		if actual := resourceNameFromLink(link); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", link, expected, actual)
+		}
+	}
+}
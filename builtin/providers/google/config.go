@@ -13,6 +13,7 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
+	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/container/v1"
 	"google.golang.org/api/dns/v1"
@@ -28,34 +29,57 @@ type Config struct {
 	Project     string
 	Region      string
 
+	// Scopes overrides the OAuth scopes that are requested for the
+	// credentials. If empty, defaultClientScopes are requested.
+	Scopes []string
+
+	// TokenSource can be set by users of this package to supply the OAuth
+	// tokens for requests directly, without going through Credentials.
+	// It takes precedence over Credentials when set.
+	TokenSource oauth2.TokenSource
+
 	clientCompute   *compute.Service
 	clientContainer *container.Service
 	clientDns       *dns.Service
 	clientStorage   *storage.Service
 	clientSqlAdmin  *sqladmin.Service
 	clientPubsub    *pubsub.Service
+
+	clientResourceManager *cloudresourcemanager.Service
+}
+
+// defaultClientScopes are the OAuth scopes requested when no Scopes are
+// configured.
+var defaultClientScopes = []string{
+	"https://www.googleapis.com/auth/compute",
+	"https://www.googleapis.com/auth/cloud-platform",
+	"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
+	"https://www.googleapis.com/auth/devstorage.full_control",
 }
 
 func (c *Config) loadAndValidate() error {
 	var account accountFile
-	clientScopes := []string{
-		"https://www.googleapis.com/auth/compute",
-		"https://www.googleapis.com/auth/cloud-platform",
-		"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
-		"https://www.googleapis.com/auth/devstorage.full_control",
+	clientScopes := defaultClientScopes
+	if len(c.Scopes) > 0 {
+		clientScopes = c.Scopes
 	}
 
 	var client *http.Client
 
-	if c.Credentials != "" {
+	if c.TokenSource != nil {
+		log.Printf("[INFO] Authenticating using the configured token source")
+		client = oauth2.NewClient(oauth2.NoContext, c.TokenSource)
+
+	} else if c.Credentials != "" {
 		contents, _, err := pathorcontents.Read(c.Credentials)
 		if err != nil {
 			return fmt.Errorf("Error loading credentials: %s", err)
 		}
 
-		// Assume account_file is a JSON string
+		// Assume account_file is a JSON string. The contents aren't part
+		// of the error since they contain the private key.
 		if err := parseJSON(&account, contents); err != nil {
-			return fmt.Errorf("Error parsing credentials '%s': %s", contents, err)
+			return fmt.Errorf("Error parsing credentials: %s", err)
 		}
 
 		// Get the token for use in our requests
@@ -137,6 +161,13 @@ func (c *Config) loadAndValidate() error {
 	}
 	c.clientPubsub.UserAgent = userAgent
 
+	log.Printf("[INFO] Instantiating Google Cloud ResourceManager Client...")
+	c.clientResourceManager, err = cloudresourcemanager.New(client)
+	if err != nil {
+		return err
+	}
+	c.clientResourceManager.UserAgent = userAgent
+
 	return nil
 }
 
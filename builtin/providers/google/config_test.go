@@ -3,6 +3,8 @@ package google
 import (
 	"io/ioutil"
 	"testing"
+
+	"golang.org/x/oauth2"
 )
 
 const testFakeCredentialsPath = "./test-fixtures/fake_account.json"
@@ -48,3 +50,31 @@ func TestConfigLoadAndValidate_accountFileJSONInvalid(t *testing.T) {
 		t.Fatalf("expected error, but got nil")
 	}
 }
+
+func TestConfigLoadAndValidate_tokenSource(t *testing.T) {
+	config := Config{
+		Credentials: "{this is not json}",
+		Project:     "my-gce-project",
+		Region:      "us-central1",
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "foo"}),
+	}
+
+	err := config.loadAndValidate()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
+
+func TestConfigLoadAndValidate_scopes(t *testing.T) {
+	config := Config{
+		Credentials: testFakeCredentialsPath,
+		Project:     "my-gce-project",
+		Region:      "us-central1",
+		Scopes:      []string{"https://www.googleapis.com/auth/compute"},
+	}
+
+	err := config.loadAndValidate()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package google
 
 import (
+	"fmt"
+	"time"
+
 	"google.golang.org/api/dns/v1"
 
 	"github.com/xanzy/terraform-api/helper/resource"
@@ -36,3 +39,21 @@ func (w *DnsChangeWaiter) Conf() *resource.StateChangeConf {
 		Refresh: w.RefreshFunc(),
 	}
 }
+
+func dnsChangeWait(config *Config, chg *dns.Change, zone string) error {
+	w := &DnsChangeWaiter{
+		Service:     config.clientDns,
+		Change:      chg,
+		Project:     config.Project,
+		ManagedZone: zone,
+	}
+	state := w.Conf()
+	state.Delay = 10 * time.Second
+	state.Timeout = 10 * time.Minute
+	state.MinTimeout = 2 * time.Second
+	if _, err := state.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
+	}
+
+	return nil
+}
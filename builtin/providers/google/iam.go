@@ -0,0 +1,140 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// iamPolicyModifyFunc changes a freshly read policy in place before it is
+// written back.
+type iamPolicyModifyFunc func(p *cloudresourcemanager.Policy) error
+
+// projectIamPolicyReadModifyWrite reads the IAM policy of a project, applies
+// modify to it and writes the result back.
+//
+// A project has a single policy that all of the IAM resources share, so
+// changes must never be computed against a stale copy. Resources within one
+// run are serialized per project, and the write carries the etag of the read
+// so that a change made elsewhere in the meantime fails with a 409 and the
+// whole cycle is retried against the current policy.
+func projectIamPolicyReadModifyWrite(config *Config, project string, modify iamPolicyModifyFunc) error {
+	mutexKey := fmt.Sprintf("google-project-iam-%s", project)
+	mutexKV.Lock(mutexKey)
+	defer mutexKV.Unlock(mutexKey)
+
+	return resource.Retry(5*time.Minute, func() error {
+		p, err := getProjectIamPolicy(config, project)
+		if err != nil {
+			return resource.RetryError{Err: err}
+		}
+
+		if err := modify(p); err != nil {
+			return resource.RetryError{Err: err}
+		}
+
+		_, err = config.clientResourceManager.Projects.SetIamPolicy(project,
+			&cloudresourcemanager.SetIamPolicyRequest{Policy: p}).Do()
+		if err != nil {
+			if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 409 {
+				log.Printf("[DEBUG] IAM policy of project %q changed concurrently, retrying", project)
+				return err
+			}
+			return resource.RetryError{Err: fmt.Errorf(
+				"Error setting IAM policy for project %q: %s", project, err)}
+		}
+
+		return nil
+	})
+}
+
+// getProjectIamPolicy returns the current IAM policy of a project.
+func getProjectIamPolicy(config *Config, project string) (*cloudresourcemanager.Policy, error) {
+	p, err := config.clientResourceManager.Projects.GetIamPolicy(project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving IAM policy for project %q: %s", project, err)
+	}
+	return p, nil
+}
+
+// rolesToMembersMap converts a list of bindings into a map of role to the
+// set of its members. Bindings that share a role are merged.
+func rolesToMembersMap(bindings []*cloudresourcemanager.Binding) map[string]map[string]bool {
+	m := make(map[string]map[string]bool)
+	for _, b := range bindings {
+		if _, ok := m[b.Role]; !ok {
+			m[b.Role] = make(map[string]bool)
+		}
+		for _, member := range b.Members {
+			m[b.Role][member] = true
+		}
+	}
+	return m
+}
+
+// rolesToMembersBindings is the inverse of rolesToMembersMap. Roles without
+// members are dropped and the output is sorted, so the same set of
+// bindings always results in the same policy.
+func rolesToMembersBindings(m map[string]map[string]bool) []*cloudresourcemanager.Binding {
+	roles := make([]string, 0, len(m))
+	for role, members := range m {
+		if len(members) > 0 {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+
+	bindings := make([]*cloudresourcemanager.Binding, 0, len(roles))
+	for _, role := range roles {
+		members := make([]string, 0, len(m[role]))
+		for member := range m[role] {
+			members = append(members, member)
+		}
+		sort.Strings(members)
+
+		bindings = append(bindings, &cloudresourcemanager.Binding{
+			Role:    role,
+			Members: members,
+		})
+	}
+	return bindings
+}
+
+// mergeBindings collapses bindings that share a role into one and removes
+// duplicate members.
+func mergeBindings(bindings []*cloudresourcemanager.Binding) []*cloudresourcemanager.Binding {
+	return rolesToMembersBindings(rolesToMembersMap(bindings))
+}
+
+// expandIamBindings converts the binding blocks of a resource into a list of
+// bindings.
+func expandIamBindings(configured []interface{}) []*cloudresourcemanager.Binding {
+	bindings := make([]*cloudresourcemanager.Binding, 0, len(configured))
+	for _, raw := range configured {
+		b := raw.(map[string]interface{})
+		bindings = append(bindings, &cloudresourcemanager.Binding{
+			Role:    b["role"].(string),
+			Members: convertStringArr(b["members"].(*schema.Set).List()),
+		})
+	}
+	return mergeBindings(bindings)
+}
+
+// flattenIamBindings converts a list of bindings into binding blocks.
+func flattenIamBindings(bindings []*cloudresourcemanager.Binding) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(bindings))
+	for _, b := range bindings {
+		result = append(result, map[string]interface{}{
+			"role":    b.Role,
+			"members": b.Members,
+		})
+	}
+	return result
+}
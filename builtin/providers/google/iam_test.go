@@ -0,0 +1,83 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestMergeBindings(t *testing.T) {
+	cases := []struct {
+		Input    []*cloudresourcemanager.Binding
+		Expected []*cloudresourcemanager.Binding
+	}{
+		{
+			Input:    []*cloudresourcemanager.Binding{},
+			Expected: []*cloudresourcemanager.Binding{},
+		},
+		{
+			// Duplicate roles are merged and the members deduplicated
+			Input: []*cloudresourcemanager.Binding{
+				{Role: "roles/viewer", Members: []string{"user:b@example.com", "user:a@example.com"}},
+				{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+				{Role: "roles/viewer", Members: []string{"user:a@example.com", "user:c@example.com"}},
+			},
+			Expected: []*cloudresourcemanager.Binding{
+				{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+				{Role: "roles/viewer", Members: []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}},
+			},
+		},
+		{
+			// Roles without members are dropped
+			Input: []*cloudresourcemanager.Binding{
+				{Role: "roles/editor", Members: []string{}},
+				{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+			},
+			Expected: []*cloudresourcemanager.Binding{
+				{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		actual := mergeBindings(tc.Input)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: expected %#v, got %#v", i, tc.Expected, actual)
+		}
+	}
+}
+
+func TestRolesToMembersMap(t *testing.T) {
+	bindings := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:a@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:b@example.com"}},
+		{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+	}
+
+	expected := map[string]map[string]bool{
+		"roles/viewer": map[string]bool{
+			"user:a@example.com": true,
+			"user:b@example.com": true,
+		},
+		"roles/owner": map[string]bool{
+			"user:a@example.com": true,
+		},
+	}
+
+	actual := rolesToMembersMap(bindings)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	// Changing one role must leave the others alone
+	delete(actual["roles/viewer"], "user:a@example.com")
+	result := rolesToMembersBindings(actual)
+	expectedBindings := []*cloudresourcemanager.Binding{
+		{Role: "roles/owner", Members: []string{"user:a@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:b@example.com"}},
+	}
+	if !reflect.DeepEqual(result, expectedBindings) {
+		t.Fatalf("expected %#v, got %#v", expectedBindings, result)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/xanzy/terraform-api/helper/mutexkv"
 	"github.com/xanzy/terraform-api/helper/pathorcontents"
 	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/terraform"
@@ -22,12 +23,22 @@ func Provider() terraform.ResourceProvider {
 			},
 
 			"credentials": &schema.Schema{
-				Type:         schema.TypeString,
-				Optional:     true,
-				DefaultFunc:  schema.EnvDefaultFunc("GOOGLE_CREDENTIALS", nil),
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"GOOGLE_CREDENTIALS",
+					"GOOGLE_CLOUD_KEYFILE_JSON",
+					"GCLOUD_KEYFILE_JSON",
+				}, nil),
 				ValidateFunc: validateCredentials,
 			},
 
+			"scopes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"project": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -59,6 +70,7 @@ func Provider() terraform.ResourceProvider {
 			"google_compute_project_metadata":       resourceComputeProjectMetadata(),
 			"google_compute_route":                  resourceComputeRoute(),
 			"google_compute_ssl_certificate":        resourceComputeSslCertificate(),
+			"google_compute_subnetwork":             resourceComputeSubnetwork(),
 			"google_compute_target_http_proxy":      resourceComputeTargetHttpProxy(),
 			"google_compute_target_https_proxy":     resourceComputeTargetHttpsProxy(),
 			"google_compute_target_pool":            resourceComputeTargetPool(),
@@ -73,6 +85,10 @@ func Provider() terraform.ResourceProvider {
 			"google_sql_user":                       resourceSqlUser(),
 			"google_pubsub_topic":                   resourcePubsubTopic(),
 			"google_pubsub_subscription":            resourcePubsubSubscription(),
+			"google_project":                        resourceGoogleProject(),
+			"google_project_iam_policy":             resourceGoogleProjectIamPolicy(),
+			"google_project_iam_binding":            resourceGoogleProjectIamBinding(),
+			"google_project_iam_member":             resourceGoogleProjectIamMember(),
 			"google_storage_bucket":                 resourceStorageBucket(),
 			"google_storage_bucket_acl":             resourceStorageBucketAcl(),
 			"google_storage_bucket_object":          resourceStorageBucketObject(),
@@ -94,6 +110,10 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Region:      d.Get("region").(string),
 	}
 
+	for _, scope := range d.Get("scopes").([]interface{}) {
+		config.Scopes = append(config.Scopes, scope.(string))
+	}
+
 	if err := config.loadAndValidate(); err != nil {
 		return nil, err
 	}
@@ -101,6 +121,18 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	return &config, nil
 }
 
+// This is a global MutexKV for use within this plugin.
+var mutexKV = mutexkv.NewMutexKV()
+
+// getProject returns the project set on the resource, falling back to the
+// project of the provider.
+func getProject(d *schema.ResourceData, config *Config) string {
+	if v, ok := d.GetOk("project"); ok {
+		return v.(string)
+	}
+	return config.Project
+}
+
 func validateAccountFile(v interface{}, k string) (warnings []string, errors []error) {
 	if v == nil {
 		return
@@ -139,7 +171,7 @@ func validateCredentials(v interface{}, k string) (warnings []string, errors []e
 	var account accountFile
 	if err := json.Unmarshal([]byte(creds), &account); err != nil {
 		errors = append(errors,
-			fmt.Errorf("credentials are not valid JSON '%s': %s", creds, err))
+			fmt.Errorf("credentials are not valid JSON: %s", err))
 	}
 
 	return
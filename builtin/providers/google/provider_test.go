@@ -1,10 +1,12 @@
 package google
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/xanzy/terraform-api/helper/resource"
 	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/terraform"
 )
@@ -19,6 +21,10 @@ func init() {
 	}
 }
 
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -50,3 +56,34 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("GOOGLE_REGION must be set to us-central1 for acceptance tests")
 	}
 }
+
+// sharedConfigForRegion returns a Config for the given region using the
+// same credentials as the acceptance tests. It is used by the sweepers,
+// which run outside of the provider.
+func sharedConfigForRegion(region string) (*Config, error) {
+	creds := os.Getenv("GOOGLE_CREDENTIALS")
+	if v := os.Getenv("GOOGLE_CREDENTIALS_FILE"); v != "" {
+		creds = v
+	}
+	if creds == "" {
+		return nil, fmt.Errorf(
+			"GOOGLE_CREDENTIALS or GOOGLE_CREDENTIALS_FILE must be set for sweepers")
+	}
+
+	project := os.Getenv("GOOGLE_PROJECT")
+	if project == "" {
+		return nil, fmt.Errorf("GOOGLE_PROJECT must be set for sweepers")
+	}
+
+	config := &Config{
+		Credentials: creds,
+		Project:     project,
+		Region:      region,
+	}
+
+	if err := config.loadAndValidate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -2,6 +2,8 @@ package google
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"testing"
 
 	"github.com/xanzy/terraform-api/helper/acctest"
@@ -10,6 +12,47 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() {
+	resource.AddTestSweepers("google_compute_address", &resource.Sweeper{
+		Name: "google_compute_address",
+		F:    testSweepComputeAddresses,
+	})
+}
+
+// testSweepComputeAddresses releases the addresses leaked by the
+// acceptance tests, since reserved addresses are billed while unused.
+func testSweepComputeAddresses(region string) error {
+	config, err := sharedConfigForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := config.clientCompute.Addresses.List(config.Project, region).Do()
+	if err != nil {
+		return fmt.Errorf("Error listing addresses: %s", err)
+	}
+
+	for _, addr := range addrs.Items {
+		if !strings.HasPrefix(addr.Name, "address-test-") {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting address: %s", addr.Name)
+		op, err := config.clientCompute.Addresses.Delete(
+			config.Project, region, addr.Name).Do()
+		if err != nil {
+			return fmt.Errorf("Error deleting address %s: %s", addr.Name, err)
+		}
+
+		err = computeOperationWaitRegion(config, op, region, "Deleting Address")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func TestAccComputeAddress_basic(t *testing.T) {
 	var addr compute.Address
 
@@ -13,6 +13,7 @@ func resourceComputeDisk() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceComputeDiskCreate,
 		Read:   resourceComputeDiskRead,
+		Update: resourceComputeDiskUpdate,
 		Delete: resourceComputeDiskDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -56,6 +57,18 @@ func resourceComputeDisk() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"create_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultComputeOperationTimeout,
+			},
+
+			"delete_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultComputeOperationTimeout,
+			},
 		},
 	}
 }
@@ -127,13 +140,19 @@ func resourceComputeDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	// It probably maybe worked, so store the ID now
 	d.SetId(disk.Name)
 
-	err = computeOperationWaitZone(config, op, d.Get("zone").(string), "Creating Disk")
+	err = computeOperationWait(config, op, "Creating Disk", d.Get("create_timeout").(int))
 	if err != nil {
 		return err
 	}
 	return resourceComputeDiskRead(d, meta)
 }
 
+// resourceComputeDiskUpdate only exists so the timeouts can be changed
+// without recreating the disk, every other field forces a new disk.
+func resourceComputeDiskUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceComputeDiskRead(d, meta)
+}
+
 func resourceComputeDiskRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -166,8 +185,7 @@ func resourceComputeDiskDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error deleting disk: %s", err)
 	}
 
-	zone := d.Get("zone").(string)
-	err = computeOperationWaitZone(config, op, zone, "Creating Disk")
+	err = computeOperationWait(config, op, "Deleting Disk", d.Get("delete_timeout").(int))
 	if err != nil {
 		return err
 	}
@@ -120,6 +120,12 @@ func resourceComputeInstance() *schema.Resource {
 							ForceNew: true,
 						},
 
+						"subnetwork": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
 						"name": &schema.Schema{
 							Type:     schema.TypeString,
 							Computed: true,
@@ -280,6 +286,18 @@ func resourceComputeInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"create_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultComputeOperationTimeout,
+			},
+
+			"delete_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  defaultComputeOperationTimeout,
+			},
 		},
 	}
 }
@@ -462,6 +480,19 @@ func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) err
 			var iface compute.NetworkInterface
 			iface.Network = network.SelfLink
 
+			// Custom mode networks need the subnetwork to be given
+			if subnetworkName := d.Get(prefix + ".subnetwork").(string); subnetworkName != "" {
+				region := resourceNameFromLink(zone.Region)
+				subnetwork, err := config.clientCompute.Subnetworks.Get(
+					config.Project, region, subnetworkName).Do()
+				if err != nil {
+					return fmt.Errorf(
+						"Error referencing subnetwork '%s' in region '%s': %s",
+						subnetworkName, region, err)
+				}
+				iface.Subnetwork = subnetwork.SelfLink
+			}
+
 			// Handle access_config structs
 			accessConfigsCount := d.Get(prefix + ".access_config.#").(int)
 			iface.AccessConfigs = make([]*compute.AccessConfig, accessConfigsCount)
@@ -541,7 +572,7 @@ func resourceComputeInstanceCreate(d *schema.ResourceData, meta interface{}) err
 	d.SetId(instance.Name)
 
 	// Wait for the operation to complete
-	waitErr := computeOperationWaitZone(config, op, zone.Name, "instance to create")
+	waitErr := computeOperationWait(config, op, "instance to create", d.Get("create_timeout").(int))
 	if waitErr != nil {
 		// The resource didn't actually create
 		d.SetId("")
@@ -664,6 +695,7 @@ func resourceComputeInstanceRead(d *schema.ResourceData, meta interface{}) error
 				"name":          iface.Name,
 				"address":       iface.NetworkIP,
 				"network":       d.Get(fmt.Sprintf("network_interface.%d.network", i)),
+				"subnetwork":    d.Get(fmt.Sprintf("network_interface.%d.subnetwork", i)),
 				"access_config": accessConfigs,
 			})
 		}
@@ -882,7 +914,7 @@ func resourceComputeInstanceDelete(d *schema.ResourceData, meta interface{}) err
 	}
 
 	// Wait for the operation to complete
-	opErr := computeOperationWaitZone(config, op, zone, "instance to delete")
+	opErr := computeOperationWait(config, op, "instance to delete", d.Get("delete_timeout").(int))
 	if opErr != nil {
 		return opErr
 	}
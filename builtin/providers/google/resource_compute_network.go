@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/helper/validation"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 )
@@ -22,9 +23,27 @@ func resourceComputeNetwork() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// mode is one of "legacy", "auto" or "custom". Legacy networks
+			// have a single range, auto networks get a subnetwork in every
+			// region and custom networks only have the subnetworks that are
+			// created for them.
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"legacy", "auto", "custom"}, false),
+			},
+
 			"ipv4_range": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
 				ForceNew: true,
 			},
 
@@ -37,6 +56,12 @@ func resourceComputeNetwork() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"subnetworks": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -44,10 +69,34 @@ func resourceComputeNetwork() *schema.Resource {
 func resourceComputeNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	ipv4Range := d.Get("ipv4_range").(string)
+	mode := d.Get("mode").(string)
+	if mode == "" {
+		// Networks used to always be legacy networks, so keep creating
+		// those when only a range is given.
+		mode = "auto"
+		if ipv4Range != "" {
+			mode = "legacy"
+		}
+	}
+
+	if mode == "legacy" && ipv4Range == "" {
+		return fmt.Errorf("ipv4_range is required for legacy networks")
+	}
+	if mode != "legacy" && ipv4Range != "" {
+		return fmt.Errorf("ipv4_range can only be set for legacy networks, not %s networks", mode)
+	}
+
 	// Build the network parameter
 	network := &compute.Network{
-		Name:      d.Get("name").(string),
-		IPv4Range: d.Get("ipv4_range").(string),
+		Name:        d.Get("name").(string),
+		IPv4Range:   ipv4Range,
+		Description: d.Get("description").(string),
+	}
+	if mode != "legacy" {
+		network.AutoCreateSubnetworks = mode == "auto"
+		// Custom mode is the zero value, so it has to be sent explicitly
+		network.ForceSendFields = []string{"AutoCreateSubnetworks"}
 	}
 	log.Printf("[DEBUG] Network insert request: %#v", network)
 	op, err := config.clientCompute.Networks.Insert(
@@ -84,8 +133,19 @@ func resourceComputeNetworkRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error reading network: %s", err)
 	}
 
+	mode := "custom"
+	if network.IPv4Range != "" {
+		mode = "legacy"
+	} else if network.AutoCreateSubnetworks {
+		mode = "auto"
+	}
+
+	d.Set("mode", mode)
+	d.Set("ipv4_range", network.IPv4Range)
+	d.Set("description", network.Description)
 	d.Set("gateway_ipv4", network.GatewayIPv4)
 	d.Set("self_link", network.SelfLink)
+	d.Set("subnetworks", network.Subnetworks)
 
 	return nil
 }
@@ -29,6 +29,28 @@ func TestAccComputeNetwork_basic(t *testing.T) {
 	})
 }
 
+func TestAccComputeNetwork_custom(t *testing.T) {
+	var network compute.Network
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeNetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeNetwork_custom,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeNetworkExists(
+						"google_compute_network.foobar", &network),
+					testAccCheckComputeNetworkIsCustom(&network),
+					resource.TestCheckResourceAttr(
+						"google_compute_network.foobar", "mode", "custom"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckComputeNetworkDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -76,8 +98,24 @@ func testAccCheckComputeNetworkExists(n string, network *compute.Network) resour
 	}
 }
 
+func testAccCheckComputeNetworkIsCustom(network *compute.Network) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if network.IPv4Range != "" || network.AutoCreateSubnetworks {
+			return fmt.Errorf("Expected a custom mode network, got: %#v", network)
+		}
+
+		return nil
+	}
+}
+
 var testAccComputeNetwork_basic = fmt.Sprintf(`
 resource "google_compute_network" "foobar" {
 	name = "network-test-%s"
 	ipv4_range = "10.0.0.0/16"
 }`, acctest.RandString(10))
+
+var testAccComputeNetwork_custom = fmt.Sprintf(`
+resource "google_compute_network" "foobar" {
+	name = "network-test-%s"
+	mode = "custom"
+}`, acctest.RandString(10))
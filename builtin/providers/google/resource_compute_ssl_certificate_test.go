@@ -9,8 +9,10 @@ import (
 	"github.com/xanzy/terraform-api/terraform"
 )
 
+var testAccComputeSslCertificatePrefix = acctest.RegisterPrefix("sslcert-test")
+
 func TestAccComputeSslCertificate_basic(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckComputeSslCertificateDestroy,
@@ -46,9 +48,9 @@ func testAccCheckComputeSslCertificateDestroy(s *terraform.State) error {
 
 func testAccCheckComputeSslCertificateExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Not found: %s", n)
+		rs, err := s.ResourceByAddress(n)
+		if err != nil {
+			return err
 		}
 
 		if rs.Primary.ID == "" {
@@ -73,9 +75,9 @@ func testAccCheckComputeSslCertificateExists(n string) resource.TestCheckFunc {
 
 var testAccComputeSslCertificate_basic = fmt.Sprintf(`
 resource "google_compute_ssl_certificate" "foobar" {
-	name = "sslcert-test-%s"
+	name = "%s"
 	description = "very descriptive"
 	private_key = "${file("test-fixtures/ssl_cert/test.key")}"
 	certificate = "${file("test-fixtures/ssl_cert/test.crt")}"
 }
-`, acctest.RandString(10))
+`, acctest.RandomWithPrefix(testAccComputeSslCertificatePrefix))
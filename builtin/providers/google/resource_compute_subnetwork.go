@@ -0,0 +1,199 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceComputeSubnetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeSubnetworkCreate,
+		Read:   resourceComputeSubnetworkRead,
+		Delete: resourceComputeSubnetworkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"network": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ip_cidr_range": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"secondary_ip_range": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"range_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"ip_cidr_range": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"gateway_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeSubnetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region := getOptionalRegion(d, config)
+
+	network, err := getNetworkLink(d, config, "network")
+	if err != nil {
+		return err
+	}
+
+	// Build the subnetwork parameter
+	subnetwork := &compute.Subnetwork{
+		Name:        d.Get("name").(string),
+		Network:     network,
+		IpCidrRange: d.Get("ip_cidr_range").(string),
+		Description: d.Get("description").(string),
+	}
+
+	for _, raw := range d.Get("secondary_ip_range").([]interface{}) {
+		r := raw.(map[string]interface{})
+		subnetwork.SecondaryIpRanges = append(subnetwork.SecondaryIpRanges, &compute.SubnetworkSecondaryRange{
+			RangeName:   r["range_name"].(string),
+			IpCidrRange: r["ip_cidr_range"].(string),
+		})
+	}
+
+	log.Printf("[DEBUG] Subnetwork insert request: %#v", subnetwork)
+	op, err := config.clientCompute.Subnetworks.Insert(
+		config.Project, region, subnetwork).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating subnetwork: %s", err)
+	}
+
+	// It probably maybe worked, so store the ID now
+	d.SetId(subnetwork.Name)
+
+	err = computeOperationWaitRegion(config, op, region, "Creating Subnetwork")
+	if err != nil {
+		return err
+	}
+
+	return resourceComputeSubnetworkRead(d, meta)
+}
+
+func resourceComputeSubnetworkRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region := getOptionalRegion(d, config)
+
+	subnetwork, err := config.clientCompute.Subnetworks.Get(
+		config.Project, region, d.Id()).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing Subnetwork %q because it's gone", d.Get("name").(string))
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading subnetwork: %s", err)
+	}
+
+	secondaryRanges := make([]map[string]interface{}, 0, len(subnetwork.SecondaryIpRanges))
+	for _, r := range subnetwork.SecondaryIpRanges {
+		secondaryRanges = append(secondaryRanges, map[string]interface{}{
+			"range_name":    r.RangeName,
+			"ip_cidr_range": r.IpCidrRange,
+		})
+	}
+
+	d.Set("ip_cidr_range", subnetwork.IpCidrRange)
+	d.Set("description", subnetwork.Description)
+	d.Set("secondary_ip_range", secondaryRanges)
+	d.Set("gateway_address", subnetwork.GatewayAddress)
+	d.Set("self_link", subnetwork.SelfLink)
+
+	return nil
+}
+
+func resourceComputeSubnetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region := getOptionalRegion(d, config)
+
+	// Delete the subnetwork
+	op, err := config.clientCompute.Subnetworks.Delete(
+		config.Project, region, d.Id()).Do()
+	if err != nil {
+		return fmt.Errorf("Error deleting subnetwork: %s", err)
+	}
+
+	err = computeOperationWaitRegion(config, op, region, "Deleting Subnetwork")
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getNetworkLink returns the self link of the network in field, which can
+// either be the name of a network in the project or a self link already.
+func getNetworkLink(d *schema.ResourceData, config *Config, field string) (string, error) {
+	name := d.Get(field).(string)
+	if strings.HasPrefix(name, "https://www.googleapis.com/compute/") {
+		return name, nil
+	}
+
+	network, err := config.clientCompute.Networks.Get(config.Project, name).Do()
+	if err != nil {
+		return "", fmt.Errorf("Error loading network '%s': %s", name, err)
+	}
+
+	return network.SelfLink, nil
+}
@@ -0,0 +1,100 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestAccComputeSubnetwork_basic(t *testing.T) {
+	var subnetwork compute.Subnetwork
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeSubnetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeSubnetwork_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeSubnetworkExists(
+						"google_compute_subnetwork.foobar", &subnetwork),
+					resource.TestCheckResourceAttr(
+						"google_compute_subnetwork.foobar", "ip_cidr_range", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr(
+						"google_compute_subnetwork.foobar", "secondary_ip_range.#", "1"),
+					resource.TestCheckResourceAttr(
+						"google_compute_subnetwork.foobar", "secondary_ip_range.0.range_name", "pods"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeSubnetworkDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_compute_subnetwork" {
+			continue
+		}
+
+		_, err := config.clientCompute.Subnetworks.Get(
+			config.Project, config.Region, rs.Primary.ID).Do()
+		if err == nil {
+			return fmt.Errorf("Subnetwork still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeSubnetworkExists(n string, subnetwork *compute.Subnetwork) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+
+		found, err := config.clientCompute.Subnetworks.Get(
+			config.Project, config.Region, rs.Primary.ID).Do()
+		if err != nil {
+			return err
+		}
+
+		if found.Name != rs.Primary.ID {
+			return fmt.Errorf("Subnetwork not found")
+		}
+
+		*subnetwork = *found
+
+		return nil
+	}
+}
+
+var testAccComputeSubnetwork_basic = fmt.Sprintf(`
+resource "google_compute_network" "custom" {
+	name = "network-test-%s"
+	mode = "custom"
+}
+
+resource "google_compute_subnetwork" "foobar" {
+	name = "subnetwork-test-%s"
+	network = "${google_compute_network.custom.self_link}"
+	ip_cidr_range = "10.0.0.0/16"
+
+	secondary_ip_range {
+		range_name = "pods"
+		ip_cidr_range = "10.1.0.0/16"
+	}
+}`, acctest.RandString(10), acctest.RandString(10))
@@ -3,7 +3,6 @@ package google
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/xanzy/terraform-api/helper/schema"
 	"google.golang.org/api/dns/v1"
@@ -14,6 +13,7 @@ func resourceDnsRecordSet() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDnsRecordSetCreate,
 		Read:   resourceDnsRecordSetRead,
+		Update: resourceDnsRecordSetUpdate,
 		Delete: resourceDnsRecordSetDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -38,16 +38,17 @@ func resourceDnsRecordSet() *schema.Resource {
 			"ttl": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
+			// Cloud DNS doesn't keep the order of the records, so they are
+			// a set to not show a diff when they come back reordered.
 			"rrdatas": &schema.Schema{
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				Set: schema.HashString,
 			},
 		},
 	}
@@ -58,25 +59,13 @@ func resourceDnsRecordSetCreate(d *schema.ResourceData, meta interface{}) error
 
 	zone := d.Get("managed_zone").(string)
 
-	rrdatasCount := d.Get("rrdatas.#").(int)
-
 	// Build the change
 	chg := &dns.Change{
 		Additions: []*dns.ResourceRecordSet{
-			&dns.ResourceRecordSet{
-				Name:    d.Get("name").(string),
-				Type:    d.Get("type").(string),
-				Ttl:     int64(d.Get("ttl").(int)),
-				Rrdatas: make([]string, rrdatasCount),
-			},
+			buildDnsRecordSet(d, d.Get("ttl"), d.Get("rrdatas")),
 		},
 	}
 
-	for i := 0; i < rrdatasCount; i++ {
-		rrdata := fmt.Sprintf("rrdatas.%d", i)
-		chg.Additions[0].Rrdatas[i] = d.Get(rrdata).(string)
-	}
-
 	log.Printf("[DEBUG] DNS Record create request: %#v", chg)
 	chg, err := config.clientDns.Changes.Create(config.Project, zone, chg).Do()
 	if err != nil {
@@ -85,19 +74,8 @@ func resourceDnsRecordSetCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(chg.Id)
 
-	w := &DnsChangeWaiter{
-		Service:     config.clientDns,
-		Change:      chg,
-		Project:     config.Project,
-		ManagedZone: zone,
-	}
-	state := w.Conf()
-	state.Delay = 10 * time.Second
-	state.Timeout = 10 * time.Minute
-	state.MinTimeout = 2 * time.Second
-	_, err = state.WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
+	if err := dnsChangeWait(config, chg, zone); err != nil {
+		return err
 	}
 
 	return resourceDnsRecordSetRead(d, meta)
@@ -141,50 +119,71 @@ func resourceDnsRecordSetRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceDnsRecordSetDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceDnsRecordSetUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
 	zone := d.Get("managed_zone").(string)
 
-	rrdatasCount := d.Get("rrdatas.#").(int)
+	oldTtl, newTtl := d.GetChange("ttl")
+	oldRrdatas, newRrdatas := d.GetChange("rrdatas")
 
-	// Build the change
+	// Replacing the record set within a single change applies atomically,
+	// so the name never stops resolving.
 	chg := &dns.Change{
 		Deletions: []*dns.ResourceRecordSet{
-			&dns.ResourceRecordSet{
-				Name:    d.Get("name").(string),
-				Type:    d.Get("type").(string),
-				Ttl:     int64(d.Get("ttl").(int)),
-				Rrdatas: make([]string, rrdatasCount),
-			},
+			buildDnsRecordSet(d, oldTtl, oldRrdatas),
+		},
+		Additions: []*dns.ResourceRecordSet{
+			buildDnsRecordSet(d, newTtl, newRrdatas),
 		},
 	}
 
-	for i := 0; i < rrdatasCount; i++ {
-		rrdata := fmt.Sprintf("rrdatas.%d", i)
-		chg.Deletions[0].Rrdatas[i] = d.Get(rrdata).(string)
+	log.Printf("[DEBUG] DNS Record update request: %#v", chg)
+	chg, err := config.clientDns.Changes.Create(config.Project, zone, chg).Do()
+	if err != nil {
+		return fmt.Errorf("Error updating DNS RecordSet: %s", err)
 	}
+
+	if err := dnsChangeWait(config, chg, zone); err != nil {
+		return err
+	}
+
+	return resourceDnsRecordSetRead(d, meta)
+}
+
+func resourceDnsRecordSetDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	zone := d.Get("managed_zone").(string)
+
+	// Build the change
+	chg := &dns.Change{
+		Deletions: []*dns.ResourceRecordSet{
+			buildDnsRecordSet(d, d.Get("ttl"), d.Get("rrdatas")),
+		},
+	}
+
 	log.Printf("[DEBUG] DNS Record delete request: %#v", chg)
 	chg, err := config.clientDns.Changes.Create(config.Project, zone, chg).Do()
 	if err != nil {
 		return fmt.Errorf("Error deleting DNS RecordSet: %s", err)
 	}
 
-	w := &DnsChangeWaiter{
-		Service:     config.clientDns,
-		Change:      chg,
-		Project:     config.Project,
-		ManagedZone: zone,
-	}
-	state := w.Conf()
-	state.Delay = 10 * time.Second
-	state.Timeout = 10 * time.Minute
-	state.MinTimeout = 2 * time.Second
-	_, err = state.WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
+	if err := dnsChangeWait(config, chg, zone); err != nil {
+		return err
 	}
 
 	d.SetId("")
 	return nil
 }
+
+// buildDnsRecordSet returns the record set of the resource with the given
+// ttl and rrdatas, which can be either the old or the new values.
+func buildDnsRecordSet(d *schema.ResourceData, ttl, rrdatas interface{}) *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{
+		Name:    d.Get("name").(string),
+		Type:    d.Get("type").(string),
+		Ttl:     int64(ttl.(int)),
+		Rrdatas: convertStringArr(rrdatas.(*schema.Set).List()),
+	}
+}
@@ -27,6 +27,35 @@ func TestAccDnsRecordSet_basic(t *testing.T) {
 	})
 }
 
+func TestAccDnsRecordSet_update(t *testing.T) {
+	zoneName := fmt.Sprintf("dnszone-test-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDnsRecordSetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDnsRecordSet_rrdatas(zoneName, `"127.0.0.1", "127.0.0.10"`, 300),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDnsRecordSetExists(
+						"google_dns_record_set.foobar", zoneName),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDnsRecordSet_rrdatas(zoneName, `"127.0.0.1", "127.0.0.11"`, 600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDnsRecordSetExists(
+						"google_dns_record_set.foobar", zoneName),
+					resource.TestCheckResourceAttr(
+						"google_dns_record_set.foobar", "ttl", "600"),
+					resource.TestCheckResourceAttr(
+						"google_dns_record_set.foobar", "rrdatas.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDnsRecordSetDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -94,3 +123,20 @@ func testAccDnsRecordSet_basic(zoneName string) string {
 	}
 	`, zoneName)
 }
+
+func testAccDnsRecordSet_rrdatas(zoneName, rrdatas string, ttl int) string {
+	return fmt.Sprintf(`
+	resource "google_dns_managed_zone" "parent-zone" {
+		name = "%s"
+		dns_name = "terraform.test."
+		description = "Test Description"
+	}
+	resource "google_dns_record_set" "foobar" {
+		managed_zone = "${google_dns_managed_zone.parent-zone.name}"
+		name = "test-record.terraform.test."
+		type = "A"
+		rrdatas = [%s]
+		ttl = %d
+	}
+	`, zoneName, rrdatas, ttl)
+}
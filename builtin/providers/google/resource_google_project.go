@@ -0,0 +1,145 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceGoogleProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleProjectCreate,
+		Read:   resourceGoogleProjectRead,
+		Update: resourceGoogleProjectUpdate,
+		Delete: resourceGoogleProjectDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"org_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"number": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleProjectCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	pid := d.Get("project_id").(string)
+	project := &cloudresourcemanager.Project{
+		ProjectId: pid,
+		Name:      d.Get("name").(string),
+	}
+
+	if v, ok := d.GetOk("org_id"); ok {
+		project.Parent = &cloudresourcemanager.ResourceId{
+			Id:   v.(string),
+			Type: "organization",
+		}
+	}
+
+	log.Printf("[DEBUG] Creating project %q", pid)
+	op, err := config.clientResourceManager.Projects.Create(project).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating project %q: %s", pid, err)
+	}
+
+	d.SetId(pid)
+
+	if err := resourceManagerOperationWait(config, op, "project to create"); err != nil {
+		// The project was never created, so there is nothing to track
+		d.SetId("")
+		return err
+	}
+
+	return resourceGoogleProjectRead(d, meta)
+}
+
+func resourceGoogleProjectRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	pid := d.Id()
+	project, err := config.clientResourceManager.Projects.Get(pid).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing Project %q because it's gone", pid)
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Project %q: %s", pid, err)
+	}
+
+	// Deleted projects linger for a while before they are purged, but they
+	// can't be used anymore.
+	if project.LifecycleState == "DELETE_REQUESTED" {
+		log.Printf("[WARN] Removing Project %q because it's pending deletion", pid)
+		d.SetId("")
+
+		return nil
+	}
+
+	d.Set("project_id", project.ProjectId)
+	d.Set("name", project.Name)
+	d.Set("number", strconv.FormatInt(project.ProjectNumber, 10))
+
+	if project.Parent != nil && project.Parent.Type == "organization" {
+		d.Set("org_id", project.Parent.Id)
+	}
+
+	return nil
+}
+
+func resourceGoogleProjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	pid := d.Id()
+	if d.HasChange("name") {
+		project, err := config.clientResourceManager.Projects.Get(pid).Do()
+		if err != nil {
+			return fmt.Errorf("Error reading Project %q: %s", pid, err)
+		}
+
+		project.Name = d.Get("name").(string)
+		if _, err := config.clientResourceManager.Projects.Update(pid, project).Do(); err != nil {
+			return fmt.Errorf("Error updating name of Project %q: %s", pid, err)
+		}
+	}
+
+	return resourceGoogleProjectRead(d, meta)
+}
+
+func resourceGoogleProjectDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	pid := d.Id()
+	if _, err := config.clientResourceManager.Projects.Delete(pid).Do(); err != nil {
+		return fmt.Errorf("Error deleting Project %q: %s", pid, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,143 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func resourceGoogleProjectIamBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleProjectIamBindingCreate,
+		Read:   resourceGoogleProjectIamBindingRead,
+		Update: resourceGoogleProjectIamBindingUpdate,
+		Delete: resourceGoogleProjectIamBindingDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"members": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleProjectIamBindingCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := getProject(d, config)
+	role := d.Get("role").(string)
+
+	if err := setProjectIamBindingMembers(d, config, project, role); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", project, role))
+	d.Set("project", project)
+
+	return resourceGoogleProjectIamBindingRead(d, meta)
+}
+
+func resourceGoogleProjectIamBindingRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Get("project").(string)
+	role := d.Get("role").(string)
+
+	p, err := getProjectIamPolicy(config, project)
+	if err != nil {
+		return err
+	}
+
+	members := rolesToMembersMap(p.Bindings)[role]
+	if len(members) == 0 {
+		log.Printf("[WARN] Removing IAM binding %q because it's gone", d.Id())
+		// The resource doesn't exist anymore
+		d.SetId("")
+
+		return nil
+	}
+
+	list := make([]string, 0, len(members))
+	for member := range members {
+		list = append(list, member)
+	}
+
+	d.Set("members", list)
+	d.Set("etag", p.Etag)
+
+	return nil
+}
+
+func resourceGoogleProjectIamBindingUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("members") {
+		project := d.Get("project").(string)
+		role := d.Get("role").(string)
+
+		if err := setProjectIamBindingMembers(d, config, project, role); err != nil {
+			return err
+		}
+	}
+
+	return resourceGoogleProjectIamBindingRead(d, meta)
+}
+
+func resourceGoogleProjectIamBindingDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Get("project").(string)
+	role := d.Get("role").(string)
+
+	err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		m := rolesToMembersMap(p.Bindings)
+		delete(m, role)
+		p.Bindings = rolesToMembersBindings(m)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// setProjectIamBindingMembers makes the configured members the only members
+// of role, leaving the bindings of all other roles untouched.
+func setProjectIamBindingMembers(d *schema.ResourceData, config *Config, project, role string) error {
+	members := make(map[string]bool)
+	for _, member := range d.Get("members").(*schema.Set).List() {
+		members[member.(string)] = true
+	}
+
+	return projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		m := rolesToMembersMap(p.Bindings)
+		m[role] = members
+		p.Bindings = rolesToMembersBindings(m)
+		return nil
+	})
+}
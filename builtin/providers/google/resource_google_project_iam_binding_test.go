@@ -0,0 +1,40 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccGoogleProjectIamBinding_basic(t *testing.T) {
+	pid := "terraform-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccGoogleProjectPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleProjectIamBinding(pid, `"user:admin@example.com"`),
+				Check: testAccCheckGoogleProjectIamMembers(pid, "roles/viewer",
+					[]string{"user:admin@example.com"}),
+			},
+			resource.TestStep{
+				Config: testAccGoogleProjectIamBinding(pid, `"user:admin@example.com", "user:ops@example.com"`),
+				Check: testAccCheckGoogleProjectIamMembers(pid, "roles/viewer",
+					[]string{"user:admin@example.com", "user:ops@example.com"}),
+			},
+		},
+	})
+}
+
+func testAccGoogleProjectIamBinding(pid, members string) string {
+	return testAccGoogleProject_create(pid, "Terraform Acceptance Tests") + fmt.Sprintf(`
+
+resource "google_project_iam_binding" "acceptance" {
+	project = "${google_project.acceptance.project_id}"
+	role = "roles/viewer"
+	members = [%s]
+}`, members)
+}
@@ -0,0 +1,115 @@
+package google
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func resourceGoogleProjectIamMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleProjectIamMemberCreate,
+		Read:   resourceGoogleProjectIamMemberRead,
+		Delete: resourceGoogleProjectIamMemberDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"member": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleProjectIamMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := getProject(d, config)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		m := rolesToMembersMap(p.Bindings)
+		if _, ok := m[role]; !ok {
+			m[role] = make(map[string]bool)
+		}
+		m[role][member] = true
+		p.Bindings = rolesToMembersBindings(m)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", project, role, member))
+	d.Set("project", project)
+
+	return resourceGoogleProjectIamMemberRead(d, meta)
+}
+
+func resourceGoogleProjectIamMemberRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Get("project").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	p, err := getProjectIamPolicy(config, project)
+	if err != nil {
+		return err
+	}
+
+	if !rolesToMembersMap(p.Bindings)[role][member] {
+		log.Printf("[WARN] Removing IAM member %q because it's gone", d.Id())
+		// The resource doesn't exist anymore
+		d.SetId("")
+
+		return nil
+	}
+
+	d.Set("etag", p.Etag)
+
+	return nil
+}
+
+func resourceGoogleProjectIamMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Get("project").(string)
+	role := d.Get("role").(string)
+	member := d.Get("member").(string)
+
+	err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		m := rolesToMembersMap(p.Bindings)
+		delete(m[role], member)
+		p.Bindings = rolesToMembersBindings(m)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,40 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccGoogleProjectIamMember_multiple(t *testing.T) {
+	pid := "terraform-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccGoogleProjectPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleProjectIamMember_multiple(pid),
+				Check: testAccCheckGoogleProjectIamMembers(pid, "roles/viewer",
+					[]string{"user:admin@example.com", "user:ops@example.com"}),
+			},
+		},
+	})
+}
+
+func testAccGoogleProjectIamMember_multiple(pid string) string {
+	return testAccGoogleProject_create(pid, "Terraform Acceptance Tests") + `
+
+resource "google_project_iam_member" "admin" {
+	project = "${google_project.acceptance.project_id}"
+	role = "roles/viewer"
+	member = "user:admin@example.com"
+}
+
+resource "google_project_iam_member" "ops" {
+	project = "${google_project.acceptance.project_id}"
+	role = "roles/viewer"
+	member = "user:ops@example.com"
+}`
+}
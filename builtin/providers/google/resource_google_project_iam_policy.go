@@ -0,0 +1,150 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func resourceGoogleProjectIamPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGoogleProjectIamPolicyCreate,
+		Read:   resourceGoogleProjectIamPolicyRead,
+		Update: resourceGoogleProjectIamPolicyUpdate,
+		Delete: resourceGoogleProjectIamPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"binding": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"members": &schema.Schema{
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The bindings the project had before this resource took over
+			// its policy, restored when the resource is destroyed.
+			"restore_policy": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGoogleProjectIamPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := getProject(d, config)
+	bindings := expandIamBindings(d.Get("binding").(*schema.Set).List())
+
+	var restore []byte
+	err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		var err error
+		restore, err = json.Marshal(mergeBindings(p.Bindings))
+		if err != nil {
+			return fmt.Errorf("Error saving IAM policy of project %q: %s", project, err)
+		}
+
+		p.Bindings = bindings
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(project)
+	d.Set("restore_policy", string(restore))
+
+	return resourceGoogleProjectIamPolicyRead(d, meta)
+}
+
+func resourceGoogleProjectIamPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Id()
+	p, err := getProjectIamPolicy(config, project)
+	if err != nil {
+		return err
+	}
+
+	d.Set("project", project)
+	d.Set("binding", flattenIamBindings(mergeBindings(p.Bindings)))
+	d.Set("etag", p.Etag)
+
+	return nil
+}
+
+func resourceGoogleProjectIamPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("binding") {
+		project := d.Id()
+		bindings := expandIamBindings(d.Get("binding").(*schema.Set).List())
+
+		err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+			p.Bindings = bindings
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceGoogleProjectIamPolicyRead(d, meta)
+}
+
+func resourceGoogleProjectIamPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project := d.Id()
+	restore := d.Get("restore_policy").(string)
+	if restore == "" {
+		log.Printf("[WARN] No policy to restore for project %q, leaving the IAM policy as is", project)
+		d.SetId("")
+		return nil
+	}
+
+	var bindings []*cloudresourcemanager.Binding
+	if err := json.Unmarshal([]byte(restore), &bindings); err != nil {
+		return fmt.Errorf("Error parsing the IAM policy to restore for project %q: %s", project, err)
+	}
+
+	err := projectIamPolicyReadModifyWrite(config, project, func(p *cloudresourcemanager.Policy) error {
+		p.Bindings = bindings
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
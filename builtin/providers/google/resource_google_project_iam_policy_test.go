@@ -0,0 +1,57 @@
+package google
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccGoogleProjectIamPolicy_basic(t *testing.T) {
+	pid := "terraform-" + acctest.RandString(10)
+	owner := testAccGoogleProjectIamPolicyOwner()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccGoogleProjectPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleProjectIamPolicy_basic(pid, owner),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleProjectIamMembers(pid, "roles/viewer",
+						[]string{"user:admin@example.com"}),
+					testAccCheckGoogleProjectIamMembers(pid, "roles/owner",
+						[]string{owner}),
+				),
+			},
+		},
+	})
+}
+
+// testAccGoogleProjectIamPolicyOwner returns the service account the tests
+// run as, which has to stay owner of the projects it manages.
+func testAccGoogleProjectIamPolicyOwner() string {
+	var account accountFile
+	parseJSON(&account, os.Getenv("GOOGLE_CREDENTIALS"))
+	return "serviceAccount:" + account.ClientEmail
+}
+
+func testAccGoogleProjectIamPolicy_basic(pid, owner string) string {
+	return testAccGoogleProject_create(pid, "Terraform Acceptance Tests") + fmt.Sprintf(`
+
+resource "google_project_iam_policy" "acceptance" {
+	project = "${google_project.acceptance.project_id}"
+
+	binding {
+		role = "roles/owner"
+		members = ["%s"]
+	}
+
+	binding {
+		role = "roles/viewer"
+		members = ["user:admin@example.com"]
+	}
+}`, owner)
+}
@@ -0,0 +1,129 @@
+package google
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccGoogleProject_create(t *testing.T) {
+	pid := "terraform-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccGoogleProjectPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGoogleProjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccGoogleProject_create(pid, "Terraform Acceptance Tests"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleProjectExists("google_project.acceptance"),
+					resource.TestCheckResourceAttr(
+						"google_project.acceptance", "project_id", pid),
+				),
+			},
+			resource.TestStep{
+				Config: testAccGoogleProject_create(pid, "Terraform Acceptance Tests Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleProjectExists("google_project.acceptance"),
+					resource.TestCheckResourceAttr(
+						"google_project.acceptance", "name", "Terraform Acceptance Tests Updated"),
+				),
+			},
+		},
+	})
+}
+
+// testAccGoogleProjectPreCheck skips tests that create projects unless an
+// organization to create them in is given.
+func testAccGoogleProjectPreCheck(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("GOOGLE_ORG"); v == "" {
+		t.Skip("GOOGLE_ORG must be set for tests that create projects")
+	}
+}
+
+func testAccCheckGoogleProjectDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_project" {
+			continue
+		}
+
+		project, err := config.clientResourceManager.Projects.Get(rs.Primary.ID).Do()
+		if err == nil && project.LifecycleState != "DELETE_REQUESTED" {
+			return fmt.Errorf("Project %q still present", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckGoogleProjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		project, err := config.clientResourceManager.Projects.Get(rs.Primary.ID).Do()
+		if err != nil {
+			return fmt.Errorf("Project not found: %s", err)
+		}
+
+		if project.ProjectId != rs.Primary.ID {
+			return fmt.Errorf("Expected project %q, got %q", rs.Primary.ID, project.ProjectId)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckGoogleProjectIamMembers checks that role has exactly the given
+// members in the IAM policy of project.
+func testAccCheckGoogleProjectIamMembers(pid, role string, members []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+		p, err := getProjectIamPolicy(config, pid)
+		if err != nil {
+			return err
+		}
+
+		var actual []string
+		for member := range rolesToMembersMap(p.Bindings)[role] {
+			actual = append(actual, member)
+		}
+		sort.Strings(actual)
+
+		expected := append([]string{}, members...)
+		sort.Strings(expected)
+
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("Expected members of %q to be %v, got %v", role, expected, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccGoogleProject_create(pid, name string) string {
+	return fmt.Sprintf(`
+resource "google_project" "acceptance" {
+	project_id = "%s"
+	name = "%s"
+	org_id = "%s"
+}`, pid, name, os.Getenv("GOOGLE_ORG"))
+}
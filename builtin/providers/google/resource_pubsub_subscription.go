@@ -2,8 +2,10 @@ package google
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/pubsub/v1"
 )
 
@@ -11,6 +13,7 @@ func resourcePubsubSubscription() *schema.Resource {
 	return &schema.Resource{
 		Create: resourcePubsubSubscriptionCreate,
 		Read:   resourcePubsubSubscriptionRead,
+		Update: resourcePubsubSubscriptionUpdate,
 		Delete: resourcePubsubSubscriptionDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -23,26 +26,28 @@ func resourcePubsubSubscription() *schema.Resource {
 			"ack_deadline_seconds": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 
+			// The push configuration is the only part of a subscription that
+			// can be changed in place, an empty one turns it into a pull
+			// subscription.
 			"push_config": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"attributes": &schema.Schema{
 							Type:     schema.TypeMap,
 							Optional: true,
-							ForceNew: true,
 							Elem:     schema.TypeString,
 						},
 
 						"push_endpoint": &schema.Schema{
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 						},
 					},
 				},
@@ -75,24 +80,13 @@ func resourcePubsubSubscriptionCreate(d *schema.ResourceData, meta interface{})
 	var ackDeadlineSeconds int64
 	ackDeadlineSeconds = 10
 	if v, ok := d.GetOk("ack_deadline_seconds"); ok {
-		ackDeadlineSeconds = v.(int64)
+		ackDeadlineSeconds = int64(v.(int))
 	}
 
-	var subscription *pubsub.Subscription
-	if v, ok := d.GetOk("push_config"); ok {
-		push_configs := v.([]interface{})
-
-		if len(push_configs) > 1 {
-			return fmt.Errorf("At most one PushConfig is allowed per subscription!")
-		}
-
-		push_config := push_configs[0].(map[string]interface{})
-		attributes := push_config["attributes"].(map[string]interface{})
-		attributesClean := cleanAdditionalArgs(attributes)
-		pushConfig := &pubsub.PushConfig{Attributes: attributesClean, PushEndpoint: push_config["push_endpoint"].(string)}
-		subscription = &pubsub.Subscription{AckDeadlineSeconds: ackDeadlineSeconds, Topic: computed_topic_name, PushConfig: pushConfig}
-	} else {
-		subscription = &pubsub.Subscription{AckDeadlineSeconds: ackDeadlineSeconds, Topic: computed_topic_name}
+	subscription := &pubsub.Subscription{
+		AckDeadlineSeconds: ackDeadlineSeconds,
+		Topic:              computed_topic_name,
+		PushConfig:         expandPubsubPushConfig(d.Get("push_config").([]interface{})),
 	}
 
 	call := config.clientPubsub.Projects.Subscriptions.Create(name, subscription)
@@ -103,7 +97,7 @@ func resourcePubsubSubscriptionCreate(d *schema.ResourceData, meta interface{})
 
 	d.SetId(res.Name)
 
-	return nil
+	return resourcePubsubSubscriptionRead(d, meta)
 }
 
 func resourcePubsubSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
@@ -111,14 +105,41 @@ func resourcePubsubSubscriptionRead(d *schema.ResourceData, meta interface{}) er
 
 	name := d.Id()
 	call := config.clientPubsub.Projects.Subscriptions.Get(name)
-	_, err := call.Do()
+	res, err := call.Do()
 	if err != nil {
-		return err
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing Pubsub Subscription %q because it's gone", d.Get("name").(string))
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Pubsub Subscription %q: %s", name, err)
 	}
 
+	d.Set("ack_deadline_seconds", res.AckDeadlineSeconds)
+
 	return nil
 }
 
+func resourcePubsubSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	if d.HasChange("push_config") {
+		req := &pubsub.ModifyPushConfigRequest{
+			PushConfig: expandPubsubPushConfig(d.Get("push_config").([]interface{})),
+		}
+
+		call := config.clientPubsub.Projects.Subscriptions.ModifyPushConfig(d.Id(), req)
+		if _, err := call.Do(); err != nil {
+			return fmt.Errorf("Error updating push config of Pubsub Subscription %q: %s", d.Id(), err)
+		}
+	}
+
+	return resourcePubsubSubscriptionRead(d, meta)
+}
+
 func resourcePubsubSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
@@ -131,3 +152,18 @@ func resourcePubsubSubscriptionDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+// expandPubsubPushConfig returns the push configuration of a subscription,
+// or an empty one for a pull subscription.
+func expandPubsubPushConfig(configured []interface{}) *pubsub.PushConfig {
+	if len(configured) == 0 || configured[0] == nil {
+		return &pubsub.PushConfig{}
+	}
+
+	pushConfig := configured[0].(map[string]interface{})
+	attributes, _ := pushConfig["attributes"].(map[string]interface{})
+	return &pubsub.PushConfig{
+		Attributes:   cleanAdditionalArgs(attributes),
+		PushEndpoint: pushConfig["push_endpoint"].(string),
+	}
+}
@@ -27,6 +27,37 @@ func TestAccPubsubSubscriptionCreate(t *testing.T) {
 	})
 }
 
+func TestAccPubsubSubscription_pushConfig(t *testing.T) {
+	topic := fmt.Sprintf("pssub-test-%s", acctest.RandString(10))
+	sub := fmt.Sprintf("pssub-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPubsubSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccPubsubSubscription_pushConfig(topic, sub, "v1beta1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPubsubSubscriptionExists(
+						"google_pubsub_subscription.foobar_sub"),
+					testAccPubsubSubscriptionPushVersion(
+						"google_pubsub_subscription.foobar_sub", "v1beta1"),
+					resource.TestCheckResourceAttr(
+						"google_pubsub_subscription.foobar_sub", "ack_deadline_seconds", "20"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccPubsubSubscription_pushConfig(topic, sub, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPubsubSubscriptionPushVersion(
+						"google_pubsub_subscription.foobar_sub", "v1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckPubsubSubscriptionDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "google_pubsub_subscription" {
@@ -35,8 +66,8 @@ func testAccCheckPubsubSubscriptionDestroy(s *terraform.State) error {
 
 		config := testAccProvider.Meta().(*Config)
 		_, err := config.clientPubsub.Projects.Subscriptions.Get(rs.Primary.ID).Do()
-		if err != nil {
-			fmt.Errorf("Subscription still present")
+		if err == nil {
+			return fmt.Errorf("Subscription still present")
 		}
 	}
 
@@ -56,7 +87,28 @@ func testAccPubsubSubscriptionExists(n string) resource.TestCheckFunc {
 		config := testAccProvider.Meta().(*Config)
 		_, err := config.clientPubsub.Projects.Subscriptions.Get(rs.Primary.ID).Do()
 		if err != nil {
-			fmt.Errorf("Subscription still present")
+			return fmt.Errorf("Subscription not found: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccPubsubSubscriptionPushVersion(n, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		sub, err := config.clientPubsub.Projects.Subscriptions.Get(rs.Primary.ID).Do()
+		if err != nil {
+			return fmt.Errorf("Subscription not found: %s", err)
+		}
+
+		if sub.PushConfig == nil || sub.PushConfig.Attributes["x-goog-version"] != version {
+			return fmt.Errorf("Expected push version %q, got: %#v", version, sub.PushConfig)
 		}
 
 		return nil
@@ -73,3 +125,23 @@ resource "google_pubsub_subscription" "foobar_sub" {
 	topic = "${google_pubsub_topic.foobar_sub.name}"
 
 }`, acctest.RandString(10), acctest.RandString(10))
+
+func testAccPubsubSubscription_pushConfig(topic, sub, version string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "foobar_sub" {
+	name = "%s"
+}
+
+resource "google_pubsub_subscription" "foobar_sub" {
+	name = "%s"
+	topic = "${google_pubsub_topic.foobar_sub.name}"
+	ack_deadline_seconds = 20
+
+	push_config {
+		push_endpoint = "https://${google_pubsub_topic.foobar_sub.name}.example.com/push"
+		attributes {
+			x-goog-version = "%s"
+		}
+	}
+}`, topic, sub, version)
+}
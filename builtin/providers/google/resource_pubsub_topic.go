@@ -2,8 +2,10 @@ package google
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/xanzy/terraform-api/helper/schema"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/pubsub/v1"
 )
 
@@ -37,7 +39,7 @@ func resourcePubsubTopicCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(res.Name)
 
-	return nil
+	return resourcePubsubTopicRead(d, meta)
 }
 
 func resourcePubsubTopicRead(d *schema.ResourceData, meta interface{}) error {
@@ -47,7 +49,15 @@ func resourcePubsubTopicRead(d *schema.ResourceData, meta interface{}) error {
 	call := config.clientPubsub.Projects.Topics.Get(name)
 	_, err := call.Do()
 	if err != nil {
-		return err
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing Pubsub Topic %q because it's gone", d.Get("name").(string))
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Pubsub Topic %q: %s", name, err)
 	}
 
 	return nil
@@ -2,8 +2,11 @@ package google
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 
@@ -46,6 +49,50 @@ func resourceStorageBucketObject() *schema.Resource {
 				ConflictsWith: []string{"source"},
 			},
 
+			"cache_control": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"content_disposition": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"content_encoding": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"content_language": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"content_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			// detect_md5hash holds the MD5 of the object in the bucket. The
+			// diff is suppressed as long as it matches the MD5 of the local
+			// source or content, so the object is replaced when either side
+			// changed it.
+			"detect_md5hash": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				// Makes the diff read as "<remote hash>" => "different hash"
+				Default:          "different hash",
+				DiffSuppressFunc: resourceStorageBucketObjectSuppressMd5Diff,
+			},
+
 			"predefined_acl": &schema.Schema{
 				Type:       schema.TypeString,
 				Deprecated: "Please use resource \"storage_object_acl.predefined_acl\" instead.",
@@ -86,11 +133,18 @@ func resourceStorageBucketObjectCreate(d *schema.ResourceData, meta interface{})
 	} else if v, ok := d.GetOk("content"); ok {
 		media = bytes.NewReader([]byte(v.(string)))
 	} else {
-		return fmt.Errorf("Error, either \"content\" or \"string\" must be specified")
+		return fmt.Errorf("Error, either \"content\" or \"source\" must be specified")
 	}
 
 	objectsService := storage.NewObjectsService(config.clientStorage)
-	object := &storage.Object{Bucket: bucket}
+	object := &storage.Object{
+		Bucket:             bucket,
+		CacheControl:       d.Get("cache_control").(string),
+		ContentDisposition: d.Get("content_disposition").(string),
+		ContentEncoding:    d.Get("content_encoding").(string),
+		ContentLanguage:    d.Get("content_language").(string),
+		ContentType:        d.Get("content_type").(string),
+	}
 
 	insertCall := objectsService.Insert(bucket, object)
 	insertCall.Name(name)
@@ -132,7 +186,13 @@ func resourceStorageBucketObjectRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.Set("md5hash", res.Md5Hash)
+	d.Set("detect_md5hash", res.Md5Hash)
 	d.Set("crc32c", res.Crc32c)
+	d.Set("cache_control", res.CacheControl)
+	d.Set("content_disposition", res.ContentDisposition)
+	d.Set("content_encoding", res.ContentEncoding)
+	d.Set("content_language", res.ContentLanguage)
+	d.Set("content_type", res.ContentType)
 
 	d.SetId(objectGetId(res))
 
@@ -156,3 +216,28 @@ func resourceStorageBucketObjectDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
+
+// resourceStorageBucketObjectSuppressMd5Diff suppresses the diff of
+// detect_md5hash as long as the MD5 of the object in the bucket, which is
+// the old value, matches the MD5 of the configured source or content.
+func resourceStorageBucketObjectSuppressMd5Diff(k, old, new string, d *schema.ResourceData) bool {
+	var localMd5Hash string
+	if v, ok := d.GetOk("source"); ok {
+		data, err := ioutil.ReadFile(v.(string))
+		if err != nil {
+			log.Printf("[WARN] Failed to read source file %q, can't detect changes: %s", v.(string), err)
+			return false
+		}
+		localMd5Hash = getMd5Hash(data)
+	} else if v, ok := d.GetOk("content"); ok {
+		localMd5Hash = getMd5Hash([]byte(v.(string)))
+	}
+
+	return localMd5Hash != "" && old == localMd5Hash
+}
+
+// getMd5Hash returns the MD5 of data, base64 encoded the way GCS reports it.
+func getMd5Hash(data []byte) string {
+	sum := md5.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
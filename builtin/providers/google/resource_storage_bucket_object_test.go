@@ -68,6 +68,65 @@ func TestAccGoogleStorageObject_content(t *testing.T) {
 	})
 }
 
+func TestAccGoogleStorageObject_recreate(t *testing.T) {
+	data := []byte("data data data")
+	updated := []byte("new data new data")
+
+	ioutil.WriteFile(tf.Name(), data, 0644)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			if err != nil {
+				panic(err)
+			}
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testGoogleStorageBucketsObjectBasic,
+				Check:  testAccCheckGoogleStorageObject(bucketName, objectName, getMd5Hash(data)),
+			},
+			resource.TestStep{
+				// Changing the source file has to upload the object again
+				PreConfig: func() {
+					ioutil.WriteFile(tf.Name(), updated, 0644)
+				},
+				Config: testGoogleStorageBucketsObjectBasic,
+				Check:  testAccCheckGoogleStorageObject(bucketName, objectName, getMd5Hash(updated)),
+			},
+		},
+	})
+}
+
+func TestAccGoogleStorageObject_metadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testGoogleStorageBucketsObjectMetadata,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleStorageObject(bucketName, objectName, getMd5Hash([]byte(content))),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket_object.object", "content_type", "text/plain; charset=utf-8"),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket_object.object", "cache_control", "no-cache"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetMd5Hash(t *testing.T) {
+	// GCS reports the base64 encoded MD5
+	expected := "CY9rzUYh03PK3k6DJie09g=="
+	if actual := getMd5Hash([]byte("test")); actual != expected {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
+
 func testAccCheckGoogleStorageObject(bucket, object, md5 string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		config := testAccProvider.Meta().(*Config)
@@ -138,3 +197,17 @@ resource "google_storage_bucket_object" "object" {
 	predefined_acl = "projectPrivate"
 }
 `, bucketName, objectName, tf.Name())
+
+var testGoogleStorageBucketsObjectMetadata = fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+}
+
+resource "google_storage_bucket_object" "object" {
+	name = "%s"
+	bucket = "${google_storage_bucket.bucket.name}"
+	content = "%s"
+	content_type = "text/plain; charset=utf-8"
+	cache_control = "no-cache"
+}
+`, bucketName, objectName, content)
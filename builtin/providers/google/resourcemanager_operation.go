@@ -0,0 +1,76 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+type ResourceManagerOperationWaiter struct {
+	Service *cloudresourcemanager.Service
+	Op      *cloudresourcemanager.Operation
+}
+
+func (w *ResourceManagerOperationWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		op, err := w.Service.Operations.Get(w.Op.Name).Do()
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Got done=%t when asking for operation %q", op.Done, w.Op.Name)
+
+		if op.Done {
+			return op, "DONE", nil
+		}
+		return op, "PENDING", nil
+	}
+}
+
+func (w *ResourceManagerOperationWaiter) Conf() *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  "DONE",
+		Refresh: w.RefreshFunc(),
+	}
+}
+
+// ResourceManagerOperationError wraps cloudresourcemanager.Status and
+// implements the error interface so it can be returned.
+type ResourceManagerOperationError cloudresourcemanager.Status
+
+func (e ResourceManagerOperationError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+func resourceManagerOperationWait(config *Config, op *cloudresourcemanager.Operation, activity string) error {
+	if op.Done {
+		if op.Error != nil {
+			return ResourceManagerOperationError(*op.Error)
+		}
+		return nil
+	}
+
+	w := &ResourceManagerOperationWaiter{
+		Service: config.clientResourceManager,
+		Op:      op,
+	}
+
+	state := w.Conf()
+	state.Timeout = 5 * time.Minute
+	state.MinTimeout = 2 * time.Second
+	opRaw, err := state.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for %s: %s", activity, err)
+	}
+
+	op = opRaw.(*cloudresourcemanager.Operation)
+	if op.Error != nil {
+		return ResourceManagerOperationError(*op.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_URL", "http://localhost:8086/"),
+				Description: "The URL of the InfluxDB server.",
+			},
+
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_USERNAME", ""),
+				Description: "The user name to authenticate with.",
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFLUXDB_PASSWORD", ""),
+				Description: "The password to authenticate with.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"influxdb_continuous_query": resourceInfluxDBContinuousQuery(),
+			"influxdb_database":         resourceInfluxDBDatabase(),
+			"influxdb_retention_policy": resourceInfluxDBRetentionPolicy(),
+			"influxdb_user":             resourceInfluxDBUser(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	u, err := url.Parse(d.Get("url").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid InfluxDB URL: %s", err)
+	}
+
+	config := client.Config{
+		URL:      *u,
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+
+	conn, err := client.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up client: %s", err)
+	}
+
+	_, _, err = conn.Ping()
+	if err != nil {
+		return nil, fmt.Errorf("Error pinging InfluxDB server at %s: %s", u, err)
+	}
+
+	log.Printf("[INFO] InfluxDB Client configured for URL: %s", u)
+
+	return conn, nil
+}
+
+// exec runs a statement that doesn't return any results.
+func exec(conn *client.Client, command string) error {
+	_, err := query(conn, command, "")
+	return err
+}
+
+// query runs a query and returns its first result, with errors from the
+// query itself turned into Go errors.
+func query(conn *client.Client, command, database string) (*client.Result, error) {
+	log.Printf("[DEBUG] Running InfluxDB query: %s", command)
+
+	resp, err := conn.Query(client.Query{
+		Command:  command,
+		Database: database,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Error(); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return &client.Result{}, nil
+	}
+
+	return &resp.Results[0], nil
+}
+
+// quoteIdentifier quotes a database, user or policy name for use in a
+// query.
+func quoteIdentifier(s string) string {
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// quoteString quotes a string literal, such as a password, for use in a
+// query.
+func quoteString(s string) string {
+	return `'` + strings.Replace(s, `'`, `\'`, -1) + `'`
+}
+
+// columnIndex returns the index of the named column, or -1.
+func columnIndex(columns []string, name string) int {
+	for i, c := range columns {
+		if c == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,102 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceInfluxDBContinuousQuery() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBContinuousQueryCreate,
+		Read:   resourceInfluxDBContinuousQueryRead,
+		Delete: resourceInfluxDBContinuousQueryDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The SELECT ... INTO ... GROUP BY time(...) statement the
+			// continuous query runs. InfluxDB rewrites the query when it is
+			// stored, so it is not read back.
+			"query": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBContinuousQueryCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	q := fmt.Sprintf("CREATE CONTINUOUS QUERY %s ON %s BEGIN %s END",
+		quoteIdentifier(name), quoteIdentifier(database), d.Get("query").(string))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error creating InfluxDB continuous query %s on %s: %s", name, database, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", database, name))
+
+	return resourceInfluxDBContinuousQueryRead(d, meta)
+}
+
+func resourceInfluxDBContinuousQueryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	result, err := query(conn, "SHOW CONTINUOUS QUERIES", "")
+	if err != nil {
+		return fmt.Errorf("Error reading InfluxDB continuous queries: %s", err)
+	}
+
+	// The queries are grouped in a series per database
+	for _, series := range result.Series {
+		if series.Name != database {
+			continue
+		}
+
+		nameIdx := columnIndex(series.Columns, "name")
+		for _, row := range series.Values {
+			if row[nameIdx].(string) == name {
+				return nil
+			}
+		}
+	}
+
+	log.Printf("[WARN] InfluxDB continuous query %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceInfluxDBContinuousQueryDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	q := fmt.Sprintf("DROP CONTINUOUS QUERY %s ON %s", quoteIdentifier(name), quoteIdentifier(database))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error dropping InfluxDB continuous query %s on %s: %s", name, database, err)
+	}
+
+	d.SetId("")
+	return nil
+}
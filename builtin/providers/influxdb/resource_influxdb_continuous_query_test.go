@@ -0,0 +1,38 @@
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccInfluxDBContinuousQuery_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBContinuousQueryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_continuous_query.minnie", "name", "minnie"),
+					resource.TestCheckResourceAttr(
+						"influxdb_continuous_query.minnie", "database", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+const testAccInfluxDBContinuousQueryConfig = `
+resource "influxdb_database" "test" {
+  name = "terraform-test"
+}
+
+resource "influxdb_continuous_query" "minnie" {
+  name = "minnie"
+  database = "${influxdb_database.test.name}"
+  query = "SELECT min(mouse) INTO min_mouse FROM zoo GROUP BY time(30m)"
+}
+`
@@ -0,0 +1,71 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceInfluxDBDatabase() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBDatabaseCreate,
+		Read:   resourceInfluxDBDatabaseRead,
+		Delete: resourceInfluxDBDatabaseDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	if err := exec(conn, "CREATE DATABASE "+quoteIdentifier(name)); err != nil {
+		return fmt.Errorf("Error creating InfluxDB database %s: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceInfluxDBDatabaseRead(d, meta)
+}
+
+func resourceInfluxDBDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	result, err := query(conn, "SHOW DATABASES", "")
+	if err != nil {
+		return fmt.Errorf("Error reading InfluxDB databases: %s", err)
+	}
+
+	for _, series := range result.Series {
+		for _, row := range series.Values {
+			if row[0].(string) == d.Id() {
+				d.Set("name", d.Id())
+				return nil
+			}
+		}
+	}
+
+	log.Printf("[WARN] InfluxDB database %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceInfluxDBDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	if err := exec(conn, "DROP DATABASE "+quoteIdentifier(d.Id())); err != nil {
+		return fmt.Errorf("Error dropping InfluxDB database %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,86 @@
+package influxdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccInfluxDBDatabase_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBDatabaseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInfluxDBDatabaseExists("influxdb_database.test"),
+					resource.TestCheckResourceAttr(
+						"influxdb_database.test", "name", "terraform-test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckInfluxDBDatabaseExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No database id set")
+		}
+
+		if !testAccInfluxDBDatabaseExists(rs.Primary.ID) {
+			return fmt.Errorf("Database %q does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckInfluxDBDatabaseDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "influxdb_database" {
+			continue
+		}
+
+		if testAccInfluxDBDatabaseExists(rs.Primary.ID) {
+			return fmt.Errorf("Database %q still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccInfluxDBDatabaseExists(name string) bool {
+	conn := testAccProvider.Meta().(*client.Client)
+
+	result, err := query(conn, "SHOW DATABASES", "")
+	if err != nil {
+		return false
+	}
+
+	for _, series := range result.Series {
+		for _, row := range series.Values {
+			if row[0].(string) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+const testAccInfluxDBDatabaseConfig = `
+resource "influxdb_database" "test" {
+  name = "terraform-test"
+}
+`
@@ -0,0 +1,210 @@
+package influxdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceInfluxDBRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBRetentionPolicyCreate,
+		Read:   resourceInfluxDBRetentionPolicyRead,
+		Update: resourceInfluxDBRetentionPolicyUpdate,
+		Delete: resourceInfluxDBRetentionPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"database": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// An InfluxDB duration such as "1d" or "4w", or "INF" to keep
+			// data forever.
+			"duration": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    normalizeRetentionDuration,
+				ValidateFunc: validateRetentionDuration,
+			},
+
+			"replication": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceInfluxDBRetentionPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	q := fmt.Sprintf("CREATE RETENTION POLICY %s ON %s %s",
+		quoteIdentifier(name), quoteIdentifier(database), retentionPolicyOptions(d))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error creating InfluxDB retention policy %s on %s: %s", name, database, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", database, name))
+
+	return resourceInfluxDBRetentionPolicyRead(d, meta)
+}
+
+func resourceInfluxDBRetentionPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	result, err := query(conn, "SHOW RETENTION POLICIES ON "+quoteIdentifier(database), "")
+	if err != nil {
+		if strings.Contains(err.Error(), "database not found") {
+			log.Printf("[WARN] InfluxDB database %s not found, removing retention policy from state", database)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading InfluxDB retention policies on %s: %s", database, err)
+	}
+
+	for _, series := range result.Series {
+		nameIdx := columnIndex(series.Columns, "name")
+		durationIdx := columnIndex(series.Columns, "duration")
+		replicationIdx := columnIndex(series.Columns, "replicaN")
+		defaultIdx := columnIndex(series.Columns, "default")
+
+		for _, row := range series.Values {
+			if row[nameIdx].(string) != name {
+				continue
+			}
+
+			d.Set("duration", normalizeRetentionDuration(row[durationIdx]))
+			if n, ok := row[replicationIdx].(json.Number); ok {
+				if i, err := strconv.Atoi(n.String()); err == nil {
+					d.Set("replication", i)
+				}
+			}
+			d.Set("default", row[defaultIdx].(bool))
+
+			return nil
+		}
+	}
+
+	log.Printf("[WARN] InfluxDB retention policy %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceInfluxDBRetentionPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	q := fmt.Sprintf("ALTER RETENTION POLICY %s ON %s %s",
+		quoteIdentifier(name), quoteIdentifier(database), retentionPolicyOptions(d))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error updating InfluxDB retention policy %s on %s: %s", name, database, err)
+	}
+
+	return resourceInfluxDBRetentionPolicyRead(d, meta)
+}
+
+func resourceInfluxDBRetentionPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	database := d.Get("database").(string)
+
+	q := fmt.Sprintf("DROP RETENTION POLICY %s ON %s", quoteIdentifier(name), quoteIdentifier(database))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error dropping InfluxDB retention policy %s on %s: %s", name, database, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func retentionPolicyOptions(d *schema.ResourceData) string {
+	options := fmt.Sprintf("DURATION %s REPLICATION %d", d.Get("duration").(string), d.Get("replication").(int))
+	if d.Get("default").(bool) {
+		options += " DEFAULT"
+	}
+	return options
+}
+
+var retentionDurationRegexp = regexp.MustCompile(`^(\d+)(ns|u|µ|ms|s|m|h|d|w)$`)
+
+func validateRetentionDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.ToUpper(value) != "INF" && !retentionDurationRegexp.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be an InfluxDB duration such as 1d or 4w, or INF, got: %s", k, value))
+	}
+	return
+}
+
+// normalizeRetentionDuration turns an InfluxDB duration into the form the
+// server reports it in, so "1d" and "24h0m0s" don't show up as a diff.
+func normalizeRetentionDuration(v interface{}) string {
+	value := v.(string)
+	if strings.ToUpper(value) == "INF" || value == "0" {
+		return "0s"
+	}
+
+	m := retentionDurationRegexp.FindStringSubmatch(value)
+	if m == nil {
+		// Already in the form reported by the server
+		return value
+	}
+
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return value
+	}
+
+	var unit time.Duration
+	switch m[2] {
+	case "ns":
+		unit = time.Nanosecond
+	case "u", "µ":
+		unit = time.Microsecond
+	case "ms":
+		unit = time.Millisecond
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	}
+
+	return (time.Duration(n) * unit).String()
+}
@@ -0,0 +1,85 @@
+package influxdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccInfluxDBRetentionPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBRetentionPolicyConfig("1d", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "duration", "24h0m0s"),
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "default", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccInfluxDBRetentionPolicyConfig("4w", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "duration", "672h0m0s"),
+					resource.TestCheckResourceAttr(
+						"influxdb_retention_policy.test", "default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestNormalizeRetentionDuration(t *testing.T) {
+	cases := map[string]string{
+		"INF":      "0s",
+		"inf":      "0s",
+		"0":        "0s",
+		"0s":       "0s",
+		"90m":      "1h30m0s",
+		"1d":       "24h0m0s",
+		"2w":       "336h0m0s",
+		"24h0m0s":  "24h0m0s",
+		"168h0m0s": "168h0m0s",
+	}
+
+	for input, expected := range cases {
+		if actual := normalizeRetentionDuration(input); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestValidateRetentionDuration(t *testing.T) {
+	for _, v := range []string{"INF", "1d", "4w", "30m", "100ms"} {
+		if _, errs := validateRetentionDuration(v, "duration"); len(errs) > 0 {
+			t.Fatalf("%s: unexpected errors: %v", v, errs)
+		}
+	}
+
+	for _, v := range []string{"", "1", "1y", "1d12h", "forever"} {
+		if _, errs := validateRetentionDuration(v, "duration"); len(errs) == 0 {
+			t.Fatalf("%s: expected errors", v)
+		}
+	}
+}
+
+func testAccInfluxDBRetentionPolicyConfig(duration string, isDefault bool) string {
+	return fmt.Sprintf(`
+resource "influxdb_database" "test" {
+  name = "terraform-test"
+}
+
+resource "influxdb_retention_policy" "test" {
+  name = "terraform-test"
+  database = "${influxdb_database.test.name}"
+  duration = "%s"
+  default = %t
+}
+`, duration, isDefault)
+}
@@ -0,0 +1,240 @@
+package influxdb
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/influxdata/influxdb/client"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceInfluxDBUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInfluxDBUserCreate,
+		Read:   resourceInfluxDBUserRead,
+		Update: resourceInfluxDBUserUpdate,
+		Delete: resourceInfluxDBUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"admin": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"grant": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						// One of READ, WRITE or ALL
+						"privilege": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePrivilege,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceInfluxDBUserCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+	name := d.Get("name").(string)
+
+	q := fmt.Sprintf("CREATE USER %s WITH PASSWORD %s",
+		quoteIdentifier(name), quoteString(d.Get("password").(string)))
+	if d.Get("admin").(bool) {
+		q += " WITH ALL PRIVILEGES"
+	}
+
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error creating InfluxDB user %s: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	for _, raw := range d.Get("grant").(*schema.Set).List() {
+		if err := grantPrivilege(conn, name, raw.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return resourceInfluxDBUserRead(d, meta)
+}
+
+func resourceInfluxDBUserRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+	name := d.Id()
+
+	result, err := query(conn, "SHOW USERS", "")
+	if err != nil {
+		return fmt.Errorf("Error reading InfluxDB users: %s", err)
+	}
+
+	found := false
+	for _, series := range result.Series {
+		userIdx := columnIndex(series.Columns, "user")
+		adminIdx := columnIndex(series.Columns, "admin")
+
+		for _, row := range series.Values {
+			if row[userIdx].(string) == name {
+				found = true
+				d.Set("admin", row[adminIdx].(bool))
+			}
+		}
+	}
+
+	if !found {
+		log.Printf("[WARN] InfluxDB user %s not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	result, err = query(conn, "SHOW GRANTS FOR "+quoteIdentifier(name), "")
+	if err != nil {
+		return fmt.Errorf("Error reading grants of InfluxDB user %s: %s", name, err)
+	}
+
+	var grants []map[string]interface{}
+	for _, series := range result.Series {
+		databaseIdx := columnIndex(series.Columns, "database")
+		privilegeIdx := columnIndex(series.Columns, "privilege")
+
+		for _, row := range series.Values {
+			privilege := normalizePrivilege(row[privilegeIdx].(string))
+			if privilege == "" {
+				continue
+			}
+
+			grants = append(grants, map[string]interface{}{
+				"database":  row[databaseIdx].(string),
+				"privilege": privilege,
+			})
+		}
+	}
+
+	d.Set("name", name)
+	d.Set("grant", grants)
+
+	return nil
+}
+
+func resourceInfluxDBUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+	name := d.Id()
+
+	if d.HasChange("password") {
+		q := fmt.Sprintf("SET PASSWORD FOR %s = %s",
+			quoteIdentifier(name), quoteString(d.Get("password").(string)))
+		if err := exec(conn, q); err != nil {
+			return fmt.Errorf("Error setting password of InfluxDB user %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("admin") {
+		q := "REVOKE ALL PRIVILEGES FROM " + quoteIdentifier(name)
+		if d.Get("admin").(bool) {
+			q = "GRANT ALL PRIVILEGES TO " + quoteIdentifier(name)
+		}
+		if err := exec(conn, q); err != nil {
+			return fmt.Errorf("Error changing admin privileges of InfluxDB user %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("grant") {
+		o, n := d.GetChange("grant")
+		oldSet := o.(*schema.Set)
+		newSet := n.(*schema.Set)
+
+		for _, raw := range oldSet.Difference(newSet).List() {
+			if err := revokePrivilege(conn, name, raw.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+
+		for _, raw := range newSet.Difference(oldSet).List() {
+			if err := grantPrivilege(conn, name, raw.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceInfluxDBUserRead(d, meta)
+}
+
+func resourceInfluxDBUserDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*client.Client)
+
+	if err := exec(conn, "DROP USER "+quoteIdentifier(d.Id())); err != nil {
+		return fmt.Errorf("Error dropping InfluxDB user %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func grantPrivilege(conn *client.Client, user string, grant map[string]interface{}) error {
+	q := fmt.Sprintf("GRANT %s ON %s TO %s",
+		grant["privilege"].(string), quoteIdentifier(grant["database"].(string)), quoteIdentifier(user))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error granting %s on %s to InfluxDB user %s: %s",
+			grant["privilege"], grant["database"], user, err)
+	}
+	return nil
+}
+
+func revokePrivilege(conn *client.Client, user string, grant map[string]interface{}) error {
+	q := fmt.Sprintf("REVOKE %s ON %s FROM %s",
+		grant["privilege"].(string), quoteIdentifier(grant["database"].(string)), quoteIdentifier(user))
+	if err := exec(conn, q); err != nil {
+		return fmt.Errorf("Error revoking %s on %s from InfluxDB user %s: %s",
+			grant["privilege"], grant["database"], user, err)
+	}
+	return nil
+}
+
+// normalizePrivilege turns a privilege as reported by SHOW GRANTS into the
+// form used in the configuration, or an empty string for no privileges.
+func normalizePrivilege(p string) string {
+	switch strings.ToUpper(p) {
+	case "READ":
+		return "READ"
+	case "WRITE":
+		return "WRITE"
+	case "ALL", "ALL PRIVILEGES":
+		return "ALL"
+	default:
+		return ""
+	}
+}
+
+func validatePrivilege(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "READ", "WRITE", "ALL":
+	default:
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of READ, WRITE or ALL, got: %s", k, v))
+	}
+	return
+}
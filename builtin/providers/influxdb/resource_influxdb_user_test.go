@@ -0,0 +1,80 @@
+package influxdb
+
+import (
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+)
+
+func TestAccInfluxDBUser_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInfluxDBDatabaseDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccInfluxDBUserConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "name", "terraform-test"),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "admin", "false"),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "grant.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccInfluxDBUserConfigUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "admin", "true"),
+					resource.TestCheckResourceAttr(
+						"influxdb_user.test", "grant.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestNormalizePrivilege(t *testing.T) {
+	cases := map[string]string{
+		"READ":           "READ",
+		"WRITE":          "WRITE",
+		"ALL PRIVILEGES": "ALL",
+		"NO PRIVILEGES":  "",
+	}
+
+	for input, expected := range cases {
+		if actual := normalizePrivilege(input); actual != expected {
+			t.Fatalf("%s: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+const testAccInfluxDBUserConfig = `
+resource "influxdb_database" "test" {
+  name = "terraform-test"
+}
+
+resource "influxdb_user" "test" {
+  name = "terraform-test"
+  password = "super-secret"
+
+  grant {
+    database = "${influxdb_database.test.name}"
+    privilege = "READ"
+  }
+}
+`
+
+const testAccInfluxDBUserConfigUpdated = `
+resource "influxdb_database" "test" {
+  name = "terraform-test"
+}
+
+resource "influxdb_user" "test" {
+  name = "terraform-test"
+  password = "super-secret"
+  admin = true
+}
+`
@@ -0,0 +1,74 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+
+	r "github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccNullResource_triggers(t *testing.T) {
+	var firstID string
+
+	r.Test(t, r.TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"null": Provider(),
+		},
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: fmt.Sprintf(testAccNullResourceConfig, "foo"),
+				Check: r.ComposeTestCheckFunc(
+					testAccCheckNullResourceID("null_resource.test", &firstID),
+					r.TestCheckResourceAttr("null_resource.test", "triggers.value", "foo"),
+				),
+			},
+			r.TestStep{
+				Config: fmt.Sprintf(testAccNullResourceConfig, "bar"),
+				Check: r.ComposeTestCheckFunc(
+					testAccCheckNullResourceReplaced("null_resource.test", &firstID),
+					r.TestCheckResourceAttr("null_resource.test", "triggers.value", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNullResourceID(n string, id *string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckNullResourceReplaced checks that changing the triggers
+// replaced the resource, which is what reruns its provisioners.
+func testAccCheckNullResourceReplaced(n string, id *string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID == *id {
+			return fmt.Errorf("Expected %s to be replaced, but its ID is still %s", n, *id)
+		}
+
+		return nil
+	}
+}
+
+const testAccNullResourceConfig = `
+resource "null_resource" "test" {
+	triggers {
+		value = "%s"
+	}
+}
+`
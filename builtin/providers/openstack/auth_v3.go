@@ -0,0 +1,185 @@
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack"
+	tokens3 "github.com/rackspace/gophercloud/openstack/identity/v3/tokens"
+)
+
+// useV3Auth returns true if the config uses options that only exist in
+// Identity v3, in which case the token request is built by the provider
+// instead of gophercloud.
+func (c *Config) useV3Auth() bool {
+	return c.UserDomainID != "" || c.UserDomainName != "" ||
+		c.ProjectDomainID != "" || c.ProjectDomainName != "" ||
+		c.ApplicationCredentialID != "" || c.ApplicationCredentialName != ""
+}
+
+// authenticateV3 requests a token from the Identity v3 service and sets up
+// the client to use it, along with the endpoints from the service catalog.
+func (c *Config) authenticateV3(client *gophercloud.ProviderClient) error {
+	v3Client := openstack.NewIdentityV3(client)
+
+	result := tokens3.Create(v3Client, authOptionsV3{c}, nil)
+	token, err := result.ExtractToken()
+	if err != nil {
+		return err
+	}
+
+	catalog, err := result.ExtractServiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	client.TokenID = token.ID
+	client.ReauthFunc = func() error {
+		client.TokenID = ""
+		return c.authenticateV3(client)
+	}
+	client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
+		return openstack.V3EndpointURL(catalog, opts)
+	}
+
+	return nil
+}
+
+// authOptionsV3 builds the body of an Identity v3 token request. Unlike the
+// gophercloud implementation, the user and the project can live in different
+// domains, and application credentials are supported.
+type authOptionsV3 struct {
+	*Config
+}
+
+func (o authOptionsV3) ToAuthOptionsV3Map(
+	c *gophercloud.ServiceClient, _ *tokens3.Scope) (map[string]interface{}, error) {
+	auth := make(map[string]interface{})
+
+	if o.ApplicationCredentialID != "" || o.ApplicationCredentialName != "" {
+		identity, err := o.applicationCredentialIdentity()
+		if err != nil {
+			return nil, err
+		}
+		auth["identity"] = identity
+
+		// Application credentials are always scoped to the project they
+		// were created for, so no scope is sent.
+		return map[string]interface{}{"auth": auth}, nil
+	}
+
+	user, err := o.user()
+	if err != nil {
+		return nil, err
+	}
+	if o.Password == "" {
+		return nil, fmt.Errorf("A password is required to authenticate with a user")
+	}
+	user["password"] = o.Password
+
+	auth["identity"] = map[string]interface{}{
+		"methods":  []string{"password"},
+		"password": map[string]interface{}{"user": user},
+	}
+
+	if scope := o.scope(); scope != nil {
+		auth["scope"] = scope
+	}
+
+	return map[string]interface{}{"auth": auth}, nil
+}
+
+func (o authOptionsV3) applicationCredentialIdentity() (map[string]interface{}, error) {
+	if o.ApplicationCredentialSecret == "" {
+		return nil, fmt.Errorf("application_credential_secret is required with application credentials")
+	}
+
+	cred := map[string]interface{}{
+		"secret": o.ApplicationCredentialSecret,
+	}
+
+	if o.ApplicationCredentialID != "" {
+		cred["id"] = o.ApplicationCredentialID
+	} else {
+		// A name is only unique per user, so the user is required too
+		user, err := o.user()
+		if err != nil {
+			return nil, err
+		}
+		cred["name"] = o.ApplicationCredentialName
+		cred["user"] = user
+	}
+
+	return map[string]interface{}{
+		"methods":                []string{"application_credential"},
+		"application_credential": cred,
+	}, nil
+}
+
+// user returns the user part of the request. A user name must be qualified
+// by the domain of the user, which falls back to domain_id or domain_name.
+func (o authOptionsV3) user() (map[string]interface{}, error) {
+	if o.UserID != "" {
+		return map[string]interface{}{"id": o.UserID}, nil
+	}
+
+	if o.Username == "" {
+		return nil, fmt.Errorf("Either user_id or user_name is required")
+	}
+
+	domain := domainMap(o.UserDomainID, o.UserDomainName)
+	if domain == nil {
+		domain = domainMap(o.DomainID, o.DomainName)
+	}
+	if domain == nil {
+		return nil, fmt.Errorf(
+			"A user domain is required with user_name, set user_domain_name or user_domain_id")
+	}
+
+	return map[string]interface{}{
+		"name":   o.Username,
+		"domain": domain,
+	}, nil
+}
+
+// scope returns the scope part of the request. A project name must be
+// qualified by the domain of the project, which falls back to domain_id or
+// domain_name. Without a project, the token is scoped to the domain.
+func (o authOptionsV3) scope() map[string]interface{} {
+	if o.TenantID != "" {
+		return map[string]interface{}{
+			"project": map[string]interface{}{"id": o.TenantID},
+		}
+	}
+
+	projectDomain := domainMap(o.ProjectDomainID, o.ProjectDomainName)
+	if projectDomain == nil {
+		projectDomain = domainMap(o.DomainID, o.DomainName)
+	}
+
+	if o.TenantName != "" {
+		project := map[string]interface{}{"name": o.TenantName}
+		if projectDomain != nil {
+			project["domain"] = projectDomain
+		}
+
+		return map[string]interface{}{"project": project}
+	}
+
+	if domain := domainMap(o.DomainID, o.DomainName); domain != nil {
+		return map[string]interface{}{"domain": domain}
+	}
+
+	return nil
+}
+
+func domainMap(id, name string) map[string]interface{} {
+	switch {
+	case id != "":
+		return map[string]interface{}{"id": id}
+	case name != "":
+		return map[string]interface{}{"name": name}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package openstack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuthOptionsV3_ToAuthOptionsV3Map(t *testing.T) {
+	cases := map[string]struct {
+		Config *Config
+		Result map[string]interface{}
+		Err    bool
+	}{
+		"PasswordProjectScope": {
+			Config: &Config{
+				Username:          "admin",
+				Password:          "secret",
+				UserDomainName:    "users",
+				TenantName:        "demo",
+				ProjectDomainName: "projects",
+			},
+			Result: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"methods": []string{"password"},
+						"password": map[string]interface{}{
+							"user": map[string]interface{}{
+								"name":     "admin",
+								"password": "secret",
+								"domain":   map[string]interface{}{"name": "users"},
+							},
+						},
+					},
+					"scope": map[string]interface{}{
+						"project": map[string]interface{}{
+							"name":   "demo",
+							"domain": map[string]interface{}{"name": "projects"},
+						},
+					},
+				},
+			},
+		},
+
+		"PasswordDomainFallback": {
+			Config: &Config{
+				Username:   "admin",
+				Password:   "secret",
+				DomainID:   "default",
+				TenantName: "demo",
+			},
+			Result: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"methods": []string{"password"},
+						"password": map[string]interface{}{
+							"user": map[string]interface{}{
+								"name":     "admin",
+								"password": "secret",
+								"domain":   map[string]interface{}{"id": "default"},
+							},
+						},
+					},
+					"scope": map[string]interface{}{
+						"project": map[string]interface{}{
+							"name":   "demo",
+							"domain": map[string]interface{}{"id": "default"},
+						},
+					},
+				},
+			},
+		},
+
+		"PasswordDomainScope": {
+			Config: &Config{
+				UserID:     "abc123",
+				Password:   "secret",
+				DomainName: "example",
+			},
+			Result: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"methods": []string{"password"},
+						"password": map[string]interface{}{
+							"user": map[string]interface{}{
+								"id":       "abc123",
+								"password": "secret",
+							},
+						},
+					},
+					"scope": map[string]interface{}{
+						"domain": map[string]interface{}{"name": "example"},
+					},
+				},
+			},
+		},
+
+		"ApplicationCredentialID": {
+			Config: &Config{
+				ApplicationCredentialID:     "cred",
+				ApplicationCredentialSecret: "secret",
+				TenantName:                  "ignored",
+			},
+			Result: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"methods": []string{"application_credential"},
+						"application_credential": map[string]interface{}{
+							"id":     "cred",
+							"secret": "secret",
+						},
+					},
+				},
+			},
+		},
+
+		"ApplicationCredentialName": {
+			Config: &Config{
+				ApplicationCredentialName:   "cred",
+				ApplicationCredentialSecret: "secret",
+				Username:                    "admin",
+				UserDomainID:                "default",
+			},
+			Result: map[string]interface{}{
+				"auth": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"methods": []string{"application_credential"},
+						"application_credential": map[string]interface{}{
+							"name":   "cred",
+							"secret": "secret",
+							"user": map[string]interface{}{
+								"name":   "admin",
+								"domain": map[string]interface{}{"id": "default"},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"ApplicationCredentialNoSecret": {
+			Config: &Config{
+				ApplicationCredentialID: "cred",
+			},
+			Err: true,
+		},
+
+		"UserNameNoDomain": {
+			Config: &Config{
+				Username: "admin",
+				Password: "secret",
+			},
+			Err: true,
+		},
+
+		"NoPassword": {
+			Config: &Config{
+				UserID: "abc123",
+			},
+			Err: true,
+		},
+	}
+
+	for name, tc := range cases {
+		result, err := authOptionsV3{tc.Config}.ToAuthOptionsV3Map(nil, nil)
+		if err != nil != tc.Err {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if tc.Err {
+			continue
+		}
+
+		if !reflect.DeepEqual(result, tc.Result) {
+			t.Fatalf("%s: bad:\n\n%#v\n\nexpected:\n\n%#v", name, result, tc.Result)
+		}
+	}
+}
+
+func TestConfig_useV3Auth(t *testing.T) {
+	if (&Config{Username: "admin", DomainName: "default"}).useV3Auth() {
+		t.Fatal("should not use v3 auth")
+	}
+	if !(&Config{Username: "admin", UserDomainName: "default"}).useV3Auth() {
+		t.Fatal("should use v3 auth")
+	}
+	if !(&Config{ApplicationCredentialID: "cred"}).useV3Auth() {
+		t.Fatal("should use v3 auth")
+	}
+}
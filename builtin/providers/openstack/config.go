@@ -22,6 +22,15 @@ type Config struct {
 	Insecure         bool
 	EndpointType     string
 
+	// Identity v3 only options, see auth_v3.go
+	UserDomainID                string
+	UserDomainName              string
+	ProjectDomainID             string
+	ProjectDomainName           string
+	ApplicationCredentialID     string
+	ApplicationCredentialName   string
+	ApplicationCredentialSecret string
+
 	osClient *gophercloud.ProviderClient
 }
 
@@ -58,7 +67,11 @@ func (c *Config) loadAndValidate() error {
 		client.HTTPClient.Transport = transport
 	}
 
-	err = openstack.Authenticate(client, ao)
+	if c.useV3Auth() {
+		err = c.authenticateV3(client)
+	} else {
+		err = openstack.Authenticate(client, ao)
+	}
 	if err != nil {
 		return err
 	}
@@ -47,14 +47,49 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: envDefaultFuncAllowMissing("OS_AUTH_TOKEN"),
 			},
 			"domain_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_DOMAIN_ID"),
 			},
 			"domain_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_DOMAIN_NAME"),
+			},
+			"user_domain_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_USER_DOMAIN_ID"),
+			},
+			"user_domain_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_USER_DOMAIN_NAME"),
+			},
+			"project_domain_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_PROJECT_DOMAIN_ID"),
+			},
+			"project_domain_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_PROJECT_DOMAIN_NAME"),
+			},
+			"application_credential_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_APPLICATION_CREDENTIAL_ID"),
+			},
+			"application_credential_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_APPLICATION_CREDENTIAL_NAME"),
+			},
+			"application_credential_secret": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFuncAllowMissing("OS_APPLICATION_CREDENTIAL_SECRET"),
 			},
 			"insecure": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -107,6 +142,14 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		DomainName:       d.Get("domain_name").(string),
 		Insecure:         d.Get("insecure").(bool),
 		EndpointType:     d.Get("endpoint_type").(string),
+
+		UserDomainID:                d.Get("user_domain_id").(string),
+		UserDomainName:              d.Get("user_domain_name").(string),
+		ProjectDomainID:             d.Get("project_domain_id").(string),
+		ProjectDomainName:           d.Get("project_domain_name").(string),
+		ApplicationCredentialID:     d.Get("application_credential_id").(string),
+		ApplicationCredentialName:   d.Get("application_credential_name").(string),
+		ApplicationCredentialSecret: d.Get("application_credential_secret").(string),
 	}
 
 	if err := config.loadAndValidate(); err != nil {
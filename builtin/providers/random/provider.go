@@ -0,0 +1,42 @@
+package random
+
+import (
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"random_id":      resourceId(),
+			"random_shuffle": resourceShuffle(),
+			"random_string":  resourceString(),
+		},
+	}
+}
+
+// keepersSchema is shared by all resources. Any change to the keepers
+// forces a new random value to be generated.
+func keepersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// resourceRemove is used as the Delete function of all resources, as
+// random values only exist in the state.
+func resourceRemove(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// resourceReadNil is used as the Read function of resources that don't
+// have to derive anything from their ID.
+func resourceReadNil(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
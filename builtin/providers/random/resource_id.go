@@ -0,0 +1,81 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceId() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIdCreate,
+		Read:   resourceIdRead,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"byte_length": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"b64": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hex": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dec": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceIdCreate generates byte_length random bytes and stores them
+// URL-safe base64 encoded as the ID of the resource.
+func resourceIdCreate(d *schema.ResourceData, meta interface{}) error {
+	byteLength := d.Get("byte_length").(int)
+	if byteLength < 1 {
+		return fmt.Errorf("byte_length must be at least 1")
+	}
+
+	bytes := make([]byte, byteLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Errorf("error generating random bytes: %s", err)
+	}
+
+	d.SetId(base64.RawURLEncoding.EncodeToString(bytes))
+
+	return resourceIdRead(d, meta)
+}
+
+// resourceIdRead derives all exported encodings from the ID.
+func resourceIdRead(d *schema.ResourceData, meta interface{}) error {
+	b64Str := d.Id()
+
+	bytes, err := base64.RawURLEncoding.DecodeString(b64Str)
+	if err != nil {
+		return fmt.Errorf("error decoding ID: %s", err)
+	}
+
+	bigInt := big.Int{}
+	bigInt.SetBytes(bytes)
+
+	d.Set("b64", b64Str)
+	d.Set("hex", hex.EncodeToString(bytes))
+	d.Set("dec", bigInt.String())
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package random
+
+import (
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceShuffle() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceShuffleCreate,
+		Read:   resourceReadNil,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"seed": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"input": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"result_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// resourceShuffleCreate permutes the input list. When result_count is
+// larger than the input, the input is repeated as often as needed.
+func resourceShuffleCreate(d *schema.ResourceData, meta interface{}) error {
+	input := d.Get("input").([]interface{})
+	seed := d.Get("seed").(string)
+
+	resultCount := d.Get("result_count").(int)
+	if resultCount == 0 {
+		resultCount = len(input)
+	}
+	result := make([]interface{}, 0, resultCount)
+
+	rand := newRand(seed)
+
+	// Keep producing permutations until we fill our result
+Batches:
+	for len(input) > 0 {
+		perm := rand.Perm(len(input))
+
+		for _, i := range perm {
+			result = append(result, input[i])
+
+			if len(result) >= resultCount {
+				break Batches
+			}
+		}
+	}
+
+	d.SetId("-")
+	d.Set("result", result)
+
+	return nil
+}
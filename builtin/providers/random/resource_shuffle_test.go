@@ -0,0 +1,82 @@
+package random
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccResourceShuffle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccResourceShuffleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					// The generated order depends on the implementation
+					// of math/rand, so only the length and the values of
+					// the results are checked.
+					testAccResourceShuffleCheck(
+						"random_shuffle.default_length", 5,
+					),
+					testAccResourceShuffleCheck(
+						"random_shuffle.shorter_length", 3,
+					),
+					testAccResourceShuffleCheck(
+						"random_shuffle.longer_length", 12,
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceShuffleCheck(id string, wantLen int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("Not found: %s", id)
+		}
+		attrs := rs.Primary.Attributes
+
+		gotLen, err := strconv.Atoi(attrs["result.#"])
+		if err != nil {
+			return fmt.Errorf("bad result.#: %s", err)
+		}
+		if gotLen != wantLen {
+			return fmt.Errorf("got %d results; want %d", gotLen, wantLen)
+		}
+
+		input := make(map[string]bool)
+		for i := 0; i < 5; i++ {
+			input[attrs[fmt.Sprintf("input.%d", i)]] = true
+		}
+		for i := 0; i < gotLen; i++ {
+			if v := attrs[fmt.Sprintf("result.%d", i)]; !input[v] {
+				return fmt.Errorf("result %d is %q, which is not in the input", i, v)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccResourceShuffleConfig = `
+resource "random_shuffle" "default_length" {
+	input = ["a", "b", "c", "d", "e"]
+	seed = "-"
+}
+resource "random_shuffle" "shorter_length" {
+	input = ["a", "b", "c", "d", "e"]
+	seed = "-"
+	result_count = 3
+}
+resource "random_shuffle" "longer_length" {
+	input = ["a", "b", "c", "d", "e"]
+	seed = "-"
+	result_count = 12
+}
+`
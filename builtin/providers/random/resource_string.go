@@ -0,0 +1,130 @@
+package random
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceString() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStringCreate,
+		Read:   resourceReadNil,
+		Delete: resourceRemove,
+
+		Schema: map[string]*schema.Schema{
+			"keepers": keepersSchema(),
+
+			"length": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"special": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"upper": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"lower": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"number": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"override_special": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"result": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceStringCreate generates a string of the given length, using a
+// cryptographically secure source, from the enabled character classes.
+func resourceStringCreate(d *schema.ResourceData, meta interface{}) error {
+	const numChars = "0123456789"
+	const lowerChars = "abcdefghijklmnopqrstuvwxyz"
+	const upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	var specialChars = "!@#$%&*()-_=+[]{}<>:?"
+
+	length := d.Get("length").(int)
+	upper := d.Get("upper").(bool)
+	lower := d.Get("lower").(bool)
+	number := d.Get("number").(bool)
+	special := d.Get("special").(bool)
+	overrideSpecial := d.Get("override_special").(string)
+
+	if length < 1 {
+		return fmt.Errorf("length must be at least 1")
+	}
+
+	if overrideSpecial != "" {
+		specialChars = overrideSpecial
+	}
+
+	var chars string
+	if upper {
+		chars += upperChars
+	}
+	if lower {
+		chars += lowerChars
+	}
+	if number {
+		chars += numChars
+	}
+	if special {
+		chars += specialChars
+	}
+	if chars == "" {
+		return fmt.Errorf("at least one of upper, lower, number or special must be enabled")
+	}
+
+	result, err := generateRandomBytes(chars, length)
+	if err != nil {
+		return fmt.Errorf("error generating random string: %s", err)
+	}
+
+	d.SetId("none")
+	d.Set("result", string(result))
+
+	return nil
+}
+
+func generateRandomBytes(charSet string, length int) ([]byte, error) {
+	bytes := make([]byte, length)
+	setLen := big.NewInt(int64(len(charSet)))
+	for i := range bytes {
+		idx, err := rand.Int(rand.Reader, setLen)
+		if err != nil {
+			return nil, err
+		}
+		bytes[i] = charSet[idx.Int64()]
+	}
+	return bytes, nil
+}
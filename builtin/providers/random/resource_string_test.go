@@ -0,0 +1,62 @@
+package random
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccResourceString(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccResourceStringConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceStringCheck("random_string.foo", `^[a-zA-Z0-9!@#$%&*()\-_=+\[\]{}<>:?]{12}$`),
+					testAccResourceStringCheck("random_string.bar", `^[a-z]{32}$`),
+					testAccResourceStringCheck("random_string.baz", `^[0-9/]{8}$`),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceStringCheck(id string, pattern string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("Not found: %s", id)
+		}
+
+		result := rs.Primary.Attributes["result"]
+		if !regexp.MustCompile(pattern).MatchString(result) {
+			return fmt.Errorf("%s: result %q does not match %s", id, result, pattern)
+		}
+
+		return nil
+	}
+}
+
+const testAccResourceStringConfig = `
+resource "random_string" "foo" {
+	length = 12
+}
+
+resource "random_string" "bar" {
+	length = 32
+	upper = false
+	number = false
+	special = false
+}
+
+resource "random_string" "baz" {
+	length = 8
+	upper = false
+	lower = false
+	override_special = "/"
+}
+`
@@ -0,0 +1,24 @@
+package random
+
+import (
+	"hash/crc64"
+	"math/rand"
+	"time"
+)
+
+// newRand returns a seeded random number generator, using a seed derived
+// from the given string.
+//
+// If the seed string is empty, a time-based seed is used.
+func newRand(seed string) *rand.Rand {
+	var seedInt int64
+	if seed != "" {
+		crcTable := crc64.MakeTable(crc64.ISO)
+		seedInt = int64(crc64.Checksum([]byte(seed), crcTable))
+	} else {
+		seedInt = time.Now().UnixNano()
+	}
+
+	randSource := rand.NewSource(seedInt)
+	return rand.New(randSource)
+}
@@ -9,6 +9,7 @@ func Provider() terraform.ResourceProvider {
 	return &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
 			"template_file":             resourceFile(),
+			"template_dir":              resourceDir(),
 			"template_cloudinit_config": resourceCloudinitConfig(),
 		},
 	}
@@ -0,0 +1,203 @@
+package template
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceDir() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTemplateDirCreate,
+		Read:   resourceTemplateDirRead,
+		Delete: resourceTemplateDirDelete,
+
+		Schema: map[string]*schema.Schema{
+			"source_dir": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Path to the directory where the files to template reside",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"vars": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Default:     make(map[string]interface{}),
+				Description: "Variables to substitute",
+				ForceNew:    true,
+			},
+			"destination_dir": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Path to the directory where the templated files will be written",
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func resourceTemplateDirRead(d *schema.ResourceData, meta interface{}) error {
+	sourceDir := d.Get("source_dir").(string)
+	destinationDir := d.Get("destination_dir").(string)
+
+	// If the output doesn't exist, mark the resource for creation.
+	if _, err := os.Stat(destinationDir); os.IsNotExist(err) {
+		d.SetId("")
+		return nil
+	}
+
+	// If the combined hash of the input and output directories is different
+	// from the stored one, mark the resource for re-creation.
+	//
+	// The output directory is technically enough for the general case, but
+	// by hashing the input directory as well, we make development much
+	// easier: when a developer modifies one of the input files, the
+	// generation is re-triggered.
+	hash, err := generateID(sourceDir, destinationDir)
+	if err != nil {
+		return err
+	}
+	if hash != d.Id() {
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceTemplateDirCreate(d *schema.ResourceData, meta interface{}) error {
+	sourceDir := d.Get("source_dir").(string)
+	destinationDir := d.Get("destination_dir").(string)
+	vars := d.Get("vars").(map[string]interface{})
+
+	// Always delete the output first, otherwise files that got deleted from
+	// the input directory might still be present in the output afterwards.
+	if err := resourceTemplateDirDelete(d, meta); err != nil {
+		return err
+	}
+
+	// Create the destination directory and any other intermediate
+	// directories leading to it.
+	if _, err := os.Stat(destinationDir); err != nil {
+		if err := os.MkdirAll(destinationDir, 0777); err != nil {
+			return err
+		}
+	}
+
+	// Recursively crawl the input files/directories and generate the output
+	// ones.
+	err := filepath.Walk(sourceDir, func(p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if f.IsDir() {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(sourceDir, p)
+		return generateDirFile(p, filepath.Join(destinationDir, relPath), f, vars)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Compute ID.
+	hash, err := generateID(sourceDir, destinationDir)
+	if err != nil {
+		return err
+	}
+	d.SetId(hash)
+
+	return nil
+}
+
+func resourceTemplateDirDelete(d *schema.ResourceData, _ interface{}) error {
+	d.SetId("")
+
+	destinationDir := d.Get("destination_dir").(string)
+	if _, err := os.Stat(destinationDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.RemoveAll(destinationDir); err != nil {
+		return fmt.Errorf("could not delete directory %q: %s", destinationDir, err)
+	}
+
+	return nil
+}
+
+// generateDirFile renders the template at sourcePath and writes the result to
+// destinationPath, keeping the permissions of the source file.
+func generateDirFile(sourcePath, destinationPath string, f os.FileInfo, vars map[string]interface{}) error {
+	inputContent, err := ioutil.ReadFile(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	outputContent, err := execute(string(inputContent), vars)
+	if err != nil {
+		return templateRenderError(fmt.Errorf("failed to render %v: %v", sourcePath, err))
+	}
+
+	outputDir := filepath.Dir(destinationPath)
+	if _, err := os.Stat(outputDir); err != nil {
+		if err := os.MkdirAll(outputDir, 0777); err != nil {
+			return err
+		}
+	}
+
+	if err := ioutil.WriteFile(destinationPath, []byte(outputContent), f.Mode()); err != nil {
+		return err
+	}
+
+	// WriteFile is subject to the umask, so set the mode explicitly.
+	return os.Chmod(destinationPath, f.Mode())
+}
+
+// generateID hashes the contents and permissions of both directories, so
+// changes on either side are detected during refresh.
+func generateID(sourceDir, destinationDir string) (string, error) {
+	fingerprint := sha1.New()
+	if err := fingerprintDir(fingerprint, sourceDir); err != nil {
+		return "", err
+	}
+	if err := fingerprintDir(fingerprint, destinationDir); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(fingerprint.Sum(nil)), nil
+}
+
+func fingerprintDir(w io.Writer, dir string) error {
+	return filepath.Walk(dir, func(p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if f.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		// Only the name, mode and contents are taken into account, so the
+		// fingerprint doesn't change when the files are merely touched.
+		fmt.Fprintf(w, "%s\x00%o\x00", filepath.ToSlash(relPath), f.Mode().Perm())
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(w, file)
+		return err
+	})
+}
@@ -32,7 +32,7 @@ func TestTemplateRendering(t *testing.T) {
 				r.TestStep{
 					Config: testTemplateConfig(tt.template, tt.vars),
 					Check: func(s *terraform.State) error {
-						got := s.RootModule().Outputs["rendered"]
+						got := s.RootModule().Outputs["rendered"].Value.(string)
 						if tt.want != got {
 							return fmt.Errorf("template:\n%s\nvars:\n%s\ngot:\n%s\nwant:\n%s\n", tt.template, tt.vars, got, tt.want)
 						}
@@ -61,7 +61,7 @@ func TestTemplateVariableChange(t *testing.T) {
 			Config: testTemplateConfig(step.template, step.vars),
 			Check: func(i int, want string) r.TestCheckFunc {
 				return func(s *terraform.State) error {
-					got := s.RootModule().Outputs["rendered"]
+					got := s.RootModule().Outputs["rendered"].Value.(string)
 					if want != got {
 						return fmt.Errorf("[%d] got:\n%q\nwant:\n%q\n", i, got, want)
 					}
@@ -1,9 +1,11 @@
 package terraform
 
 import (
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/xanzy/terraform-api/config"
 	"github.com/xanzy/terraform-api/helper/schema"
 	"github.com/xanzy/terraform-api/state/remote"
 )
@@ -62,7 +64,23 @@ func resourceRemoteStateRead(d *schema.ResourceData, meta interface{}) error {
 
 	var outputs map[string]string
 	if !state.State().Empty() {
-		outputs = state.State().RootModule().Outputs
+		outputs = make(map[string]string)
+		for k, o := range state.State().RootModule().Outputs {
+			switch v := o.Value.(type) {
+			case string:
+				outputs[k] = v
+			case []interface{}:
+				// Lists are exposed flattened, so they can be used
+				// with the list interpolation functions.
+				parts := make([]string, len(v))
+				for i, e := range v {
+					parts[i] = fmt.Sprintf("%v", e)
+				}
+				outputs[k] = config.NewStringList(parts).String()
+			default:
+				log.Printf("[DEBUG] Skipping output %s of type %s", k, o.Type)
+			}
+		}
 	}
 
 	d.SetId(time.Now().UTC().String())
@@ -50,7 +50,7 @@ EOT
                     }
                 `, testPrivateKey),
 				Check: func(s *terraform.State) error {
-					got := s.RootModule().Outputs["key_pem"]
+					got := s.RootModule().Outputs["key_pem"].Value.(string)
 					if !strings.HasPrefix(got, "-----BEGIN CERTIFICATE REQUEST----") {
 						return fmt.Errorf("key is missing CSR PEM preamble")
 					}
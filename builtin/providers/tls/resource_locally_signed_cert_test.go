@@ -47,7 +47,7 @@ EOT
                     }
                 `, testCertRequest, testCACert, testCAPrivateKey),
 				Check: func(s *terraform.State) error {
-					got := s.RootModule().Outputs["cert_pem"]
+					got := s.RootModule().Outputs["cert_pem"].Value.(string)
 					if !strings.HasPrefix(got, "-----BEGIN CERTIFICATE----") {
 						return fmt.Errorf("key is missing cert PEM preamble")
 					}
@@ -160,3 +160,109 @@ EOT
 		},
 	})
 }
+
+// TestLocallySignedCert_generatedCA builds the whole chain in the config, so
+// no fixtures are needed and the CA can never expire between test runs.
+func TestLocallySignedCert_generatedCA(t *testing.T) {
+	r.Test(t, r.TestCase{
+		Providers: testProviders,
+		Steps: []r.TestStep{
+			r.TestStep{
+				Config: `
+                    resource "tls_private_key" "ca" {
+                        algorithm = "ECDSA"
+                    }
+
+                    resource "tls_self_signed_cert" "ca" {
+                        key_algorithm = "${tls_private_key.ca.algorithm}"
+                        private_key_pem = "${tls_private_key.ca.private_key_pem}"
+
+                        subject {
+                            common_name = "Example CA"
+                            organization = "Example, Inc"
+                        }
+
+                        validity_period_hours = 1
+                        is_ca_certificate = true
+
+                        allowed_uses = [
+                            "cert_signing",
+                        ]
+                    }
+
+                    resource "tls_private_key" "server" {
+                        algorithm = "ECDSA"
+                    }
+
+                    resource "tls_cert_request" "server" {
+                        key_algorithm = "${tls_private_key.server.algorithm}"
+                        private_key_pem = "${tls_private_key.server.private_key_pem}"
+
+                        subject {
+                            common_name = "example.com"
+                        }
+
+                        dns_names = ["example.com"]
+                    }
+
+                    resource "tls_locally_signed_cert" "server" {
+                        cert_request_pem = "${tls_cert_request.server.cert_request_pem}"
+
+                        ca_key_algorithm = "${tls_private_key.ca.algorithm}"
+                        ca_private_key_pem = "${tls_private_key.ca.private_key_pem}"
+                        ca_cert_pem = "${tls_self_signed_cert.ca.cert_pem}"
+
+                        validity_period_hours = 1
+
+                        allowed_uses = [
+                            "key_encipherment",
+                            "digital_signature",
+                            "server_auth",
+                        ]
+                    }
+
+                    output "ca_cert_pem" {
+                        value = "${tls_self_signed_cert.ca.cert_pem}"
+                    }
+                    output "cert_pem" {
+                        value = "${tls_locally_signed_cert.server.cert_pem}"
+                    }
+                `,
+				Check: func(s *terraform.State) error {
+					caBlock, _ := pem.Decode([]byte(s.RootModule().Outputs["ca_cert_pem"].Value.(string)))
+					if caBlock == nil {
+						return fmt.Errorf("ca cert is not PEM encoded")
+					}
+					caCert, err := x509.ParseCertificate(caBlock.Bytes)
+					if err != nil {
+						return fmt.Errorf("error parsing ca cert: %s", err)
+					}
+					if !caCert.IsCA {
+						return fmt.Errorf("ca cert is not a CA certificate")
+					}
+
+					block, _ := pem.Decode([]byte(s.RootModule().Outputs["cert_pem"].Value.(string)))
+					if block == nil {
+						return fmt.Errorf("cert is not PEM encoded")
+					}
+					cert, err := x509.ParseCertificate(block.Bytes)
+					if err != nil {
+						return fmt.Errorf("error parsing cert: %s", err)
+					}
+
+					certPool := x509.NewCertPool()
+					certPool.AddCert(caCert)
+					_, err = cert.Verify(x509.VerifyOptions{
+						DNSName: "example.com",
+						Roots:   certPool,
+					})
+					if err != nil {
+						return fmt.Errorf("verify failed: %s", err)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
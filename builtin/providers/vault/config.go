@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/vault/api"
+)
+
+type Config struct {
+	Address       string
+	Token         string
+	CACertFile    string
+	SkipTLSVerify bool
+}
+
+// Client() returns a new client for accessing Vault.
+func (c *Config) Client() (*api.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.SkipTLSVerify,
+	}
+
+	if c.CACertFile != "" {
+		pem, err := ioutil.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA certificate %s: %s", c.CACertFile, err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in %s", c.CACertFile)
+		}
+	}
+
+	transport := cleanhttp.DefaultTransport()
+	transport.TLSClientConfig = tlsConfig
+
+	config := api.DefaultConfig()
+	config.Address = c.Address
+	config.HttpClient = &http.Client{Transport: transport}
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up client: %s", err)
+	}
+
+	client.SetToken(c.Token)
+
+	log.Printf("[INFO] Vault Client configured for address: %s", c.Address)
+
+	return client, nil
+}
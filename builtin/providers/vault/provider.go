@@ -0,0 +1,60 @@
+package vault
+
+import (
+	"github.com/xanzy/terraform-api/helper/schema"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// Provider returns a terraform.ResourceProvider.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"address": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_ADDR", nil),
+				Description: "URL of the root of the target Vault server.",
+			},
+
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_TOKEN", nil),
+				Description: "Token to use to authenticate to Vault.",
+			},
+
+			"ca_cert_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_CACERT", ""),
+				Description: "Path to a CA certificate file to validate the server's certificate.",
+			},
+
+			"skip_tls_verify": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_SKIP_VERIFY", false),
+				Description: "Set this to true only if the target Vault server is an insecure development instance.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"vault_auth_backend": resourceVaultAuthBackend(),
+			"vault_mount":        resourceVaultMount(),
+			"vault_policy":       resourceVaultPolicy(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := Config{
+		Address:       d.Get("address").(string),
+		Token:         d.Get("token").(string),
+		CACertFile:    d.Get("ca_cert_file").(string),
+		SkipTLSVerify: d.Get("skip_tls_verify").(bool),
+	}
+
+	return config.Client()
+}
@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceVaultAuthBackend() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVaultAuthBackendCreate,
+		Read:   resourceVaultAuthBackendRead,
+		Delete: resourceVaultAuthBackendDelete,
+
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the auth backend, such as 'github' or 'userpass'",
+			},
+
+			"path": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Path to mount the backend at, defaults to the type",
+				StateFunc:   normalizeVaultPath,
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Human-friendly description of the auth backend",
+			},
+		},
+	}
+}
+
+func resourceVaultAuthBackendCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	authType := d.Get("type").(string)
+	path := authType
+	if v, ok := d.GetOk("path"); ok {
+		path = normalizeVaultPath(v)
+	}
+
+	log.Printf("[DEBUG] Enabling %s auth backend at %s", authType, path)
+	if err := client.Sys().EnableAuth(path, authType, d.Get("description").(string)); err != nil {
+		return fmt.Errorf("Error enabling %s auth backend at %q: %s", authType, path, err)
+	}
+
+	d.SetId(path)
+
+	return resourceVaultAuthBackendRead(d, meta)
+}
+
+func resourceVaultAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return fmt.Errorf("Error reading auth backends from Vault: %s", err)
+	}
+
+	auth, ok := auths[d.Id()+"/"]
+	if !ok {
+		log.Printf("[WARN] Auth backend %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", d.Id())
+	d.Set("type", auth.Type)
+	d.Set("description", auth.Description)
+
+	return nil
+}
+
+func resourceVaultAuthBackendDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Disabling auth backend %s", d.Id())
+	if err := client.Sys().DisableAuth(d.Id()); err != nil {
+		return fmt.Errorf("Error disabling auth backend %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,83 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccVaultAuthBackend_basic(t *testing.T) {
+	path := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVaultAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccVaultAuthBackendConfig, path),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVaultAuthBackendExists("vault_auth_backend.test"),
+					resource.TestCheckResourceAttr(
+						"vault_auth_backend.test", "path", path),
+					resource.TestCheckResourceAttr(
+						"vault_auth_backend.test", "type", "userpass"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVaultAuthBackendExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*api.Client)
+		auths, err := client.Sys().ListAuth()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := auths[rs.Primary.ID+"/"]; !ok {
+			return fmt.Errorf("Auth backend %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVaultAuthBackendDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*api.Client)
+
+	auths, err := client.Sys().ListAuth()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_auth_backend" {
+			continue
+		}
+
+		if _, ok := auths[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("Auth backend %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccVaultAuthBackendConfig = `
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%s"
+  description = "Terraform acceptance test"
+}
+`
@@ -0,0 +1,163 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceVaultMount() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVaultMountCreate,
+		Read:   resourceVaultMountRead,
+		Update: resourceVaultMountUpdate,
+		Delete: resourceVaultMountDelete,
+
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Where the secret backend will be mounted",
+				StateFunc:   normalizeVaultPath,
+			},
+
+			"type": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the backend, such as 'generic' or 'aws'",
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Human-friendly description of the mount",
+			},
+
+			"default_lease_ttl_seconds": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default lease duration for tokens and secrets in seconds",
+			},
+
+			"max_lease_ttl_seconds": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum possible lease duration for tokens and secrets in seconds",
+			},
+		},
+	}
+}
+
+func resourceVaultMountCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	path := normalizeVaultPath(d.Get("path"))
+	info := &api.MountInput{
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		Config:      mountConfigInput(d),
+	}
+
+	log.Printf("[DEBUG] Mounting %s backend at %s", info.Type, path)
+	if err := client.Sys().Mount(path, info); err != nil {
+		return fmt.Errorf("Error mounting to %q: %s", path, err)
+	}
+
+	d.SetId(path)
+
+	return resourceVaultMountRead(d, meta)
+}
+
+func resourceVaultMountRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return fmt.Errorf("Error reading mounts from Vault: %s", err)
+	}
+
+	mount, ok := mounts[d.Id()+"/"]
+	if !ok {
+		log.Printf("[WARN] Mount %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("path", d.Id())
+	d.Set("type", mount.Type)
+	d.Set("description", mount.Description)
+	d.Set("default_lease_ttl_seconds", mount.Config.DefaultLeaseTTL)
+	d.Set("max_lease_ttl_seconds", mount.Config.MaxLeaseTTL)
+
+	return nil
+}
+
+func resourceVaultMountUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	d.Partial(true)
+
+	if d.HasChange("path") {
+		path := normalizeVaultPath(d.Get("path"))
+
+		log.Printf("[DEBUG] Remounting %s to %s", d.Id(), path)
+		if err := client.Sys().Remount(d.Id(), path); err != nil {
+			return fmt.Errorf("Error remounting %q to %q: %s", d.Id(), path, err)
+		}
+
+		d.SetId(path)
+		d.SetPartial("path")
+	}
+
+	if d.HasChange("default_lease_ttl_seconds") || d.HasChange("max_lease_ttl_seconds") {
+		log.Printf("[DEBUG] Tuning mount %s", d.Id())
+		if err := client.Sys().TuneMount(d.Id(), mountConfigInput(d)); err != nil {
+			return fmt.Errorf("Error tuning mount %q: %s", d.Id(), err)
+		}
+
+		d.SetPartial("default_lease_ttl_seconds")
+		d.SetPartial("max_lease_ttl_seconds")
+	}
+
+	d.Partial(false)
+
+	return resourceVaultMountRead(d, meta)
+}
+
+func resourceVaultMountDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Unmounting %s", d.Id())
+	if err := client.Sys().Unmount(d.Id()); err != nil {
+		return fmt.Errorf("Error unmounting %q: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// mountConfigInput returns the TTLs of a mount, where zero means the
+// system default.
+func mountConfigInput(d *schema.ResourceData) api.MountConfigInput {
+	var config api.MountConfigInput
+	if v, ok := d.GetOk("default_lease_ttl_seconds"); ok {
+		config.DefaultLeaseTTL = fmt.Sprintf("%ds", v.(int))
+	}
+	if v, ok := d.GetOk("max_lease_ttl_seconds"); ok {
+		config.MaxLeaseTTL = fmt.Sprintf("%ds", v.(int))
+	}
+	return config
+}
+
+// normalizeVaultPath strips the slashes Vault adds to and ignores on mount
+// paths, so they don't show up as a diff.
+func normalizeVaultPath(v interface{}) string {
+	return strings.Trim(v.(string), "/")
+}
@@ -0,0 +1,106 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccVaultMount_basic(t *testing.T) {
+	path := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	newPath := path + "-moved"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVaultMountDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVaultMountConfig(path, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVaultMountExists("vault_mount.test"),
+					resource.TestCheckResourceAttr(
+						"vault_mount.test", "path", path),
+					resource.TestCheckResourceAttr(
+						"vault_mount.test", "type", "generic"),
+					resource.TestCheckResourceAttr(
+						"vault_mount.test", "default_lease_ttl_seconds", "3600"),
+				),
+			},
+
+			// Moving and tuning the mount updates it in place
+			resource.TestStep{
+				Config: testAccVaultMountConfig(newPath, 7200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVaultMountExists("vault_mount.test"),
+					resource.TestCheckResourceAttr(
+						"vault_mount.test", "path", newPath),
+					resource.TestCheckResourceAttr(
+						"vault_mount.test", "default_lease_ttl_seconds", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVaultMountExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No mount path is set")
+		}
+
+		client := testAccProvider.Meta().(*api.Client)
+		mounts, err := client.Sys().ListMounts()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := mounts[rs.Primary.ID+"/"]; !ok {
+			return fmt.Errorf("Mount %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVaultMountDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*api.Client)
+
+	mounts, err := client.Sys().ListMounts()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_mount" {
+			continue
+		}
+
+		if _, ok := mounts[rs.Primary.ID+"/"]; ok {
+			return fmt.Errorf("Mount %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccVaultMountConfig(path string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+  path = "%s"
+  type = "generic"
+  description = "Terraform acceptance test"
+  default_lease_ttl_seconds = %d
+  max_lease_ttl_seconds = 36000
+}
+`, path, ttl)
+}
@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+func resourceVaultPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVaultPolicyWrite,
+		Read:   resourceVaultPolicyRead,
+		Update: resourceVaultPolicyWrite,
+		Delete: resourceVaultPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the policy",
+			},
+
+			"policy": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The policy document, in HCL",
+			},
+		},
+	}
+}
+
+func resourceVaultPolicyWrite(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Writing policy %s to Vault", name)
+	if err := client.Sys().PutPolicy(name, d.Get("policy").(string)); err != nil {
+		return fmt.Errorf("Error writing policy %q to Vault: %s", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourceVaultPolicyRead(d, meta)
+}
+
+func resourceVaultPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	policy, err := client.Sys().GetPolicy(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading policy %q from Vault: %s", d.Id(), err)
+	}
+
+	if policy == "" {
+		log.Printf("[WARN] Policy %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", d.Id())
+	d.Set("policy", policy)
+
+	return nil
+}
+
+func resourceVaultPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*api.Client)
+
+	log.Printf("[DEBUG] Deleting policy %s from Vault", d.Id())
+	if err := client.Sys().DeletePolicy(d.Id()); err != nil {
+		return fmt.Errorf("Error deleting policy %q from Vault: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
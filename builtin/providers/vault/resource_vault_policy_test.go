@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/xanzy/terraform-api/helper/acctest"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestAccVaultPolicy_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVaultPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccVaultPolicyConfig(name, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVaultPolicyExists("vault_policy.test"),
+					resource.TestCheckResourceAttr(
+						"vault_policy.test", "name", name),
+				),
+			},
+			resource.TestStep{
+				Config: testAccVaultPolicyConfig(name, "write"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVaultPolicyExists("vault_policy.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVaultPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*api.Client)
+		policy, err := client.Sys().GetPolicy(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if policy != rs.Primary.Attributes["policy"] {
+			return fmt.Errorf("Policy %s doesn't match, got: %s", rs.Primary.ID, policy)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVaultPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*api.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_policy" {
+			continue
+		}
+
+		policy, err := client.Sys().GetPolicy(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if policy != "" {
+			return fmt.Errorf("Policy %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccVaultPolicyConfig(name, policy string) string {
+	return fmt.Sprintf(`
+resource "vault_policy" "test" {
+  name = "%s"
+  policy = <<EOT
+path "secret/tf-test/*" {
+  policy = "%s"
+}
+EOT
+}
+`, name, policy)
+}
@@ -0,0 +1,215 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/xanzy/terraform-api/helper/schema"
+	"golang.org/x/net/context"
+)
+
+type virtualDisk struct {
+	size        int64
+	vmdkPath    string
+	initType    string
+	adapterType string
+	datacenter  string
+	datastore   string
+}
+
+func resourceVSphereVirtualDisk() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereVirtualDiskCreate,
+		Read:   resourceVSphereVirtualDiskRead,
+		Delete: resourceVSphereVirtualDiskDelete,
+
+		Schema: map[string]*schema.Schema{
+			// Size in GB
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vmdk_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "eagerZeroedThick",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "thin" && value != "eagerZeroedThick" && value != "lazy" {
+						errors = append(errors, fmt.Errorf(
+							"only 'thin', 'eagerZeroedThick', and 'lazy' are supported values for 'type'"))
+					}
+					return
+				},
+			},
+
+			"adapter_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "ide",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "ide" && value != "busLogic" && value != "lsiLogic" {
+						errors = append(errors, fmt.Errorf(
+							"only 'ide', 'busLogic', and 'lsiLogic' are supported values for 'adapter_type'"))
+					}
+					return
+				},
+			},
+
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"datastore": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceVSphereVirtualDiskCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Creating Virtual Disk")
+	client := meta.(*govmomi.Client)
+
+	vDisk := virtualDisk{
+		size:        int64(d.Get("size").(int)),
+		vmdkPath:    d.Get("vmdk_path").(string),
+		initType:    d.Get("type").(string),
+		adapterType: d.Get("adapter_type").(string),
+	}
+
+	if v, ok := d.GetOk("datacenter"); ok {
+		vDisk.datacenter = v.(string)
+	}
+
+	if v, ok := d.GetOk("datastore"); ok {
+		vDisk.datastore = v.(string)
+	}
+
+	dc, err := getDatacenter(client, vDisk.datacenter)
+	if err != nil {
+		return fmt.Errorf("Error finding Datacenter: %s: %s", vDisk.datacenter, err)
+	}
+
+	ds, err := getDatastore(client, dc, vDisk.datastore)
+	if err != nil {
+		return fmt.Errorf("Error finding Datastore: %s: %s", vDisk.datastore, err)
+	}
+
+	err = createHardDisk(client, vDisk.size, ds.Path(vDisk.vmdkPath), vDisk.initType, vDisk.adapterType, dc)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(ds.Path(vDisk.vmdkPath))
+	log.Printf("[DEBUG] Virtual Disk id: %v", d.Id())
+
+	return resourceVSphereVirtualDiskRead(d, meta)
+}
+
+func resourceVSphereVirtualDiskRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Reading virtual disk.")
+	client := meta.(*govmomi.Client)
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	virtualDiskManager := object.NewVirtualDiskManager(client.Client)
+	_, err = virtualDiskManager.QueryVirtualDiskUuid(context.TODO(), d.Id(), dc)
+	if err != nil {
+		if soap.IsSoapFault(err) {
+			if _, ok := soap.ToSoapFault(err).VimFault().(types.FileNotFound); ok {
+				log.Printf("[WARN] Virtual Disk %s not found, removing from state", d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return fmt.Errorf("Error reading Virtual Disk %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceVSphereVirtualDiskDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*govmomi.Client)
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	virtualDiskManager := object.NewVirtualDiskManager(client.Client)
+	task, err := virtualDiskManager.DeleteVirtualDisk(context.TODO(), d.Id(), dc)
+	if err != nil {
+		return err
+	}
+
+	_, err = task.WaitForResult(context.TODO(), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to delete Virtual Disk %s: %s", d.Id(), err)
+	}
+
+	log.Printf("[INFO] Deleted Virtual Disk: %s", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// createHardDisk creates a new Hard Disk.
+func createHardDisk(client *govmomi.Client, size int64, diskPath string, diskType string, adapterType string, dc *object.Datacenter) error {
+	virtualDiskManager := object.NewVirtualDiskManager(client.Client)
+	spec := &types.FileBackedVirtualDiskSpec{
+		VirtualDiskSpec: types.VirtualDiskSpec{
+			AdapterType: adapterType,
+			DiskType:    diskType,
+		},
+		CapacityKb: 1024 * 1024 * size,
+	}
+	log.Printf("[DEBUG] Disk spec: %v", spec)
+
+	task, err := virtualDiskManager.CreateVirtualDisk(context.TODO(), diskPath, dc, spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = task.WaitForResult(context.TODO(), nil)
+	if err != nil {
+		return fmt.Errorf("Failed to create Virtual Disk %s: %s", diskPath, err)
+	}
+	log.Printf("[INFO] Created Virtual Disk: %s", diskPath)
+
+	return nil
+}
+
+// getDatastore gets the given datastore, or the default datastore of the
+// datacenter if no name is given.
+func getDatastore(c *govmomi.Client, dc *object.Datacenter, ds string) (*object.Datastore, error) {
+	finder := find.NewFinder(c.Client, true)
+	finder = finder.SetDatacenter(dc)
+	if ds != "" {
+		return finder.Datastore(context.TODO(), ds)
+	}
+
+	return finder.DefaultDatastore(context.TODO())
+}
@@ -0,0 +1,106 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/xanzy/terraform-api/helper/resource"
+	"github.com/xanzy/terraform-api/terraform"
+	"golang.org/x/net/context"
+)
+
+func TestAccVSphereVirtualDisk_basic(t *testing.T) {
+	var datacenterOpt string
+	var datastoreOpt string
+
+	if v := os.Getenv("VSPHERE_DATACENTER"); v != "" {
+		datacenterOpt = fmt.Sprintf("    datacenter = \"%s\"\n", v)
+	}
+	if v := os.Getenv("VSPHERE_DATASTORE"); v != "" {
+		datastoreOpt = fmt.Sprintf("    datastore = \"%s\"\n", v)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVSphereVirtualDiskDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(
+					testAccCheckVSphereVirtualDiskConfig_basic,
+					datacenterOpt,
+					datastoreOpt,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVSphereVirtualDiskExists("vsphere_virtual_disk.foo"),
+					resource.TestCheckResourceAttr(
+						"vsphere_virtual_disk.foo", "size", "1"),
+					resource.TestCheckResourceAttr(
+						"vsphere_virtual_disk.foo", "type", "thin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVSphereVirtualDiskExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*govmomi.Client)
+
+		dc, err := getDatacenter(client, rs.Primary.Attributes["datacenter"])
+		if err != nil {
+			return err
+		}
+
+		_, err = object.NewVirtualDiskManager(client.Client).QueryVirtualDiskUuid(
+			context.TODO(), rs.Primary.ID, dc)
+		if err != nil {
+			return fmt.Errorf("Virtual Disk %s not found: %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVSphereVirtualDiskDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*govmomi.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vsphere_virtual_disk" {
+			continue
+		}
+
+		dc, err := getDatacenter(client, rs.Primary.Attributes["datacenter"])
+		if err != nil {
+			return err
+		}
+
+		_, err = object.NewVirtualDiskManager(client.Client).QueryVirtualDiskUuid(
+			context.TODO(), rs.Primary.ID, dc)
+		if err == nil {
+			return fmt.Errorf("Virtual Disk %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckVSphereVirtualDiskConfig_basic = `
+resource "vsphere_virtual_disk" "foo" {
+    size = 1
+    vmdk_path = "tf-test-disk.vmdk"
+    type = "thin"
+%s%s}
+`
@@ -82,6 +82,7 @@ type Provisioner struct {
 	UsePolicyfile        bool        `mapstructure:"use_policyfile"`
 	PolicyGroup          string      `mapstructure:"policy_group"`
 	PolicyName           string      `mapstructure:"policy_name"`
+	NamedRunList         string      `mapstructure:"named_run_list"`
 	HTTPProxy            string      `mapstructure:"http_proxy"`
 	HTTPSProxy           string      `mapstructure:"https_proxy"`
 	NOProxy              []string    `mapstructure:"no_proxy"`
@@ -214,6 +215,9 @@ func (r *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 	if p.UsePolicyfile && p.PolicyGroup == "" {
 		es = append(es, fmt.Errorf("Policyfile enabled but key not found: policy_group"))
 	}
+	if !p.UsePolicyfile && p.NamedRunList != "" {
+		es = append(es, fmt.Errorf("named_run_list can only be used when use_policyfile is enabled"))
+	}
 	if p.ValidationKeyPath != "" {
 		ws = append(ws, "validation_key_path is deprecated, please use "+
 			"validation_key instead and load the key contents via file()")
@@ -336,9 +340,12 @@ func (p *Provisioner) runChefClientFunc(
 		var cmd string
 
 		// Policyfiles do not support chef environments, so don't pass the `-E` flag.
-		if p.UsePolicyfile {
+		switch {
+		case p.UsePolicyfile && p.NamedRunList == "":
 			cmd = fmt.Sprintf("%s -j %q", chefCmd, fb)
-		} else {
+		case p.UsePolicyfile && p.NamedRunList != "":
+			cmd = fmt.Sprintf("%s -j %q -n %q", chefCmd, fb, p.NamedRunList)
+		default:
 			cmd = fmt.Sprintf("%s -j %q -E %q", chefCmd, fb, p.Environment)
 		}
 
@@ -503,8 +510,8 @@ func (p *Provisioner) runCommand(
 	errR, errW := io.Pipe()
 	outDoneCh := make(chan struct{})
 	errDoneCh := make(chan struct{})
-	go p.copyOutput(o, outR, outDoneCh)
-	go p.copyOutput(o, errR, errDoneCh)
+	go p.copyOutput(o, terraform.UIOutputStdout, outR, outDoneCh)
+	go p.copyOutput(o, terraform.UIOutputStderr, errR, errDoneCh)
 
 	cmd := &remote.Cmd{
 		Command: command,
@@ -536,10 +543,11 @@ func (p *Provisioner) runCommand(
 	return nil
 }
 
-func (p *Provisioner) copyOutput(o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+func (p *Provisioner) copyOutput(
+	o terraform.UIOutput, s terraform.UIOutputStream, r io.Reader, doneCh chan<- struct{}) {
 	defer close(doneCh)
 	lr := linereader.New(r)
 	for line := range lr.Ch {
-		o.Output(line)
+		terraform.OutputStream(o, s, line)
 	}
 }
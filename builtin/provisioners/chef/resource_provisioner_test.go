@@ -48,6 +48,58 @@ func TestResourceProvider_Validate_bad(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_policyfile(t *testing.T) {
+	cases := map[string]struct {
+		Config *terraform.ResourceConfig
+		Errors int
+	}{
+		"Good": {
+			Config: testConfig(t, map[string]interface{}{
+				"named_run_list":         "deploy",
+				"node_name":              "nodename1",
+				"policy_group":           "staging",
+				"policy_name":            "webserver",
+				"server_url":             "https://chef.local",
+				"use_policyfile":         true,
+				"validation_client_name": "validator",
+				"validation_key":         "contentsofsomevalidator.pem",
+			}),
+			Errors: 0,
+		},
+
+		"MissingPolicy": {
+			Config: testConfig(t, map[string]interface{}{
+				"node_name":              "nodename1",
+				"server_url":             "https://chef.local",
+				"use_policyfile":         true,
+				"validation_client_name": "validator",
+				"validation_key":         "contentsofsomevalidator.pem",
+			}),
+			Errors: 2,
+		},
+
+		"NamedRunListWithoutPolicyfile": {
+			Config: testConfig(t, map[string]interface{}{
+				"named_run_list":         "deploy",
+				"node_name":              "nodename1",
+				"run_list":               []interface{}{"cookbook::recipe"},
+				"server_url":             "https://chef.local",
+				"validation_client_name": "validator",
+				"validation_key":         "contentsofsomevalidator.pem",
+			}),
+			Errors: 1,
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	for k, tc := range cases {
+		_, errs := r.Validate(tc.Config)
+		if len(errs) != tc.Errors {
+			t.Fatalf("Test %q failed: expected %d errors, got: %v", k, tc.Errors, errs)
+		}
+	}
+}
+
 func testConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
 	r, err := config.NewRawConfig(c)
 	if err != nil {
@@ -126,6 +178,53 @@ func TestResourceProvider_runChefClient(t *testing.T) {
 					path.Join(windowsConfDir, "first-boot.json")): true,
 			},
 		},
+
+		"Policyfile": {
+			Config: testConfig(t, map[string]interface{}{
+				"node_name":              "nodename1",
+				"policy_group":           "staging",
+				"policy_name":            "webserver",
+				"prevent_sudo":           true,
+				"server_url":             "https://chef.local",
+				"use_policyfile":         true,
+				"validation_client_name": "validator",
+				"validation_key_path":    "test-fixtures/validator.pem",
+			}),
+
+			ChefCmd: linuxChefCmd,
+
+			ConfDir: linuxConfDir,
+
+			Commands: map[string]bool{
+				fmt.Sprintf(`%s -j %q`,
+					linuxChefCmd,
+					path.Join(linuxConfDir, "first-boot.json")): true,
+			},
+		},
+
+		"NamedRunList": {
+			Config: testConfig(t, map[string]interface{}{
+				"named_run_list":         "deploy",
+				"node_name":              "nodename1",
+				"policy_group":           "staging",
+				"policy_name":            "webserver",
+				"prevent_sudo":           true,
+				"server_url":             "https://chef.local",
+				"use_policyfile":         true,
+				"validation_client_name": "validator",
+				"validation_key_path":    "test-fixtures/validator.pem",
+			}),
+
+			ChefCmd: linuxChefCmd,
+
+			ConfDir: linuxConfDir,
+
+			Commands: map[string]bool{
+				fmt.Sprintf(`%s -j %q -n "deploy"`,
+					linuxChefCmd,
+					path.Join(linuxConfDir, "first-boot.json")): true,
+			},
+		},
 	}
 
 	r := new(ResourceProvisioner)
@@ -3,6 +3,7 @@ package localexec
 import (
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
 
@@ -36,39 +37,69 @@ func (p *ResourceProvisioner) Apply(
 		return fmt.Errorf("local-exec provisioner command must be a string")
 	}
 
-	// Execute the command using a shell
-	var shell, flag string
-	if runtime.GOOS == "windows" {
-		shell = "cmd"
-		flag = "/C"
+	// Execute the command using a shell, unless an interpreter is given
+	var cmdargs []string
+	if interpreterRaw, ok := c.Config["interpreter"]; ok {
+		interpreter, err := stringList(interpreterRaw)
+		if err != nil {
+			return fmt.Errorf("local-exec provisioner interpreter %s", err)
+		}
+		cmdargs = append(interpreter, command)
+	} else if runtime.GOOS == "windows" {
+		cmdargs = []string{"cmd", "/C", command}
 	} else {
-		shell = "/bin/sh"
-		flag = "-c"
+		cmdargs = []string{"/bin/sh", "-c", command}
 	}
 
-	// Setup the reader that will read the lines from the command
-	pr, pw := io.Pipe()
-	copyDoneCh := make(chan struct{})
-	go p.copyOutput(o, pr, copyDoneCh)
+	// Build the environment the command runs in
+	var env []string
+	if environmentRaw, ok := c.Config["environment"]; ok {
+		environment, err := stringMap(environmentRaw)
+		if err != nil {
+			return fmt.Errorf("local-exec provisioner environment %s", err)
+		}
+
+		env = os.Environ()
+		for k, v := range environment {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	var workingDir string
+	if workingDirRaw, ok := c.Config["working_dir"]; ok {
+		if workingDir, ok = workingDirRaw.(string); !ok {
+			return fmt.Errorf("local-exec provisioner working_dir must be a string")
+		}
+	}
+
+	// Setup the readers that will read the lines from the command
+	outR, outW := io.Pipe()
+	errR, errW := io.Pipe()
+	outDoneCh := make(chan struct{})
+	errDoneCh := make(chan struct{})
+	go p.copyOutput(o, terraform.UIOutputStdout, outR, outDoneCh)
+	go p.copyOutput(o, terraform.UIOutputStderr, errR, errDoneCh)
 
 	// Setup the command
-	cmd := exec.Command(shell, flag, command)
+	cmd := exec.Command(cmdargs[0], cmdargs[1:]...)
+	cmd.Env = env
+	cmd.Dir = workingDir
 	output, _ := circbuf.NewBuffer(maxBufSize)
-	cmd.Stderr = io.MultiWriter(output, pw)
-	cmd.Stdout = io.MultiWriter(output, pw)
+	cmd.Stderr = io.MultiWriter(output, errW)
+	cmd.Stdout = io.MultiWriter(output, outW)
 
 	// Output what we're about to run
-	o.Output(fmt.Sprintf(
-		"Executing: %s %s \"%s\"",
-		shell, flag, command))
+	o.Output(fmt.Sprintf("Executing: %q", cmdargs))
 
 	// Run the command to completion
 	err := cmd.Run()
 
-	// Close the write-end of the pipe so that the goroutine mirroring output
-	// ends properly.
-	pw.Close()
-	<-copyDoneCh
+	// Close the write-end of the pipes so that the goroutines mirroring
+	// output end properly.
+	outW.Close()
+	errW.Close()
+	<-outDoneCh
+	<-errDoneCh
 
 	if err != nil {
 		return fmt.Errorf("Error running command '%s': %v. Output: %s",
@@ -81,15 +112,69 @@ func (p *ResourceProvisioner) Apply(
 func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) ([]string, []error) {
 	validator := config.Validator{
 		Required: []string{"command"},
+		Optional: []string{"interpreter.*", "environment.*", "working_dir"},
 	}
 	return validator.Validate(c)
 }
 
 func (p *ResourceProvisioner) copyOutput(
-	o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+	o terraform.UIOutput, s terraform.UIOutputStream,
+	r io.Reader, doneCh chan<- struct{}) {
 	defer close(doneCh)
 	lr := linereader.New(r)
 	for line := range lr.Ch {
-		o.Output(line)
+		terraform.OutputStream(o, s, line)
+	}
+}
+
+// stringList converts the raw interpreter configuration into a non-empty
+// list of strings.
+func stringList(raw interface{}) ([]string, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("must contain at least one element")
 	}
+
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a list of strings")
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// stringMap converts the raw environment configuration into a map. The
+// configuration is decoded as a list containing a single map, but a plain
+// map is accepted as well.
+func stringMap(raw interface{}) (map[string]string, error) {
+	var m map[string]interface{}
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		m = v
+	case []map[string]interface{}:
+		if len(v) != 1 {
+			return nil, fmt.Errorf("must be a single map")
+		}
+		m = v[0]
+	default:
+		return nil, fmt.Errorf("must be a map")
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value for %q must be a string", k)
+		}
+		result[k] = s
+	}
+
+	return result, nil
 }
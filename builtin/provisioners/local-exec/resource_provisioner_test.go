@@ -3,7 +3,9 @@ package localexec
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/xanzy/terraform-api/config"
@@ -39,6 +41,84 @@ func TestResourceProvider_Apply(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Apply_interpreter(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"command":     "foo",
+		"interpreter": []interface{}{"echo", "interpreted"},
+	})
+
+	var l sync.Mutex
+	var lines []string
+	output := &terraform.CallbackUIOutput{
+		OutputFn: func(string) {},
+		OutputStreamFn: func(s terraform.UIOutputStream, v string) {
+			l.Lock()
+			defer l.Unlock()
+			lines = append(lines, strings.TrimSpace(v))
+		},
+	}
+
+	p := new(ResourceProvisioner)
+	if err := p.Apply(output, nil, c); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if len(lines) != 1 || lines[0] != "interpreted foo" {
+		t.Fatalf("bad: %#v", lines)
+	}
+}
+
+func TestResourceProvider_Apply_environment(t *testing.T) {
+	defer os.Remove("test_out")
+	c := testConfig(t, map[string]interface{}{
+		"command": "echo $FOO > test_out",
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO": "bar",
+			},
+		},
+	})
+
+	output := new(terraform.MockUIOutput)
+	p := new(ResourceProvisioner)
+	if err := p.Apply(output, nil, c); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	raw, err := ioutil.ReadFile("test_out")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	actual := strings.TrimSpace(string(raw))
+	if actual != "bar" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceProvider_Apply_workingDir(t *testing.T) {
+	td, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(td)
+
+	c := testConfig(t, map[string]interface{}{
+		"command":     "echo foo > test_out",
+		"working_dir": td,
+	})
+
+	output := new(terraform.MockUIOutput)
+	p := new(ResourceProvisioner)
+	if err := p.Apply(output, nil, c); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(td, "test_out")); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
 func TestResourceProvider_Validate_good(t *testing.T) {
 	c := testConfig(t, map[string]interface{}{
 		"command": "echo foo",
@@ -53,6 +133,27 @@ func TestResourceProvider_Validate_good(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_optional(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"command":     "echo foo",
+		"interpreter": []interface{}{"/bin/bash", "-c"},
+		"working_dir": "/tmp",
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO": "bar",
+			},
+		},
+	})
+	p := new(ResourceProvisioner)
+	warn, errs := p.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
 func TestResourceProvider_Validate_missing(t *testing.T) {
 	c := testConfig(t, map[string]interface{}{})
 	p := new(ResourceProvisioner)
@@ -75,3 +176,32 @@ func testConfig(
 
 	return terraform.NewResourceConfig(r)
 }
+
+func TestResourceProvider_Apply_streams(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"command": "echo out; echo err 1>&2",
+	})
+
+	var l sync.Mutex
+	streams := make(map[string]terraform.UIOutputStream)
+	output := &terraform.CallbackUIOutput{
+		OutputFn: func(string) {},
+		OutputStreamFn: func(s terraform.UIOutputStream, v string) {
+			l.Lock()
+			defer l.Unlock()
+			streams[strings.TrimSpace(v)] = s
+		},
+	}
+
+	p := new(ResourceProvisioner)
+	if err := p.Apply(output, nil, c); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if s, ok := streams["out"]; !ok || s != terraform.UIOutputStdout {
+		t.Fatalf("bad: %#v", streams)
+	}
+	if s, ok := streams["err"]; !ok || s != terraform.UIOutputStderr {
+		t.Fatalf("bad: %#v", streams)
+	}
+}
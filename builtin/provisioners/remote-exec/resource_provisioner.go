@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,10 +41,12 @@ func (p *ResourceProvisioner) Apply(
 	}
 
 	// Copy and execute each script
-	if err := p.runScripts(o, comm, scripts); err != nil {
-		return err
+	err = p.runScripts(o, comm, scripts)
+	if err != nil && onFailure(c) == "continue" {
+		o.Output(fmt.Sprintf("Continuing after remote-exec failure: %v", err))
+		return nil
 	}
-	return nil
+	return err
 }
 
 // Validate checks if the required arguments are configured
@@ -53,6 +56,7 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 		switch name {
 		case "scripts", "script", "inline":
 			num++
+		case "environment", "on_failure":
 		default:
 			es = append(es, fmt.Errorf("Unknown configuration '%s'", name))
 		}
@@ -60,13 +64,64 @@ func (p *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string
 	if num != 1 {
 		es = append(es, fmt.Errorf("Must provide one of 'scripts', 'script' or 'inline' to remote-exec"))
 	}
+
+	if _, ok := c.Raw["environment"]; ok {
+		if _, ok := c.Raw["inline"]; !ok {
+			es = append(es, fmt.Errorf("'environment' can only be used together with 'inline'"))
+		}
+	}
+
+	if v, ok := c.Config["on_failure"]; ok && !c.IsComputed("on_failure") {
+		switch v {
+		case "continue", "fail":
+		default:
+			es = append(es, fmt.Errorf("'on_failure' must be either 'continue' or 'fail', got: %v", v))
+		}
+	}
 	return
 }
 
+// ExitError is returned when a script exits with a non-zero exit status.
+type ExitError struct {
+	ExitStatus int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("Script exited with non-zero exit status: %d", e.ExitStatus)
+}
+
+// onFailure returns the configured failure behavior, which defaults to
+// "fail".
+func onFailure(c *terraform.ResourceConfig) string {
+	if v, ok := c.Config["on_failure"].(string); ok && v != "" {
+		return v
+	}
+	return "fail"
+}
+
 // generateScript takes the configuration and creates a script to be executed
 // from the inline configs
 func (p *ResourceProvisioner) generateScript(c *terraform.ResourceConfig) (string, error) {
 	var lines []string
+
+	// Export the environment before running any of the commands
+	if raw, ok := c.Config["environment"]; ok {
+		env, err := stringMap(raw)
+		if err != nil {
+			return "", fmt.Errorf("Unsupported 'environment' type! %v", err)
+		}
+
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("export %s=%s", k, shellQuote(env[k])))
+		}
+	}
+
 	command, ok := c.Config["inline"]
 	if ok {
 		switch cmd := command.(type) {
@@ -173,8 +228,8 @@ func (p *ResourceProvisioner) runScripts(
 		errR, errW := io.Pipe()
 		outDoneCh := make(chan struct{})
 		errDoneCh := make(chan struct{})
-		go p.copyOutput(o, outR, outDoneCh)
-		go p.copyOutput(o, errR, errDoneCh)
+		go p.copyOutput(o, terraform.UIOutputStdout, outR, outDoneCh)
+		go p.copyOutput(o, terraform.UIOutputStderr, errR, errDoneCh)
 
 		err = retryFunc(comm.Timeout(), func() error {
 			remotePath := comm.ScriptPath()
@@ -197,7 +252,7 @@ func (p *ResourceProvisioner) runScripts(
 		if err == nil {
 			cmd.Wait()
 			if cmd.ExitStatus != 0 {
-				err = fmt.Errorf("Script exited with non-zero exit status: %d", cmd.ExitStatus)
+				err = &ExitError{ExitStatus: cmd.ExitStatus}
 			}
 		}
 
@@ -217,12 +272,48 @@ func (p *ResourceProvisioner) runScripts(
 }
 
 func (p *ResourceProvisioner) copyOutput(
-	o terraform.UIOutput, r io.Reader, doneCh chan<- struct{}) {
+	o terraform.UIOutput, s terraform.UIOutputStream,
+	r io.Reader, doneCh chan<- struct{}) {
 	defer close(doneCh)
 	lr := linereader.New(r)
 	for line := range lr.Ch {
-		o.Output(line)
+		terraform.OutputStream(o, s, line)
+	}
+}
+
+// stringMap converts the raw environment configuration into a map. The
+// configuration is decoded as a list containing a single map, but a plain
+// map is accepted as well.
+func stringMap(raw interface{}) (map[string]string, error) {
+	var m map[string]interface{}
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		m = v
+	case []map[string]interface{}:
+		if len(v) != 1 {
+			return nil, fmt.Errorf("Must be a single map.")
+		}
+		m = v[0]
+	default:
+		return nil, fmt.Errorf("Must be a map.")
 	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("Value for '%s' must be a string.", k)
+		}
+		result[k] = s
+	}
+
+	return result, nil
+}
+
+// shellQuote wraps s in single quotes, so it is used literally by the
+// remote shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
 }
 
 // retryFunc is used to retry a function for a given duration
@@ -3,8 +3,12 @@ package remoteexec
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 
+	"github.com/xanzy/terraform-api/communicator"
+	"github.com/xanzy/terraform-api/communicator/remote"
 	"github.com/xanzy/terraform-api/config"
 	"github.com/xanzy/terraform-api/terraform"
 )
@@ -41,6 +45,41 @@ func TestResourceProvider_Validate_bad(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_Validate_onFailure(t *testing.T) {
+	p := new(ResourceProvisioner)
+
+	c := testConfig(t, map[string]interface{}{
+		"inline":     "echo foo",
+		"on_failure": "continue",
+	})
+	if _, errs := p.Validate(c); len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+
+	c = testConfig(t, map[string]interface{}{
+		"inline":     "echo foo",
+		"on_failure": "ignore",
+	})
+	if _, errs := p.Validate(c); len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
+func TestResourceProvider_Validate_environmentWithoutInline(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"script": "script1",
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO": "bar",
+			},
+		},
+	})
+	p := new(ResourceProvisioner)
+	if _, errs := p.Validate(c); len(errs) == 0 {
+		t.Fatalf("Should have errors")
+	}
+}
+
 var expectedScriptOut = `cd /tmp
 wget http://foobar
 exit 0
@@ -65,6 +104,66 @@ func TestResourceProvider_generateScript(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_generateScript_environment(t *testing.T) {
+	p := new(ResourceProvisioner)
+	conf := testConfig(t, map[string]interface{}{
+		"inline": []interface{}{
+			"echo $FOO",
+		},
+		"environment": []map[string]interface{}{
+			map[string]interface{}{
+				"FOO": "it's",
+				"BAR": "bar",
+			},
+		},
+	})
+	out, err := p.generateScript(conf)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expected := "export BAR='bar'\nexport FOO='it'\\''s'\necho $FOO\n"
+	if out != expected {
+		t.Fatalf("bad: %q", out)
+	}
+}
+
+func TestResourceProvider_runScripts_exitStatus(t *testing.T) {
+	comm := &exitStatusCommunicator{
+		MockCommunicator: communicator.MockCommunicator{
+			RemoteScriptPath: "/tmp/script.sh",
+			UploadScripts: map[string]string{
+				"/tmp/script.sh": "exit 3",
+			},
+		},
+		exitStatus: 3,
+	}
+	scripts := []io.ReadCloser{
+		ioutil.NopCloser(strings.NewReader("exit 3")),
+	}
+
+	p := new(ResourceProvisioner)
+	err := p.runScripts(new(terraform.MockUIOutput), comm, scripts)
+	exitErr, ok := err.(*ExitError)
+	if !ok {
+		t.Fatalf("expected an *ExitError, got: %#v", err)
+	}
+	if exitErr.ExitStatus != 3 {
+		t.Fatalf("bad: %d", exitErr.ExitStatus)
+	}
+}
+
+// exitStatusCommunicator runs every command with a fixed exit status.
+type exitStatusCommunicator struct {
+	communicator.MockCommunicator
+	exitStatus int
+}
+
+func (c *exitStatusCommunicator) Start(r *remote.Cmd) error {
+	r.SetExited(c.exitStatus)
+	return nil
+}
+
 func TestResourceProvider_CollectScripts_inline(t *testing.T) {
 	p := new(ResourceProvisioner)
 	conf := testConfig(t, map[string]interface{}{
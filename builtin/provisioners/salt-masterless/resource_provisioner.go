@@ -0,0 +1,457 @@
+package saltmasterless
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/go-linereader"
+	"github.com/mitchellh/mapstructure"
+	"github.com/xanzy/terraform-api/communicator"
+	"github.com/xanzy/terraform-api/communicator/remote"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+const (
+	bootstrapURL       = "https://bootstrap.saltstack.com"
+	bootstrapScript    = "install_salt.sh"
+	defaultMinionDir   = "/etc/salt"
+	defaultPillarRoots = "/srv/pillar"
+	defaultStateTree   = "/srv/salt"
+	defaultTempDir     = "/tmp/salt"
+	minionConfig       = "minion"
+	saltCallCmd        = "salt-call"
+)
+
+// Provisioner represents a specificly configured salt-masterless provisioner
+type Provisioner struct {
+	BootstrapArgs     string `mapstructure:"bootstrap_args"`
+	CustomState       string `mapstructure:"custom_state"`
+	DisableSudo       bool   `mapstructure:"disable_sudo"`
+	LocalPillarRoots  string `mapstructure:"local_pillar_roots"`
+	LocalStateTree    string `mapstructure:"local_state_tree"`
+	LogLevel          string `mapstructure:"log_level"`
+	MinionConfig      string `mapstructure:"minion_config_file"`
+	NoExitOnFailure   bool   `mapstructure:"no_exit_on_failure"`
+	RemotePillarRoots string `mapstructure:"remote_pillar_roots"`
+	RemoteStateTree   string `mapstructure:"remote_state_tree"`
+	SaltCallArgs      string `mapstructure:"salt_call_args"`
+	SkipBootstrap     bool   `mapstructure:"skip_bootstrap"`
+	TempConfigDir     string `mapstructure:"temp_config_dir"`
+
+	useSudo bool
+}
+
+// ResourceProvisioner represents a generic salt-masterless provisioner
+type ResourceProvisioner struct{}
+
+// Apply executes the salt-masterless provisioner
+func (r *ResourceProvisioner) Apply(
+	o terraform.UIOutput,
+	s *terraform.InstanceState,
+	c *terraform.ResourceConfig) error {
+	// Decode the raw config for this provisioner
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// The salt-masterless provisioner only supports ssh type connections
+	switch s.Ephemeral.ConnInfo["type"] {
+	case "ssh", "": // The default connection type is ssh, so if the type is empty assume ssh
+	default:
+		return fmt.Errorf("Unsupported connection type: %s", s.Ephemeral.ConnInfo["type"])
+	}
+
+	p.useSudo = !p.DisableSudo && s.Ephemeral.ConnInfo["user"] != "root"
+
+	// Get a new communicator
+	comm, err := communicator.New(s)
+	if err != nil {
+		return err
+	}
+
+	// Wait and retry until we establish the connection
+	err = retryFunc(comm.Timeout(), func() error {
+		err := comm.Connect(o)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	defer comm.Disconnect()
+
+	if !p.SkipBootstrap {
+		o.Output("Bootstrapping Salt...")
+		if err := p.bootstrapSalt(o, comm); err != nil {
+			return err
+		}
+	}
+
+	o.Output("Uploading Salt state tree and configuration files...")
+	if err := p.deployStateTree(o, comm); err != nil {
+		return err
+	}
+
+	o.Output("Running salt-call...")
+	return p.runSaltCall(o, comm)
+}
+
+// Validate checks if the required arguments are configured
+func (r *ResourceProvisioner) Validate(c *terraform.ResourceConfig) (ws []string, es []error) {
+	p, err := r.decodeConfig(c)
+	if err != nil {
+		es = append(es, err)
+		return ws, es
+	}
+
+	if p.LocalStateTree == "" {
+		es = append(es, fmt.Errorf("Key not found: local_state_tree"))
+	} else if !c.IsComputed("local_state_tree") {
+		if err := validateDirConfig(p.LocalStateTree, "local_state_tree"); err != nil {
+			es = append(es, err)
+		}
+	}
+
+	if p.LocalPillarRoots != "" && !c.IsComputed("local_pillar_roots") {
+		if err := validateDirConfig(p.LocalPillarRoots, "local_pillar_roots"); err != nil {
+			es = append(es, err)
+		}
+	}
+
+	if p.MinionConfig != "" {
+		if !c.IsComputed("minion_config_file") {
+			if err := validateFileConfig(p.MinionConfig, "minion_config_file"); err != nil {
+				es = append(es, err)
+			}
+		}
+
+		// The remote state tree and pillar roots are configured in the
+		// minion config, so setting them here as well is ambiguous.
+		if _, ok := c.Get("remote_state_tree"); ok {
+			es = append(es, fmt.Errorf(
+				"remote_state_tree cannot be used in combination with minion_config_file"))
+		}
+		if _, ok := c.Get("remote_pillar_roots"); ok {
+			es = append(es, fmt.Errorf(
+				"remote_pillar_roots cannot be used in combination with minion_config_file"))
+		}
+	}
+
+	if p.SkipBootstrap && p.BootstrapArgs != "" {
+		ws = append(ws, "bootstrap_args is ignored when skip_bootstrap is true")
+	}
+
+	return ws, es
+}
+
+func (r *ResourceProvisioner) decodeConfig(c *terraform.ResourceConfig) (*Provisioner, error) {
+	p := new(Provisioner)
+
+	decConf := &mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           p,
+	}
+	dec, err := mapstructure.NewDecoder(decConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need to merge both configs into a single map first. Order is
+	// important as we need to make sure interpolated values are used
+	// over raw values. This makes sure that all values are there even
+	// if some still need to be interpolated later on.
+	m := make(map[string]interface{})
+
+	for k, v := range c.Raw {
+		m[k] = v
+	}
+
+	for k, v := range c.Config {
+		m[k] = v
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	if p.RemoteStateTree == "" {
+		p.RemoteStateTree = defaultStateTree
+	}
+
+	if p.RemotePillarRoots == "" {
+		p.RemotePillarRoots = defaultPillarRoots
+	}
+
+	if p.TempConfigDir == "" {
+		p.TempConfigDir = defaultTempDir
+	}
+
+	for _, local := range []*string{&p.LocalStateTree, &p.LocalPillarRoots, &p.MinionConfig} {
+		if *local == "" {
+			continue
+		}
+		expanded, err := homedir.Expand(*local)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding the path %s: %v", *local, err)
+		}
+		*local = expanded
+	}
+
+	return p, nil
+}
+
+func validateDirConfig(dir, name string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("%s: path '%s' is invalid: %v", name, dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: path '%s' must point to a directory", name, dir)
+	}
+	return nil
+}
+
+func validateFileConfig(file, name string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("%s: path '%s' is invalid: %v", name, file, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: path '%s' must point to a file", name, file)
+	}
+	return nil
+}
+
+// retryFunc is used to retry a function for a given duration
+func retryFunc(timeout time.Duration, f func() error) error {
+	finish := time.After(timeout)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Retryable error: %v", err)
+
+		select {
+		case <-finish:
+			return err
+		case <-time.After(3 * time.Second):
+		}
+	}
+}
+
+func (p *Provisioner) bootstrapSalt(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	script := path.Join(p.TempConfigDir, bootstrapScript)
+
+	// First make sure the temp dir exists so we have a place to put the script
+	if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", p.TempConfigDir), false); err != nil {
+		return err
+	}
+
+	// Then download the bootstrap script from SaltStack
+	err := p.runCommand(o, comm, fmt.Sprintf("curl -L %s -o %q", bootstrapURL, script), false)
+	if err != nil {
+		return err
+	}
+
+	// Execute the bootstrap script to install Salt
+	err = p.runCommand(o, comm, fmt.Sprintf("sh %q %s", script, p.BootstrapArgs), true)
+	if err != nil {
+		return err
+	}
+
+	// And finally cleanup the bootstrap script again
+	return p.runCommand(o, comm, fmt.Sprintf("rm -f %q", script), false)
+}
+
+func (p *Provisioner) deployStateTree(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	tmpDir := p.TempConfigDir
+
+	// Make sure the temp dir exists and is writable for the upload
+	if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", tmpDir), false); err != nil {
+		return err
+	}
+
+	if p.MinionConfig != "" {
+		f, err := os.Open(p.MinionConfig)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		dst := path.Join(tmpDir, minionConfig)
+		if err := comm.Upload(dst, f); err != nil {
+			return fmt.Errorf("Uploading %s failed: %v", minionConfig, err)
+		}
+
+		if err := p.moveFile(o, comm, path.Join(defaultMinionDir, minionConfig), dst); err != nil {
+			return err
+		}
+	}
+
+	if err := p.deployDir(o, comm, p.LocalStateTree, tmpDir, "states", p.RemoteStateTree); err != nil {
+		return err
+	}
+
+	if p.LocalPillarRoots != "" {
+		if err := p.deployDir(o, comm, p.LocalPillarRoots, tmpDir, "pillar", p.RemotePillarRoots); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deployDir uploads a local directory to a staging location within the
+// temp dir and then (using sudo if needed) moves it to its final location.
+func (p *Provisioner) deployDir(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	src, tmpDir, name, dst string) error {
+	staging := path.Join(tmpDir, name)
+
+	// Make sure the staging dir exists and doesn't contain any leftovers
+	if err := p.runCommand(o, comm, fmt.Sprintf("rm -rf %q", staging), true); err != nil {
+		return err
+	}
+	if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", staging), false); err != nil {
+		return err
+	}
+
+	// A trailing slash makes sure only the contents of the directory are
+	// uploaded instead of the directory itself.
+	if !strings.HasSuffix(src, "/") {
+		src += "/"
+	}
+	if err := comm.UploadDir(staging, src); err != nil {
+		return fmt.Errorf("Uploading %s failed: %v", src, err)
+	}
+
+	if err := p.runCommand(o, comm, fmt.Sprintf("rm -rf %q", dst), true); err != nil {
+		return err
+	}
+
+	return p.moveFile(o, comm, dst, staging)
+}
+
+func (p *Provisioner) moveFile(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	dst, src string) error {
+	if err := p.runCommand(o, comm, fmt.Sprintf("mkdir -p %q", path.Dir(dst)), true); err != nil {
+		return err
+	}
+	return p.runCommand(o, comm, fmt.Sprintf("mv %q %q", src, dst), true)
+}
+
+// saltCallCommand builds the salt-call command used to apply the states
+func (p *Provisioner) saltCallCommand() string {
+	var buf bytes.Buffer
+
+	buf.WriteString(saltCallCmd)
+	buf.WriteString(" --local")
+
+	if p.CustomState == "" {
+		buf.WriteString(" state.highstate")
+	} else {
+		buf.WriteString(" state.sls " + p.CustomState)
+	}
+
+	// When using a custom minion config the state tree and pillar roots
+	// are expected to be configured in that file.
+	if p.MinionConfig == "" {
+		fmt.Fprintf(&buf, " --file-root=%s", p.RemoteStateTree)
+		fmt.Fprintf(&buf, " --pillar-root=%s", p.RemotePillarRoots)
+	}
+
+	if !p.NoExitOnFailure {
+		buf.WriteString(" --retcode-passthrough")
+	}
+
+	if p.LogLevel != "" {
+		buf.WriteString(" -l " + p.LogLevel)
+	}
+
+	if p.SaltCallArgs != "" {
+		buf.WriteString(" " + p.SaltCallArgs)
+	}
+
+	return buf.String()
+}
+
+func (p *Provisioner) runSaltCall(
+	o terraform.UIOutput,
+	comm communicator.Communicator) error {
+	return p.runCommand(o, comm, p.saltCallCommand(), true)
+}
+
+// runCommand is used to run already prepared commands
+func (p *Provisioner) runCommand(
+	o terraform.UIOutput,
+	comm communicator.Communicator,
+	command string,
+	sudo bool) error {
+	var err error
+
+	// Unless prevented, prefix the command with sudo
+	if sudo && p.useSudo {
+		command = "sudo " + command
+	}
+
+	outR, outW := io.Pipe()
+	errR, errW := io.Pipe()
+	outDoneCh := make(chan struct{})
+	errDoneCh := make(chan struct{})
+	go p.copyOutput(o, terraform.UIOutputStdout, outR, outDoneCh)
+	go p.copyOutput(o, terraform.UIOutputStderr, errR, errDoneCh)
+
+	cmd := &remote.Cmd{
+		Command: command,
+		Stdout:  outW,
+		Stderr:  errW,
+	}
+
+	if err := comm.Start(cmd); err != nil {
+		return fmt.Errorf("Error executing command %q: %v", cmd.Command, err)
+	}
+
+	cmd.Wait()
+	if cmd.ExitStatus != 0 {
+		err = fmt.Errorf(
+			"Command %q exited with non-zero exit status: %d", cmd.Command, cmd.ExitStatus)
+	}
+
+	// Wait for output to clean up
+	outW.Close()
+	errW.Close()
+	<-outDoneCh
+	<-errDoneCh
+
+	// If we have an error, return it out now that we've cleaned up
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provisioner) copyOutput(
+	o terraform.UIOutput, s terraform.UIOutputStream, r io.Reader, doneCh chan<- struct{}) {
+	defer close(doneCh)
+	lr := linereader.New(r)
+	for line := range lr.Ch {
+		terraform.OutputStream(o, s, line)
+	}
+}
@@ -0,0 +1,192 @@
+package saltmasterless
+
+import (
+	"testing"
+
+	"github.com/xanzy/terraform-api/communicator"
+	"github.com/xanzy/terraform-api/config"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestResourceProvisioner_impl(t *testing.T) {
+	var _ terraform.ResourceProvisioner = new(ResourceProvisioner)
+}
+
+func TestResourceProvider_Validate_good(t *testing.T) {
+	c := testConfig(t, map[string]interface{}{
+		"local_state_tree":   "test-fixtures/salt",
+		"local_pillar_roots": "test-fixtures/pillar",
+	})
+	r := new(ResourceProvisioner)
+	warn, errs := r.Validate(c)
+	if len(warn) > 0 {
+		t.Fatalf("Warnings: %v", warn)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("Errors: %v", errs)
+	}
+}
+
+func TestResourceProvider_Validate_bad(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"Invalid": {
+			"invalid": "nope",
+		},
+
+		"MissingStateTree": {
+			"log_level": "debug",
+		},
+
+		"StateTreeNotDir": {
+			"local_state_tree": "test-fixtures/minion",
+		},
+
+		"MinionConfigWithRemoteStateTree": {
+			"local_state_tree":   "test-fixtures/salt",
+			"minion_config_file": "test-fixtures/minion",
+			"remote_state_tree":  "/srv/salt",
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	for k, raw := range cases {
+		_, errs := r.Validate(testConfig(t, raw))
+		if len(errs) == 0 {
+			t.Fatalf("Test %q should have errors", k)
+		}
+	}
+}
+
+func testConfig(t *testing.T, c map[string]interface{}) *terraform.ResourceConfig {
+	r, err := config.NewRawConfig(c)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+
+	return terraform.NewResourceConfig(r)
+}
+
+func TestResourceProvider_saltCallCommand(t *testing.T) {
+	cases := map[string]struct {
+		Config  *terraform.ResourceConfig
+		Command string
+	}{
+		"Defaults": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "test-fixtures/salt",
+			}),
+
+			Command: "salt-call --local state.highstate --file-root=/srv/salt " +
+				"--pillar-root=/srv/pillar --retcode-passthrough",
+		},
+
+		"CustomState": {
+			Config: testConfig(t, map[string]interface{}{
+				"custom_state":       "webserver",
+				"local_state_tree":   "test-fixtures/salt",
+				"log_level":          "debug",
+				"no_exit_on_failure": true,
+				"salt_call_args":     "--state-output=mixed",
+			}),
+
+			Command: "salt-call --local state.sls webserver --file-root=/srv/salt " +
+				"--pillar-root=/srv/pillar -l debug --state-output=mixed",
+		},
+
+		"MinionConfig": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree":   "test-fixtures/salt",
+				"minion_config_file": "test-fixtures/minion",
+			}),
+
+			Command: "salt-call --local state.highstate --retcode-passthrough",
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	for k, tc := range cases {
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		if cmd := p.saltCallCommand(); cmd != tc.Command {
+			t.Fatalf("Test %q failed:\n\nexpected: %q\n\ngot: %q", k, tc.Command, cmd)
+		}
+	}
+}
+
+func TestResourceProvider_deployStateTree(t *testing.T) {
+	cases := map[string]struct {
+		Config     *terraform.ResourceConfig
+		Commands   map[string]bool
+		UploadDirs map[string]string
+	}{
+		"Sudo": {
+			Config: testConfig(t, map[string]interface{}{
+				"local_state_tree": "test-fixtures/salt",
+			}),
+
+			Commands: map[string]bool{
+				`mkdir -p "/tmp/salt"`:                   true,
+				`sudo rm -rf "/tmp/salt/states"`:         true,
+				`mkdir -p "/tmp/salt/states"`:            true,
+				`sudo rm -rf "/srv/salt"`:                true,
+				`sudo mkdir -p "/srv"`:                   true,
+				`sudo mv "/tmp/salt/states" "/srv/salt"`: true,
+			},
+
+			UploadDirs: map[string]string{
+				"test-fixtures/salt/": "/tmp/salt/states",
+			},
+		},
+
+		"NoSudoWithPillar": {
+			Config: testConfig(t, map[string]interface{}{
+				"disable_sudo":       true,
+				"local_pillar_roots": "test-fixtures/pillar",
+				"local_state_tree":   "test-fixtures/salt",
+				"temp_config_dir":    "/tmp/custom",
+			}),
+
+			Commands: map[string]bool{
+				`mkdir -p "/tmp/custom"`:                true,
+				`rm -rf "/tmp/custom/states"`:           true,
+				`mkdir -p "/tmp/custom/states"`:         true,
+				`rm -rf "/srv/salt"`:                    true,
+				`mkdir -p "/srv"`:                       true,
+				`mv "/tmp/custom/states" "/srv/salt"`:   true,
+				`rm -rf "/tmp/custom/pillar"`:           true,
+				`mkdir -p "/tmp/custom/pillar"`:         true,
+				`rm -rf "/srv/pillar"`:                  true,
+				`mv "/tmp/custom/pillar" "/srv/pillar"`: true,
+			},
+
+			UploadDirs: map[string]string{
+				"test-fixtures/salt/":   "/tmp/custom/states",
+				"test-fixtures/pillar/": "/tmp/custom/pillar",
+			},
+		},
+	}
+
+	r := new(ResourceProvisioner)
+	o := new(terraform.MockUIOutput)
+	c := new(communicator.MockCommunicator)
+
+	for k, tc := range cases {
+		c.Commands = tc.Commands
+		c.UploadDirs = tc.UploadDirs
+
+		p, err := r.decodeConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		p.useSudo = !p.DisableSudo
+
+		err = p.deployStateTree(o, c)
+		if err != nil {
+			t.Fatalf("Test %q failed: %v", k, err)
+		}
+	}
+}
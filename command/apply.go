@@ -94,12 +94,15 @@ func (c *ApplyCommand) Run(args []string) int {
 		}
 	}
 
-	// Build the context based on the arguments given
+	// Build the context based on the arguments given. The state stays
+	// locked until the new state has been written.
+	defer c.unlockState()
 	ctx, planned, err := c.Context(contextOpts{
 		Destroy:     c.Destroy,
 		Path:        configPath,
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "terraform " + cmdName,
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -399,7 +402,7 @@ func outputsAsString(state *terraform.State) string {
 				"  %s%s = %s\n",
 				k,
 				strings.Repeat(" ", keyLen-len(k)),
-				v))
+				formatOutput(v)))
 		}
 	}
 
@@ -26,6 +26,11 @@ const DefaultBackupExtension = ".backup"
 // by default.
 const DefaultDataDirectory = ".terraform"
 
+// DefaultPluginManifestFile is the file in the data directory with the
+// SHA256 checksums of the provider binaries. If it exists, the binaries
+// are verified against it before they are started.
+const DefaultPluginManifestFile = "plugins.sha256"
+
 // DefaultParallelism is the limit Terraform places on total parallel
 // operations as it walks the dependency graph.
 const DefaultParallelism = 10
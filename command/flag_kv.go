@@ -7,6 +7,8 @@ import (
 
 	"github.com/hashicorp/hcl"
 	"github.com/mitchellh/go-homedir"
+	"github.com/mitchellh/mapstructure"
+	"github.com/xanzy/terraform-api/config"
 )
 
 // FlagKV is a flag.Value implementation for parsing user variables
@@ -78,8 +80,8 @@ func loadKVFile(rawPath string) (map[string]string, error) {
 			"Error parsing %s: %s", path, err)
 	}
 
-	var result map[string]string
-	if err := hcl.DecodeObject(&result, obj); err != nil {
+	var raw map[string]interface{}
+	if err := hcl.DecodeObject(&raw, obj); err != nil {
 		return nil, fmt.Errorf(
 			"Error decoding Terraform vars file: %s\n\n"+
 				"The vars file should be in the format of `key = \"value\"`.\n"+
@@ -87,9 +89,48 @@ func loadKVFile(rawPath string) (map[string]string, error) {
 			err)
 	}
 
+	// Lists are turned into string lists and maps are flattened into
+	// one variable per key, which is how the context expects them.
+	result := make(map[string]string)
+	for k, v := range raw {
+		if err := flattenKVValue(result, k, v); err != nil {
+			return nil, fmt.Errorf(
+				"Error decoding Terraform vars file %s: %s", path, err)
+		}
+	}
+
 	return result, nil
 }
 
+func flattenKVValue(result map[string]string, k string, v interface{}) error {
+	switch v := v.(type) {
+	case []map[string]interface{}:
+		for _, m := range v {
+			for mk, mv := range m {
+				var s string
+				if err := mapstructure.WeakDecode(mv, &s); err != nil {
+					return fmt.Errorf("%s.%s: map values must be strings", k, mk)
+				}
+				result[k+"."+mk] = s
+			}
+		}
+	case []interface{}:
+		var l []string
+		if err := mapstructure.WeakDecode(v, &l); err != nil {
+			return fmt.Errorf("%s: list elements must be strings", k)
+		}
+		result[k] = config.NewStringList(l).String()
+	default:
+		var s string
+		if err := mapstructure.WeakDecode(v, &s); err != nil {
+			return fmt.Errorf("%s: %s", k, err)
+		}
+		result[k] = s
+	}
+
+	return nil
+}
+
 // FlagStringSlice is a flag.Value implementation for parsing targets from the
 // command line, e.g. -target=aws_instance.foo -target=aws_vpc.bar
 
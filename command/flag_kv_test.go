@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"reflect"
 	"testing"
+
+	"github.com/xanzy/terraform-api/config"
 )
 
 func TestFlagKV_impl(t *testing.T) {
@@ -96,6 +98,26 @@ foo = "bar"
 			map[string]string{"map.key": "foo"},
 			false,
 		},
+
+		{
+			`list = ["foo", "bar"]`,
+			map[string]string{
+				"list": config.NewStringList([]string{"foo", "bar"}).String(),
+			},
+			false,
+		},
+
+		{
+			`map { key = "foo" }`,
+			map[string]string{"map.key": "foo"},
+			false,
+		},
+
+		{
+			`{"map": {"key": "foo"}}`,
+			map[string]string{"map.key": "foo"},
+			false,
+		},
 	}
 
 	path := testTempFile(t)
@@ -124,11 +124,17 @@ func formatPlanModuleExpand(
 		for _, attrK := range keys {
 			attrDiff := rdiff.Attributes[attrK]
 
+			u := attrDiff.Old
 			v := attrDiff.New
 			if attrDiff.NewComputed {
 				v = "<computed>"
 			}
 
+			if attrDiff.Sensitive {
+				u = terraform.SensitiveValue
+				v = terraform.SensitiveValue
+			}
+
 			newResource := ""
 			if attrDiff.RequiresNew && rdiff.Destroy {
 				newResource = opts.Color.Color(" [red](forces new resource)")
@@ -138,7 +144,7 @@ func formatPlanModuleExpand(
 				"    %s:%s %#v => %#v%s\n",
 				attrK,
 				strings.Repeat(" ", keyLen-len(attrK)),
-				attrDiff.Old,
+				u,
 				v,
 				newResource))
 		}
@@ -61,13 +61,23 @@ func FormatState(opts *FormatStateOpts) string {
 		// Output each output k/v pair
 		for _, k := range ks {
 			v := m.Outputs[k]
-			buf.WriteString(fmt.Sprintf("%s = %s\n", k, v))
+			buf.WriteString(fmt.Sprintf("%s = %s\n", k, formatOutput(v)))
 		}
 	}
 
 	return opts.Color.Color(strings.TrimSpace(buf.String()))
 }
 
+// formatOutput renders the value of an output for humans. The value of a
+// sensitive output is never shown.
+func formatOutput(o *terraform.OutputState) string {
+	if o.Sensitive {
+		return terraform.SensitiveValue
+	}
+
+	return o.String()
+}
+
 func formatStateModuleExpand(
 	buf *bytes.Buffer, m *terraform.ModuleState, opts *FormatStateOpts) {
 	var moduleName string
@@ -124,6 +134,9 @@ func formatStateModuleExpand(
 			// Output each attribute
 			for _, ak := range attrKeys {
 				av := is.Attributes[ak]
+				if is.IsSensitive(ak) {
+					av = terraform.SensitiveValue
+				}
 				buf.WriteString(fmt.Sprintf("  %s = %s\n", ak, av))
 			}
 		}
@@ -8,7 +8,8 @@ import (
 )
 
 // StateHook is a hook that continuously updates the state by calling
-// WriteState on a state.State.
+// WriteState and PersistState on a state.State, so the resources that
+// were already applied aren't lost if Terraform dies during an apply.
 type StateHook struct {
 	terraform.NilHook
 	sync.Mutex
@@ -22,8 +23,14 @@ func (h *StateHook) PostStateUpdate(
 	defer h.Unlock()
 
 	if h.State != nil {
-		// Write the new state
-		if err := h.State.WriteState(s); err != nil {
+		// Write the new state. The state is still being modified by
+		// the walk, so write a copy of it.
+		if err := h.State.WriteState(s.DeepCopy()); err != nil {
+			return terraform.HookActionHalt, err
+		}
+
+		// Persist it right away
+		if err := h.State.PersistState(); err != nil {
 			return terraform.HookActionHalt, err
 		}
 	}
@@ -27,3 +27,38 @@ func TestStateHook(t *testing.T) {
 		t.Fatalf("bad state: %#v", is.State())
 	}
 }
+
+func TestStateHook_persist(t *testing.T) {
+	is := &persistCountState{}
+	var hook terraform.Hook = &StateHook{State: is}
+
+	s := state.TestStateInitial()
+	if _, err := hook.PostStateUpdate(s); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if is.Persisted != 1 {
+		t.Fatalf("bad: %d", is.Persisted)
+	}
+
+	// The walk keeps modifying the state, which must not change the
+	// state that was written.
+	if is.State() == s {
+		t.Fatal("should write a copy of the state")
+	}
+	if !is.State().Equal(s) {
+		t.Fatalf("bad state: %#v", is.State())
+	}
+}
+
+// persistCountState is an in-memory state that counts how often it
+// was persisted.
+type persistCountState struct {
+	state.InmemState
+
+	Persisted int
+}
+
+func (s *persistCountState) PersistState() error {
+	s.Persisted++
+	return nil
+}
@@ -89,16 +89,22 @@ func (h *UiHook) PreApply(
 	for _, attrK := range keys {
 		attrDiff := d.Attributes[attrK]
 
+		u := attrDiff.Old
 		v := attrDiff.New
 		if attrDiff.NewComputed {
 			v = "<computed>"
 		}
 
+		if attrDiff.Sensitive {
+			u = terraform.SensitiveValue
+			v = terraform.SensitiveValue
+		}
+
 		attrBuf.WriteString(fmt.Sprintf(
 			"  %s:%s %#v => %#v\n",
 			attrK,
 			strings.Repeat(" ", keyLen-len(attrK)),
-			attrDiff.Old,
+			u,
 			v))
 	}
 
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -13,6 +14,8 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
 	"github.com/xanzy/terraform-api/config/module"
+	"github.com/xanzy/terraform-api/plugin"
+	"github.com/xanzy/terraform-api/plugin/discovery"
 	"github.com/xanzy/terraform-api/state"
 	"github.com/xanzy/terraform-api/terraform"
 )
@@ -23,11 +26,20 @@ type Meta struct {
 	ContextOpts *terraform.ContextOpts
 	Ui          cli.Ui
 
+	// ProviderPlugins are all the versions of the discovered provider
+	// binaries. If set, the versions of the providers are picked to meet
+	// the version constraints of the configuration.
+	ProviderPlugins *discovery.PluginMetaSet
+
 	// State read when calling `Context`. This is available after calling
 	// `Context`.
 	state       state.State
 	stateResult *StateResult
 
+	// True if Context locked the state. It is unlocked again by
+	// unlockState.
+	stateLocked bool
+
 	// This can be set by the command itself to provide extra hooks.
 	extraHooks []terraform.Hook
 
@@ -118,6 +130,14 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 			m.state = state
 			m.stateOutPath = statePath
 
+			// The state of the plan is used as is, so there is no need to
+			// read it again after locking.
+			if copts.LockReason != "" {
+				if err := m.lockState(copts.LockReason); err != nil {
+					return nil, false, err
+				}
+			}
+
 			if len(m.variables) > 0 {
 				return nil, false, fmt.Errorf(
 					"You can't set variables with the '-var' or '-var-file' flag\n" +
@@ -126,6 +146,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 						"variable values, create a new plan file.")
 			}
 
+			if err := m.resolveProviders(opts, plan.Module); err != nil {
+				return nil, false, err
+			}
+
 			return plan.Context(opts), true, nil
 		}
 	}
@@ -144,6 +168,18 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, err
 	}
 
+	if copts.LockReason != "" {
+		if err := m.lockState(copts.LockReason); err != nil {
+			return nil, false, err
+		}
+
+		// Read the state again, since it may have been changed by whoever
+		// held the lock before us.
+		if err := state.RefreshState(); err != nil {
+			return nil, false, fmt.Errorf("Error reading state: %s", err)
+		}
+	}
+
 	// Load the root module
 	mod, err := module.NewTreeModule("", copts.Path)
 	if err != nil {
@@ -155,6 +191,10 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 		return nil, false, fmt.Errorf("Error downloading modules: %s", err)
 	}
 
+	if err := m.resolveProviders(opts, mod); err != nil {
+		return nil, false, err
+	}
+
 	opts.Module = mod
 	opts.Parallelism = copts.Parallelism
 	opts.State = state.State()
@@ -162,6 +202,52 @@ func (m *Meta) Context(copts contextOpts) (*terraform.Context, bool, error) {
 	return ctx, false, nil
 }
 
+// resolveProviders picks the versions of the discovered providers that
+// meet the version constraints of the configuration, and sets them in
+// the context options.
+func (m *Meta) resolveProviders(opts *terraform.ContextOpts, mod *module.Tree) error {
+	if m.ProviderPlugins == nil || len(*m.ProviderPlugins) == 0 || mod == nil {
+		return nil
+	}
+
+	resolved, err := discovery.ResolveProviders(
+		*m.ProviderPlugins, discovery.TreeRequirements(mod))
+	if err != nil {
+		return fmt.Errorf("Error resolving provider versions: %s", err)
+	}
+
+	// If there is a checksum manifest, the binaries are verified
+	// against it before they are started.
+	manifestPath := filepath.Join(m.DataDir(), DefaultPluginManifestFile)
+	manifest, err := discovery.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		log.Printf("[DEBUG] No plugin checksum manifest at %s", manifestPath)
+	}
+
+	providers := make(map[string]terraform.ResourceProviderFactory)
+	for k, v := range opts.Providers {
+		providers[k] = v
+	}
+	for k, meta := range resolved {
+		var secure *plugin.SecureConfig
+		if manifest != nil {
+			secure, err = manifest.SecureConfig(meta)
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Printf("[INFO] Using provider %s v%s: %s", k, meta.Version, meta.Path)
+		providers[k] = meta.ProviderFactory(secure)
+	}
+
+	opts.Providers = providers
+	return nil
+}
+
 // DataDir returns the directory where local data will be stored.
 func (m *Meta) DataDir() string {
 	dataDir := DefaultDataDirectory
@@ -258,6 +344,40 @@ func (m *Meta) UIInput() terraform.UIInput {
 	}
 }
 
+// lockState locks the state, if it supports locking, so no one else can
+// modify it until unlockState is called.
+func (m *Meta) lockState(reason string) error {
+	l, ok := m.state.(state.Locker)
+	if !ok {
+		return nil
+	}
+
+	if err := l.Lock(reason); err != nil {
+		return fmt.Errorf("Error locking state: %s", err)
+	}
+
+	m.stateLocked = true
+	return nil
+}
+
+// unlockState unlocks the state if it was locked by Context. Errors are
+// reported to the UI, since the lock may have to be removed manually.
+func (m *Meta) unlockState() {
+	if !m.stateLocked {
+		return
+	}
+
+	if err := m.state.(state.Locker).Unlock(); err != nil {
+		m.Ui.Error(fmt.Sprintf(
+			"Error unlocking state: %s\n\n"+
+				"The state may still be locked and have to be unlocked manually.",
+			err))
+		return
+	}
+
+	m.stateLocked = false
+}
+
 // PersistState is used to write out the state, handling backup of
 // the existing state file and respecting path configurations.
 func (m *Meta) PersistState(s *terraform.State) error {
@@ -444,4 +564,9 @@ type contextOpts struct {
 
 	// Number of concurrent operations allowed
 	Parallelism int
+
+	// LockReason, if set, makes Context lock the state before reading it.
+	// The reason is stored with the lock. Commands that set it must call
+	// unlockState once they are done with the state.
+	LockReason string
 }
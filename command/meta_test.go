@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/xanzy/terraform-api/state/remote"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
@@ -268,3 +269,44 @@ func TestMeta_addModuleDepthFlag(t *testing.T) {
 		}
 	}
 }
+
+func TestMetaContext_lockState(t *testing.T) {
+	client := new(remote.InmemClient)
+	m := &Meta{
+		ContextOpts: testCtxConfig(testProvider()),
+		state:       &remote.State{Client: client},
+	}
+
+	_, _, err := m.Context(contextOpts{
+		Path:       testFixturePath("apply"),
+		LockReason: "test",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockReason != "test" {
+		t.Fatalf("state should be locked: %q", client.LockReason)
+	}
+
+	// Nobody else can lock the state in the meantime
+	other := &Meta{
+		ContextOpts: testCtxConfig(testProvider()),
+		state:       &remote.State{Client: client},
+	}
+	_, _, err = other.Context(contextOpts{
+		Path:       testFixturePath("apply"),
+		LockReason: "other",
+	})
+	if err == nil {
+		t.Fatal("should fail to lock a locked state")
+	}
+	other.unlockState()
+	if client.LockReason != "test" {
+		t.Fatalf("lock should be kept: %q", client.LockReason)
+	}
+
+	m.unlockState()
+	if client.LockReason != "" {
+		t.Fatalf("state should be unlocked: %q", client.LockReason)
+	}
+}
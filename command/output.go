@@ -82,7 +82,7 @@ func (c *OutputCommand) Run(args []string) int {
 
 		for _, k := range ks {
 			v := mod.Outputs[k]
-			c.Ui.Output(fmt.Sprintf("%s = %s", k, v))
+			c.Ui.Output(fmt.Sprintf("%s = %s", k, formatOutput(v)))
 		}
 		return 0
 	}
@@ -97,7 +97,26 @@ func (c *OutputCommand) Run(args []string) int {
 		return 1
 	}
 
-	c.Ui.Output(v)
+	// A single output is printed as is, even if it is sensitive, since
+	// it was asked for explicitly.
+	switch value := v.Value.(type) {
+	case []interface{}:
+		for _, e := range value {
+			c.Ui.Output(fmt.Sprintf("%v", e))
+		}
+	case map[string]interface{}:
+		ks := make([]string, 0, len(value))
+		for k, _ := range value {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		for _, k := range ks {
+			c.Ui.Output(fmt.Sprintf("%s = %v", k, value[k]))
+		}
+	default:
+		c.Ui.Output(fmt.Sprintf("%v", value))
+	}
 	return 0
 }
 
@@ -106,7 +125,8 @@ func (c *OutputCommand) Help() string {
 Usage: terraform output [options] [NAME]
 
   Reads an output variable from a Terraform state file and prints
-  the value.  If NAME is not specified, all outputs are printed.
+  the value.  If NAME is not specified, all outputs are printed, with
+  the values of sensitive outputs hidden.
 
 Options:
 
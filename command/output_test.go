@@ -16,8 +16,11 @@ func TestOutput(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo": "bar",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
 				},
 			},
 		},
@@ -52,14 +55,20 @@ func TestModuleOutput(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo": "bar",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
 				},
 			},
 			&terraform.ModuleState{
 				Path: []string{"root", "my_module"},
-				Outputs: map[string]string{
-					"blah": "tastatur",
+				Outputs: map[string]*terraform.OutputState{
+					"blah": &terraform.OutputState{
+						Type:  "string",
+						Value: "tastatur",
+					},
 				},
 			},
 		},
@@ -96,8 +105,11 @@ func TestMissingModuleOutput(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo": "bar",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
 				},
 			},
 		},
@@ -129,8 +141,11 @@ func TestOutput_badVar(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo": "bar",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
 				},
 			},
 		},
@@ -160,9 +175,15 @@ func TestOutput_blank(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo":  "bar",
-					"name": "john-doe",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
+					"name": &terraform.OutputState{
+						Type:  "string",
+						Value: "john-doe",
+					},
 				},
 			},
 		},
@@ -194,6 +215,111 @@ func TestOutput_blank(t *testing.T) {
 	}
 }
 
+func TestOutput_sensitive(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
+					"password": &terraform.OutputState{
+						Sensitive: true,
+						Type:      "string",
+						Value:     "secret",
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	expectedOutput := "foo = bar\npassword = <sensitive>\n"
+	output := ui.OutputWriter.String()
+	if output != expectedOutput {
+		t.Fatalf("Expected output: %#v\ngiven: %#v", expectedOutput, output)
+	}
+
+	// Asking for the output by name shows the value
+	ui = new(cli.MockUi)
+	c = &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+	args = []string{
+		"-state", statePath,
+		"password",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	actual := strings.TrimSpace(ui.OutputWriter.String())
+	if actual != "secret" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestOutput_list(t *testing.T) {
+	originalState := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			&terraform.ModuleState{
+				Path: []string{"root"},
+				Outputs: map[string]*terraform.OutputState{
+					"zones": &terraform.OutputState{
+						Type:  "list",
+						Value: []interface{}{"a", "b"},
+					},
+				},
+			},
+		},
+	}
+
+	statePath := testStateFile(t, originalState)
+
+	ui := new(cli.MockUi)
+	c := &OutputCommand{
+		Meta: Meta{
+			ContextOpts: testCtxConfig(testProvider()),
+			Ui:          ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"zones",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	expectedOutput := "a\nb\n"
+	output := ui.OutputWriter.String()
+	if output != expectedOutput {
+		t.Fatalf("Expected output: %#v\ngiven: %#v", expectedOutput, output)
+	}
+}
+
 func TestOutput_manyArgs(t *testing.T) {
 	ui := new(cli.MockUi)
 	c := &OutputCommand{
@@ -253,7 +379,7 @@ func TestOutput_noVars(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path:    []string{"root"},
-				Outputs: map[string]string{},
+				Outputs: map[string]*terraform.OutputState{},
 			},
 		},
 	}
@@ -282,8 +408,11 @@ func TestOutput_stateDefault(t *testing.T) {
 		Modules: []*terraform.ModuleState{
 			&terraform.ModuleState{
 				Path: []string{"root"},
-				Outputs: map[string]string{
-					"foo": "bar",
+				Outputs: map[string]*terraform.OutputState{
+					"foo": &terraform.OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
 				},
 			},
 		},
@@ -58,11 +58,14 @@ func (c *PlanCommand) Run(args []string) int {
 	countHook := new(CountHook)
 	c.Meta.extraHooks = []terraform.Hook{countHook}
 
+	// The refresh before the plan writes the state, so it is locked
+	defer c.unlockState()
 	ctx, _, err := c.Context(contextOpts{
 		Destroy:     destroy,
 		Path:        path,
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "terraform plan",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
@@ -78,10 +78,12 @@ func (c *RefreshCommand) Run(args []string) int {
 	}
 
 	// Build the context based on the arguments given
+	defer c.unlockState()
 	ctx, _, err := c.Context(contextOpts{
 		Path:        configPath,
 		StatePath:   c.Meta.statePath,
 		Parallelism: c.Meta.parallelism,
+		LockReason:  "terraform refresh",
 	})
 	if err != nil {
 		c.Ui.Error(err.Error())
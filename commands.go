@@ -29,9 +29,10 @@ func init() {
 	}
 
 	meta := command.Meta{
-		Color:       true,
-		ContextOpts: &ContextOpts,
-		Ui:          Ui,
+		Color:           true,
+		ContextOpts:     &ContextOpts,
+		ProviderPlugins: &ProviderPlugins,
+		Ui:              Ui,
 	}
 
 	Commands = map[string]cli.CommandFactory{
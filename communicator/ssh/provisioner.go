@@ -98,11 +98,17 @@ func parseConnectionInfo(s *terraform.InstanceState) (*connectionInfo, error) {
 
 	// Load deprecated fields; we can handle either path or contents in
 	// underlying implementation.
-	if connInfo.PrivateKey == "" && connInfo.KeyFile != "" {
-		connInfo.PrivateKey = connInfo.KeyFile
+	if connInfo.KeyFile != "" {
+		log.Printf("[WARN] connection: \"key_file\": [DEPRECATED] please use 'private_key' instead")
+		if connInfo.PrivateKey == "" {
+			connInfo.PrivateKey = connInfo.KeyFile
+		}
 	}
-	if connInfo.BastionPrivateKey == "" && connInfo.BastionKeyFile != "" {
-		connInfo.BastionPrivateKey = connInfo.BastionKeyFile
+	if connInfo.BastionKeyFile != "" {
+		log.Printf("[WARN] connection: \"bastion_key_file\": [DEPRECATED] please use 'bastion_private_key' instead")
+		if connInfo.BastionPrivateKey == "" {
+			connInfo.BastionPrivateKey = connInfo.BastionKeyFile
+		}
 	}
 
 	// Default all bastion config attrs to their non-bastion counterparts
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/mitchellh/osext"
 	"github.com/xanzy/terraform-api/plugin"
+	"github.com/xanzy/terraform-api/plugin/discovery"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
@@ -25,6 +26,9 @@ type Config struct {
 
 	DisableCheckpoint          bool `hcl:"disable_checkpoint"`
 	DisableCheckpointSignature bool `hcl:"disable_checkpoint_signature"`
+
+	// All the versions of the discovered provider binaries
+	providerPlugins discovery.PluginMetaSet
 }
 
 // BuiltinConfig is the built-in defaults for the configuration. These
@@ -34,6 +38,11 @@ var BuiltinConfig Config
 // ContextOpts are the global ContextOpts we use to initialize the CLI.
 var ContextOpts terraform.ContextOpts
 
+// ProviderPlugins are all the versions of the discovered provider
+// binaries, which the commands use to resolve the provider version
+// constraints of the configuration.
+var ProviderPlugins discovery.PluginMetaSet
+
 // ConfigFile returns the default path to the configuration file.
 //
 // On Unix-like systems this is the ".terraformrc" file in the home directory.
@@ -75,13 +84,13 @@ func LoadConfig(path string) (*Config, error) {
 
 // Discover discovers plugins.
 //
-// This looks in the directory of the executable and the CWD, in that
-// order for priority.
+// This looks in the CWD, the plugins directory in the config directory
+// and the directory of the executable, in that order for priority.
+// Plugin binaries may have a version in their name, such as
+// terraform-provider-aws_v1.2.3. The newest version of every plugin is
+// used, unless the configuration constrains the versions of a provider.
 func (c *Config) Discover() error {
-	// Look in the cwd.
-	if err := c.discover("."); err != nil {
-		return err
-	}
+	dirs := []string{"."}
 
 	// Look in the plugins directory. This will override any found
 	// in the current directory.
@@ -89,9 +98,7 @@ func (c *Config) Discover() error {
 	if err != nil {
 		log.Printf("[ERR] Error loading config directory: %s", err)
 	} else {
-		if err := c.discover(filepath.Join(dir, "plugins")); err != nil {
-			return err
-		}
+		dirs = append(dirs, filepath.Join(dir, "plugins"))
 	}
 
 	// Next, look in the same directory as the executable. Any conflicts
@@ -100,14 +107,44 @@ func (c *Config) Discover() error {
 	if err != nil {
 		log.Printf("[ERR] Error loading exe directory: %s", err)
 	} else {
-		if err := c.discover(filepath.Dir(exePath)); err != nil {
-			return err
-		}
+		dirs = append(dirs, filepath.Dir(exePath))
+	}
+
+	providers, err := discovery.FindPlugins("provider", dirs)
+	if err != nil {
+		return err
+	}
+
+	provisioners, err := discovery.FindPlugins("provisioner", dirs)
+	if err != nil {
+		return err
+	}
+
+	if c.Providers == nil {
+		c.Providers = make(map[string]string)
+	}
+	for k, m := range providers.Newest() {
+		c.Providers[k] = m.Path
+	}
+
+	if c.Provisioners == nil {
+		c.Provisioners = make(map[string]string)
+	}
+	for k, m := range provisioners.Newest() {
+		c.Provisioners[k] = m.Path
 	}
 
+	c.providerPlugins = append(c.providerPlugins, providers...)
 	return nil
 }
 
+// ProviderPlugins returns all the versions of the discovered provider
+// binaries, which are used to resolve the version constraints of the
+// configuration.
+func (c *Config) ProviderPlugins() discovery.PluginMetaSet {
+	return c.providerPlugins
+}
+
 // Merge merges two configurations and returns a third entirely
 // new configuration with the two merged.
 func (c1 *Config) Merge(c2 *Config) *Config {
@@ -127,70 +164,26 @@ func (c1 *Config) Merge(c2 *Config) *Config {
 		result.Provisioners[k] = v
 	}
 
-	return &result
-}
-
-func (c *Config) discover(path string) error {
-	var err error
-
-	if !filepath.IsAbs(path) {
-		path, err = filepath.Abs(path)
-		if err != nil {
-			return err
+	// Providers that are set explicitly win over the discovered ones
+	for _, m := range c1.providerPlugins {
+		if _, ok := c2.Providers[m.Name]; !ok {
+			result.providerPlugins = append(result.providerPlugins, m)
 		}
 	}
-
-	err = c.discoverSingle(
-		filepath.Join(path, "terraform-provider-*"), &c.Providers)
-	if err != nil {
-		return err
-	}
-
-	err = c.discoverSingle(
-		filepath.Join(path, "terraform-provisioner-*"), &c.Provisioners)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (c *Config) discoverSingle(glob string, m *map[string]string) error {
-	matches, err := filepath.Glob(glob)
-	if err != nil {
-		return err
-	}
-
-	if *m == nil {
-		*m = make(map[string]string)
+	for _, m := range c2.providerPlugins {
+		result.providerPlugins = append(result.providerPlugins, m)
 	}
 
-	for _, match := range matches {
-		file := filepath.Base(match)
-
-		// If the filename has a ".", trim up to there
-		if idx := strings.Index(file, "."); idx >= 0 {
-			file = file[:idx]
-		}
-
-		// Look for foo-bar-baz. The plugin name is "baz"
-		parts := strings.SplitN(file, "-", 3)
-		if len(parts) != 3 {
-			continue
-		}
-
-		log.Printf("[DEBUG] Discovered plugin: %s = %s", parts[2], match)
-		(*m)[parts[2]] = match
-	}
-
-	return nil
+	return &result
 }
 
 // ProviderFactories returns the mapping of prefixes to
 // ResourceProviderFactory that can be used to instantiate a
-// binary-based plugin.
+// binary-based plugin. Providers registered in-process with
+// plugin.RegisterProvider are included, unless a binary-based plugin
+// with the same name is configured.
 func (c *Config) ProviderFactories() map[string]terraform.ResourceProviderFactory {
-	result := make(map[string]terraform.ResourceProviderFactory)
+	result := plugin.ProviderFactories()
 	for k, v := range c.Providers {
 		result[k] = c.providerFactory(v)
 	}
@@ -219,9 +212,11 @@ func (c *Config) providerFactory(path string) terraform.ResourceProviderFactory
 
 // ProvisionerFactories returns the mapping of prefixes to
 // ResourceProvisionerFactory that can be used to instantiate a
-// binary-based plugin.
+// binary-based plugin. Provisioners registered in-process with
+// plugin.RegisterProvisioner are included, unless a binary-based plugin
+// with the same name is configured.
 func (c *Config) ProvisionerFactories() map[string]terraform.ResourceProvisionerFactory {
-	result := make(map[string]terraform.ResourceProvisionerFactory)
+	result := plugin.ProvisionerFactories()
 	for k, v := range c.Provisioners {
 		result[k] = c.provisionerFactory(v)
 	}
@@ -4,7 +4,9 @@ package config
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -20,6 +22,11 @@ import (
 // resources, etc.) must follow.
 var NameRegexp = regexp.MustCompile(`\A[A-Za-z0-9\-\_]+\z`)
 
+// providerNameRegexp matches the full name of a provider, which is its
+// type optionally followed by a dot and an alias: "aws" or "aws.east".
+var providerNameRegexp = regexp.MustCompile(
+	`\A[A-Za-z0-9\-\_]+(\.[A-Za-z0-9\-\_]+)?\z`)
+
 // Config is the configuration that comes from loading a collection
 // of Terraform templates.
 type Config struct {
@@ -54,7 +61,14 @@ type AtlasConfig struct {
 type Module struct {
 	Name      string
 	Source    string
+	DependsOn []string
 	RawConfig *RawConfig
+
+	// Providers maps the names of providers in the module, such as "aws"
+	// or "aws.east", to the names of the providers in this configuration
+	// they get their configuration from. Providers that aren't listed get
+	// the configuration of the provider with the same name.
+	Providers map[string]string
 }
 
 // ProviderConfig is the configuration for a resource provider.
@@ -64,6 +78,7 @@ type Module struct {
 type ProviderConfig struct {
 	Name      string
 	Alias     string
+	Version   string
 	RawConfig *RawConfig
 }
 
@@ -94,19 +109,45 @@ type Provisioner struct {
 	Type      string
 	RawConfig *RawConfig
 	ConnInfo  *RawConfig
+
+	When ProvisionerWhen
+}
+
+// ProvisionerWhen is an enum for valid values for when to run provisioners.
+type ProvisionerWhen int
+
+const (
+	// ProvisionerWhenCreate runs the provisioner after the resource is
+	// created. This is the default.
+	ProvisionerWhenCreate ProvisionerWhen = iota
+
+	// ProvisionerWhenDestroy runs the provisioner before the resource is
+	// destroyed.
+	ProvisionerWhenDestroy
+)
+
+var provisionerWhenStrs = map[ProvisionerWhen]string{
+	ProvisionerWhenCreate:  "create",
+	ProvisionerWhenDestroy: "destroy",
+}
+
+func (v ProvisionerWhen) String() string {
+	return provisionerWhenStrs[v]
 }
 
 // Variable is a variable defined within the configuration.
 type Variable struct {
-	Name        string
-	Default     interface{}
-	Description string
+	Name         string
+	DeclaredType string
+	Default      interface{}
+	Description  string
 }
 
 // Output is an output defined within the configuration. An output is
 // resulting data that is highlighted by Terraform when finished.
 type Output struct {
 	Name      string
+	Sensitive bool
 	RawConfig *RawConfig
 }
 
@@ -118,8 +159,31 @@ const (
 	VariableTypeUnknown VariableType = iota
 	VariableTypeString
 	VariableTypeMap
+	VariableTypeList
 )
 
+// typeStringMap maps the type names that can be used in the type field
+// of a variable declaration to their VariableType.
+var typeStringMap = map[string]VariableType{
+	"string": VariableTypeString,
+	"list":   VariableTypeList,
+	"map":    VariableTypeMap,
+}
+
+// Printable returns a human-readable name for the variable type.
+func (t VariableType) Printable() string {
+	switch t {
+	case VariableTypeString:
+		return "string"
+	case VariableTypeList:
+		return "list"
+	case VariableTypeMap:
+		return "map"
+	default:
+		return "unknown"
+	}
+}
+
 // ProviderConfigName returns the name of the provider configuration in
 // the given mapping that maps to the proper provider configuration
 // for this resource.
@@ -175,13 +239,32 @@ func (c *Config) Validate() error {
 	}
 
 	for _, v := range c.Variables {
+		if v.DeclaredType != "" {
+			if _, ok := typeStringMap[v.DeclaredType]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': '%s' is not a valid type, "+
+						"must be one of string, list or map",
+					v.Name, v.DeclaredType))
+				continue
+			}
+		}
+
 		if v.Type() == VariableTypeUnknown {
 			errs = append(errs, fmt.Errorf(
-				"Variable '%s': must be string or mapping",
+				"Variable '%s': must be a string, list or map",
 				v.Name))
 			continue
 		}
 
+		if v.Default != nil {
+			if t := v.inferTypeFromDefault(); t != v.Type() {
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': type is %s, but the default is a %s",
+					v.Name, v.Type().Printable(), t.Printable()))
+				continue
+			}
+		}
+
 		interp := false
 		fn := func(ast.Node) (string, error) {
 			interp = true
@@ -296,6 +379,9 @@ func (c *Config) Validate() error {
 				m.Id()))
 		}
 
+		// Check that the providers passed to the module exist
+		errs = append(errs, validateModuleProviders(m, providerSet)...)
+
 		// Check that the configuration can all be strings
 		raw := make(map[string]interface{})
 		for k, v := range m.RawConfig.Raw {
@@ -366,6 +452,19 @@ func (c *Config) Validate() error {
 	}
 	dupped = nil
 
+	// Verify the depends on of modules points to things that all exist
+	for n, m := range modules {
+		for _, d := range m.DependsOn {
+			if d == "module."+n {
+				errs = append(errs, fmt.Errorf(
+					"module %s: module can't depend on itself", n))
+			}
+		}
+
+		errs = append(errs, validateDependsOn(
+			"module "+n, m.DependsOn, resources, modules, providerSet)...)
+	}
+
 	// Validate resources
 	for n, r := range resources {
 		// Verify count variables
@@ -415,25 +514,9 @@ func (c *Config) Validate() error {
 		}
 		r.RawCount.init()
 
-		// Verify depends on points to resources that all exist
-		for _, d := range r.DependsOn {
-			// Check if we contain interpolations
-			rc, err := NewRawConfig(map[string]interface{}{
-				"value": d,
-			})
-			if err == nil && len(rc.Variables) > 0 {
-				errs = append(errs, fmt.Errorf(
-					"%s: depends on value cannot contain interpolations: %s",
-					n, d))
-				continue
-			}
-
-			if _, ok := resources[d]; !ok {
-				errs = append(errs, fmt.Errorf(
-					"%s: resource depends on non-existent resource '%s'",
-					n, d))
-			}
-		}
+		// Verify depends on points to things that all exist
+		errs = append(errs, validateDependsOn(
+			n, r.DependsOn, resources, modules, providerSet)...)
 
 		// Verify provider points to a provider that is configured
 		if r.Provider != "" {
@@ -561,6 +644,53 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validateDependsOn verifies that each entry of a depends_on list names an
+// existing resource, module ("module.NAME") or provider configuration
+// ("provider.NAME" or "provider.NAME.ALIAS").
+func validateDependsOn(
+	n string,
+	deps []string,
+	resources map[string]*Resource,
+	modules map[string]*Module,
+	providers map[string]struct{}) []error {
+	var errs []error
+	for _, d := range deps {
+		// Check if we contain interpolations
+		rc, err := NewRawConfig(map[string]interface{}{
+			"value": d,
+		})
+		if err == nil && len(rc.Variables) > 0 {
+			errs = append(errs, fmt.Errorf(
+				"%s: depends on value cannot contain interpolations: %s",
+				n, d))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(d, "module."):
+			if _, ok := modules[d[len("module."):]]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: depends on non-existent module '%s'",
+					n, d))
+			}
+		case strings.HasPrefix(d, "provider."):
+			if _, ok := providers[d[len("provider."):]]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: depends on non-configured provider '%s'",
+					n, d))
+			}
+		default:
+			if _, ok := resources[d]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s: resource depends on non-existent resource '%s'",
+					n, d))
+			}
+		}
+	}
+
+	return errs
+}
+
 // InterpolatedVariables is a helper that returns a mapping of all the interpolated
 // variables within the configuration. This is used to verify references
 // are valid in the Validate step.
@@ -598,6 +728,12 @@ func (c *Config) rawConfigs() map[string]*RawConfig {
 				"%s provisioner %s (#%d)",
 				source, p.Type, i+1)
 			result[subsource] = p.RawConfig
+
+			// The connection info is interpolated per instance just like
+			// the provisioner, so it can use count.index and self too.
+			if p.ConnInfo != nil {
+				result[subsource+" connection"] = p.ConnInfo
+			}
 		}
 	}
 
@@ -659,6 +795,49 @@ func (c *Config) validateVarContextFn(
 	}
 }
 
+// validateModuleProviders checks that the providers passed to a module
+// are configured, and are of the same type as the providers they are
+// passed as.
+func validateModuleProviders(
+	m *Module, providerSet map[string]struct{}) []error {
+	var errs []error
+
+	// Sort the keys so the errors are stable
+	keys := make([]string, 0, len(m.Providers))
+	for k := range m.Providers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := m.Providers[k]
+		if !providerNameRegexp.MatchString(k) || !providerNameRegexp.MatchString(v) {
+			errs = append(errs, fmt.Errorf(
+				"%s: providers: %s = %s: provider names must be a type, "+
+					"optionally followed by a dot and an alias",
+				m.Id(), k, v))
+			continue
+		}
+
+		if strings.SplitN(k, ".", 2)[0] != strings.SplitN(v, ".", 2)[0] {
+			errs = append(errs, fmt.Errorf(
+				"%s: providers: %s = %s: providers must be of the same type",
+				m.Id(), k, v))
+			continue
+		}
+
+		// Providers without an alias don't have to be configured, but
+		// aliases only exist if they are
+		if _, ok := providerSet[v]; !ok && strings.Contains(v, ".") {
+			errs = append(errs, fmt.Errorf(
+				"%s: providers: %s = %s: provider %s is not configured",
+				m.Id(), k, v, v))
+		}
+	}
+
+	return errs
+}
+
 func (m *Module) mergerName() string {
 	return m.Id()
 }
@@ -674,6 +853,16 @@ func (m *Module) mergerMerge(other merger) merger {
 		result.Source = m2.Source
 	}
 
+	if len(m2.Providers) > 0 {
+		result.Providers = make(map[string]string)
+		for k, v := range m.Providers {
+			result.Providers[k] = v
+		}
+		for k, v := range m2.Providers {
+			result.Providers[k] = v
+		}
+	}
+
 	return &result
 }
 
@@ -687,6 +876,9 @@ func (o *Output) mergerMerge(m merger) merger {
 	result := *o
 	result.Name = o2.Name
 	result.RawConfig = result.RawConfig.merge(o2.RawConfig)
+	if o2.Sensitive {
+		result.Sensitive = true
+	}
 
 	return &result
 }
@@ -713,6 +905,9 @@ func (c *ProviderConfig) mergerMerge(m merger) merger {
 	result := *c
 	result.Name = c2.Name
 	result.RawConfig = result.RawConfig.merge(c2.RawConfig)
+	if c2.Version != "" {
+		result.Version = c2.Version
+	}
 
 	return &result
 }
@@ -750,6 +945,8 @@ func (v *Variable) DefaultsMap() map[string]string {
 	switch v.Type() {
 	case VariableTypeString:
 		return map[string]string{n: v.Default.(string)}
+	case VariableTypeList:
+		return map[string]string{n: NewStringList(v.Default.([]string)).String()}
 	case VariableTypeMap:
 		result := flatmap.Flatten(map[string]interface{}{
 			n: v.Default.(map[string]string),
@@ -770,8 +967,25 @@ func (v *Variable) Merge(v2 *Variable) *Variable {
 	// The names should be the same, but the second name always wins.
 	result.Name = v2.Name
 
+	if v2.DeclaredType != "" {
+		result.DeclaredType = v2.DeclaredType
+	}
 	if v2.Default != nil {
 		result.Default = v2.Default
+
+		// Map defaults are merged, so an override only needs to set
+		// the keys it wants to change.
+		if v.inferTypeFromDefault() == VariableTypeMap &&
+			v2.inferTypeFromDefault() == VariableTypeMap {
+			m := make(map[string]string)
+			for k, val := range v.Default.(map[string]string) {
+				m[k] = val
+			}
+			for k, val := range v2.Default.(map[string]string) {
+				m[k] = val
+			}
+			result.Default = m
+		}
 	}
 	if v2.Description != "" {
 		result.Description = v2.Description
@@ -780,8 +994,27 @@ func (v *Variable) Merge(v2 *Variable) *Variable {
 	return &result
 }
 
-// Type returns the type of varialbe this is.
+// Type returns the type of variable this is. The declared type wins if
+// there is one, otherwise the type is inferred from the default value.
 func (v *Variable) Type() VariableType {
+	if v.DeclaredType != "" {
+		t, ok := typeStringMap[v.DeclaredType]
+		if !ok {
+			return VariableTypeUnknown
+		}
+
+		// Still normalize the default
+		v.inferTypeFromDefault()
+		return t
+	}
+
+	return v.inferTypeFromDefault()
+}
+
+// inferTypeFromDefault determines the type of the variable from its
+// default value, and normalizes the default to a string, []string or
+// map[string]string accordingly.
+func (v *Variable) inferTypeFromDefault() VariableType {
 	if v.Default == nil {
 		return VariableTypeString
 	}
@@ -792,6 +1025,16 @@ func (v *Variable) Type() VariableType {
 		return VariableTypeString
 	}
 
+	if reflect.TypeOf(v.Default).Kind() == reflect.Slice {
+		var l []string
+		if err := mapstructure.WeakDecode(v.Default, &l); err == nil {
+			v.Default = l
+			return VariableTypeList
+		}
+
+		return VariableTypeUnknown
+	}
+
 	var m map[string]string
 	if err := mapstructure.WeakDecode(v.Default, &m); err == nil {
 		v.Default = m
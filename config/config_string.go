@@ -77,6 +77,26 @@ func modulesStr(ms []*Module) string {
 
 		result += fmt.Sprintf("  source = %s\n", m.Source)
 
+		if len(m.DependsOn) > 0 {
+			result += fmt.Sprintf("  dependsOn\n")
+			for _, d := range m.DependsOn {
+				result += fmt.Sprintf("    %s\n", d)
+			}
+		}
+
+		if len(m.Providers) > 0 {
+			pks := make([]string, 0, len(m.Providers))
+			for k, _ := range m.Providers {
+				pks = append(pks, k)
+			}
+			sort.Strings(pks)
+
+			result += fmt.Sprintf("  providers\n")
+			for _, k := range pks {
+				result += fmt.Sprintf("    %s = %s\n", k, m.Providers[k])
+			}
+		}
+
 		for _, k := range ks {
 			result += fmt.Sprintf("  %s\n", k)
 		}
@@ -209,6 +229,9 @@ func resourcesStr(rs []*Resource) string {
 			result += fmt.Sprintf("  provisioners\n")
 			for _, p := range r.Provisioners {
 				result += fmt.Sprintf("    %s\n", p.Type)
+				if p.When != ProvisionerWhenCreate {
+					result += fmt.Sprintf("      when = %s\n", p.When)
+				}
 
 				ks := make([]string, 0, len(p.RawConfig.Raw))
 				for k, _ := range p.RawConfig.Raw {
@@ -54,6 +54,27 @@ func TestConfigValidate_badDependsOn(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_badDependsOnModule(t *testing.T) {
+	c := testConfig(t, "validate-bad-depends-on-module")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_dependsOnModule(t *testing.T) {
+	c := testConfig(t, "validate-depends-on-module")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_moduleDependsOnSelf(t *testing.T) {
+	c := testConfig(t, "validate-module-depends-on-self")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_countInt(t *testing.T) {
 	c := testConfig(t, "validate-count-int")
 	if err := c.Validate(); err != nil {
@@ -126,6 +147,24 @@ func TestConfigValidate_countVarInvalid(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_countConnInfo(t *testing.T) {
+	c := testConfig(t, "validate-count-conninfo")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_countConnInfoInvalid(t *testing.T) {
+	c := testConfig(t, "validate-count-conninfo-invalid")
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("should not be valid")
+	}
+	if !strings.Contains(err.Error(), "connection: invalid count variable") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
 func TestConfigValidate_dependsOnVar(t *testing.T) {
 	c := testConfig(t, "validate-depends-on-var")
 	if err := c.Validate(); err == nil {
@@ -175,6 +214,27 @@ func TestConfigValidate_moduleVarMap(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_moduleProviders(t *testing.T) {
+	c := testConfig(t, "validate-module-providers")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestConfigValidate_moduleProvidersBadType(t *testing.T) {
+	c := testConfig(t, "validate-module-providers-bad-type")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
+func TestConfigValidate_moduleProvidersUnconfigured(t *testing.T) {
+	c := testConfig(t, "validate-module-providers-unconfigured")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_moduleVarSelf(t *testing.T) {
 	c := testConfig(t, "validate-module-var-self")
 	if err := c.Validate(); err == nil {
@@ -336,6 +396,20 @@ func TestConfigValidate_varDefaultBadType(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_varDefaultList(t *testing.T) {
+	c := testConfig(t, "validate-var-default-list")
+	if err := c.Validate(); err != nil {
+		t.Fatalf("should be valid: %s", err)
+	}
+}
+
+func TestConfigValidate_varBadDeclaredType(t *testing.T) {
+	c := testConfig(t, "validate-var-bad-declared-type")
+	if err := c.Validate(); err == nil {
+		t.Fatal("should not be valid")
+	}
+}
+
 func TestConfigValidate_varDefaultInterpolate(t *testing.T) {
 	c := testConfig(t, "validate-var-default-interpolate")
 	if err := c.Validate(); err == nil {
@@ -445,6 +519,13 @@ func TestVariableDefaultsMap(t *testing.T) {
 				"var.foo.bar": "baz",
 			},
 		},
+
+		{
+			[]interface{}{"foo", "bar"},
+			map[string]string{
+				"var.foo": NewStringList([]string{"foo", "bar"}).String(),
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -456,6 +537,23 @@ func TestVariableDefaultsMap(t *testing.T) {
 	}
 }
 
+func TestVariableMerge_map(t *testing.T) {
+	v1 := &Variable{
+		Name:    "foo",
+		Default: map[string]interface{}{"foo": "bar", "bar": "baz"},
+	}
+	v2 := &Variable{
+		Name:    "foo",
+		Default: map[string]interface{}{"bar": "qux"},
+	}
+
+	actual := v1.Merge(v2).Default
+	expected := map[string]string{"foo": "bar", "bar": "qux"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func testConfig(t *testing.T, name string) *Config {
 	c, err := LoadFile(filepath.Join(fixtureDir, name, "main.tf"))
 	if err != nil {
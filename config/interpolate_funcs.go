@@ -2,18 +2,22 @@ package config
 
 import (
 	"bytes"
+	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/mitchellh/go-homedir"
@@ -23,6 +27,8 @@ import (
 // Funcs is the mapping of built-in functions for configuration.
 func Funcs() map[string]ast.Function {
 	return map[string]ast.Function{
+		"add":          interpolationFuncAdd(),
+		"ceil":         interpolationFuncCeil(),
 		"cidrhost":     interpolationFuncCidrHost(),
 		"cidrnetmask":  interpolationFuncCidrNetmask(),
 		"cidrsubnet":   interpolationFuncCidrSubnet(),
@@ -30,18 +36,33 @@ func Funcs() map[string]ast.Function {
 		"compact":      interpolationFuncCompact(),
 		"concat":       interpolationFuncConcat(),
 		"element":      interpolationFuncElement(),
+		"div":          interpolationFuncDiv(),
 		"file":         interpolationFuncFile(),
+		"floor":        interpolationFuncFloor(),
 		"format":       interpolationFuncFormat(),
+		"formatdate":   interpolationFuncFormatDate(),
 		"formatlist":   interpolationFuncFormatList(),
 		"index":        interpolationFuncIndex(),
 		"join":         interpolationFuncJoin(),
+		"jsondecode":   interpolationFuncJSONDecode(),
+		"jsonencode":   interpolationFuncJSONEncode(),
 		"length":       interpolationFuncLength(),
 		"lower":        interpolationFuncLower(),
+		"max":          interpolationFuncMax(),
+		"md5":          interpolationFuncMd5(),
+		"min":          interpolationFuncMin(),
+		"mod":          interpolationFuncMod(),
+		"mul":          interpolationFuncMul(),
 		"replace":      interpolationFuncReplace(),
 		"split":        interpolationFuncSplit(),
+		"timeadd":      interpolationFuncTimeAdd(),
+		"timestamp":    interpolationFuncTimestamp(),
 		"sha1":         interpolationFuncSha1(),
 		"sha256":       interpolationFuncSha256(),
+		"signum":       interpolationFuncSignum(),
+		"sub":          interpolationFuncSub(),
 		"base64encode": interpolationFuncBase64Encode(),
+		"base64sha256": interpolationFuncBase64Sha256(),
 		"base64decode": interpolationFuncBase64Decode(),
 		"upper":        interpolationFuncUpper(),
 	}
@@ -81,6 +102,20 @@ func interpolationFuncCidrHost() ast.Function {
 				return nil, fmt.Errorf("invalid CIDR expression: %s", err)
 			}
 
+			// Negative host numbers count backwards from the end of the
+			// range, so -1 is the last address in the range.
+			if hostNum < 0 {
+				ones, bits := network.Mask.Size()
+				if hostLen := uint(bits - ones); hostLen < 32 {
+					hostNum += 1 << hostLen
+				}
+				if hostNum < 0 {
+					return nil, fmt.Errorf(
+						"prefix of %d does not accommodate a host numbered %d",
+						ones, args[1].(int))
+				}
+			}
+
 			ip, err := cidr.Host(network, hostNum)
 			if err != nil {
 				return nil, err
@@ -149,6 +184,230 @@ func interpolationFuncCidrSubnet() ast.Function {
 	}
 }
 
+// interpolationFuncCeil returns the least integer value greater than or
+// equal to the argument.
+func interpolationFuncCeil() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeFloat},
+		ReturnType: ast.TypeInt,
+		Callback: func(args []interface{}) (interface{}, error) {
+			return int(math.Ceil(args[0].(float64))), nil
+		},
+	}
+}
+
+// interpolationFuncFloor returns the greatest integer value less than or
+// equal to the argument.
+func interpolationFuncFloor() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeFloat},
+		ReturnType: ast.TypeInt,
+		Callback: func(args []interface{}) (interface{}, error) {
+			return int(math.Floor(args[0].(float64))), nil
+		},
+	}
+}
+
+// interpolationFuncMax returns the largest of the numbers given as
+// arguments. Lists of numbers can be given as well.
+func interpolationFuncMax() ast.Function {
+	return interpolationFuncNumbers(math.Max)
+}
+
+// interpolationFuncMin returns the smallest of the numbers given as
+// arguments. Lists of numbers can be given as well.
+func interpolationFuncMin() ast.Function {
+	return interpolationFuncNumbers(math.Min)
+}
+
+// interpolationFuncNumbers reduces all the numbers given as arguments,
+// expanding any lists, to a single number using the given function.
+func interpolationFuncNumbers(f func(float64, float64) float64) ast.Function {
+	return ast.Function{
+		ArgTypes:     []ast.Type{ast.TypeString},
+		ReturnType:   ast.TypeFloat,
+		Variadic:     true,
+		VariadicType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			var values []string
+			for _, arg := range args {
+				if IsStringList(arg.(string)) {
+					values = append(values, StringList(arg.(string)).Slice()...)
+				} else {
+					values = append(values, arg.(string))
+				}
+			}
+
+			if len(values) == 0 {
+				return nil, fmt.Errorf("must provide at least one number")
+			}
+
+			var result float64
+			for i, v := range values {
+				n, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%q is not a number", v)
+				}
+
+				if i == 0 {
+					result = n
+				} else {
+					result = f(result, n)
+				}
+			}
+
+			return result, nil
+		},
+	}
+}
+
+// interpolationFuncAdd adds two numbers. If either argument is a list,
+// the numbers are added element-wise.
+func interpolationFuncAdd() ast.Function {
+	return interpolationFuncElementwise(func(a, b float64) (float64, error) {
+		return a + b, nil
+	})
+}
+
+// interpolationFuncSub subtracts the second number from the first. If
+// either argument is a list, the numbers are subtracted element-wise.
+func interpolationFuncSub() ast.Function {
+	return interpolationFuncElementwise(func(a, b float64) (float64, error) {
+		return a - b, nil
+	})
+}
+
+// interpolationFuncMul multiplies two numbers. If either argument is a
+// list, the numbers are multiplied element-wise.
+func interpolationFuncMul() ast.Function {
+	return interpolationFuncElementwise(func(a, b float64) (float64, error) {
+		return a * b, nil
+	})
+}
+
+// interpolationFuncDiv divides the first number by the second. If either
+// argument is a list, the numbers are divided element-wise.
+func interpolationFuncDiv() ast.Function {
+	return interpolationFuncElementwise(func(a, b float64) (float64, error) {
+		if b == 0 {
+			return 0, errors.New("divide by zero")
+		}
+		return a / b, nil
+	})
+}
+
+// interpolationFuncMod returns the remainder of dividing the first number
+// by the second. If either argument is a list, the remainders are
+// computed element-wise.
+func interpolationFuncMod() ast.Function {
+	return interpolationFuncElementwise(func(a, b float64) (float64, error) {
+		if b == 0 {
+			return 0, errors.New("divide by zero")
+		}
+		return math.Mod(a, b), nil
+	})
+}
+
+// interpolationFuncElementwise applies the given operation to two
+// arguments, each of which is a number or a list of numbers. Two lists
+// must have the same length and are combined element by element, while a
+// single number is combined with every element of a list. The result is
+// a list if either argument is a list, and a number otherwise.
+func interpolationFuncElementwise(
+	f func(float64, float64) (float64, error)) ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString, ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			a, b := args[0].(string), args[1].(string)
+			isList := IsStringList(a) || IsStringList(b)
+
+			as, err := interpolationNumbers(a)
+			if err != nil {
+				return nil, err
+			}
+			bs, err := interpolationNumbers(b)
+			if err != nil {
+				return nil, err
+			}
+
+			// A single number is applied to every element of the other list
+			switch {
+			case len(as) == 1 && len(bs) != 1:
+				as = repeatNumber(as[0], len(bs))
+			case len(bs) == 1 && len(as) != 1:
+				bs = repeatNumber(bs[0], len(as))
+			case len(as) != len(bs):
+				return nil, fmt.Errorf(
+					"lists must have the same length, got %d and %d",
+					len(as), len(bs))
+			}
+
+			result := make([]string, len(as))
+			for i := range as {
+				n, err := f(as[i], bs[i])
+				if err != nil {
+					return nil, err
+				}
+				result[i] = strconv.FormatFloat(n, 'f', -1, 64)
+			}
+
+			if !isList {
+				return result[0], nil
+			}
+			return NewStringList(result).String(), nil
+		},
+	}
+}
+
+// interpolationNumbers parses a number, or a list of numbers, into a
+// slice of floats.
+func interpolationNumbers(s string) ([]float64, error) {
+	values := []string{s}
+	if IsStringList(s) {
+		values = StringList(s).Slice()
+	}
+
+	result := make([]float64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		result[i] = n
+	}
+
+	return result, nil
+}
+
+func repeatNumber(n float64, count int) []float64 {
+	result := make([]float64, count)
+	for i := range result {
+		result[i] = n
+	}
+	return result
+}
+
+// interpolationFuncSignum returns -1 for negative numbers, 0 for zero and
+// 1 for positive numbers.
+func interpolationFuncSignum() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeInt},
+		ReturnType: ast.TypeInt,
+		Callback: func(args []interface{}) (interface{}, error) {
+			num := args[0].(int)
+			switch {
+			case num < 0:
+				return -1, nil
+			case num > 0:
+				return +1, nil
+			default:
+				return 0, nil
+			}
+		},
+	}
+}
+
 // interpolationFuncCoalesce implements the "coalesce" function that
 // returns the first non null / empty string from the provided input
 func interpolationFuncCoalesce() ast.Function {
@@ -339,6 +598,176 @@ func interpolationFuncIndex() ast.Function {
 	}
 }
 
+// interpolationFuncTimestamp implements the "timestamp" function that
+// returns the current time in RFC 3339 format. Note that it is evaluated
+// every time the configuration is interpolated, so it returns a different
+// value during plan and apply.
+func interpolationFuncTimestamp() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			return time.Now().UTC().Format(time.RFC3339), nil
+		},
+	}
+}
+
+// interpolationFuncTimeAdd implements the "timeadd" function that adds a
+// duration such as "1h30m" to an RFC 3339 timestamp.
+func interpolationFuncTimeAdd() ast.Function {
+	return ast.Function{
+		ArgTypes: []ast.Type{
+			ast.TypeString, // RFC 3339 timestamp
+			ast.TypeString, // duration
+		},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			ts, err := time.Parse(time.RFC3339, args[0].(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp: %s", err)
+			}
+
+			duration, err := time.ParseDuration(args[1].(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration: %s", err)
+			}
+
+			return ts.Add(duration).Format(time.RFC3339), nil
+		},
+	}
+}
+
+// interpolationFuncFormatDate implements the "formatdate" function that
+// formats an RFC 3339 timestamp according to a date format specification.
+func interpolationFuncFormatDate() ast.Function {
+	return ast.Function{
+		ArgTypes: []ast.Type{
+			ast.TypeString, // format specification
+			ast.TypeString, // RFC 3339 timestamp
+		},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			ts, err := time.Parse(time.RFC3339, args[1].(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp: %s", err)
+			}
+
+			return formatDate(args[0].(string), ts)
+		},
+	}
+}
+
+// formatDate formats t according to spec. Runs of the same letter are
+// replaced by a part of the date (e.g. "YYYY" by the year), text within
+// single quotes is copied literally and all other characters are copied
+// as they are.
+func formatDate(spec string, t time.Time) (string, error) {
+	var buf bytes.Buffer
+	for i := 0; i < len(spec); {
+		c := spec[i]
+
+		// Two single quotes produce a single quote, both inside and
+		// outside of a quoted literal.
+		if c == '\'' {
+			if i+1 < len(spec) && spec[i+1] == '\'' {
+				buf.WriteByte('\'')
+				i += 2
+				continue
+			}
+
+			for i++; ; i++ {
+				if i >= len(spec) {
+					return "", fmt.Errorf("unterminated literal in date format %q", spec)
+				}
+				if spec[i] == '\'' {
+					if i+1 < len(spec) && spec[i+1] == '\'' {
+						buf.WriteByte('\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				buf.WriteByte(spec[i])
+			}
+			continue
+		}
+
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			buf.WriteByte(c)
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(spec) && spec[j] == c {
+			j++
+		}
+		token := spec[i:j]
+		i = j
+
+		hour12 := t.Hour() % 12
+		if hour12 == 0 {
+			hour12 = 12
+		}
+
+		switch token {
+		case "YYYY":
+			fmt.Fprintf(&buf, "%04d", t.Year())
+		case "YY":
+			fmt.Fprintf(&buf, "%02d", t.Year()%100)
+		case "MMMM":
+			buf.WriteString(t.Month().String())
+		case "MMM":
+			buf.WriteString(t.Month().String()[:3])
+		case "MM":
+			fmt.Fprintf(&buf, "%02d", t.Month())
+		case "M":
+			fmt.Fprintf(&buf, "%d", t.Month())
+		case "DD":
+			fmt.Fprintf(&buf, "%02d", t.Day())
+		case "D":
+			fmt.Fprintf(&buf, "%d", t.Day())
+		case "EEEE":
+			buf.WriteString(t.Weekday().String())
+		case "EEE":
+			buf.WriteString(t.Weekday().String()[:3])
+		case "hh":
+			fmt.Fprintf(&buf, "%02d", t.Hour())
+		case "h":
+			fmt.Fprintf(&buf, "%d", t.Hour())
+		case "HH":
+			fmt.Fprintf(&buf, "%02d", hour12)
+		case "H":
+			fmt.Fprintf(&buf, "%d", hour12)
+		case "AA":
+			buf.WriteString(t.Format("PM"))
+		case "aa":
+			buf.WriteString(t.Format("pm"))
+		case "mm":
+			fmt.Fprintf(&buf, "%02d", t.Minute())
+		case "m":
+			fmt.Fprintf(&buf, "%d", t.Minute())
+		case "ss":
+			fmt.Fprintf(&buf, "%02d", t.Second())
+		case "s":
+			fmt.Fprintf(&buf, "%d", t.Second())
+		case "ZZZZZ":
+			buf.WriteString(t.Format("-07:00"))
+		case "ZZZZ":
+			buf.WriteString(t.Format("-0700"))
+		case "ZZZ":
+			buf.WriteString(t.Format("MST"))
+		case "Z":
+			buf.WriteString(t.Format("Z07:00"))
+		default:
+			return "", fmt.Errorf("invalid date format %q: unsupported sequence %q", spec, token)
+		}
+	}
+
+	return buf.String(), nil
+}
+
 // interpolationFuncJoin implements the "join" function that allows
 // multi-variable values to be joined by some character.
 func interpolationFuncJoin() ast.Function {
@@ -357,6 +786,77 @@ func interpolationFuncJoin() ast.Function {
 	}
 }
 
+// interpolationFuncJSONEncode implements the "jsonencode" function that
+// encodes a string as a JSON string and a list as a JSON array of strings.
+func interpolationFuncJSONEncode() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			var value interface{} = args[0].(string)
+			if IsStringList(args[0].(string)) {
+				value = StringList(args[0].(string)).Slice()
+			}
+
+			result, err := json.Marshal(value)
+			if err != nil {
+				return "", err
+			}
+
+			return string(result), nil
+		},
+	}
+}
+
+// interpolationFuncJSONDecode implements the "jsondecode" function that
+// decodes a JSON array into a list and any other JSON value into a string.
+// JSON objects can't be decoded since there are no map values.
+func interpolationFuncJSONDecode() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(args[0].(string)), &value); err != nil {
+				return "", fmt.Errorf("invalid JSON: %s", err)
+			}
+
+			list, ok := value.([]interface{})
+			if !ok {
+				return jsonScalarString(value)
+			}
+
+			parts := make([]string, len(list))
+			for i, v := range list {
+				s, err := jsonScalarString(v)
+				if err != nil {
+					return "", fmt.Errorf("element %d: %s", i, err)
+				}
+				parts[i] = s
+			}
+
+			return NewStringList(parts).String(), nil
+		},
+	}
+}
+
+// jsonScalarString returns the string representation of a decoded JSON
+// string, number, boolean or null.
+func jsonScalarString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("can't decode JSON %T into a string", v)
+	}
+}
+
 // interpolationFuncReplace implements the "replace" function that does
 // string replacement.
 func interpolationFuncReplace() ast.Function {
@@ -617,3 +1117,34 @@ func interpolationFuncSha256() ast.Function {
 		},
 	}
 }
+
+// interpolationFuncBase64Sha256 returns the base64-encoded SHA-256 hash of
+// the string, which is the format some APIs such as AWS Lambda use.
+func interpolationFuncBase64Sha256() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			s := args[0].(string)
+			h := sha256.New()
+			h.Write([]byte(s))
+			shaSum := h.Sum(nil)
+			encoded := base64.StdEncoding.EncodeToString(shaSum[:])
+			return encoded, nil
+		},
+	}
+}
+
+func interpolationFuncMd5() ast.Function {
+	return ast.Function{
+		ArgTypes:   []ast.Type{ast.TypeString},
+		ReturnType: ast.TypeString,
+		Callback: func(args []interface{}) (interface{}, error) {
+			s := args[0].(string)
+			h := md5.New()
+			h.Write([]byte(s))
+			hash := hex.EncodeToString(h.Sum(nil))
+			return hash, nil
+		},
+	}
+}
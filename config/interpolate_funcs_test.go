@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/xanzy/terraform-api/config/lang"
 	"github.com/xanzy/terraform-api/config/lang/ast"
@@ -46,6 +47,21 @@ func TestInterpolateFuncCidrHost(t *testing.T) {
 				"192.168.1.5",
 				false,
 			},
+			{
+				`${cidrhost("192.168.1.0/24", -1)}`,
+				"192.168.1.255",
+				false,
+			},
+			{
+				`${cidrhost("192.168.1.0/24", -6)}`,
+				"192.168.1.250",
+				false,
+			},
+			{
+				`${cidrhost("192.168.1.0/30", -5)}`,
+				nil,
+				true, // -5 doesn't fit in two bits
+			},
 			{
 				`${cidrhost("192.168.1.0/30", 255)}`,
 				nil,
@@ -477,6 +493,411 @@ func TestInterpolateFuncReplace(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncCeil(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${ceil(1.2)}`,
+				"2",
+				false,
+			},
+			{
+				`${ceil(-1.8)}`,
+				"-1",
+				false,
+			},
+			{
+				`${ceil(3)}`,
+				"3",
+				false,
+			},
+			{
+				`${ceil("1.5")}`,
+				"2",
+				false,
+			},
+			{
+				`${ceil("foo")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncFloor(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${floor(1.8)}`,
+				"1",
+				false,
+			},
+			{
+				`${floor(-1.2)}`,
+				"-2",
+				false,
+			},
+			{
+				`${floor(10 / 4)}`,
+				"2",
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncMax(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${max(1, 3, 2)}`,
+				"3",
+				false,
+			},
+			{
+				`${max(-1.5, -2)}`,
+				"-1.5",
+				false,
+			},
+			{
+				`${max(split(",", "4,8,6"), 5)}`,
+				"8",
+				false,
+			},
+			{
+				`${max("foo")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncMin(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${min(3, 1, 2)}`,
+				"1",
+				false,
+			},
+			{
+				`${min(0.5, 2)}`,
+				"0.5",
+				false,
+			},
+			{
+				`${min(split(",", "4,8,6"))}`,
+				"4",
+				false,
+			},
+			{
+				`${min(split(",", "4,foo"))}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncSignum(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${signum(-5)}`,
+				"-1",
+				false,
+			},
+			{
+				`${signum(0)}`,
+				"0",
+				false,
+			},
+			{
+				`${signum(15)}`,
+				"1",
+				false,
+			},
+			{
+				`${signum("-3")}`,
+				"-1",
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncAdd(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${add(1, 2)}`,
+				"3",
+				false,
+			},
+			{
+				`${add(split(",", "1,2,3"), 1)}`,
+				NewStringList([]string{"2", "3", "4"}).String(),
+				false,
+			},
+			{
+				`${add(0.5, split(",", "1,2"))}`,
+				NewStringList([]string{"1.5", "2.5"}).String(),
+				false,
+			},
+			{
+				`${add(split(",", "1,2"), split(",", "10,20"))}`,
+				NewStringList([]string{"11", "22"}).String(),
+				false,
+			},
+			{
+				`${add(split(",", "1,2"), split(",", "1,2,3"))}`,
+				nil,
+				true,
+			},
+			{
+				`${add(split(",", "1,foo"), 1)}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncSub(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${sub(1, 2)}`,
+				"-1",
+				false,
+			},
+			{
+				`${sub(split(",", "10,20"), split(",", "1,2"))}`,
+				NewStringList([]string{"9", "18"}).String(),
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncMul(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${mul(3, 4)}`,
+				"12",
+				false,
+			},
+			{
+				`${mul(split(",", "1,2,3"), 1024)}`,
+				NewStringList([]string{"1024", "2048", "3072"}).String(),
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncDiv(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${div(7, 2)}`,
+				"3.5",
+				false,
+			},
+			{
+				`${div(split(",", "8,4"), 4)}`,
+				NewStringList([]string{"2", "1"}).String(),
+				false,
+			},
+			{
+				`${div(1, 0)}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncMod(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${mod(7, 3)}`,
+				"1",
+				false,
+			},
+			{
+				`${mod(split(",", "5,6,7"), 3)}`,
+				NewStringList([]string{"2", "0", "1"}).String(),
+				false,
+			},
+			{
+				`${mod(split(",", "1,2"), 0)}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncTimestamp(t *testing.T) {
+	currentTime := time.Now().UTC()
+	ast, err := lang.Parse("${timestamp()}")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	result, _, err := lang.Eval(ast, langEvalConfig(nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resultTime, err := time.Parse(time.RFC3339, result.(string))
+	if err != nil {
+		t.Fatalf("error parsing timestamp: %s", err)
+	}
+
+	if resultTime.Sub(currentTime).Seconds() > 10.0 {
+		t.Fatalf("timestamp %s is too far from the current time %s", resultTime, currentTime)
+	}
+}
+
+func TestInterpolateFuncTimeAdd(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${timeadd("2017-11-22T00:00:00Z", "1s")}`,
+				"2017-11-22T00:00:01Z",
+				false,
+			},
+			{
+				`${timeadd("2017-11-22T00:00:00Z", "10m1s")}`,
+				"2017-11-22T00:10:01Z",
+				false,
+			},
+			{
+				`${timeadd("2017-11-22T00:00:00Z", "-1h")}`,
+				"2017-11-21T23:00:00Z",
+				false,
+			},
+			{
+				`${timeadd("2017-11-22 00:00:00", "1s")}`,
+				nil,
+				true, // not RFC 3339
+			},
+			{
+				`${timeadd("2017-11-22T00:00:00Z", "1d")}`,
+				nil,
+				true, // days aren't a valid unit
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncFormatDate(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${formatdate("YYYY-MM-DD", "2017-01-02T15:04:05Z")}`,
+				"2017-01-02",
+				false,
+			},
+			{
+				`${formatdate("EEE, DD MMM YYYY hh:mm:ss ZZZ", "2017-01-02T15:04:05Z")}`,
+				"Mon, 02 Jan 2017 15:04:05 UTC",
+				false,
+			},
+			{
+				`${formatdate("MMMM D, H:mmaa", "2017-01-02T15:04:05+02:00")}`,
+				"January 2, 3:04pm",
+				false,
+			},
+			{
+				`${formatdate("'Week of' YY/M/D Z", "2017-01-02T15:04:05-08:00")}`,
+				"Week of 17/1/2 -08:00",
+				false,
+			},
+			{
+				`${formatdate("h 'o''clock'", "2017-01-02T09:04:05Z")}`,
+				"9 o'clock",
+				false,
+			},
+			{
+				`${formatdate("YYY", "2017-01-02T15:04:05Z")}`,
+				nil,
+				true,
+			},
+			{
+				`${formatdate("YYYY", "2017-01-02")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncJSONEncode(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${jsonencode("hello \"world\"")}`,
+				`"hello \"world\""`,
+				false,
+			},
+			{
+				`${jsonencode(split(",", "a,b,c"))}`,
+				`["a","b","c"]`,
+				false,
+			},
+			{
+				`${jsonencode("")}`,
+				`""`,
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncJSONDecode(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${jsondecode("\"hello\"")}`,
+				"hello",
+				false,
+			},
+			{
+				`${jsondecode("42.5")}`,
+				"42.5",
+				false,
+			},
+			{
+				`${join(",", jsondecode("[\"a\", 1, true]"))}`,
+				"a,1,true",
+				false,
+			},
+			{
+				`${jsondecode("{\"a\": 1}")}`,
+				nil,
+				true,
+			},
+			{
+				`${jsondecode("[1, [2]]")}`,
+				nil,
+				true,
+			},
+			{
+				`${jsondecode("not json")}`,
+				nil,
+				true,
+			},
+		},
+	})
+}
+
 func TestInterpolateFuncLength(t *testing.T) {
 	testFunction(t, testFunctionConfig{
 		Cases: []testFunctionCase{
@@ -858,6 +1279,42 @@ func TestInterpolateFuncSha256(t *testing.T) {
 	})
 }
 
+func TestInterpolateFuncBase64Sha256(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${base64sha256("test")}`,
+				"n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+				false,
+			},
+			{
+				// This will differ because we're base64-encoding hex representation,
+				// not raw bytes
+				`${base64encode(sha256("test"))}`,
+				"OWY4NmQwODE4ODRjN2Q2NTlhMmZlYWEwYzU1YWQwMTVhM2JmNGYxYjJiMGI4MjJjZDE1ZDZjMTViMGYwMGEwOA==",
+				false,
+			},
+		},
+	})
+}
+
+func TestInterpolateFuncMd5(t *testing.T) {
+	testFunction(t, testFunctionConfig{
+		Cases: []testFunctionCase{
+			{
+				`${md5("tada")}`,
+				"ce47d07243bb6eaf5e1322c81baf9bbf",
+				false,
+			},
+			{
+				`${md5("")}`,
+				"d41d8cd98f00b204e9800998ecf8427e",
+				false,
+			},
+		},
+	})
+}
+
 type testFunctionConfig struct {
 	Cases []testFunctionCase
 	Vars  map[string]ast.Variable
@@ -237,6 +237,9 @@ func (w *interpolationWalker) splitSlice() {
 		s = v
 	case []map[string]interface{}:
 		return
+	case []string:
+		// Normalized list variable defaults; nothing to split.
+		return
 	default:
 		panic("Unknown kind: " + raw.Kind().String())
 	}
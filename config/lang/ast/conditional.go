@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"fmt"
+)
+
+// Conditional represents a node that evaluates to TrueExpr if CondExpr
+// is true and to FalseExpr otherwise: cond ? true : false.
+type Conditional struct {
+	CondExpr  Node
+	TrueExpr  Node
+	FalseExpr Node
+	Posx      Pos
+}
+
+func (n *Conditional) Accept(v Visitor) Node {
+	n.CondExpr = n.CondExpr.Accept(v)
+	n.TrueExpr = n.TrueExpr.Accept(v)
+	n.FalseExpr = n.FalseExpr.Accept(v)
+
+	return v(n)
+}
+
+func (n *Conditional) Pos() Pos {
+	return n.Posx
+}
+
+func (n *Conditional) GoString() string {
+	return fmt.Sprintf("*%#v", *n)
+}
+
+func (n *Conditional) String() string {
+	return fmt.Sprintf("%s ? %s : %s", n.CondExpr, n.TrueExpr, n.FalseExpr)
+}
+
+func (n *Conditional) Type(s Scope) (Type, error) {
+	return n.TrueExpr.Type(s)
+}
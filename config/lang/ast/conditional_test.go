@@ -0,0 +1,20 @@
+package ast
+
+import (
+	"testing"
+)
+
+func TestConditionalType(t *testing.T) {
+	c := &Conditional{
+		CondExpr:  &LiteralNode{Value: true, Typex: TypeBool},
+		TrueExpr:  &LiteralNode{Value: 1, Typex: TypeInt},
+		FalseExpr: &LiteralNode{Value: 2, Typex: TypeInt},
+	}
+	actual, err := c.Type(nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != TypeInt {
+		t.Fatalf("bad: %s", actual)
+	}
+}
@@ -33,10 +33,16 @@ func (n *UnaryArithmetic) String() string {
 		sign = '+'
 	case ArithmeticOpSub:
 		sign = '-'
+	case ArithmeticOpLogicalNot:
+		sign = '!'
 	}
 	return fmt.Sprintf("%c%s", sign, n.Expr)
 }
 
 func (n *UnaryArithmetic) Type(Scope) (Type, error) {
+	if n.Op == ArithmeticOpLogicalNot {
+		return TypeBool, nil
+	}
+
 	return TypeInt, nil
 }
@@ -67,6 +67,9 @@ func (v *TypeCheck) visit(raw ast.Node) ast.Node {
 	case *ast.Concat:
 		tc := &typeCheckConcat{n}
 		result, err = tc.TypeCheck(v)
+	case *ast.Conditional:
+		tc := &typeCheckConditional{n}
+		result, err = tc.TypeCheck(v)
 	case *ast.LiteralNode:
 		tc := &typeCheckLiteral{n}
 		result, err = tc.TypeCheck(v)
@@ -97,6 +100,10 @@ type typeCheckUnaryArithmetic struct {
 }
 
 func (tc *typeCheckUnaryArithmetic) TypeCheck(v *TypeCheck) (ast.Node, error) {
+	if tc.n.Op == ast.ArithmeticOpLogicalNot {
+		return tc.checkLogicalNot(v)
+	}
+
 	// Only support + or - as unary op
 	if tc.n.Op != ast.ArithmeticOpAdd && tc.n.Op != ast.ArithmeticOpSub {
 		fmt.Printf("%+v\n", tc.n.Op)
@@ -134,6 +141,34 @@ func (tc *typeCheckUnaryArithmetic) TypeCheck(v *TypeCheck) (ast.Node, error) {
 	}, nil
 }
 
+func (tc *typeCheckUnaryArithmetic) checkLogicalNot(v *TypeCheck) (ast.Node, error) {
+	expr := v.StackPop()
+	if expr != ast.TypeBool {
+		cn := v.ImplicitConversion(expr, ast.TypeBool, tc.n.Expr)
+		if cn == nil {
+			return nil, fmt.Errorf(
+				"operand for ! should be %s, got %s", ast.TypeBool, expr)
+		}
+		tc.n.Expr = cn
+	}
+
+	// Return type
+	v.StackPush(ast.TypeBool)
+
+	return &ast.Call{
+		Func: "__builtin_Logical",
+		Args: []ast.Node{
+			&ast.LiteralNode{
+				Value: tc.n.Op,
+				Typex: ast.TypeInt,
+				Posx:  tc.n.Pos(),
+			},
+			tc.n.Expr,
+		},
+		Posx: tc.n.Pos(),
+	}, nil
+}
+
 type typeCheckArithmetic struct {
 	n *ast.Arithmetic
 }
@@ -145,6 +180,15 @@ func (tc *typeCheckArithmetic) TypeCheck(v *TypeCheck) (ast.Node, error) {
 		exprs[len(tc.n.Exprs)-1-i] = v.StackPop()
 	}
 
+	switch tc.n.Op {
+	case ast.ArithmeticOpLogicalAnd, ast.ArithmeticOpLogicalOr:
+		return tc.checkLogical(v, exprs)
+	case ast.ArithmeticOpEqual, ast.ArithmeticOpNotEqual,
+		ast.ArithmeticOpLessThan, ast.ArithmeticOpLessThanOrEqual,
+		ast.ArithmeticOpGreaterThan, ast.ArithmeticOpGreaterThanOrEqual:
+		return tc.checkComparison(v, exprs)
+	}
+
 	// Determine the resulting type we want. We do this by going over
 	// every expression until we find one with a type we recognize.
 	// We do this because the first expr might be a string ("var.foo")
@@ -209,6 +253,102 @@ func (tc *typeCheckArithmetic) TypeCheck(v *TypeCheck) (ast.Node, error) {
 	}, nil
 }
 
+func (tc *typeCheckArithmetic) checkComparison(v *TypeCheck, exprs []ast.Type) (ast.Node, error) {
+	// Numbers are compared as floats if either side is a float, and as
+	// ints otherwise. Only equality is defined for strings and bools. If
+	// there is no number on either side of an ordering comparison, both
+	// sides are parsed as floats.
+	equality := tc.n.Op == ast.ArithmeticOpEqual ||
+		tc.n.Op == ast.ArithmeticOpNotEqual
+	compareType := ast.TypeString
+	if !equality {
+		compareType = ast.TypeFloat
+	}
+	for _, t := range exprs {
+		if t == ast.TypeFloat {
+			compareType = ast.TypeFloat
+			break
+		}
+		if t == ast.TypeInt || (equality && t == ast.TypeBool) {
+			compareType = t
+		}
+	}
+
+	compareFunc := "__builtin_StringCompare"
+	switch compareType {
+	case ast.TypeInt:
+		compareFunc = "__builtin_IntCompare"
+	case ast.TypeFloat:
+		compareFunc = "__builtin_FloatCompare"
+	case ast.TypeBool:
+		compareFunc = "__builtin_BoolCompare"
+	}
+
+	// Verify the args
+	for i, arg := range exprs {
+		if arg != compareType {
+			cn := v.ImplicitConversion(arg, compareType, tc.n.Exprs[i])
+			if cn != nil {
+				tc.n.Exprs[i] = cn
+				continue
+			}
+
+			return nil, fmt.Errorf(
+				"operand %d should be %s, got %s",
+				i+1, compareType, arg)
+		}
+	}
+
+	// Return type
+	v.StackPush(ast.TypeBool)
+
+	args := make([]ast.Node, len(tc.n.Exprs)+1)
+	args[0] = &ast.LiteralNode{
+		Value: tc.n.Op,
+		Typex: ast.TypeInt,
+		Posx:  tc.n.Pos(),
+	}
+	copy(args[1:], tc.n.Exprs)
+	return &ast.Call{
+		Func: compareFunc,
+		Args: args,
+		Posx: tc.n.Pos(),
+	}, nil
+}
+
+func (tc *typeCheckArithmetic) checkLogical(v *TypeCheck, exprs []ast.Type) (ast.Node, error) {
+	// Verify the args
+	for i, arg := range exprs {
+		if arg != ast.TypeBool {
+			cn := v.ImplicitConversion(arg, ast.TypeBool, tc.n.Exprs[i])
+			if cn != nil {
+				tc.n.Exprs[i] = cn
+				continue
+			}
+
+			return nil, fmt.Errorf(
+				"operand %d should be %s, got %s",
+				i+1, ast.TypeBool, arg)
+		}
+	}
+
+	// Return type
+	v.StackPush(ast.TypeBool)
+
+	args := make([]ast.Node, len(tc.n.Exprs)+1)
+	args[0] = &ast.LiteralNode{
+		Value: tc.n.Op,
+		Typex: ast.TypeInt,
+		Posx:  tc.n.Pos(),
+	}
+	copy(args[1:], tc.n.Exprs)
+	return &ast.Call{
+		Func: "__builtin_Logical",
+		Args: args,
+		Posx: tc.n.Pos(),
+	}, nil
+}
+
 type typeCheckCall struct {
 	n *ast.Call
 }
@@ -303,6 +443,63 @@ func (tc *typeCheckConcat) TypeCheck(v *TypeCheck) (ast.Node, error) {
 	return n, nil
 }
 
+type typeCheckConditional struct {
+	n *ast.Conditional
+}
+
+func (tc *typeCheckConditional) TypeCheck(v *TypeCheck) (ast.Node, error) {
+	// The expressions are on the stack in reverse order.
+	falseType := v.StackPop()
+	trueType := v.StackPop()
+	condType := v.StackPop()
+
+	if condType != ast.TypeBool {
+		cn := v.ImplicitConversion(condType, ast.TypeBool, tc.n.CondExpr)
+		if cn == nil {
+			return nil, fmt.Errorf(
+				"condition must be %s, got %s", ast.TypeBool, condType)
+		}
+		tc.n.CondExpr = cn
+	}
+
+	// Both results must have the same type. Strings win if either side
+	// is a string, since everything can be turned into one. Otherwise
+	// the false result is converted to the type of the true result if
+	// possible, or the other way around.
+	resultType := trueType
+	if trueType != falseType {
+		switch {
+		case falseType == ast.TypeString:
+			resultType = falseType
+			if cn := v.ImplicitConversion(trueType, falseType, tc.n.TrueExpr); cn != nil {
+				tc.n.TrueExpr = cn
+				break
+			}
+			return nil, fmt.Errorf(
+				"true and false results must have the same type, got %s and %s",
+				trueType, falseType)
+		default:
+			if cn := v.ImplicitConversion(falseType, trueType, tc.n.FalseExpr); cn != nil {
+				tc.n.FalseExpr = cn
+				break
+			}
+			resultType = falseType
+			if cn := v.ImplicitConversion(trueType, falseType, tc.n.TrueExpr); cn != nil {
+				tc.n.TrueExpr = cn
+				break
+			}
+			return nil, fmt.Errorf(
+				"true and false results must have the same type, got %s and %s",
+				trueType, falseType)
+		}
+	}
+
+	// Return type
+	v.StackPush(resultType)
+
+	return tc.n, nil
+}
+
 type typeCheckLiteral struct {
 	n *ast.LiteralNode
 }
@@ -32,6 +32,9 @@ func Eval(root ast.Node, config *EvalConfig) (interface{}, ast.Type, error) {
 	}
 	scope := registerBuiltins(config.GlobalScope)
 	implicitMap := map[ast.Type]map[ast.Type]string{
+		ast.TypeBool: {
+			ast.TypeString: "__builtin_BoolToString",
+		},
 		ast.TypeFloat: {
 			ast.TypeInt:    "__builtin_FloatToInt",
 			ast.TypeString: "__builtin_FloatToString",
@@ -41,7 +44,9 @@ func Eval(root ast.Node, config *EvalConfig) (interface{}, ast.Type, error) {
 			ast.TypeString: "__builtin_IntToString",
 		},
 		ast.TypeString: {
-			ast.TypeInt: "__builtin_StringToInt",
+			ast.TypeBool:  "__builtin_StringToBool",
+			ast.TypeFloat: "__builtin_StringToFloat",
+			ast.TypeInt:   "__builtin_StringToInt",
 		},
 	}
 
@@ -144,6 +149,8 @@ func evalNode(raw ast.Node) (EvalNode, error) {
 		return &evalCall{n}, nil
 	case *ast.Concat:
 		return &evalConcat{n}, nil
+	case *ast.Conditional:
+		return &evalConditional{n}, nil
 	case *ast.LiteralNode:
 		return &evalLiteralNode{n}, nil
 	case *ast.VariableAccess:
@@ -202,6 +209,23 @@ func (v *evalConcat) Eval(s ast.Scope, stack *ast.Stack) (interface{}, ast.Type,
 	return buf.String(), ast.TypeString, nil
 }
 
+type evalConditional struct{ *ast.Conditional }
+
+func (v *evalConditional) Eval(s ast.Scope, stack *ast.Stack) (interface{}, ast.Type, error) {
+	// All three expressions have already been evaluated and are on the
+	// stack in reverse order. Note that this means both results are
+	// always evaluated, even the one that isn't returned.
+	falseNode := stack.Pop().(*ast.LiteralNode)
+	trueNode := stack.Pop().(*ast.LiteralNode)
+	condNode := stack.Pop().(*ast.LiteralNode)
+
+	if condNode.Value.(bool) {
+		return trueNode.Value, trueNode.Typex, nil
+	}
+
+	return falseNode.Value, falseNode.Typex, nil
+}
+
 type evalLiteralNode struct{ *ast.LiteralNode }
 
 func (v *evalLiteralNode) Eval(ast.Scope, *ast.Stack) (interface{}, ast.Type, error) {
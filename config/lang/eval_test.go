@@ -111,6 +111,21 @@ func TestEval(t *testing.T) {
 			ast.TypeString,
 		},
 
+		{
+			"foo ${1.5+bar}",
+			&ast.BasicScope{
+				VarMap: map[string]ast.Variable{
+					"bar": ast.Variable{
+						Value: "2.5",
+						Type:  ast.TypeString,
+					},
+				},
+			},
+			false,
+			"foo 4",
+			ast.TypeString,
+		},
+
 		{
 			"foo ${42+2*2}",
 			nil,
@@ -313,6 +328,98 @@ func TestEval(t *testing.T) {
 			"foo -36",
 			ast.TypeString,
 		},
+
+		{
+			"${bar > 1 ? \"many\" : \"one\"}",
+			&ast.BasicScope{
+				VarMap: map[string]ast.Variable{
+					"bar": ast.Variable{
+						Value: "3",
+						Type:  ast.TypeString,
+					},
+				},
+			},
+			false,
+			"many",
+			ast.TypeString,
+		},
+
+		{
+			"${bar ? 2 : 0}",
+			&ast.BasicScope{
+				VarMap: map[string]ast.Variable{
+					"bar": ast.Variable{
+						Value: "0",
+						Type:  ast.TypeString,
+					},
+				},
+			},
+			false,
+			"0",
+			ast.TypeString,
+		},
+
+		{
+			"${true ? false ? 1 : 2 : 3}",
+			nil,
+			false,
+			"2",
+			ast.TypeString,
+		},
+
+		{
+			"${1 + 1 == 2 && !(1.5 >= 2) || false}",
+			nil,
+			false,
+			"true",
+			ast.TypeString,
+		},
+
+		{
+			"${bar == \"baz\" ? 1 : 0.5}",
+			&ast.BasicScope{
+				VarMap: map[string]ast.Variable{
+					"bar": ast.Variable{
+						Value: "baz",
+						Type:  ast.TypeString,
+					},
+				},
+			},
+			false,
+			"1",
+			ast.TypeString,
+		},
+
+		{
+			"${true == \"1\"}",
+			nil,
+			false,
+			"true",
+			ast.TypeString,
+		},
+
+		{
+			"${1 < 2 < 3}",
+			nil,
+			true,
+			nil,
+			ast.TypeInvalid,
+		},
+
+		{
+			"${bar ? 1 : 0}",
+			&ast.BasicScope{
+				VarMap: map[string]ast.Variable{
+					"bar": ast.Variable{
+						Value: "maybe",
+						Type:  ast.TypeString,
+					},
+				},
+			},
+			true,
+			nil,
+			ast.TypeInvalid,
+		},
 	}
 
 	for _, tc := range cases {
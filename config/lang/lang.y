@@ -22,12 +22,20 @@ import (
 %token  <str> PROGRAM_STRING_START PROGRAM_STRING_END
 %token  <str> PAREN_LEFT PAREN_RIGHT COMMA
 
-%token <token> ARITH_OP IDENTIFIER INTEGER FLOAT STRING
+%token <token> ARITH_OP IDENTIFIER INTEGER FLOAT STRING BOOL
+%token <token> EQUALITY COMPARISON AND OR BANG
+%token  <str> QUESTION COLON
 
 %type <node> expr interpolation literal literalModeTop literalModeValue
 %type <nodeList> args
 
+%right QUESTION COLON
+%left OR
+%left AND
+%left EQUALITY
+%left COMPARISON
 %left ARITH_OP
+%right BANG
 
 %%
 
@@ -122,6 +130,23 @@ expr:
             Posx:  $1.Pos,
         }
     }
+|   BOOL
+    {
+        $$ = &ast.LiteralNode{
+            Value: $1.Value.(bool),
+            Typex:  ast.TypeBool,
+            Posx:  $1.Pos,
+        }
+    }
+|   expr QUESTION expr COLON expr
+    {
+        $$ = &ast.Conditional{
+            CondExpr:  $1,
+            TrueExpr:  $3,
+            FalseExpr: $5,
+            Posx:      $1.Pos(),
+        }
+    }
 |   expr ARITH_OP expr
     {
         $$ = &ast.Arithmetic{
@@ -130,6 +155,38 @@ expr:
             Posx:  $1.Pos(),
         }
     }
+|   expr EQUALITY expr
+    {
+        $$ = &ast.Arithmetic{
+            Op:    $2.Value.(ast.ArithmeticOp),
+            Exprs: []ast.Node{$1, $3},
+            Posx:  $1.Pos(),
+        }
+    }
+|   expr COMPARISON expr
+    {
+        $$ = &ast.Arithmetic{
+            Op:    $2.Value.(ast.ArithmeticOp),
+            Exprs: []ast.Node{$1, $3},
+            Posx:  $1.Pos(),
+        }
+    }
+|   expr AND expr
+    {
+        $$ = &ast.Arithmetic{
+            Op:    $2.Value.(ast.ArithmeticOp),
+            Exprs: []ast.Node{$1, $3},
+            Posx:  $1.Pos(),
+        }
+    }
+|   expr OR expr
+    {
+        $$ = &ast.Arithmetic{
+            Op:    $2.Value.(ast.ArithmeticOp),
+            Exprs: []ast.Node{$1, $3},
+            Posx:  $1.Pos(),
+        }
+    }
 |   ARITH_OP expr
     {
         $$ = &ast.UnaryArithmetic{
@@ -138,6 +195,14 @@ expr:
             Posx:  $1.Pos,
         }
     }
+|   BANG expr
+    {
+        $$ = &ast.UnaryArithmetic{
+            Op:    $1.Value.(ast.ArithmeticOp),
+            Expr:  $2,
+            Posx:  $1.Pos,
+        }
+    }
 |   IDENTIFIER
     {
         $$ = &ast.VariableAccess{Name: $1.Value.(string), Posx: $1.Pos}
@@ -183,6 +183,56 @@ func (x *parserLex) lexModeInterpolation(yylval *parserSymType) int {
 		case '%':
 			yylval.token = &parserToken{Value: ast.ArithmeticOpMod}
 			return ARITH_OP
+		case '?':
+			return QUESTION
+		case ':':
+			return COLON
+		case '=':
+			if x.peek() != '=' {
+				x.Error("expected \"==\", got \"=\"")
+				return lexEOF
+			}
+			x.next()
+			yylval.token = &parserToken{Value: ast.ArithmeticOpEqual}
+			return EQUALITY
+		case '!':
+			if x.peek() == '=' {
+				x.next()
+				yylval.token = &parserToken{Value: ast.ArithmeticOpNotEqual}
+				return EQUALITY
+			}
+			yylval.token = &parserToken{Value: ast.ArithmeticOpLogicalNot}
+			return BANG
+		case '<':
+			yylval.token = &parserToken{Value: ast.ArithmeticOpLessThan}
+			if x.peek() == '=' {
+				x.next()
+				yylval.token.Value = ast.ArithmeticOpLessThanOrEqual
+			}
+			return COMPARISON
+		case '>':
+			yylval.token = &parserToken{Value: ast.ArithmeticOpGreaterThan}
+			if x.peek() == '=' {
+				x.next()
+				yylval.token.Value = ast.ArithmeticOpGreaterThanOrEqual
+			}
+			return COMPARISON
+		case '&':
+			if x.peek() != '&' {
+				x.Error("expected \"&&\", got \"&\"")
+				return lexEOF
+			}
+			x.next()
+			yylval.token = &parserToken{Value: ast.ArithmeticOpLogicalAnd}
+			return AND
+		case '|':
+			if x.peek() != '|' {
+				x.Error("expected \"||\", got \"|\"")
+				return lexEOF
+			}
+			x.next()
+			yylval.token = &parserToken{Value: ast.ArithmeticOpLogicalOr}
+			return OR
 		default:
 			x.backup()
 			return x.lexId(yylval)
@@ -226,8 +276,15 @@ func (x *parserLex) lexId(yylval *parserSymType) int {
 		last = c
 	}
 
-	yylval.token = &parserToken{Value: b.String()}
-	return IDENTIFIER
+	// The boolean literals look like identifiers, so pick them out here.
+	switch v := b.String(); v {
+	case "true", "false":
+		yylval.token = &parserToken{Value: v == "true"}
+		return BOOL
+	default:
+		yylval.token = &parserToken{Value: v}
+		return IDENTIFIER
+	}
 }
 
 // lexNumber lexes out a number: an integer or a float.
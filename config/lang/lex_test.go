@@ -135,6 +135,34 @@ func TestLex(t *testing.T) {
 				PROGRAM_BRACKET_LEFT, IDENTIFIER, PROGRAM_BRACKET_RIGHT,
 				PROGRAM_BRACKET_RIGHT, lexEOF},
 		},
+
+		{
+			"${var.foo ? 1 : 2}",
+			[]int{PROGRAM_BRACKET_LEFT,
+				IDENTIFIER, QUESTION, INTEGER, COLON, INTEGER,
+				PROGRAM_BRACKET_RIGHT, lexEOF},
+		},
+
+		{
+			"${a == b != c < d <= e > f >= g}",
+			[]int{PROGRAM_BRACKET_LEFT,
+				IDENTIFIER, EQUALITY, IDENTIFIER, EQUALITY, IDENTIFIER,
+				COMPARISON, IDENTIFIER, COMPARISON, IDENTIFIER,
+				COMPARISON, IDENTIFIER, COMPARISON, IDENTIFIER,
+				PROGRAM_BRACKET_RIGHT, lexEOF},
+		},
+
+		{
+			"${!true && false || a}",
+			[]int{PROGRAM_BRACKET_LEFT,
+				BANG, BOOL, AND, BOOL, OR, IDENTIFIER,
+				PROGRAM_BRACKET_RIGHT, lexEOF},
+		},
+
+		{
+			"${a = b}",
+			[]int{PROGRAM_BRACKET_LEFT, IDENTIFIER, lexEOF},
+		},
 	}
 
 	for _, tc := range cases {
@@ -349,6 +349,50 @@ func TestParse(t *testing.T) {
 			true,
 			nil,
 		},
+
+		{
+			"${var.foo == 1 ? true : \"no\"}",
+			false,
+			&ast.Concat{
+				Posx: ast.Pos{Column: 3, Line: 1},
+				Exprs: []ast.Node{
+					&ast.Conditional{
+						CondExpr: &ast.Arithmetic{
+							Op: ast.ArithmeticOpEqual,
+							Exprs: []ast.Node{
+								&ast.VariableAccess{
+									Name: "var.foo",
+									Posx: ast.Pos{Column: 3, Line: 1},
+								},
+								&ast.LiteralNode{
+									Value: 1,
+									Typex: ast.TypeInt,
+									Posx:  ast.Pos{Column: 13, Line: 1},
+								},
+							},
+							Posx: ast.Pos{Column: 3, Line: 1},
+						},
+						TrueExpr: &ast.LiteralNode{
+							Value: true,
+							Typex: ast.TypeBool,
+							Posx:  ast.Pos{Column: 17, Line: 1},
+						},
+						FalseExpr: &ast.LiteralNode{
+							Value: "no",
+							Typex: ast.TypeString,
+							Posx:  ast.Pos{Column: 24, Line: 1},
+						},
+						Posx: ast.Pos{Column: 3, Line: 1},
+					},
+				},
+			},
+		},
+
+		{
+			"${a ? b ?}",
+			true,
+			nil,
+		},
 	}
 
 	for _, tc := range cases {
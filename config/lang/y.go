@@ -29,6 +29,14 @@ const IDENTIFIER = 57354
 const INTEGER = 57355
 const FLOAT = 57356
 const STRING = 57357
+const BOOL = 57358
+const EQUALITY = 57359
+const COMPARISON = 57360
+const AND = 57361
+const OR = 57362
+const BANG = 57363
+const QUESTION = 57364
+const COLON = 57365
 
 var parserToknames = [...]string{
 	"$end",
@@ -46,6 +54,14 @@ var parserToknames = [...]string{
 	"INTEGER",
 	"FLOAT",
 	"STRING",
+	"BOOL",
+	"EQUALITY",
+	"COMPARISON",
+	"AND",
+	"OR",
+	"BANG",
+	"QUESTION",
+	"COLON",
 }
 var parserStatenames = [...]string{}
 
@@ -53,7 +69,7 @@ const parserEofCode = 1
 const parserErrCode = 2
 const parserInitialStackSize = 16
 
-//line lang.y:173
+//line lang.y:238
 
 //line yacctab:1
 var parserExca = [...]int{
@@ -62,52 +78,65 @@ var parserExca = [...]int{
 	-2, 0,
 }
 
-const parserNprod = 20
+const parserNprod = 27
 const parserPrivate = 57344
 
 var parserTokenNames []string
 var parserStates []string
 
-const parserLast = 34
+const parserLast = 90
 
 var parserAct = [...]int{
 
-	9, 7, 3, 16, 22, 8, 17, 17, 20, 17,
-	1, 18, 6, 23, 8, 19, 25, 26, 21, 11,
-	2, 24, 7, 4, 5, 0, 10, 27, 0, 14,
-	15, 12, 13, 6,
+	9, 5, 4, 11, 2, 36, 3, 1, 20, 8,
+	28, 25, 20, 0, 21, 22, 26, 27, 8, 22,
+	29, 30, 31, 32, 33, 34, 7, 39, 40, 37,
+	10, 20, 0, 15, 17, 12, 13, 6, 14, 41,
+	18, 42, 20, 16, 0, 0, 20, 0, 21, 22,
+	23, 0, 21, 22, 23, 24, 35, 19, 20, 7,
+	0, 0, 0, 0, 21, 22, 23, 24, 20, 19,
+	6, 0, 0, 0, 21, 22, 23, 24, 20, 19,
+	38, 0, 0, 0, 21, 22, 23, 24, 0, 19,
 }
 var parserPact = [...]int{
 
-	-3, -1000, -3, -1000, -1000, -1000, -1000, 18, -1000, -2,
-	18, -3, -1000, -1000, 18, 0, -1000, 18, -5, -1000,
-	18, -1000, -1000, 7, -4, -1000, 18, -4,
+	55, -1000, 55, -1000, -1000, -1000, -1000, 22, -1000, 35,
+	22, 55, -1000, -1000, -1000, 22, 22, 2, -1000, 22,
+	22, 22, 22, 22, 22, 47, -1000, -1000, 22, 57,
+	-1000, 1, 20, -3, 31, -1000, 18, 67, 22, -1000,
+	22, 67, 67,
 }
 var parserPgo = [...]int{
 
-	0, 0, 24, 23, 19, 2, 13, 10,
+	0, 0, 1, 2, 3, 6, 5, 7,
 }
 var parserR1 = [...]int{
 
 	0, 7, 7, 4, 4, 5, 5, 2, 1, 1,
-	1, 1, 1, 1, 1, 1, 6, 6, 6, 3,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 6, 6, 6, 3,
 }
 var parserR2 = [...]int{
 
 	0, 0, 1, 1, 2, 1, 1, 3, 3, 1,
-	1, 1, 3, 2, 1, 4, 0, 3, 1, 1,
+	1, 1, 1, 5, 3, 3, 3, 3, 3, 2,
+	2, 1, 4, 0, 3, 1, 1,
 }
 var parserChk = [...]int{
 
 	-1000, -7, -4, -5, -3, -2, 15, 4, -5, -1,
-	8, -4, 13, 14, 11, 12, 5, 11, -1, -1,
-	8, -1, 9, -6, -1, 9, 10, -1,
+	8, -4, 13, 14, 16, 11, 21, 12, 5, 22,
+	11, 17, 18, 19, 20, -1, -1, -1, 8, -1,
+	-1, -1, -1, -1, -1, 9, -6, -1, 23, 9,
+	10, -1, -1,
 }
 var parserDef = [...]int{
 
-	1, -2, 2, 3, 5, 6, 19, 0, 4, 0,
-	0, 9, 10, 11, 0, 14, 7, 0, 0, 13,
-	16, 12, 8, 0, 18, 15, 0, 17,
+	1, -2, 2, 3, 5, 6, 26, 0, 4, 0,
+	0, 9, 10, 11, 12, 0, 0, 21, 7, 0,
+	0, 0, 0, 0, 0, 0, 19, 20, 23, 0,
+	14, 15, 16, 17, 18, 8, 0, 25, 0, 22,
+	0, 13, 24,
 }
 var parserTok1 = [...]int{
 
@@ -116,7 +145,8 @@ var parserTok1 = [...]int{
 var parserTok2 = [...]int{
 
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
-	12, 13, 14, 15,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22, 23,
 }
 var parserTok3 = [...]int{
 	0,
@@ -461,7 +491,7 @@ parserdefault:
 
 	case 1:
 		parserDollar = parserS[parserpt-0 : parserpt+1]
-		//line lang.y:35
+		//line lang.y:43
 		{
 			parserResult = &ast.LiteralNode{
 				Value: "",
@@ -471,7 +501,7 @@ parserdefault:
 		}
 	case 2:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:43
+		//line lang.y:51
 		{
 			parserResult = parserDollar[1].node
 
@@ -494,13 +524,13 @@ parserdefault:
 		}
 	case 3:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:66
+		//line lang.y:74
 		{
 			parserVAL.node = parserDollar[1].node
 		}
 	case 4:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-		//line lang.y:70
+		//line lang.y:78
 		{
 			var result []ast.Node
 			if c, ok := parserDollar[1].node.(*ast.Concat); ok {
@@ -516,37 +546,37 @@ parserdefault:
 		}
 	case 5:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:86
+		//line lang.y:94
 		{
 			parserVAL.node = parserDollar[1].node
 		}
 	case 6:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:90
+		//line lang.y:98
 		{
 			parserVAL.node = parserDollar[1].node
 		}
 	case 7:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-		//line lang.y:96
+		//line lang.y:104
 		{
 			parserVAL.node = parserDollar[2].node
 		}
 	case 8:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-		//line lang.y:102
+		//line lang.y:110
 		{
 			parserVAL.node = parserDollar[2].node
 		}
 	case 9:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:106
+		//line lang.y:114
 		{
 			parserVAL.node = parserDollar[1].node
 		}
 	case 10:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:110
+		//line lang.y:118
 		{
 			parserVAL.node = &ast.LiteralNode{
 				Value: parserDollar[1].token.Value.(int),
@@ -556,7 +586,7 @@ parserdefault:
 		}
 	case 11:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:118
+		//line lang.y:126
 		{
 			parserVAL.node = &ast.LiteralNode{
 				Value: parserDollar[1].token.Value.(float64),
@@ -565,8 +595,29 @@ parserdefault:
 			}
 		}
 	case 12:
+		parserDollar = parserS[parserpt-1 : parserpt+1]
+		//line lang.y:134
+		{
+			parserVAL.node = &ast.LiteralNode{
+				Value: parserDollar[1].token.Value.(bool),
+				Typex: ast.TypeBool,
+				Posx:  parserDollar[1].token.Pos,
+			}
+		}
+	case 13:
+		parserDollar = parserS[parserpt-5 : parserpt+1]
+		//line lang.y:142
+		{
+			parserVAL.node = &ast.Conditional{
+				CondExpr:  parserDollar[1].node,
+				TrueExpr:  parserDollar[3].node,
+				FalseExpr: parserDollar[5].node,
+				Posx:      parserDollar[1].node.Pos(),
+			}
+		}
+	case 14:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-		//line lang.y:126
+		//line lang.y:151
 		{
 			parserVAL.node = &ast.Arithmetic{
 				Op:    parserDollar[2].token.Value.(ast.ArithmeticOp),
@@ -574,9 +625,49 @@ parserdefault:
 				Posx:  parserDollar[1].node.Pos(),
 			}
 		}
-	case 13:
+	case 15:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+		//line lang.y:159
+		{
+			parserVAL.node = &ast.Arithmetic{
+				Op:    parserDollar[2].token.Value.(ast.ArithmeticOp),
+				Exprs: []ast.Node{parserDollar[1].node, parserDollar[3].node},
+				Posx:  parserDollar[1].node.Pos(),
+			}
+		}
+	case 16:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+		//line lang.y:167
+		{
+			parserVAL.node = &ast.Arithmetic{
+				Op:    parserDollar[2].token.Value.(ast.ArithmeticOp),
+				Exprs: []ast.Node{parserDollar[1].node, parserDollar[3].node},
+				Posx:  parserDollar[1].node.Pos(),
+			}
+		}
+	case 17:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+		//line lang.y:175
+		{
+			parserVAL.node = &ast.Arithmetic{
+				Op:    parserDollar[2].token.Value.(ast.ArithmeticOp),
+				Exprs: []ast.Node{parserDollar[1].node, parserDollar[3].node},
+				Posx:  parserDollar[1].node.Pos(),
+			}
+		}
+	case 18:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+		//line lang.y:183
+		{
+			parserVAL.node = &ast.Arithmetic{
+				Op:    parserDollar[2].token.Value.(ast.ArithmeticOp),
+				Exprs: []ast.Node{parserDollar[1].node, parserDollar[3].node},
+				Posx:  parserDollar[1].node.Pos(),
+			}
+		}
+	case 19:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-		//line lang.y:134
+		//line lang.y:191
 		{
 			parserVAL.node = &ast.UnaryArithmetic{
 				Op:   parserDollar[1].token.Value.(ast.ArithmeticOp),
@@ -584,39 +675,49 @@ parserdefault:
 				Posx: parserDollar[1].token.Pos,
 			}
 		}
-	case 14:
+	case 20:
+		parserDollar = parserS[parserpt-2 : parserpt+1]
+		//line lang.y:199
+		{
+			parserVAL.node = &ast.UnaryArithmetic{
+				Op:   parserDollar[1].token.Value.(ast.ArithmeticOp),
+				Expr: parserDollar[2].node,
+				Posx: parserDollar[1].token.Pos,
+			}
+		}
+	case 21:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:142
+		//line lang.y:207
 		{
 			parserVAL.node = &ast.VariableAccess{Name: parserDollar[1].token.Value.(string), Posx: parserDollar[1].token.Pos}
 		}
-	case 15:
+	case 22:
 		parserDollar = parserS[parserpt-4 : parserpt+1]
-		//line lang.y:146
+		//line lang.y:211
 		{
 			parserVAL.node = &ast.Call{Func: parserDollar[1].token.Value.(string), Args: parserDollar[3].nodeList, Posx: parserDollar[1].token.Pos}
 		}
-	case 16:
+	case 23:
 		parserDollar = parserS[parserpt-0 : parserpt+1]
-		//line lang.y:151
+		//line lang.y:216
 		{
 			parserVAL.nodeList = nil
 		}
-	case 17:
+	case 24:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-		//line lang.y:155
+		//line lang.y:220
 		{
 			parserVAL.nodeList = append(parserDollar[1].nodeList, parserDollar[3].node)
 		}
-	case 18:
+	case 25:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:159
+		//line lang.y:224
 		{
 			parserVAL.nodeList = append(parserVAL.nodeList, parserDollar[1].node)
 		}
-	case 19:
+	case 26:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-		//line lang.y:165
+		//line lang.y:230
 		{
 			parserVAL.node = &ast.LiteralNode{
 				Value: parserDollar[1].token.Value.(string),
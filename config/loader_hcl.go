@@ -28,9 +28,10 @@ func (t *hclConfigurable) Config() (*Config, error) {
 	}
 
 	type hclVariable struct {
-		Default     interface{}
-		Description string
-		Fields      []string `hcl:",decodedFields"`
+		DeclaredType string `hcl:"type"`
+		Default      interface{}
+		Description  string
+		Fields       []string `hcl:",decodedFields"`
 	}
 
 	var rawConfig struct {
@@ -70,9 +71,10 @@ func (t *hclConfigurable) Config() (*Config, error) {
 			}
 
 			newVar := &Variable{
-				Name:        k,
-				Default:     v.Default,
-				Description: v.Description,
+				Name:         k,
+				DeclaredType: v.DeclaredType,
+				Default:      v.Default,
+				Description:  v.Description,
 			}
 
 			config.Variables = append(config.Variables, newVar)
@@ -265,6 +267,8 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 
 		// Remove the fields we handle specially
 		delete(config, "source")
+		delete(config, "depends_on")
+		delete(config, "providers")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -286,10 +290,36 @@ func loadModulesHcl(list *ast.ObjectList) ([]*Module, error) {
 			}
 		}
 
+		// If we have depends fields, then add those in
+		var dependsOn []string
+		if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
+			err := hcl.DecodeObject(&dependsOn, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading depends_on for module %s: %s",
+					k,
+					err)
+			}
+		}
+
+		// The providers passed to the module
+		var providers map[string]string
+		if o := listVal.Filter("providers"); len(o.Items) > 0 {
+			err := hcl.DecodeObject(&providers, o.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading providers for module %s: %s",
+					k,
+					err)
+			}
+		}
+
 		result = append(result, &Module{
 			Name:      k,
 			Source:    source,
+			DependsOn: dependsOn,
 			RawConfig: rawConfig,
+			Providers: providers,
 		})
 	}
 
@@ -314,6 +344,20 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 			return nil, err
 		}
 
+		// The sensitive flag isn't part of the value, so pull it out
+		// before we build the raw config.
+		var sensitive bool
+		if raw, ok := config["sensitive"]; ok {
+			if err := mapstructure.WeakDecode(raw, &sensitive); err != nil {
+				return nil, fmt.Errorf(
+					"Error reading sensitive flag for output %s: %s",
+					n,
+					err)
+			}
+
+			delete(config, "sensitive")
+		}
+
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -324,6 +368,7 @@ func loadOutputsHcl(list *ast.ObjectList) ([]*Output, error) {
 
 		result = append(result, &Output{
 			Name:      n,
+			Sensitive: sensitive,
 			RawConfig: rawConfig,
 		})
 	}
@@ -357,6 +402,7 @@ func loadProvidersHcl(list *ast.ObjectList) ([]*ProviderConfig, error) {
 		}
 
 		delete(config, "alias")
+		delete(config, "version")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -366,6 +412,18 @@ func loadProvidersHcl(list *ast.ObjectList) ([]*ProviderConfig, error) {
 				err)
 		}
 
+		// If we have a version constraint, then add that in
+		var version string
+		if v := listVal.Filter("version"); len(v.Items) > 0 {
+			err := hcl.DecodeObject(&version, v.Items[0].Val)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Error reading version for provider[%s]: %s",
+					n,
+					err)
+			}
+		}
+
 		// If we have an alias field, then add those in
 		var alias string
 		if a := listVal.Filter("alias"); len(a.Items) > 0 {
@@ -381,6 +439,7 @@ func loadProvidersHcl(list *ast.ObjectList) ([]*ProviderConfig, error) {
 		result = append(result, &ProviderConfig{
 			Name:      n,
 			Alias:     alias,
+			Version:   version,
 			RawConfig: rawConfig,
 		})
 	}
@@ -597,8 +656,24 @@ func loadProvisionersHcl(list *ast.ObjectList, connInfo map[string]interface{})
 			return nil, err
 		}
 
+		// Parse the "when" value, which defaults to running the
+		// provisioner when the resource is created
+		when := ProvisionerWhenCreate
+		if v, ok := config["when"]; ok {
+			switch v {
+			case "create":
+				when = ProvisionerWhenCreate
+			case "destroy":
+				when = ProvisionerWhenDestroy
+			default:
+				return nil, fmt.Errorf(
+					"provisioner '%s': 'when' must be 'create' or 'destroy', got: %v", n, v)
+			}
+		}
+
 		// Delete the "connection" section, handle separately
 		delete(config, "connection")
+		delete(config, "when")
 
 		rawConfig, err := NewRawConfig(config)
 		if err != nil {
@@ -637,6 +712,7 @@ func loadProvisionersHcl(list *ast.ObjectList, connInfo map[string]interface{})
 			Type:      n,
 			RawConfig: rawConfig,
 			ConnInfo:  connRaw,
+			When:      when,
 		})
 	}
 
@@ -280,6 +280,38 @@ func TestLoadFileBasic_modules(t *testing.T) {
 	}
 }
 
+func TestLoadFile_modulesDependsOn(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "modules-depends-on.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	actual := modulesStr(c.Modules)
+	if actual != strings.TrimSpace(modulesDependsOnModulesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestLoadFile_modulesProviders(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "modules-providers.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	actual := modulesStr(c.Modules)
+	if actual != strings.TrimSpace(modulesProvidersModulesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
 func TestLoadJSONBasic(t *testing.T) {
 	raw, err := ioutil.ReadFile(filepath.Join(fixtureDir, "basic.tf.json"))
 	if err != nil {
@@ -344,6 +376,73 @@ func TestLoadFile_variables(t *testing.T) {
 	}
 }
 
+func TestLoadFile_variableTypes(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "variables-typed.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]VariableType{
+		"list":     VariableTypeList,
+		"map":      VariableTypeMap,
+		"string":   VariableTypeString,
+		"inferred": VariableTypeList,
+	}
+	for _, v := range c.Variables {
+		if v.Type() != expected[v.Name] {
+			t.Fatalf("%s: bad type: %s", v.Name, v.Type().Printable())
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestLoadFile_outputSensitive(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "output-sensitive.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]bool{
+		"password": true,
+		"zones":    false,
+	}
+	for _, o := range c.Outputs {
+		if o.Sensitive != expected[o.Name] {
+			t.Fatalf("%s: bad sensitive: %t", o.Name, o.Sensitive)
+		}
+		if _, ok := o.RawConfig.Raw["sensitive"]; ok {
+			t.Fatalf("%s: sensitive should not be in the raw config", o.Name)
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestLoadFile_providerVersion(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "provider-version.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"aws": "~> 1.2",
+		"do":  "",
+	}
+	for _, pc := range c.ProviderConfigs {
+		if pc.Version != expected[pc.Name] {
+			t.Fatalf("%s: bad version: %q", pc.Name, pc.Version)
+		}
+		if _, ok := pc.RawConfig.Raw["version"]; ok {
+			t.Fatalf("%s: version should not be in the raw config", pc.Name)
+		}
+	}
+}
+
 func TestLoadDir_basic(t *testing.T) {
 	dir := filepath.Join(fixtureDir, "dir-basic")
 	c, err := LoadDir(dir)
@@ -460,6 +559,35 @@ func TestLoadFile_provisioners(t *testing.T) {
 	}
 }
 
+func TestLoadFile_provisionersDestroy(t *testing.T) {
+	c, err := LoadFile(filepath.Join(fixtureDir, "provisioners-destroy.tf"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if c == nil {
+		t.Fatal("config should not be nil")
+	}
+
+	actual := resourcesStr(c.Resources)
+	if actual != strings.TrimSpace(provisionerDestroyResourcesStr) {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	// The "when" key is not part of the provisioner configuration
+	p := c.Resources[0].Provisioners[1]
+	if _, ok := p.RawConfig.Raw["when"]; ok {
+		t.Fatalf("bad: %#v", p.RawConfig.Raw)
+	}
+}
+
+func TestLoadFile_provisionersWhenBad(t *testing.T) {
+	_, err := LoadFile(filepath.Join(fixtureDir, "provisioners-when-bad.tf"))
+	if err == nil {
+		t.Fatal("should have error")
+	}
+}
+
 func TestLoadFile_connections(t *testing.T) {
 	c, err := LoadFile(filepath.Join(fixtureDir, "connection.tf"))
 	if err != nil {
@@ -907,6 +1035,23 @@ bar
   memory
 `
 
+const modulesDependsOnModulesStr = `
+bar
+  source = baz
+  dependsOn
+    module.foo
+  memory
+`
+
+const modulesProvidersModulesStr = `
+bar
+  source = baz
+  providers
+    aws = aws.east
+    aws.west = aws.eu_west_1
+  memory
+`
+
 const provisionerResourcesStr = `
 aws_instance[web] (x1)
   ami
@@ -919,6 +1064,16 @@ aws_instance[web] (x1)
     user: var.foo
 `
 
+const provisionerDestroyResourcesStr = `
+aws_instance[web] (x1)
+  provisioners
+    shell
+      path
+    shell
+      when = destroy
+      path
+`
+
 const connectionResourcesStr = `
 aws_instance[web] (x1)
   ami
@@ -26,6 +26,15 @@ const (
 	GetModeUpdate
 )
 
+func init() {
+	// Register the getters that extend or replace the ones go-getter
+	// ships with. The git getter replaces the default one so modules
+	// can be fetched with a shallow clone.
+	getter.Getters["git"] = new(GitGetter)
+	getter.Getters["gcs"] = new(GCSGetter)
+	getter.Getters["s3"] = new(S3Getter)
+}
+
 // GetCopy is the same as Get except that it downloads a copy of the
 // module represented by source.
 //
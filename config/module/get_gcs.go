@@ -0,0 +1,161 @@
+package module
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/storage/v1"
+)
+
+// GCSGetter is a getter.Getter implementation that fetches modules from
+// a Google Cloud Storage bucket.
+//
+// URLs have the form https://www.googleapis.com/storage/v1/bucket/path.
+//
+// Credentials are looked up using the Application Default Credentials:
+// the file in GOOGLE_APPLICATION_CREDENTIALS, the gcloud SDK credentials
+// and the credentials of the GCE instance.
+type GCSGetter struct{}
+
+// Get downloads all objects below the path in the URL into dst.
+func (g *GCSGetter) Get(dst string, u *url.URL) error {
+	bucket, path, err := parseGCSURL(u)
+	if err != nil {
+		return err
+	}
+
+	// The path is a prefix for the objects that make up the module
+	prefix := strings.TrimSuffix(path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	svc, err := gcsService()
+	if err != nil {
+		return err
+	}
+
+	// Start from scratch, since objects could have been removed
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	var names []string
+	token := ""
+	for {
+		call := svc.Objects.List(bucket).Prefix(prefix)
+		if token != "" {
+			call = call.PageToken(token)
+		}
+
+		res, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("Error listing GCS objects in %s/%s: %s",
+				bucket, prefix, err)
+		}
+
+		for _, o := range res.Items {
+			// Skip the placeholder objects some tools create for
+			// directories.
+			if strings.HasSuffix(o.Name, "/") {
+				continue
+			}
+			names = append(names, o.Name)
+		}
+
+		token = res.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("No GCS objects found in %s/%s", bucket, prefix)
+	}
+
+	for _, name := range names {
+		path := filepath.Join(dst, filepath.FromSlash(name[len(prefix):]))
+		if err := gcsGetObject(svc, bucket, name, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetFile downloads the single object in the URL to dst.
+func (g *GCSGetter) GetFile(dst string, u *url.URL) error {
+	bucket, path, err := parseGCSURL(u)
+	if err != nil {
+		return err
+	}
+
+	svc, err := gcsService()
+	if err != nil {
+		return err
+	}
+
+	return gcsGetObject(svc, bucket, path, dst)
+}
+
+func gcsService() (*storage.Service, error) {
+	client, err := google.DefaultClient(
+		oauth2.NoContext, storage.DevstorageReadOnlyScope)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Google credentials: %s", err)
+	}
+
+	return storage.New(client)
+}
+
+func gcsGetObject(svc *storage.Service, bucket, name, dst string) error {
+	resp, err := svc.Objects.Get(bucket, name).Download()
+	if err != nil {
+		return fmt.Errorf("Error getting GCS object %s/%s: %s",
+			bucket, name, err)
+	}
+	defer resp.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+// parseGCSURL parses the bucket and object path out of a GCS URL.
+func parseGCSURL(u *url.URL) (string, string, error) {
+	const apiPrefix = "/storage/v1/"
+
+	if !strings.HasPrefix(u.Path, apiPrefix) {
+		return "", "", fmt.Errorf(
+			"Invalid GCS URL, expected a path starting with %s: %s", apiPrefix, u)
+	}
+
+	parts := strings.SplitN(u.Path[len(apiPrefix):], "/", 2)
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("Invalid GCS URL, no bucket: %s", u)
+	}
+
+	path := ""
+	if len(parts) == 2 {
+		path = parts[1]
+	}
+
+	return parts[0], path, nil
+}
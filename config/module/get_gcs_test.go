@@ -0,0 +1,67 @@
+package module
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+)
+
+func TestGCSGetter_impl(t *testing.T) {
+	var _ getter.Getter = new(GCSGetter)
+}
+
+func TestParseGCSURL(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Bucket string
+		Path   string
+		Err    bool
+	}{
+		{
+			"https://www.googleapis.com/storage/v1/bucket/modules/vpc",
+			"bucket",
+			"modules/vpc",
+			false,
+		},
+		{
+			"https://www.googleapis.com/storage/v1/bucket/vpc.tar.gz",
+			"bucket",
+			"vpc.tar.gz",
+			false,
+		},
+		{
+			"https://www.googleapis.com/storage/v1/bucket",
+			"bucket",
+			"",
+			false,
+		},
+		{
+			"https://www.googleapis.com/storage/v1/",
+			"",
+			"",
+			true,
+		},
+		{
+			"https://www.googleapis.com/bucket/vpc",
+			"",
+			"",
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		u, err := url.Parse(tc.Input)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		bucket, path, err := parseGCSURL(u)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: err: %s", tc.Input, err)
+		}
+		if bucket != tc.Bucket || path != tc.Path {
+			t.Fatalf("%s: bad: %s, %s", tc.Input, bucket, path)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package module
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GitGetter is a getter.Getter implementation that fetches modules from
+// a git repository.
+//
+// The URL supports the following query parameters:
+//
+//   - ref - The branch, tag or commit to check out. Defaults to the
+//     default branch of the repository.
+//
+//   - depth - Create a shallow clone with a history truncated to the
+//     given number of commits. When used together with ref, the ref must
+//     be a branch or a tag.
+//
+// Subdirectories ("//subdir") are handled by go-getter before this getter
+// is called, so the URL never contains them.
+type GitGetter struct{}
+
+// Get clones the repository at u into dst, or updates the existing clone
+// if dst already exists.
+func (g *GitGetter) Get(dst string, u *url.URL) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git must be available and on the PATH")
+	}
+
+	u, ref, depth, err := gitURLParams(u)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(dst)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		return g.update(dst, ref, depth)
+	}
+
+	return g.clone(dst, u, ref, depth)
+}
+
+// GetFile is not supported for git sources, since modules are always
+// directories.
+func (g *GitGetter) GetFile(dst string, u *url.URL) error {
+	return fmt.Errorf("Getting a single file is not supported for git sources")
+}
+
+func (g *GitGetter) clone(dst string, u *url.URL, ref string, depth int) error {
+	args := []string{"clone"}
+	if depth > 0 {
+		// A shallow clone can only be created from a named ref, so
+		// the ref is passed along instead of checked out afterwards.
+		args = append(args, "--depth", strconv.Itoa(depth))
+		if ref != "" {
+			args = append(args, "--branch", ref)
+		}
+	}
+	args = append(args, u.String(), dst)
+
+	if err := runGit("", args...); err != nil {
+		return err
+	}
+
+	if ref == "" || depth > 0 {
+		return nil
+	}
+
+	return runGit(dst, "checkout", ref)
+}
+
+func (g *GitGetter) update(dst, ref string, depth int) error {
+	if depth > 0 {
+		// A shallow clone only has the history it needs, so fetch the
+		// ref directly and check out whatever was fetched.
+		if ref == "" {
+			ref = "HEAD"
+		}
+		if err := runGit(dst, "fetch", "--depth", strconv.Itoa(depth), "origin", ref); err != nil {
+			return err
+		}
+
+		return runGit(dst, "checkout", "--force", "FETCH_HEAD")
+	}
+
+	if err := runGit(dst, "fetch", "--tags", "origin"); err != nil {
+		return err
+	}
+	if ref == "" {
+		var err error
+		ref, err = gitDefaultBranch(dst)
+		if err != nil {
+			return err
+		}
+	}
+	if err := runGit(dst, "checkout", ref); err != nil {
+		return err
+	}
+
+	// Only a branch can be fast-forwarded, tags and commits are fixed
+	if err := runGit(dst, "symbolic-ref", "-q", "HEAD"); err != nil {
+		return nil
+	}
+
+	return runGit(dst, "pull", "--ff-only")
+}
+
+// gitURLParams removes the query parameters used by the GitGetter from
+// the URL, since git itself doesn't understand them, and returns their
+// values together with the cleaned up URL.
+func gitURLParams(u *url.URL) (*url.URL, string, int, error) {
+	q := u.Query()
+
+	ref := q.Get("ref")
+	q.Del("ref")
+
+	depth := 0
+	if v := q.Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, "", 0, fmt.Errorf(
+				"Invalid depth %q: must be a positive number", v)
+		}
+		depth = n
+	}
+	q.Del("depth")
+
+	// Copy the URL so we don't modify the one we were given
+	var newU url.URL = *u
+	newU.RawQuery = q.Encode()
+
+	return &newU, ref, depth, nil
+}
+
+// gitDefaultBranch returns the name of the default branch of the origin
+// remote of the clone in dir. The remote is asked again, since its
+// default branch may have changed after the clone was made.
+func gitDefaultBranch(dir string) (string, error) {
+	if err := runGit(dir, "remote", "set-head", "origin", "--auto"); err != nil {
+		return "", err
+	}
+
+	out, err := gitOutput(
+		dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(out), "origin/"), nil
+}
+
+func runGit(dir string, args ...string) error {
+	_, err := gitOutput(dir, args...)
+	return err
+}
+
+// gitOutput runs git in dir and returns what it wrote to stdout.
+func gitOutput(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("Error running git %s: %s\n\n%s%s",
+			args[0], err, stdout.String(), stderr.String())
+	}
+
+	return stdout.String(), nil
+}
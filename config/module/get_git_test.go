@@ -0,0 +1,233 @@
+package module
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+)
+
+func TestGitGetter_impl(t *testing.T) {
+	var _ getter.Getter = new(GitGetter)
+}
+
+func TestGitURLParams(t *testing.T) {
+	cases := []struct {
+		Input string
+		URL   string
+		Ref   string
+		Depth int
+		Err   bool
+	}{
+		{
+			"https://example.com/foo.git",
+			"https://example.com/foo.git",
+			"",
+			0,
+			false,
+		},
+		{
+			"https://example.com/foo.git?ref=v1.0.0",
+			"https://example.com/foo.git",
+			"v1.0.0",
+			0,
+			false,
+		},
+		{
+			"ssh://git@example.com/foo.git?ref=master&depth=1",
+			"ssh://git@example.com/foo.git",
+			"master",
+			1,
+			false,
+		},
+		{
+			"https://example.com/foo.git?depth=5&foo=bar",
+			"https://example.com/foo.git?foo=bar",
+			"",
+			5,
+			false,
+		},
+		{
+			"https://example.com/foo.git?depth=0",
+			"",
+			"",
+			0,
+			true,
+		},
+		{
+			"https://example.com/foo.git?depth=all",
+			"",
+			"",
+			0,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		u, err := url.Parse(tc.Input)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual, ref, depth, err := gitURLParams(u)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: err: %s", tc.Input, err)
+		}
+		if tc.Err {
+			continue
+		}
+
+		if actual.String() != tc.URL {
+			t.Fatalf("%s: bad URL: %s", tc.Input, actual)
+		}
+		if ref != tc.Ref {
+			t.Fatalf("%s: bad ref: %s", tc.Input, ref)
+		}
+		if depth != tc.Depth {
+			t.Fatalf("%s: bad depth: %d", tc.Input, depth)
+		}
+		if u.String() != tc.Input {
+			t.Fatalf("%s: input URL was modified: %s", tc.Input, u)
+		}
+	}
+}
+
+func TestGitGetter(t *testing.T) {
+	repo := testGitRepo(t)
+	defer os.RemoveAll(repo)
+
+	cases := map[string]struct {
+		Query   string
+		Content string
+		Commits int
+	}{
+		"default branch": {"", "v2", 2},
+		"tag":            {"?ref=v1", "v1", 1},
+		"shallow":        {"?depth=1", "v2", 1},
+		"shallow tag":    {"?ref=v1&depth=1", "v1", 1},
+	}
+
+	for name, tc := range cases {
+		dst := tempDir(t)
+		defer os.RemoveAll(dst)
+
+		u, err := url.Parse("file://" + repo + tc.Query)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		g := new(GitGetter)
+		if err := g.Get(dst, u); err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		testGitContent(t, name, dst, tc.Content, tc.Commits)
+
+		// Getting again updates the existing clone
+		if err := g.Get(dst, u); err != nil {
+			t.Fatalf("%s: update err: %s", name, err)
+		}
+
+		testGitContent(t, name, dst, tc.Content, tc.Commits)
+	}
+}
+
+func TestGitGetter_updateDefaultBranch(t *testing.T) {
+	repo := testGitRepo(t)
+	defer os.RemoveAll(repo)
+
+	// The default branch isn't always called master
+	testGitRun(t, repo, "branch", "-m", "master", "main")
+
+	dst := tempDir(t)
+	defer os.RemoveAll(dst)
+
+	u, err := url.Parse("file://" + repo)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	g := new(GitGetter)
+	if err := g.Get(dst, u); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testGitContent(t, "clone", dst, "v2", 2)
+
+	path := filepath.Join(repo, "main.tf")
+	if err := ioutil.WriteFile(path, []byte("v3"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testGitRun(t, repo, "commit", "-am", "v3")
+
+	if err := g.Get(dst, u); err != nil {
+		t.Fatalf("update err: %s", err)
+	}
+	testGitContent(t, "update", dst, "v3", 3)
+}
+
+// testGitRepo creates a repository with two commits, where the first
+// one is tagged "v1". A full clone of the tag therefore has one commit,
+// just like a shallow clone.
+func testGitRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found, skipping")
+	}
+
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	commit := func(content string) {
+		path := filepath.Join(dir, "main.tf")
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		testGitRun(t, dir, "add", "main.tf")
+		testGitRun(t, dir, "commit", "-m", content)
+	}
+
+	testGitRun(t, dir, "init")
+	testGitRun(t, dir, "config", "user.name", "test")
+	testGitRun(t, dir, "config", "user.email", "test@example.com")
+	testGitRun(t, dir, "checkout", "-b", "master")
+	commit("v1")
+	testGitRun(t, dir, "tag", "v1")
+	commit("v2")
+
+	return dir
+}
+
+func testGitContent(t *testing.T, name, dir, content string, commits int) {
+	actual, err := ioutil.ReadFile(filepath.Join(dir, "main.tf"))
+	if err != nil {
+		t.Fatalf("%s: err: %s", name, err)
+	}
+	if string(actual) != content {
+		t.Fatalf("%s: bad content: %s", name, actual)
+	}
+
+	out, err := exec.Command(
+		"git", "-C", dir, "rev-list", "--count", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("%s: err: %s", name, err)
+	}
+	if n := strings.TrimSpace(string(out)); n != strconv.Itoa(commits) {
+		t.Fatalf("%s: bad number of commits: %s", name, n)
+	}
+}
+
+func testGitRun(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %s\n\n%s", args[0], err, out)
+	}
+}
@@ -0,0 +1,200 @@
+package module
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// S3Getter is a getter.Getter implementation that fetches modules from
+// an S3 bucket.
+//
+// Both path style (https://s3-eu-west-1.amazonaws.com/bucket/path) and
+// virtual hosted style (https://bucket.s3.amazonaws.com/path) URLs are
+// supported. Any other host is used as a custom endpoint with a path
+// style URL. The region can be overridden with the "region" parameter.
+//
+// Credentials are looked up using the default AWS credential chain: the
+// environment, the shared credentials file and the EC2 instance role.
+type S3Getter struct{}
+
+// Get downloads all objects below the path in the URL into dst.
+func (g *S3Getter) Get(dst string, u *url.URL) error {
+	loc, err := parseS3URL(u)
+	if err != nil {
+		return err
+	}
+
+	// The path is a prefix for the objects that make up the module
+	prefix := strings.TrimSuffix(loc.Key, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	// Start from scratch, since objects could have been removed
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	conn := loc.client()
+
+	var keys []string
+	err = conn.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(loc.Bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, o := range page.Contents {
+			// Skip the placeholder objects some tools create for
+			// directories.
+			if strings.HasSuffix(*o.Key, "/") {
+				continue
+			}
+			keys = append(keys, *o.Key)
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing S3 objects in %s/%s: %s",
+			loc.Bucket, prefix, err)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("No S3 objects found in %s/%s", loc.Bucket, prefix)
+	}
+
+	for _, key := range keys {
+		path := filepath.Join(dst, filepath.FromSlash(key[len(prefix):]))
+		if err := loc.getObject(conn, key, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetFile downloads the single object in the URL to dst.
+func (g *S3Getter) GetFile(dst string, u *url.URL) error {
+	loc, err := parseS3URL(u)
+	if err != nil {
+		return err
+	}
+
+	return loc.getObject(loc.client(), loc.Key, dst)
+}
+
+// s3Location is the location of an S3 object or prefix, as parsed from
+// a module source URL.
+type s3Location struct {
+	Endpoint string
+	Region   string
+	Bucket   string
+	Key      string
+}
+
+func (l *s3Location) client() *s3.S3 {
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvProvider{},
+		&credentials.SharedCredentialsProvider{Filename: "", Profile: ""},
+		&ec2rolecreds.EC2RoleProvider{Client: ec2metadata.New(session.New())},
+	})
+
+	awsConfig := &aws.Config{
+		Credentials: creds,
+		Region:      aws.String(l.Region),
+		HTTPClient:  cleanhttp.DefaultClient(),
+	}
+	if l.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(l.Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	return s3.New(session.New(awsConfig))
+}
+
+func (l *s3Location) getObject(conn *s3.S3, key, dst string) error {
+	resp, err := conn.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(l.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting S3 object %s/%s: %s",
+			l.Bucket, key, err)
+	}
+	defer resp.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+// parseS3URL parses the bucket, key and region out of an S3 URL.
+func parseS3URL(u *url.URL) (*s3Location, error) {
+	loc := &s3Location{Region: "us-east-1"}
+	path := strings.TrimPrefix(u.Path, "/")
+
+	host := u.Host
+	if strings.HasSuffix(host, ".amazonaws.com") {
+		host = strings.TrimSuffix(host, ".amazonaws.com")
+
+		// Virtual hosted style URLs have the bucket in the host name
+		if i := strings.LastIndex(host, ".s3"); i > 0 {
+			loc.Bucket = host[:i]
+			host = host[i+1:]
+		}
+
+		// What's left is "s3", "s3-<region>" or "s3.<region>"
+		switch {
+		case host == "s3" || host == "s3-external-1":
+		case strings.HasPrefix(host, "s3-"):
+			loc.Region = host[len("s3-"):]
+		case strings.HasPrefix(host, "s3."):
+			loc.Region = host[len("s3."):]
+		default:
+			return nil, fmt.Errorf("Invalid S3 host: %s", u.Host)
+		}
+	} else {
+		loc.Endpoint = fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	}
+
+	if loc.Bucket == "" {
+		parts := strings.SplitN(path, "/", 2)
+		loc.Bucket = parts[0]
+		path = ""
+		if len(parts) == 2 {
+			path = parts[1]
+		}
+	}
+	loc.Key = path
+
+	if v := u.Query().Get("region"); v != "" {
+		loc.Region = v
+	}
+
+	if loc.Bucket == "" {
+		return nil, fmt.Errorf("Invalid S3 URL, no bucket: %s", u)
+	}
+
+	return loc, nil
+}
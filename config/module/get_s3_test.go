@@ -0,0 +1,102 @@
+package module
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+)
+
+func TestS3Getter_impl(t *testing.T) {
+	var _ getter.Getter = new(S3Getter)
+}
+
+func TestParseS3URL(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output *s3Location
+		Err    bool
+	}{
+		{
+			"https://s3.amazonaws.com/bucket/modules/vpc",
+			&s3Location{
+				Region: "us-east-1",
+				Bucket: "bucket",
+				Key:    "modules/vpc",
+			},
+			false,
+		},
+		{
+			"https://s3-eu-west-1.amazonaws.com/bucket/vpc.zip",
+			&s3Location{
+				Region: "eu-west-1",
+				Bucket: "bucket",
+				Key:    "vpc.zip",
+			},
+			false,
+		},
+		{
+			"https://s3.eu-central-1.amazonaws.com/bucket/vpc",
+			&s3Location{
+				Region: "eu-central-1",
+				Bucket: "bucket",
+				Key:    "vpc",
+			},
+			false,
+		},
+		{
+			"https://my.bucket.s3-us-west-2.amazonaws.com/modules/vpc",
+			&s3Location{
+				Region: "us-west-2",
+				Bucket: "my.bucket",
+				Key:    "modules/vpc",
+			},
+			false,
+		},
+		{
+			"https://s3.amazonaws.com/bucket/vpc?region=ap-southeast-1",
+			&s3Location{
+				Region: "ap-southeast-1",
+				Bucket: "bucket",
+				Key:    "vpc",
+			},
+			false,
+		},
+		{
+			"http://127.0.0.1:9000/bucket/vpc",
+			&s3Location{
+				Endpoint: "http://127.0.0.1:9000",
+				Region:   "us-east-1",
+				Bucket:   "bucket",
+				Key:      "vpc",
+			},
+			false,
+		},
+		{
+			"https://ec2.amazonaws.com/bucket/vpc",
+			nil,
+			true,
+		},
+		{
+			"https://s3.amazonaws.com/",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		u, err := url.Parse(tc.Input)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		actual, err := parseS3URL(u)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: err: %s", tc.Input, err)
+		}
+		if !reflect.DeepEqual(actual, tc.Output) {
+			t.Fatalf("%s: bad: %#v", tc.Input, actual)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module "bar" {
+    memory = "1G"
+    source = "baz"
+    depends_on = ["module.foo"]
+}
@@ -0,0 +1,9 @@
+module "bar" {
+    memory = "1G"
+    source = "baz"
+
+    providers {
+        aws = "aws.east"
+        "aws.west" = "aws.eu_west_1"
+    }
+}
@@ -0,0 +1,10 @@
+variable "password" {}
+
+output "password" {
+  value = "${var.password}"
+  sensitive = true
+}
+
+output "zones" {
+  value = ["a", "b"]
+}
@@ -0,0 +1,8 @@
+provider "aws" {
+    version = "~> 1.2"
+    region = "us-east-1"
+}
+
+provider "do" {
+    api_key = "foo"
+}
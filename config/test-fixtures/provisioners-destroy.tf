@@ -0,0 +1,10 @@
+resource "aws_instance" "web" {
+    provisioner "shell" {
+        path = "foo"
+    }
+
+    provisioner "shell" {
+        path = "bar"
+        when = "destroy"
+    }
+}
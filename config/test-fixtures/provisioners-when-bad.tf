@@ -0,0 +1,6 @@
+resource "aws_instance" "web" {
+    provisioner "shell" {
+        path = "foo"
+        when = "update"
+    }
+}
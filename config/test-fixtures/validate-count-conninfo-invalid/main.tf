@@ -0,0 +1,11 @@
+resource "aws_instance" "foo" {
+    count = 2
+
+    connection {
+        host = "${count.foo}"
+    }
+
+    provisioner "shell" {
+        value = "${self.private_ip}"
+    }
+}
@@ -0,0 +1,12 @@
+resource "aws_instance" "foo" {
+    count = 2
+
+    connection {
+        host = "${self.private_ip}"
+        port = "${2200 + count.index}"
+    }
+
+    provisioner "shell" {
+        value = "${count.index}"
+    }
+}
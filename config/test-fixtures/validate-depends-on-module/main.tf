@@ -0,0 +1,16 @@
+provider "aws" {
+    alias = "west"
+}
+
+module "vpc" {
+    source = "./vpc"
+}
+
+module "app" {
+    source = "./app"
+    depends_on = ["module.vpc", "provider.aws.west"]
+}
+
+resource "aws_instance" "web" {
+    depends_on = ["module.app"]
+}
@@ -0,0 +1,4 @@
+module "vpc" {
+    source = "./vpc"
+    depends_on = ["module.vpc"]
+}
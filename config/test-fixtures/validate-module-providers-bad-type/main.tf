@@ -0,0 +1,11 @@
+provider "google" {
+    alias = "east"
+}
+
+module "foo" {
+    source = "./foo"
+
+    providers {
+        aws = "google.east"
+    }
+}
@@ -0,0 +1,12 @@
+provider "aws" {
+    alias = "east"
+}
+
+module "foo" {
+    source = "./foo"
+
+    providers {
+        aws = "aws.east"
+        "aws.west" = "aws"
+    }
+}
@@ -0,0 +1,4 @@
+variable "foo" {
+  type = "bool"
+  default = "1"
+}
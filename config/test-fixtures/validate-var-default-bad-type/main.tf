@@ -1,3 +1,4 @@
 variable "foo" {
+  type = "string"
   default = ["foo", "bar"]
 }
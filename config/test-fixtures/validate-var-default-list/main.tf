@@ -0,0 +1,12 @@
+variable "foo" {
+  type = "list"
+  default = ["foo", "bar"]
+}
+
+variable "bar" {
+  default = ["baz"]
+}
+
+variable "empty" {
+  type = "list"
+}
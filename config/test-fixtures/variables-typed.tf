@@ -0,0 +1,19 @@
+variable "list" {
+  type = "list"
+  default = ["foo", "bar"]
+}
+
+variable "map" {
+  type = "map"
+  default = {
+    foo = "bar"
+  }
+}
+
+variable "string" {
+  type = "string"
+}
+
+variable "inferred" {
+  default = ["baz"]
+}
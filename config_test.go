@@ -4,6 +4,8 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/xanzy/terraform-api/plugin/discovery"
 )
 
 // This is the directory where our test fixtures are.
@@ -66,3 +68,27 @@ func TestConfig_Merge(t *testing.T) {
 		t.Fatalf("bad: %#v", actual)
 	}
 }
+
+func TestConfig_Merge_providerPlugins(t *testing.T) {
+	c1 := &Config{
+		providerPlugins: discovery.PluginMetaSet{
+			{Name: "aws", Path: "/bin/terraform-provider-aws"},
+			{Name: "do", Path: "/bin/terraform-provider-do"},
+		},
+	}
+
+	c2 := &Config{
+		Providers: map[string]string{
+			"aws": "/custom/terraform-provider-aws",
+		},
+	}
+
+	expected := discovery.PluginMetaSet{
+		{Name: "do", Path: "/bin/terraform-provider-do"},
+	}
+
+	actual := c1.Merge(c2).ProviderPlugins()
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
@@ -0,0 +1,62 @@
+package acctest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Registry of name prefixes used by acceptance tests, along with the
+// random names that were handed out for them. This allows tests that
+// run in parallel to create resources without their names colliding.
+var (
+	prefixes   = make(map[string]struct{})
+	names      = make(map[string]struct{})
+	prefixesMu sync.Mutex
+)
+
+// RegisterPrefix registers a name prefix for the resources created by a
+// set of acceptance tests and returns it, so it can be assigned to a
+// package level variable.
+//
+// It panics if the prefix is empty, or if it is already registered or
+// overlaps with another registered prefix, since names generated for the
+// two sets of tests could then collide.
+func RegisterPrefix(prefix string) string {
+	prefixesMu.Lock()
+	defer prefixesMu.Unlock()
+
+	if prefix == "" {
+		panic("acctest: prefix can't be empty")
+	}
+
+	for p := range prefixes {
+		if strings.HasPrefix(p, prefix) || strings.HasPrefix(prefix, p) {
+			panic(fmt.Sprintf(
+				"acctest: prefix %q overlaps with registered prefix %q", prefix, p))
+		}
+	}
+
+	prefixes[prefix] = struct{}{}
+	return prefix
+}
+
+// RandomWithPrefix returns a random name starting with the given prefix.
+// The prefix must be registered with RegisterPrefix first. Names are never
+// handed out twice within the same test binary.
+func RandomWithPrefix(prefix string) string {
+	prefixesMu.Lock()
+	defer prefixesMu.Unlock()
+
+	if _, ok := prefixes[prefix]; !ok {
+		panic(fmt.Sprintf("acctest: prefix %q is not registered", prefix))
+	}
+
+	for {
+		name := fmt.Sprintf("%s-%s", prefix, RandStringFromCharSet(10, CharSetAlphaNum))
+		if _, ok := names[name]; !ok {
+			names[name] = struct{}{}
+			return name
+		}
+	}
+}
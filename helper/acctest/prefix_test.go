@@ -0,0 +1,58 @@
+package acctest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterPrefix(t *testing.T) {
+	p := RegisterPrefix("tf-test-register")
+	if p != "tf-test-register" {
+		t.Fatalf("bad: %s", p)
+	}
+
+	cases := []string{
+		"",
+		"tf-test-register",
+		"tf-test-register-foo",
+		"tf-test",
+	}
+
+	for _, tc := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%q: should panic", tc)
+				}
+			}()
+
+			RegisterPrefix(tc)
+		}()
+	}
+}
+
+func TestRandomWithPrefix(t *testing.T) {
+	p := RegisterPrefix("tf-random")
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		name := RandomWithPrefix(p)
+		if !strings.HasPrefix(name, p+"-") {
+			t.Fatalf("bad: %s", name)
+		}
+		if _, ok := seen[name]; ok {
+			t.Fatalf("duplicate name: %s", name)
+		}
+		seen[name] = struct{}{}
+	}
+}
+
+func TestRandomWithPrefix_unregistered(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("should panic")
+		}
+	}()
+
+	RandomWithPrefix("tf-unregistered")
+}
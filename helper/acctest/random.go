@@ -2,16 +2,26 @@ package acctest
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 )
 
 // Helpers for generating random tidbits for use in identifiers to prevent
 // collisions in acceptance tests.
 
+var (
+	// rnd is shared by all helpers and seeded once, so tests running in
+	// parallel don't end up with the same values by seeding from the
+	// same timestamp.
+	rnd   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rndMu sync.Mutex
+)
+
 // RandInt generates a random integer
 func RandInt() int {
-	reseed()
-	return rand.New(rand.NewSource(time.Now().UnixNano())).Int()
+	rndMu.Lock()
+	defer rndMu.Unlock()
+	return rnd.Int()
 }
 
 // RandString generates a random alphanumeric string of the length specified
@@ -22,19 +32,15 @@ func RandString(strlen int) string {
 // RandStringFromCharSet generates a random string by selecting characters from
 // the charset provided
 func RandStringFromCharSet(strlen int, charSet string) string {
-	reseed()
+	rndMu.Lock()
+	defer rndMu.Unlock()
 	result := make([]byte, strlen)
 	for i := 0; i < strlen; i++ {
-		result[i] = charSet[rand.Intn(len(charSet))]
+		result[i] = charSet[rnd.Intn(len(charSet))]
 	}
 	return string(result)
 }
 
-// Seeds random with current timestamp
-func reseed() {
-	rand.Seed(time.Now().UTC().UnixNano())
-}
-
 const (
 	// CharSetAlphaNum is the alphanumeric character set for use with
 	// RandStringFromCharSet
@@ -8,6 +8,10 @@ import (
 	"time"
 )
 
+// ErrCancelled is returned when waiting for a state change was cancelled,
+// usually because Terraform was asked to stop.
+var ErrCancelled = errors.New("cancelled while waiting for state change")
+
 // StateRefreshFunc is a function type used for StateChangeConf that is
 // responsible for refreshing the item being watched for a state change.
 //
@@ -29,6 +33,7 @@ type StateChangeConf struct {
 	Timeout        time.Duration    // The amount of time to wait before timeout
 	MinTimeout     time.Duration    // Smallest time to wait before refreshes
 	NotFoundChecks int              // Number of times to allow not found
+	Cancel         <-chan struct{}  // Stop waiting when this is closed
 }
 
 // WaitForState watches an object and waits for it to achieve the state
@@ -41,7 +46,7 @@ type StateChangeConf struct {
 // listed in Pending, return immediately with an error.
 //
 // If the Timeout is exceeded before reaching the Target state, return an
-// error.
+// error. If the Cancel channel is closed before that, return ErrCancelled.
 //
 // Otherwise, result the result of the first call to the Refresh function to
 // reach the target state.
@@ -58,12 +63,32 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 	var result interface{}
 	var resulterr error
 
+	// The refresh loop stops once we return, so it doesn't keep calling
+	// Refresh after a timeout or after being cancelled.
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// sleep waits for the given duration and returns false if the refresh
+	// loop should stop instead.
+	sleep := func(d time.Duration) bool {
+		select {
+		case <-time.After(d):
+			return true
+		case <-stopCh:
+			return false
+		case <-conf.Cancel:
+			return false
+		}
+	}
+
 	doneCh := make(chan struct{})
 	go func() {
 		defer close(doneCh)
 
 		// Wait for the delay
-		time.Sleep(conf.Delay)
+		if !sleep(conf.Delay) {
+			return
+		}
 
 		var err error
 		for tries := 0; ; tries++ {
@@ -77,7 +102,9 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 			}
 
 			log.Printf("[TRACE] Waiting %s before next try", wait)
-			time.Sleep(wait)
+			if !sleep(wait) {
+				return
+			}
 
 			var currentState string
 			result, currentState, err = conf.Refresh()
@@ -133,5 +160,8 @@ func (conf *StateChangeConf) WaitForState() (interface{}, error) {
 		return nil, fmt.Errorf(
 			"timeout while waiting for state to become '%s'",
 			conf.Target)
+	case <-conf.Cancel:
+		log.Printf("[DEBUG] Cancelled waiting for state to become: %s", conf.Target)
+		return nil, ErrCancelled
 	}
 }
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -45,6 +46,56 @@ func TestWaitForState_timeout(t *testing.T) {
 
 }
 
+func TestWaitForState_timeoutStopsRefresh(t *testing.T) {
+	var refreshes int32
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  "running",
+		Refresh: func() (interface{}, string, error) {
+			atomic.AddInt32(&refreshes, 1)
+			return struct{}{}, "pending", nil
+		},
+		Timeout: 150 * time.Millisecond,
+	}
+
+	if _, err := conf.WaitForState(); err == nil {
+		t.Fatal("should error")
+	}
+
+	// Without the timeout, the next refresh would be after 300ms
+	n := atomic.LoadInt32(&refreshes)
+	time.Sleep(500 * time.Millisecond)
+	if actual := atomic.LoadInt32(&refreshes); actual != n {
+		t.Fatalf("refreshed after timeout: %d != %d", actual, n)
+	}
+}
+
+func TestWaitForState_cancel(t *testing.T) {
+	var refreshes int32
+	cancel := make(chan struct{})
+	conf := &StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  "running",
+		Refresh: func() (interface{}, string, error) {
+			if atomic.AddInt32(&refreshes, 1) == 1 {
+				close(cancel)
+			}
+			return struct{}{}, "pending", nil
+		},
+		Timeout: 1 * time.Minute,
+		Cancel:  cancel,
+	}
+
+	if _, err := conf.WaitForState(); err != ErrCancelled {
+		t.Fatalf("err: %s", err)
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	if actual := atomic.LoadInt32(&refreshes); actual != 1 {
+		t.Fatalf("refreshed after cancel: %d", actual)
+	}
+}
+
 func TestWaitForState_success(t *testing.T) {
 	conf := &StateChangeConf{
 		Pending: []string{"pending", "incomplete"},
@@ -86,6 +86,67 @@ type TestStep struct {
 	// ExpectNonEmptyPlan can be set to true for specific types of tests that are
 	// looking to verify that a diff occurs
 	ExpectNonEmptyPlan bool
+
+	//---------------------------------------------------------------
+	// ImportState testing
+	//---------------------------------------------------------------
+
+	// ImportState, if true, will test the functionality of importing
+	// the resource given by ResourceName into a fresh state using only
+	// its ID. Config is optional for this step, the configuration of
+	// the previous step is used if it isn't set. The state of the test
+	// is not modified by this step.
+	ImportState bool
+
+	// ResourceName is the name of the resource to import, for example
+	// "aws_instance.foo". It must exist in the state of the previous step.
+	ResourceName string
+
+	// ImportStateId is the ID to import. If this isn't set, the ID of the
+	// resource in the state of the previous step is used.
+	//
+	// ImportStateIdFunc can be used to compute the ID from that state
+	// instead and takes precedence over ImportStateId.
+	ImportStateId     string
+	ImportStateIdFunc ImportStateIdFunc
+
+	// ImportStateCheck checks the instance states that resulted from the
+	// import. This can be used to verify import results in more detail
+	// than ImportStateVerify allows.
+	ImportStateCheck ImportStateCheckFunc
+
+	// ImportStateVerify, if true, verifies that the attributes of the
+	// imported resource match the attributes of the resource in the state
+	// of the previous step. Attributes that can't be imported, such as
+	// passwords, can be excluded by adding them (or a prefix of them) to
+	// ImportStateVerifyIgnore.
+	ImportStateVerify       bool
+	ImportStateVerifyIgnore []string
+}
+
+// ImportStateIdFunc is the callback type used with ImportState test steps
+// to compute the ID to import from the state of the previous step.
+type ImportStateIdFunc func(*terraform.State) (string, error)
+
+// ImportStateCheckFunc is the callback type used with ImportState test
+// steps to check the instance states that were imported.
+type ImportStateCheckFunc func([]*terraform.InstanceState) error
+
+// ParallelTest performs an acceptance test on a resource, allowing it to
+// run concurrently with other tests started with ParallelTest.
+//
+// Resources created by parallel tests must not share names, so test
+// configurations should name them using a prefix registered with
+// acctest.RegisterPrefix and acctest.RandomWithPrefix.
+//
+// The test only runs in parallel if t has a Parallel method, such as
+// *testing.T does.
+func ParallelTest(t TestT, c TestCase) {
+	if p, ok := t.(interface{ Parallel() }); ok {
+		p.Parallel()
+	}
+
+	Test(t, c)
 }
 
 // Test performs an acceptance test on a resource.
@@ -138,11 +199,26 @@ func Test(t TestT, c TestCase) {
 	// A single state variable to track the lifecycle, starting with no state
 	var state *terraform.State
 
+	// The config of the last step, which import steps default to. It is
+	// tracked here so the steps of the test case are never modified.
+	var lastConfig string
+
 	// Go through each step and run it
 	for i, step := range c.Steps {
 		var err error
 		log.Printf("[WARN] Test: Executing step %d", i)
-		state, err = testStep(opts, state, step)
+
+		if step.ImportState {
+			// Import steps default to the config of the previous step
+			if step.Config == "" {
+				step.Config = lastConfig
+			}
+
+			state, err = testStepImportState(opts, state, step)
+		} else {
+			state, err = testStep(opts, state, step)
+		}
+		lastConfig = step.Config
 		if err != nil {
 			t.Error(fmt.Sprintf(
 				"Step %d error: %s", i, err))
@@ -152,8 +228,13 @@ func Test(t TestT, c TestCase) {
 
 	// If we have a state, then run the destroy
 	if state != nil {
+		destroyConfig := c.Steps[len(c.Steps)-1].Config
+		if destroyConfig == "" {
+			destroyConfig = lastConfig
+		}
+
 		destroyStep := TestStep{
-			Config:  c.Steps[len(c.Steps)-1].Config,
+			Config:  destroyConfig,
 			Check:   c.CheckDestroy,
 			Destroy: true,
 		}
@@ -188,34 +269,9 @@ func testStep(
 	}
 	defer os.RemoveAll(cfgPath)
 
-	// Write the configuration
-	cfgF, err := os.Create(filepath.Join(cfgPath, "main.tf"))
+	mod, err := testModule(cfgPath, step)
 	if err != nil {
-		return state, fmt.Errorf(
-			"Error creating temporary file for config: %s", err)
-	}
-
-	_, err = io.Copy(cfgF, strings.NewReader(step.Config))
-	cfgF.Close()
-	if err != nil {
-		return state, fmt.Errorf(
-			"Error creating temporary file for config: %s", err)
-	}
-
-	// Parse the configuration
-	mod, err := module.NewTreeModule("", cfgPath)
-	if err != nil {
-		return state, fmt.Errorf(
-			"Error loading configuration: %s", err)
-	}
-
-	// Load the modules
-	modStorage := &getter.FolderStorage{
-		StorageDir: filepath.Join(cfgPath, ".tfmodules"),
-	}
-	err = mod.Load(modStorage, module.GetModeGet)
-	if err != nil {
-		return state, fmt.Errorf("Error downloading modules: %s", err)
+		return state, err
 	}
 
 	// Build the context
@@ -309,6 +365,42 @@ func testStep(
 	return state, nil
 }
 
+// testModule writes the configuration of the given step to cfgPath and
+// loads it, along with any modules it uses.
+func testModule(cfgPath string, step TestStep) (*module.Tree, error) {
+	// Write the configuration
+	cfgF, err := os.Create(filepath.Join(cfgPath, "main.tf"))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error creating temporary file for config: %s", err)
+	}
+
+	_, err = io.Copy(cfgF, strings.NewReader(step.Config))
+	cfgF.Close()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error creating temporary file for config: %s", err)
+	}
+
+	// Parse the configuration
+	mod, err := module.NewTreeModule("", cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error loading configuration: %s", err)
+	}
+
+	// Load the modules
+	modStorage := &getter.FolderStorage{
+		StorageDir: filepath.Join(cfgPath, ".tfmodules"),
+	}
+	err = mod.Load(modStorage, module.GetModeGet)
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading modules: %s", err)
+	}
+
+	return mod, nil
+}
+
 // ComposeTestCheckFunc lets you compose multiple TestCheckFuncs into
 // a single TestCheckFunc.
 //
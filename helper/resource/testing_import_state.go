@@ -0,0 +1,182 @@
+package resource
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// testStepImportState runs an import state test step
+func testStepImportState(
+	opts terraform.ContextOpts,
+	state *terraform.State,
+	step TestStep) (*terraform.State, error) {
+	if step.PreConfig != nil {
+		step.PreConfig()
+	}
+
+	if step.ResourceName == "" {
+		return state, fmt.Errorf("ResourceName must be set for an ImportState step")
+	}
+
+	// Find the resource that is going to be imported
+	var old *terraform.ResourceState
+	if state != nil {
+		old = state.RootModule().Resources[step.ResourceName]
+	}
+	if old == nil || old.Primary == nil {
+		return state, fmt.Errorf(
+			"Import: resource %s not found in the current state", step.ResourceName)
+	}
+
+	// Determine the ID to import
+	importId := step.ImportStateId
+	if importId == "" {
+		importId = old.Primary.ID
+	}
+	if step.ImportStateIdFunc != nil {
+		var err error
+		importId, err = step.ImportStateIdFunc(state)
+		if err != nil {
+			return state, err
+		}
+	}
+	log.Printf("[WARN] Test: Importing %s with ID %q", step.ResourceName, importId)
+
+	cfgPath, err := ioutil.TempDir("", "tf-test")
+	if err != nil {
+		return state, fmt.Errorf(
+			"Error creating temporary directory for config: %s", err)
+	}
+	defer os.RemoveAll(cfgPath)
+
+	mod, err := testModule(cfgPath, step)
+	if err != nil {
+		return state, err
+	}
+
+	// Start from a fresh state that only knows the ID of the resource,
+	// and let a refresh fill in the rest.
+	importState := &terraform.State{}
+	importState.AddModule(terraform.RootModulePath)
+	importState.RootModule().Resources[step.ResourceName] = &terraform.ResourceState{
+		Type:     old.Type,
+		Provider: old.Provider,
+		Primary:  &terraform.InstanceState{ID: importId},
+	}
+
+	opts.Module = mod
+	opts.State = importState
+	opts.Destroy = false
+	ctx := terraform.NewContext(&opts)
+	if _, es := ctx.Validate(); len(es) > 0 {
+		estrs := make([]string, len(es))
+		for i, e := range es {
+			estrs[i] = e.Error()
+		}
+		return state, fmt.Errorf(
+			"Configuration is invalid.\n\nErrors: %#v", estrs)
+	}
+
+	newState, err := ctx.Refresh()
+	if err != nil {
+		return state, fmt.Errorf("Error importing: %s", err)
+	}
+
+	// Collect the imported instances
+	var states []*terraform.InstanceState
+	for _, r := range newState.RootModule().Resources {
+		if r.Type == old.Type && r.Primary != nil {
+			states = append(states, r.Primary)
+		}
+	}
+	if len(states) == 0 {
+		return state, fmt.Errorf(
+			"Import: resource %s with ID %q was not found", step.ResourceName, importId)
+	}
+
+	if step.ImportStateCheck != nil {
+		if err := step.ImportStateCheck(states); err != nil {
+			return state, fmt.Errorf("Import check failed: %s", err)
+		}
+	}
+
+	if step.ImportStateVerify {
+		imported := newState.RootModule().Resources[step.ResourceName]
+		if imported == nil || imported.Primary == nil {
+			return state, fmt.Errorf(
+				"Import: resource %s not found in the imported state", step.ResourceName)
+		}
+
+		expected := testImportStateAttributes(old.Primary, step.ImportStateVerifyIgnore)
+		actual := testImportStateAttributes(imported.Primary, step.ImportStateVerifyIgnore)
+		if !reflect.DeepEqual(expected, actual) {
+			return state, fmt.Errorf(
+				"Import: imported attributes don't match the created resource:\n\n%s",
+				testImportStateAttributesDiff(expected, actual))
+		}
+	}
+
+	// The import doesn't affect the state of the test
+	return state, nil
+}
+
+// testImportStateAttributes returns the attributes of the instance,
+// leaving out any attributes that start with one of the ignored prefixes.
+func testImportStateAttributes(
+	is *terraform.InstanceState, ignore []string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range is.Attributes {
+		skip := false
+		for _, prefix := range ignore {
+			if strings.HasPrefix(k, prefix) {
+				skip = true
+				break
+			}
+		}
+
+		if !skip {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func testImportStateAttributesDiff(expected, actual map[string]string) string {
+	keys := make(map[string]struct{})
+	for k := range expected {
+		keys[k] = struct{}{}
+	}
+	for k := range actual {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var buf []string
+	for _, k := range sorted {
+		e, eok := expected[k]
+		a, aok := actual[k]
+		switch {
+		case !aok:
+			buf = append(buf, fmt.Sprintf("  %s: %q => <missing>", k, e))
+		case !eok:
+			buf = append(buf, fmt.Sprintf("  %s: <missing> => %q", k, a))
+		case e != a:
+			buf = append(buf, fmt.Sprintf("  %s: %q => %q", k, e, a))
+		}
+	}
+
+	return strings.Join(buf, "\n")
+}
@@ -0,0 +1,198 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestTest_importState(t *testing.T) {
+	created := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":       "foo",
+			"name":     "bar",
+			"password": "secret",
+		},
+	}
+
+	mp := testImportStateProvider(created, func(s *terraform.InstanceState) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "foo",
+			Attributes: map[string]string{
+				"id":   "foo",
+				"name": "bar",
+			},
+		}
+	})
+
+	checked := false
+	checkFn := func(s []*terraform.InstanceState) error {
+		checked = true
+
+		if len(s) != 1 {
+			return fmt.Errorf("bad: %#v", s)
+		}
+		if s[0].ID != "foo" {
+			return fmt.Errorf("bad: %#v", s)
+		}
+
+		return nil
+	}
+
+	steps := []TestStep{
+		TestStep{
+			Config: testConfigStr,
+		},
+		TestStep{
+			ResourceName:            "test_instance.foo",
+			ImportState:             true,
+			ImportStateCheck:        checkFn,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: []string{"password"},
+		},
+	}
+
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": mp,
+		},
+
+		Steps: steps,
+	})
+
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+	if !checked {
+		t.Fatal("didn't call check")
+	}
+
+	// The import step uses the config of the previous step, but must not
+	// modify the test case to do so.
+	if steps[1].Config != "" {
+		t.Fatalf("import step config modified: %q", steps[1].Config)
+	}
+}
+
+func TestTest_importStateVerifyFail(t *testing.T) {
+	created := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":   "foo",
+			"name": "bar",
+		},
+	}
+
+	mp := testImportStateProvider(created, func(s *terraform.InstanceState) *terraform.InstanceState {
+		// Only the ID is known when importing
+		if len(s.Attributes) > 0 {
+			return s
+		}
+
+		return &terraform.InstanceState{
+			ID: "foo",
+			Attributes: map[string]string{
+				"id":   "foo",
+				"name": "baz",
+			},
+		}
+	})
+
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": mp,
+		},
+
+		Steps: []TestStep{
+			TestStep{
+				Config: testConfigStr,
+			},
+			TestStep{
+				ResourceName:      "test_instance.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	if !mt.failed() {
+		t.Fatal("should fail")
+	}
+}
+
+func TestTest_importStateIdFunc(t *testing.T) {
+	var importedId string
+	created := &terraform.InstanceState{ID: "foo"}
+	mp := testImportStateProvider(created, func(s *terraform.InstanceState) *terraform.InstanceState {
+		if s.ID == "bar" {
+			importedId = s.ID
+		}
+
+		return s
+	})
+
+	mt := new(mockT)
+	Test(mt, TestCase{
+		Providers: map[string]terraform.ResourceProvider{
+			"test": mp,
+		},
+
+		Steps: []TestStep{
+			TestStep{
+				Config: testConfigStr,
+			},
+			TestStep{
+				ResourceName: "test_instance.foo",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return "bar", nil
+				},
+			},
+		},
+	})
+
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+	if importedId != "bar" {
+		t.Fatalf("bad: %q", importedId)
+	}
+}
+
+// testImportStateProvider returns a provider that creates the given state
+// and refreshes it with the given function until it is destroyed.
+func testImportStateProvider(
+	created *terraform.InstanceState,
+	refresh func(*terraform.InstanceState) *terraform.InstanceState) *terraform.MockResourceProvider {
+	mp := testProvider()
+	mp.DiffReturn = nil
+
+	destroyed := false
+	mp.ApplyFn = func(
+		i *terraform.InstanceInfo,
+		s *terraform.InstanceState,
+		d *terraform.InstanceDiff) (*terraform.InstanceState, error) {
+		if d.Destroy {
+			destroyed = true
+			return nil, nil
+		}
+
+		return created, nil
+	}
+
+	mp.RefreshFn = func(
+		i *terraform.InstanceInfo,
+		s *terraform.InstanceState) (*terraform.InstanceState, error) {
+		if destroyed {
+			return nil, nil
+		}
+
+		return refresh(s), nil
+	}
+
+	return mp
+}
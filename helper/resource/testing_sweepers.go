@@ -0,0 +1,179 @@
+package resource
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// flagSweep is a comma separated list of regions to run the sweepers in.
+// When it is set, TestMain runs the sweepers instead of the tests.
+var flagSweep = flag.String("sweep", "", "List of Regions to run available Sweepers")
+
+// flagSweepRun is a comma separated list of sweeper names to run. Sweepers
+// that the listed sweepers depend on are run as well.
+var flagSweepRun = flag.String("sweep-run", "", "Comma separated list of Sweeper Tests to run")
+
+var sweeperFuncs = make(map[string]*Sweeper)
+
+// SweeperFunc is the signature of a function that cleans up leaked
+// acceptance test resources in the given region.
+type SweeperFunc func(region string) error
+
+// Sweeper is a function that removes leftover resources of a single type
+// that acceptance tests may have leaked, e.g. because a test panicked
+// before it could destroy them.
+type Sweeper struct {
+	// Name is the name of the sweeper, usually the resource type it
+	// sweeps.
+	Name string
+
+	// Dependencies lists the names of the sweepers that must run before
+	// this one, for example because their resources prevent the resources
+	// of this sweeper from being deleted.
+	Dependencies []string
+
+	// F is the function that does the sweeping.
+	F SweeperFunc
+}
+
+// AddTestSweepers registers a sweeper under the given name. This is
+// usually called from an init function in the test files of a provider.
+// Registering the same name twice is an error.
+func AddTestSweepers(name string, s *Sweeper) {
+	if _, ok := sweeperFuncs[name]; ok {
+		log.Fatalf("[ERR] Error adding (%s) to sweeperFuncs: function already exists in map", name)
+	}
+
+	sweeperFuncs[name] = s
+}
+
+// TestMain should be called by the TestMain function of packages that
+// register sweepers. If the -sweep flag is set, the registered sweepers
+// are run for each of the given regions instead of the tests.
+//
+//	func TestMain(m *testing.M) {
+//		resource.TestMain(m)
+//	}
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *flagSweep == "" {
+		os.Exit(m.Run())
+	}
+
+	sweepers, err := filterSweepers(*flagSweepRun, sweeperFuncs)
+	if err != nil {
+		log.Fatalf("[ERR] %s", err)
+	}
+
+	for _, region := range strings.Split(*flagSweep, ",") {
+		region = strings.TrimSpace(region)
+		log.Printf("[DEBUG] Running Sweepers for region (%s)", region)
+
+		ran, err := runSweepers(region, sweepers)
+		if err != nil {
+			log.Fatalf("[ERR] Error running sweepers for region (%s): %s", region, err)
+		}
+
+		log.Printf("[INFO] Sweepers ran for region (%s): %s", region, strings.Join(ran, ", "))
+	}
+}
+
+// filterSweepers returns the sweepers named in the comma separated filter
+// along with all of their dependencies. An empty filter returns all of the
+// sweepers.
+func filterSweepers(f string, source map[string]*Sweeper) (map[string]*Sweeper, error) {
+	if f == "" {
+		return source, nil
+	}
+
+	result := make(map[string]*Sweeper)
+	var add func(string) error
+	add = func(name string) error {
+		if _, ok := result[name]; ok {
+			return nil
+		}
+
+		s, ok := source[name]
+		if !ok {
+			return fmt.Errorf("sweeper %q not found", name)
+		}
+		result[name] = s
+
+		for _, dep := range s.Dependencies {
+			if err := add(dep); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, name := range strings.Split(f, ",") {
+		if err := add(strings.TrimSpace(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// runSweepers runs all of the given sweepers in the region, making sure
+// dependencies run first and every sweeper runs only once. It returns the
+// names of the sweepers that ran, in order.
+func runSweepers(region string, sweepers map[string]*Sweeper) ([]string, error) {
+	// Sort the names so the order of independent sweepers is stable
+	names := make([]string, 0, len(sweepers))
+	for name := range sweepers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ran []string
+	done := make(map[string]bool)
+	var run func(name string, path []string) error
+	run = func(name string, path []string) error {
+		if done[name] {
+			return nil
+		}
+
+		for _, p := range path {
+			if p == name {
+				return fmt.Errorf(
+					"dependency cycle: %s", strings.Join(append(path, name), " -> "))
+			}
+		}
+
+		s, ok := sweepers[name]
+		if !ok {
+			return fmt.Errorf("sweeper %q not found", name)
+		}
+
+		for _, dep := range s.Dependencies {
+			if err := run(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		log.Printf("[DEBUG] Running sweeper (%s) in region (%s)", name, region)
+		if err := s.F(region); err != nil {
+			return fmt.Errorf("error running (%s): %s", name, err)
+		}
+
+		done[name] = true
+		ran = append(ran, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := run(name, nil); err != nil {
+			return ran, err
+		}
+	}
+
+	return ran, nil
+}
@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFilterSweepers(t *testing.T) {
+	sweepers := map[string]*Sweeper{
+		"aws_dummy": &Sweeper{
+			Name: "aws_dummy",
+		},
+		"aws_top": &Sweeper{
+			Name:         "aws_top",
+			Dependencies: []string{"aws_sub"},
+		},
+		"aws_sub": &Sweeper{
+			Name: "aws_sub",
+		},
+	}
+
+	cases := []struct {
+		Filter   string
+		Expected []string
+		Err      bool
+	}{
+		{"", []string{"aws_dummy", "aws_sub", "aws_top"}, false},
+		{"aws_dummy", []string{"aws_dummy"}, false},
+		{"aws_top", []string{"aws_sub", "aws_top"}, false},
+		{"aws_dummy, aws_sub", []string{"aws_dummy", "aws_sub"}, false},
+		{"aws_nope", nil, true},
+	}
+
+	for i, tc := range cases {
+		result, err := filterSweepers(tc.Filter, sweepers)
+		if err != nil != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		var actual []string
+		for k := range result {
+			actual = append(actual, k)
+		}
+		sort.Strings(actual)
+
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%d: bad: %#v", i, actual)
+		}
+	}
+}
+
+func TestRunSweepers(t *testing.T) {
+	var ran []string
+	sweeper := func(name string) SweeperFunc {
+		return func(region string) error {
+			if region != "us-east-1" {
+				return fmt.Errorf("bad region: %s", region)
+			}
+
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	sweepers := map[string]*Sweeper{
+		"aws_a": &Sweeper{
+			Name:         "aws_a",
+			Dependencies: []string{"aws_c"},
+			F:            sweeper("aws_a"),
+		},
+		"aws_b": &Sweeper{
+			Name:         "aws_b",
+			Dependencies: []string{"aws_c"},
+			F:            sweeper("aws_b"),
+		},
+		"aws_c": &Sweeper{
+			Name: "aws_c",
+			F:    sweeper("aws_c"),
+		},
+	}
+
+	result, err := runSweepers("us-east-1", sweepers)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{"aws_c", "aws_a", "aws_b"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("bad: %#v", ran)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("bad: %#v", result)
+	}
+}
+
+func TestRunSweepers_cycle(t *testing.T) {
+	f := func(string) error { return nil }
+	sweepers := map[string]*Sweeper{
+		"aws_a": &Sweeper{
+			Name:         "aws_a",
+			Dependencies: []string{"aws_b"},
+			F:            f,
+		},
+		"aws_b": &Sweeper{
+			Name:         "aws_b",
+			Dependencies: []string{"aws_a"},
+			F:            f,
+		},
+	}
+
+	if _, err := runSweepers("us-east-1", sweepers); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestRunSweepers_error(t *testing.T) {
+	sweepers := map[string]*Sweeper{
+		"aws_a": &Sweeper{
+			Name: "aws_a",
+			F:    func(string) error { return fmt.Errorf("error") },
+		},
+	}
+
+	if _, err := runSweepers("us-east-1", sweepers); err == nil {
+		t.Fatal("should error")
+	}
+}
@@ -83,6 +83,31 @@ func TestTest(t *testing.T) {
 	}
 }
 
+func TestParallelTest(t *testing.T) {
+	mt := new(mockT)
+	ParallelTest(mt, TestCase{})
+
+	if !mt.ParallelCalled {
+		t.Fatal("Parallel() not called")
+	}
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+}
+
+func TestParallelTest_noParallel(t *testing.T) {
+	// Wrapping hides the Parallel method of the mock
+	mt := new(mockT)
+	ParallelTest(struct{ TestT }{mt}, TestCase{})
+
+	if mt.ParallelCalled {
+		t.Fatal("Parallel() should not be called")
+	}
+	if mt.failed() {
+		t.Fatalf("test failed: %s", mt.failMessage())
+	}
+}
+
 func TestTest_empty(t *testing.T) {
 	destroyCalled := false
 	checkDestroyFn := func(*terraform.State) error {
@@ -237,6 +262,8 @@ type mockT struct {
 	SkipCalled  bool
 	SkipArgs    []interface{}
 
+	ParallelCalled bool
+
 	f bool
 }
 
@@ -258,6 +285,10 @@ func (t *mockT) Skip(args ...interface{}) {
 	t.f = true
 }
 
+func (t *mockT) Parallel() {
+	t.ParallelCalled = true
+}
+
 func (t *mockT) failed() bool {
 	return t.f
 }
@@ -10,11 +10,21 @@ type RetryFunc func() error
 // Retry is a basic wrapper around StateChangeConf that will just retry
 // a function until it no longer returns an error.
 func Retry(timeout time.Duration, f RetryFunc) error {
+	return RetryWithCancel(timeout, nil, f)
+}
+
+// RetryWithCancel is like Retry, but stops retrying and returns
+// ErrCancelled as soon as the cancel channel is closed. Resources pass
+// the StopCh of their ResourceData, so that the retries are aborted when
+// Terraform is stopped.
+func RetryWithCancel(
+	timeout time.Duration, cancel <-chan struct{}, f RetryFunc) error {
 	c := &StateChangeConf{
 		Pending:    []string{"error"},
 		Target:     "success",
 		Timeout:    timeout,
 		MinTimeout: 500 * time.Millisecond,
+		Cancel:     cancel,
 		Refresh: func() (interface{}, string, error) {
 			err := f()
 			if err == nil {
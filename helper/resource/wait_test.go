@@ -60,3 +60,28 @@ func TestRetry_error(t *testing.T) {
 		t.Fatal("timeout")
 	}
 }
+
+func TestRetryWithCancel(t *testing.T) {
+	t.Parallel()
+
+	f := func() error {
+		return fmt.Errorf("always")
+	}
+
+	cancel := make(chan struct{})
+	errCh := make(chan error)
+	go func() {
+		errCh <- RetryWithCancel(1*time.Minute, cancel, f)
+	}()
+
+	close(cancel)
+
+	select {
+	case err := <-errCh:
+		if err != ErrCancelled {
+			t.Fatalf("bad: %#v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+}
@@ -261,14 +261,17 @@ func (w *MapFieldWriter) setSet(
 	// If it is a slice, then we have to turn it into a *Set so that
 	// we get the proper order back based on the hash code.
 	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice {
-		// Build a temp *ResourceData to use for the conversion
+		// Build a temp *ResourceData to use for the conversion. Only the
+		// last part of the address is used, since the set may be nested
+		// in a list or set and the temp schema only contains the set.
+		tempAddr := addr[len(addr)-1:]
 		tempSchema := *schema
 		tempSchema.Type = TypeList
-		tempSchemaMap := map[string]*Schema{addr[0]: &tempSchema}
+		tempSchemaMap := map[string]*Schema{tempAddr[0]: &tempSchema}
 		tempW := &MapFieldWriter{Schema: tempSchemaMap}
 
 		// Set the entire list, this lets us get sane values out of it
-		if err := tempW.WriteField(addr, value); err != nil {
+		if err := tempW.WriteField(tempAddr, value); err != nil {
 			return err
 		}
 
@@ -276,15 +279,17 @@ func (w *MapFieldWriter) setSet(
 		// hashing them into the set. The reason we go over the list and
 		// not the `value` directly is because this forces all types
 		// to become []interface{} (generic) instead of []string, which
-		// most hash functions are expecting.
-		s := &Set{F: schema.Set}
+		// most hash functions are expecting. The zero value of the
+		// schema has the Set function, or the default one if there is
+		// none.
+		s := schema.ZeroValue().(*Set)
 		tempR := &MapFieldReader{
 			Map:    BasicMapReader(tempW.Map()),
 			Schema: tempSchemaMap,
 		}
 		for i := 0; i < v.Len(); i++ {
 			is := strconv.FormatInt(int64(i), 10)
-			result, err := tempR.ReadField(append(addrCopy, is))
+			result, err := tempR.ReadField(append(tempAddr, is))
 			if err != nil {
 				return err
 			}
@@ -55,6 +55,42 @@ func TestMapFieldWriter(t *testing.T) {
 				return a.(map[string]interface{})["index"].(int)
 			},
 		},
+		"setDefault": &Schema{
+			Type: TypeSet,
+			Elem: &Schema{Type: TypeInt},
+		},
+		"listNestedSet": &Schema{
+			Type: TypeList,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"set": &Schema{
+						Type: TypeSet,
+						Elem: &Schema{Type: TypeInt},
+						Set: func(a interface{}) int {
+							return a.(int)
+						},
+					},
+				},
+			},
+		},
+		"setNestedSet": &Schema{
+			Type: TypeSet,
+			Elem: &Resource{
+				Schema: map[string]*Schema{
+					"index": &Schema{Type: TypeInt},
+					"set": &Schema{
+						Type: TypeSet,
+						Elem: &Schema{Type: TypeInt},
+						Set: func(a interface{}) int {
+							return a.(int)
+						},
+					},
+				},
+			},
+			Set: func(a interface{}) int {
+				return a.(map[string]interface{})["index"].(int)
+			},
+		},
 	}
 
 	cases := map[string]struct {
@@ -225,6 +261,51 @@ func TestMapFieldWriter(t *testing.T) {
 			},
 		},
 
+		"set default hash": {
+			[]string{"setDefault"},
+			[]interface{}{1, 2},
+			false,
+			map[string]string{
+				"setDefault.#":         "2",
+				"setDefault.915405929": "1",
+				"setDefault.497201066": "2",
+			},
+		},
+
+		"set in list": {
+			[]string{"listNestedSet"},
+			[]interface{}{
+				map[string]interface{}{
+					"set": []interface{}{1, 2},
+				},
+			},
+			false,
+			map[string]string{
+				"listNestedSet.#":       "1",
+				"listNestedSet.0.set.#": "2",
+				"listNestedSet.0.set.1": "1",
+				"listNestedSet.0.set.2": "2",
+			},
+		},
+
+		"set in set": {
+			[]string{"setNestedSet"},
+			[]interface{}{
+				map[string]interface{}{
+					"index": 10,
+					"set":   []interface{}{1, 2},
+				},
+			},
+			false,
+			map[string]string{
+				"setNestedSet.#":        "1",
+				"setNestedSet.10.index": "10",
+				"setNestedSet.10.set.#": "2",
+				"setNestedSet.10.set.1": "1",
+				"setNestedSet.10.set.2": "2",
+			},
+		},
+
 		"set element": {
 			[]string{"set", "5"},
 			5,
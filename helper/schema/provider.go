@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/xanzy/terraform-api/terraform"
 )
@@ -39,7 +40,17 @@ type Provider struct {
 	// See the ConfigureFunc documentation for more information.
 	ConfigureFunc ConfigureFunc
 
+	// ExistsFunc is called during refresh to check if a resource still
+	// exists, for the resources that don't have an Exists function of
+	// their own. If it returns false, the resource is removed from the
+	// state without calling Read. This is useful for APIs that can look
+	// up any resource by its ID in the same way.
+	ExistsFunc ProviderExistsFunc
+
 	meta interface{}
+
+	stopCh   chan struct{}
+	stopLock sync.Mutex
 }
 
 // ConfigureFunc is the function used to configure a Provider.
@@ -50,6 +61,10 @@ type Provider struct {
 // structure, etc.
 type ConfigureFunc func(*ResourceData) (interface{}, error)
 
+// ProviderExistsFunc is the function used to check if a resource of the
+// given type still exists. See Provider.ExistsFunc.
+type ProviderExistsFunc func(string, *ResourceData, interface{}) (bool, error)
+
 // InternalValidate should be called to validate the structure
 // of the provider.
 //
@@ -88,6 +103,38 @@ func (p *Provider) SetMeta(v interface{}) {
 	p.meta = v
 }
 
+// StopCh returns a channel that is closed once the provider is asked to
+// stop. It is passed to the resources through ResourceData.StopCh.
+func (p *Provider) StopCh() <-chan struct{} {
+	p.stopLock.Lock()
+	defer p.stopLock.Unlock()
+
+	if p.stopCh == nil {
+		p.stopCh = make(chan struct{})
+	}
+
+	return p.stopCh
+}
+
+// Stop implementation of terraform.ResourceProviderStopper interface.
+func (p *Provider) Stop() error {
+	p.stopLock.Lock()
+	defer p.stopLock.Unlock()
+
+	if p.stopCh == nil {
+		p.stopCh = make(chan struct{})
+	}
+
+	select {
+	case <-p.stopCh:
+		// Already stopped
+	default:
+		close(p.stopCh)
+	}
+
+	return nil
+}
+
 // Input implementation of terraform.ResourceProvider interface.
 func (p *Provider) Input(
 	input terraform.UIInput,
@@ -130,7 +177,7 @@ func (p *Provider) Configure(c *terraform.ResourceConfig) error {
 
 	// Get a ResourceData for this configuration. To do this, we actually
 	// generate an intermediary "diff" although that is never exposed.
-	diff, err := sm.Diff(nil, c)
+	diff, err := sm.Diff(nil, c, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -159,7 +206,7 @@ func (p *Provider) Apply(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Apply(s, d, p.meta)
+	return r.apply(s, d, p.meta, p.StopCh())
 }
 
 // Diff implementation of terraform.ResourceProvider interface.
@@ -172,7 +219,7 @@ func (p *Provider) Diff(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Diff(s, c)
+	return r.Diff(s, c, p.meta)
 }
 
 // Refresh implementation of terraform.ResourceProvider interface.
@@ -184,7 +231,14 @@ func (p *Provider) Refresh(
 		return nil, fmt.Errorf("unknown resource type: %s", info.Type)
 	}
 
-	return r.Refresh(s, p.meta)
+	exists := r.Exists
+	if exists == nil && p.ExistsFunc != nil {
+		exists = func(d *ResourceData, meta interface{}) (bool, error) {
+			return p.ExistsFunc(info.Type, d, meta)
+		}
+	}
+
+	return r.refresh(s, p.meta, exists, p.StopCh())
 }
 
 // Resources implementation of terraform.ResourceProvider interface.
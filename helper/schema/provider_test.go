@@ -198,3 +198,134 @@ func TestProviderMeta(t *testing.T) {
 		t.Fatalf("bad: %#v", v)
 	}
 }
+
+func TestProviderStop(t *testing.T) {
+	var p interface{} = new(Provider)
+	stopper, ok := p.(terraform.ResourceProviderStopper)
+	if !ok {
+		t.Fatal("should be a ResourceProviderStopper")
+	}
+
+	if err := stopper.Stop(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	select {
+	case <-p.(*Provider).StopCh():
+	default:
+		t.Fatal("should be stopped")
+	}
+
+	// Stopping twice is fine
+	if err := stopper.Stop(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProviderApply_stopCh(t *testing.T) {
+	var stopCh <-chan struct{}
+	p := &Provider{
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeInt,
+						Optional: true,
+					},
+				},
+				Create: func(d *ResourceData, m interface{}) error {
+					stopCh = d.StopCh()
+					d.SetId("foo")
+					return nil
+				},
+			},
+		},
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": &terraform.ResourceAttrDiff{
+				New: "42",
+			},
+		},
+	}
+
+	info := &terraform.InstanceInfo{Type: "foo"}
+	if _, err := p.Apply(info, nil, d); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if stopCh == nil || stopCh != p.StopCh() {
+		t.Fatal("resource should get the stop channel of the provider")
+	}
+}
+
+func TestProviderRefresh_existsFunc(t *testing.T) {
+	readCalled := false
+	read := func(d *ResourceData, m interface{}) error {
+		readCalled = true
+		return nil
+	}
+
+	var existsType string
+	p := &Provider{
+		ResourcesMap: map[string]*Resource{
+			"foo": &Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeInt,
+						Optional: true,
+					},
+				},
+				Read: read,
+			},
+			"bar": &Resource{
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeInt,
+						Optional: true,
+					},
+				},
+				Read: read,
+				Exists: func(d *ResourceData, m interface{}) (bool, error) {
+					return true, nil
+				},
+			},
+		},
+		ExistsFunc: func(t string, d *ResourceData, m interface{}) (bool, error) {
+			existsType = t
+			return d.Id() != "gone", nil
+		},
+	}
+
+	// A resource without Exists uses the one of the provider
+	s := &terraform.InstanceState{ID: "gone"}
+	actual, err := p.Refresh(&terraform.InstanceInfo{Type: "foo"}, s)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if existsType != "foo" {
+		t.Fatalf("bad: %s", existsType)
+	}
+	if readCalled {
+		t.Fatal("read should not be called")
+	}
+
+	// A resource with Exists uses its own
+	existsType = ""
+	actual, err = p.Refresh(&terraform.InstanceInfo{Type: "bar"}, s)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual == nil || actual.ID != "gone" {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if existsType != "" {
+		t.Fatal("provider ExistsFunc should not be called")
+	}
+	if !readCalled {
+		t.Fatal("read should be called")
+	}
+}
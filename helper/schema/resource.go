@@ -3,6 +3,7 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"log"
 	"strconv"
 
 	"github.com/xanzy/terraform-api/terraform"
@@ -41,8 +42,10 @@ type Resource struct {
 	// MigrateState is responsible for updating an InstanceState with an old
 	// version to the format expected by the current version of the Schema.
 	//
-	// It is called during Refresh if the State's stored SchemaVersion is less
-	// than the current SchemaVersion of the Resource.
+	// It is called during Refresh, Diff and Apply if the State's stored
+	// SchemaVersion is less than the current SchemaVersion of the Resource,
+	// so the other functions only ever see the current layout. It gets a
+	// copy of the state, which it may modify and return.
 	//
 	// The function is yielded the state's stored SchemaVersion and a pointer to
 	// the InstanceState that needs updating, as well as the configured
@@ -50,6 +53,17 @@ type Resource struct {
 	// needs to make any remote API calls.
 	MigrateState StateMigrateFunc
 
+	// CustomizeDiff is a function that is called after the diff for this
+	// resource has been computed from the schema, and allows the resource
+	// to alter the diff based on logic that can't be expressed in the
+	// schema alone, e.g. forcing a new resource only for certain changes
+	// or setting computed values that are known at plan time.
+	//
+	// The ResourceDiff passed in can be queried like ResourceData and
+	// modified with its SetNew, SetNewComputed, ForceNew and Clear
+	// functions. CustomizeDiff is only valid for top-level resources.
+	CustomizeDiff CustomizeDiffFunc
+
 	// The functions below are the CRUD operations for this resource.
 	//
 	// The only optional operation is Update. If Update is not implemented,
@@ -95,6 +109,9 @@ type DeleteFunc func(*ResourceData, interface{}) error
 // See Resource documentation.
 type ExistsFunc func(*ResourceData, interface{}) (bool, error)
 
+// See Resource documentation.
+type CustomizeDiffFunc func(*ResourceDiff, interface{}) error
+
 // See Resource documentation.
 type StateMigrateFunc func(
 	int, *terraform.InstanceState, interface{}) (*terraform.InstanceState, error)
@@ -104,7 +121,22 @@ func (r *Resource) Apply(
 	s *terraform.InstanceState,
 	d *terraform.InstanceDiff,
 	meta interface{}) (*terraform.InstanceState, error) {
-	data, err := schemaMap(r.Schema).Data(s, d)
+	return r.apply(s, d, meta, nil)
+}
+
+// apply is Apply with the channel that is closed when the provider is
+// asked to stop.
+func (r *Resource) apply(
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff,
+	meta interface{},
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
+	s, err := r.migrateState(s, meta)
+	if err != nil {
+		return s, err
+	}
+
+	data, err := r.data(s, d, stopCh)
 	if err != nil {
 		return s, err
 	}
@@ -133,7 +165,7 @@ func (r *Resource) Apply(
 		}
 
 		// Reset the data to be stateless since we just destroyed
-		data, err = schemaMap(r.Schema).Data(nil, d)
+		data, err = r.data(nil, d, stopCh)
 		if err != nil {
 			return nil, err
 		}
@@ -158,8 +190,14 @@ func (r *Resource) Apply(
 // ResourceProvider interface.
 func (r *Resource) Diff(
 	s *terraform.InstanceState,
-	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
-	return schemaMap(r.Schema).Diff(s, c)
+	c *terraform.ResourceConfig,
+	meta interface{}) (*terraform.InstanceDiff, error) {
+	s, err := r.migrateState(s, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaMap(r.Schema).Diff(s, c, r.CustomizeDiff, meta)
 }
 
 // Validate validates the resource configuration against the schema.
@@ -171,37 +209,45 @@ func (r *Resource) Validate(c *terraform.ResourceConfig) ([]string, []error) {
 func (r *Resource) Refresh(
 	s *terraform.InstanceState,
 	meta interface{}) (*terraform.InstanceState, error) {
+	return r.refresh(s, meta, r.Exists, nil)
+}
+
+// refresh is Refresh with the function that checks if the resource
+// exists, and the channel that is closed when the provider is asked to
+// stop.
+func (r *Resource) refresh(
+	s *terraform.InstanceState,
+	meta interface{},
+	exists ExistsFunc,
+	stopCh <-chan struct{}) (*terraform.InstanceState, error) {
 	// If the ID is already somehow blank, it doesn't exist
 	if s.ID == "" {
 		return nil, nil
 	}
 
-	if r.Exists != nil {
+	s, err := r.migrateState(s, meta)
+	if err != nil {
+		return s, err
+	}
+
+	if exists != nil {
 		// Make a copy of data so that if it is modified it doesn't
 		// affect our Read later.
-		data, err := schemaMap(r.Schema).Data(s, nil)
+		data, err := r.data(s, nil, stopCh)
 		if err != nil {
 			return s, err
 		}
 
-		exists, err := r.Exists(data, meta)
+		ok, err := exists(data, meta)
 		if err != nil {
 			return s, err
 		}
-		if !exists {
+		if !ok {
 			return nil, nil
 		}
 	}
 
-	needsMigration, stateSchemaVersion := r.checkSchemaVersion(s)
-	if needsMigration && r.MigrateState != nil {
-		s, err := r.MigrateState(stateSchemaVersion, s, meta)
-		if err != nil {
-			return s, err
-		}
-	}
-
-	data, err := schemaMap(r.Schema).Data(s, nil)
+	data, err := r.data(s, nil, stopCh)
 	if err != nil {
 		return s, err
 	}
@@ -215,6 +261,21 @@ func (r *Resource) Refresh(
 	return r.recordCurrentSchemaVersion(state), err
 }
 
+// data returns the ResourceData for the given state and diff, which is
+// stopped through the given channel.
+func (r *Resource) data(
+	s *terraform.InstanceState,
+	d *terraform.InstanceDiff,
+	stopCh <-chan struct{}) (*ResourceData, error) {
+	data, err := schemaMap(r.Schema).Data(s, d)
+	if err != nil {
+		return nil, err
+	}
+
+	data.stopCh = stopCh
+	return data, nil
+}
+
 // InternalValidate should be called to validate the structure
 // of the resource.
 //
@@ -259,6 +320,8 @@ func (r *Resource) InternalValidate(topSchemaMap schemaMap) error {
 		}
 
 		tsm = schemaMap(r.Schema)
+	} else if r.CustomizeDiff != nil {
+		return fmt.Errorf("CustomizeDiff is only valid for top-level resources")
 	}
 
 	return schemaMap(r.Schema).InternalValidate(tsm)
@@ -277,6 +340,30 @@ func (r *Resource) checkSchemaVersion(is *terraform.InstanceState) (bool, int) {
 	return stateSchemaVersion < r.SchemaVersion, stateSchemaVersion
 }
 
+// migrateState returns a copy of the state migrated to the current
+// SchemaVersion if it was written by an older version, or the state
+// itself if it doesn't need to be migrated or the migration fails.
+func (r *Resource) migrateState(
+	s *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if s == nil || r.MigrateState == nil {
+		return s, nil
+	}
+
+	needsMigration, stateSchemaVersion := r.checkSchemaVersion(s)
+	if !needsMigration {
+		return s, nil
+	}
+
+	log.Printf("[DEBUG] Migrating state from schema version %d to %d",
+		stateSchemaVersion, r.SchemaVersion)
+	migrated, err := r.MigrateState(stateSchemaVersion, s.DeepCopy(), meta)
+	if err != nil {
+		return s, err
+	}
+
+	return migrated, nil
+}
+
 func (r *Resource) recordCurrentSchemaVersion(
 	state *terraform.InstanceState) *terraform.InstanceState {
 	if state != nil && r.SchemaVersion > 0 {
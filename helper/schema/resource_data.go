@@ -30,6 +30,7 @@ type ResourceData struct {
 	partial     bool
 	partialMap  map[string]struct{}
 	once        sync.Once
+	stopCh      <-chan struct{}
 }
 
 // getResult is the internal structure that is generated when a Get
@@ -186,6 +187,17 @@ func (d *ResourceData) Id() string {
 	return result
 }
 
+// StopCh returns a channel that is closed when Terraform asks the
+// provider to stop. Long running operations, such as waiting for a
+// resource to become available, should give up when it is closed, for
+// example by passing it to resource.RetryWithCancel.
+//
+// The channel is nil, and so never closed, if the resource isn't managed
+// through a Provider.
+func (d *ResourceData) StopCh() <-chan struct{} {
+	return d.stopCh
+}
+
 // ConnInfo returns the connection info for this resource.
 func (d *ResourceData) ConnInfo() map[string]string {
 	if d.newState != nil {
@@ -270,6 +282,14 @@ func (d *ResourceData) State() *terraform.InstanceState {
 		result.Attributes["id"] = d.Id()
 	}
 
+	// Record which attributes are sensitive, so their values can be
+	// redacted whenever the state is displayed.
+	if keys := schemaMap(d.schema).sensitiveKeys(result.Attributes); len(keys) > 0 {
+		result.Meta = map[string]string{
+			terraform.SensitiveAttributesMetaKey: strings.Join(keys, ","),
+		}
+	}
+
 	return &result
 }
 
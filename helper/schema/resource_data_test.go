@@ -2836,6 +2836,52 @@ func TestResourceDataState(t *testing.T) {
 				},
 			},
 		},
+
+		// #28 Sensitive attributes
+		{
+			Schema: map[string]*Schema{
+				"password": &Schema{
+					Type:      TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+
+				"tokens": &Schema{
+					Type:      TypeList,
+					Optional:  true,
+					Sensitive: true,
+					Elem:      &Schema{Type: TypeString},
+				},
+
+				"user": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: nil,
+
+			Diff: nil,
+
+			Set: map[string]interface{}{
+				"password": "secret",
+				"tokens":   []interface{}{"foo", "bar"},
+				"user":     "admin",
+			},
+
+			Result: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"password": "secret",
+					"tokens.#": "2",
+					"tokens.0": "foo",
+					"tokens.1": "bar",
+					"user":     "admin",
+				},
+				Meta: map[string]string{
+					terraform.SensitiveAttributesMetaKey: "password,tokens.0,tokens.1",
+				},
+			},
+		},
 	}
 
 	for i, tc := range cases {
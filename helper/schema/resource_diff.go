@@ -0,0 +1,272 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// ResourceDiff is used to query and change the diff of a resource while
+// it is being planned.
+//
+// ResourceDiff is the argument received by the CustomizeDiff function of
+// a resource. It can be queried just like ResourceData, where the new
+// values reflect the diff as it currently stands, and allows setting new
+// values for computed attributes, forcing a new resource for attributes
+// that have changed, and clearing the diff of an attribute altogether.
+type ResourceDiff struct {
+	schema schemaMap
+	config *terraform.ResourceConfig
+	state  *terraform.InstanceState
+	diff   *terraform.InstanceDiff
+}
+
+// newResourceDiff returns a ResourceDiff that operates directly on the
+// given diff.
+func newResourceDiff(
+	schema schemaMap,
+	config *terraform.ResourceConfig,
+	state *terraform.InstanceState,
+	diff *terraform.InstanceDiff) *ResourceDiff {
+	if diff.Attributes == nil {
+		diff.Attributes = make(map[string]*terraform.ResourceAttrDiff)
+	}
+
+	return &ResourceDiff{
+		schema: schema,
+		config: config,
+		state:  state,
+		diff:   diff,
+	}
+}
+
+// Get returns the new value for the given key. See ResourceData.Get for
+// more details.
+func (d *ResourceDiff) Get(key string) interface{} {
+	return d.data().Get(key)
+}
+
+// GetOk returns the new value for the given key and whether or not it was
+// set to a non-zero value. See ResourceData.GetOk for more details.
+func (d *ResourceDiff) GetOk(key string) (interface{}, bool) {
+	return d.data().GetOk(key)
+}
+
+// GetChange returns the old and new value for the given key.
+func (d *ResourceDiff) GetChange(key string) (interface{}, interface{}) {
+	return d.data().GetChange(key)
+}
+
+// HasChange returns whether or not the given key has been changed.
+func (d *ResourceDiff) HasChange(key string) bool {
+	return d.data().HasChange(key)
+}
+
+// GetConfig returns the value of the given key as it is set in the
+// configuration, ignoring the state and the diff, and whether or not it
+// was set. Unlike Get, this can tell a value that was removed from the
+// configuration apart from a computed value kept from the state.
+func (d *ResourceDiff) GetConfig(key string) (interface{}, bool) {
+	schemaList := addrToSchema(strings.Split(key, "."), d.schema)
+	if len(schemaList) == 0 || d.config == nil {
+		return nil, false
+	}
+
+	r := &ConfigFieldReader{Schema: d.schema, Config: d.config}
+	result, err := r.ReadField(strings.Split(key, "."))
+	if err != nil || result.Computed {
+		return nil, false
+	}
+
+	return result.ValueOrZero(schemaList[len(schemaList)-1]), result.Exists
+}
+
+// NewValueKnown returns false if the value of the given key in the
+// configuration depends on a value that is only known after apply.
+func (d *ResourceDiff) NewValueKnown(key string) bool {
+	if d.config == nil {
+		return true
+	}
+
+	// Maps and lists are computed as a whole if any of their elements are
+	r := &ConfigFieldReader{Schema: d.schema, Config: d.config}
+	result, err := r.ReadField(strings.Split(key, "."))
+	if err == nil && result.Computed {
+		return false
+	}
+
+	return !d.config.IsComputed(key)
+}
+
+// Id returns the ID of the resource from the state, or an empty string
+// if the resource doesn't exist yet.
+func (d *ResourceDiff) Id() string {
+	if d.state == nil {
+		return ""
+	}
+
+	return d.state.ID
+}
+
+// SetNew sets the new value of a computed key in the diff. The value is
+// compared against the state, so setting the value a key already has
+// results in no diff for that key.
+func (d *ResourceDiff) SetNew(key string, value interface{}) error {
+	schema, err := d.computedSchema(key, "SetNew")
+	if err != nil {
+		return err
+	}
+
+	w := &MapFieldWriter{Schema: d.schema}
+	if err := w.WriteField(strings.Split(key, "."), value); err != nil {
+		return err
+	}
+	attrs := w.Map()
+
+	d.clear(key)
+
+	old := d.stateAttributes()
+	for k, v := range attrs {
+		if o, ok := old[k]; ok && o == v {
+			continue
+		}
+
+		d.diff.Attributes[k] = &terraform.ResourceAttrDiff{
+			Old:       old[k],
+			New:       v,
+			Sensitive: schema.Sensitive,
+		}
+	}
+
+	// Anything that was in the state but is no longer part of the
+	// new value is removed.
+	for k, o := range old {
+		if !keyHasPrefix(k, key) {
+			continue
+		}
+		if _, ok := attrs[k]; ok {
+			continue
+		}
+
+		d.diff.Attributes[k] = &terraform.ResourceAttrDiff{
+			Old:        o,
+			NewRemoved: true,
+			Sensitive:  schema.Sensitive,
+		}
+	}
+
+	return nil
+}
+
+// SetNewComputed marks a computed key as being computed, meaning its new
+// value won't be known until after apply.
+func (d *ResourceDiff) SetNewComputed(key string) error {
+	schema, err := d.computedSchema(key, "SetNewComputed")
+	if err != nil {
+		return err
+	}
+
+	d.clear(key)
+
+	k := key
+	switch schema.Type {
+	case TypeList, TypeMap, TypeSet:
+		k += ".#"
+	}
+
+	d.diff.Attributes[k] = &terraform.ResourceAttrDiff{
+		Old:         d.stateAttributes()[k],
+		NewComputed: true,
+		Sensitive:   schema.Sensitive,
+	}
+
+	return nil
+}
+
+// ForceNew marks the diff of the given key as requiring a new resource.
+// The key must have a change, otherwise an error is returned.
+func (d *ResourceDiff) ForceNew(key string) error {
+	if _, err := d.topLevelSchema(key, "ForceNew"); err != nil {
+		return err
+	}
+
+	if !d.HasChange(key) {
+		return fmt.Errorf("ForceNew: No changes for %s", key)
+	}
+
+	for k, attr := range d.diff.Attributes {
+		if attr != nil && keyHasPrefix(k, key) {
+			attr.RequiresNew = true
+		}
+	}
+
+	return nil
+}
+
+// Clear removes the diff of the given key, so the key keeps the value it
+// has in the state.
+func (d *ResourceDiff) Clear(key string) error {
+	if _, err := d.topLevelSchema(key, "Clear"); err != nil {
+		return err
+	}
+
+	d.clear(key)
+	return nil
+}
+
+func (d *ResourceDiff) clear(key string) {
+	for k := range d.diff.Attributes {
+		if keyHasPrefix(k, key) {
+			delete(d.diff.Attributes, k)
+		}
+	}
+}
+
+// data returns a ResourceData that reads from the diff in its current
+// form. A new one is created every time since the diff can be modified
+// in between calls.
+func (d *ResourceDiff) data() *ResourceData {
+	return &ResourceData{
+		schema: d.schema,
+		config: d.config,
+		state:  d.state,
+		diff:   d.diff,
+	}
+}
+
+func (d *ResourceDiff) stateAttributes() map[string]string {
+	if d.state == nil {
+		return nil
+	}
+
+	return d.state.Attributes
+}
+
+func (d *ResourceDiff) topLevelSchema(key, caller string) (*Schema, error) {
+	schema, ok := d.schema[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: Invalid key %q, only top-level keys are allowed", caller, key)
+	}
+
+	return schema, nil
+}
+
+func (d *ResourceDiff) computedSchema(key, caller string) (*Schema, error) {
+	schema, err := d.topLevelSchema(key, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	if !schema.Computed {
+		return nil, fmt.Errorf("%s: Only computed keys can be set, %q is not computed", caller, key)
+	}
+
+	return schema, nil
+}
+
+// keyHasPrefix returns true if k is the given key or one of its
+// nested keys.
+func keyHasPrefix(k, key string) bool {
+	return k == key || strings.HasPrefix(k, key+".")
+}
@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/terraform-api/config"
+	"github.com/xanzy/terraform-api/config/lang/ast"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func testResourceDiffSchema() schemaMap {
+	return schemaMap{
+		"name": &Schema{
+			Type:     TypeString,
+			Optional: true,
+		},
+		"tags": &Schema{
+			Type:     TypeMap,
+			Optional: true,
+			Computed: true,
+		},
+	}
+}
+
+func TestResourceDiffGetConfig(t *testing.T) {
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Key    string
+		Value  interface{}
+		Ok     bool
+	}{
+		"set": {
+			map[string]interface{}{"name": "foo"},
+			"name",
+			"foo",
+			true,
+		},
+		"not set": {
+			map[string]interface{}{},
+			"name",
+			nil,
+			false,
+		},
+		"map": {
+			map[string]interface{}{
+				"tags": map[string]interface{}{"foo": "bar"},
+			},
+			"tags",
+			map[string]interface{}{"foo": "bar"},
+			true,
+		},
+		"map element": {
+			map[string]interface{}{
+				"tags": map[string]interface{}{"foo": "bar"},
+			},
+			"tags.foo",
+			"bar",
+			true,
+		},
+		"unknown key": {
+			map[string]interface{}{"name": "foo"},
+			"nope",
+			nil,
+			false,
+		},
+	}
+
+	for tn, tc := range cases {
+		// The state has values that aren't in the configuration, which
+		// must not be returned.
+		s := &terraform.InstanceState{
+			ID: "foo",
+			Attributes: map[string]string{
+				"name":     "old",
+				"tags.#":   "1",
+				"tags.old": "value",
+			},
+		}
+
+		d := newResourceDiff(
+			testResourceDiffSchema(), testConfig(t, tc.Config), s,
+			new(terraform.InstanceDiff))
+
+		v, ok := d.GetConfig(tc.Key)
+		if ok != tc.Ok {
+			t.Fatalf("%s: bad ok: %t", tn, ok)
+		}
+		if ok && !reflect.DeepEqual(v, tc.Value) {
+			t.Fatalf("%s: bad: %#v", tn, v)
+		}
+	}
+}
+
+func TestResourceDiffGetConfig_computed(t *testing.T) {
+	c := testConfigInterpolate(t, map[string]interface{}{
+		"name": "${var.foo}",
+	}, map[string]ast.Variable{
+		"var.foo": ast.Variable{
+			Value: config.UnknownVariableValue,
+			Type:  ast.TypeString,
+		},
+	})
+
+	d := newResourceDiff(
+		testResourceDiffSchema(), c, nil, new(terraform.InstanceDiff))
+	if v, ok := d.GetConfig("name"); ok {
+		t.Fatalf("bad: %#v", v)
+	}
+}
+
+func TestResourceDiffNewValueKnown(t *testing.T) {
+	c := testConfigInterpolate(t, map[string]interface{}{
+		"name": "${var.foo}",
+		"tags": map[string]interface{}{
+			"known":   "value",
+			"unknown": "${var.foo}",
+		},
+	}, map[string]ast.Variable{
+		"var.foo": ast.Variable{
+			Value: config.UnknownVariableValue,
+			Type:  ast.TypeString,
+		},
+	})
+
+	d := newResourceDiff(
+		testResourceDiffSchema(), c, nil, new(terraform.InstanceDiff))
+
+	cases := map[string]bool{
+		"name":         false,
+		"tags":         false,
+		"tags.unknown": false,
+	}
+	for k, expected := range cases {
+		if actual := d.NewValueKnown(k); actual != expected {
+			t.Fatalf("%s: bad: %t", k, actual)
+		}
+	}
+
+	known := newResourceDiff(
+		testResourceDiffSchema(),
+		testConfig(t, map[string]interface{}{"name": "foo"}),
+		nil, new(terraform.InstanceDiff))
+	if !known.NewValueKnown("name") {
+		t.Fatal("name should be known")
+	}
+
+	// Without a configuration, such as when destroying, nothing depends
+	// on unknown values.
+	destroy := newResourceDiff(
+		testResourceDiffSchema(), nil, nil, new(terraform.InstanceDiff))
+	if !destroy.NewValueKnown("name") {
+		t.Fatal("name should be known without a config")
+	}
+}
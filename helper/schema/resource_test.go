@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/xanzy/terraform-api/config"
+	"github.com/xanzy/terraform-api/config/lang/ast"
 	"github.com/xanzy/terraform-api/terraform"
 )
 
@@ -268,6 +270,71 @@ func TestResourceApply_update(t *testing.T) {
 	}
 }
 
+func TestResourceApply_migrateState(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 1,
+		Schema: map[string]*Schema{
+			"newfoo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		s.Attributes["newfoo"] = s.Attributes["oldfoo"]
+		delete(s.Attributes, "oldfoo")
+		return s, nil
+	}
+
+	r.Update = func(d *ResourceData, m interface{}) error {
+		if o, _ := d.GetChange("newfoo"); o.(int) != 12 {
+			t.Fatalf("Update got unmigrated state: %#v", o)
+		}
+
+		return nil
+	}
+
+	s := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"oldfoo": "12",
+		},
+	}
+
+	d := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"newfoo": &terraform.ResourceAttrDiff{
+				Old: "12",
+				New: "13",
+			},
+		},
+	}
+
+	actual, err := r.Apply(s, d, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":     "foo",
+			"newfoo": "13",
+		},
+		Meta: map[string]string{
+			"schema_version": "1",
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestResourceApply_updateNoCallback(t *testing.T) {
 	r := &Resource{
 		Schema: map[string]*Schema{
@@ -365,6 +432,20 @@ func TestResourceInternalValidate(t *testing.T) {
 			},
 			true,
 		},
+
+		// CustomizeDiff defined for a sub-resource
+		{
+			&Resource{
+				CustomizeDiff: func(d *ResourceDiff, meta interface{}) error { return nil },
+				Schema: map[string]*Schema{
+					"foo": &Schema{
+						Type:     TypeInt,
+						Optional: true,
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for i, tc := range cases {
@@ -375,6 +456,175 @@ func TestResourceInternalValidate(t *testing.T) {
 	}
 }
 
+func TestResourceDiff_customizeDiff(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"foo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+			"bar": &Schema{
+				Type:     TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	r.CustomizeDiff = func(d *ResourceDiff, m interface{}) error {
+		if m != 42 {
+			return fmt.Errorf("meta not passed")
+		}
+		return d.SetNew("bar", fmt.Sprintf("%d", d.Get("foo").(int)*2))
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{"foo": 21})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := r.Diff(nil, terraform.NewResourceConfig(raw), 42)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"foo": &terraform.ResourceAttrDiff{
+				New: "21",
+			},
+			"bar": &terraform.ResourceAttrDiff{
+				New: "42",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceDiff_migrateState(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 1,
+		Schema: map[string]*Schema{
+			"newfoo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		s.Attributes["newfoo"] = s.Attributes["oldfoo"]
+		delete(s.Attributes, "oldfoo")
+		return s, nil
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{"newfoo": 12})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s := &terraform.InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"oldfoo": "12",
+		},
+	}
+
+	// Once migrated, the state matches the configuration
+	actual, err := r.Diff(s, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != nil {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceDiff_customizeDiffGetConfig(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"tags": &Schema{
+				Type:     TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+			"computed": &Schema{
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+	}
+
+	r.CustomizeDiff = func(d *ResourceDiff, m interface{}) error {
+		if v, ok := d.GetConfig("tags"); ok {
+			return fmt.Errorf("tags should not be set: %#v", v)
+		}
+		if d.NewValueKnown("computed") {
+			return fmt.Errorf("computed should not be known")
+		}
+
+		// The state value is kept by the diff, but removed from the config
+		if v := d.Get("tags").(map[string]interface{}); v["foo"] != "bar" {
+			return fmt.Errorf("bad tags: %#v", v)
+		}
+		return d.SetNew("tags", map[string]interface{}{})
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"computed": "${var.foo}",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	err = raw.Interpolate(map[string]ast.Variable{
+		"var.foo": ast.Variable{
+			Value: config.UnknownVariableValue,
+			Type:  ast.TypeString,
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"tags.#":   "1",
+			"tags.foo": "bar",
+		},
+	}
+
+	actual, err := r.Diff(s, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"computed": &terraform.ResourceAttrDiff{
+				New: "${var.foo}",
+			},
+			"tags.#": &terraform.ResourceAttrDiff{
+				Old: "1",
+				New: "0",
+			},
+			"tags.foo": &terraform.ResourceAttrDiff{
+				Old:        "bar",
+				NewRemoved: true,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func TestResourceRefresh(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
@@ -730,6 +980,111 @@ func TestResourceRefresh_stateSchemaVersionUnset(t *testing.T) {
 	}
 }
 
+func TestResourceRefresh_migrateStateNew(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 1,
+		Schema: map[string]*Schema{
+			"newfoo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		return d.Set("newfoo", d.Get("newfoo").(int)+1)
+	}
+
+	// The migration builds a new state instead of modifying the old one
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		return &terraform.InstanceState{
+			ID: s.ID,
+			Attributes: map[string]string{
+				"newfoo": s.Attributes["oldfoo"],
+			},
+		}, nil
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"oldfoo": "12",
+		},
+	}
+
+	actual, err := r.Refresh(s, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"id":     "bar",
+			"newfoo": "13",
+		},
+		Meta: map[string]string{
+			"schema_version": "1",
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad:\n\nexpected: %#v\ngot: %#v", expected, actual)
+	}
+
+	// The original state must be left alone
+	if _, ok := s.Attributes["oldfoo"]; !ok || len(s.Attributes) != 1 {
+		t.Fatalf("state was modified: %#v", s)
+	}
+}
+
+func TestResourceRefresh_migrateBeforeExists(t *testing.T) {
+	r := &Resource{
+		SchemaVersion: 1,
+		Schema: map[string]*Schema{
+			"newfoo": &Schema{
+				Type:     TypeInt,
+				Optional: true,
+			},
+		},
+	}
+
+	r.Exists = func(d *ResourceData, m interface{}) (bool, error) {
+		if v := d.Get("newfoo").(int); v != 12 {
+			t.Fatalf("Exists got unmigrated state: %d", v)
+		}
+
+		return true, nil
+	}
+
+	r.Read = func(d *ResourceData, m interface{}) error {
+		return nil
+	}
+
+	r.MigrateState = func(
+		v int,
+		s *terraform.InstanceState,
+		meta interface{}) (*terraform.InstanceState, error) {
+		s.Attributes["newfoo"] = s.Attributes["oldfoo"]
+		delete(s.Attributes, "oldfoo")
+		return s, nil
+	}
+
+	s := &terraform.InstanceState{
+		ID: "bar",
+		Attributes: map[string]string{
+			"oldfoo": "12",
+		},
+	}
+
+	if _, err := r.Refresh(s, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestResourceRefresh_migrateStateErr(t *testing.T) {
 	r := &Resource{
 		SchemaVersion: 2,
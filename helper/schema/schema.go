@@ -89,9 +89,16 @@ type Schema struct {
 	// storing it in the state (and likewise before comparing for diffs).
 	// The use for this is for example with large strings, you may want
 	// to simply store the hash of it.
-	Computed  bool
-	ForceNew  bool
-	StateFunc SchemaStateFunc
+	//
+	// DiffSuppressFunc is a function used to determine whether a detected
+	// diff for this attribute is a real change or not. If it returns true
+	// the diff is suppressed and will not show up in the plan. This is
+	// meant for values that can be semantically equal while being textually
+	// different, like JSON documents with different whitespace.
+	Computed         bool
+	ForceNew         bool
+	StateFunc        SchemaStateFunc
+	DiffSuppressFunc SchemaDiffSuppressFunc
 
 	// The following fields are only set for a TypeList or TypeSet Type.
 	//
@@ -101,6 +108,14 @@ type Schema struct {
 	// element type is a complex structure, potentially with its own lifecycle.
 	Elem interface{}
 
+	// MaxItems and MinItems define the maximum and minimum amount of items
+	// that can exist within a TypeSet or TypeList, and are checked when
+	// the configuration is validated. A MaxItems of 1 is useful for a
+	// block that may only be given once, such as a complex structure
+	// wrapped in a list. Zero means no limit.
+	MaxItems int
+	MinItems int
+
 	// The following fields are only valid for a TypeSet type.
 	//
 	// Set defines a function to determine the unique ID of an item so that
@@ -132,6 +147,15 @@ type Schema struct {
 	// what do to about the removed attribute.
 	Removed string
 
+	// Sensitive ensures that the attribute's value does not get displayed in
+	// the plan output, in logs or when pretty-printing the state. The raw
+	// value is still stored in the state, so this is not a replacement for
+	// properly securing the state itself.
+	//
+	// When set on a TypeList, TypeSet or TypeMap, all of its elements are
+	// treated as sensitive as well.
+	Sensitive bool
+
 	// ValidateFunc allows individual fields to define arbitrary validation
 	// logic. It is yielded the provided config value as an interface{} that is
 	// guaranteed to be of the proper Schema type, and it can yield warnings or
@@ -141,6 +165,13 @@ type Schema struct {
 	ValidateFunc SchemaValidateFunc
 }
 
+// SchemaDiffSuppressFunc is a function which can be used to determine
+// whether a detected diff on a schema element is "valid" or not, and
+// suppress it from the plan if necessary.
+//
+// Return true if the diff should be suppressed, false to retain it.
+type SchemaDiffSuppressFunc func(k, old, new string, d *ResourceData) bool
+
 // SchemaDefaultFunc is a function called to return a default value for
 // a field.
 type SchemaDefaultFunc func() (interface{}, error)
@@ -163,13 +194,52 @@ func EnvDefaultFunc(k string, dv interface{}) SchemaDefaultFunc {
 // none of the environment variables return a value, the default value is
 // returned.
 func MultiEnvDefaultFunc(ks []string, dv interface{}) SchemaDefaultFunc {
+	fs := make([]SchemaDefaultFunc, 0, len(ks)+1)
+	for _, k := range ks {
+		fs = append(fs, EnvDefaultFunc(k, nil))
+	}
+
+	return DefaultFuncChain(append(fs, ValueDefaultFunc(dv))...)
+}
+
+// DefaultFuncChain is a helper function that composes default functions.
+// The functions are called in order, and the first value that is set is
+// returned, where nil and the empty string are not set. If none of the
+// functions return a value that is set, the value of the last function is
+// returned. An error returned by any of the functions is returned
+// immediately.
+//
+// This allows falling back through several sources, for example:
+//
+//	DefaultFuncChain(
+//		EnvDefaultFunc("FOO_TOKEN", nil),
+//		readTokenFile,
+//		ValueDefaultFunc("anonymous"),
+//	)
+func DefaultFuncChain(fs ...SchemaDefaultFunc) SchemaDefaultFunc {
 	return func() (interface{}, error) {
-		for _, k := range ks {
-			if v := os.Getenv(k); v != "" {
+		var v interface{}
+		for _, f := range fs {
+			var err error
+			v, err = f()
+			if err != nil {
+				return nil, err
+			}
+
+			if v != nil && v != "" {
 				return v, nil
 			}
 		}
-		return dv, nil
+
+		return v, nil
+	}
+}
+
+// ValueDefaultFunc is a helper function that always returns the given
+// value. It is mostly useful as the last function of a DefaultFuncChain.
+func ValueDefaultFunc(v interface{}) SchemaDefaultFunc {
+	return func() (interface{}, error) {
+		return v, nil
 	}
 }
 
@@ -259,9 +329,26 @@ func (s *Schema) finalizeDiff(
 		d.RequiresNew = true
 	}
 
+	if s.Sensitive {
+		// Sensitive, make sure the values are not displayed
+		d.Sensitive = true
+	}
+
 	return d
 }
 
+// inheritSensitive returns the given element schema, marked as sensitive
+// if this (parent) schema is sensitive.
+func (s *Schema) inheritSensitive(elem *Schema) *Schema {
+	if !s.Sensitive || elem.Sensitive {
+		return elem
+	}
+
+	result := *elem
+	result.Sensitive = true
+	return &result
+}
+
 // schemaMap is a wrapper that adds nice functions on top of schemas.
 type schemaMap map[string]*Schema
 
@@ -280,9 +367,14 @@ func (m schemaMap) Data(
 
 // Diff returns the diff for a resource given the schema map,
 // state, and configuration.
+//
+// If customizeDiff is non-nil it is called with the computed diff so the
+// resource can alter it before it is returned.
 func (m schemaMap) Diff(
 	s *terraform.InstanceState,
-	c *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	c *terraform.ResourceConfig,
+	customizeDiff CustomizeDiffFunc,
+	meta interface{}) (*terraform.InstanceDiff, error) {
 	result := new(terraform.InstanceDiff)
 	result.Attributes = make(map[string]*terraform.ResourceAttrDiff)
 
@@ -299,6 +391,13 @@ func (m schemaMap) Diff(
 		}
 	}
 
+	// Give the resource a chance to customize the diff
+	if customizeDiff != nil {
+		if err := customizeDiff(newResourceDiff(m, c, s, result), meta); err != nil {
+			return nil, err
+		}
+	}
+
 	// If the diff requires a new resource, then we recompute the diff
 	// so we have the complete new resource diff, and preserve the
 	// RequiresNew fields where necessary so the user knows exactly what
@@ -321,6 +420,14 @@ func (m schemaMap) Diff(
 			}
 		}
 
+		// Customize the new diff as well, any fields it forces new are
+		// preserved from the first pass below.
+		if customizeDiff != nil {
+			if err := customizeDiff(newResourceDiff(m, c, nil, result2), meta); err != nil {
+				return nil, err
+			}
+		}
+
 		// Force all the fields to not force a new since we know what we
 		// want to force new.
 		for k, attr := range result2.Attributes {
@@ -483,6 +590,12 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 			return fmt.Errorf("%s: ConflictsWith cannot be set with Required", k)
 		}
 
+		// A required attribute can't be phased out: configurations would
+		// have to keep setting it, and can't set it once it is removed.
+		if v.Required && (v.Deprecated != "" || v.Removed != "") {
+			return fmt.Errorf("%s: Deprecated and Removed cannot be set with Required", k)
+		}
+
 		if len(v.ConflictsWith) > 0 {
 			for _, key := range v.ConflictsWith {
 				parts := strings.Split(key, ".")
@@ -529,6 +642,14 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 				return fmt.Errorf("%s: Set can only be set for TypeSet", k)
 			}
 
+			if v.MaxItems < 0 || v.MinItems < 0 {
+				return fmt.Errorf("%s: MaxItems and MinItems can't be negative", k)
+			}
+
+			if v.MaxItems > 0 && v.MinItems > v.MaxItems {
+				return fmt.Errorf("%s: MinItems can't be greater than MaxItems", k)
+			}
+
 			switch t := v.Elem.(type) {
 			case *Resource:
 				if err := t.InternalValidate(topSchemaMap); err != nil {
@@ -543,6 +664,13 @@ func (m schemaMap) InternalValidate(topSchemaMap schemaMap) error {
 			}
 		}
 
+		if v.Type != TypeList && v.Type != TypeSet {
+			if v.MaxItems != 0 || v.MinItems != 0 {
+				return fmt.Errorf(
+					"%s: MaxItems and MinItems are only supported on lists or sets", k)
+			}
+		}
+
 		if v.ValidateFunc != nil {
 			switch v.Type {
 			case TypeList, TypeSet:
@@ -560,20 +688,38 @@ func (m schemaMap) diff(
 	diff *terraform.InstanceDiff,
 	d *ResourceData,
 	all bool) error {
+	// If we have a DiffSuppressFunc, we diff into a separate diff first
+	// so we can filter out all the suppressed attributes afterwards.
+	target := diff
+	if schema.DiffSuppressFunc != nil {
+		target = &terraform.InstanceDiff{
+			Attributes: make(map[string]*terraform.ResourceAttrDiff),
+		}
+	}
+
 	var err error
 	switch schema.Type {
 	case TypeBool, TypeInt, TypeFloat, TypeString:
-		err = m.diffString(k, schema, diff, d, all)
+		err = m.diffString(k, schema, target, d, all)
 	case TypeList:
-		err = m.diffList(k, schema, diff, d, all)
+		err = m.diffList(k, schema, target, d, all)
 	case TypeMap:
-		err = m.diffMap(k, schema, diff, d, all)
+		err = m.diffMap(k, schema, target, d, all)
 	case TypeSet:
-		err = m.diffSet(k, schema, diff, d, all)
+		err = m.diffSet(k, schema, target, d, all)
 	default:
 		err = fmt.Errorf("%s: unknown type %#v", k, schema.Type)
 	}
 
+	if target != diff {
+		for attrK, attrV := range target.Attributes {
+			if attrV != nil && schema.DiffSuppressFunc(attrK, attrV.Old, attrV.New, d) {
+				continue
+			}
+			diff.Attributes[attrK] = attrV
+		}
+	}
+
 	return err
 }
 
@@ -665,9 +811,9 @@ func (m schemaMap) diffList(
 	case *Resource:
 		// This is a complex resource
 		for i := 0; i < maxLen; i++ {
-			for k2, schema := range t.Schema {
+			for k2, s2 := range t.Schema {
 				subK := fmt.Sprintf("%s.%d.%s", k, i, k2)
-				err := m.diff(subK, schema, diff, d, all)
+				err := m.diff(subK, schema.inheritSensitive(s2), diff, d, all)
 				if err != nil {
 					return err
 				}
@@ -676,7 +822,7 @@ func (m schemaMap) diffList(
 	case *Schema:
 		// Copy the schema so that we can set Computed/ForceNew from
 		// the parent schema (the TypeList).
-		t2 := *t
+		t2 := *schema.inheritSensitive(t)
 		t2.ForceNew = schema.ForceNew
 
 		// This is just a primitive element, so go through each and
@@ -879,9 +1025,9 @@ func (m schemaMap) diffSet(
 			switch t := schema.Elem.(type) {
 			case *Resource:
 				// This is a complex resource
-				for k2, schema := range t.Schema {
+				for k2, s2 := range t.Schema {
 					subK := fmt.Sprintf("%s.%s.%s", k, code, k2)
-					err := m.diff(subK, schema, diff, d, true)
+					err := m.diff(subK, schema.inheritSensitive(s2), diff, d, true)
 					if err != nil {
 						return err
 					}
@@ -889,7 +1035,7 @@ func (m schemaMap) diffSet(
 			case *Schema:
 				// Copy the schema so that we can set Computed/ForceNew from
 				// the parent schema (the TypeSet).
-				t2 := *t
+				t2 := *schema.inheritSensitive(t)
 				t2.ForceNew = schema.ForceNew
 
 				// This is just a primitive element, so go through each and
@@ -963,6 +1109,28 @@ func (m schemaMap) diffString(
 	return nil
 }
 
+// sensitiveKeys returns the sorted keys of all the given (flattened)
+// attributes for which the schema, or any of its parents, is sensitive.
+func (m schemaMap) sensitiveKeys(attrs map[string]string) []string {
+	var keys []string
+	for k := range attrs {
+		// The number of elements is never considered sensitive
+		if strings.HasSuffix(k, ".#") {
+			continue
+		}
+
+		for _, schema := range addrToSchema(strings.Split(k, "."), m) {
+			if schema.Sensitive {
+				keys = append(keys, k)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 func (m schemaMap) inputString(
 	input terraform.UIInput,
 	k string,
@@ -1055,6 +1223,18 @@ func (m schemaMap) validateList(
 		raws[i] = rawV.Index(i).Interface()
 	}
 
+	// Validate the number of items
+	if schema.MaxItems > 0 && len(raws) > schema.MaxItems {
+		return nil, []error{fmt.Errorf(
+			"%s: attribute supports %d item(s) maximum, config has %d declared",
+			k, schema.MaxItems, len(raws))}
+	}
+	if schema.MinItems > 0 && len(raws) < schema.MinItems {
+		return nil, []error{fmt.Errorf(
+			"%s: attribute requires %d item(s) minimum, config has %d declared",
+			k, schema.MinItems, len(raws))}
+	}
+
 	var ws []string
 	var es []error
 	for i, raw := range raws {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/xanzy/terraform-api/config"
@@ -101,6 +102,62 @@ func TestMultiEnvDefaultFunc(t *testing.T) {
 	}
 }
 
+func TestDefaultFuncChain(t *testing.T) {
+	key := "TF_TEST_DEFAULT_FUNC_CHAIN"
+	defer os.Unsetenv(key)
+
+	f := DefaultFuncChain(
+		EnvDefaultFunc(key, nil),
+		ValueDefaultFunc(""),
+		ValueDefaultFunc("42"),
+	)
+
+	// Test that empty values are skipped
+	actual, err := f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != "42" {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// Test that the first value that is set is returned
+	if err := os.Setenv(key, "foo"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	actual, err = f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != "foo" {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// Test that the last value is returned if none are set
+	f = DefaultFuncChain(ValueDefaultFunc(nil), ValueDefaultFunc(""))
+	actual, err = f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != "" {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// Test that errors are returned immediately
+	f = DefaultFuncChain(
+		func() (interface{}, error) {
+			return nil, fmt.Errorf("error")
+		},
+		func() (interface{}, error) {
+			t.Fatal("should not be called")
+			return nil, nil
+		},
+	)
+	if _, err := f(); err == nil {
+		t.Fatal("should error")
+	}
+}
+
 func TestValueType_Zero(t *testing.T) {
 	cases := []struct {
 		Type  ValueType
@@ -129,6 +186,7 @@ func TestSchemaMap_Diff(t *testing.T) {
 		State           *terraform.InstanceState
 		Config          map[string]interface{}
 		ConfigVariables map[string]string
+		CustomizeDiff   CustomizeDiffFunc
 		Diff            *terraform.InstanceDiff
 		Err             bool
 	}{
@@ -2344,6 +2402,379 @@ func TestSchemaMap_Diff(t *testing.T) {
 
 			Err: false,
 		},
+
+		"#61 - Sensitive attribute": {
+			Schema: map[string]*Schema{
+				"password": &Schema{
+					Type:      TypeString,
+					Required:  true,
+					Sensitive: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"password": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"password": "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"password": &terraform.ResourceAttrDiff{
+						Old:       "foo",
+						New:       "bar",
+						Sensitive: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#62 - Sensitive list with nested resource": {
+			Schema: map[string]*Schema{
+				"user": &Schema{
+					Type:      TypeList,
+					Optional:  true,
+					Sensitive: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"name": &Schema{
+								Type:     TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+
+			State: nil,
+
+			Config: map[string]interface{}{
+				"user": []map[string]interface{}{
+					map[string]interface{}{
+						"name": "foo",
+					},
+				},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"user.#": &terraform.ResourceAttrDiff{
+						Old: "0",
+						New: "1",
+					},
+					"user.0.name": &terraform.ResourceAttrDiff{
+						Old:       "",
+						New:       "foo",
+						Sensitive: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#63 - DiffSuppressFunc suppresses the diff": {
+			Schema: map[string]*Schema{
+				"arn": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+						return strings.ToLower(old) == strings.ToLower(new)
+					},
+				},
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"arn":  "arn:aws:iam::123456789012:user/FOO",
+					"name": "foo",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"arn":  "arn:aws:iam::123456789012:user/foo",
+				"name": "bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": &terraform.ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#64 - DiffSuppressFunc keeps real changes": {
+			Schema: map[string]*Schema{
+				"arn": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+						return strings.ToLower(old) == strings.ToLower(new)
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"arn": "arn:aws:iam::123456789012:user/FOO",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"arn": "arn:aws:iam::123456789012:user/bar",
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"arn": &terraform.ResourceAttrDiff{
+						Old: "arn:aws:iam::123456789012:user/FOO",
+						New: "arn:aws:iam::123456789012:user/bar",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#65 - DiffSuppressFunc on list elements": {
+			Schema: map[string]*Schema{
+				"names": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+					DiffSuppressFunc: func(k, old, new string, d *ResourceData) bool {
+						return strings.ToLower(old) == strings.ToLower(new)
+					},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"names.#": "2",
+					"names.0": "FOO",
+					"names.1": "bar",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"names": []interface{}{"foo", "baz"},
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"names.1": &terraform.ResourceAttrDiff{
+						Old: "bar",
+						New: "baz",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#66 - CustomizeDiff SetNew on computed attribute": {
+			Schema: map[string]*Schema{
+				"name": &Schema{
+					Type:     TypeString,
+					Required: true,
+				},
+				"fqdn": &Schema{
+					Type:     TypeString,
+					Computed: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"name": "foo",
+					"fqdn": "foo.example.com",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "bar",
+			},
+
+			CustomizeDiff: func(d *ResourceDiff, meta interface{}) error {
+				return d.SetNew("fqdn", d.Get("name").(string)+".example.com")
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": &terraform.ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+					"fqdn": &terraform.ResourceAttrDiff{
+						Old: "foo.example.com",
+						New: "bar.example.com",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#67 - CustomizeDiff SetNewComputed": {
+			Schema: map[string]*Schema{
+				"version": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+				"checksums": &Schema{
+					Type:     TypeList,
+					Computed: true,
+					Elem:     &Schema{Type: TypeString},
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"version":     "1",
+					"checksums.#": "1",
+					"checksums.0": "abc",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"version": "2",
+			},
+
+			CustomizeDiff: func(d *ResourceDiff, meta interface{}) error {
+				if d.HasChange("version") {
+					return d.SetNewComputed("checksums")
+				}
+				return nil
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"version": &terraform.ResourceAttrDiff{
+						Old: "1",
+						New: "2",
+					},
+					"checksums.#": &terraform.ResourceAttrDiff{
+						Old:         "1",
+						NewComputed: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#68 - CustomizeDiff ForceNew": {
+			Schema: map[string]*Schema{
+				"size": &Schema{
+					Type:     TypeInt,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"size": "20",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"size": 10,
+			},
+
+			CustomizeDiff: func(d *ResourceDiff, meta interface{}) error {
+				o, n := d.GetChange("size")
+				if n.(int) < o.(int) {
+					return d.ForceNew("size")
+				}
+				return nil
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"size": &terraform.ResourceAttrDiff{
+						Old:         "20",
+						New:         "10",
+						RequiresNew: true,
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#69 - CustomizeDiff Clear": {
+			Schema: map[string]*Schema{
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+				"description": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			State: &terraform.InstanceState{
+				Attributes: map[string]string{
+					"name":        "foo",
+					"description": "old",
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name":        "bar",
+				"description": "new",
+			},
+
+			CustomizeDiff: func(d *ResourceDiff, meta interface{}) error {
+				return d.Clear("description")
+			},
+
+			Diff: &terraform.InstanceDiff{
+				Attributes: map[string]*terraform.ResourceAttrDiff{
+					"name": &terraform.ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+				},
+			},
+
+			Err: false,
+		},
+
+		"#70 - CustomizeDiff SetNew on non-computed attribute": {
+			Schema: map[string]*Schema{
+				"name": &Schema{
+					Type:     TypeString,
+					Optional: true,
+				},
+			},
+
+			Config: map[string]interface{}{
+				"name": "bar",
+			},
+
+			CustomizeDiff: func(d *ResourceDiff, meta interface{}) error {
+				return d.SetNew("name", "baz")
+			},
+
+			Err: true,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -2364,7 +2795,7 @@ func TestSchemaMap_Diff(t *testing.T) {
 		}
 
 		d, err := schemaMap(tc.Schema).Diff(
-			tc.State, terraform.NewResourceConfig(c))
+			tc.State, terraform.NewResourceConfig(c), tc.CustomizeDiff, nil)
 		if err != nil != tc.Err {
 			t.Fatalf("#%q err: %s", tn, err)
 		}
@@ -2854,6 +3285,87 @@ func TestSchemaMap_InternalValidate(t *testing.T) {
 			},
 			true,
 		},
+
+		"MaxItems on list": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+					MaxItems: 1,
+				},
+			},
+			false,
+		},
+
+		"MaxItems on non-list": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeString,
+					Optional: true,
+					MaxItems: 1,
+				},
+			},
+			true,
+		},
+
+		"MinItems greater than MaxItems": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeSet,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+					MinItems: 2,
+					MaxItems: 1,
+				},
+			},
+			true,
+		},
+
+		"Negative MinItems": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem:     &Schema{Type: TypeString},
+					MinItems: -1,
+				},
+			},
+			true,
+		},
+
+		"Deprecated with Required": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:       TypeString,
+					Required:   true,
+					Deprecated: "use bar",
+				},
+			},
+			true,
+		},
+
+		"Removed with Required": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:     TypeString,
+					Required: true,
+					Removed:  "use bar",
+				},
+			},
+			true,
+		},
+
+		"Deprecated with Optional": {
+			map[string]*Schema{
+				"foo": &Schema{
+					Type:       TypeString,
+					Optional:   true,
+					Deprecated: "use bar",
+				},
+			},
+			false,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -3401,6 +3913,38 @@ func TestSchemaMap_Validate(t *testing.T) {
 			},
 		},
 
+		"Deprecated nested attribute usage generates warning": {
+			Schema: map[string]*Schema{
+				"connection": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"key_file": &Schema{
+								Type:       TypeString,
+								Optional:   true,
+								Deprecated: "please use 'private_key' instead",
+							},
+						},
+					},
+				},
+			},
+
+			Config: map[string]interface{}{
+				"connection": []interface{}{
+					map[string]interface{}{
+						"key_file": "~/.ssh/id_rsa",
+					},
+				},
+			},
+
+			Err: false,
+
+			Warnings: []string{
+				"\"connection.0.key_file\": [DEPRECATED] please use 'private_key' instead",
+			},
+		},
+
 		"Deprecated generates no warnings if attr not used": {
 			Schema: map[string]*Schema{
 				"old_news": &Schema{
@@ -3605,6 +4149,89 @@ func TestSchemaMap_Validate(t *testing.T) {
 
 			Err: false,
 		},
+
+		"MaxItems exceeded": {
+			Schema: map[string]*Schema{
+				"options": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"size": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			Config: map[string]interface{}{
+				"options": []interface{}{
+					map[string]interface{}{"size": 1},
+					map[string]interface{}{"size": 2},
+				},
+			},
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("options: attribute supports 1 item(s) maximum, config has 2 declared"),
+			},
+		},
+
+		"MaxItems not exceeded": {
+			Schema: map[string]*Schema{
+				"options": &Schema{
+					Type:     TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &Resource{
+						Schema: map[string]*Schema{
+							"size": &Schema{
+								Type:     TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+			Config: map[string]interface{}{
+				"options": []interface{}{
+					map[string]interface{}{"size": 1},
+				},
+			},
+		},
+
+		"MinItems not met": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeSet,
+					Required: true,
+					MinItems: 2,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+			Config: map[string]interface{}{
+				"ports": []interface{}{80},
+			},
+			Err: true,
+			Errors: []error{
+				fmt.Errorf("ports: attribute requires 2 item(s) minimum, config has 1 declared"),
+			},
+		},
+
+		"MinItems met": {
+			Schema: map[string]*Schema{
+				"ports": &Schema{
+					Type:     TypeSet,
+					Required: true,
+					MinItems: 2,
+					Elem:     &Schema{Type: TypeInt},
+				},
+			},
+			Config: map[string]interface{}{
+				"ports": []interface{}{80, 443},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
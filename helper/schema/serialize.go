@@ -49,7 +49,13 @@ func SerializeValueForHash(buf *bytes.Buffer, val interface{}, schema *Schema) {
 		buf.WriteRune(']')
 	case TypeSet:
 		buf.WriteRune('{')
-		s := val.(*Set)
+		s, ok := val.(*Set)
+		if !ok {
+			// Nested sets of values that haven't been read back yet are
+			// still plain lists. Build the set so the items are in the
+			// same order, using the Set function of the schema.
+			s = NewSet(schema.ZeroValue().(*Set).F, val.([]interface{}))
+		}
 		for _, innerVal := range s.List() {
 			serializeCollectionMemberForHash(buf, innerVal, schema.Elem)
 		}
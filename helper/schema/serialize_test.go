@@ -193,6 +193,32 @@ func TestSerializeForHash(t *testing.T) {
 			},
 			Expected: "green:1;name:my-fun-database;size:12;",
 		},
+
+		testCase{
+			Schema: &Resource{
+				Schema: map[string]*Schema{
+					"name": &Schema{
+						Type:     TypeString,
+						Required: true,
+					},
+					"ports": &Schema{
+						Type:     TypeSet,
+						Optional: true,
+						Elem: &Schema{
+							Type: TypeInt,
+						},
+						Set: func(v interface{}) int {
+							return v.(int)
+						},
+					},
+				},
+			},
+			Value: map[string]interface{}{
+				"name":  "web",
+				"ports": []interface{}{443, 80},
+			},
+			Expected: "name:web;ports:{443;80;};",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,382 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GetStruct decodes the value of the given key into the Go value that out
+// points to. See DecodeStruct for how values are decoded.
+func (d *ResourceData) GetStruct(key string, out interface{}) error {
+	if err := DecodeStruct(d.Get(key), out); err != nil {
+		return fmt.Errorf("%s: %s", key, err)
+	}
+
+	return nil
+}
+
+// SetStruct encodes the given Go value and sets it as the value of the
+// given key. See EncodeStruct for how values are encoded.
+func (d *ResourceData) SetStruct(key string, in interface{}) error {
+	v, err := EncodeStruct(in)
+	if err != nil {
+		return fmt.Errorf("%s: %s", key, err)
+	}
+
+	return d.Set(key, v)
+}
+
+// DecodeStruct decodes a value as returned by ResourceData.Get into the Go
+// value that out points to, so nested blocks can be used as typed structs
+// instead of casting the elements of the list to maps.
+//
+// A nested block, which is a list or set with a single map, can be
+// decoded into a struct or a pointer to a struct. The pointer is left nil
+// if the list is empty. A list or set of blocks can be decoded into a
+// slice of structs.
+//
+// The fields of a struct are matched by their "tf" tag, or by the name of
+// the field in snake case if there is no tag. Fields tagged with "-" are
+// skipped. Numbers are converted to the type of the field, so an int in
+// the schema can be decoded into an int64 field.
+func DecodeStruct(raw interface{}, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("DecodeStruct needs a non-nil pointer, got %T", out)
+	}
+
+	return decodeValue("", raw, v.Elem())
+}
+
+// EncodeStruct encodes a Go value into a value that can be passed to
+// ResourceData.Set. It is the reverse of DecodeStruct: a struct, or a
+// pointer to one, is encoded as a list with a single map, where a nil
+// pointer is an empty list. Fields with the "omitempty" tag option are
+// left out if they have their zero value.
+func EncodeStruct(in interface{}) (interface{}, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	return encodeValue("", reflect.ValueOf(in))
+}
+
+func decodeValue(k string, raw interface{}, v reflect.Value) error {
+	if raw == nil {
+		return nil
+	}
+
+	// Sets are decoded as the list of their items
+	if s, ok := raw.(*Set); ok {
+		raw = s.List()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if l, ok := raw.([]interface{}); ok && len(l) == 0 {
+			return nil
+		}
+
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeValue(k, raw, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Struct:
+		return decodeStruct(k, raw, v)
+	case reflect.Slice:
+		l, ok := raw.([]interface{})
+		if !ok {
+			return structError(k, "expected a list, got %T", raw)
+		}
+
+		result := reflect.MakeSlice(v.Type(), len(l), len(l))
+		for i, item := range l {
+			ik := structKey(k, strconv.Itoa(i))
+			if err := decodeValue(ik, item, result.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(result)
+		return nil
+	case reflect.Map:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return structError(k, "expected a map, got %T", raw)
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return structError(k, "map keys must be strings")
+		}
+
+		result := reflect.MakeMap(v.Type())
+		for mk, mv := range m {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeValue(structKey(k, mk), mv, elem); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(mk).Convert(v.Type().Key()), elem)
+		}
+		v.Set(result)
+		return nil
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(raw))
+		return nil
+	default:
+		return decodePrimitive(k, raw, v)
+	}
+}
+
+func decodeStruct(k string, raw interface{}, v reflect.Value) error {
+	// A nested block is a list with at most a single element
+	if l, ok := raw.([]interface{}); ok {
+		switch len(l) {
+		case 0:
+			return nil
+		case 1:
+			raw = l[0]
+			if raw == nil {
+				return nil
+			}
+		default:
+			return structError(k, "expected a single block, got %d", len(l))
+		}
+	}
+
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return structError(k, "expected a block, got %T", raw)
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, ok := structFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(structKey(k, name), m[name], v.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodePrimitive(k string, raw interface{}, v reflect.Value) error {
+	rv := reflect.ValueOf(raw)
+	if kindClass(rv.Kind()) != kindClass(v.Kind()) || kindClass(v.Kind()) == reflect.Invalid {
+		return structError(k, "expected %s, got %T", v.Type(), raw)
+	}
+
+	v.Set(rv.Convert(v.Type()))
+	return nil
+}
+
+func encodeValue(k string, v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if v.Type().Elem().Kind() == reflect.Struct {
+				return []interface{}{}, nil
+			}
+
+			return nil, nil
+		}
+
+		return encodeValue(k, v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return encodeValue(k, v.Elem())
+	case reflect.Struct:
+		m, err := encodeStruct(k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		return []interface{}{m}, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ik := structKey(k, strconv.Itoa(i))
+			item := v.Index(i)
+
+			// The items of a list of blocks are the maps themselves
+			for item.Kind() == reflect.Ptr && !item.IsNil() {
+				item = item.Elem()
+			}
+
+			var err error
+			if item.Kind() == reflect.Struct {
+				result[i], err = encodeStruct(ik, item)
+			} else {
+				result[i], err = encodeValue(ik, item)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return result, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, structError(k, "map keys must be strings")
+		}
+
+		result := make(map[string]interface{}, v.Len())
+		for _, mk := range v.MapKeys() {
+			ev, err := encodeValue(structKey(k, mk.String()), v.MapIndex(mk))
+			if err != nil {
+				return nil, err
+			}
+			result[mk.String()] = ev
+		}
+
+		return result, nil
+	}
+
+	// Primitives are encoded as the types used by the schema
+	switch kindClass(v.Kind()) {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int:
+		if v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr {
+			return int(v.Uint()), nil
+		}
+		return int(v.Int()), nil
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	}
+
+	return nil, structError(k, "unsupported type %s", v.Type())
+}
+
+func encodeStruct(k string, v reflect.Value) (map[string]interface{}, error) {
+	t := v.Type()
+	result := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		name, omitEmpty, ok := structFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+
+		f := v.Field(i)
+		if omitEmpty && isZeroValue(f) {
+			continue
+		}
+
+		ev, err := encodeValue(structKey(k, name), f)
+		if err != nil {
+			return nil, err
+		}
+		if ev != nil {
+			result[name] = ev
+		}
+	}
+
+	return result, nil
+}
+
+// structFieldName returns the name of the attribute the given struct field
+// maps to, and whether the field has the omitempty option. It returns
+// false if the field isn't mapped to an attribute.
+func structFieldName(f reflect.StructField) (string, bool, bool) {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return "", false, false
+	}
+
+	tag := f.Tag.Get("tf")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = snakeCase(f.Name)
+	}
+
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, true
+}
+
+// snakeCase converts a Go field name such as VolumeSize or EBSEnabled to
+// the attribute name volume_size or ebs_enabled.
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var buf []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				buf = append(buf, '_')
+			}
+		}
+		buf = append(buf, unicode.ToLower(r))
+	}
+
+	return string(buf)
+}
+
+// kindClass groups the kinds that can be converted into each other when
+// decoding and encoding primitives.
+func kindClass(k reflect.Kind) reflect.Kind {
+	switch {
+	case k == reflect.Bool:
+		return reflect.Bool
+	case k >= reflect.Int && k <= reflect.Uintptr:
+		return reflect.Int
+	case k == reflect.Float32 || k == reflect.Float64:
+		return reflect.Float64
+	case k == reflect.String:
+		return reflect.String
+	default:
+		return reflect.Invalid
+	}
+}
+
+func isZeroValue(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// structKey returns the key of the given attribute of a nested value, for
+// use in error messages.
+func structKey(k, name string) string {
+	if k == "" {
+		return name
+	}
+
+	return k + "." + name
+}
+
+func structError(k string, format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	if k == "" {
+		return err
+	}
+
+	return fmt.Errorf("%s: %s", k, err)
+}
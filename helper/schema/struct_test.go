@@ -0,0 +1,255 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+type testStructEBS struct {
+	EBSEnabled bool   `tf:"ebs_enabled"`
+	VolumeSize int64  `tf:"volume_size"`
+	VolumeType string `tf:",omitempty"`
+	Ignored    string `tf:"-"`
+}
+
+type testStructCluster struct {
+	InstanceCount int
+	Zones         []string
+	Tags          map[string]string
+	EBS           *testStructEBS   `tf:"ebs"`
+	Nodes         []testStructNode `tf:"node"`
+}
+
+type testStructNode struct {
+	Name string
+	Port int
+}
+
+func TestDecodeStruct(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"instance_count": 3,
+			"zones":          []interface{}{"a", "b"},
+			"tags":           map[string]interface{}{"env": "prod"},
+			"ebs": []interface{}{
+				map[string]interface{}{
+					"ebs_enabled": true,
+					"volume_size": 10,
+					"volume_type": "gp2",
+				},
+			},
+			"node": NewSet(func(v interface{}) int {
+				return v.(map[string]interface{})["port"].(int)
+			}, []interface{}{
+				map[string]interface{}{"name": "one", "port": 1},
+				map[string]interface{}{"name": "two", "port": 2},
+			}),
+		},
+	}
+
+	var actual testStructCluster
+	if err := DecodeStruct(raw, &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := testStructCluster{
+		InstanceCount: 3,
+		Zones:         []string{"a", "b"},
+		Tags:          map[string]string{"env": "prod"},
+		EBS: &testStructEBS{
+			EBSEnabled: true,
+			VolumeSize: 10,
+			VolumeType: "gp2",
+		},
+		Nodes: []testStructNode{
+			{Name: "one", Port: 1},
+			{Name: "two", Port: 2},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestDecodeStruct_empty(t *testing.T) {
+	var ebs *testStructEBS
+	if err := DecodeStruct([]interface{}{}, &ebs); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ebs != nil {
+		t.Fatalf("bad: %#v", ebs)
+	}
+
+	var cluster testStructCluster
+	if err := DecodeStruct(nil, &cluster); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(cluster, testStructCluster{}) {
+		t.Fatalf("bad: %#v", cluster)
+	}
+}
+
+func TestDecodeStruct_errors(t *testing.T) {
+	cases := map[string]struct {
+		Raw interface{}
+		Out interface{}
+		Err string
+	}{
+		"not a pointer": {
+			[]interface{}{},
+			testStructEBS{},
+			"DecodeStruct needs a non-nil pointer, got schema.testStructEBS",
+		},
+
+		"multiple blocks": {
+			[]interface{}{
+				map[string]interface{}{},
+				map[string]interface{}{},
+			},
+			new(testStructEBS),
+			"expected a single block, got 2",
+		},
+
+		"wrong type": {
+			[]interface{}{
+				map[string]interface{}{"volume_size": "10"},
+			},
+			new(testStructEBS),
+			"volume_size: expected int64, got string",
+		},
+
+		"nested wrong type": {
+			[]interface{}{
+				map[string]interface{}{
+					"node": []interface{}{
+						map[string]interface{}{"port": true},
+					},
+				},
+			},
+			new(testStructCluster),
+			"node.0.port: expected int, got bool",
+		},
+	}
+
+	for name, tc := range cases {
+		err := DecodeStruct(tc.Raw, tc.Out)
+		if err == nil {
+			t.Fatalf("%s: should error", name)
+		}
+		if err.Error() != tc.Err {
+			t.Fatalf("%s: bad: %s", name, err)
+		}
+	}
+}
+
+func TestEncodeStruct(t *testing.T) {
+	in := &testStructCluster{
+		InstanceCount: 3,
+		Zones:         []string{"a"},
+		EBS: &testStructEBS{
+			EBSEnabled: true,
+			VolumeSize: 10,
+			Ignored:    "foo",
+		},
+		Nodes: []testStructNode{
+			{Name: "one", Port: 1},
+		},
+	}
+
+	actual, err := EncodeStruct(in)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"instance_count": 3,
+			"zones":          []interface{}{"a"},
+			"ebs": []interface{}{
+				map[string]interface{}{
+					"ebs_enabled": true,
+					"volume_size": 10,
+				},
+			},
+			"node": []interface{}{
+				map[string]interface{}{"name": "one", "port": 1},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	var nilEBS *testStructEBS
+	actual, err = EncodeStruct(nilEBS)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(actual, []interface{}{}) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResourceDataStruct(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"ebs": &Schema{
+				Type:     TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"ebs_enabled": &Schema{
+							Type:     TypeBool,
+							Required: true,
+						},
+						"volume_size": &Schema{
+							Type:     TypeInt,
+							Optional: true,
+						},
+						"volume_type": &Schema{
+							Type:     TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	d, err := schemaMap(r.Schema).Data(nil, &terraform.InstanceDiff{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	in := &testStructEBS{EBSEnabled: true, VolumeSize: 20, VolumeType: "io1"}
+	if err := d.SetStruct("ebs", in); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var out *testStructEBS
+	if err := d.GetStruct("ebs", &out); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("bad: %#v", out)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Name":          "name",
+		"VolumeSize":    "volume_size",
+		"EBSEnabled":    "ebs_enabled",
+		"InstanceCount": "instance_count",
+		"Port80":        "port80",
+	}
+
+	for in, expected := range cases {
+		if actual := snakeCase(in); actual != expected {
+			t.Fatalf("%s: bad: %s", in, actual)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package validation contains reusable validation functions that can be
+// used as the ValidateFunc of a schema.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+// All returns a SchemaValidateFunc which runs all of the given validators
+// and collects their warnings and errors.
+func All(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, es []error) {
+		for _, validator := range validators {
+			w, e := validator(v, k)
+			ws = append(ws, w...)
+			es = append(es, e...)
+		}
+		return
+	}
+}
+
+// StringLenBetween returns a SchemaValidateFunc which checks that the
+// length of a string is between min and max, inclusive.
+func StringLenBetween(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (ws []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+
+		if len(v) < min || len(v) > max {
+			es = append(es, fmt.Errorf(
+				"expected length of %q to be in the range (%d - %d), got %d",
+				k, min, max, len(v)))
+		}
+		return
+	}
+}
+
+// IntBetween returns a SchemaValidateFunc which checks that an int is
+// between min and max, inclusive.
+func IntBetween(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (ws []string, es []error) {
+		v, ok := i.(int)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %q to be int", k))
+			return
+		}
+
+		if v < min || v > max {
+			es = append(es, fmt.Errorf(
+				"expected %q to be in the range (%d - %d), got %d", k, min, max, v))
+		}
+		return
+	}
+}
+
+// StringInSlice returns a SchemaValidateFunc which checks that a string is
+// one of the given valid values, optionally ignoring case.
+func StringInSlice(valid []string, ignoreCase bool) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (ws []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+
+		for _, str := range valid {
+			if v == str || (ignoreCase && strings.ToLower(v) == strings.ToLower(str)) {
+				return
+			}
+		}
+
+		es = append(es, fmt.Errorf("expected %q to be one of %v, got %s", k, valid, v))
+		return
+	}
+}
+
+// StringMatch returns a SchemaValidateFunc which checks that a string
+// matches the given regular expression. The message is used as the error
+// if it doesn't match, prefixed with the name of the attribute. If the
+// message is empty, a generic message mentioning the expression is used.
+func StringMatch(r *regexp.Regexp, message string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (ws []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+
+		if !r.MatchString(v) {
+			if message != "" {
+				es = append(es, fmt.Errorf("%q %s", k, message))
+			} else {
+				es = append(es, fmt.Errorf(
+					"expected %q to match regular expression %q, got %q", k, r, v))
+			}
+		}
+		return
+	}
+}
+
+// CIDRNetwork returns a SchemaValidateFunc which checks that a string is a
+// CIDR network address, with a prefix size between min and max bits,
+// inclusive. The address must be the network address itself, so
+// "10.0.0.0/16" is valid but "10.0.1.0/16" isn't.
+func CIDRNetwork(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (ws []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+
+		_, ipnet, err := net.ParseCIDR(v)
+		if err != nil {
+			es = append(es, fmt.Errorf(
+				"expected %q to contain a valid CIDR, got %q: %s", k, v, err))
+			return
+		}
+
+		if ipnet == nil || v != ipnet.String() {
+			es = append(es, fmt.Errorf(
+				"expected %q to contain a valid network CIDR, expected %s, got %s",
+				k, ipnet, v))
+			return
+		}
+
+		sigbits, _ := ipnet.Mask.Size()
+		if sigbits < min || sigbits > max {
+			es = append(es, fmt.Errorf(
+				"expected %q to contain a network CIDR with between %d and %d significant bits, got %d",
+				k, min, max, sigbits))
+		}
+		return
+	}
+}
+
+// ARN is a SchemaValidateFunc which checks that a string is an Amazon
+// Resource Name, in the form arn:partition:service:region:account:resource.
+// The region and account may be empty, as they are for some services.
+func ARN(i interface{}, k string) (ws []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	parts := strings.SplitN(v, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" ||
+		parts[1] == "" || parts[2] == "" || parts[5] == "" {
+		es = append(es, fmt.Errorf("expected %q to be a valid ARN, got %q", k, v))
+	}
+	return
+}
+
+// JSONString is a SchemaValidateFunc which checks that a string contains
+// valid JSON.
+func JSONString(i interface{}, k string) (ws []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	var js interface{}
+	if err := json.Unmarshal([]byte(v), &js); err != nil {
+		es = append(es, fmt.Errorf("%q contains invalid JSON: %s", k, err))
+	}
+	return
+}
@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/terraform-api/helper/schema"
+)
+
+type validateCase struct {
+	Value interface{}
+	Err   string
+}
+
+func runValidateCases(t *testing.T, f schema.SchemaValidateFunc, cases []validateCase) {
+	for i, tc := range cases {
+		_, es := f(tc.Value, "test_property")
+
+		if tc.Err == "" {
+			if len(es) > 0 {
+				t.Fatalf("%d: %#v: unexpected errors: %v", i, tc.Value, es)
+			}
+			continue
+		}
+
+		if len(es) == 0 {
+			t.Fatalf("%d: %#v: expected an error containing %q", i, tc.Value, tc.Err)
+		}
+		if !strings.Contains(es[0].Error(), tc.Err) {
+			t.Fatalf("%d: %#v: bad error: %s", i, tc.Value, es[0])
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	f := All(
+		StringLenBetween(1, 3),
+		StringMatch(regexp.MustCompile(`^[a-z]+$`), "must be lowercase"),
+	)
+
+	_, es := f("ab", "test_property")
+	if len(es) != 0 {
+		t.Fatalf("bad: %v", es)
+	}
+
+	_, es = f("ABCD", "test_property")
+	if len(es) != 2 {
+		t.Fatalf("bad: %v", es)
+	}
+}
+
+func TestStringLenBetween(t *testing.T) {
+	runValidateCases(t, StringLenBetween(2, 4), []validateCase{
+		{"ab", ""},
+		{"abcd", ""},
+		{"a", "expected length of \"test_property\" to be in the range (2 - 4), got 1"},
+		{"abcde", "got 5"},
+		{1, "to be string"},
+	})
+}
+
+func TestIntBetween(t *testing.T) {
+	runValidateCases(t, IntBetween(1, 10), []validateCase{
+		{1, ""},
+		{10, ""},
+		{0, "expected \"test_property\" to be in the range (1 - 10), got 0"},
+		{11, "got 11"},
+		{"1", "to be int"},
+	})
+}
+
+func TestStringInSlice(t *testing.T) {
+	runValidateCases(t, StringInSlice([]string{"foo", "bar"}, false), []validateCase{
+		{"foo", ""},
+		{"bar", ""},
+		{"FOO", "expected \"test_property\" to be one of [foo bar], got FOO"},
+		{1, "to be string"},
+	})
+
+	runValidateCases(t, StringInSlice([]string{"foo", "bar"}, true), []validateCase{
+		{"FOO", ""},
+		{"baz", "got baz"},
+	})
+}
+
+func TestStringMatch(t *testing.T) {
+	r := regexp.MustCompile(`^[a-z]+$`)
+
+	runValidateCases(t, StringMatch(r, "must be lowercase"), []validateCase{
+		{"foo", ""},
+		{"Foo", "\"test_property\" must be lowercase"},
+	})
+
+	runValidateCases(t, StringMatch(r, ""), []validateCase{
+		{"Foo", "expected \"test_property\" to match regular expression"},
+	})
+}
+
+func TestCIDRNetwork(t *testing.T) {
+	runValidateCases(t, CIDRNetwork(16, 24), []validateCase{
+		{"10.0.0.0/16", ""},
+		{"10.0.0.0/24", ""},
+		{"10.0.0.0", "to contain a valid CIDR"},
+		{"10.0.1.0/16", "to contain a valid network CIDR"},
+		{"10.0.0.0/8", "between 16 and 24 significant bits, got 8"},
+		{"10.0.0.0/28", "got 28"},
+	})
+}
+
+func TestARN(t *testing.T) {
+	runValidateCases(t, ARN, []validateCase{
+		{"arn:aws:iam::123456789012:role/foo", ""},
+		{"arn:aws:s3:::my-bucket", ""},
+		{"arn:aws:sns:us-west-2:123456789012:topic:sub", ""},
+		{"arn:aws-us-gov:ec2:us-gov-west-1:123456789012:instance/i-1", ""},
+		{"arn:aws:iam::123456789012", "to be a valid ARN"},
+		{"aws:iam::123456789012:role/foo", "to be a valid ARN"},
+		{"arn:aws:::123456789012:foo", "to be a valid ARN"},
+	})
+}
+
+func TestJSONString(t *testing.T) {
+	runValidateCases(t, JSONString, []validateCase{
+		{`{"foo": "bar"}`, ""},
+		{`[]`, ""},
+		{`{"foo": }`, "contains invalid JSON"},
+		{``, "contains invalid JSON"},
+	})
+}
@@ -136,6 +136,7 @@ func wrappedMain() int {
 	// Initialize the TFConfig settings for the commands...
 	ContextOpts.Providers = config.ProviderFactories()
 	ContextOpts.Provisioners = config.ProvisionerFactories()
+	ProviderPlugins = config.ProviderPlugins()
 
 	exitCode, err := cli.Run()
 	if err != nil {
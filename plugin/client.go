@@ -65,6 +65,18 @@ type ClientConfig struct {
 	// If non-nil, then the stderr of the client will be written to here
 	// (as well as the log).
 	Stderr io.Writer
+
+	// LogFunc, if non-nil, is called with every line of the log output of
+	// the plugin, so the host can filter it by level and attribute it to
+	// the plugin. If it is nil, the lines are written to the log of the
+	// host with their level, prefixed with the name of the plugin binary.
+	// It may be called from several goroutines at once.
+	LogFunc func(tfrpc.LogEntry)
+
+	// If non-nil, the checksum of the plugin binary is verified before
+	// it is executed. Start returns ErrChecksumsDoNotMatch if it doesn't
+	// match.
+	SecureConfig *SecureConfig
 }
 
 // This makes sure all the managed subprocesses are killed and properly
@@ -142,6 +154,16 @@ func (c *Client) Client() (*tfrpc.Client, error) {
 		return nil, err
 	}
 
+	// Stream the log output of the plugin. Older plugins don't support
+	// this, and keep logging to stderr.
+	logs, err := c.client.Logs()
+	if err != nil {
+		log.Printf("[DEBUG] %s: not streaming logs: %s",
+			filepath.Base(c.config.Cmd.Path), err)
+	} else {
+		go c.logLines(logs)
+	}
+
 	return c.client, nil
 }
 
@@ -185,6 +207,17 @@ func (c *Client) Start() (addr net.Addr, err error) {
 		return c.address, nil
 	}
 
+	// Verify the binary before running it
+	if c.config.SecureConfig != nil {
+		ok, err := c.config.SecureConfig.Check(c.config.Cmd.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Error verifying checksum of %s: %s", c.config.Cmd.Path, err)
+		}
+		if !ok {
+			return nil, ErrChecksumsDoNotMatch
+		}
+	}
+
 	c.doneLogging = make(chan struct{})
 
 	env := []string{
@@ -324,9 +357,7 @@ func (c *Client) logStderr(r io.Reader) {
 		line, err := bufR.ReadString('\n')
 		if line != "" {
 			c.config.Stderr.Write([]byte(line))
-
-			line = strings.TrimRightFunc(line, unicode.IsSpace)
-			log.Printf("[DEBUG] %s: %s", filepath.Base(c.config.Cmd.Path), line)
+			c.logLine(line)
 		}
 
 		if err == io.EOF {
@@ -337,3 +368,38 @@ func (c *Client) logStderr(r io.Reader) {
 	// Flag that we've completed logging for others
 	close(c.doneLogging)
 }
+
+// logLines logs the lines of the log stream of the plugin until it is
+// closed.
+func (c *Client) logLines(r io.ReadCloser) {
+	defer r.Close()
+
+	bufR := bufio.NewReader(r)
+	for {
+		line, err := bufR.ReadString('\n')
+		if line != "" {
+			c.logLine(line)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *Client) logLine(line string) {
+	entry := tfrpc.ParseLogLine(
+		strings.TrimRightFunc(line, unicode.IsSpace))
+	if c.config.LogFunc != nil {
+		c.config.LogFunc(entry)
+		return
+	}
+
+	// Lines without a level keep being logged as debug output
+	level := entry.Level
+	if level == "" {
+		level = "DEBUG"
+	}
+
+	log.Printf("[%s] %s: %s", level, filepath.Base(c.config.Cmd.Path), entry.Message)
+}
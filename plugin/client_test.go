@@ -2,6 +2,8 @@ package plugin
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -71,6 +73,52 @@ func TestClient_Start_Timeout(t *testing.T) {
 	}
 }
 
+func TestClient_SecureConfig(t *testing.T) {
+	process := helperProcess("stderr")
+
+	// A bad checksum refuses to start the plugin
+	c := NewClient(&ClientConfig{
+		Cmd: process,
+		SecureConfig: &SecureConfig{
+			Checksum: []byte{1, 2, 3, 4},
+			Hash:     sha256.New,
+		},
+	})
+	defer c.Kill()
+
+	if _, err := c.Start(); err != ErrChecksumsDoNotMatch {
+		t.Fatalf("err: %s", err)
+	}
+	if process.Process != nil {
+		t.Fatal("process should not be started")
+	}
+
+	// The right checksum starts it
+	f, err := os.Open(process.Path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	c = NewClient(&ClientConfig{
+		Cmd: helperProcess("stderr"),
+		SecureConfig: &SecureConfig{
+			Checksum: h.Sum(nil),
+			Hash:     sha256.New,
+		},
+	})
+	defer c.Kill()
+
+	if _, err := c.Start(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestClient_Stderr(t *testing.T) {
 	stderr := new(bytes.Buffer)
 	process := helperProcess("stderr")
@@ -0,0 +1,30 @@
+package discovery
+
+import (
+	"os/exec"
+
+	"github.com/xanzy/terraform-api/plugin"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// ProviderFactory returns a ResourceProviderFactory that starts the
+// plugin binary of a provider and talks to it over RPC. If secure isn't
+// nil, the binary is verified with it before it is started.
+func (m PluginMeta) ProviderFactory(
+	secure *plugin.SecureConfig) terraform.ResourceProviderFactory {
+	// Build the plugin client configuration and init the plugin
+	var config plugin.ClientConfig
+	config.Cmd = exec.Command(m.Path)
+	config.Managed = true
+	config.SecureConfig = secure
+	client := plugin.NewClient(&config)
+
+	return func() (terraform.ResourceProvider, error) {
+		rpcClient, err := client.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		return rpcClient.ResourceProvider()
+	}
+}
@@ -0,0 +1,150 @@
+// The discovery package finds plugin binaries on disk, parses their
+// versions from the file names, and picks the versions that meet the
+// version constraints declared in the configuration.
+package discovery
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PluginMeta describes a plugin binary that was found on disk.
+type PluginMeta struct {
+	// Name is the name of the plugin, such as "aws" for
+	// terraform-provider-aws.
+	Name string
+
+	// Version is parsed from the file name. Binaries without a version
+	// in their name have the zero version, 0.0.0.
+	Version Version
+
+	// Path is the absolute path of the binary.
+	Path string
+}
+
+// PluginMetaSet is a set of plugins, usually of the same kind.
+type PluginMetaSet []PluginMeta
+
+// WithName returns the plugins of the set that have the given name,
+// sorted from the newest to the oldest version.
+func (s PluginMetaSet) WithName(name string) PluginMetaSet {
+	var result PluginMetaSet
+	for _, m := range s {
+		if m.Name == name {
+			result = append(result, m)
+		}
+	}
+
+	sort.Stable(byNewest(result))
+	return result
+}
+
+// Newest returns the newest version of every plugin in the set, keyed
+// by name.
+func (s PluginMetaSet) Newest() map[string]PluginMeta {
+	result := make(map[string]PluginMeta)
+	for _, m := range s {
+		if current, ok := result[m.Name]; ok && current.Version.Compare(m.Version) > 0 {
+			continue
+		}
+
+		result[m.Name] = m
+	}
+
+	return result
+}
+
+type byNewest PluginMetaSet
+
+func (s byNewest) Len() int           { return len(s) }
+func (s byNewest) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byNewest) Less(i, j int) bool { return s[i].Version.Compare(s[j].Version) > 0 }
+
+// FindPlugins looks in the given directories for plugin binaries of the
+// given kind, such as "provider" or "provisioner".
+//
+// Binaries are named terraform-KIND-NAME, optionally followed by a
+// version: terraform-provider-aws_v1.2.3. When the same name and version
+// are found in more than one directory, the later directory wins.
+func FindPlugins(kind string, dirs []string) (PluginMetaSet, error) {
+	found := make(map[string]int)
+
+	var result PluginMetaSet
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "terraform-"+kind+"-*"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range matches {
+			path, err := filepath.Abs(match)
+			if err != nil {
+				return nil, err
+			}
+
+			meta, ok := ParsePluginPath(kind, path)
+			if !ok {
+				log.Printf("[WARN] Ignoring plugin with an invalid name: %s", path)
+				continue
+			}
+
+			log.Printf(
+				"[DEBUG] Discovered plugin: %s %s (v%s) = %s",
+				kind, meta.Name, meta.Version, meta.Path)
+
+			key := meta.Name + "_v" + meta.Version.String()
+			if i, ok := found[key]; ok {
+				result[i] = meta
+				continue
+			}
+
+			found[key] = len(result)
+			result = append(result, meta)
+		}
+	}
+
+	return result, nil
+}
+
+// ParsePluginPath parses the name and the version of a plugin of the
+// given kind from the file name of its binary. It returns false if the
+// file name isn't a valid plugin name.
+func ParsePluginPath(kind, path string) (PluginMeta, bool) {
+	file := filepath.Base(path)
+
+	// Trim the extension, such as ".exe" on Windows
+	if ext := filepath.Ext(file); ext != "" {
+		if _, err := ParseVersion(strings.TrimPrefix(ext, ".")); err != nil {
+			file = strings.TrimSuffix(file, ext)
+		}
+	}
+
+	prefix := "terraform-" + kind + "-"
+	if !strings.HasPrefix(file, prefix) {
+		return PluginMeta{}, false
+	}
+
+	name := file[len(prefix):]
+	var version Version
+	if idx := strings.LastIndex(name, "_v"); idx >= 0 {
+		v, err := ParseVersion(name[idx+2:])
+		if err != nil {
+			return PluginMeta{}, false
+		}
+
+		name = name[:idx]
+		version = v
+	}
+
+	if name == "" {
+		return PluginMeta{}, false
+	}
+
+	return PluginMeta{
+		Name:    name,
+		Version: version,
+		Path:    path,
+	}, true
+}
@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsePluginPath(t *testing.T) {
+	cases := []struct {
+		Path    string
+		Name    string
+		Version Version
+		Ok      bool
+	}{
+		{"/bin/terraform-provider-aws", "aws", Version{}, true},
+		{"/bin/terraform-provider-aws.exe", "aws", Version{}, true},
+		{"/bin/terraform-provider-aws_v1.2.3", "aws", Version{1, 2, 3}, true},
+		{"/bin/terraform-provider-aws_v1.2.3.exe", "aws", Version{1, 2, 3}, true},
+		{"/bin/terraform-provider-google-beta_v0.1", "google-beta", Version{0, 1, 0}, true},
+		{"/bin/terraform-provider-aws_vfoo", "", Version{}, false},
+		{"/bin/terraform-provider-_v1.0.0", "", Version{}, false},
+		{"/bin/terraform-provisioner-chef", "", Version{}, false},
+	}
+
+	for _, tc := range cases {
+		meta, ok := ParsePluginPath("provider", tc.Path)
+		if ok != tc.Ok {
+			t.Fatalf("%s: bad ok: %t", tc.Path, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if meta.Name != tc.Name || meta.Version != tc.Version || meta.Path != tc.Path {
+			t.Fatalf("%s: bad: %#v", tc.Path, meta)
+		}
+	}
+}
+
+func TestFindPlugins(t *testing.T) {
+	td1 := testPluginDir(t,
+		"terraform-provider-aws_v1.0.0",
+		"terraform-provider-aws_v1.1.0",
+		"terraform-provider-do",
+		"terraform-provisioner-chef")
+	defer os.RemoveAll(td1)
+	td2 := testPluginDir(t, "terraform-provider-aws_v1.1.0")
+	defer os.RemoveAll(td2)
+
+	metas, err := FindPlugins("provider", []string{td1, td2})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := PluginMetaSet{
+		{"aws", Version{1, 0, 0}, filepath.Join(td1, "terraform-provider-aws_v1.0.0")},
+		{"aws", Version{1, 1, 0}, filepath.Join(td2, "terraform-provider-aws_v1.1.0")},
+		{"do", Version{}, filepath.Join(td1, "terraform-provider-do")},
+	}
+	if !reflect.DeepEqual(metas, expected) {
+		t.Fatalf("bad: %#v", metas)
+	}
+
+	newest := metas.Newest()
+	if len(newest) != 2 || newest["aws"].Version != (Version{1, 1, 0}) {
+		t.Fatalf("bad: %#v", newest)
+	}
+}
+
+func testPluginDir(t *testing.T, files ...string) string {
+	td, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(td, f), nil, 0755); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	return td
+}
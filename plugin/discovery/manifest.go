@@ -0,0 +1,94 @@
+package discovery
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xanzy/terraform-api/plugin"
+)
+
+// Manifest maps the file names of plugin binaries to their expected
+// SHA256 checksums.
+//
+// A manifest file has the format written by sha256sum: a line per
+// binary, with the hex checksum and the file name separated by
+// whitespace. Empty lines and lines starting with # are ignored.
+type Manifest map[string][]byte
+
+// ReadManifest reads a manifest from r.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	result := make(Manifest)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf(
+				"line %d: expected a checksum and a file name", n)
+		}
+
+		sum, err := hex.DecodeString(fields[0])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf(
+				"line %d: invalid SHA256 checksum: %s", n, fields[0])
+		}
+
+		// sha256sum marks files read in binary mode with a *
+		name := strings.TrimPrefix(fields[1], "*")
+		result[filepath.Base(name)] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LoadManifest reads the manifest file at path. It returns a nil
+// manifest and no error if the file doesn't exist.
+func LoadManifest(path string) (Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	result, err := ReadManifest(f)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %s", path, err)
+	}
+
+	return result, nil
+}
+
+// SecureConfig returns the configuration that verifies the binary of
+// the plugin against its checksum in the manifest before it is
+// executed. It returns an error if the binary isn't in the manifest.
+func (m Manifest) SecureConfig(meta PluginMeta) (*plugin.SecureConfig, error) {
+	name := filepath.Base(meta.Path)
+	sum, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf(
+			"plugin %s (%s) is not listed in the checksum manifest", meta.Name, name)
+	}
+
+	return &plugin.SecureConfig{
+		Checksum: sum,
+		Hash:     sha256.New,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package discovery
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testManifestSum = "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447"
+
+func TestReadManifest(t *testing.T) {
+	input := strings.Join([]string{
+		"# Provider checksums",
+		"",
+		testManifestSum + "  terraform-provider-aws_v1.0.0",
+		testManifestSum + " *bin/terraform-provider-do",
+	}, "\n")
+
+	m, err := ReadManifest(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(m) != 2 {
+		t.Fatalf("bad: %#v", m)
+	}
+	for _, name := range []string{"terraform-provider-aws_v1.0.0", "terraform-provider-do"} {
+		if len(m[name]) != sha256.Size {
+			t.Fatalf("%s: bad: %#v", name, m[name])
+		}
+	}
+}
+
+func TestReadManifest_invalid(t *testing.T) {
+	cases := []string{
+		"terraform-provider-aws",
+		"abc terraform-provider-aws",
+		testManifestSum + " terraform-provider-aws extra",
+	}
+
+	for _, tc := range cases {
+		if _, err := ReadManifest(strings.NewReader(tc)); err == nil {
+			t.Fatalf("%q: should error", tc)
+		}
+	}
+}
+
+func TestLoadManifest_notExist(t *testing.T) {
+	m, err := LoadManifest(filepath.Join("test-fixtures", "does-not-exist"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if m != nil {
+		t.Fatalf("bad: %#v", m)
+	}
+}
+
+func TestManifestSecureConfig(t *testing.T) {
+	td := testPluginDir(t, "terraform-provider-aws_v1.0.0")
+	defer os.RemoveAll(td)
+
+	path := filepath.Join(td, "terraform-provider-aws_v1.0.0")
+	if err := ioutil.WriteFile(path, []byte("hello\n"), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	m, err := ReadManifest(strings.NewReader(
+		"5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  " +
+			"terraform-provider-aws_v1.0.0"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	meta := PluginMeta{Name: "aws", Version: Version{1, 0, 0}, Path: path}
+	secure, err := m.SecureConfig(meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ok, err := secure.Check(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !ok {
+		t.Fatal("checksum should match")
+	}
+
+	// Tampering with the binary is detected
+	if err := ioutil.WriteFile(path, []byte("tampered\n"), 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ok, err = secure.Check(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ok {
+		t.Fatal("checksum should not match")
+	}
+
+	// Binaries that aren't in the manifest are refused
+	meta = PluginMeta{Name: "do", Path: filepath.Join(td, "terraform-provider-do")}
+	if _, err := m.SecureConfig(meta); err == nil {
+		t.Fatal("should error")
+	}
+}
@@ -0,0 +1,144 @@
+package discovery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/xanzy/terraform-api/config/module"
+)
+
+// Requirement is a version constraint on a provider, together with the
+// module that declared it.
+type Requirement struct {
+	// Source is the module that declared the constraint, such as "root"
+	// or "root.child".
+	Source string
+
+	// Constraints is the constraint as written in the configuration.
+	Constraints string
+}
+
+// Requirements are the version constraints on providers, keyed by the
+// name of the provider.
+type Requirements map[string][]Requirement
+
+// TreeRequirements returns the provider version constraints declared by
+// the "version" field of the provider blocks in the module tree.
+func TreeRequirements(t *module.Tree) Requirements {
+	result := make(Requirements)
+	treeRequirements(t, result)
+	return result
+}
+
+func treeRequirements(t *module.Tree, result Requirements) {
+	if c := t.Config(); c != nil {
+		source := strings.Join(append([]string{"root"}, t.Path()...), ".")
+		for _, pc := range c.ProviderConfigs {
+			if pc.Version == "" {
+				continue
+			}
+
+			result[pc.Name] = append(result[pc.Name], Requirement{
+				Source:      source,
+				Constraints: pc.Version,
+			})
+		}
+	}
+
+	// Walk the children in a stable order, so errors are stable too
+	children := t.Children()
+	names := make([]string, 0, len(children))
+	for name := range children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		treeRequirements(children[name], result)
+	}
+}
+
+// ResolveProviders picks the newest version of every available provider
+// that meets all the constraints on it. Providers without binaries in
+// available are ignored, since they may come from elsewhere, such as a
+// provider registered in-process.
+//
+// An error is returned for invalid constraints, and for providers of
+// which none of the available versions meet all the constraints. The
+// error lists every constraint and the module it comes from, so
+// conflicting constraints between modules can be tracked down.
+func ResolveProviders(
+	available PluginMetaSet, reqs Requirements) (map[string]PluginMeta, error) {
+	var errs error
+
+	names := make([]string, 0, len(reqs))
+	for name := range reqs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := available.Newest()
+	for _, name := range names {
+		rs := reqs[name]
+		var cs Constraints
+		valid := true
+		for _, r := range rs {
+			c, err := ParseConstraints(r.Constraints)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf(
+					"provider.%s (%s): %s", name, r.Source, err))
+				valid = false
+				continue
+			}
+
+			cs = append(cs, c...)
+		}
+		if !valid {
+			continue
+		}
+
+		versions := available.WithName(name)
+		if len(versions) == 0 {
+			continue
+		}
+
+		found := false
+		for _, m := range versions {
+			if cs.Check(m.Version) {
+				result[name] = m
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		errs = multierror.Append(errs, noMatchError(name, rs, versions))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return result, nil
+}
+
+func noMatchError(name string, rs []Requirement, versions PluginMetaSet) error {
+	constraints := make([]string, len(rs))
+	for i, r := range rs {
+		constraints[i] = fmt.Sprintf("%q (%s)", r.Constraints, r.Source)
+	}
+
+	installed := make([]string, len(versions))
+	for i, m := range versions {
+		installed[i] = m.Version.String()
+	}
+
+	return fmt.Errorf(
+		"provider.%s: no available version meets all the constraints: %s. "+
+			"Available versions: %s",
+		name, strings.Join(constraints, ", "), strings.Join(installed, ", "))
+}
@@ -0,0 +1,116 @@
+package discovery
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/xanzy/terraform-api/config/module"
+)
+
+func TestTreeRequirements(t *testing.T) {
+	td, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(td)
+
+	tree, err := module.NewTreeModule(
+		"", filepath.Join("test-fixtures", "requirements"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := tree.Load(&getter.FolderStorage{StorageDir: td}, module.GetModeGet); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := Requirements{
+		"aws": []Requirement{
+			{Source: "root", Constraints: ">= 1.0"},
+			{Source: "root.child", Constraints: "< 2.0"},
+		},
+		"template": []Requirement{
+			{Source: "root.other", Constraints: "~> 0.1"},
+		},
+	}
+
+	actual := TreeRequirements(tree)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResolveProviders(t *testing.T) {
+	available := PluginMetaSet{
+		{"aws", Version{1, 0, 0}, "aws_v1.0.0"},
+		{"aws", Version{1, 5, 0}, "aws_v1.5.0"},
+		{"aws", Version{2, 0, 0}, "aws_v2.0.0"},
+		{"do", Version{0, 1, 0}, "do_v0.1.0"},
+	}
+	reqs := Requirements{
+		"aws": []Requirement{
+			{Source: "root", Constraints: ">= 1.0"},
+			{Source: "root.child", Constraints: "< 2.0"},
+		},
+		"template": []Requirement{
+			{Source: "root", Constraints: "~> 0.1"},
+		},
+	}
+
+	actual, err := ResolveProviders(available, reqs)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]PluginMeta{
+		"aws": {"aws", Version{1, 5, 0}, "aws_v1.5.0"},
+		"do":  {"do", Version{0, 1, 0}, "do_v0.1.0"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestResolveProviders_conflict(t *testing.T) {
+	available := PluginMetaSet{
+		{"aws", Version{1, 0, 0}, "aws_v1.0.0"},
+		{"aws", Version{2, 0, 0}, "aws_v2.0.0"},
+	}
+	reqs := Requirements{
+		"aws": []Requirement{
+			{Source: "root", Constraints: "~> 1.0"},
+			{Source: "root.child", Constraints: ">= 2.0"},
+		},
+	}
+
+	_, err := ResolveProviders(available, reqs)
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	for _, s := range []string{`"~> 1.0" (root)`, `">= 2.0" (root.child)`, "2.0.0, 1.0.0"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("error should contain %q: %s", s, err)
+		}
+	}
+}
+
+func TestResolveProviders_invalid(t *testing.T) {
+	reqs := Requirements{
+		"aws": []Requirement{
+			{Source: "root.child", Constraints: "~> foo"},
+		},
+	}
+
+	_, err := ResolveProviders(nil, reqs)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "provider.aws (root.child)") {
+		t.Fatalf("bad: %s", err)
+	}
+}
@@ -0,0 +1,3 @@
+provider "aws" {
+    version = "< 2.0"
+}
@@ -0,0 +1,13 @@
+provider "aws" {
+    version = ">= 1.0"
+}
+
+provider "do" {}
+
+module "child" {
+    source = "./child"
+}
+
+module "other" {
+    source = "./other"
+}
@@ -0,0 +1,3 @@
+provider "template" {
+    version = "~> 0.1"
+}
@@ -0,0 +1,184 @@
+package discovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of a plugin binary, in the form
+// MAJOR.MINOR.PATCH. Missing segments are zero, so "1.2" is the same
+// version as "1.2.0".
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a version with one to three numeric segments,
+// optionally prefixed with a "v", such as "v1.2.3".
+func ParseVersion(s string) (Version, error) {
+	v, _, err := parseVersion(s)
+	return v, err
+}
+
+// parseVersion parses a version and also returns the number of segments
+// it was written with, which is needed for the pessimistic constraint.
+func parseVersion(s string) (Version, int, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	parts := strings.Split(raw, ".")
+	if raw == "" || len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("malformed version: %q", s)
+	}
+
+	var segments [3]int
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("malformed version: %q", s)
+		}
+
+		segments[i] = int(n)
+	}
+
+	return Version{
+		Major: segments[0],
+		Minor: segments[1],
+		Patch: segments[2],
+	}, len(parts), nil
+}
+
+// Compare returns -1, 0 or 1 if v is respectively lower than, equal to
+// or higher than other.
+func (v Version) Compare(other Version) int {
+	a := [3]int{v.Major, v.Minor, v.Patch}
+	b := [3]int{other.Major, other.Minor, other.Patch}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Constraint is a single version constraint, such as ">= 1.2".
+type Constraint struct {
+	op       string
+	version  Version
+	segments int
+}
+
+// Constraints is a set of constraints that must all be met.
+type Constraints []Constraint
+
+// constraintOps are the supported operators. The longer operators come
+// first, so they are matched before their prefixes.
+var constraintOps = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// ParseConstraints parses a comma-separated list of constraints, such as
+// ">= 1.0, < 2.0". The supported operators are =, !=, >, >=, <, <= and
+// the pessimistic operator ~>. A version without an operator must match
+// exactly.
+//
+// The pessimistic operator allows the last given segment of the version
+// to increase: "~> 1.2" matches 1.2 and newer but not 2.0, while
+// "~> 1.2.3" matches 1.2.3 and newer but not 1.3.0.
+func ParseConstraints(s string) (Constraints, error) {
+	var result Constraints
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+
+		op := "="
+		for _, o := range constraintOps {
+			if strings.HasPrefix(raw, o) {
+				op = o
+				raw = raw[len(o):]
+				break
+			}
+		}
+
+		v, segments, err := parseVersion(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %s", s, err)
+		}
+
+		result = append(result, Constraint{
+			op:       op,
+			version:  v,
+			segments: segments,
+		})
+	}
+
+	return result, nil
+}
+
+// Check returns true if the version meets the constraint.
+func (c Constraint) Check(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		if cmp < 0 {
+			return false
+		}
+
+		// All the segments but the last given one must be equal
+		switch c.segments {
+		case 1, 2:
+			return v.Major == c.version.Major
+		default:
+			return v.Major == c.version.Major && v.Minor == c.version.Minor
+		}
+	}
+
+	panic("unknown constraint operator: " + c.op)
+}
+
+func (c Constraint) String() string {
+	v := []int{c.version.Major, c.version.Minor, c.version.Patch}[:c.segments]
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	return fmt.Sprintf("%s %s", c.op, strings.Join(parts, "."))
+}
+
+// Check returns true if the version meets all the constraints.
+func (cs Constraints) Check(v Version) bool {
+	for _, c := range cs {
+		if !c.Check(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cs Constraints) String() string {
+	parts := make([]string, len(cs))
+	for i, c := range cs {
+		parts[i] = c.String()
+	}
+
+	return strings.Join(parts, ", ")
+}
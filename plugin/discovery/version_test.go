@@ -0,0 +1,92 @@
+package discovery
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output Version
+		Err    bool
+	}{
+		{"1.2.3", Version{1, 2, 3}, false},
+		{"v1.2.3", Version{1, 2, 3}, false},
+		{"1.2", Version{1, 2, 0}, false},
+		{"1", Version{1, 0, 0}, false},
+		{"", Version{}, true},
+		{"1.2.3.4", Version{}, true},
+		{"1.a", Version{}, true},
+		{"-1", Version{}, true},
+	}
+
+	for _, tc := range cases {
+		actual, err := ParseVersion(tc.Input)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%q: err: %s", tc.Input, err)
+		}
+		if actual != tc.Output {
+			t.Fatalf("%q: bad: %#v", tc.Input, actual)
+		}
+	}
+}
+
+func TestConstraintsCheck(t *testing.T) {
+	cases := []struct {
+		Constraints string
+		Version     string
+		Result      bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"= 1.2", "1.2.0", true},
+		{"= 1.2", "1.2.1", false},
+		{"!= 1.2", "1.2.1", true},
+		{"> 1.2", "1.2.1", true},
+		{">= 1.2", "1.2.0", true},
+		{"< 1.2", "1.2.0", false},
+		{"<= 1.2", "1.2.0", true},
+		{"~> 1.2", "1.9.0", true},
+		{"~> 1.2", "2.0.0", false},
+		{"~> 1.2", "1.1.0", false},
+		{"~> 1.2.3", "1.2.9", true},
+		{"~> 1.2.3", "1.3.0", false},
+		{"~> 1", "1.9.9", true},
+		{">= 1.0, < 2.0", "1.5.0", true},
+		{">= 1.0, < 2.0", "2.0.0", false},
+	}
+
+	for _, tc := range cases {
+		cs, err := ParseConstraints(tc.Constraints)
+		if err != nil {
+			t.Fatalf("%q: err: %s", tc.Constraints, err)
+		}
+
+		v, err := ParseVersion(tc.Version)
+		if err != nil {
+			t.Fatalf("%q: err: %s", tc.Version, err)
+		}
+
+		if actual := cs.Check(v); actual != tc.Result {
+			t.Fatalf("%q %s: bad: %t", tc.Constraints, tc.Version, actual)
+		}
+	}
+}
+
+func TestParseConstraints_invalid(t *testing.T) {
+	for _, input := range []string{"", ">=", "~> 1.x", "1.0,"} {
+		if _, err := ParseConstraints(input); err == nil {
+			t.Fatalf("%q: should error", input)
+		}
+	}
+}
+
+func TestConstraintsString(t *testing.T) {
+	cs, err := ParseConstraints(">=1.0,~> 1.2.3")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual := cs.String(); actual != ">= 1.0, ~> 1.2.3" {
+		t.Fatalf("bad: %s", actual)
+	}
+}
@@ -7,4 +7,8 @@
 //
 // plugin.Serve fully manages listeners to expose an RPC server from a binary
 // that plugin.Client can connect to.
+//
+// plugin.RegisterProvider and plugin.RegisterProvisioner register plugins
+// that are linked into the program itself, which are then used in-process
+// without a binary or RPC.
 package plugin
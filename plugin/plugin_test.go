@@ -59,6 +59,14 @@ func TestHelperProcess(*testing.T) {
 		Serve(&ServeOpts{
 			ProviderFunc: testProviderFixed(new(terraform.MockResourceProvider)),
 		})
+	case "resource-provider-log":
+		p := new(terraform.MockResourceProvider)
+		p.ConfigureFn = func(*terraform.ResourceConfig) error {
+			log.Printf("[WARN] configuring")
+			return nil
+		}
+
+		Serve(&ServeOpts{ProviderFunc: testProviderFixed(p)})
 	case "resource-provisioner":
 		Serve(&ServeOpts{
 			ProvisionerFunc: testProvisionerFixed(
@@ -0,0 +1,92 @@
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	tfrpc "github.com/xanzy/terraform-api/rpc"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// The registry holds the providers and provisioners that are linked into
+// the running program. They are used in-process, without starting a
+// plugin binary and without the RPC layer in between.
+var (
+	registryLock sync.RWMutex
+	providers    = make(map[string]tfrpc.ProviderFunc)
+	provisioners = make(map[string]tfrpc.ProvisionerFunc)
+)
+
+// RegisterProvider registers an in-process provider under the given name,
+// which is the prefix of the resources of the provider. It is usually
+// called from an init function, for example:
+//
+//	plugin.RegisterProvider("aws", aws.Provider)
+//
+// RegisterProvider panics if a provider is already registered with the
+// same name, or if f is nil.
+func RegisterProvider(name string, f tfrpc.ProviderFunc) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if f == nil {
+		panic(fmt.Sprintf("plugin: provider %q registered with a nil func", name))
+	}
+	if _, ok := providers[name]; ok {
+		panic(fmt.Sprintf("plugin: provider %q is already registered", name))
+	}
+
+	providers[name] = f
+}
+
+// RegisterProvisioner registers an in-process provisioner under the given
+// name. It panics if a provisioner is already registered with the same
+// name, or if f is nil.
+func RegisterProvisioner(name string, f tfrpc.ProvisionerFunc) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if f == nil {
+		panic(fmt.Sprintf("plugin: provisioner %q registered with a nil func", name))
+	}
+	if _, ok := provisioners[name]; ok {
+		panic(fmt.Sprintf("plugin: provisioner %q is already registered", name))
+	}
+
+	provisioners[name] = f
+}
+
+// ProviderFactories returns the mapping of names to
+// ResourceProviderFactory for all the registered in-process providers.
+func ProviderFactories() map[string]terraform.ResourceProviderFactory {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	result := make(map[string]terraform.ResourceProviderFactory, len(providers))
+	for k, f := range providers {
+		f := f
+		result[k] = func() (terraform.ResourceProvider, error) {
+			return f(), nil
+		}
+	}
+
+	return result
+}
+
+// ProvisionerFactories returns the mapping of names to
+// ResourceProvisionerFactory for all the registered in-process
+// provisioners.
+func ProvisionerFactories() map[string]terraform.ResourceProvisionerFactory {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	result := make(map[string]terraform.ResourceProvisionerFactory, len(provisioners))
+	for k, f := range provisioners {
+		f := f
+		result[k] = func() (terraform.ResourceProvisioner, error) {
+			return f(), nil
+		}
+	}
+
+	return result
+}
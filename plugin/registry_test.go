@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestRegisterProvider(t *testing.T) {
+	defer testUnregister("registry-test")
+
+	p := new(terraform.MockResourceProvider)
+	RegisterProvider("registry-test", func() terraform.ResourceProvider {
+		return p
+	})
+
+	f, ok := ProviderFactories()["registry-test"]
+	if !ok {
+		t.Fatal("provider should be registered")
+	}
+
+	actual, err := f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != p {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestRegisterProvider_duplicate(t *testing.T) {
+	defer testUnregister("registry-test-duplicate")
+
+	f := func() terraform.ResourceProvider {
+		return new(terraform.MockResourceProvider)
+	}
+	RegisterProvider("registry-test-duplicate", f)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("should panic")
+		}
+	}()
+
+	RegisterProvider("registry-test-duplicate", f)
+}
+
+func TestRegisterProvisioner(t *testing.T) {
+	defer testUnregister("registry-test")
+
+	p := new(terraform.MockResourceProvisioner)
+	RegisterProvisioner("registry-test", func() terraform.ResourceProvisioner {
+		return p
+	})
+
+	f, ok := ProvisionerFactories()["registry-test"]
+	if !ok {
+		t.Fatal("provisioner should be registered")
+	}
+
+	actual, err := f()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if actual != p {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+// testUnregister removes the providers and provisioners registered by a
+// test, so the tests can run more than once.
+func testUnregister(name string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	delete(providers, name)
+	delete(provisioners, name)
+}
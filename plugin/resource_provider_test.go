@@ -1,7 +1,11 @@
 package plugin
 
 import (
+	"sync"
 	"testing"
+
+	tfrpc "github.com/xanzy/terraform-api/rpc"
+	"github.com/xanzy/terraform-api/terraform"
 )
 
 func TestResourceProvider(t *testing.T) {
@@ -13,3 +17,43 @@ func TestResourceProvider(t *testing.T) {
 		t.Fatalf("should not have error: %s", err)
 	}
 }
+
+func TestResourceProvider_log(t *testing.T) {
+	var l sync.Mutex
+	var entries []tfrpc.LogEntry
+	c := NewClient(&ClientConfig{
+		Cmd: helperProcess("resource-provider-log"),
+		LogFunc: func(e tfrpc.LogEntry) {
+			l.Lock()
+			defer l.Unlock()
+			entries = append(entries, e)
+		},
+	})
+	defer c.Kill()
+
+	client, err := c.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p, err := client.ResourceProvider()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := p.Configure(new(terraform.ResourceConfig)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	c.Kill()
+
+	l.Lock()
+	defer l.Unlock()
+	for _, e := range entries {
+		if e.Level == "WARN" && e.Message == "configuring" {
+			return
+		}
+	}
+
+	t.Fatalf("log line not found: %#v", entries)
+}
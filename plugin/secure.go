@@ -0,0 +1,49 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"io"
+	"os"
+)
+
+// ErrChecksumsDoNotMatch is returned by Client.Start when the checksum
+// of the plugin binary doesn't match the one of its SecureConfig.
+var ErrChecksumsDoNotMatch = errors.New("checksums did not match")
+
+// SecureConfig is used to verify a plugin binary before it is executed,
+// so a binary that was replaced or tampered with isn't run.
+type SecureConfig struct {
+	// Checksum is the expected checksum of the binary.
+	Checksum []byte
+
+	// Hash returns a new hash that computes the checksum, such as
+	// sha256.New. A new hash is used for every check, so the same
+	// SecureConfig can be shared by several clients.
+	Hash func() hash.Hash
+}
+
+// Check returns true if the checksum of the file at path matches the
+// expected checksum.
+func (s *SecureConfig) Check(path string) (bool, error) {
+	if len(s.Checksum) == 0 {
+		return false, errors.New("no checksum provided")
+	}
+	if s.Hash == nil {
+		return false, errors.New("no hash provided")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	h := s.Hash()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(h.Sum(nil), s.Checksum), nil
+}
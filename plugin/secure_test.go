@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestSecureConfig_Check(t *testing.T) {
+	f, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString("hello\n"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	f.Close()
+
+	sum := sha256.Sum256([]byte("hello\n"))
+	s := &SecureConfig{
+		Checksum: sum[:],
+		Hash:     sha256.New,
+	}
+
+	// Checking again must not be affected by the earlier checks
+	for i := 0; i < 2; i++ {
+		ok, err := s.Check(f.Name())
+		if err != nil {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if !ok {
+			t.Fatalf("%d: checksum should match", i)
+		}
+	}
+
+	// Neither are concurrent checks
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if ok, err := s.Check(f.Name()); err != nil || !ok {
+				t.Errorf("bad: %t %v", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSecureConfig_CheckInvalid(t *testing.T) {
+	if _, err := new(SecureConfig).Check("foo"); err == nil {
+		t.Fatal("should error without a checksum")
+	}
+
+	s := &SecureConfig{Checksum: []byte{1, 2, 3, 4}}
+	if _, err := s.Check("foo"); err == nil {
+		t.Fatal("should error without a hash")
+	}
+}
@@ -54,10 +54,16 @@ func Serve(opts *ServeOpts) {
 	}
 	defer listener.Close()
 
+	// Send the log output to Terraform over RPC once it asks for it, so
+	// it can tell the log lines of the plugins apart.
+	logServer := &tfrpc.LogServer{Fallback: os.Stderr}
+	log.SetOutput(logServer)
+
 	// Create the RPC server to dispense
 	server := &tfrpc.Server{
 		ProviderFunc:    opts.ProviderFunc,
 		ProvisionerFunc: opts.ProvisionerFunc,
+		LogServer:       logServer,
 	}
 
 	// Output the address and service name to stdout so that Terraform
@@ -69,6 +69,19 @@ func (c *Client) Close() error {
 	return c.broker.Close()
 }
 
+// Logs returns the stream of the log output of the plugin, which can be
+// read line by line and parsed with ParseLogLine. An error is returned
+// if the plugin doesn't stream its logs.
+func (c *Client) Logs() (io.ReadCloser, error) {
+	var id uint32
+	if err := c.control.Call(
+		"Dispenser.Logs", new(interface{}), &id); err != nil {
+		return nil, err
+	}
+
+	return c.broker.Dial(id)
+}
+
 func (c *Client) ResourceProvider() (terraform.ResourceProvider, error) {
 	var id uint32
 	if err := c.control.Call(
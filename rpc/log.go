@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LogEntry is a line of the log output of a plugin.
+type LogEntry struct {
+	// Level is the level of the line, such as "DEBUG" or "WARN", parsed
+	// from its "[LEVEL]" prefix. It is empty if the line has no level.
+	Level string
+
+	// Message is the line without the timestamp, level and newline.
+	Message string
+}
+
+// logTimestamp matches the timestamp the standard logger prefixes lines
+// with by default.
+var logTimestamp = regexp.MustCompile(
+	`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(\.\d+)? `)
+
+// logLevels maps the level prefixes used throughout Terraform to the
+// levels understood by the log filter.
+var logLevels = map[string]string{
+	"TRACE": "TRACE",
+	"DEBUG": "DEBUG",
+	"INFO":  "INFO",
+	"WARN":  "WARN",
+	"ERR":   "ERROR",
+	"ERROR": "ERROR",
+}
+
+// ParseLogLine parses a line of log output into a LogEntry.
+func ParseLogLine(line string) LogEntry {
+	line = strings.TrimRight(line, "\r\n")
+	line = logTimestamp.ReplaceAllString(line, "")
+
+	if strings.HasPrefix(line, "[") {
+		if idx := strings.Index(line, "]"); idx > 0 {
+			if level, ok := logLevels[line[1:idx]]; ok {
+				return LogEntry{
+					Level:   level,
+					Message: strings.TrimLeft(line[idx+1:], " "),
+				}
+			}
+		}
+	}
+
+	return LogEntry{Message: line}
+}
+
+// LogServer forwards the log output of a plugin to the host over RPC. It
+// is meant to be set as the output of the standard logger of the plugin
+// process.
+//
+// Until the host requests the log stream, and if the stream breaks, the
+// output is written to Fallback instead.
+type LogServer struct {
+	Fallback io.Writer
+
+	l    sync.Mutex
+	conn io.WriteCloser
+}
+
+func (s *LogServer) Write(p []byte) (int, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.conn != nil {
+		if _, err := s.conn.Write(p); err == nil {
+			return len(p), nil
+		}
+
+		// The host went away, keep the output
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return s.Fallback.Write(p)
+}
+
+// attach sends the output to conn from now on.
+func (s *LogServer) attach(conn io.WriteCloser) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.conn != nil {
+		s.conn.Close()
+	}
+
+	s.conn = conn
+}
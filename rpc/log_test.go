@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestParseLogLine(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Output LogEntry
+	}{
+		{
+			"2016/03/01 12:00:00 [DEBUG] Creating instance\n",
+			LogEntry{Level: "DEBUG", Message: "Creating instance"},
+		},
+		{
+			"2016/03/01 12:00:00.123456 [WARN] Retrying",
+			LogEntry{Level: "WARN", Message: "Retrying"},
+		},
+		{
+			"[ERR] Failed",
+			LogEntry{Level: "ERROR", Message: "Failed"},
+		},
+		{
+			"[foo] not a level",
+			LogEntry{Message: "[foo] not a level"},
+		},
+		{
+			"panic: oops",
+			LogEntry{Message: "panic: oops"},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := ParseLogLine(tc.Input)
+		if actual != tc.Output {
+			t.Fatalf("%q: bad: %#v", tc.Input, actual)
+		}
+	}
+}
+
+func TestClient_Logs(t *testing.T) {
+	clientConn, serverConn := testConn(t)
+
+	fallback := new(bytes.Buffer)
+	logs := &LogServer{Fallback: fallback}
+	server := &Server{LogServer: logs}
+	go server.ServeConn(serverConn)
+
+	client, err := NewClient(clientConn)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer client.Close()
+
+	// Before the stream is requested, the output goes to the fallback
+	logs.Write([]byte("[INFO] before\n"))
+	if fallback.String() != "[INFO] before\n" {
+		t.Fatalf("bad: %q", fallback.String())
+	}
+
+	stream, err := client.Logs()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer stream.Close()
+
+	// The stream is attached asynchronously, so wait for it
+	for i := 0; ; i++ {
+		logs.l.Lock()
+		attached := logs.conn != nil
+		logs.l.Unlock()
+		if attached {
+			break
+		}
+		if i > 100 {
+			t.Fatal("log stream not attached")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	logs.Write([]byte("[WARN] after\n"))
+	line, err := bufio.NewReader(stream).ReadString('\n')
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual := ParseLogLine(line); actual != (LogEntry{Level: "WARN", Message: "after"}) {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if fallback.String() != "[INFO] before\n" {
+		t.Fatalf("bad: %q", fallback.String())
+	}
+}
+
+func TestClient_Logs_unsupported(t *testing.T) {
+	client, _ := testNewClientServer(t)
+	defer client.Close()
+
+	if _, err := client.Logs(); err == nil {
+		t.Fatal("should error")
+	}
+}
@@ -174,6 +174,19 @@ func (p *ResourceProvider) Resources() []terraform.ResourceType {
 	return result
 }
 
+func (p *ResourceProvider) Stop() error {
+	var resp ResourceProviderStopResponse
+	err := p.Client.Call(p.Name+".Stop", new(interface{}), &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		err = resp.Error
+	}
+
+	return err
+}
+
 func (p *ResourceProvider) Close() error {
 	return p.Client.Close()
 }
@@ -189,6 +202,10 @@ type ResourceProviderConfigureResponse struct {
 	Error *BasicError
 }
 
+type ResourceProviderStopResponse struct {
+	Error *BasicError
+}
+
 type ResourceProviderInputArgs struct {
 	InputId uint32
 	Config  *terraform.ResourceConfig
@@ -350,6 +367,20 @@ func (s *ResourceProviderServer) Refresh(
 	return nil
 }
 
+func (s *ResourceProviderServer) Stop(
+	nothing interface{},
+	result *ResourceProviderStopResponse) error {
+	var err error
+	if p, ok := s.Provider.(terraform.ResourceProviderStopper); ok {
+		err = p.Stop()
+	}
+
+	*result = ResourceProviderStopResponse{
+		Error: NewBasicError(err),
+	}
+	return nil
+}
+
 func (s *ResourceProviderServer) Resources(
 	nothing interface{},
 	result *[]terraform.ResourceType) error {
@@ -266,6 +266,28 @@ func TestResourceProvider_refresh(t *testing.T) {
 	}
 }
 
+func TestResourceProvider_stop(t *testing.T) {
+	p := new(terraform.MockResourceProvider)
+	client, server := testClientServer(t)
+	name, err := Register(server, p)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	provider := &ResourceProvider{Client: client, Name: name}
+
+	var _ terraform.ResourceProviderStopper = provider
+
+	// Stop
+	p.StopReturnError = errors.New("foo")
+	err = provider.Stop()
+	if !p.StopCalled {
+		t.Fatal("stop should be called")
+	}
+	if err == nil || err.Error() != "foo" {
+		t.Fatalf("bad: %#v", err)
+	}
+}
+
 func TestResourceProvider_resources(t *testing.T) {
 	p := new(terraform.MockResourceProvider)
 	client, server := testClientServer(t)
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"errors"
 	"io"
 	"log"
 	"net"
@@ -15,6 +16,10 @@ import (
 type Server struct {
 	ProviderFunc    ProviderFunc
 	ProvisionerFunc ProvisionerFunc
+
+	// LogServer, if set, streams the log output of the plugin to the
+	// clients that request it.
+	LogServer *LogServer
 }
 
 // ProviderFunc creates terraform.ResourceProviders when they're requested
@@ -70,6 +75,7 @@ func (s *Server) ServeConn(conn io.ReadWriteCloser) {
 	server.RegisterName("Dispenser", &dispenseServer{
 		ProviderFunc:    s.ProviderFunc,
 		ProvisionerFunc: s.ProvisionerFunc,
+		LogServer:       s.LogServer,
 
 		broker: broker,
 	})
@@ -80,6 +86,7 @@ func (s *Server) ServeConn(conn io.ReadWriteCloser) {
 type dispenseServer struct {
 	ProviderFunc    ProviderFunc
 	ProvisionerFunc ProvisionerFunc
+	LogServer       *LogServer
 
 	broker *muxBroker
 }
@@ -126,6 +133,28 @@ func (d *dispenseServer) ResourceProvisioner(
 	return nil
 }
 
+func (d *dispenseServer) Logs(
+	args interface{}, response *uint32) error {
+	if d.LogServer == nil {
+		return errors.New("plugin doesn't stream its logs")
+	}
+
+	id := d.broker.NextId()
+	*response = id
+
+	go func() {
+		conn, err := d.broker.Accept(id)
+		if err != nil {
+			log.Printf("[ERR] Plugin dispense: %s", err)
+			return
+		}
+
+		d.LogServer.attach(conn)
+	}()
+
+	return nil
+}
+
 func acceptAndServe(mux *muxBroker, id uint32, n string, v interface{}) {
 	conn, err := mux.Accept(id)
 	if err != nil {
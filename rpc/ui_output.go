@@ -17,14 +17,35 @@ func (o *UIOutput) Output(v string) {
 	o.Client.Call(o.Name+".Output", v, new(interface{}))
 }
 
+func (o *UIOutput) OutputStream(s terraform.UIOutputStream, v string) {
+	args := &UIOutputStreamArgs{
+		Stream: s,
+		Value:  v,
+	}
+
+	o.Client.Call(o.Name+".OutputStream", args, new(interface{}))
+}
+
 // UIOutputServer is the RPC server for serving UIOutput.
 type UIOutputServer struct {
 	UIOutput terraform.UIOutput
 }
 
+type UIOutputStreamArgs struct {
+	Stream terraform.UIOutputStream
+	Value  string
+}
+
 func (s *UIOutputServer) Output(
 	v string,
 	reply *interface{}) error {
 	s.UIOutput.Output(v)
 	return nil
 }
+
+func (s *UIOutputServer) OutputStream(
+	args *UIOutputStreamArgs,
+	reply *interface{}) error {
+	terraform.OutputStream(s.UIOutput, args.Stream, args.Value)
+	return nil
+}
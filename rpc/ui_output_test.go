@@ -32,3 +32,58 @@ func TestUIOutput_input(t *testing.T) {
 		t.Fatalf("bad: %#v", o.OutputMessage)
 	}
 }
+
+func TestUIOutput_stream(t *testing.T) {
+	var _ terraform.UIStreamOutput = new(UIOutput)
+
+	client, server := testClientServer(t)
+	defer client.Close()
+
+	var stream terraform.UIOutputStream
+	var message string
+	o := &terraform.CallbackUIOutput{
+		OutputFn: func(string) {
+			t.Fatal("output shouldn't be called")
+		},
+		OutputStreamFn: func(s terraform.UIOutputStream, v string) {
+			stream = s
+			message = v
+		},
+	}
+
+	err := server.RegisterName("UIOutput", &UIOutputServer{
+		UIOutput: o,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	output := &UIOutput{Client: client, Name: "UIOutput"}
+	output.OutputStream(terraform.UIOutputStderr, "foo")
+	if stream != terraform.UIOutputStderr {
+		t.Fatalf("bad: %d", stream)
+	}
+	if message != "foo" {
+		t.Fatalf("bad: %#v", message)
+	}
+}
+
+func TestUIOutput_streamPlain(t *testing.T) {
+	client, server := testClientServer(t)
+	defer client.Close()
+
+	o := new(terraform.MockUIOutput)
+
+	err := server.RegisterName("UIOutput", &UIOutputServer{
+		UIOutput: o,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	output := &UIOutput{Client: client, Name: "UIOutput"}
+	output.OutputStream(terraform.UIOutputStderr, "foo")
+	if o.OutputMessage != "foo" {
+		t.Fatalf("bad: %#v", o.OutputMessage)
+	}
+}
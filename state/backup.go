@@ -43,6 +43,28 @@ func (s *BackupState) PersistState() error {
 	return s.Real.PersistState()
 }
 
+// Lock locks the real state, if it supports locking.
+//
+// Locker impl.
+func (s *BackupState) Lock(reason string) error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the real state, if it supports locking.
+//
+// Locker impl.
+func (s *BackupState) Unlock() error {
+	if l, ok := s.Real.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
+
 func (s *BackupState) backup() error {
 	state := s.Real.State()
 	if state == nil {
@@ -27,3 +27,40 @@ func TestBackupState(t *testing.T) {
 		t.Fatalf("bad: %d", fi.Size())
 	}
 }
+
+func TestBackupState_locker(t *testing.T) {
+	inner := &lockingState{InmemState: new(InmemState)}
+	s := &BackupState{Real: inner}
+
+	var _ Locker = s
+
+	if err := s.Lock("test"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if inner.reason != "test" {
+		t.Fatalf("bad: %q", inner.reason)
+	}
+
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if inner.reason != "" {
+		t.Fatalf("bad: %q", inner.reason)
+	}
+}
+
+// lockingState is an InmemState that records its lock.
+type lockingState struct {
+	*InmemState
+	reason string
+}
+
+func (s *lockingState) Lock(reason string) error {
+	s.reason = reason
+	return nil
+}
+
+func (s *lockingState) Unlock() error {
+	s.reason = ""
+	return nil
+}
@@ -131,6 +131,28 @@ func (s *CacheState) PersistState() error {
 	return s.Durable.PersistState()
 }
 
+// Lock locks the durable storage, if it supports locking.
+//
+// Locker impl.
+func (s *CacheState) Lock(reason string) error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the durable storage, if it supports locking.
+//
+// Locker impl.
+func (s *CacheState) Unlock() error {
+	if l, ok := s.Durable.(Locker); ok {
+		return l.Unlock()
+	}
+
+	return nil
+}
+
 // CacheStateCache is the meta-interface that must be implemented for
 // the cache for the CacheState.
 type CacheStateCache interface {
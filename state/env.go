@@ -0,0 +1,165 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+// DefaultEnv is the name of the environment that always exists, and is
+// selected unless another environment is selected explicitly.
+const DefaultEnv = "default"
+
+// envNameRegexp is the format environment names must match, so they can
+// safely be used as part of a path or key.
+var envNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// EnvManager is implemented by storage that can hold the state of
+// multiple named environments, such as dev, staging and prod, for a
+// single configuration.
+type EnvManager interface {
+	// Envs returns the names of the environments that have a state. The
+	// DefaultEnv may or may not be included.
+	Envs() ([]string, error)
+
+	// EnvState returns the state of the named environment. The state
+	// doesn't have to exist yet.
+	EnvState(name string) (State, error)
+
+	// DeleteEnv deletes the state of the named environment.
+	DeleteEnv(name string) error
+}
+
+// Environments manages the environments stored by an EnvManager, and
+// keeps track of the selected environment.
+type Environments struct {
+	Manager EnvManager
+
+	current string
+}
+
+// Current returns the name of the selected environment.
+func (e *Environments) Current() string {
+	if e.current == "" {
+		return DefaultEnv
+	}
+
+	return e.current
+}
+
+// List returns the sorted names of all environments, which always
+// includes the DefaultEnv.
+func (e *Environments) List() ([]string, error) {
+	envs, err := e.Manager.Envs()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{DefaultEnv: true}
+	result := []string{DefaultEnv}
+	for _, name := range envs {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// Create creates a new environment with an empty state. It is an error
+// if the environment already exists.
+func (e *Environments) Create(name string) error {
+	if err := ValidateEnvName(name); err != nil {
+		return err
+	}
+
+	exists, err := e.exists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("Environment %q already exists", name)
+	}
+
+	s, err := e.Manager.EnvState(name)
+	if err != nil {
+		return err
+	}
+	if err := s.WriteState(terraform.NewState()); err != nil {
+		return err
+	}
+
+	return s.PersistState()
+}
+
+// Select selects the environment with the given name, which must exist.
+func (e *Environments) Select(name string) error {
+	exists, err := e.exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Environment %q doesn't exist", name)
+	}
+
+	e.current = name
+	return nil
+}
+
+// Delete deletes the environment with the given name. The DefaultEnv and
+// the selected environment can't be deleted.
+func (e *Environments) Delete(name string) error {
+	if name == DefaultEnv {
+		return fmt.Errorf("Can't delete the %q environment", DefaultEnv)
+	}
+	if name == e.Current() {
+		return fmt.Errorf(
+			"Can't delete the selected environment %q, select another one first", name)
+	}
+
+	exists, err := e.exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Environment %q doesn't exist", name)
+	}
+
+	return e.Manager.DeleteEnv(name)
+}
+
+// State returns the state of the selected environment.
+func (e *Environments) State() (State, error) {
+	return e.Manager.EnvState(e.Current())
+}
+
+func (e *Environments) exists(name string) (bool, error) {
+	envs, err := e.List()
+	if err != nil {
+		return false, err
+	}
+
+	for _, env := range envs {
+		if env == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ValidateEnvName returns an error if the given name can't be used as the
+// name of an environment.
+func ValidateEnvName(name string) error {
+	if !envNameRegexp.MatchString(name) {
+		return fmt.Errorf(
+			"Invalid environment name %q: must start with a letter or number "+
+				"and only contain letters, numbers, dashes and underscores", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLocalEnvs_impl(t *testing.T) {
+	var _ EnvManager = new(LocalEnvs)
+}
+
+func TestEnvironments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "terraform.tfstate")
+	envs := &Environments{Manager: &LocalEnvs{Path: path}}
+
+	if current := envs.Current(); current != DefaultEnv {
+		t.Fatalf("bad: %s", current)
+	}
+
+	// The default environment always exists
+	testEnvironmentsList(t, envs, []string{"default"})
+
+	if err := envs.Create("staging"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := envs.Create("prod"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testEnvironmentsList(t, envs, []string{"default", "prod", "staging"})
+
+	// The states are stored next to the default state
+	if _, err := os.Stat(filepath.Join(dir, LocalEnvDir, "prod", "terraform.tfstate")); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := envs.Create("prod"); err == nil {
+		t.Fatal("creating an existing environment should fail")
+	}
+	if err := envs.Create("../prod"); err == nil {
+		t.Fatal("creating an environment with an invalid name should fail")
+	}
+	if err := envs.Select("dev"); err == nil {
+		t.Fatal("selecting an unknown environment should fail")
+	}
+
+	if err := envs.Select("prod"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if current := envs.Current(); current != "prod" {
+		t.Fatalf("bad: %s", current)
+	}
+
+	// The state of the selected environment is returned
+	s, err := envs.State()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if ls := s.(*LocalState); ls.Path != filepath.Join(dir, LocalEnvDir, "prod", "terraform.tfstate") {
+		t.Fatalf("bad: %s", ls.Path)
+	}
+	if s.State() == nil {
+		t.Fatal("state should exist")
+	}
+
+	if err := envs.Delete("prod"); err == nil {
+		t.Fatal("deleting the selected environment should fail")
+	}
+	if err := envs.Delete(DefaultEnv); err == nil {
+		t.Fatal("deleting the default environment should fail")
+	}
+	if err := envs.Delete("dev"); err == nil {
+		t.Fatal("deleting an unknown environment should fail")
+	}
+
+	if err := envs.Delete("staging"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	testEnvironmentsList(t, envs, []string{"default", "prod"})
+}
+
+func TestValidateEnvName(t *testing.T) {
+	cases := map[string]bool{
+		"prod":       true,
+		"us-east_1":  true,
+		"1st":        true,
+		"":           false,
+		"-prod":      false,
+		"prod/blue":  false,
+		"../prod":    false,
+		"prod green": false,
+	}
+
+	for name, valid := range cases {
+		err := ValidateEnvName(name)
+		if (err == nil) != valid {
+			t.Fatalf("%q: bad: %v", name, err)
+		}
+	}
+}
+
+func testEnvironmentsList(t *testing.T, envs *Environments, expected []string) {
+	actual, err := envs.List()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
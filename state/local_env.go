@@ -0,0 +1,92 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalEnvDir is the name of the directory, next to the state of the
+// default environment, that holds the states of the other environments.
+const LocalEnvDir = "terraform.tfstate.d"
+
+// LocalEnvs is an EnvManager that stores the states of the environments
+// on the local filesystem.
+//
+// The state of the default environment is stored at Path, and the state
+// of every other environment in a directory named after it in Dir, with
+// the same file name as the default state.
+type LocalEnvs struct {
+	// Path is the path of the state of the default environment.
+	Path string
+
+	// Dir is the directory that holds the other environments. If not
+	// specified, LocalEnvDir next to Path is used.
+	Dir string
+}
+
+// EnvManager impl.
+func (e *LocalEnvs) Envs() ([]string, error) {
+	entries, err := ioutil.ReadDir(e.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var envs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		// Only directories with a state are environments
+		if _, err := os.Stat(e.envPath(entry.Name())); err == nil {
+			envs = append(envs, entry.Name())
+		}
+	}
+
+	return envs, nil
+}
+
+// EnvManager impl.
+func (e *LocalEnvs) EnvState(name string) (State, error) {
+	path := e.Path
+	if name != DefaultEnv {
+		if err := ValidateEnvName(name); err != nil {
+			return nil, err
+		}
+
+		path = e.envPath(name)
+	}
+
+	s := &LocalState{Path: path}
+	if err := s.RefreshState(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// EnvManager impl.
+func (e *LocalEnvs) DeleteEnv(name string) error {
+	if err := ValidateEnvName(name); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(filepath.Join(e.dir(), name))
+}
+
+func (e *LocalEnvs) dir() string {
+	if e.Dir != "" {
+		return e.Dir
+	}
+
+	return filepath.Join(filepath.Dir(e.Path), LocalEnvDir)
+}
+
+func (e *LocalEnvs) envPath(name string) string {
+	return filepath.Join(e.dir(), name, filepath.Base(e.Path))
+}
@@ -112,7 +112,10 @@ func TestAtlasClient_LegitimateConflict(t *testing.T) {
 	}
 
 	// Changing the state but not the serial. Should generate a conflict.
-	state.RootModule().Outputs["drift"] = "happens"
+	state.RootModule().Outputs["drift"] = &terraform.OutputState{
+		Type:  "string",
+		Value: "happens",
+	}
 
 	var stateJson bytes.Buffer
 	if err := terraform.WriteState(state, &stateJson); err != nil {
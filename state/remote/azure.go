@@ -0,0 +1,283 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+)
+
+const (
+	// azureLeaseHeader is the header used to pass the ID of the lease
+	// that's held on a blob.
+	azureLeaseHeader = "x-ms-lease-id"
+
+	// azureLockReasonKey and azureLockCreatedKey are the metadata keys
+	// used to store information about a lock on the state blob.
+	azureLockReasonKey  = "terraformlockreason"
+	azureLockCreatedKey = "terraformlockcreated"
+
+	// azureEnvSuffix separates the configured key from the name of an
+	// environment in the key of its state.
+	azureEnvSuffix = "-env:"
+)
+
+func azureFactory(conf map[string]string) (Client, error) {
+	storageAccountName, ok := conf["storage_account_name"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'storage_account_name' configuration")
+	}
+
+	containerName, ok := conf["container_name"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'container_name' configuration")
+	}
+
+	keyName, ok := conf["key"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'key' configuration")
+	}
+
+	accessKey, ok := conf["access_key"]
+	if !ok {
+		accessKey = os.Getenv("ARM_ACCESS_KEY")
+		if accessKey == "" {
+			return nil, fmt.Errorf(
+				"missing 'access_key' configuration or ARM_ACCESS_KEY environment variable")
+		}
+	}
+
+	storageClient, err := storage.NewBasicClient(storageAccountName, accessKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating storage client for account %s: %s",
+			storageAccountName, err)
+	}
+
+	return &AzureClient{
+		blobClient:    storageClient.GetBlobService(),
+		containerName: containerName,
+		keyName:       keyName,
+	}, nil
+}
+
+// AzureClient is a remote client that stores data in an Azure blob.
+//
+// Locking is implemented with an infinite lease on the blob. While the
+// lease is held, the blob can only be written by the client holding it.
+type AzureClient struct {
+	blobClient    storage.BlobStorageClient
+	containerName string
+	keyName       string
+
+	// leaseID is the ID of the lease held by this client, if any.
+	leaseID string
+}
+
+func (c *AzureClient) Get() (*Payload, error) {
+	blob, err := c.blobClient.GetBlob(c.containerName, c.keyName)
+	if err != nil {
+		if serr, ok := err.(storage.AzureStorageServiceError); ok && serr.Code == "BlobNotFound" {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Failed to get remote state: %s", err)
+	}
+	defer blob.Close()
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, blob); err != nil {
+		return nil, fmt.Errorf("Failed to read remote state: %s", err)
+	}
+
+	// If there was no data, then return nil
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+
+	md5 := md5.Sum(buf.Bytes())
+	return &Payload{
+		Data: buf.Bytes(),
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *AzureClient) Put(data []byte) error {
+	headers := c.leaseHeaders()
+	headers["Content-Type"] = "application/json"
+
+	log.Printf("[DEBUG] Uploading remote state to Azure: %s/%s", c.containerName, c.keyName)
+
+	err := c.blobClient.CreateBlockBlobFromReader(
+		c.containerName, c.keyName, uint64(len(data)), bytes.NewReader(data), headers)
+	if err != nil {
+		return fmt.Errorf("Failed to upload state: %s", err)
+	}
+
+	return nil
+}
+
+func (c *AzureClient) Delete() error {
+	err := c.blobClient.DeleteBlob(c.containerName, c.keyName, c.leaseHeaders())
+	if err != nil {
+		if serr, ok := err.(storage.AzureStorageServiceError); ok && serr.Code == "BlobNotFound" {
+			return nil
+		}
+
+		return fmt.Errorf("Failed to delete state: %s", err)
+	}
+
+	// Deleting the blob also removes the lease
+	c.leaseID = ""
+	return nil
+}
+
+// Lock acquires an infinite lease on the state blob, creating an empty
+// blob first if the state doesn't exist yet. The reason is stored in the
+// metadata of the blob, so it can be reported when locking fails.
+func (c *AzureClient) Lock(reason string) error {
+	if c.leaseID != "" {
+		return fmt.Errorf("State %s/%s is already locked by this client",
+			c.containerName, c.keyName)
+	}
+
+	exists, err := c.blobClient.BlobExists(c.containerName, c.keyName)
+	if err != nil {
+		return fmt.Errorf("Error locking state: %s", err)
+	}
+	if !exists {
+		// A lease can only be acquired on an existing blob
+		if err := c.blobClient.CreateBlockBlob(c.containerName, c.keyName); err != nil {
+			return fmt.Errorf("Error locking state: %s", err)
+		}
+	}
+
+	proposedID, err := azureLeaseID()
+	if err != nil {
+		return fmt.Errorf("Error locking state: %s", err)
+	}
+
+	leaseID, err := c.blobClient.AcquireLease(c.containerName, c.keyName, -1, proposedID)
+	if err != nil {
+		if serr, ok := err.(storage.AzureStorageServiceError); ok && serr.Code == "LeaseAlreadyPresent" {
+			return fmt.Errorf("State %s/%s is locked: %s",
+				c.containerName, c.keyName, c.lockInfo())
+		}
+
+		return fmt.Errorf("Error locking state: %s", err)
+	}
+	c.leaseID = leaseID
+
+	metadata := map[string]string{
+		azureLockReasonKey:  reason,
+		azureLockCreatedKey: time.Now().UTC().Format(time.RFC3339),
+	}
+	err = c.blobClient.SetBlobMetadata(c.containerName, c.keyName, metadata, c.leaseHeaders())
+	if err != nil {
+		// Don't leave the state locked if we can't record why
+		if uerr := c.Unlock(); uerr != nil {
+			log.Printf("[WARN] Error unlocking state: %s", uerr)
+		}
+
+		return fmt.Errorf("Error locking state: %s", err)
+	}
+
+	return nil
+}
+
+// Unlock releases the lease held on the state blob.
+func (c *AzureClient) Unlock() error {
+	if c.leaseID == "" {
+		return nil
+	}
+
+	// Clear the lock information while we still hold the lease
+	err := c.blobClient.SetBlobMetadata(
+		c.containerName, c.keyName, map[string]string{}, c.leaseHeaders())
+	if err != nil {
+		log.Printf("[WARN] Error clearing lock metadata: %s", err)
+	}
+
+	if err := c.blobClient.ReleaseLease(c.containerName, c.keyName, c.leaseID); err != nil {
+		return fmt.Errorf("Error unlocking state: %s", err)
+	}
+
+	c.leaseID = ""
+	return nil
+}
+
+// leaseHeaders returns the headers needed to modify the blob, including
+// the lease ID if this client holds a lease.
+func (c *AzureClient) leaseHeaders() map[string]string {
+	headers := make(map[string]string)
+	if c.leaseID != "" {
+		headers[azureLeaseHeader] = c.leaseID
+	}
+
+	return headers
+}
+
+// lockInfo returns a description of the current lock, for use in error
+// messages.
+func (c *AzureClient) lockInfo() string {
+	metadata, err := c.blobClient.GetBlobMetadata(c.containerName, c.keyName)
+	if err != nil || metadata[azureLockReasonKey] == "" {
+		return "unknown lock holder"
+	}
+
+	return fmt.Sprintf("%q, created at %s",
+		metadata[azureLockReasonKey], metadata[azureLockCreatedKey])
+}
+
+// azureLeaseID generates a random lease ID, which must be a GUID.
+func azureLeaseID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+func (c *AzureClient) Envs() ([]string, error) {
+	prefix := c.envPrefix()
+	params := storage.ListBlobsParameters{Prefix: prefix}
+
+	var envs []string
+	for {
+		resp, err := c.blobClient.ListBlobs(c.containerName, params)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list environments: %s", err)
+		}
+
+		for _, blob := range resp.Blobs {
+			envs = append(envs, strings.TrimPrefix(blob.Name, prefix))
+		}
+
+		if resp.NextMarker == "" {
+			break
+		}
+		params.Marker = resp.NextMarker
+	}
+
+	return envs, nil
+}
+
+func (c *AzureClient) Env(name string) (Client, error) {
+	return &AzureClient{
+		blobClient:    c.blobClient,
+		containerName: c.containerName,
+		keyName:       c.envPrefix() + name,
+	}, nil
+}
+
+// envPrefix is the prefix of the keys of the states of all environments.
+func (c *AzureClient) envPrefix() string {
+	return c.keyName + azureEnvSuffix
+}
@@ -0,0 +1,169 @@
+package remote
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+)
+
+func TestAzureClient_impl(t *testing.T) {
+	var _ Client = new(AzureClient)
+	var _ ClientLocker = new(AzureClient)
+	var _ EnvClient = new(AzureClient)
+}
+
+func TestAzureFactory(t *testing.T) {
+	// This test just instantiates the client. Shouldn't make any actual
+	// requests nor incur any costs.
+
+	config := make(map[string]string)
+
+	// Empty config is an error
+	_, err := azureFactory(config)
+	if err == nil {
+		t.Fatalf("Empty config should be error")
+	}
+
+	config["storage_account_name"] = "foo"
+	config["container_name"] = "bar"
+	config["key"] = "baz"
+	config["access_key"] = "c2VjcmV0"
+
+	client, err := azureFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	azureClient := client.(*AzureClient)
+
+	if azureClient.containerName != "bar" {
+		t.Fatalf("Incorrect containerName was populated")
+	}
+	if azureClient.keyName != "baz" {
+		t.Fatalf("Incorrect keyName was populated")
+	}
+	if len(azureClient.leaseHeaders()) != 0 {
+		t.Fatalf("No lease should be held")
+	}
+
+	// Environments are stored next to the configured key
+	env, err := azureClient.Env("dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if name := env.(*AzureClient).keyName; name != "baz-env:dev" {
+		t.Fatalf("Incorrect environment keyName: %s", name)
+	}
+
+	// Envs lists the keys below the prefix and strips it again
+	if name := strings.TrimPrefix("baz-env:dev", azureClient.envPrefix()); name != "dev" {
+		t.Fatalf("Incorrect environment name: %s", name)
+	}
+}
+
+func TestAzureLeaseID(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+	id, err := azureLeaseID()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !re.MatchString(id) {
+		t.Fatalf("bad: %s", id)
+	}
+}
+
+func TestAzureClient(t *testing.T) {
+	// This test creates a container in Azure and populates it.
+	// It may incur costs, so it will only run if the storage account
+	// environment variables are present.
+
+	accountName := os.Getenv("ARM_STORAGE_ACCOUNT_NAME")
+	accessKey := os.Getenv("ARM_ACCESS_KEY")
+	if accountName == "" || accessKey == "" {
+		t.Skipf("skipping; ARM_STORAGE_ACCOUNT_NAME and ARM_ACCESS_KEY must be set")
+	}
+
+	containerName := fmt.Sprintf("terraform-remote-azure-test-%x", time.Now().Unix())
+
+	config := make(map[string]string)
+	config["storage_account_name"] = accountName
+	config["container_name"] = containerName
+	config["key"] = "testState"
+	config["access_key"] = accessKey
+
+	client, err := azureFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	azureClient := client.(*AzureClient)
+	blobClient := azureClient.blobClient
+
+	// Be clear about what we're doing in case the user needs to clean
+	// this up later.
+	t.Logf("Creating Azure container %s in %s", containerName, accountName)
+	if err := blobClient.CreateContainer(containerName, storage.ContainerAccessTypePrivate); err != nil {
+		t.Skipf("Failed to create test Azure container, so skipping")
+	}
+
+	defer func() {
+		if _, err := blobClient.DeleteContainerIfExists(containerName); err != nil {
+			t.Logf("WARNING: Failed to delete the test Azure container. It has been left in your storage account and may incur storage charges. (error was %s)", err)
+		}
+	}()
+
+	testClient(t, client)
+
+	// A second client can't lock the state while the first holds it
+	if err := azureClient.Lock("test"); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+
+	other, err := azureFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+	if err := other.(*AzureClient).Lock("other"); err == nil {
+		t.Fatalf("locking a locked state should fail")
+	}
+
+	// The lock holder can still write the state
+	if err := azureClient.Put([]byte("data")); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	if err := azureClient.Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+	if err := azureClient.Delete(); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+
+	// An environment written through Env is listed by Envs
+	env, err := azureClient.Env("dev")
+	if err != nil {
+		t.Fatalf("env: %s", err)
+	}
+	if err := env.Put([]byte("data")); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	envs, err := azureClient.Envs()
+	if err != nil {
+		t.Fatalf("envs: %s", err)
+	}
+	if !reflect.DeepEqual(envs, []string{"dev"}) {
+		t.Fatalf("bad envs: %#v", envs)
+	}
+
+	if err := env.Delete(); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+}
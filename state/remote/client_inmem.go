@@ -2,15 +2,27 @@ package remote
 
 import (
 	"crypto/md5"
+	"fmt"
+	"sort"
 )
 
 // InmemClient is a Client implementation that stores data in memory.
 type InmemClient struct {
 	Data []byte
 	MD5  []byte
+
+	// LockReason is the reason given for the current lock, and is
+	// empty if the state isn't locked.
+	LockReason string
+
+	envs map[string]*InmemClient
 }
 
 func (c *InmemClient) Get() (*Payload, error) {
+	if c.Data == nil {
+		return nil, nil
+	}
+
 	return &Payload{
 		Data: c.Data,
 		MD5:  c.MD5,
@@ -30,3 +42,40 @@ func (c *InmemClient) Delete() error {
 	c.MD5 = nil
 	return nil
 }
+
+func (c *InmemClient) Lock(reason string) error {
+	if c.LockReason != "" {
+		return fmt.Errorf("state already locked: %s", c.LockReason)
+	}
+
+	c.LockReason = reason
+	return nil
+}
+
+func (c *InmemClient) Unlock() error {
+	c.LockReason = ""
+	return nil
+}
+
+func (c *InmemClient) Envs() ([]string, error) {
+	var envs []string
+	for name, env := range c.envs {
+		if env.Data != nil {
+			envs = append(envs, name)
+		}
+	}
+	sort.Strings(envs)
+
+	return envs, nil
+}
+
+func (c *InmemClient) Env(name string) (Client, error) {
+	if c.envs == nil {
+		c.envs = make(map[string]*InmemClient)
+	}
+	if _, ok := c.envs[name]; !ok {
+		c.envs[name] = new(InmemClient)
+	}
+
+	return c.envs[name], nil
+}
@@ -83,3 +83,29 @@ func (c *ConsulClient) Delete() error {
 	_, err := kv.Delete(c.Path, nil)
 	return err
 }
+
+// consulEnvSuffix separates the configured path from the name of an
+// environment in the path of its state.
+const consulEnvSuffix = "-env:"
+
+func (c *ConsulClient) Envs() ([]string, error) {
+	prefix := c.Path + consulEnvSuffix
+	keys, _, err := c.Client.KV().Keys(prefix, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	envs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		envs = append(envs, strings.TrimPrefix(key, prefix))
+	}
+
+	return envs, nil
+}
+
+func (c *ConsulClient) Env(name string) (Client, error) {
+	return &ConsulClient{
+		Client: c.Client,
+		Path:   c.Path + consulEnvSuffix + name,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 
 func TestConsulClient_impl(t *testing.T) {
 	var _ Client = new(ConsulClient)
+	var _ EnvClient = new(ConsulClient)
 }
 
 func TestConsulClient(t *testing.T) {
@@ -0,0 +1,73 @@
+package remote
+
+import (
+	"fmt"
+
+	"github.com/xanzy/terraform-api/state"
+)
+
+// EnvManager implements state.EnvManager on top of a remote client that
+// supports environments.
+type EnvManager struct {
+	Client EnvClient
+}
+
+// NewEnvManager returns a new EnvManager using a client with the given
+// type and configuration. The configuration determines where the state
+// of the default environment is stored.
+func NewEnvManager(t string, conf map[string]string) (*EnvManager, error) {
+	client, err := NewClient(t, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	envClient, ok := client.(EnvClient)
+	if !ok {
+		return nil, fmt.Errorf(
+			"remote client type %s doesn't support environments", t)
+	}
+
+	return &EnvManager{Client: envClient}, nil
+}
+
+// state.EnvManager impl.
+func (m *EnvManager) Envs() ([]string, error) {
+	return m.Client.Envs()
+}
+
+// state.EnvManager impl.
+func (m *EnvManager) EnvState(name string) (state.State, error) {
+	client, err := m.client(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &State{Client: client}
+	if err := s.RefreshState(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// state.EnvManager impl.
+func (m *EnvManager) DeleteEnv(name string) error {
+	client, err := m.client(name)
+	if err != nil {
+		return err
+	}
+
+	return client.Delete()
+}
+
+func (m *EnvManager) client(name string) (Client, error) {
+	if name == state.DefaultEnv {
+		return m.Client, nil
+	}
+
+	if err := state.ValidateEnvName(name); err != nil {
+		return nil, err
+	}
+
+	return m.Client.Env(name)
+}
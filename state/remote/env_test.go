@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xanzy/terraform-api/state"
+)
+
+func TestEnvManager_impl(t *testing.T) {
+	var _ state.EnvManager = new(EnvManager)
+}
+
+func TestNewEnvManager(t *testing.T) {
+	if _, err := NewEnvManager("http", map[string]string{"address": "http://127.0.0.1"}); err == nil {
+		t.Fatal("a client without environment support should fail")
+	}
+}
+
+func TestEnvManager(t *testing.T) {
+	client := new(InmemClient)
+	envs := &state.Environments{Manager: &EnvManager{Client: client}}
+
+	if err := envs.Create("prod"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := envs.Create("staging"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := envs.List()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := []string{"default", "prod", "staging"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// Each environment is stored separately from the default state
+	if client.Data != nil {
+		t.Fatalf("default state should not be written: %s", client.Data)
+	}
+	if client.envs["prod"].Data == nil {
+		t.Fatal("prod state should be written")
+	}
+
+	if err := envs.Select("prod"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	s, err := envs.State()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if c := s.(*State).Client; c != client.envs["prod"] {
+		t.Fatalf("bad client: %#v", c)
+	}
+
+	if err := envs.Select(state.DefaultEnv); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := envs.Delete("prod"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err = envs.List()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected = []string{"default", "staging"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
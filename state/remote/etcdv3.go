@@ -0,0 +1,295 @@
+package remote
+
+import (
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	etcdv3 "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
+	"golang.org/x/net/context"
+)
+
+const (
+	// etcdv3LockSuffix is appended to the path of the state to get the
+	// key that holds the lock.
+	etcdv3LockSuffix = ".lock"
+
+	// etcdv3EnvSuffix separates the configured path from the name of an
+	// environment in the path of its state.
+	etcdv3EnvSuffix = "-env:"
+
+	// etcdv3LockTTL is the TTL of the lease attached to the lock, in
+	// seconds. The lease is kept alive while the lock is held, so the
+	// lock is only released automatically if the holder goes away.
+	etcdv3LockTTL = 30
+)
+
+func etcdv3Factory(conf map[string]string) (Client, error) {
+	path, ok := conf["path"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'path' configuration")
+	}
+
+	endpoints, ok := conf["endpoints"]
+	if !ok || endpoints == "" {
+		return nil, fmt.Errorf("missing 'endpoints' configuration")
+	}
+
+	lock := true
+	if raw, ok := conf["lock"]; ok {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"'lock' field couldn't be parsed as bool: %s", err)
+		}
+
+		lock = v
+	}
+
+	config := etcdv3.Config{
+		Endpoints:   strings.Split(endpoints, " "),
+		DialTimeout: 5 * time.Second,
+	}
+	if username, ok := conf["username"]; ok && username != "" {
+		config.Username = username
+	}
+	if password, ok := conf["password"]; ok && password != "" {
+		config.Password = password
+	}
+
+	tlsConfig, err := etcdv3TLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	config.TLS = tlsConfig
+
+	client, err := etcdv3.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EtcdV3Client{
+		Client: client,
+		Path:   path,
+		lock:   lock,
+	}, nil
+}
+
+func etcdv3TLSConfig(conf map[string]string) (*tls.Config, error) {
+	info := transport.TLSInfo{
+		TrustedCAFile: conf["cacert_path"],
+		CertFile:      conf["cert_path"],
+		KeyFile:       conf["key_path"],
+	}
+	if info.Empty() && info.TrustedCAFile == "" {
+		return nil, nil
+	}
+
+	config, err := info.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Error loading TLS configuration: %s", err)
+	}
+
+	return config, nil
+}
+
+// EtcdV3Client is a remote client that stores data in etcd using the
+// v3 API.
+//
+// Locking is implemented with a lock key next to the state, which is
+// created in a transaction that only succeeds if the key doesn't exist
+// yet. The key is attached to a lease that is kept alive while the lock
+// is held.
+type EtcdV3Client struct {
+	Client *etcdv3.Client
+	Path   string
+
+	lock    bool
+	leaseID etcdv3.LeaseID
+	cancel  context.CancelFunc
+}
+
+// etcdv3LockInfo is the value stored in the lock key.
+type etcdv3LockInfo struct {
+	Reason  string
+	Created string
+}
+
+func (c *EtcdV3Client) Get() (*Payload, error) {
+	resp, err := c.Client.Get(context.TODO(), c.Path)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count == 0 {
+		return nil, nil
+	}
+
+	data := resp.Kvs[0].Value
+	md5 := md5.Sum(data)
+	return &Payload{
+		Data: data,
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *EtcdV3Client) Put(data []byte) error {
+	// Without a lock held by us, just write the state
+	if c.leaseID == 0 {
+		_, err := c.Client.Put(context.TODO(), c.Path, string(data))
+		return err
+	}
+
+	// Only write the state if we still hold the lock
+	resp, err := c.Client.Txn(context.TODO()).
+		If(etcdv3.Compare(etcdv3.LeaseValue(c.lockKey()), "=", c.leaseID)).
+		Then(etcdv3.OpPut(c.Path, string(data))).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("Failed to write state: the lock on %s was lost", c.Path)
+	}
+
+	return nil
+}
+
+func (c *EtcdV3Client) Delete() error {
+	_, err := c.Client.Delete(context.TODO(), c.Path)
+	return err
+}
+
+// Lock creates the lock key for the state, attached to a new lease. It
+// fails if the lock key already exists. Locking is a no-op if it was
+// disabled in the configuration.
+func (c *EtcdV3Client) Lock(reason string) error {
+	if !c.lock {
+		return nil
+	}
+	if c.leaseID != 0 {
+		return fmt.Errorf("State %s is already locked by this client", c.Path)
+	}
+
+	info, err := json.Marshal(&etcdv3LockInfo{
+		Reason:  reason,
+		Created: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	lease, err := c.Client.Grant(context.TODO(), etcdv3LockTTL)
+	if err != nil {
+		return fmt.Errorf("Error locking state %s: %s", c.Path, err)
+	}
+
+	resp, err := c.Client.Txn(context.TODO()).
+		If(etcdv3.Compare(etcdv3.CreateRevision(c.lockKey()), "=", 0)).
+		Then(etcdv3.OpPut(c.lockKey(), string(info), etcdv3.WithLease(lease.ID))).
+		Else(etcdv3.OpGet(c.lockKey())).
+		Commit()
+	if err != nil || !resp.Succeeded {
+		// Don't leave the unused lease around until it expires
+		if _, rerr := c.Client.Revoke(context.TODO(), lease.ID); rerr != nil {
+			log.Printf("[WARN] Error revoking lease %x: %s", lease.ID, rerr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Error locking state %s: %s", c.Path, err)
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("State %s is locked: %s", c.Path, etcdv3DescribeLock(resp))
+	}
+
+	// Keep the lease alive for as long as we hold the lock
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := c.Client.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		cancel()
+		c.Client.Revoke(context.TODO(), lease.ID)
+		return fmt.Errorf("Error locking state %s: %s", c.Path, err)
+	}
+	go func() {
+		for range ch {
+		}
+	}()
+
+	c.leaseID = lease.ID
+	c.cancel = cancel
+	return nil
+}
+
+// Unlock revokes the lease of the lock, which removes the lock key.
+func (c *EtcdV3Client) Unlock() error {
+	if c.leaseID == 0 {
+		return nil
+	}
+
+	c.cancel()
+	if _, err := c.Client.Revoke(context.TODO(), c.leaseID); err != nil {
+		return fmt.Errorf("Error unlocking state %s: %s", c.Path, err)
+	}
+
+	c.leaseID = 0
+	c.cancel = nil
+	return nil
+}
+
+func (c *EtcdV3Client) lockKey() string {
+	return c.Path + etcdv3LockSuffix
+}
+
+// etcdv3DescribeLock returns a description of the lock that made the
+// lock transaction fail, for use in error messages.
+func etcdv3DescribeLock(resp *etcdv3.TxnResponse) string {
+	if len(resp.Responses) == 0 {
+		return "unknown lock holder"
+	}
+
+	rangeResp := resp.Responses[0].GetResponseRange()
+	if rangeResp == nil || len(rangeResp.Kvs) == 0 {
+		return "unknown lock holder"
+	}
+
+	var info etcdv3LockInfo
+	if err := json.Unmarshal(rangeResp.Kvs[0].Value, &info); err != nil {
+		return "unknown lock holder"
+	}
+
+	return fmt.Sprintf("%q, created at %s", info.Reason, info.Created)
+}
+
+func (c *EtcdV3Client) Envs() ([]string, error) {
+	prefix := c.Path + etcdv3EnvSuffix
+	resp, err := c.Client.Get(context.TODO(), prefix, etcdv3.WithPrefix(), etcdv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []string
+	for _, kv := range resp.Kvs {
+		name := strings.TrimPrefix(string(kv.Key), prefix)
+
+		// Skip the locks of the environments
+		if strings.HasSuffix(name, etcdv3LockSuffix) {
+			continue
+		}
+		envs = append(envs, name)
+	}
+
+	return envs, nil
+}
+
+func (c *EtcdV3Client) Env(name string) (Client, error) {
+	return &EtcdV3Client{
+		Client: c.Client,
+		Path:   c.Path + etcdv3EnvSuffix + name,
+		lock:   c.lock,
+	}, nil
+}
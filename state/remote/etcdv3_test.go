@@ -0,0 +1,92 @@
+package remote
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEtcdV3Client_impl(t *testing.T) {
+	var _ Client = new(EtcdV3Client)
+	var _ ClientLocker = new(EtcdV3Client)
+	var _ EnvClient = new(EtcdV3Client)
+}
+
+func TestEtcdV3Factory(t *testing.T) {
+	config := make(map[string]string)
+
+	// Empty config is an error
+	if _, err := etcdv3Factory(config); err == nil {
+		t.Fatalf("Empty config should be error")
+	}
+
+	config["path"] = "tf-unit/state"
+	if _, err := etcdv3Factory(config); err == nil {
+		t.Fatalf("Missing endpoints should be error")
+	}
+
+	config["endpoints"] = "http://127.0.0.1:2379"
+	config["lock"] = "maybe"
+	if _, err := etcdv3Factory(config); err == nil {
+		t.Fatalf("Invalid lock should be error")
+	}
+}
+
+func TestEtcdV3Client(t *testing.T) {
+	endpoint := os.Getenv("ETCDV3_ENDPOINT")
+	if endpoint == "" {
+		t.Skipf("skipping; ETCDV3_ENDPOINT must be set")
+	}
+
+	config := map[string]string{
+		"endpoints": endpoint,
+		"path":      fmt.Sprintf("tf-unit/%s", time.Now().String()),
+	}
+
+	if username := os.Getenv("ETCDV3_USERNAME"); username != "" {
+		config["username"] = username
+	}
+	if password := os.Getenv("ETCDV3_PASSWORD"); password != "" {
+		config["password"] = password
+	}
+
+	client, err := etcdv3Factory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	testClient(t, client)
+
+	// A second client can't lock the state while the first holds it
+	etcdClient := client.(*EtcdV3Client)
+	if err := etcdClient.Lock("test"); err != nil {
+		t.Fatalf("lock: %s", err)
+	}
+
+	other, err := etcdv3Factory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+	if err := other.(*EtcdV3Client).Lock("other"); err == nil {
+		t.Fatalf("locking a locked state should fail")
+	}
+
+	// The lock holder can still write the state
+	if err := etcdClient.Put([]byte("data")); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+
+	if err := etcdClient.Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+	if err := other.(*EtcdV3Client).Lock("other"); err != nil {
+		t.Fatalf("lock after unlock: %s", err)
+	}
+	if err := other.(*EtcdV3Client).Unlock(); err != nil {
+		t.Fatalf("unlock: %s", err)
+	}
+	if err := etcdClient.Delete(); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+}
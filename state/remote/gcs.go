@@ -0,0 +1,295 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/terraform-api/helper/pathorcontents"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+)
+
+func gcsFactory(conf map[string]string) (Client, error) {
+	bucketName, ok := conf["bucket"]
+	if !ok {
+		return nil, fmt.Errorf("missing 'bucket' configuration")
+	}
+
+	// The state of every environment is stored in its own object below
+	// the prefix. The configured client holds the default one, and the
+	// others are selected with Env.
+	objectName := path.Join(conf["prefix"], "default.tfstate")
+
+	credentials, ok := conf["credentials"]
+	if !ok {
+		credentials = os.Getenv("GOOGLE_CREDENTIALS")
+	}
+
+	encryptionKey, ok := conf["encryption_key"]
+	if !ok {
+		encryptionKey = os.Getenv("GOOGLE_ENCRYPTION_KEY")
+	}
+
+	var transport *gcsEncryptionTransport
+	if encryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"'encryption_key' couldn't be decoded as base64: %s", err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf(
+				"'encryption_key' must be a 256 bit AES key, got %d bits", len(key)*8)
+		}
+
+		hash := sha256.Sum256(key)
+		transport = &gcsEncryptionTransport{
+			key:     encryptionKey,
+			keyHash: base64.StdEncoding.EncodeToString(hash[:]),
+		}
+	}
+
+	client, err := gcsHTTPClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the encryption headers on top of the authenticated transport
+	if transport != nil {
+		transport.base = client.Transport
+		client.Transport = transport
+	}
+
+	nativeClient, err := storage.New(client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating GCS client: %s", err)
+	}
+
+	return &GCSClient{
+		nativeClient: nativeClient,
+		bucketName:   bucketName,
+		prefix:       conf["prefix"],
+		objectName:   objectName,
+	}, nil
+}
+
+func gcsHTTPClient(credentials string) (*http.Client, error) {
+	if credentials == "" {
+		log.Printf("[INFO] Authenticating to GCS using DefaultClient")
+		return google.DefaultClient(
+			oauth2.NoContext, storage.DevstorageReadWriteScope)
+	}
+
+	contents, _, err := pathorcontents.Read(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading credentials: %s", err)
+	}
+
+	// The contents aren't part of the error since they contain the
+	// private key.
+	conf, err := google.JWTConfigFromJSON(
+		[]byte(contents), storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing credentials: %s", err)
+	}
+
+	return conf.Client(oauth2.NoContext), nil
+}
+
+// GCSClient is a remote client that stores data in Google Cloud Storage.
+//
+// The generation of the object is tracked and used as a precondition for
+// writes, so a state that was modified by someone else since it was read
+// isn't overwritten.
+type GCSClient struct {
+	nativeClient *storage.Service
+	bucketName   string
+	prefix       string
+	objectName   string
+
+	// generation is the generation of the object as last read or
+	// written, where 0 means the object doesn't exist. It is only used
+	// once known, so a client that never read the state can write it.
+	generation      int64
+	generationKnown bool
+}
+
+func (c *GCSClient) Get() (*Payload, error) {
+	resp, err := c.nativeClient.Objects.Get(c.bucketName, c.objectName).Download()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			c.setGeneration(0)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Failed to get remote state: %s", err)
+	}
+	defer resp.Body.Close()
+
+	generation, err := strconv.ParseInt(resp.Header.Get("X-Goog-Generation"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read remote state generation: %s", err)
+	}
+	c.setGeneration(generation)
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("Failed to read remote state: %s", err)
+	}
+
+	// If there was no data, then return nil
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+
+	md5 := md5.Sum(buf.Bytes())
+	return &Payload{
+		Data: buf.Bytes(),
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *GCSClient) Put(data []byte) error {
+	object := &storage.Object{
+		Name:        c.objectName,
+		ContentType: "application/json",
+	}
+
+	call := c.nativeClient.Objects.Insert(c.bucketName, object).Media(bytes.NewReader(data))
+	if c.generationKnown {
+		call = call.IfGenerationMatch(c.generation)
+	}
+
+	log.Printf("[DEBUG] Uploading remote state to GCS: %s/%s", c.bucketName, c.objectName)
+
+	result, err := call.Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 412 {
+			return fmt.Errorf(
+				"Failed to upload state: the remote state was modified since " +
+					"it was last read. Refresh the state and try again.")
+		}
+
+		return fmt.Errorf("Failed to upload state: %s", err)
+	}
+
+	c.setGeneration(result.Generation)
+	return nil
+}
+
+func (c *GCSClient) Delete() error {
+	call := c.nativeClient.Objects.Delete(c.bucketName, c.objectName)
+	if c.generationKnown && c.generation != 0 {
+		call = call.IfGenerationMatch(c.generation)
+	}
+
+	if err := call.Do(); err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			c.setGeneration(0)
+			return nil
+		}
+
+		return fmt.Errorf("Failed to delete state: %s", err)
+	}
+
+	c.setGeneration(0)
+	return nil
+}
+
+func (c *GCSClient) setGeneration(generation int64) {
+	c.generation = generation
+	c.generationKnown = true
+}
+
+// gcsEncryptionTransport adds the headers for a customer-supplied
+// encryption key to every request, so objects are encrypted with it.
+type gcsEncryptionTransport struct {
+	base    http.RoundTripper
+	key     string
+	keyHash string
+}
+
+func (t *gcsEncryptionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Don't modify the original request, as required by RoundTripper
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header)+3)
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+
+	r.Header.Set("X-Goog-Encryption-Algorithm", "AES256")
+	r.Header.Set("X-Goog-Encryption-Key", t.key)
+	r.Header.Set("X-Goog-Encryption-Key-Sha256", t.keyHash)
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(r)
+}
+
+// Envs returns the names of all environments stored below the prefix,
+// except for the default one.
+func (c *GCSClient) Envs() ([]string, error) {
+	prefix := ""
+	if c.prefix != "" {
+		prefix = strings.TrimSuffix(c.prefix, "/") + "/"
+	}
+
+	var envs []string
+	token := ""
+	for {
+		call := c.nativeClient.Objects.List(c.bucketName).Prefix(prefix).Delimiter("/")
+		if token != "" {
+			call = call.PageToken(token)
+		}
+
+		res, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list environments: %s", err)
+		}
+
+		for _, o := range res.Items {
+			name := strings.TrimPrefix(o.Name, prefix)
+			if !strings.HasSuffix(name, ".tfstate") {
+				continue
+			}
+
+			name = strings.TrimSuffix(name, ".tfstate")
+			if name != "default" {
+				envs = append(envs, name)
+			}
+		}
+
+		token = res.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+
+	return envs, nil
+}
+
+// Env returns a client for the environment with the given name.
+func (c *GCSClient) Env(name string) (Client, error) {
+	return &GCSClient{
+		nativeClient: c.nativeClient,
+		bucketName:   c.bucketName,
+		prefix:       c.prefix,
+		objectName:   path.Join(c.prefix, name+".tfstate"),
+	}, nil
+}
@@ -0,0 +1,190 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/api/storage/v1"
+)
+
+const testGCSCredentials = `{
+  "type": "service_account",
+  "client_email": "test@example.iam.gserviceaccount.com",
+  "private_key_id": "abc",
+  "private_key": "key"
+}`
+
+func TestGCSClient_impl(t *testing.T) {
+	var _ Client = new(GCSClient)
+	var _ EnvClient = new(GCSClient)
+}
+
+func TestGCSFactory(t *testing.T) {
+	// This test just instantiates the client. Shouldn't make any actual
+	// requests nor incur any costs.
+
+	config := make(map[string]string)
+
+	// Empty config is an error
+	_, err := gcsFactory(config)
+	if err == nil {
+		t.Fatalf("Empty config should be error")
+	}
+
+	config["bucket"] = "foo"
+	config["credentials"] = testGCSCredentials
+
+	client, err := gcsFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	gcsClient := client.(*GCSClient)
+
+	if gcsClient.bucketName != "foo" {
+		t.Fatalf("Incorrect bucketName was populated")
+	}
+	if gcsClient.objectName != "default.tfstate" {
+		t.Fatalf("Incorrect objectName was populated: %s", gcsClient.objectName)
+	}
+
+	config["prefix"] = "network/prod"
+
+	client, err = gcsFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	gcsClient = client.(*GCSClient)
+
+	if gcsClient.objectName != "network/prod/default.tfstate" {
+		t.Fatalf("Incorrect objectName was populated: %s", gcsClient.objectName)
+	}
+
+	env, err := gcsClient.Env("green")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if name := env.(*GCSClient).objectName; name != "network/prod/green.tfstate" {
+		t.Fatalf("Incorrect environment objectName: %s", name)
+	}
+
+	// The encryption key must be a base64 encoded 256 bit key
+	config["encryption_key"] = "notbase64!"
+	if _, err := gcsFactory(config); err == nil {
+		t.Fatalf("Invalid encryption key should be error")
+	}
+
+	config["encryption_key"] = "c2hvcnQ="
+	if _, err := gcsFactory(config); err == nil {
+		t.Fatalf("Short encryption key should be error")
+	}
+
+	config["encryption_key"] = "yI0Gq1WJ2yX8bbFVsJvC2qO/i9oH2RkD8v6Lb0tcbXI="
+	if _, err := gcsFactory(config); err != nil {
+		t.Fatalf("Error for valid encryption key: %s", err)
+	}
+}
+
+func TestGCSEncryptionTransport(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+	}))
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &gcsEncryptionTransport{
+			key:     "key",
+			keyHash: "hash",
+		},
+	}
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	expected := map[string]string{
+		"X-Goog-Encryption-Algorithm":  "AES256",
+		"X-Goog-Encryption-Key":        "key",
+		"X-Goog-Encryption-Key-Sha256": "hash",
+	}
+	for k, v := range expected {
+		if actual := headers.Get(k); actual != v {
+			t.Fatalf("bad %s: %s", k, actual)
+		}
+	}
+
+	// The original request must not be modified
+	if len(req.Header) != 0 {
+		t.Fatalf("request was modified: %#v", req.Header)
+	}
+}
+
+func TestGCSClient(t *testing.T) {
+	// This test creates a bucket in GCS and populates it.
+	// It may incur costs, so it will only run if the Google project
+	// environment variable is present.
+
+	projectID := os.Getenv("GOOGLE_PROJECT")
+	if projectID == "" {
+		t.Skipf("skipping; GOOGLE_PROJECT must be set")
+	}
+
+	bucketName := fmt.Sprintf("terraform-remote-gcs-test-%x", time.Now().Unix())
+
+	config := make(map[string]string)
+	config["bucket"] = bucketName
+	config["prefix"] = "test"
+
+	client, err := gcsFactory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config: %s", err)
+	}
+
+	gcsClient := client.(*GCSClient)
+	nativeClient := gcsClient.nativeClient
+
+	// Be clear about what we're doing in case the user needs to clean
+	// this up later.
+	t.Logf("Creating GCS bucket %s in %s", bucketName, projectID)
+	_, err = nativeClient.Buckets.Insert(projectID, &storage.Bucket{Name: bucketName}).Do()
+	if err != nil {
+		t.Skipf("Failed to create test GCS bucket, so skipping")
+	}
+
+	defer func() {
+		if err := nativeClient.Buckets.Delete(bucketName).Do(); err != nil {
+			t.Logf("WARNING: Failed to delete the test GCS bucket. It has been left in your Google project and may incur storage charges. (error was %s)", err)
+		}
+	}()
+
+	testClient(t, client)
+
+	// A write based on an outdated generation must fail
+	if err := gcsClient.Put([]byte("first")); err != nil {
+		t.Fatalf("put: %s", err)
+	}
+	gcsClient.setGeneration(gcsClient.generation - 1)
+	if err := gcsClient.Put([]byte("second")); err == nil {
+		t.Fatalf("put with an outdated generation should fail")
+	}
+
+	if _, err := gcsClient.Get(); err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	if err := gcsClient.Delete(); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+}
@@ -13,6 +13,30 @@ type Client interface {
 	Delete() error
 }
 
+// ClientLocker is an optional interface that can be implemented by a
+// remote state driver that supports locking the state.
+type ClientLocker interface {
+	Client
+
+	Lock(reason string) error
+	Unlock() error
+}
+
+// EnvClient is an optional interface that can be implemented by a remote
+// state driver that can store the state of multiple environments. The
+// state of each environment is stored under its own key, derived from
+// the configured one, which holds the state of the default environment.
+type EnvClient interface {
+	Client
+
+	// Envs returns the names of the other environments that have a
+	// state stored.
+	Envs() ([]string, error)
+
+	// Env returns a client for the state of the named environment.
+	Env(name string) (Client, error)
+}
+
 // Payload is the return value from the remote state storage.
 type Payload struct {
 	MD5  []byte
@@ -37,8 +61,11 @@ func NewClient(t string, conf map[string]string) (Client, error) {
 // NewClient.
 var BuiltinClients = map[string]Factory{
 	"atlas":       atlasFactory,
+	"azure":       azureFactory,
 	"consul":      consulFactory,
 	"etcd":        etcdFactory,
+	"etcdv3":      etcdv3Factory,
+	"gcs":         gcsFactory,
 	"http":        httpFactory,
 	"s3":          s3Factory,
 	"swift":       swiftFactory,
@@ -2,11 +2,14 @@ package remote
 
 import (
 	"bytes"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -14,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-cleanhttp"
 )
@@ -59,6 +63,8 @@ func s3Factory(conf map[string]string) (Client, error) {
 		acl = raw
 	}
 	kmsKeyID := conf["kms_key_id"]
+	lockTable := conf["lock_table"]
+	versionID := conf["version_id"]
 
 	accessKeyId := conf["access_key"]
 	secretAccessKey := conf["secret_key"]
@@ -89,31 +95,49 @@ func s3Factory(conf map[string]string) (Client, error) {
 	}
 	sess := session.New(awsConfig)
 	nativeClient := s3.New(sess)
+	dynClient := dynamodb.New(sess)
 
 	return &S3Client{
 		nativeClient:         nativeClient,
+		dynClient:            dynClient,
 		bucketName:           bucketName,
 		keyName:              keyName,
 		serverSideEncryption: serverSideEncryption,
 		acl:                  acl,
 		kmsKeyID:             kmsKeyID,
+		lockTable:            lockTable,
+		versionID:            versionID,
 	}, nil
 }
 
 type S3Client struct {
 	nativeClient         *s3.S3
+	dynClient            *dynamodb.DynamoDB
 	bucketName           string
 	keyName              string
 	serverSideEncryption bool
 	acl                  string
 	kmsKeyID             string
+	lockTable            string
+	versionID            string
+
+	// lockOwner identifies the lock item created by this client, if any,
+	// so that Unlock never removes a lock taken by someone else.
+	lockOwner string
 }
 
 func (c *S3Client) Get() (*Payload, error) {
-	output, err := c.nativeClient.GetObject(&s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: &c.bucketName,
 		Key:    &c.keyName,
-	})
+	}
+
+	// In a versioned bucket, a specific version of the state can be read
+	if c.versionID != "" {
+		input.VersionId = &c.versionID
+	}
+
+	output, err := c.nativeClient.GetObject(input)
 
 	if err != nil {
 		if awserr := err.(awserr.Error); awserr != nil {
@@ -147,6 +171,11 @@ func (c *S3Client) Get() (*Payload, error) {
 }
 
 func (c *S3Client) Put(data []byte) error {
+	if c.versionID != "" {
+		return fmt.Errorf(
+			"Cannot write state while reading version %q of it", c.versionID)
+	}
+
 	contentType := "application/json"
 	contentLength := int64(len(data))
 
@@ -173,14 +202,26 @@ func (c *S3Client) Put(data []byte) error {
 
 	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
 
-	if _, err := c.nativeClient.PutObject(i); err == nil {
-		return nil
-	} else {
+	output, err := c.nativeClient.PutObject(i)
+	if err != nil {
 		return fmt.Errorf("Failed to upload state: %v", err)
 	}
+
+	if output.VersionId != nil {
+		log.Printf("[DEBUG] Uploaded remote state as version %s", *output.VersionId)
+	}
+
+	return nil
 }
 
 func (c *S3Client) Delete() error {
+	if c.versionID != "" {
+		return fmt.Errorf(
+			"Cannot delete state while reading version %q of it", c.versionID)
+	}
+
+	// In a versioned bucket this only adds a delete marker, so earlier
+	// versions of the state remain available.
 	_, err := c.nativeClient.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: &c.bucketName,
 		Key:    &c.keyName,
@@ -188,3 +229,157 @@ func (c *S3Client) Delete() error {
 
 	return err
 }
+
+// Lock creates a lock item for the state in the DynamoDB lock table. The
+// table must have a string hash key named "LockID". Locking is a no-op
+// when no lock table is configured.
+func (c *S3Client) Lock(reason string) error {
+	if c.lockTable == "" {
+		return nil
+	}
+	if c.lockOwner != "" {
+		return fmt.Errorf("State %s is already locked by this client", c.lockID())
+	}
+
+	owner, err := s3LockOwner()
+	if err != nil {
+		return fmt.Errorf("Error locking state %s: %s", c.lockID(), err)
+	}
+
+	_, err = c.dynClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(c.lockTable),
+		Item: map[string]*dynamodb.AttributeValue{
+			"LockID":  {S: aws.String(c.lockID())},
+			"Owner":   {S: aws.String(owner)},
+			"Reason":  {S: aws.String(reason)},
+			"Created": {S: aws.String(time.Now().UTC().Format(time.RFC3339))},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(LockID)"),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ConditionalCheckFailedException" {
+			return fmt.Errorf("State %s is locked: %s", c.lockID(), c.lockInfo())
+		}
+
+		return fmt.Errorf("Error locking state %s: %s", c.lockID(), err)
+	}
+
+	c.lockOwner = owner
+	return nil
+}
+
+// Unlock removes the lock item for the state from the lock table. Only
+// the lock created by this client is removed; if the lock was broken and
+// taken by someone else in the meantime, it is left alone.
+func (c *S3Client) Unlock() error {
+	if c.lockTable == "" || c.lockOwner == "" {
+		return nil
+	}
+
+	_, err := c.dynClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(c.lockTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(c.lockID())},
+		},
+		// OWNER is a reserved word in DynamoDB, so it needs a placeholder
+		ConditionExpression: aws.String("#owner = :owner"),
+		ExpressionAttributeNames: map[string]*string{
+			"#owner": aws.String("Owner"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":owner": {S: aws.String(c.lockOwner)},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ConditionalCheckFailedException" {
+			c.lockOwner = ""
+			return fmt.Errorf("State %s is no longer locked by this client: %s",
+				c.lockID(), c.lockInfo())
+		}
+
+		return fmt.Errorf("Error unlocking state %s: %s", c.lockID(), err)
+	}
+
+	c.lockOwner = ""
+	return nil
+}
+
+// s3LockOwner generates a random ID for a new lock item.
+func s3LockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
+
+// lockID is the key of the lock item in the lock table. It includes the
+// bucket, so a single table can hold the locks for many states.
+func (c *S3Client) lockID() string {
+	return fmt.Sprintf("%s/%s", c.bucketName, c.keyName)
+}
+
+// lockInfo returns a description of the current lock, for use in error
+// messages.
+func (c *S3Client) lockInfo() string {
+	output, err := c.dynClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(c.lockTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockID": {S: aws.String(c.lockID())},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil || output.Item == nil {
+		return "unknown lock holder"
+	}
+
+	var reason, created string
+	if v, ok := output.Item["Reason"]; ok && v.S != nil {
+		reason = *v.S
+	}
+	if v, ok := output.Item["Created"]; ok && v.S != nil {
+		created = *v.S
+	}
+
+	return fmt.Sprintf("%q, created at %s", reason, created)
+}
+
+// s3EnvPrefix is the prefix of the keys that hold the states of the
+// environments. The state of an environment is stored at
+// "<prefix><name>/<key>".
+const s3EnvPrefix = "env:/"
+
+func (c *S3Client) Envs() ([]string, error) {
+	var envs []string
+	err := c.nativeClient.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(c.bucketName),
+		Prefix: aws.String(s3EnvPrefix),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, o := range page.Contents {
+			parts := strings.SplitN(strings.TrimPrefix(*o.Key, s3EnvPrefix), "/", 2)
+			if len(parts) == 2 && parts[1] == c.keyName {
+				envs = append(envs, parts[0])
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list environments: %s", err)
+	}
+
+	return envs, nil
+}
+
+func (c *S3Client) Env(name string) (Client, error) {
+	return &S3Client{
+		nativeClient:         c.nativeClient,
+		dynClient:            c.dynClient,
+		bucketName:           c.bucketName,
+		keyName:              s3EnvPrefix + name + "/" + c.keyName,
+		serverSideEncryption: c.serverSideEncryption,
+		acl:                  c.acl,
+		kmsKeyID:             c.kmsKeyID,
+		lockTable:            c.lockTable,
+	}, nil
+}
@@ -3,14 +3,19 @@ package remote
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
 func TestS3Client_impl(t *testing.T) {
 	var _ Client = new(S3Client)
+	var _ ClientLocker = new(S3Client)
+	var _ EnvClient = new(S3Client)
 }
 
 func TestS3Factory(t *testing.T) {
@@ -53,6 +58,10 @@ func TestS3Factory(t *testing.T) {
 		t.Fatalf("Incorrect keyName was populated")
 	}
 
+	if s3Client.lockTable != "" {
+		t.Fatalf("Lock table should be empty by default")
+	}
+
 	credentials, err := s3Client.nativeClient.Config.Credentials.Get()
 	if err != nil {
 		t.Fatalf("Error when requesting credentials")
@@ -65,6 +74,128 @@ func TestS3Factory(t *testing.T) {
 	}
 }
 
+func TestS3Factory_locking(t *testing.T) {
+	config := map[string]string{
+		"region":     "us-west-1",
+		"bucket":     "foo",
+		"key":        "bar",
+		"lock_table": "terraform-locks",
+		"version_id": "abc123",
+		"access_key": "bazkey",
+		"secret_key": "bazsecret",
+	}
+
+	client, err := s3Factory(config)
+	if err != nil {
+		t.Fatalf("Error for valid config")
+	}
+
+	s3Client := client.(*S3Client)
+
+	if s3Client.lockTable != "terraform-locks" {
+		t.Fatalf("Incorrect lockTable was populated")
+	}
+	if s3Client.lockID() != "foo/bar" {
+		t.Fatalf("Incorrect lock ID: %s", s3Client.lockID())
+	}
+
+	// Environments are stored below their own prefix, and have their
+	// own lock
+	env, err := s3Client.Env("prod")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id := env.(*S3Client).lockID(); id != "foo/env:/prod/bar" {
+		t.Fatalf("Incorrect environment lock ID: %s", id)
+	}
+	if *s3Client.dynClient.Config.Region != "us-west-1" {
+		t.Fatalf("Incorrect DynamoDB region was populated")
+	}
+
+	// Reading a specific version makes the client read-only
+	if err := s3Client.Put([]byte("data")); err == nil {
+		t.Fatalf("Put should fail when a version is set")
+	}
+	if err := s3Client.Delete(); err == nil {
+		t.Fatalf("Delete should fail when a version is set")
+	}
+
+	// A client that didn't take the lock must not remove it
+	if err := s3Client.Unlock(); err != nil {
+		t.Fatalf("Unlock without a lock should be a no-op: %s", err)
+	}
+}
+
+func TestS3Client_unlock(t *testing.T) {
+	client, err := s3Factory(map[string]string{
+		"region":     "us-west-1",
+		"bucket":     "foo",
+		"key":        "bar",
+		"lock_table": "terraform-locks",
+		"access_key": "bazkey",
+		"secret_key": "bazsecret",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	s3Client := client.(*S3Client)
+	s3Client.lockOwner = "abc123"
+
+	// Capture the request instead of sending it
+	var input *dynamodb.DeleteItemInput
+	s3Client.dynClient.Handlers.Clear()
+	s3Client.dynClient.Handlers.Send.PushBack(func(r *request.Request) {
+		input = r.Params.(*dynamodb.DeleteItemInput)
+	})
+
+	if err := s3Client.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s3Client.lockOwner != "" {
+		t.Fatalf("lock owner should be reset: %s", s3Client.lockOwner)
+	}
+
+	if input == nil {
+		t.Fatal("DeleteItem should be called")
+	}
+	if *input.TableName != "terraform-locks" {
+		t.Fatalf("bad table: %s", *input.TableName)
+	}
+	if id := *input.Key["LockID"].S; id != "foo/bar" {
+		t.Fatalf("bad lock ID: %s", id)
+	}
+	if *input.ConditionExpression != "#owner = :owner" {
+		t.Fatalf("bad condition: %s", *input.ConditionExpression)
+	}
+	if name := input.ExpressionAttributeNames["#owner"]; name == nil || *name != "Owner" {
+		t.Fatalf("bad attribute names: %#v", input.ExpressionAttributeNames)
+	}
+	if owner := *input.ExpressionAttributeValues[":owner"].S; owner != "abc123" {
+		t.Fatalf("bad owner: %s", owner)
+	}
+}
+
+func TestS3LockOwner(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	a, err := s3LockOwner()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !re.MatchString(a) {
+		t.Fatalf("bad: %s", a)
+	}
+
+	b, err := s3LockOwner()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if a == b {
+		t.Fatalf("lock owners should be unique: %s", a)
+	}
+}
+
 func TestS3Client(t *testing.T) {
 	// This test creates a bucket in S3 and populates it.
 	// It may incur costs, so it will only run if AWS credential environment
@@ -47,6 +47,29 @@ func (s *State) RefreshState() error {
 	return nil
 }
 
+// Lock locks the remote state if the client supports locking, and does
+// nothing otherwise.
+//
+// state.Locker impl.
+func (s *State) Lock(reason string) error {
+	if c, ok := s.Client.(ClientLocker); ok {
+		return c.Lock(reason)
+	}
+
+	return nil
+}
+
+// Unlock unlocks the remote state if the client supports locking.
+//
+// state.Locker impl.
+func (s *State) Unlock() error {
+	if c, ok := s.Client.(ClientLocker); ok {
+		return c.Unlock()
+	}
+
+	return nil
+}
+
 // StatePersister impl.
 func (s *State) PersistState() error {
 	s.state.IncrementSerialMaybe(s.readState)
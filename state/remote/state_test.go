@@ -24,4 +24,40 @@ func TestState_impl(t *testing.T) {
 	var _ state.StateWriter = new(State)
 	var _ state.StatePersister = new(State)
 	var _ state.StateRefresher = new(State)
+	var _ state.Locker = new(State)
+}
+
+func TestState_lock(t *testing.T) {
+	client := new(InmemClient)
+	s := &State{Client: client}
+
+	if err := s.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockReason != "apply" {
+		t.Fatalf("bad: %s", client.LockReason)
+	}
+
+	// A second lock must fail while the first is held
+	if err := s.Lock("plan"); err == nil {
+		t.Fatal("should error")
+	}
+
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.LockReason != "" {
+		t.Fatalf("bad: %s", client.LockReason)
+	}
+}
+
+func TestState_lockUnsupported(t *testing.T) {
+	s := &State{Client: &HTTPClient{}}
+
+	if err := s.Lock("apply"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := s.Unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
 }
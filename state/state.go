@@ -34,6 +34,15 @@ type StateRefresher interface {
 	RefreshState() error
 }
 
+// Locker is implemented by states that can be locked, to prevent multiple
+// operations from modifying the state at the same time. Lock must return
+// an error if the state is already locked. The reason is stored with the
+// lock to help users figure out who holds it.
+type Locker interface {
+	Lock(reason string) error
+	Unlock() error
+}
+
 // StatePersister is implemented to truly persist a state. Whereas StateWriter
 // is allowed to perhaps be caching in memory, PersistState must write the
 // state to some durable storage.
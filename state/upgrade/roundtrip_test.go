@@ -0,0 +1,91 @@
+package upgrade_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/terraform-api/state/upgrade"
+	"github.com/xanzy/terraform-api/terraform"
+)
+
+func TestCurrentVersion(t *testing.T) {
+	if upgrade.CurrentVersion != terraform.StateVersion {
+		t.Fatalf("bad: %d != %d", upgrade.CurrentVersion, terraform.StateVersion)
+	}
+}
+
+// TestReadState_roundTrip reads a state of every version, and checks that
+// it survives writing and reading it again.
+func TestReadState_roundTrip(t *testing.T) {
+	for _, name := range []string{"v0.json", "v1.json", "v2.json"} {
+		s := testReadState(t, name)
+		if s.Version != terraform.StateVersion {
+			t.Fatalf("%s: bad version: %d", name, s.Version)
+		}
+
+		rs := s.RootModule().Resources["aws_instance.web"]
+		if rs == nil || rs.Primary.ID != "i-abc123" {
+			t.Fatalf("%s: bad: %#v", name, s)
+		}
+
+		var buf bytes.Buffer
+		if err := terraform.WriteState(s, &buf); err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+
+		actual, err := terraform.ReadState(&buf)
+		if err != nil {
+			t.Fatalf("%s: err: %s", name, err)
+		}
+		if !reflect.DeepEqual(actual, s) {
+			t.Fatalf("%s: bad:\n\n%#v\n\n%#v", name, actual, s)
+		}
+	}
+}
+
+// TestReadState_versions checks that every version reads to the same
+// state.
+func TestReadState_versions(t *testing.T) {
+	expected := testReadState(t, "v2.json")
+	for _, name := range []string{"v0.json", "v1.json"} {
+		actual := testReadState(t, name)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%s: bad:\n\n%#v\n\n%#v", name, actual, expected)
+		}
+	}
+}
+
+func TestReadState_future(t *testing.T) {
+	f, err := os.Open(filepath.Join("test-fixtures", "future.json"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	_, err = terraform.ReadState(f)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "99") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func testReadState(t *testing.T, name string) *terraform.State {
+	f, err := os.Open(filepath.Join("test-fixtures", name))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+
+	s, err := terraform.ReadState(f)
+	if err != nil {
+		t.Fatalf("%s: err: %s", name, err)
+	}
+
+	return s
+}
@@ -0,0 +1,204 @@
+// Package upgrade upgrades the JSON representation of a state written by
+// any earlier version of the state format to the current version.
+//
+// Upgrades work on the raw, decoded JSON of the state so that every
+// historical version can be read without keeping a Go type around for
+// it. Each upgrade step takes a state of one version to the next, and the
+// version of the state is stamped after every step.
+//
+// The binary format used before Terraform 0.3 is not JSON, and is
+// upgraded by terraform.ReadState before these upgrades run.
+package upgrade
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xanzy/terraform-api/config"
+)
+
+// CurrentVersion is the latest version of the state format. It must be
+// kept equal to terraform.StateVersion.
+const CurrentVersion = 2
+
+// Func upgrades the raw state in place from the version it is registered
+// for to the next version. The version itself is stamped by Upgrade.
+type Func func(state map[string]interface{}) error
+
+// upgrades contains the upgrade for every version before the current
+// one, keyed by the version it upgrades from.
+var upgrades = map[int]Func{
+	0: upgradeV0,
+	1: upgradeV1,
+}
+
+// Version returns the version stamped in the raw state. A state without
+// a version is version 0.
+func Version(state map[string]interface{}) (int, error) {
+	raw := state[versionKey(state)]
+	if raw == nil {
+		return 0, nil
+	}
+
+	v, ok := raw.(float64)
+	if !ok || v != float64(int(v)) || v < 0 {
+		return 0, fmt.Errorf("Invalid state version: %v", raw)
+	}
+
+	return int(v), nil
+}
+
+// Upgrade upgrades the raw state in place to the current version, and
+// returns the version the state had before.
+func Upgrade(state map[string]interface{}) (int, error) {
+	from, err := Version(state)
+	if err != nil {
+		return 0, err
+	}
+
+	if from > CurrentVersion {
+		return from, fmt.Errorf(
+			"State version %d not supported, the latest supported version "+
+				"is %d. The state was written by a newer version of "+
+				"Terraform, please update.", from, CurrentVersion)
+	}
+
+	// Stamp the version using the key the state is written with from now
+	// on, no matter how it was spelled.
+	if k := versionKey(state); k != "version" {
+		delete(state, k)
+	}
+
+	for v := from; v < CurrentVersion; v++ {
+		f, ok := upgrades[v]
+		if !ok {
+			return from, fmt.Errorf(
+				"No upgrade available for state version %d", v)
+		}
+
+		if err := f(state); err != nil {
+			return from, fmt.Errorf(
+				"Error upgrading state from version %d to %d: %s", v, v+1, err)
+		}
+
+		state["version"] = v + 1
+	}
+
+	return from, nil
+}
+
+// UpgradeJSON upgrades the JSON encoded state to the current version. If
+// the state already is the current version, it is returned unmodified.
+func UpgradeJSON(data []byte) ([]byte, error) {
+	var state map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&state); err != nil {
+		return nil, fmt.Errorf("Decoding state failed: %s", err)
+	}
+	if state == nil {
+		return nil, fmt.Errorf("Decoding state failed: state is not an object")
+	}
+
+	// Numbers are kept as json.Number so large serials survive the
+	// round trip, convert the version for Upgrade.
+	k := versionKey(state)
+	if n, ok := state[k].(json.Number); ok {
+		v, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid state version: %s", n)
+		}
+		state[k] = v
+	}
+
+	from, err := Upgrade(state)
+	if err != nil {
+		return nil, err
+	}
+	if from == CurrentVersion {
+		return data, nil
+	}
+
+	return json.Marshal(state)
+}
+
+// versionKey returns the key of the version in the raw state. Like
+// encoding/json, the key is matched case-insensitively.
+func versionKey(state map[string]interface{}) string {
+	if _, ok := state["version"]; ok {
+		return "version"
+	}
+	for k := range state {
+		if strings.EqualFold(k, "version") {
+			return k
+		}
+	}
+
+	return "version"
+}
+
+// upgradeV0 upgrades a state without a version. The format didn't change,
+// only the version is stamped.
+func upgradeV0(state map[string]interface{}) error {
+	return nil
+}
+
+// upgradeV1 upgrades the outputs of every module from plain strings to
+// typed outputs. Lists used to be stored flattened, so those become real
+// lists.
+func upgradeV1(state map[string]interface{}) error {
+	rawModules, ok := state["modules"]
+	if !ok || rawModules == nil {
+		return nil
+	}
+	modules, ok := rawModules.([]interface{})
+	if !ok {
+		return fmt.Errorf("modules must be a list")
+	}
+
+	for i, rawModule := range modules {
+		module, ok := rawModule.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("module %d must be an object", i)
+		}
+
+		rawOutputs, ok := module["outputs"]
+		if !ok || rawOutputs == nil {
+			continue
+		}
+		outputs, ok := rawOutputs.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("outputs of module %d must be an object", i)
+		}
+
+		for k, raw := range outputs {
+			v, ok := raw.(string)
+			if !ok {
+				return fmt.Errorf(
+					"output %s of module %d must be a string", k, i)
+			}
+
+			output := map[string]interface{}{
+				"sensitive": false,
+				"type":      "string",
+				"value":     v,
+			}
+			if config.IsStringList(v) {
+				parts := config.StringList(v).Slice()
+				list := make([]interface{}, len(parts))
+				for j, p := range parts {
+					list[j] = p
+				}
+
+				output["type"] = "list"
+				output["value"] = list
+			}
+
+			outputs[k] = output
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package upgrade
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/terraform-api/config"
+)
+
+func TestVersion(t *testing.T) {
+	cases := []struct {
+		State   map[string]interface{}
+		Version int
+		Err     bool
+	}{
+		{map[string]interface{}{}, 0, false},
+		{map[string]interface{}{"version": nil}, 0, false},
+		{map[string]interface{}{"version": float64(1)}, 1, false},
+		{map[string]interface{}{"Version": float64(2)}, 2, false},
+		{map[string]interface{}{"version": float64(1.5)}, 0, true},
+		{map[string]interface{}{"version": float64(-1)}, 0, true},
+		{map[string]interface{}{"version": "1"}, 0, true},
+	}
+
+	for i, tc := range cases {
+		v, err := Version(tc.State)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: err: %s", i, err)
+		}
+		if v != tc.Version {
+			t.Fatalf("%d: bad: %d", i, v)
+		}
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	state := map[string]interface{}{"serial": float64(2)}
+
+	from, err := Upgrade(state)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if from != 0 {
+		t.Fatalf("bad: %d", from)
+	}
+	if state["version"] != CurrentVersion {
+		t.Fatalf("bad: %#v", state)
+	}
+	if state["serial"] != float64(2) {
+		t.Fatalf("bad: %#v", state)
+	}
+}
+
+func TestUpgrade_current(t *testing.T) {
+	state := map[string]interface{}{"version": float64(CurrentVersion)}
+
+	from, err := Upgrade(state)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if from != CurrentVersion {
+		t.Fatalf("bad: %d", from)
+	}
+}
+
+func TestUpgrade_future(t *testing.T) {
+	state := map[string]interface{}{"version": float64(CurrentVersion + 1)}
+
+	_, err := Upgrade(state)
+	if err == nil {
+		t.Fatal("should error")
+	}
+	if !strings.Contains(err.Error(), "not supported") {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestUpgrade_stampsEachStep(t *testing.T) {
+	defer func(old map[int]Func) { upgrades = old }(upgrades)
+
+	var seen []int
+	upgrades = map[int]Func{
+		0: func(state map[string]interface{}) error {
+			seen = append(seen, 0)
+			return nil
+		},
+		1: func(state map[string]interface{}) error {
+			if state["version"] != 1 {
+				t.Fatalf("bad: %#v", state)
+			}
+			seen = append(seen, 1)
+			return nil
+		},
+	}
+
+	state := map[string]interface{}{"Version": float64(0)}
+	if _, err := Upgrade(state); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("bad: %#v", seen)
+	}
+	if _, ok := state["Version"]; ok {
+		t.Fatalf("bad: %#v", state)
+	}
+	if state["version"] != 2 {
+		t.Fatalf("bad: %#v", state)
+	}
+}
+
+func TestUpgrade_missingStep(t *testing.T) {
+	defer func(old map[int]Func) { upgrades = old }(upgrades)
+	upgrades = map[int]Func{}
+
+	_, err := Upgrade(map[string]interface{}{})
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestUpgradeJSON(t *testing.T) {
+	out, err := UpgradeJSON([]byte(`{"serial": 12345678901234567890}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(out, &state); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(state["version"]) != "2" {
+		t.Fatalf("bad: %s", out)
+	}
+
+	// Numbers must survive the upgrade unmodified
+	if string(state["serial"]) != "12345678901234567890" {
+		t.Fatalf("bad: %s", out)
+	}
+}
+
+func TestUpgradeJSON_current(t *testing.T) {
+	in := []byte(`{"version": 2, "serial": 1}`)
+
+	out, err := UpgradeJSON(in)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(out) != string(in) {
+		t.Fatalf("bad: %s", out)
+	}
+}
+
+func TestUpgradeJSON_invalid(t *testing.T) {
+	inputs := []string{
+		`nope`,
+		`null`,
+		`{"version": "one"}`,
+	}
+
+	for _, in := range inputs {
+		if _, err := UpgradeJSON([]byte(in)); err == nil {
+			t.Fatalf("should error: %s", in)
+		}
+	}
+}
+
+func TestUpgradeV1(t *testing.T) {
+	list := config.NewStringList([]string{"a", "b"}).String()
+	state := map[string]interface{}{
+		"modules": []interface{}{
+			map[string]interface{}{
+				"outputs": map[string]interface{}{
+					"address": "10.0.0.1",
+					"zones":   list,
+				},
+			},
+			map[string]interface{}{},
+		},
+	}
+
+	if err := upgradeV1(state); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"address": map[string]interface{}{
+			"sensitive": false,
+			"type":      "string",
+			"value":     "10.0.0.1",
+		},
+		"zones": map[string]interface{}{
+			"sensitive": false,
+			"type":      "list",
+			"value":     []interface{}{"a", "b"},
+		},
+	}
+	modules := state["modules"].([]interface{})
+	actual := modules[0].(map[string]interface{})["outputs"]
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestUpgradeV1_invalid(t *testing.T) {
+	state := map[string]interface{}{
+		"modules": []interface{}{
+			map[string]interface{}{
+				"outputs": map[string]interface{}{
+					"address": float64(1),
+				},
+			},
+		},
+	}
+
+	if err := upgradeV1(state); err == nil {
+		t.Fatal("should error")
+	}
+}
@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -46,9 +47,27 @@ type ContextOpts struct {
 	Targets      []string
 	Variables    map[string]string
 
+	// Events, if set, receives a typed event for the progress of every
+	// resource, such as the planned changes and the start and result of
+	// applying it.
+	Events EventHandler
+
 	UIInput UIInput
+
+	// UIOutput, if set, returns the UIOutput the output of every
+	// provisioner is sent to, in addition to the ProvisionOutput hook. If
+	// it implements UIStreamOutput, the output written to stdout and
+	// stderr is sent to it separately.
+	UIOutput UIOutputFunc
 }
 
+// ErrForceStopped is returned by an operation of a Context that was
+// forcefully stopped by calling Stop twice. Operations that were still
+// running may continue in the background, and the next operation of the
+// Context waits for them to finish.
+var ErrForceStopped = errors.New(
+	"operation forcefully stopped, in-flight operations were abandoned")
+
 // Context represents all the context that Terraform needs in order to
 // perform operations on infrastructure. This structure is built using
 // NewContext. See the documentation for that.
@@ -65,12 +84,16 @@ type Context struct {
 	stateLock    sync.RWMutex
 	targets      []string
 	uiInput      UIInput
+	uiOutput     UIOutputFunc
 	variables    map[string]string
 
 	l                   sync.Mutex // Lock acquired during any task
 	parallelSem         Semaphore
 	providerInputConfig map[string]map[string]interface{}
 	runCh               <-chan struct{}
+	forceCh             chan struct{}
+	abandonedCh         <-chan struct{}
+	walker              *ContextGraphWalker
 }
 
 // NewContext creates a new Context structure.
@@ -82,9 +105,12 @@ func NewContext(opts *ContextOpts) *Context {
 	// Copy all the hooks and add our stop hook. We don't append directly
 	// to the Config so that we're not modifying that in-place.
 	sh := new(stopHook)
-	hooks := make([]Hook, len(opts.Hooks)+1)
+	hooks := make([]Hook, len(opts.Hooks), len(opts.Hooks)+2)
 	copy(hooks, opts.Hooks)
-	hooks[len(opts.Hooks)] = sh
+	if opts.Events != nil {
+		hooks = append(hooks, &EventHook{Handler: opts.Events})
+	}
+	hooks = append(hooks, sh)
 
 	state := opts.State
 	if state == nil {
@@ -130,6 +156,7 @@ func NewContext(opts *ContextOpts) *Context {
 		state:        state,
 		targets:      opts.Targets,
 		uiInput:      opts.UIInput,
+		uiOutput:     opts.UIOutput,
 		variables:    variables,
 
 		parallelSem:         NewSemaphore(par),
@@ -208,6 +235,8 @@ func (c *Context) Input(mode InputMode) error {
 				continue
 			case config.VariableTypeMap:
 				continue
+			case config.VariableTypeList:
+				continue
 			case config.VariableTypeString:
 				// Good!
 			default:
@@ -279,16 +308,33 @@ func (c *Context) Input(mode InputMode) error {
 // In addition to returning the resulting state, this context is updated
 // with the latest state.
 func (c *Context) Apply() (*State, error) {
+	state, _, err := c.ApplyWithSummary()
+	return state, err
+}
+
+// ApplyWithSummary is like Apply, but also returns the summary of the
+// changes that were made to the resources. Changes that failed are not
+// part of the summary.
+func (c *Context) ApplyWithSummary() (*State, *ChangeSummary, error) {
 	v := c.acquireRun()
 	defer c.releaseRun(v)
 
+	// Record the changes while walking. The hook goes first so the
+	// changes are recorded even if a later hook halts.
+	sh := new(summaryHook)
+	hooks := c.hooks
+	c.hooks = append([]Hook{sh}, hooks...)
+	defer func() {
+		c.hooks = hooks
+	}()
+
 	// Copy our own state
 	c.state = c.state.DeepCopy()
 
 	// Build the graph
 	graph, err := c.Graph(&ContextGraphOpts{Validate: true})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Do the walk
@@ -298,10 +344,21 @@ func (c *Context) Apply() (*State, error) {
 		_, err = c.walk(graph, walkApply)
 	}
 
+	// If we were forced to stop, the walk is still modifying the state,
+	// so return a copy of what we have so far.
+	if err == ErrForceStopped {
+		c.stateLock.RLock()
+		s := c.state.DeepCopy()
+		c.stateLock.RUnlock()
+
+		s.prune()
+		return s, sh.result(), err
+	}
+
 	// Clean out any unused things
 	c.state.prune()
 
-	return c.state, err
+	return c.state, sh.result(), err
 }
 
 // Plan generates an execution plan for the given context.
@@ -310,7 +367,8 @@ func (c *Context) Apply() (*State, error) {
 // in order to reinstantiate a context later for Apply.
 //
 // Plan also updates the diff of this context to be the diff generated
-// by the plan, so Apply can be called after.
+// by the plan, so Apply can be called after. Plan.Summary tells whether
+// the plan has any changes.
 func (c *Context) Plan() (*Plan, error) {
 	v := c.acquireRun()
 	defer c.releaseRun(v)
@@ -401,7 +459,16 @@ func (c *Context) Refresh() (*State, error) {
 
 // Stop stops the running task.
 //
-// Stop will block until the task completes.
+// The first call stops the task gracefully: no new operations are
+// started, and the providers that implement ResourceProviderStopper are
+// asked to abort their in-flight operations. Stop will block until the
+// task completes, so the state returned by Apply includes everything that
+// was done until then.
+//
+// Calling Stop again while the task is stopping forces it to stop: the
+// task returns right away with ErrForceStopped, without waiting for the
+// in-flight operations, and Apply returns the state as it is at that
+// moment. This call doesn't block.
 func (c *Context) Stop() {
 	c.l.Lock()
 	ch := c.runCh
@@ -412,11 +479,29 @@ func (c *Context) Stop() {
 		return
 	}
 
+	// If we're already stopping, force it
+	if c.sh.Stopped() {
+		select {
+		case <-c.forceCh:
+		default:
+			close(c.forceCh)
+		}
+
+		c.l.Unlock()
+		return
+	}
+
 	// Tell the hook we want to stop
 	c.sh.Stop()
 
-	// Wait for us to stop
+	// Tell the providers we want to stop
+	walker := c.walker
 	c.l.Unlock()
+	if walker != nil {
+		walker.stopProviders()
+	}
+
+	// Wait for us to stop
 	<-ch
 }
 
@@ -488,16 +573,26 @@ func (c *Context) acquireRun() chan<- struct{} {
 	c.l.Lock()
 	defer c.l.Unlock()
 
-	// Wait for no channel to exist
-	for c.runCh != nil {
-		c.l.Unlock()
+	// Wait for no channel to exist. A walk that was abandoned by a forced
+	// stop still uses our state, so we wait for it to finish as well.
+	for c.runCh != nil || c.abandonedCh != nil {
 		ch := c.runCh
+		if ch == nil {
+			ch = c.abandonedCh
+		}
+
+		c.l.Unlock()
 		<-ch
 		c.l.Lock()
 	}
 
+	// The stop hook is only reset now, so that an abandoned walk keeps
+	// halting until it is done.
+	c.sh.Reset()
+
 	ch := make(chan struct{})
 	c.runCh = ch
+	c.forceCh = make(chan struct{})
 	return ch
 }
 
@@ -507,13 +602,61 @@ func (c *Context) releaseRun(ch chan<- struct{}) {
 
 	close(ch)
 	c.runCh = nil
-	c.sh.Reset()
+	c.forceCh = nil
 }
 
 func (c *Context) walk(
 	graph *Graph, operation walkOperation) (*ContextGraphWalker, error) {
 	// Walk the graph
 	log.Printf("[DEBUG] Starting graph walk: %s", operation.String())
-	walker := &ContextGraphWalker{Context: c, Operation: operation}
-	return walker, graph.Walk(walker)
+	walker := &ContextGraphWalker{
+		Context:   c,
+		Operation: operation,
+		hooks:     c.hooks,
+		state:     c.state,
+	}
+
+	// Keep track of the walker, so Stop can reach its providers
+	c.l.Lock()
+	c.walker = walker
+	forceCh := c.forceCh
+	c.l.Unlock()
+	defer func() {
+		c.l.Lock()
+		c.walker = nil
+		c.l.Unlock()
+	}()
+
+	doneCh := make(chan error, 1)
+	walkCh := make(chan struct{})
+	go func() {
+		err := graph.Walk(walker)
+
+		c.l.Lock()
+		close(walkCh)
+		if c.abandonedCh == walkCh {
+			c.abandonedCh = nil
+		}
+		c.l.Unlock()
+
+		doneCh <- err
+	}()
+
+	select {
+	case err := <-doneCh:
+		return walker, err
+	case <-forceCh:
+		log.Printf("[WARN] Graph walk forcefully stopped: %s", operation.String())
+
+		// Block the next run until the walk is really done
+		c.l.Lock()
+		select {
+		case <-walkCh:
+		default:
+			c.abandonedCh = walkCh
+		}
+		c.l.Unlock()
+
+		return walker, ErrForceStopped
+	}
 }
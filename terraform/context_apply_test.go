@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/xanzy/terraform-api/config"
 	"github.com/xanzy/terraform-api/config/module"
 )
 
@@ -47,6 +48,84 @@ func TestContext2Apply(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_summary(t *testing.T) {
+	m := testModule(t, "apply-good")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err := ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if plan.Summary().Empty() {
+		t.Fatal("plan should have changes")
+	}
+
+	state, summary, err := ctx.ApplyWithSummary()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]EventAction{
+		"aws_instance.foo": EventActionCreate,
+		"aws_instance.bar": EventActionCreate,
+	}
+	if !reflect.DeepEqual(summary.Resources, expected) {
+		t.Fatalf("bad: %#v", summary.Resources)
+	}
+
+	// Nothing changes when planning again
+	ctx = testContext2(t, &ContextOpts{
+		State:  state,
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	plan, err = ctx.Plan()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if s := plan.Summary(); !s.Empty() {
+		t.Fatalf("plan should be empty: %#v", s.Resources)
+	}
+
+	// Destroy everything
+	ctx = testContext2(t, &ContextOpts{
+		Destroy: true,
+		State:   state,
+		Module:  m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, summary, err = ctx.ApplyWithSummary()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected = map[string]EventAction{
+		"aws_instance.foo": EventActionDestroy,
+		"aws_instance.bar": EventActionDestroy,
+	}
+	if !reflect.DeepEqual(summary.Resources, expected) {
+		t.Fatalf("bad: %#v", summary.Resources)
+	}
+}
+
 func TestContext2Apply_providerAlias(t *testing.T) {
 	m := testModule(t, "apply-provider-alias")
 	p := testProvider("aws")
@@ -509,6 +588,161 @@ func TestContext2Apply_cancel(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_cancelStopProvider(t *testing.T) {
+	m := testModule(t, "apply-cancel")
+	p := testProvider("aws")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	// The apply blocks until the provider is told to stop
+	stopCh := make(chan struct{})
+	p.StopFn = func() error {
+		close(stopCh)
+		return nil
+	}
+	p.ApplyFn = func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error) {
+		go ctx.Stop()
+		<-stopCh
+
+		return &InstanceState{
+			ID: "foo",
+			Attributes: map[string]string{
+				"num": "2",
+			},
+		}, nil
+	}
+	p.DiffFn = func(*InstanceInfo, *InstanceState, *ResourceConfig) (*InstanceDiff, error) {
+		return &InstanceDiff{
+			Attributes: map[string]*ResourceAttrDiff{
+				"num": &ResourceAttrDiff{
+					New: "bar",
+				},
+			},
+		}, nil
+	}
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !p.StopCalled {
+		t.Fatal("stop should be called")
+	}
+
+	actual := strings.TrimSpace(state.String())
+	expected := strings.TrimSpace(testTerraformApplyCancelStr)
+	if actual != expected {
+		t.Fatalf("bad: \n%s", actual)
+	}
+}
+
+func TestContext2Apply_cancelForce(t *testing.T) {
+	m := testModule(t, "apply-cancel")
+	p := testProvider("aws")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	// The apply ignores the first stop and blocks until the test ends
+	releaseCh := make(chan struct{})
+	defer close(releaseCh)
+	p.ApplyFn = func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error) {
+		go ctx.Stop()
+		for !ctx.sh.Stopped() {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		ctx.Stop()
+		<-releaseCh
+
+		return &InstanceState{ID: "foo"}, nil
+	}
+	p.DiffFn = testDiffFn
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != ErrForceStopped {
+		t.Fatalf("err: %s", err)
+	}
+
+	if state == nil {
+		t.Fatal("state should not be nil")
+	}
+	if len(state.RootModule().Resources) != 0 {
+		t.Fatalf("bad: %s", state.String())
+	}
+}
+
+func TestContext2Apply_cancelForceNextRun(t *testing.T) {
+	m := testModule(t, "apply-cancel")
+	p := testProvider("aws")
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	releaseCh := make(chan struct{})
+	p.ApplyFn = func(*InstanceInfo, *InstanceState, *InstanceDiff) (*InstanceState, error) {
+		go ctx.Stop()
+		for !ctx.sh.Stopped() {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		ctx.Stop()
+		<-releaseCh
+
+		return &InstanceState{ID: "foo"}, nil
+	}
+	p.DiffFn = testDiffFn
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := ctx.Apply(); err != ErrForceStopped {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The next run must wait for the abandoned walk, since it still
+	// uses the state of the context
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		ctx.Refresh()
+	}()
+
+	select {
+	case <-doneCh:
+		t.Fatal("refresh should wait for the abandoned walk")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(releaseCh)
+
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresh should run once the abandoned walk is done")
+	}
+}
+
 func TestContext2Apply_compute(t *testing.T) {
 	m := testModule(t, "apply-compute")
 	p := testProvider("aws")
@@ -807,6 +1041,46 @@ func TestContext2Apply_countVariable(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_listVariable(t *testing.T) {
+	m := testModule(t, "apply-list-var")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Variables: map[string]string{
+			"zones": config.NewStringList([]string{"eu-west-1a"}).String(),
+		},
+	})
+
+	if w, e := ctx.Validate(); len(w) > 0 || len(e) > 0 {
+		t.Fatalf("bad: %#v %#v", w, e)
+	}
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(state.String())
+	expected := strings.TrimSpace(`
+aws_instance.foo:
+  ID = foo
+  foo = eu-west-1a
+  type = aws_instance
+	`)
+	if actual != expected {
+		t.Fatalf("got: \n%s\nexpected: \n%s", actual, expected)
+	}
+}
+
 func TestContext2Apply_mapVariableOverride(t *testing.T) {
 	m := testModule(t, "apply-map-var-override")
 	p := testProvider("aws")
@@ -918,8 +1192,11 @@ func TestContext2Apply_moduleDestroyOrder(t *testing.T) {
 						},
 					},
 				},
-				Outputs: map[string]string{
-					"a_output": "a",
+				Outputs: map[string]*OutputState{
+					"a_output": &OutputState{
+						Type:  "string",
+						Value: "a",
+					},
 				},
 			},
 		},
@@ -1275,7 +1552,7 @@ func TestContext2Apply_multiVar(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
-	actual := state.RootModule().Outputs["output"]
+	actual := state.RootModule().Outputs["output"].Value
 	expected := "bar0,bar1,bar2"
 	if actual != expected {
 		t.Fatalf("bad: \n%s", actual)
@@ -1303,7 +1580,7 @@ func TestContext2Apply_multiVar(t *testing.T) {
 			t.Fatalf("err: %s", err)
 		}
 
-		actual := state.RootModule().Outputs["output"]
+		actual := state.RootModule().Outputs["output"].Value
 		expected := "bar0"
 		if actual != expected {
 			t.Fatalf("bad: \n%s", actual)
@@ -1346,9 +1623,15 @@ func TestContext2Apply_outputOrphan(t *testing.T) {
 		Modules: []*ModuleState{
 			&ModuleState{
 				Path: rootModulePath,
-				Outputs: map[string]string{
-					"foo": "bar",
-					"bar": "baz",
+				Outputs: map[string]*OutputState{
+					"foo": &OutputState{
+						Type:  "string",
+						Value: "bar",
+					},
+					"bar": &OutputState{
+						Type:  "string",
+						Value: "baz",
+					},
 				},
 			},
 		},
@@ -1880,6 +2163,135 @@ aws_instance.web:
 	`)
 }
 
+func TestContext2Apply_provisionerDestroy(t *testing.T) {
+	m := testModule(t, "apply-provisioner-destroy")
+	p := testProvider("aws")
+	pr := testProvisioner()
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+
+	var commands []string
+	pr.ApplyFn = func(rs *InstanceState, c *ResourceConfig) error {
+		val, ok := c.Config["command"]
+		if !ok {
+			t.Fatalf("bad value for command: %v %#v", val, c)
+		}
+		commands = append(commands, val.(string))
+		return nil
+	}
+
+	// Creating the resource must not run the destroy-time provisioner
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(commands) > 0 {
+		t.Fatalf("provisioner should not be called on create: %#v", commands)
+	}
+
+	// Destroying it must run the provisioner, with access to self
+	ctx = testContext2(t, &ContextOpts{
+		Module: m,
+		State:  state,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+		Destroy: true,
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err = ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{"destroy bar"}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Fatalf("bad: %#v", commands)
+	}
+
+	checkStateString(t, state, `<no state>`)
+}
+
+func TestContext2Apply_provisionerDestroyFail(t *testing.T) {
+	m := testModule(t, "apply-provisioner-destroy")
+	p := testProvider("aws")
+	pr := testProvisioner()
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	pr.ApplyFn = func(*InstanceState, *ResourceConfig) error {
+		return fmt.Errorf("EXPLOSION")
+	}
+
+	s := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID: "bar",
+							Attributes: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		State:  s,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+		Destroy: true,
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	// The resource must not be destroyed when its provisioner failed
+	checkStateString(t, state, `
+aws_instance.foo:
+  ID = bar
+  foo = bar
+	`)
+}
+
 func TestContext2Apply_provisionerResourceRef(t *testing.T) {
 	m := testModule(t, "apply-provisioner-resource-ref")
 	p := testProvider("aws")
@@ -2168,6 +2580,58 @@ func TestContext2Apply_outputDiffVars(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_Provisioner_ConnInfoCount(t *testing.T) {
+	var lock sync.Mutex
+	conns := make([]string, 0, 2)
+
+	m := testModule(t, "apply-provisioner-conninfo-count")
+	p := testProvider("aws")
+	pr := testProvisioner()
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	pr.ApplyFn = func(rs *InstanceState, c *ResourceConfig) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		conn := rs.Ephemeral.ConnInfo
+		conns = append(conns, fmt.Sprintf(
+			"%s:%s %s", conn["host"], conn["port"], c.Config["command"]))
+		return nil
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+	})
+
+	if w, e := ctx.Validate(); len(w) > 0 || len(e) > 0 {
+		t.Fatalf("bad: %#v %#v", w, e)
+	}
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Every instance is provisioned through its own connection
+	sort.Strings(conns)
+	expected := []string{
+		"10.0.0.0:2200 echo 0",
+		"10.0.0.1:2201 echo 1",
+	}
+	if !reflect.DeepEqual(conns, expected) {
+		t.Fatalf("bad: %#v", conns)
+	}
+}
+
 func TestContext2Apply_Provisioner_ConnInfo(t *testing.T) {
 	m := testModule(t, "apply-provisioner-conninfo")
 	p := testProvider("aws")
@@ -2791,6 +3255,106 @@ func TestContext2Apply_hookOrphan(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_hookStateBeforeProvision(t *testing.T) {
+	m := testModule(t, "apply-provisioner-self-ref")
+	h := new(MockHook)
+	p := testProvider("aws")
+	pr := testProvisioner()
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	pr.ApplyFn = func(rs *InstanceState, c *ResourceConfig) error {
+		// The resource must be in the state given to the hooks before
+		// the provisioners run.
+		if !h.PostStateUpdateCalled {
+			t.Fatal("should call post state update")
+		}
+		rs = h.PostStateUpdateState.RootModule().Resources["aws_instance.foo"].Primary
+		if rs.ID != "foo" {
+			t.Fatalf("bad: %#v", rs)
+		}
+		return nil
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Hooks:  []Hook{h},
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Only look at the hook calls of the apply
+	h.PostStateUpdateCalled = false
+	h.PostStateUpdateState = nil
+
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !pr.ApplyCalled {
+		t.Fatal("provisioner not invoked")
+	}
+}
+
+func TestContext2Apply_hookDestroy(t *testing.T) {
+	m := testModule(t, "apply-destroy")
+	h := new(MockHook)
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+
+	state := &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo": &ResourceState{
+						Type: "aws_instance",
+						Primary: &InstanceState{
+							ID: "foo",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Destroy: true,
+		Module:  m,
+		State:   state,
+		Hooks:   []Hook{h},
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	h.PostStateUpdateCalled = false
+	h.PostStateUpdateState = nil
+
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !h.PostStateUpdateCalled {
+		t.Fatal("should call post state update")
+	}
+	if len(h.PostStateUpdateState.RootModule().Resources) != 0 {
+		t.Fatalf("bad: %s", h.PostStateUpdateState)
+	}
+}
+
 func TestContext2Apply_idAttr(t *testing.T) {
 	m := testModule(t, "apply-idattr")
 	p := testProvider("aws")
@@ -2968,6 +3532,54 @@ func TestContext2Apply_outputList(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_outputTyped(t *testing.T) {
+	m := testModule(t, "apply-output-typed")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	state, err := ctx.Apply()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]*OutputState{
+		"ids": &OutputState{
+			Type:  "list",
+			Value: []interface{}{"bar0", "bar1"},
+		},
+		"password": &OutputState{
+			Sensitive: true,
+			Type:      "string",
+			Value:     "secret",
+		},
+		"tags": &OutputState{
+			Type:  "map",
+			Value: map[string]interface{}{"name": "web"},
+		},
+	}
+	actual := state.RootModule().Outputs
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// The list output of the module is usable as a list
+	rs := state.RootModule().Resources["aws_instance.foo"]
+	if rs.Primary.Attributes["foo"] != "bar0-bar1" {
+		t.Fatalf("bad: %#v", rs.Primary.Attributes)
+	}
+}
+
 func TestContext2Apply_outputMulti(t *testing.T) {
 	m := testModule(t, "apply-output-multi")
 	p := testProvider("aws")
@@ -3825,3 +4437,67 @@ func TestContext2Apply_singleDestroy(t *testing.T) {
 		t.Fatalf("bad: %d", invokeCount)
 	}
 }
+
+func TestContext2Apply_provisionerUIOutput(t *testing.T) {
+	m := testModule(t, "apply-provisioner-resource-ref")
+	p := testProvider("aws")
+	pr := testProvisioner()
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+	pr.ApplyFn = func(rs *InstanceState, c *ResourceConfig) error {
+		pr.ApplyOutput.Output("plain")
+		OutputStream(pr.ApplyOutput, UIOutputStderr, "error")
+		return nil
+	}
+
+	hook := new(MockHook)
+	var info *InstanceInfo
+	var provisioner string
+	var output []string
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Hooks:  []Hook{hook},
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Provisioners: map[string]ResourceProvisionerFactory{
+			"shell": testProvisionerFuncFixed(pr),
+		},
+		UIOutput: func(i *InstanceInfo, p string) UIOutput {
+			info = i
+			provisioner = p
+			return &CallbackUIOutput{
+				OutputFn: func(v string) {
+					output = append(output, "out:"+v)
+				},
+				OutputStreamFn: func(s UIOutputStream, v string) {
+					if s == UIOutputStderr {
+						v = "err:" + v
+					}
+					output = append(output, v)
+				},
+			}
+		},
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if info == nil || info.Id != "aws_instance.bar" || provisioner != "shell" {
+		t.Fatalf("bad: %#v %q", info, provisioner)
+	}
+	expected := []string{"out:plain", "err:error"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("bad: %#v", output)
+	}
+
+	// The hooks still get the output
+	if !hook.ProvisionOutputCalled || hook.ProvisionOutputMessage != "error" {
+		t.Fatalf("bad: %#v", hook)
+	}
+}
@@ -452,8 +452,11 @@ func TestContext2Refresh_output(t *testing.T) {
 						},
 					},
 
-					Outputs: map[string]string{
-						"foo": "foo",
+					Outputs: map[string]*OutputState{
+						"foo": &OutputState{
+							Type:  "string",
+							Value: "foo",
+						},
 					},
 				},
 			},
@@ -738,9 +741,15 @@ func TestContext2Refresh_orphanModule(t *testing.T) {
 						},
 					},
 				},
-				Outputs: map[string]string{
-					"id":            "i-bcd234",
-					"grandchild_id": "i-cde345",
+				Outputs: map[string]*OutputState{
+					"id": &OutputState{
+						Type:  "string",
+						Value: "i-bcd234",
+					},
+					"grandchild_id": &OutputState{
+						Type:  "string",
+						Value: "i-cde345",
+					},
 				},
 			},
 			&ModuleState{
@@ -752,8 +761,11 @@ func TestContext2Refresh_orphanModule(t *testing.T) {
 						},
 					},
 				},
-				Outputs: map[string]string{
-					"id": "i-cde345",
+				Outputs: map[string]*OutputState{
+					"id": &OutputState{
+						Type:  "string",
+						Value: "i-cde345",
+					},
 				},
 			},
 		},
@@ -245,11 +245,17 @@ func (d *ModuleDiff) String() string {
 		for _, attrK := range keys {
 			attrDiff := rdiff.Attributes[attrK]
 
+			u := attrDiff.Old
 			v := attrDiff.New
 			if attrDiff.NewComputed {
 				v = "<computed>"
 			}
 
+			if attrDiff.Sensitive {
+				u = SensitiveValue
+				v = SensitiveValue
+			}
+
 			newResource := ""
 			if attrDiff.RequiresNew {
 				newResource = " (forces new resource)"
@@ -259,7 +265,7 @@ func (d *ModuleDiff) String() string {
 				"  %s:%s %#v => %#v%s\n",
 				attrK,
 				strings.Repeat(" ", keyLen-len(attrK)),
-				attrDiff.Old,
+				u,
 				v,
 				newResource))
 		}
@@ -283,11 +289,33 @@ type ResourceAttrDiff struct {
 	NewRemoved  bool        // True if this attribute is being removed
 	NewExtra    interface{} // Extra information for the provider
 	RequiresNew bool        // True if change requires new resource
+	Sensitive   bool        // True if the values should not be displayed
 	Type        DiffAttrType
 }
 
 func (d *ResourceAttrDiff) GoString() string {
-	return fmt.Sprintf("*%#v", *d)
+	return fmt.Sprintf("*%#v", *d.Redacted())
+}
+
+// Redacted returns a copy of the attribute diff where the old and new
+// values are replaced with SensitiveValue if the attribute is marked as
+// sensitive. Non-sensitive diffs are returned as is.
+func (d *ResourceAttrDiff) Redacted() *ResourceAttrDiff {
+	if d == nil || !d.Sensitive {
+		return d
+	}
+
+	r := *d
+	if r.Old != "" {
+		r.Old = SensitiveValue
+	}
+	if r.New != "" {
+		r.New = SensitiveValue
+	}
+	if r.NewExtra != nil {
+		r.NewExtra = SensitiveValue
+	}
+	return &r
 }
 
 // DiffAttrType is an enum type that says whether a resource attribute
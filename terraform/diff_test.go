@@ -165,6 +165,57 @@ func TestModuleDiff_String(t *testing.T) {
 	}
 }
 
+func TestModuleDiff_StringSensitive(t *testing.T) {
+	diff := &ModuleDiff{
+		Resources: map[string]*InstanceDiff{
+			"nodeA": &InstanceDiff{
+				Attributes: map[string]*ResourceAttrDiff{
+					"foo": &ResourceAttrDiff{
+						Old: "foo",
+						New: "bar",
+					},
+					"password": &ResourceAttrDiff{
+						Old:       "secret",
+						New:       "supersecret",
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+
+	actual := strings.TrimSpace(diff.String())
+	expected := strings.TrimSpace(moduleDiffStrSensitive)
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+}
+
+func TestResourceAttrDiff_Redacted(t *testing.T) {
+	d := &ResourceAttrDiff{
+		Old:       "secret",
+		New:       "supersecret",
+		NewExtra:  "supersecret",
+		Sensitive: true,
+	}
+
+	r := d.Redacted()
+	if r.Old != SensitiveValue || r.New != SensitiveValue || r.NewExtra != SensitiveValue {
+		t.Fatalf("bad: %#v", r)
+	}
+	if d.Old != "secret" || d.New != "supersecret" {
+		t.Fatalf("original should not be modified: %#v", *d)
+	}
+	if strings.Contains(d.GoString(), "secret\"") {
+		t.Fatalf("GoString should be redacted: %s", d.GoString())
+	}
+
+	d.Sensitive = false
+	if r := d.Redacted(); r != d {
+		t.Fatalf("non-sensitive diff should not be copied: %#v", r)
+	}
+}
+
 func TestInstanceDiff_ChangeType(t *testing.T) {
 	cases := []struct {
 		Diff   *InstanceDiff
@@ -605,6 +656,12 @@ func TestInstanceDiffSame(t *testing.T) {
 	}
 }
 
+const moduleDiffStrSensitive = `
+UPDATE: nodeA
+  foo:      "foo" => "bar"
+  password: "<sensitive>" => "<sensitive>"
+`
+
 const moduleDiffStrBasic = `
 CREATE: nodeA
   bar:     "foo" => "<computed>"
@@ -141,18 +141,23 @@ type EvalApplyProvisioners struct {
 	CreateNew      *bool
 	Tainted        *bool
 	Error          *error
+
+	// When is the type of provisioners to run at this point. CreateNew
+	// is only checked for create-time provisioners.
+	When config.ProvisionerWhen
 }
 
 // TODO: test
 func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 	state := *n.State
 
-	if !*n.CreateNew {
+	if n.When == config.ProvisionerWhenCreate && !*n.CreateNew {
 		// If we're not creating a new resource, then don't run provisioners
 		return nil, nil
 	}
 
-	if len(n.Resource.Provisioners) == 0 {
+	provs := n.filterProvisioners()
+	if len(provs) == 0 {
 		// We have no provisioners, so don't do anything
 		return nil, nil
 	}
@@ -179,7 +184,7 @@ func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 
 	// If there are no errors, then we append it to our output error
 	// if we have one, otherwise we just output it.
-	err := n.apply(ctx)
+	err := n.apply(ctx, provs)
 	if n.Tainted != nil {
 		*n.Tainted = err != nil
 	}
@@ -204,7 +209,26 @@ func (n *EvalApplyProvisioners) Eval(ctx EvalContext) (interface{}, error) {
 	return nil, nil
 }
 
-func (n *EvalApplyProvisioners) apply(ctx EvalContext) error {
+// filterProvisioners returns the provisioners of the resource that should
+// run at the configured point in its lifecycle.
+func (n *EvalApplyProvisioners) filterProvisioners() []*config.Provisioner {
+	// The config can be nil when the resource is being destroyed after
+	// it was removed from the configuration.
+	if n.Resource == nil {
+		return nil
+	}
+
+	result := make([]*config.Provisioner, 0, len(n.Resource.Provisioners))
+	for _, p := range n.Resource.Provisioners {
+		if p.When == n.When {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func (n *EvalApplyProvisioners) apply(ctx EvalContext, provs []*config.Provisioner) error {
 	state := *n.State
 
 	// Store the original connection info, restore later
@@ -213,7 +237,7 @@ func (n *EvalApplyProvisioners) apply(ctx EvalContext) error {
 		state.Ephemeral.ConnInfo = origConnInfo
 	}()
 
-	for _, prov := range n.Resource.Provisioners {
+	for _, prov := range provs {
 		// Get the provisioner
 		provisioner := ctx.Provisioner(prov.Type)
 
@@ -268,16 +292,33 @@ func (n *EvalApplyProvisioners) apply(ctx EvalContext) error {
 			}
 		}
 
-		// The output function
-		outputFn := func(msg string) {
+		// The output functions. The output is sent to the hooks, and to
+		// the UIOutput for this provisioner if it is captured.
+		uiOutput := ctx.Output(n.Info, prov.Type)
+		hookFn := func(msg string) {
 			ctx.Hook(func(h Hook) (HookAction, error) {
 				h.ProvisionOutput(n.Info, prov.Type, msg)
 				return HookActionContinue, nil
 			})
 		}
+		outputFn := func(msg string) {
+			hookFn(msg)
+			if uiOutput != nil {
+				uiOutput.Output(msg)
+			}
+		}
+		outputStreamFn := func(s UIOutputStream, msg string) {
+			hookFn(msg)
+			if uiOutput != nil {
+				OutputStream(uiOutput, s, msg)
+			}
+		}
 
 		// Invoke the Provisioner
-		output := CallbackUIOutput{OutputFn: outputFn}
+		output := CallbackUIOutput{
+			OutputFn:       outputFn,
+			OutputStreamFn: outputStreamFn,
+		}
 		if err := provisioner.Apply(&output, state, provConfig); err != nil {
 			return err
 		}
@@ -18,6 +18,11 @@ type EvalContext interface {
 	// Input is the UIInput object for interacting with the UI.
 	Input() UIInput
 
+	// Output returns the UIOutput the output of the given provisioner
+	// is sent to while provisioning the given instance, in addition to
+	// the hooks. It returns nil if the output isn't captured.
+	Output(*InstanceInfo, string) UIOutput
+
 	// InitProvider initializes the provider with the given name and
 	// returns the implementation of the resource provider or an error.
 	//
@@ -28,6 +28,7 @@ type BuiltinEvalContext struct {
 
 	Hooks               []Hook
 	InputValue          UIInput
+	OutputValue         UIOutputFunc
 	Providers           map[string]ResourceProviderFactory
 	ProviderCache       map[string]ResourceProvider
 	ProviderConfigCache map[string]*ResourceConfig
@@ -41,6 +42,12 @@ type BuiltinEvalContext struct {
 	StateValue          *State
 	StateLock           *sync.RWMutex
 
+	// ProviderAliases are the providers passed to modules, keyed by the
+	// path of the module. They map the names of providers in the module
+	// to the names of the providers in its parent that they inherit the
+	// configuration of. The map is shared and must not be modified.
+	ProviderAliases map[string]map[string]string
+
 	once sync.Once
 }
 
@@ -68,6 +75,14 @@ func (ctx *BuiltinEvalContext) Input() UIInput {
 	return ctx.InputValue
 }
 
+func (ctx *BuiltinEvalContext) Output(info *InstanceInfo, p string) UIOutput {
+	if ctx.OutputValue == nil {
+		return nil
+	}
+
+	return ctx.OutputValue(info, p)
+}
+
 func (ctx *BuiltinEvalContext) InitProvider(n string) (ResourceProvider, error) {
 	ctx.once.Do(ctx.init)
 
@@ -168,15 +183,7 @@ func (ctx *BuiltinEvalContext) ProviderInput(n string) map[string]interface{} {
 	ctx.ProviderLock.Lock()
 	defer ctx.ProviderLock.Unlock()
 
-	// Make a copy of the path so we can safely edit it
-	path := ctx.Path()
-	pathCopy := make([]string, len(path)+1)
-	copy(pathCopy, path)
-
-	// Go up the tree.
-	for i := len(path) - 1; i >= 0; i-- {
-		pathCopy[i+1] = n
-		k := PathCacheKey(pathCopy[:i+2])
+	for _, k := range ctx.providerKeys(n) {
 		if v, ok := ctx.ProviderInputConfig[k]; ok {
 			return v
 		}
@@ -200,15 +207,7 @@ func (ctx *BuiltinEvalContext) ParentProviderConfig(n string) *ResourceConfig {
 	ctx.ProviderLock.Lock()
 	defer ctx.ProviderLock.Unlock()
 
-	// Make a copy of the path so we can safely edit it
-	path := ctx.Path()
-	pathCopy := make([]string, len(path)+1)
-	copy(pathCopy, path)
-
-	// Go up the tree.
-	for i := len(path) - 1; i >= 0; i-- {
-		pathCopy[i+1] = n
-		k := PathCacheKey(pathCopy[:i+2])
+	for _, k := range ctx.providerKeys(n) {
 		if v, ok := ctx.ProviderConfigCache[k]; ok {
 			return v
 		}
@@ -217,6 +216,27 @@ func (ctx *BuiltinEvalContext) ParentProviderConfig(n string) *ResourceConfig {
 	return nil
 }
 
+// providerKeys returns the cache keys of the provider n in this module
+// and each of its parents, up to the root module. When a module was
+// passed a provider, the key in the parent uses the name of the provider
+// it was passed.
+func (ctx *BuiltinEvalContext) providerKeys(n string) []string {
+	path := ctx.Path()
+	result := make([]string, 0, len(path))
+	for i := len(path); i > 0; i-- {
+		providerPath := make([]string, i+1)
+		copy(providerPath, path[:i])
+		providerPath[i] = n
+		result = append(result, PathCacheKey(providerPath))
+
+		if parent, ok := ctx.ProviderAliases[PathCacheKey(path[:i])][n]; ok {
+			n = parent
+		}
+	}
+
+	return result
+}
+
 func (ctx *BuiltinEvalContext) InitProvisioner(
 	n string) (ResourceProvisioner, error) {
 	ctx.once.Do(ctx.init)
@@ -37,6 +37,36 @@ func TestBuiltinEvalContextProviderInput(t *testing.T) {
 	}
 }
 
+func TestBuiltinEvalContextParentProviderConfig_aliases(t *testing.T) {
+	var lock sync.Mutex
+	cache := make(map[string]*ResourceConfig)
+	aliases := map[string]map[string]string{
+		PathCacheKey([]string{"root", "child"}): map[string]string{
+			"aws": "aws.east",
+		},
+	}
+
+	ctx1 := testBuiltinEvalContext(t)
+	ctx1.PathValue = []string{"root"}
+	ctx1.ProviderConfigCache = cache
+	ctx1.ProviderLock = &lock
+
+	ctx2 := testBuiltinEvalContext(t)
+	ctx2.PathValue = []string{"root", "child", "grandchild"}
+	ctx2.ProviderConfigCache = cache
+	ctx2.ProviderLock = &lock
+	ctx2.ProviderAliases = aliases
+
+	expected := testResourceConfig(t, map[string]interface{}{"value": "east"})
+	ctx1.SetProviderConfig("aws", testResourceConfig(
+		t, map[string]interface{}{"value": "root"}))
+	ctx1.SetProviderConfig("aws.east", expected)
+
+	if actual := ctx2.ParentProviderConfig("aws"); actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
 func testBuiltinEvalContext(t *testing.T) *BuiltinEvalContext {
 	return &BuiltinEvalContext{}
 }
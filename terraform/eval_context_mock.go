@@ -16,6 +16,11 @@ type MockEvalContext struct {
 	InputCalled bool
 	InputInput  UIInput
 
+	OutputCalled      bool
+	OutputInfo        *InstanceInfo
+	OutputProvisioner string
+	OutputOutput      UIOutput
+
 	InitProviderCalled   bool
 	InitProviderName     string
 	InitProviderProvider ResourceProvider
@@ -101,6 +106,13 @@ func (c *MockEvalContext) Input() UIInput {
 	return c.InputInput
 }
 
+func (c *MockEvalContext) Output(info *InstanceInfo, p string) UIOutput {
+	c.OutputCalled = true
+	c.OutputInfo = info
+	c.OutputProvisioner = p
+	return c.OutputOutput
+}
+
 func (c *MockEvalContext) InitProvider(n string) (ResourceProvider, error) {
 	c.InitProviderCalled = true
 	c.InitProviderName = n
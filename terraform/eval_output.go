@@ -37,8 +37,9 @@ func (n *EvalDeleteOutput) Eval(ctx EvalContext) (interface{}, error) {
 // EvalWriteOutput is an EvalNode implementation that writes the output
 // for the given name to the current state.
 type EvalWriteOutput struct {
-	Name  string
-	Value *config.RawConfig
+	Name      string
+	Sensitive bool
+	Value     *config.RawConfig
 }
 
 // TODO: test
@@ -76,16 +77,87 @@ func (n *EvalWriteOutput) Eval(ctx EvalContext) (interface{}, error) {
 		}
 	}
 
-	// If it is a list of values, get the first one
-	if list, ok := valueRaw.([]interface{}); ok {
-		valueRaw = list[0]
-	}
-	if _, ok := valueRaw.(string); !ok {
-		return nil, fmt.Errorf("output %s is not a string", n.Name)
+	output, err := outputState(valueRaw)
+	if err != nil {
+		return nil, fmt.Errorf("output %s: %s", n.Name, err)
 	}
+	output.Sensitive = n.Sensitive
 
 	// Write the output
-	mod.Outputs[n.Name] = valueRaw.(string)
+	mod.Outputs[n.Name] = output
 
 	return nil, nil
 }
+
+// outputState builds the state of an output from the raw value of the
+// interpolated config. Flattened lists become real lists, and maps are
+// merged from the list of maps that HCL decodes them into.
+func outputState(raw interface{}) (*OutputState, error) {
+	switch v := raw.(type) {
+	case string:
+		if v != config.UnknownVariableValue && config.IsStringList(v) {
+			return &OutputState{
+				Type:  "list",
+				Value: outputList(config.StringList(v).Slice()),
+			}, nil
+		}
+
+		return &OutputState{Type: "string", Value: v}, nil
+	case []interface{}:
+		var parts []string
+		for _, e := range v {
+			s, ok := outputString(e)
+			if !ok {
+				return nil, fmt.Errorf("list elements must be strings")
+			}
+
+			if config.IsStringList(s) {
+				parts = append(parts, config.StringList(s).Slice()...)
+			} else {
+				parts = append(parts, s)
+			}
+		}
+
+		return &OutputState{Type: "list", Value: outputList(parts)}, nil
+	case []map[string]interface{}:
+		m := make(map[string]interface{})
+		for _, part := range v {
+			for k, e := range part {
+				s, ok := outputString(e)
+				if !ok {
+					return nil, fmt.Errorf("map values must be strings")
+				}
+				m[k] = s
+			}
+		}
+
+		return &OutputState{Type: "map", Value: m}, nil
+	case map[string]interface{}:
+		return outputState([]map[string]interface{}{v})
+	default:
+		return nil, fmt.Errorf("value is not a string, list or map")
+	}
+}
+
+// outputString returns the string value of a list element or map value.
+// Numbers and booleans are turned into strings, like they are everywhere
+// else in the config.
+func outputString(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case int, float64, bool:
+		return fmt.Sprintf("%v", v), true
+	default:
+		return "", false
+	}
+}
+
+func outputList(parts []string) []interface{} {
+	result := make([]interface{}, len(parts))
+	for i, p := range parts {
+		result[i] = p
+	}
+
+	return result
+}
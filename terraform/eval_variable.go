@@ -3,7 +3,6 @@ package terraform
 import (
 	"fmt"
 
-	"github.com/hashicorp/errwrap"
 	"github.com/mitchellh/mapstructure"
 	"github.com/xanzy/terraform-api/config"
 )
@@ -36,16 +35,33 @@ func (n *EvalVariableBlock) Eval(ctx EvalContext) (interface{}, error) {
 		delete(n.Variables, k)
 	}
 
-	// Get our configuration
+	// Get our configuration. Lists are passed on as string lists and
+	// maps are flattened into one variable per key, which is how the
+	// values of list and map variables are represented.
 	rc := *n.Config
 	for k, v := range rc.Config {
 		var vStr string
-		if err := mapstructure.WeakDecode(v, &vStr); err != nil {
-			return nil, errwrap.Wrapf(fmt.Sprintf(
-				"%s: error reading value: {{err}}", k), err)
+		if err := mapstructure.WeakDecode(v, &vStr); err == nil {
+			n.Variables[k] = vStr
+			continue
 		}
 
-		n.Variables[k] = vStr
+		var vList []string
+		if err := mapstructure.WeakDecode(v, &vList); err == nil {
+			n.Variables[k] = config.NewStringList(vList).String()
+			continue
+		}
+
+		var vMap map[string]string
+		if err := mapstructure.WeakDecode(v, &vMap); err == nil {
+			for mk, mv := range vMap {
+				n.Variables[k+"."+mk] = mv
+			}
+			continue
+		}
+
+		return nil, fmt.Errorf("%s: error reading value: "+
+			"must be a string, list or map", k)
 	}
 	for k, _ := range rc.Raw {
 		if _, ok := n.Variables[k]; !ok {
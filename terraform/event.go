@@ -0,0 +1,211 @@
+package terraform
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// EventResourcePlanned is emitted for every resource with changes
+	// after it is diffed.
+	EventResourcePlanned EventType = "resource_planned"
+
+	// EventApplyStart, EventApplyComplete and EventApplyErrored are
+	// emitted before and after a single resource is applied.
+	EventApplyStart    EventType = "apply_start"
+	EventApplyComplete EventType = "apply_complete"
+	EventApplyErrored  EventType = "apply_errored"
+
+	// EventProvisionStart and EventProvisionComplete are emitted before
+	// and after a single provisioner is run, and EventProvisionOutput for
+	// every line of output sent back by it.
+	EventProvisionStart    EventType = "provision_start"
+	EventProvisionComplete EventType = "provision_complete"
+	EventProvisionOutput   EventType = "provision_output"
+
+	// EventRefreshStart and EventRefreshComplete are emitted before and
+	// after a single resource is refreshed.
+	EventRefreshStart    EventType = "refresh_start"
+	EventRefreshComplete EventType = "refresh_complete"
+)
+
+// Event is a single, machine-readable progress event of an operation.
+// Events are meant to be streamed to callers, so they can be encoded as
+// JSON as is.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Resource is the human-friendly ID of the resource the event is
+	// about, including the module path, and ResourceType its type.
+	Resource     string `json:"resource"`
+	ResourceType string `json:"resource_type"`
+
+	// Action is the change planned or being applied: create, update,
+	// replace or destroy.
+	Action EventAction `json:"action,omitempty"`
+
+	// ID is the ID of the resource, once it is known.
+	ID string `json:"id,omitempty"`
+
+	// Provisioner and Output are set for provisioner events.
+	Provisioner string `json:"provisioner,omitempty"`
+	Output      string `json:"output,omitempty"`
+
+	// Error is the error that occurred, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// EventAction is the change that's made to a resource.
+type EventAction string
+
+const (
+	EventActionCreate  EventAction = "create"
+	EventActionUpdate  EventAction = "update"
+	EventActionReplace EventAction = "replace"
+	EventActionDestroy EventAction = "destroy"
+)
+
+// EventHandler handles the events emitted by a Context. See
+// ContextOpts.Events.
+type EventHandler interface {
+	HandleEvent(*Event)
+}
+
+// EventHandlerFunc is a function that implements EventHandler.
+type EventHandlerFunc func(*Event)
+
+// HandleEvent implements EventHandler.
+func (f EventHandlerFunc) HandleEvent(e *Event) {
+	f(e)
+}
+
+// EventHook is a Hook that turns the hook calls into Events and sends
+// them to the Handler.
+//
+// Hooks are called concurrently while walking the graph, but the Handler
+// is only called for one event at a time, in the order they occurred.
+type EventHook struct {
+	NilHook
+
+	Handler EventHandler
+
+	l sync.Mutex
+}
+
+func (h *EventHook) PostDiff(n *InstanceInfo, d *InstanceDiff) (HookAction, error) {
+	if d == nil || d.Empty() {
+		return HookActionContinue, nil
+	}
+
+	e := h.event(EventResourcePlanned, n)
+	e.Action = eventAction(d)
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) PreApply(
+	n *InstanceInfo, s *InstanceState, d *InstanceDiff) (HookAction, error) {
+	e := h.event(EventApplyStart, n)
+	e.Action = eventAction(d)
+	if s != nil {
+		e.ID = s.ID
+	}
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) PostApply(
+	n *InstanceInfo, s *InstanceState, applyErr error) (HookAction, error) {
+	e := h.event(EventApplyComplete, n)
+	if applyErr != nil {
+		e.Type = EventApplyErrored
+		e.Error = applyErr.Error()
+	}
+	if s != nil {
+		e.ID = s.ID
+	}
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) PreProvision(n *InstanceInfo, p string) (HookAction, error) {
+	e := h.event(EventProvisionStart, n)
+	e.Provisioner = p
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) PostProvision(n *InstanceInfo, p string) (HookAction, error) {
+	e := h.event(EventProvisionComplete, n)
+	e.Provisioner = p
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) ProvisionOutput(n *InstanceInfo, p string, output string) {
+	e := h.event(EventProvisionOutput, n)
+	e.Provisioner = p
+	e.Output = output
+	h.emit(e)
+}
+
+func (h *EventHook) PreRefresh(n *InstanceInfo, s *InstanceState) (HookAction, error) {
+	e := h.event(EventRefreshStart, n)
+	if s != nil {
+		e.ID = s.ID
+	}
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) PostRefresh(n *InstanceInfo, s *InstanceState) (HookAction, error) {
+	e := h.event(EventRefreshComplete, n)
+	if s != nil {
+		e.ID = s.ID
+	}
+	h.emit(e)
+	return HookActionContinue, nil
+}
+
+func (h *EventHook) event(t EventType, n *InstanceInfo) *Event {
+	return &Event{
+		Type:         t,
+		Time:         time.Now().UTC(),
+		Resource:     n.HumanId(),
+		ResourceType: n.Type,
+	}
+}
+
+func (h *EventHook) emit(e *Event) {
+	if h.Handler == nil {
+		return
+	}
+
+	h.l.Lock()
+	defer h.l.Unlock()
+	h.Handler.HandleEvent(e)
+}
+
+// eventAction returns the EventAction for the given diff.
+func eventAction(d *InstanceDiff) EventAction {
+	if d == nil {
+		return ""
+	}
+
+	switch d.ChangeType() {
+	case DiffCreate:
+		return EventActionCreate
+	case DiffUpdate:
+		return EventActionUpdate
+	case DiffDestroyCreate:
+		return EventActionReplace
+	case DiffDestroy:
+		return EventActionDestroy
+	default:
+		return ""
+	}
+}
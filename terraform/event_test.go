@@ -0,0 +1,163 @@
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestEventHook_impl(t *testing.T) {
+	var _ Hook = new(EventHook)
+}
+
+func TestEventHook(t *testing.T) {
+	var events []*Event
+	h := &EventHook{Handler: EventHandlerFunc(func(e *Event) {
+		events = append(events, e)
+	})}
+
+	n := &InstanceInfo{
+		Id:         "aws_instance.foo",
+		ModulePath: []string{"root", "child"},
+		Type:       "aws_instance",
+	}
+	create := &InstanceDiff{Attributes: map[string]*ResourceAttrDiff{
+		"ami": &ResourceAttrDiff{New: "ami-123", RequiresNew: true},
+	}}
+
+	h.PostDiff(n, &InstanceDiff{})
+	h.PostDiff(n, create)
+	h.PreApply(n, &InstanceState{}, create)
+	h.PostApply(n, &InstanceState{ID: "i-abc"}, nil)
+	h.ProvisionOutput(n, "local-exec", "hello")
+	h.PostApply(n, &InstanceState{}, errors.New("boom"))
+
+	expected := []*Event{
+		&Event{
+			Type:   EventResourcePlanned,
+			Action: EventActionCreate,
+		},
+		&Event{
+			Type:   EventApplyStart,
+			Action: EventActionCreate,
+		},
+		&Event{
+			Type: EventApplyComplete,
+			ID:   "i-abc",
+		},
+		&Event{
+			Type:        EventProvisionOutput,
+			Provisioner: "local-exec",
+			Output:      "hello",
+		},
+		&Event{
+			Type:  EventApplyErrored,
+			Error: "boom",
+		},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("bad: %#v", events)
+	}
+	for i, e := range events {
+		if e.Time.IsZero() {
+			t.Fatalf("%d: no time: %#v", i, e)
+		}
+		if e.Resource != "module.child.aws_instance.foo" || e.ResourceType != "aws_instance" {
+			t.Fatalf("%d: bad: %#v", i, e)
+		}
+
+		e.Time = expected[i].Time
+		e.Resource = ""
+		e.ResourceType = ""
+		if !reflect.DeepEqual(e, expected[i]) {
+			t.Fatalf("%d: bad: %#v", i, e)
+		}
+	}
+}
+
+func TestEventHook_noHandler(t *testing.T) {
+	h := new(EventHook)
+	h.PostApply(&InstanceInfo{Id: "aws_instance.foo"}, nil, nil)
+}
+
+func TestEvent_json(t *testing.T) {
+	e := &Event{
+		Type:         EventApplyStart,
+		Resource:     "aws_instance.foo",
+		ResourceType: "aws_instance",
+		Action:       EventActionReplace,
+	}
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(raw, &actual); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"type":          "apply_start",
+		"time":          "0001-01-01T00:00:00Z",
+		"resource":      "aws_instance.foo",
+		"resource_type": "aws_instance",
+		"action":        "replace",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestContext2Apply_events(t *testing.T) {
+	m := testModule(t, "apply-good")
+	p := testProvider("aws")
+	p.ApplyFn = testApplyFn
+	p.DiffFn = testDiffFn
+
+	var events []*Event
+	ctx := testContext2(t, &ContextOpts{
+		Module: m,
+		Providers: map[string]ResourceProviderFactory{
+			"aws": testProviderFuncFixed(p),
+		},
+		Events: EventHandlerFunc(func(e *Event) {
+			events = append(events, e)
+		}),
+	})
+
+	if _, err := ctx.Plan(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	var planned []string
+	for _, e := range events {
+		if e.Type != EventResourcePlanned || e.Action != EventActionCreate {
+			t.Fatalf("bad: %#v", e)
+		}
+		planned = append(planned, e.Resource)
+	}
+	sort.Strings(planned)
+	if !reflect.DeepEqual(planned, []string{"aws_instance.bar", "aws_instance.foo"}) {
+		t.Fatalf("bad: %#v", planned)
+	}
+
+	events = nil
+	if _, err := ctx.Apply(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	counts := make(map[EventType]int)
+	for _, e := range events {
+		counts[e.Type]++
+	}
+	if counts[EventApplyStart] != 2 || counts[EventApplyComplete] != 2 {
+		t.Fatalf("bad: %#v", counts)
+	}
+	if counts[EventApplyErrored] != 0 {
+		t.Fatalf("bad: %#v", counts)
+	}
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"log"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/xanzy/terraform-api/config/module"
 )
 
@@ -39,6 +40,13 @@ func (b *BasicGraphBuilder) Build(path []string) (*Graph, error) {
 	if b.Validate {
 		if err := g.Validate(); err != nil {
 			log.Printf("[ERROR] Graph validation failed. Graph:\n\n%s", g.String())
+
+			// Cycles are hard to read on their own, so explain the ones
+			// we know how to fix.
+			if cbdErr := createBeforeDestroyCycleError(g); cbdErr != nil {
+				err = multierror.Append(err, cbdErr)
+			}
+
 			return nil, err
 		}
 	}
@@ -134,6 +134,45 @@ func TestBuiltinGraphBuilder_cbdDepNonCbd_errorsWhenVerbose(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected err, got none")
 	}
+
+	// The error should point at the dependency missing the flag
+	for _, s := range []string{"aws_asg.foo -> aws_lc.foo", "to aws_lc.foo."} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected %q in err: %s", s, err)
+		}
+	}
+}
+
+func TestBuiltinGraphBuilder_cbdChain(t *testing.T) {
+	b := &BuiltinGraphBuilder{
+		Root:     testModule(t, "graph-builder-cbd-chain"),
+		Validate: true,
+		Verbose:  true,
+	}
+
+	_, err := b.Build(RootModulePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestBuiltinGraphBuilder_moduleDependsOn(t *testing.T) {
+	b := &BuiltinGraphBuilder{
+		Root:      testModule(t, "graph-builder-module-depends-on"),
+		Providers: []string{"aws"},
+		Validate:  true,
+	}
+
+	g, err := b.Build(RootModulePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(testBuiltinGraphBuilderModuleDependsOnStr)
+	if actual != expected {
+		t.Fatalf("bad: %s", actual)
+	}
 }
 
 func TestBuiltinGraphBuilder_multiLevelModule(t *testing.T) {
@@ -276,6 +315,36 @@ root
   module.foo.plan-destroy
 `
 
+const testBuiltinGraphBuilderModuleDependsOnStr = `
+aws_elb.lb
+  module.app.aws_instance.web
+  module.app.plan-destroy
+module.app.aws_instance.web
+  module.app.provider.aws
+module.app.plan-destroy
+  module.vpc.aws_vpc.foo
+  module.vpc.plan-destroy
+module.app.provider.aws
+  module.vpc.aws_vpc.foo
+  module.vpc.plan-destroy
+module.vpc.aws_vpc.foo
+  module.vpc.provider.aws
+module.vpc.plan-destroy
+module.vpc.provider.aws
+  provider.aws
+provider.aws
+provider.aws (close)
+  aws_elb.lb
+provider.module.app.aws (close)
+  module.app.aws_instance.web
+provider.module.vpc.aws (close)
+  module.vpc.aws_vpc.foo
+root
+  provider.aws (close)
+  provider.module.app.aws (close)
+  provider.module.vpc.aws (close)
+`
+
 const testBuiltinGraphBuilderOrphanDepsStr = `
 aws_instance.bar (orphan)
   provider.aws
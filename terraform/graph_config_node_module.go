@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/xanzy/terraform-api/config"
@@ -35,7 +36,9 @@ func (n *GraphNodeConfigModule) DependableName() []string {
 
 func (n *GraphNodeConfigModule) DependentOn() []string {
 	vars := n.Module.RawConfig.Variables
-	result := make([]string, 0, len(vars))
+	result := make([]string, len(n.Module.DependsOn),
+		len(vars)+len(n.Module.DependsOn))
+	copy(result, n.Module.DependsOn)
 	for _, v := range vars {
 		if vn := varNameForVar(v); vn != "" {
 			result = append(result, vn)
@@ -84,13 +87,24 @@ func (n *GraphNodeConfigModule) ProvidedBy() []string {
 	// Build up the list of providers by simply going over our configuration
 	// to find the providers that are configured there as well as the
 	// providers that the resources use.
+	//
+	// Providers passed to the module are provided by the provider they
+	// were passed from instead.
 	config := n.Tree.Config()
 	providers := make(map[string]struct{})
 	for _, p := range config.ProviderConfigs {
-		providers[p.Name] = struct{}{}
+		name := p.Name
+		if parent, ok := n.Module.Providers[p.FullName()]; ok {
+			name = parent
+		}
+		providers[name] = struct{}{}
 	}
 	for _, r := range config.Resources {
-		providers[resourceProvider(r.Type, r.Provider)] = struct{}{}
+		name := resourceProvider(r.Type, r.Provider)
+		if parent, ok := n.Module.Providers[name]; ok {
+			name = parent
+		}
+		providers[name] = struct{}{}
 	}
 
 	// Turn the map into a string. This makes sure that the list is
@@ -134,6 +148,28 @@ func (n *graphNodeModuleExpanded) DependentOn() []string {
 	return n.Original.DependentOn()
 }
 
+// graphNodeFlatDependable impl.
+func (n *graphNodeModuleExpanded) FlatDependableName() string {
+	return n.Original.Name()
+}
+
+// graphNodeFlatDependable impl.
+//
+// The flattened module also depends on the providers passed to it, so
+// they are configured before the providers of the module inherit their
+// configuration.
+func (n *graphNodeModuleExpanded) FlatDependentOn() []string {
+	providers := make([]string, 0, len(n.Original.Module.Providers))
+	for _, p := range n.Original.Module.Providers {
+		providers = append(providers, "provider."+p)
+	}
+	sort.Strings(providers)
+
+	result := make([]string, 0, len(n.Original.Module.DependsOn)+len(providers))
+	result = append(result, n.Original.Module.DependsOn...)
+	return append(result, providers...)
+}
+
 // GraphNodeDotter impl.
 func (n *graphNodeModuleExpanded) DotNode(name string, opts *GraphDotOpts) *dot.Node {
 	return dot.NewNode(name, map[string]string{
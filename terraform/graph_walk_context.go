@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/errwrap"
+	"github.com/xanzy/terraform-api/config/module"
 	"github.com/xanzy/terraform-api/dag"
 )
 
@@ -31,9 +32,16 @@ type ContextGraphWalker struct {
 	interpolaterVarLock sync.Mutex
 	providerCache       map[string]ResourceProvider
 	providerConfigCache map[string]*ResourceConfig
+	providerAliases     map[string]map[string]string
 	providerLock        sync.Mutex
 	provisionerCache    map[string]ResourceProvisioner
 	provisionerLock     sync.Mutex
+
+	// The hooks and state of the Context when the walk started. A walk
+	// that was abandoned by a forced stop keeps using these, even after
+	// the Context has moved on.
+	hooks []Hook
+	state *State
 }
 
 func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
@@ -66,24 +74,26 @@ func (w *ContextGraphWalker) EnterPath(path []string) EvalContext {
 
 	ctx := &BuiltinEvalContext{
 		PathValue:           path,
-		Hooks:               w.Context.hooks,
+		Hooks:               w.hooks,
 		InputValue:          w.Context.uiInput,
+		OutputValue:         w.Context.uiOutput,
 		Providers:           w.Context.providers,
 		ProviderCache:       w.providerCache,
 		ProviderConfigCache: w.providerConfigCache,
 		ProviderInputConfig: w.Context.providerInputConfig,
 		ProviderLock:        &w.providerLock,
+		ProviderAliases:     w.providerAliases,
 		Provisioners:        w.Context.provisioners,
 		ProvisionerCache:    w.provisionerCache,
 		ProvisionerLock:     &w.provisionerLock,
 		DiffValue:           w.Context.diff,
 		DiffLock:            &w.Context.diffLock,
-		StateValue:          w.Context.state,
+		StateValue:          w.state,
 		StateLock:           &w.Context.stateLock,
 		Interpolater: &Interpolater{
 			Operation: w.Operation,
 			Module:    w.Context.module,
-			State:     w.Context.state,
+			State:     w.state,
 			StateLock: &w.Context.stateLock,
 			Variables: variables,
 		},
@@ -148,4 +158,50 @@ func (w *ContextGraphWalker) init() {
 	w.providerConfigCache = make(map[string]*ResourceConfig, 5)
 	w.provisionerCache = make(map[string]ResourceProvisioner, 5)
 	w.interpolaterVars = make(map[string]map[string]string, 5)
+
+	w.providerAliases = make(map[string]map[string]string)
+	if w.Context.module != nil {
+		moduleProviderAliases(w.Context.module, RootModulePath, w.providerAliases)
+	}
+}
+
+// moduleProviderAliases collects the providers passed to the modules of
+// the tree t at path, keyed by the path of the module.
+func moduleProviderAliases(
+	t *module.Tree, path []string, result map[string]map[string]string) {
+	children := t.Children()
+	for _, m := range t.Config().Modules {
+		childPath := make([]string, len(path)+1)
+		copy(childPath, path)
+		childPath[len(path)] = m.Name
+
+		if len(m.Providers) > 0 {
+			result[PathCacheKey(childPath)] = m.Providers
+		}
+
+		if child, ok := children[m.Name]; ok {
+			moduleProviderAliases(child, childPath, result)
+		}
+	}
+}
+
+// stopProviders asks every provider that was started during this walk
+// and implements ResourceProviderStopper to stop its running operations.
+func (w *ContextGraphWalker) stopProviders() {
+	w.once.Do(w.init)
+
+	w.providerLock.Lock()
+	defer w.providerLock.Unlock()
+
+	for k, p := range w.providerCache {
+		s, ok := p.(ResourceProviderStopper)
+		if !ok {
+			continue
+		}
+
+		log.Printf("[INFO] Stopping provider: %s", k)
+		if err := s.Stop(); err != nil {
+			log.Printf("[ERROR] Error stopping provider %s: %s", k, err)
+		}
+	}
 }
@@ -147,11 +147,26 @@ func (i *Interpolater) valueModuleVar(
 		value = config.UnknownVariableValue
 	} else {
 		// Get the value from the outputs
-		var ok bool
-		value, ok = mod.Outputs[v.Field]
+		output, ok := mod.Outputs[v.Field]
 		if !ok {
 			// Same reasons as the comment above.
-			value = config.UnknownVariableValue
+			output = &OutputState{Value: config.UnknownVariableValue}
+		}
+
+		switch ov := output.Value.(type) {
+		case string:
+			value = ov
+		case []interface{}:
+			// Lists are passed on flattened, like any other list
+			parts := make([]string, len(ov))
+			for i, e := range ov {
+				parts[i] = fmt.Sprintf("%v", e)
+			}
+			value = config.NewStringList(parts).String()
+		default:
+			return fmt.Errorf(
+				"%s: module output %s is a %s, which can't be interpolated",
+				n, v.Field, output.Type)
 		}
 	}
 
@@ -182,7 +182,7 @@ func (c *ResourceConfig) get(
 	}
 
 	var current interface{} = raw
-	for _, part := range parts {
+	for i, part := range parts {
 		if current == nil {
 			return nil, false
 		}
@@ -192,6 +192,15 @@ func (c *ResourceConfig) get(
 		case reflect.Map:
 			v := cv.MapIndex(reflect.ValueOf(part))
 			if !v.IsValid() {
+				// Map keys may contain dots themselves, so try the rest of
+				// the key as a whole before giving up.
+				if i > 0 && i < len(parts)-1 {
+					v = cv.MapIndex(reflect.ValueOf(strings.Join(parts[i:], ".")))
+					if v.IsValid() {
+						return v.Interface(), true
+					}
+				}
+
 				return nil, false
 			}
 			current = v.Interface()
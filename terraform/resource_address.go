@@ -75,6 +75,32 @@ func (addr *ResourceAddress) Equals(raw interface{}) bool {
 		typeMatch
 }
 
+// String returns the address in the syntax parsed by ParseResourceAddress.
+func (addr *ResourceAddress) String() string {
+	var parts []string
+	for _, p := range addr.Path {
+		parts = append(parts, "module", p)
+	}
+
+	if addr.Type != "" {
+		parts = append(parts, addr.Type, addr.Name)
+	}
+
+	switch addr.InstanceType {
+	case TypeTainted:
+		parts = append(parts, "tainted")
+	case TypeDeposed:
+		parts = append(parts, "deposed")
+	}
+
+	result := strings.Join(parts, ".")
+	if addr.Index >= 0 {
+		result += fmt.Sprintf("[%d]", addr.Index)
+	}
+
+	return result
+}
+
 func ParseResourceIndex(s string) (int, error) {
 	if s == "" {
 		return -1, nil
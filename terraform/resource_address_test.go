@@ -118,6 +118,29 @@ func TestParseResourceAddress(t *testing.T) {
 	}
 }
 
+func TestResourceAddressString(t *testing.T) {
+	cases := []string{
+		"aws_instance.foo",
+		"aws_instance.foo[2]",
+		"aws_instance.foo.tainted",
+		"aws_instance.foo.deposed[1]",
+		"module.child.aws_instance.foo",
+		"module.a.module.b.aws_instance.foo[0]",
+		"module.a",
+	}
+
+	for _, tc := range cases {
+		addr, err := ParseResourceAddress(tc)
+		if err != nil {
+			t.Fatalf("unexpected err: %#v", err)
+		}
+
+		if actual := addr.String(); actual != tc {
+			t.Fatalf("bad: %q\n\ngot: %q", tc, actual)
+		}
+	}
+}
+
 func TestResourceAddressEquals(t *testing.T) {
 	cases := map[string]struct {
 		Address *ResourceAddress
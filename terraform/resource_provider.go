@@ -77,6 +77,15 @@ type ResourceProviderCloser interface {
 	Close() error
 }
 
+// ResourceProviderStopper is an interface that providers that can abort
+// their in-flight operations must implement. Stop is called when the
+// running operation is asked to stop, and may be called concurrently with
+// any other call. The operations that are aborted should return as soon
+// as possible with an error.
+type ResourceProviderStopper interface {
+	Stop() error
+}
+
 // ResourceType is a type of resource that a resource provider can manage.
 type ResourceType struct {
 	Name string
@@ -44,6 +44,9 @@ type MockResourceProvider struct {
 	RefreshReturnError           error
 	ResourcesCalled              bool
 	ResourcesReturn              []ResourceType
+	StopCalled                   bool
+	StopFn                       func() error
+	StopReturnError              error
 	ValidateCalled               bool
 	ValidateConfig               *ResourceConfig
 	ValidateFn                   func(*ResourceConfig) ([]string, []error)
@@ -62,6 +65,18 @@ func (p *MockResourceProvider) Close() error {
 	return p.CloseError
 }
 
+func (p *MockResourceProvider) Stop() error {
+	p.Lock()
+	p.StopCalled = true
+	p.Unlock()
+
+	if p.StopFn != nil {
+		return p.StopFn()
+	}
+
+	return p.StopReturnError
+}
+
 func (p *MockResourceProvider) Input(
 	input UIInput, c *ResourceConfig) (*ResourceConfig, error) {
 	p.InputCalled = true
@@ -88,6 +88,39 @@ func TestResourceConfigGet(t *testing.T) {
 			Key:   "foo.5",
 			Value: nil,
 		},
+
+		{
+			Config: map[string]interface{}{
+				"foo": map[string]interface{}{
+					"bar.baz": "qux",
+				},
+			},
+			Key:   "foo.bar.baz",
+			Value: "qux",
+		},
+
+		{
+			Config: map[string]interface{}{
+				"foo": map[string]interface{}{
+					"bar.baz": "qux",
+				},
+			},
+			Key:   "foo.bar",
+			Value: nil,
+		},
+
+		{
+			Config: map[string]interface{}{
+				"foo": map[string]interface{}{
+					"bar": map[string]interface{}{
+						"baz": "nested",
+					},
+					"bar.baz": "dotted",
+				},
+			},
+			Key:   "foo.bar.baz",
+			Value: "nested",
+		},
 	}
 
 	for i, tc := range cases {
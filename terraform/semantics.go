@@ -2,6 +2,7 @@ package terraform
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/xanzy/terraform-api/config"
@@ -86,6 +87,11 @@ func smcUserVariables(c *config.Config, vs map[string]string) []error {
 	}
 	for k, _ := range vs {
 		delete(required, k)
+
+		// Setting a single key of a map also counts as setting the map.
+		if idx := strings.Index(k, "."); idx > 0 {
+			delete(required, k[:idx])
+		}
 	}
 	if len(required) > 0 {
 		for k, _ := range required {
@@ -94,17 +100,40 @@ func smcUserVariables(c *config.Config, vs map[string]string) []error {
 		}
 	}
 
-	// Check that types match up
-	for k, _ := range vs {
+	// Check that types match up. Lists are passed around as string
+	// lists, and maps can only be set one key at a time.
+	for k, val := range vs {
 		v, ok := cvs[k]
 		if !ok {
+			if idx := strings.Index(k, "."); idx > 0 {
+				v, ok := cvs[k[:idx]]
+				if ok && v.Type() != config.VariableTypeMap {
+					errs = append(errs, fmt.Errorf(
+						"%s: cannot set a key on %s type",
+						k, v.Type().Printable()))
+				}
+			}
+
 			continue
 		}
 
-		if v.Type() != config.VariableTypeString {
+		switch v.Type() {
+		case config.VariableTypeString:
+			if config.IsStringList(val) {
+				errs = append(errs, fmt.Errorf(
+					"%s: cannot assign list value to string type",
+					k))
+			}
+		case config.VariableTypeList:
+			if !config.IsStringList(val) {
+				errs = append(errs, fmt.Errorf(
+					"%s: cannot assign string value to list type",
+					k))
+			}
+		default:
 			errs = append(errs, fmt.Errorf(
-				"%s: cannot assign string value to map type",
-				k))
+				"%s: cannot assign string value to %s type",
+				k, v.Type().Printable()))
 		}
 	}
 
@@ -2,6 +2,8 @@ package terraform
 
 import (
 	"testing"
+
+	"github.com/xanzy/terraform-api/config"
 )
 
 func TestSMCUserVariables(t *testing.T) {
@@ -37,4 +39,30 @@ func TestSMCUserVariables(t *testing.T) {
 		t.Fatal("should have errors")
 	}
 
+	// List assignment
+	errs = smcUserVariables(c, map[string]string{
+		"foo":  "bar",
+		"list": config.NewStringList([]string{"a", "b"}).String(),
+	})
+	if len(errs) != 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	// String value for a list
+	errs = smcUserVariables(c, map[string]string{
+		"foo":  "bar",
+		"list": "a,b",
+	})
+	if len(errs) == 0 {
+		t.Fatal("should have errors")
+	}
+
+	// Map key on a string
+	errs = smcUserVariables(c, map[string]string{
+		"foo":     "bar",
+		"bar.baz": "qux",
+	})
+	if len(errs) == 0 {
+		t.Fatal("should have errors")
+	}
 }
@@ -6,17 +6,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/xanzy/terraform-api/config"
+	"github.com/xanzy/terraform-api/state/upgrade"
 )
 
 const (
 	// StateVersion is the current version for our state file
-	StateVersion = 1
+	StateVersion = upgrade.CurrentVersion
+)
+
+const (
+	// SensitiveValue is displayed in place of the value of any attribute
+	// that is marked as sensitive.
+	SensitiveValue = "<sensitive>"
+
+	// SensitiveAttributesMetaKey is the InstanceState Meta key used to
+	// store a comma separated list of attribute keys whose values are
+	// sensitive and should be redacted when displayed.
+	SensitiveAttributesMetaKey = "sensitive_attributes"
 )
 
 // rootModulePath is the path of the root module
@@ -391,7 +404,7 @@ type ModuleState struct {
 	// Outputs declared by the module and maintained for each module
 	// even though only the root module technically needs to be kept.
 	// This allows operators to inspect values at the boundaries.
-	Outputs map[string]string `json:"outputs"`
+	Outputs map[string]*OutputState `json:"outputs"`
 
 	// Resources is a mapping of the logically named resource to
 	// the state of the resource. Each resource may actually have
@@ -426,7 +439,7 @@ func (m *ModuleState) Equal(other *ModuleState) bool {
 		return false
 	}
 	for k, v := range m.Outputs {
-		if other.Outputs[k] != v {
+		if !v.Equal(other.Outputs[k]) {
 			return false
 		}
 	}
@@ -516,7 +529,7 @@ func (m *ModuleState) View(id string) *ModuleState {
 
 func (m *ModuleState) init() {
 	if m.Outputs == nil {
-		m.Outputs = make(map[string]string)
+		m.Outputs = make(map[string]*OutputState)
 	}
 	if m.Resources == nil {
 		m.Resources = make(map[string]*ResourceState)
@@ -529,16 +542,20 @@ func (m *ModuleState) deepcopy() *ModuleState {
 	}
 	n := &ModuleState{
 		Path:      make([]string, len(m.Path)),
-		Outputs:   make(map[string]string, len(m.Outputs)),
+		Outputs:   make(map[string]*OutputState, len(m.Outputs)),
 		Resources: make(map[string]*ResourceState, len(m.Resources)),
 	}
 	copy(n.Path, m.Path)
 	for k, v := range m.Outputs {
-		n.Outputs[k] = v
+		n.Outputs[k] = v.deepcopy()
 	}
 	for k, v := range m.Resources {
 		n.Resources[k] = v.deepcopy()
 	}
+	if m.Dependencies != nil {
+		n.Dependencies = make([]string, len(m.Dependencies))
+		copy(n.Dependencies, m.Dependencies)
+	}
 	return n
 }
 
@@ -553,7 +570,7 @@ func (m *ModuleState) prune() {
 	}
 
 	for k, v := range m.Outputs {
-		if v == config.UnknownVariableValue {
+		if v.Value == config.UnknownVariableValue {
 			delete(m.Outputs, k)
 		}
 	}
@@ -622,8 +639,16 @@ func (m *ModuleState) String() string {
 		}
 		sort.Strings(attrKeys)
 
+		var sensitive map[string]struct{}
+		if rs.Primary != nil {
+			sensitive = rs.Primary.sensitiveAttributes()
+		}
+
 		for _, ak := range attrKeys {
 			av := attributes[ak]
+			if _, ok := sensitive[ak]; ok {
+				av = SensitiveValue
+			}
 			buf.WriteString(fmt.Sprintf("  %s = %s\n", ak, av))
 		}
 
@@ -661,6 +686,85 @@ func (m *ModuleState) String() string {
 	return buf.String()
 }
 
+// OutputState is the state of a single output of a module. The value of
+// an output is a string, a list of strings or a map of strings.
+type OutputState struct {
+	// Sensitive is true if the output was marked as sensitive in the
+	// configuration. The value is kept in the state, but is redacted
+	// whenever the output is rendered for humans.
+	Sensitive bool `json:"sensitive"`
+
+	// Type is the type of the value: "string", "list" or "map".
+	Type string `json:"type"`
+
+	// Value is the value of the output. Depending on the type it is a
+	// string, a []interface{} of strings or a map[string]interface{} of
+	// strings.
+	Value interface{} `json:"value"`
+}
+
+// Equal tests whether one output state is equal to another.
+func (o *OutputState) Equal(other *OutputState) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+
+	return reflect.DeepEqual(o, other)
+}
+
+func (o *OutputState) deepcopy() *OutputState {
+	if o == nil {
+		return nil
+	}
+
+	n := &OutputState{
+		Sensitive: o.Sensitive,
+		Type:      o.Type,
+		Value:     o.Value,
+	}
+	switch v := o.Value.(type) {
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		copy(l, v)
+		n.Value = l
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = e
+		}
+		n.Value = m
+	}
+
+	return n
+}
+
+// String renders the value of the output. Sensitive outputs are not
+// redacted here, that is up to the caller.
+func (o *OutputState) String() string {
+	switch v := o.Value.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprintf("%v", e)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
+	case map[string]interface{}:
+		ks := make([]string, 0, len(v))
+		for k, _ := range v {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+
+		parts := make([]string, len(ks))
+		for i, k := range ks {
+			parts[i] = fmt.Sprintf("%s = %v", k, v[k])
+		}
+		return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // ResourceState holds the state of a resource that is used so that
 // a provider can find and manage an existing resource as well as for
 // storing attributes that are used to populate variables of child
@@ -898,7 +1002,8 @@ type InstanceState struct {
 
 	// Meta is a simple K/V map that is persisted to the State but otherwise
 	// ignored by Terraform core. It's meant to be used for accounting by
-	// external client code.
+	// external client code. The only exception is SensitiveAttributesMetaKey,
+	// which is used to redact sensitive values when displaying the state.
 	Meta map[string]string `json:"meta,omitempty"`
 }
 
@@ -912,6 +1017,12 @@ func (i *InstanceState) init() {
 	i.Ephemeral.init()
 }
 
+// DeepCopy returns a copy of the instance state that can be modified
+// without affecting the original.
+func (i *InstanceState) DeepCopy() *InstanceState {
+	return i.deepcopy()
+}
+
 func (i *InstanceState) deepcopy() *InstanceState {
 	if i == nil {
 		return nil
@@ -1022,7 +1133,17 @@ func (s *InstanceState) MergeDiff(d *InstanceDiff) *InstanceState {
 }
 
 func (i *InstanceState) GoString() string {
-	return fmt.Sprintf("*%#v", *i)
+	r := *i
+	if sensitive := i.sensitiveAttributes(); len(sensitive) > 0 {
+		r.Attributes = make(map[string]string, len(i.Attributes))
+		for k, v := range i.Attributes {
+			if _, ok := sensitive[k]; ok {
+				v = SensitiveValue
+			}
+			r.Attributes[k] = v
+		}
+	}
+	return fmt.Sprintf("*%#v", r)
 }
 
 func (i *InstanceState) String() string {
@@ -1045,14 +1166,40 @@ func (i *InstanceState) String() string {
 	}
 	sort.Strings(attrKeys)
 
+	sensitive := i.sensitiveAttributes()
 	for _, ak := range attrKeys {
 		av := attributes[ak]
+		if _, ok := sensitive[ak]; ok {
+			av = SensitiveValue
+		}
 		buf.WriteString(fmt.Sprintf("%s = %s\n", ak, av))
 	}
 
 	return buf.String()
 }
 
+// IsSensitive returns true if the attribute with the given key is marked
+// as sensitive and its value should not be displayed.
+func (i *InstanceState) IsSensitive(k string) bool {
+	_, ok := i.sensitiveAttributes()[k]
+	return ok
+}
+
+// sensitiveAttributes returns the set of attribute keys that are
+// marked as sensitive in the metadata of this instance.
+func (i *InstanceState) sensitiveAttributes() map[string]struct{} {
+	if i == nil || i.Meta[SensitiveAttributesMetaKey] == "" {
+		return nil
+	}
+
+	keys := strings.Split(i.Meta[SensitiveAttributesMetaKey], ",")
+	result := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		result[k] = struct{}{}
+	}
+	return result
+}
+
 // EphemeralState is used for transient state that is only kept in-memory
 type EphemeralState struct {
 	// ConnInfo is used for the providers to export information which is
@@ -1100,18 +1247,21 @@ func ReadState(src io.Reader) (*State, error) {
 		return upgradeV1State(old)
 	}
 
-	// Otherwise, must be V2
-	dec := json.NewDecoder(buf)
-	state := &State{}
-	if err := dec.Decode(state); err != nil {
-		return nil, fmt.Errorf("Decoding state file failed: %v", err)
+	// Otherwise, must be JSON. Upgrade it from whatever version it was
+	// written in, this also ensures we don't read a future version that
+	// we don't understand.
+	raw, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return nil, fmt.Errorf("Reading state file failed: %v", err)
+	}
+	raw, err = upgrade.UpgradeJSON(raw)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check the version, this to ensure we don't read a future
-	// version that we don't understand
-	if state.Version > StateVersion {
-		return nil, fmt.Errorf("State version %d not supported, please update.",
-			state.Version)
+	state := &State{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, fmt.Errorf("Decoding state file failed: %v", err)
 	}
 
 	// Sort it
@@ -1155,8 +1305,13 @@ func upgradeV1State(old *StateV1) (*State, error) {
 	// directly into the root module.
 	root := s.RootModule()
 
-	// Copy the outputs
-	root.Outputs = old.Outputs
+	// Copy the outputs, which were always strings
+	for k, v := range old.Outputs {
+		root.Outputs[k] = &OutputState{
+			Type:  "string",
+			Value: v,
+		}
+	}
 
 	// Upgrade the resources
 	for id, rs := range old.Resources {
@@ -0,0 +1,187 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StateFilterResult is a single resource instance in the state that
+// matched a filter.
+type StateFilterResult struct {
+	// Address is the address of the instance. Its Index is -1 if the
+	// resource doesn't have a count.
+	Address *ResourceAddress
+
+	// Module is the module the resource is in, Resource the resource and
+	// Instance the primary, tainted or deposed instance that matched,
+	// depending on the InstanceType of the Address.
+	Module   *ModuleState
+	Resource *ResourceState
+	Instance *InstanceState
+}
+
+func (r *StateFilterResult) String() string {
+	return r.Address.String()
+}
+
+// Filter returns the resource instances in the state that match any of
+// the given addresses, in the order of the modules in the state and the
+// resource keys within them.
+//
+// The addresses use the same syntax as targets, such as "aws_instance.foo",
+// "aws_instance.foo[1]", "aws_instance.foo.tainted" or "module.child"
+// for all the resources of a module. Without addresses, all the primary
+// instances in the state are returned.
+func (s *State) Filter(fs ...string) ([]*StateFilterResult, error) {
+	addrs := make([]*ResourceAddress, 0, len(fs))
+	for _, f := range fs {
+		addr, err := ParseResourceAddress(f)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+
+	return s.FilterAddresses(addrs...), nil
+}
+
+// FilterAddresses is like Filter, but takes parsed addresses. The empty
+// Type, Name and an Index of -1 match anything, so addresses can also
+// filter on only the type or name of resources, which can't be expressed
+// with the address syntax. Like with targets, an empty Path is the root
+// module, unless the address doesn't name a resource at all.
+func (s *State) FilterAddresses(addrs ...*ResourceAddress) []*StateFilterResult {
+	if s == nil {
+		return nil
+	}
+
+	if len(addrs) == 0 {
+		addrs = []*ResourceAddress{&ResourceAddress{
+			Index:        -1,
+			InstanceType: TypePrimary,
+		}}
+	}
+
+	var result []*StateFilterResult
+	for _, mod := range s.Modules {
+		keys := make([]string, 0, len(mod.Resources))
+		for k := range mod.Resources {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			rs := mod.Resources[k]
+			for _, r := range stateFilterInstances(mod, k, rs) {
+				for _, addr := range addrs {
+					if stateFilterMatch(addr, r.Address) {
+						result = append(result, r)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// ResourceByAddress returns the resource with the given address, such as
+// "aws_instance.foo" or "module.child.aws_instance.foo[1]". An error is
+// returned if the address doesn't match exactly one resource.
+func (s *State) ResourceByAddress(addr string) (*ResourceState, error) {
+	results, err := s.Filter(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ResourceState
+	for _, r := range results {
+		if result != nil && result != r.Resource {
+			return nil, fmt.Errorf("Multiple resources match %s", addr)
+		}
+		result = r.Resource
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("Not found: %s", addr)
+	}
+
+	return result, nil
+}
+
+// stateFilterMatch returns true if the address of an instance in the
+// state matches the filter. Unlike ResourceAddress.Equals, a filter
+// without a module path matches resources in all modules when it doesn't
+// name a resource either.
+func stateFilterMatch(filter, addr *ResourceAddress) bool {
+	if len(filter.Path) == 0 && filter.Type == "" && filter.Name == "" {
+		f := *filter
+		f.Path = addr.Path
+		filter = &f
+	}
+
+	return filter.Equals(addr)
+}
+
+// stateFilterInstances returns a result for every instance of the
+// resource with the given key in the module.
+func stateFilterInstances(
+	mod *ModuleState, k string, rs *ResourceState) []*StateFilterResult {
+	typ, name, index := parseResourceStateKey(k)
+
+	var path []string
+	if len(mod.Path) > 1 {
+		path = mod.Path[1:]
+	}
+
+	result := make([]*StateFilterResult, 0, 1+len(rs.Tainted)+len(rs.Deposed))
+	add := func(t InstanceType, is *InstanceState) {
+		result = append(result, &StateFilterResult{
+			Address: &ResourceAddress{
+				Path:         path,
+				Index:        index,
+				InstanceType: t,
+				Name:         name,
+				Type:         typ,
+			},
+			Module:   mod,
+			Resource: rs,
+			Instance: is,
+		})
+	}
+
+	if rs.Primary != nil {
+		add(TypePrimary, rs.Primary)
+	}
+	for _, is := range rs.Tainted {
+		add(TypeTainted, is)
+	}
+	for _, is := range rs.Deposed {
+		add(TypeDeposed, is)
+	}
+
+	return result
+}
+
+// parseResourceStateKey parses a key of ModuleState.Resources, such as
+// "aws_instance.foo" or "aws_instance.foo.1", into the type, name and
+// index of the resource. The index is -1 if the key has none.
+func parseResourceStateKey(k string) (string, string, int) {
+	parts := strings.Split(k, ".")
+	if len(parts) < 2 {
+		return k, "", -1
+	}
+
+	index := -1
+	if len(parts) > 2 {
+		if i, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+			index = i
+			parts = parts[:len(parts)-1]
+		}
+	}
+
+	return parts[0], strings.Join(parts[1:], "."), index
+}
@@ -0,0 +1,169 @@
+package terraform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testStateFilter() *State {
+	return &State{
+		Modules: []*ModuleState{
+			&ModuleState{
+				Path: rootModulePath,
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo.0": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "foo0"},
+					},
+					"aws_instance.foo.1": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "foo1"},
+						Tainted: []*InstanceState{
+							&InstanceState{ID: "foo1-tainted"},
+						},
+					},
+					"aws_vpc.main": &ResourceState{
+						Type:    "aws_vpc",
+						Primary: &InstanceState{ID: "vpc"},
+					},
+				},
+			},
+			&ModuleState{
+				Path: []string{"root", "child"},
+				Resources: map[string]*ResourceState{
+					"aws_instance.foo": &ResourceState{
+						Type:    "aws_instance",
+						Primary: &InstanceState{ID: "child"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestStateFilter(t *testing.T) {
+	cases := map[string]struct {
+		Filters  []string
+		Expected []string
+	}{
+		"all": {
+			nil,
+			[]string{
+				"aws_instance.foo[0]",
+				"aws_instance.foo[1]",
+				"aws_vpc.main",
+				"module.child.aws_instance.foo",
+			},
+		},
+		"resource": {
+			[]string{"aws_instance.foo"},
+			[]string{"aws_instance.foo[0]", "aws_instance.foo[1]"},
+		},
+		"index": {
+			[]string{"aws_instance.foo[1]"},
+			[]string{"aws_instance.foo[1]"},
+		},
+		"tainted": {
+			[]string{"aws_instance.foo.tainted"},
+			[]string{"aws_instance.foo.tainted[1]"},
+		},
+		"module": {
+			[]string{"module.child"},
+			[]string{"module.child.aws_instance.foo"},
+		},
+		"multiple": {
+			[]string{"aws_vpc.main", "module.child.aws_instance.foo"},
+			[]string{"aws_vpc.main", "module.child.aws_instance.foo"},
+		},
+		"none": {
+			[]string{"aws_instance.bar"},
+			nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		results, err := testStateFilter().Filter(tc.Filters...)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tn, err)
+		}
+
+		var actual []string
+		for _, r := range results {
+			actual = append(actual, r.String())
+		}
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Fatalf("%s: bad: %#v", tn, actual)
+		}
+	}
+}
+
+func TestStateFilter_badAddress(t *testing.T) {
+	if _, err := testStateFilter().Filter("aws_instance"); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestStateFilterAddresses(t *testing.T) {
+	results := testStateFilter().FilterAddresses(&ResourceAddress{
+		Type:         "aws_instance",
+		InstanceType: TypePrimary,
+		Index:        -1,
+	})
+
+	var actual []string
+	for _, r := range results {
+		actual = append(actual, r.Instance.ID)
+	}
+
+	expected := []string{"foo0", "foo1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestStateResourceByAddress(t *testing.T) {
+	s := testStateFilter()
+
+	rs, err := s.ResourceByAddress("module.child.aws_instance.foo")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if rs.Primary.ID != "child" {
+		t.Fatalf("bad: %#v", rs)
+	}
+
+	rs, err = s.ResourceByAddress("aws_instance.foo[1]")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if rs.Primary.ID != "foo1" {
+		t.Fatalf("bad: %#v", rs)
+	}
+
+	if _, err := s.ResourceByAddress("aws_instance.foo"); err == nil {
+		t.Fatal("should error with multiple matches")
+	}
+
+	if _, err := s.ResourceByAddress("aws_instance.bar"); err == nil {
+		t.Fatal("should error if not found")
+	}
+}
+
+func TestParseResourceStateKey(t *testing.T) {
+	cases := map[string]struct {
+		Type  string
+		Name  string
+		Index int
+	}{
+		"aws_instance.foo":    {"aws_instance", "foo", -1},
+		"aws_instance.foo.12": {"aws_instance", "foo", 12},
+		"aws_instance.foo-1":  {"aws_instance", "foo-1", -1},
+	}
+
+	for k, tc := range cases {
+		typ, name, index := parseResourceStateKey(k)
+		if typ != tc.Type || name != tc.Name || index != tc.Index {
+			t.Fatalf("%s: bad: %s %s %d", k, typ, name, index)
+		}
+	}
+}
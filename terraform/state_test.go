@@ -505,6 +505,39 @@ func TestInstanceStateEmpty(t *testing.T) {
 	}
 }
 
+func TestInstanceStateString_sensitive(t *testing.T) {
+	is := &InstanceState{
+		ID: "foo",
+		Attributes: map[string]string{
+			"id":       "foo",
+			"name":     "bar",
+			"password": "secret",
+		},
+		Meta: map[string]string{
+			SensitiveAttributesMetaKey: "password",
+		},
+	}
+
+	actual := strings.TrimSpace(is.String())
+	expected := "ID = foo\nname = bar\npassword = <sensitive>"
+	if actual != expected {
+		t.Fatalf("bad:\n%s", actual)
+	}
+
+	if !is.IsSensitive("password") || is.IsSensitive("name") {
+		t.Fatalf("bad: %#v", is.Meta)
+	}
+
+	if strings.Contains(is.GoString(), "secret") {
+		t.Fatalf("GoString should be redacted: %s", is.GoString())
+	}
+
+	// The raw value must still be available in the state itself
+	if is.Attributes["password"] != "secret" {
+		t.Fatalf("bad: %#v", is.Attributes)
+	}
+}
+
 func TestInstanceStateEqual(t *testing.T) {
 	cases := []struct {
 		Result   bool
@@ -860,7 +893,7 @@ func TestUpgradeV1State(t *testing.T) {
 	if len(root.Outputs) != 1 {
 		t.Fatalf("bad outputs: %v", root.Outputs)
 	}
-	if root.Outputs["ip"] != "127.0.0.1" {
+	if root.Outputs["ip"].Value != "127.0.0.1" {
 		t.Fatalf("bad outputs: %v", root.Outputs)
 	}
 
@@ -0,0 +1,175 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ChangeSummary summarizes the changes to the resources in a plan, or
+// the changes made by an apply, so callers can tell whether anything
+// changes without parsing the diff or the UI output.
+type ChangeSummary struct {
+	// Resources is the change to every changed resource, keyed by the
+	// human-friendly ID of the resource including the module path.
+	Resources map[string]EventAction
+}
+
+// Empty returns true if there are no changes.
+func (s *ChangeSummary) Empty() bool {
+	return s == nil || len(s.Resources) == 0
+}
+
+// Count returns the number of resources that are added, changed and
+// destroyed. Replaced resources count as both added and destroyed.
+func (s *ChangeSummary) Count() (add, change, destroy int) {
+	if s == nil {
+		return
+	}
+
+	for _, a := range s.Resources {
+		switch a {
+		case EventActionCreate:
+			add++
+		case EventActionUpdate:
+			change++
+		case EventActionReplace:
+			add++
+			destroy++
+		case EventActionDestroy:
+			destroy++
+		}
+	}
+
+	return
+}
+
+func (s *ChangeSummary) String() string {
+	add, change, destroy := s.Count()
+	return fmt.Sprintf(
+		"%d to add, %d to change, %d to destroy", add, change, destroy)
+}
+
+// record adds the change to the resource with the given ID. A resource
+// that is both created and destroyed, which happens when it is replaced
+// with create_before_destroy, is recorded as replaced.
+func (s *ChangeSummary) record(id string, a EventAction) {
+	if a == "" {
+		return
+	}
+
+	if s.Resources == nil {
+		s.Resources = make(map[string]EventAction)
+	}
+
+	if old, ok := s.Resources[id]; ok && old != a {
+		a = EventActionReplace
+	}
+	s.Resources[id] = a
+}
+
+// Summary returns the summary of the changes in the plan.
+func (p *Plan) Summary() *ChangeSummary {
+	result := new(ChangeSummary)
+	if p.Diff == nil {
+		return result
+	}
+
+	for _, md := range p.Diff.Modules {
+		var ms *ModuleState
+		if p.State != nil {
+			ms = p.State.ModuleByPath(md.Path)
+		}
+
+		for k, d := range md.Resources {
+			var s *InstanceState
+			if ms != nil {
+				if rs, ok := ms.Resources[k]; ok {
+					s = rs.Primary
+				}
+			}
+
+			result.record(summaryId(md.Path, k), summaryAction(s, d))
+		}
+	}
+
+	return result
+}
+
+// summaryId returns the human-friendly ID of the resource with the given
+// ID in the module with the given path, like InstanceInfo.HumanId.
+func summaryId(path []string, id string) string {
+	if len(path) <= 1 {
+		return id
+	}
+
+	return fmt.Sprintf("module.%s.%s", strings.Join(path[1:], "."), id)
+}
+
+// summaryAction returns the change the diff makes to the resource with
+// the given state. Unlike the change type of the diff alone, resources
+// that don't exist yet are always created.
+func summaryAction(s *InstanceState, d *InstanceDiff) EventAction {
+	a := eventAction(d)
+	if a == EventActionUpdate && (s == nil || s.ID == "") {
+		a = EventActionCreate
+	}
+
+	return a
+}
+
+// summaryHook is a private Hook implementation that builds the summary
+// of the changes made by an apply.
+type summaryHook struct {
+	NilHook
+
+	l       sync.Mutex
+	pending map[string]EventAction
+	summary ChangeSummary
+}
+
+func (h *summaryHook) PreApply(
+	n *InstanceInfo, s *InstanceState, d *InstanceDiff) (HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]EventAction)
+	}
+	h.pending[n.HumanId()] = summaryAction(s, d)
+
+	return HookActionContinue, nil
+}
+
+func (h *summaryHook) PostApply(
+	n *InstanceInfo, s *InstanceState, err error) (HookAction, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	id := n.HumanId()
+	a, ok := h.pending[id]
+	if !ok {
+		return HookActionContinue, nil
+	}
+	delete(h.pending, id)
+
+	// Only changes that were made are part of the summary
+	if err == nil {
+		h.summary.record(id, a)
+	}
+
+	return HookActionContinue, nil
+}
+
+// result returns a copy of the summary recorded so far.
+func (h *summaryHook) result() *ChangeSummary {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	result := new(ChangeSummary)
+	for k, v := range h.summary.Resources {
+		result.record(k, v)
+	}
+
+	return result
+}
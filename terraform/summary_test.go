@@ -0,0 +1,119 @@
+package terraform
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSummaryHook_impl(t *testing.T) {
+	var _ Hook = new(summaryHook)
+}
+
+func TestChangeSummary(t *testing.T) {
+	s := new(ChangeSummary)
+	if !s.Empty() {
+		t.Fatal("should be empty")
+	}
+
+	s.record("aws_instance.foo", EventActionCreate)
+	s.record("aws_instance.bar", EventActionUpdate)
+	s.record("aws_instance.baz", EventActionCreate)
+	s.record("aws_instance.baz", EventActionDestroy)
+	s.record("aws_instance.qux", "")
+	if s.Empty() {
+		t.Fatal("should not be empty")
+	}
+
+	expected := map[string]EventAction{
+		"aws_instance.foo": EventActionCreate,
+		"aws_instance.bar": EventActionUpdate,
+		"aws_instance.baz": EventActionReplace,
+	}
+	if !reflect.DeepEqual(s.Resources, expected) {
+		t.Fatalf("bad: %#v", s.Resources)
+	}
+
+	if actual := s.String(); actual != "2 to add, 1 to change, 1 to destroy" {
+		t.Fatalf("bad: %s", actual)
+	}
+}
+
+func TestPlanSummary(t *testing.T) {
+	p := &Plan{
+		Diff: &Diff{
+			Modules: []*ModuleDiff{
+				&ModuleDiff{
+					Path: rootModulePath,
+					Resources: map[string]*InstanceDiff{
+						"aws_instance.new": &InstanceDiff{
+							Attributes: map[string]*ResourceAttrDiff{
+								"ami": &ResourceAttrDiff{New: "ami-123"},
+							},
+						},
+						"aws_instance.old": &InstanceDiff{
+							Attributes: map[string]*ResourceAttrDiff{
+								"ami": &ResourceAttrDiff{Old: "ami-1", New: "ami-2"},
+							},
+						},
+						"aws_instance.same": &InstanceDiff{},
+					},
+				},
+				&ModuleDiff{
+					Path: []string{"root", "child"},
+					Resources: map[string]*InstanceDiff{
+						"aws_instance.foo": &InstanceDiff{Destroy: true},
+					},
+				},
+			},
+		},
+		State: &State{
+			Modules: []*ModuleState{
+				&ModuleState{
+					Path: rootModulePath,
+					Resources: map[string]*ResourceState{
+						"aws_instance.old": &ResourceState{
+							Type:    "aws_instance",
+							Primary: &InstanceState{ID: "i-abc"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := map[string]EventAction{
+		"aws_instance.new":              EventActionCreate,
+		"aws_instance.old":              EventActionUpdate,
+		"module.child.aws_instance.foo": EventActionDestroy,
+	}
+	if actual := p.Summary().Resources; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	if s := (&Plan{Diff: new(Diff)}).Summary(); !s.Empty() {
+		t.Fatalf("bad: %#v", s)
+	}
+}
+
+func TestSummaryHook(t *testing.T) {
+	h := new(summaryHook)
+
+	foo := &InstanceInfo{Id: "aws_instance.foo", ModulePath: rootModulePath}
+	bar := &InstanceInfo{Id: "aws_instance.bar", ModulePath: rootModulePath}
+	create := &InstanceDiff{Attributes: map[string]*ResourceAttrDiff{
+		"ami": &ResourceAttrDiff{New: "ami-123"},
+	}}
+
+	h.PreApply(foo, &InstanceState{}, create)
+	h.PostApply(foo, &InstanceState{ID: "i-abc"}, nil)
+	h.PreApply(bar, &InstanceState{}, create)
+	h.PostApply(bar, &InstanceState{}, errors.New("boom"))
+
+	expected := map[string]EventAction{
+		"aws_instance.foo": EventActionCreate,
+	}
+	if actual := h.result().Resources; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
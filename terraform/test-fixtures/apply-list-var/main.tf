@@ -0,0 +1,9 @@
+variable "zones" {
+    type = "list"
+    default = ["us-east-1a", "us-east-1b"]
+}
+
+resource "aws_instance" "foo" {
+    count = "${length(var.zones)}"
+    foo = "${element(var.zones, count.index)}"
+}
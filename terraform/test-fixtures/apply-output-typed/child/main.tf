@@ -0,0 +1,8 @@
+resource "aws_instance" "bar" {
+    foo = "bar${count.index}"
+    count = 2
+}
+
+output "ids" {
+    value = ["${aws_instance.bar.*.foo}"]
+}
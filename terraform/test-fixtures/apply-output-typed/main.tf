@@ -0,0 +1,22 @@
+module "child" {
+    source = "./child"
+}
+
+resource "aws_instance" "foo" {
+    foo = "${join("-", module.child.ids)}"
+}
+
+output "ids" {
+    value = "${module.child.ids}"
+}
+
+output "password" {
+    value = "secret"
+    sensitive = true
+}
+
+output "tags" {
+    value = {
+        name = "web"
+    }
+}
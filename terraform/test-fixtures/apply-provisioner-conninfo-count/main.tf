@@ -0,0 +1,13 @@
+resource "aws_instance" "foo" {
+    count = 2
+    foo = "10.0.0.${count.index}"
+
+    connection {
+        host = "${self.foo}"
+        port = "${2200 + count.index}"
+    }
+
+    provisioner "shell" {
+        command = "echo ${count.index}"
+    }
+}
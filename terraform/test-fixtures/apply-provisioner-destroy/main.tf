@@ -0,0 +1,8 @@
+resource "aws_instance" "foo" {
+    foo = "bar"
+
+    provisioner "shell" {
+        command = "destroy ${self.foo}"
+        when = "destroy"
+    }
+}
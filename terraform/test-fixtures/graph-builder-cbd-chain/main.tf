@@ -0,0 +1,17 @@
+provider "aws" {}
+
+resource "aws_lc" "foo" {
+    lifecycle { create_before_destroy = true }
+}
+
+resource "aws_asg" "foo" {
+    lc = "${aws_lc.foo.id}"
+
+    lifecycle { create_before_destroy = true }
+}
+
+resource "aws_elb" "foo" {
+    asg = "${aws_asg.foo.id}"
+
+    lifecycle { create_before_destroy = true }
+}
@@ -0,0 +1,12 @@
+module "vpc" {
+    source = "./vpc"
+}
+
+module "app" {
+    source = "./app"
+    depends_on = ["module.vpc"]
+}
+
+resource "aws_elb" "lb" {
+    depends_on = ["module.app"]
+}
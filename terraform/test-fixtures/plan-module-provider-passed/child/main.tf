@@ -0,0 +1,9 @@
+provider "aws" {
+    alias = "other"
+}
+
+resource "aws_instance" "foo" {}
+
+resource "aws_instance" "bar" {
+    provider = "aws.other"
+}
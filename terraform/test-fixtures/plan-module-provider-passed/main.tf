@@ -0,0 +1,22 @@
+provider "aws" {
+    from = "root"
+}
+
+provider "aws" {
+    alias = "east"
+    from = "east"
+}
+
+provider "aws" {
+    alias = "west"
+    from = "west"
+}
+
+module "child" {
+    source = "./child"
+
+    providers {
+        aws = "aws.east"
+        "aws.other" = "aws.west"
+    }
+}
@@ -13,3 +13,9 @@ variable "map" {
         foo = "bar"
     }
 }
+
+# List
+variable "list" {
+    type = "list"
+    default = ["foo"]
+}
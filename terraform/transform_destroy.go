@@ -1,6 +1,11 @@
 package terraform
 
-import "github.com/xanzy/terraform-api/dag"
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/xanzy/terraform-api/dag"
+)
 
 type GraphNodeDestroyMode byte
 
@@ -261,3 +266,57 @@ func (t *PruneDestroyTransformer) Transform(g *Graph) error {
 
 	return nil
 }
+
+// createBeforeDestroyCycleError inspects the cycles in the graph for the
+// most common create_before_destroy mistake: a resource with the flag set
+// depends on a resource without it. Since the new dependent must be created
+// before the old one is destroyed, the dependency can't be replaced (destroyed
+// first) in between, which inverts an edge and closes the cycle.
+//
+// The returned error names the offending edges and which resource needs
+// the flag. It is nil if none of the cycles are caused by this.
+func createBeforeDestroyCycleError(g *Graph) error {
+	var result error
+	seen := make(map[string]struct{})
+	for _, cycle := range g.Cycles() {
+		// Index the destroy nodes in this cycle by their create node so
+		// we can find the destroy side of a dependency.
+		destroys := make(map[dag.Vertex][]GraphNodeDestroy)
+		for _, v := range cycle {
+			if dn, ok := v.(GraphNodeDestroy); ok {
+				cn := dn.CreateNode()
+				destroys[cn] = append(destroys[cn], dn)
+			}
+		}
+
+		for cn, dns := range destroys {
+			if !dns[0].CreateBeforeDestroy() {
+				continue
+			}
+
+			for _, raw := range g.DownEdges(cn).List() {
+				dep := raw.(dag.Vertex)
+				depDestroys, ok := destroys[dep]
+				if !ok || depDestroys[0].CreateBeforeDestroy() {
+					continue
+				}
+
+				from, to := dag.VertexName(cn), dag.VertexName(dep)
+				key := from + "\x00" + to
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+
+				result = multierror.Append(result, fmt.Errorf(
+					"%s has create_before_destroy set and depends on %s (edge %s -> %s), "+
+						"which doesn't. The dependencies of a create_before_destroy "+
+						"resource must also create before destroy: add "+
+						"\"lifecycle { create_before_destroy = true }\" to %s.",
+					from, to, from, to, to))
+			}
+		}
+	}
+
+	return result
+}
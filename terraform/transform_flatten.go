@@ -22,12 +22,35 @@ type GraphNodeFlattenable interface {
 	Flatten(path []string) (dag.Vertex, error)
 }
 
+// graphNodeFlatDependable can be implemented by nodes that can be
+// flattened and depended on as a whole, such as modules. Since the node
+// itself is removed when flattened, anything depending on
+// FlatDependableName will depend on every vertex of the flattened subgraph
+// instead, and every vertex of the subgraph will depend on FlatDependentOn.
+type graphNodeFlatDependable interface {
+	FlatDependableName() string
+	FlatDependentOn() []string
+}
+
 // FlattenTransformer is a transformer that goes through the graph, finds
 // subgraphs that can be flattened, and flattens them into this graph,
 // removing the prior subgraph node.
 type FlattenTransformer struct{}
 
 func (t *FlattenTransformer) Transform(g *Graph) error {
+	// Keep track of the graphNodeFlatDependables we flattened, so the
+	// dependencies between them are kept regardless of the order in which
+	// they are flattened.
+	flatVertices := make(map[string][]dag.Vertex)
+	flatDeps := make(map[dag.Vertex][]string)
+	connectFlat := func(v dag.Vertex, names []string) {
+		for _, n := range names {
+			for _, target := range flatVertices[n] {
+				g.Connect(dag.BasicEdge(v, target))
+			}
+		}
+	}
+
 	for _, v := range g.Vertices() {
 		fn, ok := v.(GraphNodeFlatGraph)
 		if !ok {
@@ -91,15 +114,28 @@ func (t *FlattenTransformer) Transform(g *Graph) error {
 
 		// Connect the dependencies for all the new nodes that we added.
 		// This will properly connect variables to their sources, for example.
+		fd, flatDependable := v.(graphNodeFlatDependable)
 		for _, sv := range subgraph.Vertices() {
 			g.ConnectDependent(sv)
+			if flatDependable {
+				deps := fd.FlatDependentOn()
+				connectFlat(sv, g.ConnectTo(sv, deps))
+				flatDeps[sv] = deps
+			}
+		}
+		if flatDependable {
+			flatVertices[fd.FlatDependableName()] = subgraph.Vertices()
 		}
 
 		// Re-connect all the things that dependent on the graph
 		// we just flattened. This should connect them back into the
 		// correct nodes if their DependentOn() is setup correctly.
 		for _, v := range dependents {
-			g.ConnectDependent(v)
+			missing := g.ConnectDependent(v)
+			if flatDependable {
+				// If the whole subgraph was depended on, depend on all of it.
+				connectFlat(v, append(missing, flatDeps[v]...))
+			}
 		}
 	}
 
@@ -200,17 +200,7 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 	var resourceConfig *ResourceConfig
 	var state *InstanceState
 
-	// Build the resource. If we aren't part of a multi-resource, then
-	// we still consider ourselves as count index zero.
-	index := n.Index
-	if index < 0 {
-		index = 0
-	}
-	resource := &Resource{
-		Name:       n.Resource.Name,
-		Type:       n.Resource.Type,
-		CountIndex: index,
-	}
+	resource := n.interpResource()
 
 	seq := &EvalSequence{Nodes: make([]EvalNode, 0, 5)}
 
@@ -476,6 +466,10 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 					Dependencies: n.StateDependencies(),
 					State:        &state,
 				},
+
+				// Let the hooks persist the resource before the
+				// provisioners run, since those can take a long time.
+				&EvalUpdateStateHook{},
 				&EvalApplyProvisioners{
 					Info:           info,
 					State:          &state,
@@ -484,6 +478,7 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 					CreateNew:      &createNew,
 					Tainted:        &tainted,
 					Error:          &err,
+					When:           config.ProvisionerWhenCreate,
 				},
 				&EvalIf{
 					If: func(ctx EvalContext) (bool, error) {
@@ -552,6 +547,22 @@ func (n *graphNodeExpandedResource) EvalTree() EvalNode {
 	return seq
 }
 
+// interpResource builds the resource used for interpolation. If we aren't
+// part of a multi-resource, then we still consider ourselves as count
+// index zero.
+func (n *graphNodeExpandedResource) interpResource() *Resource {
+	index := n.Index
+	if index < 0 {
+		index = 0
+	}
+
+	return &Resource{
+		Name:       n.Resource.Name,
+		Type:       n.Resource.Type,
+		CountIndex: index,
+	}
+}
+
 // instanceInfo is used for EvalTree.
 func (n *graphNodeExpandedResource) instanceInfo() *InstanceInfo {
 	return &InstanceInfo{Id: n.stateId(), Type: n.Resource.Type}
@@ -634,6 +645,17 @@ func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
 				&EvalRequireState{
 					State: &state,
 				},
+
+				// Run the destroy-time provisioners. If any of them fail,
+				// the resource is not destroyed.
+				&EvalApplyProvisioners{
+					Info:           info,
+					State:          &state,
+					Resource:       n.Resource,
+					InterpResource: n.interpResource(),
+					When:           config.ProvisionerWhenDestroy,
+				},
+
 				&EvalApply{
 					Info:     info,
 					State:    &state,
@@ -654,6 +676,7 @@ func (n *graphNodeExpandedResourceDestroy) EvalTree() EvalNode {
 					State: &state,
 					Error: &err,
 				},
+				&EvalUpdateStateHook{},
 			},
 		},
 	}
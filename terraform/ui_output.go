@@ -5,3 +5,35 @@ package terraform
 type UIOutput interface {
 	Output(string)
 }
+
+// UIOutputStream is the stream that output was written to.
+type UIOutputStream byte
+
+const (
+	UIOutputStdout UIOutputStream = iota
+	UIOutputStderr
+)
+
+// UIStreamOutput is implemented by a UIOutput that can receive the
+// output written to stdout and stderr separately.
+type UIStreamOutput interface {
+	UIOutput
+
+	OutputStream(UIOutputStream, string)
+}
+
+// UIOutputFunc returns the UIOutput that the output of the provisioner
+// of the given type is sent to while provisioning the given instance. It
+// may return nil to not capture the output.
+type UIOutputFunc func(info *InstanceInfo, provisioner string) UIOutput
+
+// OutputStream sends the output written to the given stream to o. If o
+// can't tell the streams apart, it is sent as regular output.
+func OutputStream(o UIOutput, s UIOutputStream, v string) {
+	if so, ok := o.(UIStreamOutput); ok {
+		so.OutputStream(s, v)
+		return
+	}
+
+	o.Output(v)
+}
@@ -1,9 +1,19 @@
 package terraform
 
 type CallbackUIOutput struct {
-	OutputFn func(string)
+	OutputFn       func(string)
+	OutputStreamFn func(UIOutputStream, string)
 }
 
 func (o *CallbackUIOutput) Output(v string) {
 	o.OutputFn(v)
 }
+
+func (o *CallbackUIOutput) OutputStream(s UIOutputStream, v string) {
+	if o.OutputStreamFn == nil {
+		o.OutputFn(v)
+		return
+	}
+
+	o.OutputStreamFn(s, v)
+}
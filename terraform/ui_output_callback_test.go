@@ -7,3 +7,36 @@ import (
 func TestCallbackUIOutput_impl(t *testing.T) {
 	var _ UIOutput = new(CallbackUIOutput)
 }
+
+func TestCallbackUIOutput_stream(t *testing.T) {
+	var _ UIStreamOutput = new(CallbackUIOutput)
+
+	var output []string
+	o := &CallbackUIOutput{OutputFn: func(v string) {
+		output = append(output, v)
+	}}
+
+	// Without a stream function, the output is sent as regular output
+	OutputStream(o, UIOutputStderr, "foo")
+	if len(output) != 1 || output[0] != "foo" {
+		t.Fatalf("bad: %#v", output)
+	}
+
+	var stream UIOutputStream
+	o.OutputStreamFn = func(s UIOutputStream, v string) {
+		stream = s
+		output = append(output, "stream:"+v)
+	}
+	OutputStream(o, UIOutputStderr, "bar")
+	if stream != UIOutputStderr || output[1] != "stream:bar" {
+		t.Fatalf("bad: %d %#v", stream, output)
+	}
+}
+
+func TestOutputStream_plain(t *testing.T) {
+	o := new(MockUIOutput)
+	OutputStream(o, UIOutputStdout, "foo")
+	if !o.OutputCalled || o.OutputMessage != "foo" {
+		t.Fatalf("bad: %#v", o)
+	}
+}